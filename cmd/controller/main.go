@@ -18,10 +18,24 @@ package main
 
 import (
 	"flag"
+	"log"
+	"net/http"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/health"
+	"github.com/tektoncd/pipeline/pkg/logging"
+	"github.com/tektoncd/pipeline/pkg/reconciler/githubchecks"
+	"github.com/tektoncd/pipeline/pkg/reconciler/notification"
+	"github.com/tektoncd/pipeline/pkg/reconciler/orphans"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelineconfig"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/scheduler"
+	"github.com/tektoncd/pipeline/pkg/reconciler/resolutionrequest"
+	"github.com/tektoncd/pipeline/pkg/reconciler/storagemigration"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolvers"
+	"knative.dev/pkg/injection"
 	"knative.dev/pkg/injection/sharedmain"
 )
 
@@ -43,12 +57,40 @@ var (
 	shellImage  = flag.String("shell-image", "busybox", "The container image containing a shell")
 	gsutilImage = flag.String("gsutil-image", "google/cloud-sdk",
 		"The container image containing gsutil")
+	awsCliImage = flag.String("aws-cli-image", "amazon/aws-cli:2.0.6",
+		"The container image containing the AWS CLI, used to store artifacts in S3 buckets.")
 	buildGCSFetcherImage = flag.String("build-gcs-fetcher-image", "gcr.io/cloud-builders/gcs-fetcher:latest",
 		"The container image containing our GCS fetcher binary.")
 	prImage = flag.String("pr-image", "override-with-pr:latest",
 		"The container image containing our PR binary.")
 	imageDigestExporterImage = flag.String("imagedigest-exporter-image", "override-with-imagedigest-exporter-image:latest",
 		"The container image containing our image digest exporter binary.")
+	dockerDaemonImage = flag.String("docker-daemon-image", "docker:18.09-dind",
+		"The container image run as the Docker daemon sidecar for Tasks with the docker capability.")
+	buildkitDaemonImage = flag.String("buildkit-daemon-image", "moby/buildkit:v0.6.3",
+		"The container image run as the buildkitd sidecar for Tasks with the buildkit capability.")
+	curlImage = flag.String("curl-image", "curlimages/curl:7.68.0",
+		"The container image containing curl, used to download and upload http resources.")
+	enableGitHubChecks = flag.Bool("enable-github-checks", false,
+		"Report the PipelineTasks of annotated PipelineRuns as GitHub check runs.")
+	podPolicyURL = flag.String("pod-policy-url", "",
+		"The URL of the endpoint reviewing the pods of the TaskRuns before they are created, empty to create them without review.")
+	podPolicyTimeout = flag.Duration("pod-policy-timeout", 10*time.Second,
+		"How long the pod policy endpoint has to review a pod.")
+	podPolicyIgnoreFailures = flag.Bool("pod-policy-ignore-failures", false,
+		"Create the pods that the pod policy endpoint couldn't review, rather than retrying until it does.")
+	debugAddress = flag.String("debug-address", "localhost:8009",
+		"The address serving the log level overrides of the controllers, empty to disable it.")
+	healthAddress = flag.String("health-address", ":8080",
+		"The address serving the liveness and readiness of the controller, empty to disable it.")
+	maxRunningTaskRuns = flag.Int("max-running-taskruns", 0,
+		"How many TaskRuns of PipelineRuns may run at once across all namespaces, 0 for no limit.")
+	namespaceWeights = flag.String("namespace-weights", "",
+		"The shares of the free TaskRun slots of the namespaces waiting for one, e.g. \"team-a=3,team-b=2\". Namespaces have weight 1 by default.")
+	orphanSweepInterval = flag.Duration("orphan-sweep-interval", 0,
+		"How often to delete the pods and PVCs of deleted runs, 0 to never delete them.")
+	orphanSweepDryRun = flag.Bool("orphan-sweep-dry-run", false,
+		"Only log and count the pods and PVCs of deleted runs, without deleting them.")
 )
 
 func main() {
@@ -61,12 +103,69 @@ func main() {
 		KubeconfigWriterImage:    *kubeconfigWriterImage,
 		ShellImage:               *shellImage,
 		GsutilImage:              *gsutilImage,
+		AwsCliImage:              *awsCliImage,
 		BuildGCSFetcherImage:     *buildGCSFetcherImage,
 		PRImage:                  *prImage,
 		ImageDigestExporterImage: *imageDigestExporterImage,
+		DockerDaemonImage:        *dockerDaemonImage,
+		BuildkitDaemonImage:      *buildkitDaemonImage,
+		CurlImage:                *curlImage,
+	}
+	weights, err := scheduler.ParseWeights(*namespaceWeights)
+	if err != nil {
+		log.Fatalf("Invalid -namespace-weights: %v", err)
+	}
+	ctors := []injection.ControllerConstructor{
+		taskrun.NewController(images, taskrun.PodPolicy{
+			URL:            *podPolicyURL,
+			Timeout:        *podPolicyTimeout,
+			IgnoreFailures: *podPolicyIgnoreFailures,
+		}),
+		pipelinerun.NewController(images, pipelinerun.SchedulingPolicy{
+			MaxRunningTaskRuns: *maxRunningTaskRuns,
+			NamespaceWeights:   weights,
+		}),
+		notification.NewController(),
+		storagemigration.NewController(),
+		pipelineconfig.NewController(),
+		resolutionrequest.NewController(&resolvers.Git{}, &resolvers.HTTP{}, &resolvers.Hub{}),
+	}
+	if *enableGitHubChecks {
+		ctors = append(ctors, githubchecks.NewController())
+	}
+	if *orphanSweepInterval > 0 {
+		ctors = append(ctors, orphans.NewController(orphans.SweepPolicy{
+			Interval: *orphanSweepInterval,
+			DryRun:   *orphanSweepDryRun,
+		}))
+	}
+	if *debugAddress != "" {
+		go serveDebug(*debugAddress)
+	}
+	if *healthAddress != "" {
+		go serveHealth(*healthAddress)
+	}
+	sharedmain.Main(ControllerLogKey, ctors...)
+}
+
+// serveDebug serves the log level overrides of the controllers at address.
+// It listens on localhost by default, so that it is only reachable with
+// kubectl port-forward.
+func serveDebug(address string) {
+	mux := http.NewServeMux()
+	mux.Handle(logging.LevelPath, logging.DefaultLevels)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		log.Printf("Debug server stopped: %v", err)
+	}
+}
+
+// serveHealth serves the liveness and readiness of the controller at address:
+// it is ready once the caches of the informers of all the controllers synced,
+// and while its ConfigMaps parse.
+func serveHealth(address string) {
+	mux := http.NewServeMux()
+	health.DefaultChecks.Handle(mux)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		log.Printf("Health server stopped: %v", err)
 	}
-	sharedmain.Main(ControllerLogKey,
-		taskrun.NewController(images),
-		pipelinerun.NewController(images),
-	)
 }
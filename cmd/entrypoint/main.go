@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"flag"
 	"log"
 	"os"
@@ -25,7 +26,9 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	"github.com/tektoncd/pipeline/pkg/termination"
 )
 
 var (
@@ -33,28 +36,68 @@ var (
 	waitFiles       = flag.String("wait_file", "", "Comma-separated list of paths to wait for")
 	waitFileContent = flag.Bool("wait_file_content", false, "If specified, expect wait_file to have content")
 	postFile        = flag.String("post_file", "", "If specified, file to write upon completion")
-
-	waitPollingInterval = time.Second
+	alwaysRun       = flag.Bool("always_run", false, "If specified, run even if a previous step failed")
+	waitFileTimeout = flag.Duration("wait_file_timeout", 0, "If specified, how long to wait for wait_file before failing")
+	terminationPath = flag.String("termination_path", "/dev/termination-log", "If specified, file to write the termination message to")
+	retries         = flag.Int("retries", 0, "If specified, how many times to run the command again if it fails")
+	retryBackoff    = flag.Duration("retry_backoff", 0, "If specified, how long to wait before the first retry, doubled for each of the next ones")
+	timeout         = flag.Duration("timeout", 0, "If specified, how long the command can run for, retries included, before it is killed")
+	onError         = flag.String("on_error", "", "If set to continue, report the results and run the next steps even if the command failed")
+	results         = flag.String("results", "", "If specified, comma-separated list of paths of result files to report once the command succeeded")
 )
 
 func main() {
 	flag.Parse()
 
+	var resultFiles []string
+	if *results != "" {
+		resultFiles = strings.Split(*results, ",")
+	}
+	var deadline time.Time
+	if v := os.Getenv(entrypoint.DeadlineEnvVar); v != "" {
+		var err error
+		if deadline, err = time.Parse(time.RFC3339, v); err != nil {
+			log.Printf("Ignoring the invalid %s %q: %v", entrypoint.DeadlineEnvVar, v, err)
+		}
+	}
 	e := entrypoint.Entrypointer{
 		Entrypoint:      *ep,
 		WaitFiles:       strings.Split(*waitFiles, ","),
 		WaitFileContent: *waitFileContent,
 		PostFile:        *postFile,
+		AlwaysRun:       *alwaysRun,
+		Retries:         *retries,
+		RetryBackoff:    *retryBackoff,
+		Timeout:         *timeout,
+		OnError:         v1alpha1.OnErrorType(*onError),
+		Deadline:        deadline,
+		Results:         resultFiles,
 		Args:            flag.Args(),
-		Waiter:          &realWaiter{},
-		Runner:          &realRunner{},
-		PostWriter:      &realPostWriter{},
+		Waiter:          &entrypoint.FileWaiter{Timeout: *waitFileTimeout},
+		Runner:          &entrypoint.CommandRunner{},
+		PostWriter:      &entrypoint.FilePostWriter{},
+		ResultsWriter:   &entrypoint.TerminationResultsWriter{Path: *terminationPath},
 	}
 	if err := e.Go(); err != nil {
-		switch t := err.(type) {
-		case skipError:
+		if errors.Is(err, entrypoint.ErrSkipPreviousStepFailed) {
 			log.Print("Skipping step because a previous step failed")
 			os.Exit(1)
+		}
+		if errors.Is(err, entrypoint.ErrWaitTimeout) {
+			// Tell the controller why the step failed, it never ran the
+			// command that could have written its own termination message.
+			if err := termination.WriteReason(*terminationPath, termination.ReasonStepsStartTimeout); err != nil {
+				log.Printf("Error writing termination message: %v", err)
+			}
+			log.Fatalf("Error waiting for the step to start: %v", err)
+		}
+		if errors.Is(err, entrypoint.ErrStepTimeout) {
+			if err := termination.WriteReason(*terminationPath, termination.ReasonStepTimeout); err != nil {
+				log.Printf("Error writing termination message: %v", err)
+			}
+			log.Fatalf("Error executing command: %v", err)
+		}
+		switch t := err.(type) {
 		case *exec.ExitError:
 			// Copied from https://stackoverflow.com/questions/10385551/get-exit-code-go
 			// This works on both Unix and Windows. Although
@@ -36,6 +36,8 @@ func init() {
 	flag.StringVar(&fetchSpec.Path, "path", "", "Path of directory under which Git repository will be copied")
 	flag.BoolVar(&submodules, "submodules", true, "Initialize and fetch Git submodules")
 	flag.UintVar(&fetchSpec.Depth, "depth", 1, "Perform a shallow clone to this depth")
+	flag.StringVar(&fetchSpec.Refspec, "refspec", "", "The Git refspec to fetch before checking out the revision")
+	flag.StringVar(&fetchSpec.SparseCheckoutDirectories, "sparseCheckoutDirectories", "", "Comma separated list of the directories to check out")
 	flag.StringVar(&terminationMessagePath, "terminationMessagePath", "/dev/termination-log", "Location of file containing termination message")
 }
 
@@ -60,8 +62,9 @@ func main() {
 	}
 	output := []v1alpha1.PipelineResourceResult{
 		{
-			Key:   "commit",
-			Value: commit,
+			Key:        "commit",
+			Value:      commit,
+			ResultType: v1alpha1.PipelineResourceResultType,
 		},
 	}
 
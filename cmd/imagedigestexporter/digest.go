@@ -1,9 +1,18 @@
 package main
 
 import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 )
 
+// digestFileName is the name of the file, in the directory of an image
+// resource, which builders write the digest of the image they pushed to, e.g.
+// with kaniko's --digest-file or buildah's --digestfile.
+const digestFileName = "digest"
+
 // GetDigest returns the digest of an OCI image index. If there is only one image in the index, the
 // digest of the image is returned; otherwise, the digest of the whole index is returned.
 func GetDigest(ii v1.ImageIndex) (v1.Hash, error) {
@@ -16,3 +25,17 @@ func GetDigest(ii v1.ImageIndex) (v1.Hash, error) {
 	}
 	return ii.Digest()
 }
+
+// ReadDigestFile returns the digest in the digest file of dir. The file holds
+// either the digest or the image reference with its digest.
+func ReadDigestFile(dir string) (v1.Hash, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, digestFileName))
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	digest := strings.TrimSpace(string(b))
+	if i := strings.LastIndex(digest, "@"); i >= 0 {
+		digest = digest[i+1:]
+	}
+	return v1.NewHash(digest)
+}
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -63,3 +66,47 @@ func TestGetDigest(t *testing.T) {
 		})
 	}
 }
+
+func TestReadDigestFile(t *testing.T) {
+	const digest = "sha256:eed29cd0b6feeb1a92bc3c4f977fd203c63b376a638731c88cacefe3adb1c660"
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{{
+		name:    "digest",
+		content: digest + "\n",
+	}, {
+		name:    "image reference with digest",
+		content: "gcr.io/some-image@" + digest,
+	}, {
+		name:    "invalid digest",
+		content: "latest",
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "image-digest-exporter")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			if err := ioutil.WriteFile(filepath.Join(dir, "digest"), []byte(test.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ReadDigestFile(dir)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error reading %q", test.content)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cannot read digest file: %s", err)
+			}
+			if got.String() != digest {
+				t.Errorf("read digest %s, want %s", got, digest)
+			}
+		})
+	}
+}
@@ -19,10 +19,12 @@ package main
 import (
 	"encoding/json"
 	"flag"
+	"os"
 
 	"github.com/tektoncd/pipeline/pkg/termination"
 	"knative.dev/pkg/logging"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/layout"
 	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 )
@@ -34,10 +36,10 @@ var (
 
 /* The input of this go program will be a JSON string with all the output PipelineResources of type
 Image, which will include the path to where the index.json file will be located. The program will
-read the related index.json file(s) and log another JSON string including the name of the image resource
-and the digests.
+read the related index.json file(s), or the digest file(s) when there is none, and log another JSON
+string including the name of the image resource and the digests.
 The input is an array of ImageResource, ex: [{"name":"srcimg1","type":"image","url":"gcr.io/some-image-1","digest":""}]
-The output is an array of PipelineResourceResult, ex: [{"name":"image","digest":"sha256:eed29..660"}]
+The output is a versioned termination message, see pkg/termination, ex: {"version":1,"results":[{"name":"image","digest":"sha256:eed29..660"}]}
 */
 func main() {
 	flag.Parse()
@@ -51,14 +53,17 @@ func main() {
 
 	output := []v1alpha1.PipelineResourceResult{}
 	for _, imageResource := range imageResources {
-		ii, err := layout.ImageIndexFromPath(imageResource.OutputImageDir)
-		if err != nil {
-			logger.Infof("No index.json found for: %s", imageResource.Name)
-			continue
-		}
-		digest, err := GetDigest(ii)
-		if err != nil {
-			logger.Fatalf("Unexpected error getting image digest for %s: %v", imageResource.Name, err)
+		var digest v1.Hash
+		if ii, err := layout.ImageIndexFromPath(imageResource.OutputImageDir); err == nil {
+			if digest, err = GetDigest(ii); err != nil {
+				logger.Fatalf("Unexpected error getting image digest for %s: %v", imageResource.Name, err)
+			}
+		} else if digest, err = ReadDigestFile(imageResource.OutputImageDir); err != nil {
+			if os.IsNotExist(err) {
+				logger.Infof("No index.json or digest file found for: %s", imageResource.Name)
+				continue
+			}
+			logger.Fatalf("Unexpected error reading the digest file of %s: %v", imageResource.Name, err)
 		}
 		// We need to write both the old Name/Digest style and the new Key/Value styles.
 		output = append(output, v1alpha1.PipelineResourceResult{
@@ -72,6 +77,7 @@ func main() {
 			ResourceRef: v1alpha1.PipelineResourceRef{
 				Name: imageResource.Name,
 			},
+			ResultType: v1alpha1.PipelineResourceResultType,
 		})
 
 	}
@@ -21,6 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"go.uber.org/zap"
@@ -68,18 +69,19 @@ func createKubeconfigFile(resource *v1alpha1.ClusterResource, logger *zap.Sugare
 	if passwordFromEnv := os.Getenv("PASSWORD"); passwordFromEnv != "" {
 		resource.Password = passwordFromEnv
 	}
-	//only one authentication technique per user is allowed in a kubeconfig, so clear out the password if a token is provided
-	user := resource.Username
-	pass := resource.Password
-	if resource.Token != "" {
-		user = ""
-		pass = ""
-	}
-	auth := &clientcmdapi.AuthInfo{
-		Token:    resource.Token,
-		Username: user,
-		Password: pass,
+	for _, field := range []struct {
+		env   string
+		value *string
+	}{
+		{"OIDCCLIENTSECRET", &resource.OIDCClientSecret},
+		{"OIDCIDTOKEN", &resource.OIDCIDToken},
+		{"OIDCREFRESHTOKEN", &resource.OIDCRefreshToken},
+	} {
+		if fromEnv := os.Getenv(field.env); fromEnv != "" {
+			*field.value = strings.TrimRight(fromEnv, "\r\n")
+		}
 	}
+	auth := authInfo(resource)
 	context := &clientcmdapi.Context{
 		Cluster:  resource.Name,
 		AuthInfo: resource.Username,
@@ -100,3 +102,45 @@ func createKubeconfigFile(resource *v1alpha1.ClusterResource, logger *zap.Sugare
 	}
 	logger.Infof("kubeconfig file successfully written to %s", destinationFile)
 }
+
+// authInfo returns how to authenticate to the cluster. Only one authentication
+// technique per user is allowed in a kubeconfig: a credential plugin, an OIDC
+// provider, a token or a username and a password, in this order of precedence.
+func authInfo(resource *v1alpha1.ClusterResource) *clientcmdapi.AuthInfo {
+	switch {
+	case resource.ExecCommand != "":
+		exec := &clientcmdapi.ExecConfig{
+			Command:    resource.ExecCommand,
+			Args:       resource.ExecArgs,
+			APIVersion: resource.ExecAPIVersion,
+		}
+		var names []string
+		for name := range resource.ExecEnv {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: name, Value: resource.ExecEnv[name]})
+		}
+		return &clientcmdapi.AuthInfo{Exec: exec}
+	case resource.OIDCIssuerURL != "":
+		config := map[string]string{
+			"idp-issuer-url": resource.OIDCIssuerURL,
+			"client-id":      resource.OIDCClientID,
+		}
+		for key, value := range map[string]string{
+			"client-secret": resource.OIDCClientSecret,
+			"id-token":      resource.OIDCIDToken,
+			"refresh-token": resource.OIDCRefreshToken,
+		} {
+			if value != "" {
+				config[key] = value
+			}
+		}
+		return &clientcmdapi.AuthInfo{AuthProvider: &clientcmdapi.AuthProviderConfig{Name: "oidc", Config: config}}
+	case resource.Token != "":
+		return &clientcmdapi.AuthInfo{Token: resource.Token}
+	default:
+		return &clientcmdapi.AuthInfo{Username: resource.Username, Password: resource.Password}
+	}
+}
@@ -45,7 +45,7 @@ func main() {
 	token := os.Getenv("AUTH_TOKEN")
 	client, err := pullrequest.NewSCMHandler(logger, *prURL, *provider, token)
 	if err != nil {
-		logger.Fatalf("error creating GitHub client: %v", err)
+		logger.Fatalf("error creating SCM client: %v", err)
 	}
 
 	switch *mode {
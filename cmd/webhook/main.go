@@ -20,15 +20,25 @@ import (
 	"context"
 	"flag"
 	"log"
+	"net/http"
 	"os"
+	"time"
 
 	apiconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
 	"github.com/tektoncd/pipeline/pkg/contexts"
+	"github.com/tektoncd/pipeline/pkg/conversion"
+	"github.com/tektoncd/pipeline/pkg/health"
 	tklogging "github.com/tektoncd/pipeline/pkg/logging"
+	"github.com/tektoncd/pipeline/pkg/resourceplugins"
 	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/pipeline/pkg/webhookcerts"
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"knative.dev/pkg/configmap"
@@ -41,6 +51,18 @@ import (
 // WebhookLogKey is the name of the logger for the webhook cmd
 const WebhookLogKey = "webhook"
 
+// serverCertKey is the key of the certificate of the webhook in its Secret.
+const serverCertKey = "server-cert.pem"
+
+var (
+	healthAddress = flag.String("health-address", ":8080",
+		"The address serving the liveness and readiness of the webhook, empty to disable it.")
+	certMinValidity = flag.Duration("cert-min-validity", 24*time.Hour,
+		"How long the certificate of the webhook must remain valid for the webhook to be ready.")
+	certSecret = flag.String("cert-secret", "",
+		"The kubernetes.io/tls Secret, with a ca.crt, issued to the webhook by an external CA such as cert-manager, empty to generate a self-signed certificate.")
+)
+
 func main() {
 	flag.Parse()
 	cm, err := configmap.Load("/etc/config-logging")
@@ -75,6 +97,7 @@ func main() {
 
 	store := apiconfig.NewStore(logger.Named("config-store"))
 	store.WatchConfigs(configMapWatcher)
+	resourceplugins.Watch(configMapWatcher, logger)
 
 	if err = configMapWatcher.Start(stopCh); err != nil {
 		logger.Fatalf("failed to start configuration manager: %v", err)
@@ -94,14 +117,26 @@ func main() {
 		WebhookName:                     "webhook.tekton.dev",
 		ResourceAdmissionControllerPath: "/",
 	}
+	var certs webhookcerts.Certs
+	health.DefaultChecks.Add("certificate", health.CertificateValid(certs.CertPEM, *certMinValidity, time.Now))
+	if *healthAddress != "" {
+		go serveHealth(*healthAddress, logger)
+	}
+
 	resourceHandlers := map[schema.GroupVersionKind]webhook.GenericCRD{
-		v1alpha1.SchemeGroupVersion.WithKind("Pipeline"):         &v1alpha1.Pipeline{},
-		v1alpha1.SchemeGroupVersion.WithKind("PipelineResource"): &v1alpha1.PipelineResource{},
-		v1alpha1.SchemeGroupVersion.WithKind("Task"):             &v1alpha1.Task{},
-		v1alpha1.SchemeGroupVersion.WithKind("ClusterTask"):      &v1alpha1.ClusterTask{},
-		v1alpha1.SchemeGroupVersion.WithKind("TaskRun"):          &v1alpha1.TaskRun{},
-		v1alpha1.SchemeGroupVersion.WithKind("PipelineRun"):      &v1alpha1.PipelineRun{},
-		v1alpha1.SchemeGroupVersion.WithKind("Condition"):        &v1alpha1.Condition{},
+		v1alpha1.SchemeGroupVersion.WithKind("Pipeline"):             &v1alpha1.Pipeline{},
+		v1alpha1.SchemeGroupVersion.WithKind("PipelineResource"):     &v1alpha1.PipelineResource{},
+		v1alpha1.SchemeGroupVersion.WithKind("Task"):                 &v1alpha1.Task{},
+		v1alpha1.SchemeGroupVersion.WithKind("ClusterTask"):          &v1alpha1.ClusterTask{},
+		v1alpha1.SchemeGroupVersion.WithKind("TaskRun"):              &v1alpha1.TaskRun{},
+		v1alpha1.SchemeGroupVersion.WithKind("PipelineRun"):          &v1alpha1.PipelineRun{},
+		v1alpha1.SchemeGroupVersion.WithKind("Condition"):            &v1alpha1.Condition{},
+		v1alpha1.SchemeGroupVersion.WithKind("NotificationPolicy"):   &v1alpha1.NotificationPolicy{},
+		v1alpha1.SchemeGroupVersion.WithKind("StorageMigration"):     &v1alpha1.StorageMigration{},
+		v1alpha1.SchemeGroupVersion.WithKind("ResolutionRequest"):    &v1alpha1.ResolutionRequest{},
+		v1alpha1.SchemeGroupVersion.WithKind("Run"):                  &v1alpha1.Run{},
+		v1alpha1.SchemeGroupVersion.WithKind("TektonPipelineConfig"): &v1alpha1.TektonPipelineConfig{},
+		v1alpha2.SchemeGroupVersion.WithKind("Task"):                 &v1alpha2.Task{},
 	}
 
 	resourceAdmissionController := webhook.NewResourceAdmissionController(resourceHandlers, options, true)
@@ -119,7 +154,56 @@ func main() {
 		logger.Fatal("Error creating admission controller", zap.Error(err))
 	}
 
-	if err := controller.Run(stopCh); err != nil {
+	// The conversion webhook is served next to the admission controllers,
+	// with the same certificate.
+	dynamicClient, err := dynamic.NewForConfig(clusterConfig)
+	if err != nil {
+		logger.Fatal("Failed to get the dynamic client", zap.Error(err))
+	}
+	crds := dynamicClient.Resource(schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1beta1", Resource: "customresourcedefinitions"})
+	patchCRD := func(name string, data []byte) error {
+		_, err := crds.Patch(name, types.MergePatchType, data, metav1.UpdateOptions{})
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(conversion.Path, conversion.NewHandler(logger))
+	mux.Handle("/", controller)
+
+	// Register the webhook with the certificate of the CA, each time it's
+	// rotated when it's issued by an external CA.
+	ctx := logging.WithLogger(context.Background(), logger)
+	register := func(caCert []byte) error {
+		for _, c := range admissionControllers {
+			if err := c.Register(ctx, kubeClient, caCert); err != nil {
+				return err
+			}
+		}
+		return conversion.Register(patchCRD, caCert)
+	}
+	if *certSecret == "" {
+		if err := certs.SelfSigned(ctx, kubeClient, options.Namespace, options.SecretName, options.ServiceName); err != nil {
+			logger.Fatal("Error generating the webhook certificate", zap.Error(err))
+		}
+		if err := register(certs.CACert()); err != nil {
+			logger.Fatal("Error registering the webhook", zap.Error(err))
+		}
+	} else {
+		if err := certs.Watch(kubeClient, options.Namespace, *certSecret, register, logger, stopCh); err != nil {
+			logger.Fatal("Error loading the webhook certificate", zap.Error(err))
+		}
+		logger.Infof("Serving the certificate of Secret %q", *certSecret)
+	}
+	if err := certs.Serve(options.Port, mux, stopCh); err != nil {
 		logger.Fatal("Error running admission controller", zap.Error(err))
 	}
 }
+
+// serveHealth serves the liveness and readiness of the webhook at address:
+// it is ready while its certificate remains valid for long enough.
+func serveHealth(address string, logger *zap.SugaredLogger) {
+	mux := http.NewServeMux()
+	health.DefaultChecks.Handle(mux)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		logger.Errorw("Health server stopped", zap.Error(err))
+	}
+}
@@ -18,19 +18,48 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
 	// ConfigName is the name of the configmap
-	DefaultsConfigName       = "config-defaults"
-	DefaultTimeoutMinutes    = 60
-	NoTimeoutDuration        = 0 * time.Minute
-	defaultTimeoutMinutesKey = "default-timeout-minutes"
-	defaultServiceAccountKey = "default-service-account"
+	DefaultsConfigName           = "config-defaults"
+	DefaultTimeoutMinutes        = 60
+	NoTimeoutDuration            = 0 * time.Minute
+	DefaultTimeoutMinutesKey     = "default-timeout-minutes"
+	DefaultServiceAccountKey     = "default-service-account"
+	MaximumTimeoutMinutesKey     = "maximum-timeout-minutes"
+	MaximumTimeoutPolicyKey      = "maximum-timeout-policy"
+	AllowNoTimeoutKey            = "allow-no-timeout"
+	MaximumPodVolumesKey         = "maximum-pod-volumes"
+	MaximumMemoryVolumesKey      = "maximum-memory-volumes-size"
+	StepsStartTimeoutKey         = "steps-start-timeout"
+	DefaultCloudEventsSinkKey    = "default-cloud-events-sink"
+	MaximumPipelineTasksKey      = "maximum-pipeline-tasks"
+	MaximumPipelineParamsKey     = "maximum-pipeline-params"
+	MaximumMatrixCombinationsKey = "maximum-matrix-combinations"
+	MaximumPipelineResultRefsKey = "maximum-pipeline-result-references"
+	// DefaultMaximumMatrixCombinations is the largest number of TaskRuns a
+	// PipelineTask may be fanned out into with its matrix, unless
+	// maximum-matrix-combinations is set.
+	DefaultMaximumMatrixCombinations = 256
+)
+
+// MaximumTimeoutPolicy is what happens to runs requesting a timeout beyond
+// the maximum timeout.
+type MaximumTimeoutPolicy string
+
+const (
+	// MaximumTimeoutPolicyReject makes the webhook reject such runs.
+	MaximumTimeoutPolicyReject MaximumTimeoutPolicy = "reject"
+	// MaximumTimeoutPolicyClamp makes the webhook lower their timeout to the
+	// maximum timeout.
+	MaximumTimeoutPolicyClamp MaximumTimeoutPolicy = "clamp"
 )
 
 // Defaults holds the default configurations
@@ -38,31 +67,180 @@ const (
 type Defaults struct {
 	DefaultTimeoutMinutes int
 	DefaultServiceAccount string
+	// MaximumTimeoutMinutes is the largest timeout runs may request, 0 if there
+	// is no maximum. A timeout of NoTimeoutDuration exceeds any maximum.
+	MaximumTimeoutMinutes int
+	MaximumTimeoutPolicy  MaximumTimeoutPolicy
+	// AllowNoTimeout is true if runs may disable their timeout by setting it
+	// to NoTimeoutDuration. It doesn't apply to runs that omit their timeout,
+	// which get DefaultTimeoutMinutes.
+	AllowNoTimeout bool
+	// MaximumPodVolumes is the largest number of volumes the Pod of a
+	// TaskRun may mount, 0 if there is no maximum.
+	MaximumPodVolumes int
+	// MaximumMemoryVolumesBytes is a hint of the memory the nodes can
+	// allocate to the memoryVolumes of a Task, 0 if there is no maximum.
+	MaximumMemoryVolumesBytes int64
+	// StepsStartTimeout is how long the first step of a TaskRun waits for
+	// the Pod to signal it can start, 0 if it waits until the TaskRun times
+	// out.
+	StepsStartTimeout time.Duration
+	// DefaultCloudEventsSink is the URL the reconcilers send the cloud events
+	// about the lifecycle of TaskRuns and PipelineRuns to, none are sent if it
+	// is empty.
+	DefaultCloudEventsSink string
+	// MaximumPipelineTasks is the largest number of tasks, including the
+	// finally tasks, a Pipeline may have, 0 if there is no maximum.
+	MaximumPipelineTasks int
+	// MaximumPipelineParams is the largest number of params a Pipeline may
+	// declare, 0 if there is no maximum.
+	MaximumPipelineParams int
+	// MaximumMatrixCombinations is the largest number of TaskRuns a
+	// PipelineTask may be fanned out into with its matrix.
+	MaximumMatrixCombinations int
+	// MaximumPipelineResultRefs is the largest number of references to the
+	// results of tasks the tasks of a Pipeline may make, 0 if there is no
+	// maximum.
+	MaximumPipelineResultRefs int
 }
 
 // Equals returns true if two Configs are identical
 func (cfg *Defaults) Equals(other *Defaults) bool {
 	return other.DefaultTimeoutMinutes == cfg.DefaultTimeoutMinutes &&
-		other.DefaultServiceAccount == cfg.DefaultServiceAccount
+		other.DefaultServiceAccount == cfg.DefaultServiceAccount &&
+		other.MaximumTimeoutMinutes == cfg.MaximumTimeoutMinutes &&
+		other.MaximumTimeoutPolicy == cfg.MaximumTimeoutPolicy &&
+		other.AllowNoTimeout == cfg.AllowNoTimeout &&
+		other.MaximumPodVolumes == cfg.MaximumPodVolumes &&
+		other.MaximumMemoryVolumesBytes == cfg.MaximumMemoryVolumesBytes &&
+		other.StepsStartTimeout == cfg.StepsStartTimeout &&
+		other.DefaultCloudEventsSink == cfg.DefaultCloudEventsSink &&
+		other.MaximumPipelineTasks == cfg.MaximumPipelineTasks &&
+		other.MaximumPipelineParams == cfg.MaximumPipelineParams &&
+		other.MaximumMatrixCombinations == cfg.MaximumMatrixCombinations &&
+		other.MaximumPipelineResultRefs == cfg.MaximumPipelineResultRefs
+}
+
+// MaximumTimeout returns the largest timeout runs may request, or
+// NoTimeoutDuration if there is no maximum.
+func (cfg *Defaults) MaximumTimeout() time.Duration {
+	return time.Duration(cfg.MaximumTimeoutMinutes) * time.Minute
+}
+
+// ExceedsMaximumTimeout returns true if runs may not request timeout.
+func (cfg *Defaults) ExceedsMaximumTimeout(timeout time.Duration) bool {
+	if cfg.MaximumTimeoutMinutes <= 0 {
+		return false
+	}
+	return timeout == NoTimeoutDuration || timeout > cfg.MaximumTimeout()
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
 func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 	tc := Defaults{
-		DefaultTimeoutMinutes: DefaultTimeoutMinutes,
+		DefaultTimeoutMinutes:     DefaultTimeoutMinutes,
+		MaximumTimeoutPolicy:      MaximumTimeoutPolicyReject,
+		AllowNoTimeout:            true,
+		MaximumMatrixCombinations: DefaultMaximumMatrixCombinations,
 	}
-	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
+	if defaultTimeoutMin, ok := cfgMap[DefaultTimeoutMinutesKey]; ok {
 		timeout, err := strconv.ParseInt(defaultTimeoutMin, 10, 0)
 		if err != nil {
-			return nil, fmt.Errorf("failed parsing tracing config %q", defaultTimeoutMinutesKey)
+			return nil, fmt.Errorf("failed parsing tracing config %q", DefaultTimeoutMinutesKey)
 		}
 		tc.DefaultTimeoutMinutes = int(timeout)
 	}
 
-	if defaultServiceAccount, ok := cfgMap[defaultServiceAccountKey]; ok {
+	if defaultServiceAccount, ok := cfgMap[DefaultServiceAccountKey]; ok {
 		tc.DefaultServiceAccount = defaultServiceAccount
 	}
 
+	if maximumTimeoutMin, ok := cfgMap[MaximumTimeoutMinutesKey]; ok {
+		timeout, err := strconv.ParseInt(maximumTimeoutMin, 10, 0)
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", MaximumTimeoutMinutesKey)
+		}
+		tc.MaximumTimeoutMinutes = int(timeout)
+	}
+
+	if maximumTimeoutPolicy, ok := cfgMap[MaximumTimeoutPolicyKey]; ok {
+		switch p := MaximumTimeoutPolicy(maximumTimeoutPolicy); p {
+		case MaximumTimeoutPolicyReject, MaximumTimeoutPolicyClamp:
+			tc.MaximumTimeoutPolicy = p
+		default:
+			return nil, fmt.Errorf("invalid %q %q, must be %q or %q", MaximumTimeoutPolicyKey, maximumTimeoutPolicy, MaximumTimeoutPolicyReject, MaximumTimeoutPolicyClamp)
+		}
+	}
+
+	if allowNoTimeout, ok := cfgMap[AllowNoTimeoutKey]; ok {
+		allow, err := strconv.ParseBool(allowNoTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q", AllowNoTimeoutKey)
+		}
+		tc.AllowNoTimeout = allow
+	}
+
+	if maximumPodVolumes, ok := cfgMap[MaximumPodVolumesKey]; ok {
+		maximum, err := strconv.ParseInt(maximumPodVolumes, 10, 0)
+		if err != nil || maximum < 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", MaximumPodVolumesKey)
+		}
+		tc.MaximumPodVolumes = int(maximum)
+	}
+
+	if maximumMemoryVolumes, ok := cfgMap[MaximumMemoryVolumesKey]; ok {
+		maximum, err := resource.ParseQuantity(maximumMemoryVolumes)
+		if err != nil || maximum.Sign() < 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", MaximumMemoryVolumesKey)
+		}
+		tc.MaximumMemoryVolumesBytes = maximum.Value()
+	}
+
+	if stepsStartTimeout, ok := cfgMap[StepsStartTimeoutKey]; ok {
+		timeout, err := time.ParseDuration(stepsStartTimeout)
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", StepsStartTimeoutKey)
+		}
+		tc.StepsStartTimeout = timeout
+	}
+
+	if sink, ok := cfgMap[DefaultCloudEventsSinkKey]; ok && sink != "" {
+		u, err := url.Parse(sink)
+		if err != nil || !u.IsAbs() {
+			return nil, fmt.Errorf("failed parsing defaults config %q: %q isn't an absolute URL", DefaultCloudEventsSinkKey, sink)
+		}
+		tc.DefaultCloudEventsSink = sink
+	}
+
+	for key, maximum := range map[string]*int{
+		MaximumPipelineTasksKey:      &tc.MaximumPipelineTasks,
+		MaximumPipelineParamsKey:     &tc.MaximumPipelineParams,
+		MaximumPipelineResultRefsKey: &tc.MaximumPipelineResultRefs,
+	} {
+		if value, ok := cfgMap[key]; ok {
+			m, err := strconv.ParseInt(value, 10, 0)
+			if err != nil || m < 0 {
+				return nil, fmt.Errorf("failed parsing defaults config %q", key)
+			}
+			*maximum = int(m)
+		}
+	}
+
+	if maximumMatrixCombinations, ok := cfgMap[MaximumMatrixCombinationsKey]; ok {
+		maximum, err := strconv.ParseInt(maximumMatrixCombinations, 10, 0)
+		if err != nil || maximum <= 0 {
+			return nil, fmt.Errorf("failed parsing defaults config %q", MaximumMatrixCombinationsKey)
+		}
+		tc.MaximumMatrixCombinations = int(maximum)
+	}
+
+	if !tc.AllowNoTimeout && tc.DefaultTimeoutMinutes == 0 {
+		return nil, fmt.Errorf("%q can't be 0 when %q is false", DefaultTimeoutMinutesKey, AllowNoTimeoutKey)
+	}
+	if tc.ExceedsMaximumTimeout(time.Duration(tc.DefaultTimeoutMinutes) * time.Minute) {
+		return nil, fmt.Errorf("%q %d exceeds %q %d", DefaultTimeoutMinutesKey, tc.DefaultTimeoutMinutes, MaximumTimeoutMinutesKey, tc.MaximumTimeoutMinutes)
+	}
+
 	return &tc, nil
 }
 
@@ -18,6 +18,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
@@ -25,16 +26,149 @@ import (
 
 func TestNewDefaultsFromConfigMap(t *testing.T) {
 	expectedConfig := &Defaults{
-		DefaultTimeoutMinutes: 50,
-		DefaultServiceAccount: "tekton",
+		DefaultTimeoutMinutes:     50,
+		DefaultServiceAccount:     "tekton",
+		MaximumTimeoutPolicy:      MaximumTimeoutPolicyReject,
+		AllowNoTimeout:            true,
+		MaximumPodVolumes:         20,
+		MaximumMemoryVolumesBytes: 4 << 30,
+		StepsStartTimeout:         5 * time.Minute,
+		DefaultCloudEventsSink:    "http://events.example.com",
+		MaximumPipelineTasks:      100,
+		MaximumPipelineParams:     50,
+		MaximumMatrixCombinations: 64,
+		MaximumPipelineResultRefs: 200,
 	}
 	verifyConfigFileWithExpectedConfig(t, DefaultsConfigName, expectedConfig)
 }
 
+func TestNewDefaultsFromConfigMapWithMaximumTimeout(t *testing.T) {
+	expectedConfig := &Defaults{
+		DefaultTimeoutMinutes:     50,
+		MaximumTimeoutMinutes:     120,
+		MaximumTimeoutPolicy:      MaximumTimeoutPolicyClamp,
+		AllowNoTimeout:            true,
+		MaximumMatrixCombinations: DefaultMaximumMatrixCombinations,
+	}
+	verifyConfigFileWithExpectedConfig(t, "config-defaults-maximum-timeout", expectedConfig)
+}
+
+func TestNewDefaultsFromMapInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		cfgMap map[string]string
+	}{{
+		name:   "relative cloud events sink",
+		cfgMap: map[string]string{"default-cloud-events-sink": "events"},
+	}, {
+		name:   "invalid maximum timeout",
+		cfgMap: map[string]string{"maximum-timeout-minutes": "forever"},
+	}, {
+		name:   "negative maximum timeout",
+		cfgMap: map[string]string{"maximum-timeout-minutes": "-1"},
+	}, {
+		name:   "invalid maximum timeout policy",
+		cfgMap: map[string]string{"maximum-timeout-policy": "ignore"},
+	}, {
+		name:   "default timeout exceeds maximum timeout",
+		cfgMap: map[string]string{"maximum-timeout-minutes": "30"},
+	}, {
+		name:   "invalid allow no timeout",
+		cfgMap: map[string]string{"allow-no-timeout": "sometimes"},
+	}, {
+		name:   "invalid maximum pod volumes",
+		cfgMap: map[string]string{"maximum-pod-volumes": "many"},
+	}, {
+		name:   "negative maximum pod volumes",
+		cfgMap: map[string]string{"maximum-pod-volumes": "-1"},
+	}, {
+		name:   "invalid maximum memory volumes size",
+		cfgMap: map[string]string{"maximum-memory-volumes-size": "lots"},
+	}, {
+		name:   "negative maximum memory volumes size",
+		cfgMap: map[string]string{"maximum-memory-volumes-size": "-1Gi"},
+	}, {
+		name:   "invalid steps start timeout",
+		cfgMap: map[string]string{"steps-start-timeout": "5"},
+	}, {
+		name:   "negative steps start timeout",
+		cfgMap: map[string]string{"steps-start-timeout": "-5m"},
+	}, {
+		name:   "invalid maximum pipeline tasks",
+		cfgMap: map[string]string{"maximum-pipeline-tasks": "lots"},
+	}, {
+		name:   "negative maximum pipeline params",
+		cfgMap: map[string]string{"maximum-pipeline-params": "-1"},
+	}, {
+		name:   "negative maximum pipeline result references",
+		cfgMap: map[string]string{"maximum-pipeline-result-references": "-1"},
+	}, {
+		name:   "no matrix combinations",
+		cfgMap: map[string]string{"maximum-matrix-combinations": "0"},
+	}, {
+		name: "no default timeout when no timeout is not allowed",
+		cfgMap: map[string]string{
+			"default-timeout-minutes": "0",
+			"allow-no-timeout":        "false",
+		},
+	}, {
+		name: "no default timeout with maximum timeout",
+		cfgMap: map[string]string{
+			"default-timeout-minutes": "0",
+			"maximum-timeout-minutes": "30",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewDefaultsFromMap(tc.cfgMap); err == nil {
+				t.Error("NewDefaultsFromMap() expected an error")
+			}
+		})
+	}
+}
+
+func TestExceedsMaximumTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		maximum  int
+		timeout  time.Duration
+		expected bool
+	}{{
+		name:    "no maximum",
+		timeout: 24 * time.Hour,
+	}, {
+		name:    "no maximum and no timeout",
+		timeout: NoTimeoutDuration,
+	}, {
+		name:    "within maximum",
+		maximum: 60,
+		timeout: time.Hour,
+	}, {
+		name:     "exceeds maximum",
+		maximum:  60,
+		timeout:  time.Hour + time.Second,
+		expected: true,
+	}, {
+		name:     "no timeout exceeds maximum",
+		maximum:  60,
+		timeout:  NoTimeoutDuration,
+		expected: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Defaults{MaximumTimeoutMinutes: tc.maximum}
+			if got := cfg.ExceedsMaximumTimeout(tc.timeout); got != tc.expected {
+				t.Errorf("ExceedsMaximumTimeout(%s) = %t, expected %t", tc.timeout, got, tc.expected)
+			}
+		})
+	}
+}
+
 func TestNewDefaultsFromEmptyConfigMap(t *testing.T) {
 	DefaultsConfigEmptyName := "config-defaults-empty"
 	expectedConfig := &Defaults{
-		DefaultTimeoutMinutes: 60,
+		DefaultTimeoutMinutes:     60,
+		MaximumTimeoutPolicy:      MaximumTimeoutPolicyReject,
+		AllowNoTimeout:            true,
+		MaximumMatrixCombinations: DefaultMaximumMatrixCombinations,
 	}
 	verifyConfigFileWithExpectedConfig(t, DefaultsConfigEmptyName, expectedConfig)
 }
@@ -0,0 +1,204 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// FeatureFlagsConfigName is the name of the configmap holding the feature flags
+	FeatureFlagsConfigName      = "feature-flags"
+	DisableCredsInitKey         = "disable-creds-init"
+	EnableCleanupFinalizerKey   = "enable-cleanup-finalizer"
+	RecordDefaultedFieldsKey    = "record-defaulted-fields"
+	RecordStepCommandsKey       = "record-step-commands"
+	AllowedOutputResourcesKey   = "allowed-output-resources"
+	EnableRunCloudEventsSinkKey = "enable-run-cloud-events-sink"
+
+	CredsInitSecretLabelSelectorKey      = "creds-init-secret-label-selector"
+	CredsInitSecretAnnotationSelectorKey = "creds-init-secret-annotation-selector"
+)
+
+// FeatureFlags holds the features configurations
+// +k8s:deepcopy-gen=true
+type FeatureFlags struct {
+	// DisableCredsInit is true if no TaskRun Pod should initialize
+	// credentials from the Secrets of its ServiceAccount.
+	DisableCredsInit bool
+	// CredsInitSecretLabelSelector and CredsInitSecretAnnotationSelector,
+	// when set, restrict creds init to the Secrets of the ServiceAccount
+	// whose labels, respectively annotations, match them. The label
+	// selector uses the label selector syntax, e.g.
+	// "tekton.dev/creds-init=allowed". The annotation selector is a
+	// comma-separated list of "key" or "key=value", e.g.
+	// "tekton.dev/git-0=https://github.com", whose values are compared as
+	// they are, since annotation values needn't be valid label values.
+	CredsInitSecretLabelSelector      string
+	CredsInitSecretAnnotationSelector string
+	// EnableCleanupFinalizer is true if PipelineRuns and TaskRuns get a
+	// finalizer, so that the controller cleans up what they created outside
+	// of the cluster before they are deleted.
+	EnableCleanupFinalizer bool
+	// RecordDefaultedFields is true if the webhook logs the fields it
+	// defaults, and records them in an annotation of the defaulted object.
+	RecordDefaultedFields bool
+	// RecordStepCommands is true if the status of TaskRuns lists what the
+	// entrypoint of each step executes.
+	RecordStepCommands bool
+	// AllowedOutputResources are the types of the output PipelineResources
+	// that are copied, through the artifact storage, to the Tasks of a
+	// Pipeline using them as inputs. v1alpha2.AllowedOutputResources are if
+	// nil.
+	AllowedOutputResources []v1alpha2.PipelineResourceType
+	// EnableRunCloudEventsSink is true if the cloud events about a run are
+	// sent to the cloudEventsSink of its spec. It is off by default, since
+	// the controller would send requests to any URL a run sets.
+	EnableRunCloudEventsSink bool
+}
+
+// passableOutputResources are the types of PipelineResources whose outputs
+// can be allowed to be copied to the next Tasks of a Pipeline.
+var passableOutputResources = map[v1alpha2.PipelineResourceType]bool{
+	v1alpha2.PipelineResourceTypeGit:     true,
+	v1alpha2.PipelineResourceTypeStorage: true,
+	v1alpha2.PipelineResourceTypeImage:   true,
+	v1alpha2.PipelineResourceTypeCluster: true,
+}
+
+// OutputResourceAllowed returns true if the outputs of the PipelineResources
+// of type t are copied to the next Tasks of a Pipeline.
+func (cfg *FeatureFlags) OutputResourceAllowed(t v1alpha2.PipelineResourceType) bool {
+	if cfg.AllowedOutputResources == nil {
+		return v1alpha2.AllowedOutputResources[t]
+	}
+	for _, allowed := range cfg.AllowedOutputResources {
+		if allowed == t {
+			return true
+		}
+	}
+	return false
+}
+
+// CredsInitSecretMatches returns true if creds init may use secret, that is if
+// it matches both CredsInitSecretLabelSelector and
+// CredsInitSecretAnnotationSelector.
+func (cfg *FeatureFlags) CredsInitSecretMatches(secret *corev1.Secret) bool {
+	// The selectors are validated when the config is parsed.
+	labelSelector, _ := labels.Parse(cfg.CredsInitSecretLabelSelector)
+	if !labelSelector.Matches(labels.Set(secret.Labels)) {
+		return false
+	}
+	for _, r := range annotationRequirements(cfg.CredsInitSecretAnnotationSelector) {
+		if value, ok := secret.Annotations[r.key]; !ok || (r.hasValue && value != r.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// annotationRequirement is a "key" or "key=value" of an annotation selector.
+type annotationRequirement struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+// annotationRequirements returns the requirements of selector, a
+// comma-separated list of "key" or "key=value".
+func annotationRequirements(selector string) []annotationRequirement {
+	var requirements []annotationRequirement
+	for _, part := range strings.Split(selector, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		r := annotationRequirement{key: part}
+		if i := strings.Index(part, "="); i >= 0 {
+			r = annotationRequirement{key: strings.TrimSpace(part[:i]), value: strings.TrimSpace(part[i+1:]), hasValue: true}
+		}
+		requirements = append(requirements, r)
+	}
+	return requirements
+}
+
+// validateAnnotationSelector returns an error if a key of selector isn't a
+// valid annotation key.
+func validateAnnotationSelector(selector string) error {
+	for _, r := range annotationRequirements(selector) {
+		if errs := validation.IsQualifiedName(r.key); len(errs) > 0 {
+			return fmt.Errorf("invalid annotation key %q: %s", r.key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// NewFeatureFlagsFromMap returns a FeatureFlags given a map corresponding to a ConfigMap
+func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
+	tc := FeatureFlags{}
+	for key, flag := range map[string]*bool{
+		DisableCredsInitKey:         &tc.DisableCredsInit,
+		EnableCleanupFinalizerKey:   &tc.EnableCleanupFinalizer,
+		RecordDefaultedFieldsKey:    &tc.RecordDefaultedFields,
+		RecordStepCommandsKey:       &tc.RecordStepCommands,
+		EnableRunCloudEventsSinkKey: &tc.EnableRunCloudEventsSink,
+	} {
+		if s, ok := cfgMap[key]; ok {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, fmt.Errorf("failed parsing feature flags config %q", key)
+			}
+			*flag = b
+		}
+	}
+	if s, ok := cfgMap[CredsInitSecretLabelSelectorKey]; ok {
+		if _, err := labels.Parse(s); err != nil {
+			return nil, fmt.Errorf("failed parsing feature flags config %q: %v", CredsInitSecretLabelSelectorKey, err)
+		}
+		tc.CredsInitSecretLabelSelector = s
+	}
+	if s, ok := cfgMap[CredsInitSecretAnnotationSelectorKey]; ok {
+		if err := validateAnnotationSelector(s); err != nil {
+			return nil, fmt.Errorf("failed parsing feature flags config %q: %v", CredsInitSecretAnnotationSelectorKey, err)
+		}
+		tc.CredsInitSecretAnnotationSelector = s
+	}
+	if s, ok := cfgMap[AllowedOutputResourcesKey]; ok {
+		tc.AllowedOutputResources = []v1alpha2.PipelineResourceType{}
+		for _, t := range strings.Split(s, ",") {
+			if t = strings.TrimSpace(t); t == "" {
+				continue
+			}
+			if !passableOutputResources[v1alpha2.PipelineResourceType(t)] {
+				return nil, fmt.Errorf("failed parsing feature flags config %q: the outputs of %q resources can't be copied to the next Tasks", AllowedOutputResourcesKey, t)
+			}
+			tc.AllowedOutputResources = append(tc.AllowedOutputResources, v1alpha2.PipelineResourceType(t))
+		}
+	}
+	return &tc, nil
+}
+
+// NewFeatureFlagsFromConfigMap returns a FeatureFlags for the given configmap
+func NewFeatureFlagsFromConfigMap(config *corev1.ConfigMap) (*FeatureFlags, error) {
+	return NewFeatureFlagsFromMap(config.Data)
+}
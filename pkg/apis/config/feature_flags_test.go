@@ -0,0 +1,185 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
+	expectedConfig := &FeatureFlags{
+		DisableCredsInit:                  true,
+		CredsInitSecretLabelSelector:      "tekton.dev/creds-init=allowed",
+		CredsInitSecretAnnotationSelector: "tekton.dev/git-0",
+		EnableCleanupFinalizer:            true,
+		RecordDefaultedFields:             true,
+		RecordStepCommands:                true,
+		AllowedOutputResources:            []v1alpha2.PipelineResourceType{"git", "storage", "image"},
+		EnableRunCloudEventsSink:          true,
+	}
+	cm := test.ConfigMapFromTestFile(t, FeatureFlagsConfigName)
+	featureFlags, err := NewFeatureFlagsFromConfigMap(cm)
+	if err != nil {
+		t.Fatalf("NewFeatureFlagsFromConfigMap(actual) = %v", err)
+	}
+	if d := cmp.Diff(expectedConfig, featureFlags); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestNewFeatureFlagsFromEmptyMap(t *testing.T) {
+	featureFlags, err := NewFeatureFlagsFromMap(map[string]string{})
+	if err != nil {
+		t.Fatalf("NewFeatureFlagsFromMap() = %v", err)
+	}
+	if d := cmp.Diff(&FeatureFlags{}, featureFlags); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestNewFeatureFlagsFromMapInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		cfgMap map[string]string
+	}{{
+		name:   "invalid disable creds init",
+		cfgMap: map[string]string{"disable-creds-init": "sometimes"},
+	}, {
+		name:   "invalid enable cleanup finalizer",
+		cfgMap: map[string]string{"enable-cleanup-finalizer": "maybe"},
+	}, {
+		name:   "invalid record defaulted fields",
+		cfgMap: map[string]string{"record-defaulted-fields": "often"},
+	}, {
+		name:   "invalid record step commands",
+		cfgMap: map[string]string{"record-step-commands": "always"},
+	}, {
+		name:   "invalid enable run cloud events sink",
+		cfgMap: map[string]string{"enable-run-cloud-events-sink": "yes please"},
+	}, {
+		name:   "invalid creds init secret label selector",
+		cfgMap: map[string]string{"creds-init-secret-label-selector": "a=b=c"},
+	}, {
+		name:   "invalid creds init secret annotation selector",
+		cfgMap: map[string]string{"creds-init-secret-annotation-selector": "!="},
+	}, {
+		name:   "unknown allowed output resource",
+		cfgMap: map[string]string{"allowed-output-resources": "git,docker"},
+	}, {
+		name:   "allowed output resource that can't be copied",
+		cfgMap: map[string]string{"allowed-output-resources": "pullRequest"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewFeatureFlagsFromMap(tc.cfgMap); err == nil {
+				t.Error("NewFeatureFlagsFromMap() expected an error")
+			}
+		})
+	}
+}
+
+func TestCredsInitSecretMatches(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:        "creds",
+		Labels:      map[string]string{"tekton.dev/creds-init": "allowed"},
+		Annotations: map[string]string{"tekton.dev/git-0": "https://github.com"},
+	}}
+	for _, tc := range []struct {
+		name         string
+		featureFlags FeatureFlags
+		expected     bool
+	}{{
+		name:     "no selectors",
+		expected: true,
+	}, {
+		name:         "matching label selector",
+		featureFlags: FeatureFlags{CredsInitSecretLabelSelector: "tekton.dev/creds-init=allowed"},
+		expected:     true,
+	}, {
+		name:         "not matching label selector",
+		featureFlags: FeatureFlags{CredsInitSecretLabelSelector: "tekton.dev/creds-init!=allowed"},
+	}, {
+		name:         "matching annotation selector",
+		featureFlags: FeatureFlags{CredsInitSecretAnnotationSelector: "tekton.dev/git-0"},
+		expected:     true,
+	}, {
+		name:         "not matching annotation selector",
+		featureFlags: FeatureFlags{CredsInitSecretAnnotationSelector: "tekton.dev/docker-0"},
+	}, {
+		name:         "matching annotation selector with a url value",
+		featureFlags: FeatureFlags{CredsInitSecretAnnotationSelector: "tekton.dev/git-0=https://github.com"},
+		expected:     true,
+	}, {
+		name:         "not matching annotation selector with a url value",
+		featureFlags: FeatureFlags{CredsInitSecretAnnotationSelector: "tekton.dev/git-0=https://gitlab.com"},
+	}, {
+		name:         "not matching one of the annotation requirements",
+		featureFlags: FeatureFlags{CredsInitSecretAnnotationSelector: "tekton.dev/git-0, tekton.dev/docker-0"},
+	}, {
+		name: "only label selector matching",
+		featureFlags: FeatureFlags{
+			CredsInitSecretLabelSelector:      "tekton.dev/creds-init",
+			CredsInitSecretAnnotationSelector: "tekton.dev/docker-0",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.featureFlags.CredsInitSecretMatches(secret); got != tc.expected {
+				t.Errorf("CredsInitSecretMatches() = %t, want %t", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestOutputResourceAllowed(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cfgMap  map[string]string
+		allowed []v1alpha2.PipelineResourceType
+	}{{
+		name:    "default",
+		cfgMap:  map[string]string{},
+		allowed: []v1alpha2.PipelineResourceType{"git", "storage"},
+	}, {
+		name:    "image and cluster",
+		cfgMap:  map[string]string{"allowed-output-resources": "image,cluster"},
+		allowed: []v1alpha2.PipelineResourceType{"image", "cluster"},
+	}, {
+		name:   "none",
+		cfgMap: map[string]string{"allowed-output-resources": ""},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			featureFlags, err := NewFeatureFlagsFromMap(tc.cfgMap)
+			if err != nil {
+				t.Fatalf("NewFeatureFlagsFromMap() = %v", err)
+			}
+			var allowed []v1alpha2.PipelineResourceType
+			for _, r := range v1alpha2.AllResourceTypes {
+				if featureFlags.OutputResourceAllowed(r) {
+					allowed = append(allowed, r)
+				}
+			}
+			if d := cmp.Diff(tc.allowed, allowed); d != "" {
+				t.Errorf("Diff:\n%s", d)
+			}
+		})
+	}
+}
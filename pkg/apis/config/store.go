@@ -27,7 +27,9 @@ type cfgKey struct{}
 // Config holds the collection of configurations that we attach to contexts.
 // +k8s:deepcopy-gen=false
 type Config struct {
-	Defaults *Defaults
+	Defaults     *Defaults
+	FeatureFlags *FeatureFlags
+	TaskPolicy   *TaskPolicy
 }
 
 // FromContext extracts a Config from the provided context.
@@ -46,8 +48,12 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 		return cfg
 	}
 	defaults, _ := NewDefaultsFromMap(map[string]string{})
+	featureFlags, _ := NewFeatureFlagsFromMap(map[string]string{})
+	taskPolicy, _ := NewTaskPolicyFromMap(map[string]string{})
 	return &Config{
-		Defaults: defaults,
+		Defaults:     defaults,
+		FeatureFlags: featureFlags,
+		TaskPolicy:   taskPolicy,
 	}
 }
 
@@ -70,7 +76,9 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 			"defaults",
 			logger,
 			configmap.Constructors{
-				DefaultsConfigName: NewDefaultsFromConfigMap,
+				DefaultsConfigName:     NewDefaultsFromConfigMap,
+				FeatureFlagsConfigName: NewFeatureFlagsFromConfigMap,
+				TaskPolicyConfigName:   NewTaskPolicyFromConfigMap,
 			},
 			onAfterStore...,
 		),
@@ -84,9 +92,18 @@ func (s *Store) ToContext(ctx context.Context) context.Context {
 	return ToContext(ctx, s.Load())
 }
 
-// Load creates a Config from the current config state of the Store.
+// Load creates a Config from the current config state of the Store. A
+// configmap that hasn't been observed yet loads as its defaults.
 func (s *Store) Load() *Config {
-	return &Config{
-		Defaults: s.UntypedLoad(DefaultsConfigName).(*Defaults).DeepCopy(),
+	cfg := FromContextOrDefaults(context.Background())
+	if defaults, ok := s.UntypedLoad(DefaultsConfigName).(*Defaults); ok {
+		cfg.Defaults = defaults.DeepCopy()
+	}
+	if featureFlags, ok := s.UntypedLoad(FeatureFlagsConfigName).(*FeatureFlags); ok {
+		cfg.FeatureFlags = featureFlags.DeepCopy()
+	}
+	if taskPolicy, ok := s.UntypedLoad(TaskPolicyConfigName).(*TaskPolicy); ok {
+		cfg.TaskPolicy = taskPolicy.DeepCopy()
 	}
+	return cfg
 }
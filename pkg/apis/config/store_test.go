@@ -28,7 +28,11 @@ import (
 func TestStoreLoadWithContext(t *testing.T) {
 	store := NewStore(logtesting.TestLogger(t))
 	defaultConfig := test.ConfigMapFromTestFile(t, "config-defaults")
+	featureFlagsConfig := test.ConfigMapFromTestFile(t, "feature-flags")
 	store.OnConfigChanged(defaultConfig)
+	taskPolicyConfig := test.ConfigMapFromTestFile(t, "config-task-policy")
+	store.OnConfigChanged(featureFlagsConfig)
+	store.OnConfigChanged(taskPolicyConfig)
 
 	config := FromContext(store.ToContext(context.Background()))
 
@@ -36,4 +40,22 @@ func TestStoreLoadWithContext(t *testing.T) {
 	if diff := cmp.Diff(config.Defaults, expected); diff != "" {
 		t.Errorf("Unexpected default config (-want, +got): %v", diff)
 	}
+	expectedFeatureFlags, _ := NewFeatureFlagsFromConfigMap(featureFlagsConfig)
+	if diff := cmp.Diff(config.FeatureFlags, expectedFeatureFlags); diff != "" {
+		t.Errorf("Unexpected feature flags (-want, +got): %v", diff)
+	}
+	expectedTaskPolicy, _ := NewTaskPolicyFromConfigMap(taskPolicyConfig)
+	if diff := cmp.Diff(config.TaskPolicy, expectedTaskPolicy); diff != "" {
+		t.Errorf("Unexpected task policy (-want, +got): %v", diff)
+	}
+}
+
+func TestStoreLoadBeforeConfigChanged(t *testing.T) {
+	store := NewStore(logtesting.TestLogger(t))
+
+	config := FromContext(store.ToContext(context.Background()))
+
+	if diff := cmp.Diff(FromContextOrDefaults(context.Background()), config); diff != "" {
+		t.Errorf("Unexpected config (-want, +got): %v", diff)
+	}
 }
@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// TaskPolicyConfigName is the name of the configmap holding the policy
+	// enforced on Tasks and TaskRuns
+	TaskPolicyConfigName  = "config-task-policy"
+	disallowPrivilegedKey = "disallow-privileged"
+	disallowHostPathKey   = "disallow-host-path"
+	disallowedImagesKey   = "disallowed-images"
+	requireImageDigestKey = "require-image-digests"
+	exemptNamespacesKey   = "exempt-namespaces"
+)
+
+// imageDigest matches the digest an image reference ends with when it is
+// pinned, e.g. "@sha256:4a1c...".
+var imageDigest = regexp.MustCompile(`@[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]{32,}$`)
+
+// TaskPolicy holds the fields the webhook forbids in the Tasks, ClusterTasks
+// and TaskRuns that are created.
+// +k8s:deepcopy-gen=true
+type TaskPolicy struct {
+	// DisallowPrivileged is true if no step, step template or sidecar may
+	// run privileged.
+	DisallowPrivileged bool
+	// DisallowHostPath is true if no hostPath volume may be mounted.
+	DisallowHostPath bool
+	// DisallowedImages are the images no step or sidecar may run. An image
+	// ending with "*" disallows every image starting with the rest of it.
+	DisallowedImages []string
+	// RequireImageDigests is true if every step and sidecar must run an
+	// image pinned to a digest.
+	RequireImageDigests bool
+	// ExemptNamespaces are the namespaces the policy doesn't apply to.
+	ExemptNamespaces []string
+}
+
+// Exempts returns true if the policy doesn't apply to namespace.
+func (p *TaskPolicy) Exempts(namespace string) bool {
+	for _, ns := range p.ExemptNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// ImageDisallowed returns true if image matches one of DisallowedImages.
+func (p *TaskPolicy) ImageDisallowed(image string) bool {
+	for _, disallowed := range p.DisallowedImages {
+		if prefix := strings.TrimSuffix(disallowed, "*"); prefix != disallowed {
+			if strings.HasPrefix(image, prefix) {
+				return true
+			}
+		} else if image == disallowed {
+			return true
+		}
+	}
+	return false
+}
+
+// ImageNotPinned returns true if RequireImageDigests is set and image isn't
+// pinned to a digest.
+func (p *TaskPolicy) ImageNotPinned(image string) bool {
+	return p.RequireImageDigests && !imageDigest.MatchString(image)
+}
+
+// NewTaskPolicyFromMap returns a TaskPolicy given a map corresponding to a ConfigMap
+func NewTaskPolicyFromMap(cfgMap map[string]string) (*TaskPolicy, error) {
+	tc := TaskPolicy{}
+	for key, flag := range map[string]*bool{
+		disallowPrivilegedKey: &tc.DisallowPrivileged,
+		disallowHostPathKey:   &tc.DisallowHostPath,
+		requireImageDigestKey: &tc.RequireImageDigests,
+	} {
+		if s, ok := cfgMap[key]; ok {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, fmt.Errorf("failed parsing task policy config %q", key)
+			}
+			*flag = b
+		}
+	}
+	for key, list := range map[string]*[]string{
+		disallowedImagesKey: &tc.DisallowedImages,
+		exemptNamespacesKey: &tc.ExemptNamespaces,
+	} {
+		if s, ok := cfgMap[key]; ok {
+			*list = splitList(s)
+		}
+	}
+	return &tc, nil
+}
+
+// NewTaskPolicyFromConfigMap returns a TaskPolicy for the given configmap
+func NewTaskPolicyFromConfigMap(config *corev1.ConfigMap) (*TaskPolicy, error) {
+	return NewTaskPolicyFromMap(config.Data)
+}
+
+// splitList returns the non-empty items of the comma-separated list s.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+)
+
+func TestNewTaskPolicyFromConfigMap(t *testing.T) {
+	expectedConfig := &TaskPolicy{
+		DisallowPrivileged:  true,
+		DisallowHostPath:    true,
+		DisallowedImages:    []string{"docker:dind", "gcr.io/untrusted/*"},
+		RequireImageDigests: true,
+		ExemptNamespaces:    []string{"tekton-pipelines", "kube-system"},
+	}
+	cm := test.ConfigMapFromTestFile(t, TaskPolicyConfigName)
+	taskPolicy, err := NewTaskPolicyFromConfigMap(cm)
+	if err != nil {
+		t.Fatalf("NewTaskPolicyFromConfigMap(actual) = %v", err)
+	}
+	if d := cmp.Diff(expectedConfig, taskPolicy); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestNewTaskPolicyFromEmptyMap(t *testing.T) {
+	taskPolicy, err := NewTaskPolicyFromMap(map[string]string{"disallowed-images": " , "})
+	if err != nil {
+		t.Fatalf("NewTaskPolicyFromMap() = %v", err)
+	}
+	if d := cmp.Diff(&TaskPolicy{}, taskPolicy); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
+func TestNewTaskPolicyFromMapInvalid(t *testing.T) {
+	for _, key := range []string{"disallow-privileged", "disallow-host-path", "require-image-digests"} {
+		t.Run(key, func(t *testing.T) {
+			if _, err := NewTaskPolicyFromMap(map[string]string{key: "mostly"}); err == nil {
+				t.Error("NewTaskPolicyFromMap() expected an error")
+			}
+		})
+	}
+}
+
+func TestTaskPolicyImageDisallowed(t *testing.T) {
+	policy := &TaskPolicy{DisallowedImages: []string{"docker:dind", "gcr.io/untrusted/*"}}
+	for _, tc := range []struct {
+		image    string
+		expected bool
+	}{{
+		image:    "docker:dind",
+		expected: true,
+	}, {
+		image: "docker:19.03",
+	}, {
+		image:    "gcr.io/untrusted/builder:latest",
+		expected: true,
+	}, {
+		image: "gcr.io/trusted/builder",
+	}} {
+		t.Run(tc.image, func(t *testing.T) {
+			if got := policy.ImageDisallowed(tc.image); got != tc.expected {
+				t.Errorf("ImageDisallowed() = %t, want %t", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTaskPolicyImageNotPinned(t *testing.T) {
+	policy := &TaskPolicy{RequireImageDigests: true}
+	for _, tc := range []struct {
+		image    string
+		expected bool
+	}{{
+		image:    "busybox",
+		expected: true,
+	}, {
+		image:    "gcr.io/builders/kaniko:v0.17.1",
+		expected: true,
+	}, {
+		image: "busybox@sha256:895ab622e92e18d6b461d671081757af7dbaa3b00e3e28e12505af7817f73649",
+	}, {
+		image: "gcr.io/builders/kaniko:v0.17.1@sha256:895ab622e92e18d6b461d671081757af7dbaa3b00e3e28e12505af7817f73649",
+	}, {
+		image:    "busybox@sha256:latest",
+		expected: true,
+	}} {
+		t.Run(tc.image, func(t *testing.T) {
+			if got := policy.ImageNotPinned(tc.image); got != tc.expected {
+				t.Errorf("ImageNotPinned() = %t, want %t", got, tc.expected)
+			}
+		})
+	}
+	if (&TaskPolicy{}).ImageNotPinned("busybox") {
+		t.Error("ImageNotPinned(busybox) = true without require-image-digests, want false")
+	}
+}
+
+func TestTaskPolicyExempts(t *testing.T) {
+	policy := &TaskPolicy{ExemptNamespaces: []string{"kube-system"}}
+	if !policy.Exempts("kube-system") {
+		t.Error("Exempts(kube-system) = false, want true")
+	}
+	if policy.Exempts("default") {
+		t.Error("Exempts(default) = true, want false")
+	}
+}
@@ -20,6 +20,10 @@ limitations under the License.
 
 package config
 
+import (
+	v1alpha2 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
+)
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Defaults) DeepCopyInto(out *Defaults) {
 	*out = *in
@@ -35,3 +39,50 @@ func (in *Defaults) DeepCopy() *Defaults {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureFlags) DeepCopyInto(out *FeatureFlags) {
+	*out = *in
+	if in.AllowedOutputResources != nil {
+		in, out := &in.AllowedOutputResources, &out.AllowedOutputResources
+		*out = make([]v1alpha2.PipelineResourceType, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureFlags.
+func (in *FeatureFlags) DeepCopy() *FeatureFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskPolicy) DeepCopyInto(out *TaskPolicy) {
+	*out = *in
+	if in.DisallowedImages != nil {
+		in, out := &in.DisallowedImages, &out.DisallowedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExemptNamespaces != nil {
+		in, out := &in.ExemptNamespaces, &out.ExemptNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskPolicy.
+func (in *TaskPolicy) DeepCopy() *TaskPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
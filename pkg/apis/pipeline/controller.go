@@ -23,4 +23,28 @@ const (
 
 	// TaskRunControllerName holds the name of the PipelineRun controller
 	TaskRunControllerName = "TaskRun"
+
+	// GitHubChecksControllerName holds the name of the controller reporting
+	// PipelineRuns to the GitHub Checks API
+	GitHubChecksControllerName = "GitHubChecks"
+
+	// NotificationControllerName holds the name of the controller sending
+	// the notifications of NotificationPolicies
+	NotificationControllerName = "Notification"
+
+	// StorageMigrationControllerName holds the name of the controller running
+	// the StorageMigrations
+	StorageMigrationControllerName = "StorageMigration"
+
+	// PipelineConfigControllerName holds the name of the controller applying
+	// the TektonPipelineConfig
+	PipelineConfigControllerName = "PipelineConfig"
+
+	// ResolutionRequestControllerName holds the name of the controller
+	// resolving the ResolutionRequests
+	ResolutionRequestControllerName = "ResolutionRequest"
+
+	// OrphanSweeperControllerName holds the name of the controller deleting
+	// the Pods and PersistentVolumeClaims of deleted runs
+	OrphanSweeperControllerName = "OrphanSweeper"
 )
@@ -33,10 +33,18 @@ type Images struct {
 	ShellImage string
 	// GsutilImage is the container miage containing gsutil.
 	GsutilImage string
+	// AwsCliImage is the container image containing the AWS CLI, used to store artifacts in S3 buckets.
+	AwsCliImage string
 	// BuildGCSFetcherImage is the container image containing our GCS fetcher binary.
 	BuildGCSFetcherImage string
 	// PRImage is the container image that we use to implement the PR source step.
 	PRImage string
 	// ImageDigestExporterImage is the container image containing our image digest exporter binary.
 	ImageDigestExporterImage string
+	// DockerDaemonImage is the container image run as a Docker-in-Docker sidecar for Tasks with the docker capability.
+	DockerDaemonImage string
+	// BuildkitDaemonImage is the container image run as a buildkitd sidecar for Tasks with the buildkit capability.
+	BuildkitDaemonImage string
+	// CurlImage is the container image containing curl, used to implement the HTTP resource.
+	CurlImage string
 }
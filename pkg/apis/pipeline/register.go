@@ -23,6 +23,9 @@ const (
 	// TaskLabelKey is used as the label identifier for a task
 	TaskLabelKey = "/task"
 
+	// ClusterTaskLabelKey is used as the label identifier for a ClusterTask
+	ClusterTaskLabelKey = "/clusterTask"
+
 	// TaskRunLabelKey is used as the label identifier for a TaskRun
 	TaskRunLabelKey = "/taskRun"
 
@@ -29,15 +29,33 @@ import (
 /* #nosec */
 const secretVolumeMountPath = "/var/bucketsecret"
 
+const (
+	// ArtifactBucketProviderGCS stores the artifacts with gsutil, in a GCS
+	// bucket or in an S3 bucket of us-east-1 configured by a boto
+	// configuration.
+	ArtifactBucketProviderGCS = "gcs"
+	// ArtifactBucketProviderS3 stores the artifacts with the AWS CLI, in an
+	// S3 bucket of any region or in a bucket of an S3-compatible endpoint.
+	ArtifactBucketProviderS3 = "s3"
+)
+
 // ArtifactBucket contains the Storage bucket configuration defined in the
 // Bucket config map.
 type ArtifactBucket struct {
 	Name     string
 	Location string
 	Secrets  []SecretParam
+	// Provider is the tool copying the artifacts, ArtifactBucketProviderGCS
+	// if empty.
+	Provider string
+	// Region and Endpoint are the region and the endpoint URL of the bucket
+	// of the S3 provider, the AWS CLI's defaults if empty.
+	Region   string
+	Endpoint string
 
 	ShellImage  string
 	GsutilImage string
+	AwsCliImage string
 }
 
 // GetType returns the type of the artifact storage
@@ -52,34 +70,78 @@ func (b *ArtifactBucket) StorageBasePath(pr *PipelineRun) string {
 
 // GetCopyFromStorageToSteps returns a container used to download artifacts from temporary storage
 func (b *ArtifactBucket) GetCopyFromStorageToSteps(name, sourcePath, destinationPath string) []Step {
-	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
-
-	return []Step{{Container: corev1.Container{
+	mkdir := corev1.Container{
 		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-dest-mkdir-%s", name)),
 		Image:   b.ShellImage,
 		Command: []string{"mkdir", "-p", destinationPath},
-	}}, {Container: corev1.Container{
-		Name:         names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-copy-from-%s", name)),
-		Image:        b.GsutilImage,
-		Command:      []string{"gsutil"},
-		Args:         []string{"cp", "-P", "-r", fmt.Sprintf("%s/%s/*", b.Location, sourcePath), destinationPath},
-		Env:          envVars,
-		VolumeMounts: secretVolumeMount,
-	}}}
+	}
+	copyFrom := b.container(fmt.Sprintf("artifact-copy-from-%s", name))
+	if b.Provider == ArtifactBucketProviderS3 {
+		copyFrom.Args = b.awsArgs("s3", "cp", "--recursive", fmt.Sprintf("%s/%s", b.Location, sourcePath), destinationPath)
+	} else {
+		copyFrom.Args = []string{"cp", "-P", "-r", fmt.Sprintf("%s/%s/*", b.Location, sourcePath), destinationPath}
+	}
+	return []Step{{Container: mkdir}, {Container: copyFrom}}
 }
 
 // GetCopyToStorageFromSteps returns a container used to upload artifacts for temporary storage
 func (b *ArtifactBucket) GetCopyToStorageFromSteps(name, sourcePath, destinationPath string) []Step {
-	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
+	copyTo := b.container(fmt.Sprintf("artifact-copy-to-%s", name))
+	if b.Provider == ArtifactBucketProviderS3 {
+		copyTo.Args = b.awsArgs("s3", "cp", "--recursive", sourcePath, fmt.Sprintf("%s/%s", b.Location, destinationPath))
+	} else {
+		copyTo.Args = []string{"cp", "-P", "-r", sourcePath, fmt.Sprintf("%s/%s", b.Location, destinationPath)}
+	}
+	return []Step{{Container: copyTo}}
+}
+
+// GetDeleteFromStorageStep returns a container used to delete the artifacts
+// stored under path, if there are any.
+func (b *ArtifactBucket) GetDeleteFromStorageStep(path string) Step {
+	deleteStep := b.container("")
+	deleteStep.Name = "artifact-delete"
+	if b.Provider == ArtifactBucketProviderS3 {
+		// aws s3 rm succeeds when no object matches.
+		deleteStep.Args = b.awsArgs("s3", "rm", "--recursive", fmt.Sprintf("%s/%s", b.Location, path))
+	} else {
+		deleteStep.Command = []string{"sh", "-c"}
+		// gsutil rm fails when no object matches, as it is the case for runs
+		// that didn't store any artifact.
+		deleteStep.Args = []string{`if gsutil -q ls "$0" >/dev/null 2>&1; then gsutil -m rm -r "$0"; fi`, fmt.Sprintf("%s/%s", b.Location, path)}
+	}
+	return Step{Container: deleteStep}
+}
 
-	return []Step{{Container: corev1.Container{
-		Name:         names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-copy-to-%s", name)),
+// container returns the container of the provider of b named after name,
+// with the credentials of the bucket and without its args.
+func (b *ArtifactBucket) container(name string) corev1.Container {
+	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
+	c := corev1.Container{
 		Image:        b.GsutilImage,
 		Command:      []string{"gsutil"},
-		Args:         []string{"cp", "-P", "-r", sourcePath, fmt.Sprintf("%s/%s", b.Location, destinationPath)},
 		Env:          envVars,
 		VolumeMounts: secretVolumeMount,
-	}}}
+	}
+	if name != "" {
+		c.Name = names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(name)
+	}
+	if b.Provider == ArtifactBucketProviderS3 {
+		c.Image = b.AwsCliImage
+		c.Command = []string{"aws"}
+		if b.Region != "" {
+			c.Env = append(c.Env, corev1.EnvVar{Name: "AWS_DEFAULT_REGION", Value: b.Region})
+		}
+	}
+	return c
+}
+
+// awsArgs returns the args of the AWS CLI running command against the
+// endpoint of b.
+func (b *ArtifactBucket) awsArgs(command ...string) []string {
+	if b.Endpoint == "" {
+		return command
+	}
+	return append([]string{"--endpoint-url", b.Endpoint}, command...)
 }
 
 // GetSecretsVolumes returns the list of volumes for secrets to be mounted
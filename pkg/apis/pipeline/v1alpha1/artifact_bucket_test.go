@@ -84,6 +84,22 @@ func TestBucketGetCopyToContainerSpec(t *testing.T) {
 	}
 }
 
+func TestBucketGetDeleteFromStorageStep(t *testing.T) {
+	want := v1alpha1.Step{Container: corev1.Container{
+		Name:         "artifact-delete",
+		Image:        "google/cloud-sdk",
+		Command:      []string{"sh", "-c"},
+		Args:         []string{`if gsutil -q ls "$0" >/dev/null 2>&1; then gsutil -m rm -r "$0"; fi`, "gs://fake-bucket/pr-ns-bucket"},
+		Env:          []corev1.EnvVar{{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: fmt.Sprintf("/var/bucketsecret/%s/serviceaccount", secretName)}},
+		VolumeMounts: []corev1.VolumeMount{{Name: expectedVolumeName, MountPath: fmt.Sprintf("/var/bucketsecret/%s", secretName)}},
+	}}
+
+	got := bucket.GetDeleteFromStorageStep("pr-ns-bucket")
+	if d := cmp.Diff(got, want); d != "" {
+		t.Errorf("Diff:\n%s", d)
+	}
+}
+
 func TestGetSecretsVolumes(t *testing.T) {
 	names.TestingSeed()
 	want := []corev1.Volume{{
@@ -99,3 +115,65 @@ func TestGetSecretsVolumes(t *testing.T) {
 		t.Errorf("Diff:\n%s", d)
 	}
 }
+
+func TestS3BucketSteps(t *testing.T) {
+	names.TestingSeed()
+	s3Bucket := v1alpha1.ArtifactBucket{
+		Location: "s3://fake-bucket",
+		Secrets: []v1alpha1.SecretParam{{
+			FieldName:  "AWS_SHARED_CREDENTIALS_FILE",
+			SecretName: secretName,
+			SecretKey:  "credentials",
+		}},
+		Provider:    v1alpha1.ArtifactBucketProviderS3,
+		Region:      "eu-west-1",
+		Endpoint:    "https://minio.example.com",
+		ShellImage:  "busybox",
+		AwsCliImage: "amazon/aws-cli",
+	}
+	env := []corev1.EnvVar{
+		{Name: "AWS_SHARED_CREDENTIALS_FILE", Value: fmt.Sprintf("/var/bucketsecret/%s/credentials", secretName)},
+		{Name: "AWS_DEFAULT_REGION", Value: "eu-west-1"},
+	}
+	volumeMounts := []corev1.VolumeMount{{Name: expectedVolumeName, MountPath: fmt.Sprintf("/var/bucketsecret/%s", secretName)}}
+
+	wantCopyFrom := []v1alpha1.Step{{Container: corev1.Container{
+		Name:    "artifact-dest-mkdir-workspace-9l9zj",
+		Image:   "busybox",
+		Command: []string{"mkdir", "-p", "/workspace/destination"},
+	}}, {Container: corev1.Container{
+		Name:         "artifact-copy-from-workspace-mz4c7",
+		Image:        "amazon/aws-cli",
+		Command:      []string{"aws"},
+		Args:         []string{"--endpoint-url", "https://minio.example.com", "s3", "cp", "--recursive", "s3://fake-bucket/src-path", "/workspace/destination"},
+		Env:          env,
+		VolumeMounts: volumeMounts,
+	}}}
+	if d := cmp.Diff(wantCopyFrom, s3Bucket.GetCopyFromStorageToSteps("workspace", "src-path", "/workspace/destination")); d != "" {
+		t.Errorf("Unexpected copy from steps (-want, +got): %s", d)
+	}
+
+	wantCopyTo := []v1alpha1.Step{{Container: corev1.Container{
+		Name:         "artifact-copy-to-workspace-mssqb",
+		Image:        "amazon/aws-cli",
+		Command:      []string{"aws"},
+		Args:         []string{"--endpoint-url", "https://minio.example.com", "s3", "cp", "--recursive", "src-path", "s3://fake-bucket/workspace/destination"},
+		Env:          env,
+		VolumeMounts: volumeMounts,
+	}}}
+	if d := cmp.Diff(wantCopyTo, s3Bucket.GetCopyToStorageFromSteps("workspace", "src-path", "workspace/destination")); d != "" {
+		t.Errorf("Unexpected copy to steps (-want, +got): %s", d)
+	}
+
+	wantDelete := v1alpha1.Step{Container: corev1.Container{
+		Name:         "artifact-delete",
+		Image:        "amazon/aws-cli",
+		Command:      []string{"aws"},
+		Args:         []string{"--endpoint-url", "https://minio.example.com", "s3", "rm", "--recursive", "s3://fake-bucket/pr-ns-bucket"},
+		Env:          env,
+		VolumeMounts: volumeMounts,
+	}}
+	if d := cmp.Diff(wantDelete, s3Bucket.GetDeleteFromStorageStep("pr-ns-bucket")); d != "" {
+		t.Errorf("Unexpected delete step (-want, +got): %s", d)
+	}
+}
@@ -38,6 +38,7 @@ var images = pipeline.Images{
 	BuildGCSFetcherImage:     "gcr.io/cloud-builders/gcs-fetcher:latest",
 	PRImage:                  "override-with-pr:latest",
 	ImageDigestExporterImage: "override-with-imagedigest-exporter-image:latest",
+	CurlImage:                "curlimages/curl",
 }
 
 func Test_Invalid_BuildGCSResource(t *testing.T) {
@@ -27,6 +27,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
+// defaultExecAPIVersion is the version of the ExecCredentials which credential
+// plugins return by default.
+const defaultExecAPIVersion = "client.authentication.k8s.io/v1alpha1"
+
 // ClusterResource represents a cluster configuration (kubeconfig)
 // that can be accessed by tasks in the pipeline
 type ClusterResource struct {
@@ -48,6 +52,26 @@ type ClusterResource struct {
 	// CAData holds PEM-encoded bytes (typically read from a root certificates bundle).
 	// CAData takes precedence over CAFile
 	CAData []byte `json:"cadata"`
+	// ExecCommand is the client-go credential plugin, e.g. aws-iam-authenticator,
+	// fetching the credentials. It must be in the image of the steps using
+	// the kubeconfig.
+	ExecCommand string `json:"execCommand,omitempty"`
+	// ExecArgs are the arguments of ExecCommand.
+	ExecArgs []string `json:"execArgs,omitempty"`
+	// ExecEnv are the environment variables ExecCommand is run with, on top of
+	// the ones of the step.
+	ExecEnv map[string]string `json:"execEnv,omitempty"`
+	// ExecAPIVersion is the version of the ExecCredentials ExecCommand
+	// returns.
+	ExecAPIVersion string `json:"execAPIVersion,omitempty"`
+	// OIDCIssuerURL is the URL of the OpenID Connect provider issuing the ID
+	// tokens authenticating to the server. The other OIDC fields are used along
+	// with it, to refresh the ID token when it expires.
+	OIDCIssuerURL    string `json:"oidcIssuerURL,omitempty"`
+	OIDCClientID     string `json:"oidcClientID,omitempty"`
+	OIDCClientSecret string `json:"oidcClientSecret,omitempty"`
+	OIDCIDToken      string `json:"oidcIDToken,omitempty"`
+	OIDCRefreshToken string `json:"oidcRefreshToken,omitempty"`
 	//Secrets holds a struct to indicate a field name and corresponding secret name to populate it
 	Secrets []SecretParam `json:"secrets"`
 
@@ -86,10 +110,42 @@ func NewClusterResource(kubeconfigWriterImage string, r *PipelineResource) (*Clu
 				sDec, _ := b64.StdEncoding.DecodeString(param.Value)
 				clusterResource.CAData = sDec
 			}
+		case strings.EqualFold(param.Name, "ExecCommand"):
+			clusterResource.ExecCommand = param.Value
+		case strings.EqualFold(param.Name, "ExecArgs"):
+			clusterResource.ExecArgs = strings.Fields(param.Value)
+		case strings.EqualFold(param.Name, "ExecEnv"):
+			env, err := parseExecEnv(param.Value)
+			if err != nil {
+				return nil, fmt.Errorf("ClusterResource: Invalid execEnv of Cluster resource %s: %w", r.Name, err)
+			}
+			clusterResource.ExecEnv = env
+		case strings.EqualFold(param.Name, "ExecAPIVersion"):
+			clusterResource.ExecAPIVersion = param.Value
+		case strings.EqualFold(param.Name, "OIDCIssuerURL"):
+			clusterResource.OIDCIssuerURL = param.Value
+		case strings.EqualFold(param.Name, "OIDCClientID"):
+			clusterResource.OIDCClientID = param.Value
+		case strings.EqualFold(param.Name, "OIDCClientSecret"):
+			clusterResource.OIDCClientSecret = param.Value
+		case strings.EqualFold(param.Name, "OIDCIDToken"):
+			clusterResource.OIDCIDToken = param.Value
+		case strings.EqualFold(param.Name, "OIDCRefreshToken"):
+			clusterResource.OIDCRefreshToken = param.Value
 		}
 	}
 	clusterResource.Secrets = r.Spec.SecretParams
 
+	if clusterResource.ExecCommand != "" && clusterResource.OIDCIssuerURL != "" {
+		return nil, fmt.Errorf("ClusterResource: Cluster resource %s can't use both an execCommand and an oidcIssuerURL", r.Name)
+	}
+	if clusterResource.ExecCommand != "" && clusterResource.ExecAPIVersion == "" {
+		clusterResource.ExecAPIVersion = defaultExecAPIVersion
+	}
+	if clusterResource.OIDCIssuerURL != "" && clusterResource.OIDCClientID == "" {
+		return nil, fmt.Errorf("ClusterResource: Need OIDCClientID to be specified along with OIDCIssuerURL in Cluster resource %s", r.Name)
+	}
+
 	if len(clusterResource.CAData) == 0 {
 		clusterResource.Insecure = true
 		for _, secret := range clusterResource.Secrets {
@@ -103,6 +159,23 @@ func NewClusterResource(kubeconfigWriterImage string, r *PipelineResource) (*Clu
 	return &clusterResource, nil
 }
 
+// parseExecEnv parses the comma separated NAME=value pairs of the execEnv param.
+func parseExecEnv(value string) (map[string]string, error) {
+	env := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("%q should be NAME=value", pair)
+		}
+		env[parts[0]] = parts[1]
+	}
+	return env, nil
+}
+
 // GetName returns the name of the resource
 func (s ClusterResource) GetName() string {
 	return s.Name
@@ -121,16 +194,19 @@ func (s *ClusterResource) GetURL() string {
 // Replacements is used for template replacement on a ClusterResource inside of a Taskrun.
 func (s *ClusterResource) Replacements() map[string]string {
 	return map[string]string{
-		"name":      s.Name,
-		"type":      string(s.Type),
-		"url":       s.URL,
-		"revision":  s.Revision,
-		"username":  s.Username,
-		"password":  s.Password,
-		"namespace": s.Namespace,
-		"token":     s.Token,
-		"insecure":  strconv.FormatBool(s.Insecure),
-		"cadata":    string(s.CAData),
+		"name":          s.Name,
+		"type":          string(s.Type),
+		"url":           s.URL,
+		"revision":      s.Revision,
+		"username":      s.Username,
+		"password":      s.Password,
+		"namespace":     s.Namespace,
+		"token":         s.Token,
+		"insecure":      strconv.FormatBool(s.Insecure),
+		"cadata":        string(s.CAData),
+		"execCommand":   s.ExecCommand,
+		"oidcIssuerURL": s.OIDCIssuerURL,
+		"oidcClientID":  s.OIDCClientID,
 	}
 }
 
@@ -121,6 +121,51 @@ func TestNewClusterResource(t *testing.T) {
 			}},
 			KubeconfigWriterImage: "override-with-kubeconfig-writer:latest",
 		},
+	}, {
+		desc: "resource with a credential plugin",
+		resource: tb.PipelineResource("test-cluster-resource", "default", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeCluster,
+			tb.PipelineResourceSpecParam("url", "https://eks.example.com"),
+			tb.PipelineResourceSpecParam("cadata", "bXktY2x1c3Rlci1jZXJ0"),
+			tb.PipelineResourceSpecParam("execCommand", "aws-iam-authenticator"),
+			tb.PipelineResourceSpecParam("execArgs", "token -i my-cluster"),
+			tb.PipelineResourceSpecParam("execEnv", "AWS_PROFILE=ci, AWS_REGION=eu-west-1"),
+		)),
+		want: &v1alpha1.ClusterResource{
+			Name:                  "test-cluster-resource",
+			Type:                  v1alpha1.PipelineResourceTypeCluster,
+			URL:                   "https://eks.example.com",
+			CAData:                []byte("my-cluster-cert"),
+			ExecCommand:           "aws-iam-authenticator",
+			ExecArgs:              []string{"token", "-i", "my-cluster"},
+			ExecEnv:               map[string]string{"AWS_PROFILE": "ci", "AWS_REGION": "eu-west-1"},
+			ExecAPIVersion:        "client.authentication.k8s.io/v1alpha1",
+			KubeconfigWriterImage: "override-with-kubeconfig-writer:latest",
+		},
+	}, {
+		desc: "resource with an OIDC provider",
+		resource: tb.PipelineResource("test-cluster-resource", "default", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeCluster,
+			tb.PipelineResourceSpecParam("url", "https://10.10.10.10"),
+			tb.PipelineResourceSpecParam("cadata", "bXktY2x1c3Rlci1jZXJ0"),
+			tb.PipelineResourceSpecParam("oidcIssuerURL", "https://accounts.example.com"),
+			tb.PipelineResourceSpecParam("oidcClientID", "tekton"),
+			tb.PipelineResourceSpecSecretParam("oidcRefreshToken", "secret1", "refreshtoken"),
+		)),
+		want: &v1alpha1.ClusterResource{
+			Name:          "test-cluster-resource",
+			Type:          v1alpha1.PipelineResourceTypeCluster,
+			URL:           "https://10.10.10.10",
+			CAData:        []byte("my-cluster-cert"),
+			OIDCIssuerURL: "https://accounts.example.com",
+			OIDCClientID:  "tekton",
+			Secrets: []v1alpha1.SecretParam{{
+				FieldName:  "oidcRefreshToken",
+				SecretKey:  "refreshtoken",
+				SecretName: "secret1",
+			}},
+			KubeconfigWriterImage: "override-with-kubeconfig-writer:latest",
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			got, err := v1alpha1.NewClusterResource("override-with-kubeconfig-writer:latest", c.resource)
@@ -175,3 +220,40 @@ func Test_ClusterResource_GetInputTaskModifier(t *testing.T) {
 		t.Errorf("Error mismatch between download steps: %s", d)
 	}
 }
+
+func TestNewClusterResource_Invalid(t *testing.T) {
+	for _, c := range []struct {
+		desc     string
+		resource *v1alpha1.PipelineResource
+	}{{
+		desc: "credential plugin and OIDC provider",
+		resource: tb.PipelineResource("test-cluster-resource", "default", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeCluster,
+			tb.PipelineResourceSpecParam("url", "https://10.10.10.10"),
+			tb.PipelineResourceSpecParam("execCommand", "aws-iam-authenticator"),
+			tb.PipelineResourceSpecParam("oidcIssuerURL", "https://accounts.example.com"),
+			tb.PipelineResourceSpecParam("oidcClientID", "tekton"),
+		)),
+	}, {
+		desc: "OIDC provider without client ID",
+		resource: tb.PipelineResource("test-cluster-resource", "default", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeCluster,
+			tb.PipelineResourceSpecParam("url", "https://10.10.10.10"),
+			tb.PipelineResourceSpecParam("oidcIssuerURL", "https://accounts.example.com"),
+		)),
+	}, {
+		desc: "invalid credential plugin env",
+		resource: tb.PipelineResource("test-cluster-resource", "default", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeCluster,
+			tb.PipelineResourceSpecParam("url", "https://10.10.10.10"),
+			tb.PipelineResourceSpecParam("execCommand", "aws-iam-authenticator"),
+			tb.PipelineResourceSpecParam("execEnv", "AWS_PROFILE"),
+		)),
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			if _, err := v1alpha1.NewClusterResource("override-with-kubeconfig-writer:latest", c.resource); err == nil {
+				t.Error("Expected error creating Cluster resource")
+			}
+		})
+	}
+}
@@ -25,5 +25,6 @@ import (
 var _ apis.Defaultable = (*ClusterTask)(nil)
 
 func (t *ClusterTask) SetDefaults(ctx context.Context) {
+	defer recordDefaultedFields(ctx, t)()
 	t.Spec.SetDefaults(ctx)
 }
@@ -29,5 +29,13 @@ func (t *ClusterTask) Validate(ctx context.Context) *apis.FieldError {
 	if err := validate.ObjectMetadata(t.GetObjectMeta()); err != nil {
 		return err.ViaField("metadata")
 	}
-	return t.Spec.Validate(ctx)
+	if err := t.Spec.Validate(ctx); err != nil {
+		return err
+	}
+	var old *TaskSpec
+	if base, ok := apis.GetBaseline(ctx).(*ClusterTask); ok && base != nil {
+		old = &base.Spec
+	}
+	// ClusterTasks have no namespace, so they are never exempted.
+	return validateTaskPolicy(ctx, t.Namespace, &t.Spec, old).ViaField("spec")
 }
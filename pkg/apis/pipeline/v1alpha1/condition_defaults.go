@@ -25,6 +25,7 @@ import (
 var _ apis.Defaultable = (*Condition)(nil)
 
 func (c *Condition) SetDefaults(ctx context.Context) {
+	defer recordDefaultedFields(ctx, c)()
 	c.Spec.SetDefaults(ctx)
 }
 
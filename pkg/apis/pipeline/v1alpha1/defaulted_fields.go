@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	"knative.dev/pkg/logging"
+)
+
+// DefaultedFieldsAnnotationKey is the annotation recording the fields the
+// webhook set when defaulting an object, if the record-defaulted-fields
+// feature flag is on.
+const DefaultedFieldsAnnotationKey = pipeline.GroupName + "/defaulted-fields"
+
+// maxDefaultedValueLength is the length past which the values of the
+// defaulted fields are truncated, so that defaulting a whole embedded spec
+// doesn't blow up the annotation.
+const maxDefaultedValueLength = 64
+
+type defaultableObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// recordDefaultedFields snapshots obj and returns a func that, once obj is
+// defaulted, logs and annotates obj with the fields defaulting changed. It
+// only records the defaulting done by the webhook, when the
+// record-defaulted-fields feature flag is on, and not the defaulting done by
+// the controller.
+func recordDefaultedFields(ctx context.Context, obj defaultableObject) func() {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.RecordDefaultedFields || !(apis.IsInCreate(ctx) || apis.IsInUpdate(ctx)) {
+		return func() {}
+	}
+	before := obj.DeepCopyObject()
+	return func() {
+		logger := logging.FromContext(ctx)
+		patch, err := duck.CreatePatch(before, obj)
+		if err != nil {
+			logger.Warnf("Failed to diff the defaulting of %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+			return
+		}
+		if len(patch) == 0 {
+			return
+		}
+		changes := make([]string, 0, len(patch))
+		for _, op := range patch {
+			changes = append(changes, describePatchOperation(op.Operation, op.Path, op.Value))
+		}
+		sort.Strings(changes)
+		diff := strings.Join(changes, ", ")
+		logger.Infof("Defaulted %s %s/%s: %s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName(), diff)
+
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[DefaultedFieldsAnnotationKey] = diff
+		obj.SetAnnotations(annotations)
+	}
+}
+
+// describePatchOperation returns a compact description of a JSON patch
+// operation, for example `spec.timeout="1h0m0s"`.
+func describePatchOperation(operation, path string, value interface{}) string {
+	field := strings.Replace(strings.TrimPrefix(path, "/"), "/", ".", -1)
+	if operation == "remove" {
+		return field + " removed"
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%s=%v", field, value)
+	}
+	v := string(b)
+	if len(v) > maxDefaultedValueLength {
+		v = v[:maxDefaultedValueLength] + "..."
+	}
+	return field + "=" + v
+}
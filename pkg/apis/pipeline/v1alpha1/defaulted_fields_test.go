@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/contexts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestRecordDefaultedFields(t *testing.T) {
+	withConfig := func(ctx context.Context, record bool) context.Context {
+		defaults, _ := config.NewDefaultsFromMap(map[string]string{"default-service-account": "tekton"})
+		return config.ToContext(ctx, &config.Config{
+			Defaults:     defaults,
+			FeatureFlags: &config.FeatureFlags{RecordDefaultedFields: record},
+		})
+	}
+	for _, tc := range []struct {
+		name     string
+		ctx      context.Context
+		tr       *v1alpha1.TaskRun
+		expected map[string]string
+	}{{
+		name: "created",
+		ctx:  apis.WithinCreate(withConfig(context.Background(), true)),
+		tr: &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr", Annotations: map[string]string{"owner": "ci"}},
+			Spec:       v1alpha1.TaskRunSpec{TaskRef: &v1alpha1.TaskRef{Name: "build", Kind: v1alpha1.NamespacedTaskKind}},
+		},
+		expected: map[string]string{
+			"owner":                               "ci",
+			v1alpha1.DefaultedFieldsAnnotationKey: `spec.serviceAccountName="tekton", spec.timeout="1h0m0s"`,
+		},
+	}, {
+		name: "updated",
+		ctx:  apis.WithinUpdate(withConfig(context.Background(), true), &v1alpha1.TaskRun{}),
+		tr: &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr"},
+			Spec: v1alpha1.TaskRunSpec{
+				TaskRef:            &v1alpha1.TaskRef{Name: "build"},
+				ServiceAccountName: "builder",
+				Timeout:            &metav1.Duration{},
+			},
+		},
+		expected: map[string]string{
+			v1alpha1.DefaultedFieldsAnnotationKey: `spec.taskRef.kind="Task"`,
+		},
+	}, {
+		name: "nothing defaulted",
+		ctx:  apis.WithinCreate(withConfig(context.Background(), true)),
+		tr: &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr"},
+			Spec: v1alpha1.TaskRunSpec{
+				TaskRef:            &v1alpha1.TaskRef{Name: "build", Kind: v1alpha1.NamespacedTaskKind},
+				ServiceAccountName: "builder",
+				Timeout:            &metav1.Duration{},
+			},
+		},
+	}, {
+		name: "feature flag off",
+		ctx:  apis.WithinCreate(withConfig(context.Background(), false)),
+		tr: &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr"},
+			Spec:       v1alpha1.TaskRunSpec{TaskRef: &v1alpha1.TaskRef{Name: "build"}},
+		},
+	}, {
+		name: "defaulted by the controller",
+		ctx:  contexts.WithUpgradeViaDefaulting(withConfig(context.Background(), true)),
+		tr: &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr"},
+			Spec:       v1alpha1.TaskRunSpec{TaskRef: &v1alpha1.TaskRef{Name: "build"}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.tr.SetDefaults(tc.ctx)
+			if d := cmp.Diff(tc.expected, tc.tr.Annotations); d != "" {
+				t.Errorf("annotations -want, +got: %s", d)
+			}
+		})
+	}
+}
@@ -44,6 +44,12 @@ type GitResource struct {
 	Submodules bool   `json:"submodules"`
 
 	Depth uint `json:"depth"`
+	// Refspec is the git refspec to fetch before checking out the revision,
+	// e.g. to fetch the head of a pull request.
+	Refspec string `json:"refspec"`
+	// SparseCheckoutDirectories is a comma separated list of the directories
+	// to check out, e.g. to only check out a part of a monorepo.
+	SparseCheckoutDirectories string `json:"sparseCheckoutDirectories"`
 
 	GitImage string `json:"-"`
 }
@@ -70,6 +76,10 @@ func NewGitResource(gitImage string, r *PipelineResource) (*GitResource, error)
 			gitResource.Submodules = toBool(param.Value, true)
 		case strings.EqualFold(param.Name, "Depth"):
 			gitResource.Depth = toUint(param.Value, 1)
+		case strings.EqualFold(param.Name, "Refspec"):
+			gitResource.Refspec = param.Value
+		case strings.EqualFold(param.Name, "SparseCheckoutDirectories"):
+			gitResource.SparseCheckoutDirectories = param.Value
 		}
 	}
 	// default revision to master if nothing is provided
@@ -116,11 +126,13 @@ func (s *GitResource) GetURL() string {
 // Replacements is used for template replacement on a GitResource inside of a Taskrun.
 func (s *GitResource) Replacements() map[string]string {
 	return map[string]string{
-		"name":     s.Name,
-		"type":     string(s.Type),
-		"url":      s.URL,
-		"revision": s.Revision,
-		"depth":    strconv.FormatUint(uint64(s.Depth), 10),
+		"name":                      s.Name,
+		"type":                      string(s.Type),
+		"url":                       s.URL,
+		"revision":                  s.Revision,
+		"depth":                     strconv.FormatUint(uint64(s.Depth), 10),
+		"refspec":                   s.Refspec,
+		"sparseCheckoutDirectories": s.SparseCheckoutDirectories,
 	}
 }
 
@@ -138,6 +150,12 @@ func (s *GitResource) GetInputTaskModifier(_ *TaskSpec, path string) (TaskModifi
 	if s.Depth != 1 {
 		args = append(args, "-depth", strconv.FormatUint(uint64(s.Depth), 10))
 	}
+	if s.Refspec != "" {
+		args = append(args, "-refspec", s.Refspec)
+	}
+	if s.SparseCheckoutDirectories != "" {
+		args = append(args, "-sparseCheckoutDirectories", s.SparseCheckoutDirectories)
+	}
 
 	step := Step{
 		Container: corev1.Container{
@@ -141,6 +141,27 @@ func Test_Valid_NewGitResource(t *testing.T) {
 			Submodules: true,
 			Depth:      0,
 		},
+	}, {
+		desc: "With refspec and sparse checkout directories",
+		pipelineResource: tb.PipelineResource("git-resource", "default",
+			tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeGit,
+				tb.PipelineResourceSpecParam("URL", "git@github.com:test/test.git"),
+				tb.PipelineResourceSpecParam("Revision", "pr"),
+				tb.PipelineResourceSpecParam("Refspec", "refs/pull/42/head:pr"),
+				tb.PipelineResourceSpecParam("SparseCheckoutDirectories", "cmd/,docs/"),
+			),
+		),
+		want: &v1alpha1.GitResource{
+			Name:                      "git-resource",
+			Type:                      v1alpha1.PipelineResourceTypeGit,
+			URL:                       "git@github.com:test/test.git",
+			Revision:                  "pr",
+			Refspec:                   "refs/pull/42/head:pr",
+			SparseCheckoutDirectories: "cmd/,docs/",
+			GitImage:                  "override-with-git:latest",
+			Submodules:                true,
+			Depth:                     1,
+		},
 	}} {
 		t.Run(tc.desc, func(t *testing.T) {
 			got, err := v1alpha1.NewGitResource("override-with-git:latest", tc.pipelineResource)
@@ -157,19 +178,23 @@ func Test_Valid_NewGitResource(t *testing.T) {
 
 func Test_GitResource_Replacements(t *testing.T) {
 	r := &v1alpha1.GitResource{
-		Name:     "git-resource",
-		Type:     v1alpha1.PipelineResourceTypeGit,
-		URL:      "git@github.com:test/test.git",
-		Revision: "master",
-		Depth:    16,
+		Name:                      "git-resource",
+		Type:                      v1alpha1.PipelineResourceTypeGit,
+		URL:                       "git@github.com:test/test.git",
+		Revision:                  "master",
+		Depth:                     16,
+		Refspec:                   "refs/pull/42/head:pr",
+		SparseCheckoutDirectories: "cmd/,docs/",
 	}
 
 	want := map[string]string{
-		"name":     "git-resource",
-		"type":     string(v1alpha1.PipelineResourceTypeGit),
-		"url":      "git@github.com:test/test.git",
-		"revision": "master",
-		"depth":    "16",
+		"name":                      "git-resource",
+		"type":                      string(v1alpha1.PipelineResourceTypeGit),
+		"url":                       "git@github.com:test/test.git",
+		"revision":                  "master",
+		"depth":                     "16",
+		"refspec":                   "refs/pull/42/head:pr",
+		"sparseCheckoutDirectories": "cmd/,docs/",
 	}
 
 	got := r.Replacements()
@@ -268,6 +293,38 @@ func Test_GitResource_GetDownloadTaskModifier(t *testing.T) {
 			WorkingDir: "/workspace",
 			Env:        []corev1.EnvVar{{Name: "TEKTON_RESOURCE_NAME", Value: "git-resource"}},
 		},
+	}, {
+		desc: "With refspec and sparse checkout directories",
+		gitResource: &v1alpha1.GitResource{
+			Name:                      "git-resource",
+			Type:                      v1alpha1.PipelineResourceTypeGit,
+			URL:                       "git@github.com:test/test.git",
+			Revision:                  "pr",
+			Refspec:                   "refs/pull/42/head:pr",
+			SparseCheckoutDirectories: "cmd/,docs/",
+			GitImage:                  "override-with-git:latest",
+			Submodules:                true,
+			Depth:                     1,
+		},
+		want: corev1.Container{
+			Name:    "git-source-git-resource-78c5n",
+			Image:   "override-with-git:latest",
+			Command: []string{"/ko-app/git-init"},
+			Args: []string{
+				"-url",
+				"git@github.com:test/test.git",
+				"-revision",
+				"pr",
+				"-path",
+				"/test/test",
+				"-refspec",
+				"refs/pull/42/head:pr",
+				"-sparseCheckoutDirectories",
+				"cmd/,docs/",
+			},
+			WorkingDir: "/workspace",
+			Env:        []corev1.EnvVar{{Name: "TEKTON_RESOURCE_NAME", Value: "git-resource"}},
+		},
 	}} {
 		t.Run(tc.desc, func(t *testing.T) {
 			ts := v1alpha1.TaskSpec{}
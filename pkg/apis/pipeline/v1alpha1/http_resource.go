@@ -0,0 +1,198 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// HTTPResource is a file downloaded from a URL when it is an input of a Task,
+// and uploaded to it when it is an output.
+type HTTPResource struct {
+	Name string               `json:"name"`
+	Type PipelineResourceType `json:"type"`
+	URL  string               `json:"url"`
+	// Filename is the name of the file in the directory of the resource. It
+	// defaults to the last segment of the path of the URL.
+	Filename string `json:"filename"`
+	// Method is the method uploading the file, PUT or POST.
+	Method string `json:"method"`
+	// Headers are sent with the requests, as "Name: value".
+	Headers []string `json:"headers"`
+	// Secrets hold the values of headers: the field name of each is the
+	// name of the header.
+	Secrets []SecretParam `json:"secrets"`
+
+	ShellImage string `json:"-"`
+	CurlImage  string `json:"-"`
+}
+
+// NewHTTPResource creates a new HTTP resource to pass to a Task
+func NewHTTPResource(images pipeline.Images, r *PipelineResource) (*HTTPResource, error) {
+	if r.Spec.Type != PipelineResourceTypeHTTP {
+		return nil, fmt.Errorf("HTTPResource: Cannot create an HTTP resource from a %s Pipeline Resource", r.Spec.Type)
+	}
+	s := &HTTPResource{
+		Name:       r.Name,
+		Type:       r.Spec.Type,
+		Method:     http.MethodPut,
+		Secrets:    r.Spec.SecretParams,
+		ShellImage: images.ShellImage,
+		CurlImage:  images.CurlImage,
+	}
+	for _, param := range r.Spec.Params {
+		switch {
+		case strings.EqualFold(param.Name, "URL"):
+			s.URL = param.Value
+		case strings.EqualFold(param.Name, "Filename"):
+			s.Filename = param.Value
+		case strings.EqualFold(param.Name, "Method"):
+			s.Method = strings.ToUpper(param.Value)
+		case strings.EqualFold(param.Name, "Headers"):
+			s.Headers = splitHeaders(param.Value)
+		}
+	}
+
+	if s.URL == "" {
+		return nil, fmt.Errorf("HTTPResource: Need URL to be specified in order to create HTTP resource %s", r.Name)
+	}
+	if s.Method != http.MethodPut && s.Method != http.MethodPost {
+		return nil, fmt.Errorf("HTTPResource: Method of HTTP resource %s must be PUT or POST, not %s", r.Name, s.Method)
+	}
+	if s.Filename == "" {
+		u, err := url.Parse(s.URL)
+		if err != nil {
+			return nil, fmt.Errorf("HTTPResource: Invalid URL of HTTP resource %s: %w", r.Name, err)
+		}
+		if s.Filename = u.Path[strings.LastIndex(u.Path, "/")+1:]; s.Filename == "" {
+			return nil, fmt.Errorf("HTTPResource: Need Filename to be specified for HTTP resource %s, whose URL doesn't end with one", r.Name)
+		}
+	}
+	// The file must stay in the directory of the resource.
+	if s.Filename == "." || s.Filename == ".." || strings.Contains(s.Filename, "/") {
+		return nil, fmt.Errorf("HTTPResource: Filename of HTTP resource %s must be the name of a file, not %q", r.Name, s.Filename)
+	}
+	return s, nil
+}
+
+// splitHeaders returns the non-empty lines of headers.
+func splitHeaders(headers string) []string {
+	var lines []string
+	for _, line := range strings.Split(headers, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// GetName returns the name of the resource
+func (s HTTPResource) GetName() string {
+	return s.Name
+}
+
+// GetType returns the type of the resource, in this case "http"
+func (s HTTPResource) GetType() PipelineResourceType {
+	return PipelineResourceTypeHTTP
+}
+
+// Replacements is used for template replacement on an HTTPResource inside of a Taskrun.
+func (s *HTTPResource) Replacements() map[string]string {
+	return map[string]string{
+		"name":     s.Name,
+		"type":     string(s.Type),
+		"url":      s.URL,
+		"filename": s.Filename,
+	}
+}
+
+// headerArgsAndEnv returns the curl arguments sending the headers of the
+// resource, and the env vars they read the secret values from. The values of
+// the secrets are expanded by the kubelet, so that they don't appear in the
+// Pod.
+func (s *HTTPResource) headerArgsAndEnv() ([]string, []corev1.EnvVar) {
+	var args []string
+	for _, h := range s.Headers {
+		args = append(args, "--header", h)
+	}
+	var envVars []corev1.EnvVar
+	for i, sec := range s.Secrets {
+		name := fmt.Sprintf("HTTP_HEADER_%d", i)
+		envVars = append(envVars, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: sec.SecretName,
+					},
+					Key: sec.SecretKey,
+				},
+			},
+		})
+		args = append(args, "--header", fmt.Sprintf("%s: $(%s)", sec.FieldName, name))
+	}
+	return args, envVars
+}
+
+// GetInputTaskModifier returns the TaskModifier to be used when this resource is an input.
+func (s *HTTPResource) GetInputTaskModifier(_ *TaskSpec, path string) (TaskModifier, error) {
+	if path == "" {
+		return nil, fmt.Errorf("HTTPResource: Expect Destination Directory param to be set %s", s.Name)
+	}
+	// Redirects aren't followed: curl would send the headers, secrets
+	// included, to whichever host the URL redirects to.
+	headerArgs, envVars := s.headerArgsAndEnv()
+	args := append([]string{"--fail", "--silent", "--show-error", "--output", filepath.Join(path, s.Filename)}, headerArgs...)
+	steps := []Step{
+		CreateDirStep(s.ShellImage, s.Name, path),
+		{Container: corev1.Container{
+			Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("fetch-%s", s.Name)),
+			Image:   s.CurlImage,
+			Command: []string{"curl"},
+			Args:    append(args, s.URL),
+			Env:     envVars,
+		}}}
+
+	return &InternalTaskModifier{
+		StepsToPrepend: steps,
+	}, nil
+}
+
+// GetOutputTaskModifier returns the TaskModifier to be used when this resource is an output.
+func (s *HTTPResource) GetOutputTaskModifier(_ *TaskSpec, path string) (TaskModifier, error) {
+	headerArgs, envVars := s.headerArgsAndEnv()
+	args := append([]string{"--fail", "--silent", "--show-error", "--request", s.Method, "--upload-file", filepath.Join(path, s.Filename)}, headerArgs...)
+	step := Step{Container: corev1.Container{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("upload-%s", s.Name)),
+		Image:   s.CurlImage,
+		Command: []string{"curl"},
+		Args:    append(args, s.URL),
+		Env:     envVars,
+	}}
+
+	return &InternalTaskModifier{
+		StepsToAppend: []Step{step},
+	}, nil
+}
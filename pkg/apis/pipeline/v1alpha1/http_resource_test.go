@@ -0,0 +1,235 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_Invalid_NewHTTPResource(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		pipelineResource *v1alpha1.PipelineResource
+	}{{
+		name: "wrong-resource-type",
+		pipelineResource: tb.PipelineResource("http-resource", "default",
+			tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeGit),
+		),
+	}, {
+		name: "no url",
+		pipelineResource: tb.PipelineResource("http-resource", "default",
+			tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeHTTP,
+				tb.PipelineResourceSpecParam("filename", "app.tar.gz"),
+			),
+		),
+	}, {
+		name: "unsupported method",
+		pipelineResource: tb.PipelineResource("http-resource", "default",
+			tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeHTTP,
+				tb.PipelineResourceSpecParam("url", "https://artifacts.example.com/app.tar.gz"),
+				tb.PipelineResourceSpecParam("method", "PATCH"),
+			),
+		),
+	}, {
+		name: "no filename",
+		pipelineResource: tb.PipelineResource("http-resource", "default",
+			tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeHTTP,
+				tb.PipelineResourceSpecParam("url", "https://artifacts.example.com/"),
+			),
+		),
+	}, {
+		name: "filename in another directory",
+		pipelineResource: tb.PipelineResource("http-resource", "default",
+			tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeHTTP,
+				tb.PipelineResourceSpecParam("url", "https://artifacts.example.com/app.tar.gz"),
+				tb.PipelineResourceSpecParam("filename", "../app.tar.gz"),
+			),
+		),
+	}, {
+		name: "filename derived from the url is the parent directory",
+		pipelineResource: tb.PipelineResource("http-resource", "default",
+			tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeHTTP,
+				tb.PipelineResourceSpecParam("url", "https://artifacts.example.com/app/.."),
+			),
+		),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := v1alpha1.NewHTTPResource(images, tc.pipelineResource); err == nil {
+				t.Error("Expected error creating HTTP resource")
+			}
+		})
+	}
+}
+
+func Test_Valid_NewHTTPResource(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		pipelineResource *v1alpha1.PipelineResource
+		want             *v1alpha1.HTTPResource
+	}{{
+		name: "defaults",
+		pipelineResource: tb.PipelineResource("http-resource", "default", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeHTTP,
+			tb.PipelineResourceSpecParam("URL", "https://artifacts.example.com/releases/app.tar.gz?version=1"),
+		)),
+		want: &v1alpha1.HTTPResource{
+			Name:       "http-resource",
+			Type:       v1alpha1.PipelineResourceTypeHTTP,
+			URL:        "https://artifacts.example.com/releases/app.tar.gz?version=1",
+			Filename:   "app.tar.gz",
+			Method:     "PUT",
+			ShellImage: "busybox",
+			CurlImage:  "curlimages/curl",
+		},
+	}, {
+		name: "all params",
+		pipelineResource: tb.PipelineResource("http-resource", "default", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeHTTP,
+			tb.PipelineResourceSpecParam("url", "https://artifacts.example.com/upload"),
+			tb.PipelineResourceSpecParam("filename", "report.json"),
+			tb.PipelineResourceSpecParam("method", "post"),
+			tb.PipelineResourceSpecParam("headers", "Content-Type: application/json\n\nX-Build: 42\n"),
+			tb.PipelineResourceSpecSecretParam("Authorization", "artifacts-token", "header"),
+		)),
+		want: &v1alpha1.HTTPResource{
+			Name:     "http-resource",
+			Type:     v1alpha1.PipelineResourceTypeHTTP,
+			URL:      "https://artifacts.example.com/upload",
+			Filename: "report.json",
+			Method:   "POST",
+			Headers:  []string{"Content-Type: application/json", "X-Build: 42"},
+			Secrets: []v1alpha1.SecretParam{{
+				FieldName:  "Authorization",
+				SecretName: "artifacts-token",
+				SecretKey:  "header",
+			}},
+			ShellImage: "busybox",
+			CurlImage:  "curlimages/curl",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := v1alpha1.NewHTTPResource(images, tc.pipelineResource)
+			if err != nil {
+				t.Fatalf("Unexpected error creating HTTP resource: %s", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Mismatch of HTTP resource: %s", d)
+			}
+		})
+	}
+}
+
+func Test_HTTPGetReplacements(t *testing.T) {
+	httpResource := &v1alpha1.HTTPResource{
+		Name:     "http-resource",
+		Type:     v1alpha1.PipelineResourceTypeHTTP,
+		URL:      "https://artifacts.example.com/app.tar.gz",
+		Filename: "app.tar.gz",
+	}
+	expectedReplacementMap := map[string]string{
+		"name":     "http-resource",
+		"type":     "http",
+		"url":      "https://artifacts.example.com/app.tar.gz",
+		"filename": "app.tar.gz",
+	}
+	if d := cmp.Diff(expectedReplacementMap, httpResource.Replacements()); d != "" {
+		t.Errorf("HTTP Replacement map mismatch: %s", d)
+	}
+}
+
+var httpResourceWithSecret = &v1alpha1.HTTPResource{
+	Name:     "http-valid",
+	URL:      "https://artifacts.example.com/app.tar.gz",
+	Filename: "app.tar.gz",
+	Method:   "POST",
+	Headers:  []string{"X-Build: 42"},
+	Secrets: []v1alpha1.SecretParam{{
+		FieldName:  "Authorization",
+		SecretName: "artifacts-token",
+		SecretKey:  "header",
+	}},
+	ShellImage: "busybox",
+	CurlImage:  "curlimages/curl",
+}
+
+var httpSecretEnv = []corev1.EnvVar{{
+	Name: "HTTP_HEADER_0",
+	ValueFrom: &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "artifacts-token"},
+			Key:                  "header",
+		},
+	},
+}}
+
+func Test_HTTPGetInputTaskModifier(t *testing.T) {
+	names.TestingSeed()
+	want := []v1alpha1.Step{{Container: corev1.Container{
+		Name:    "create-dir-http-valid-9l9zj",
+		Image:   "busybox",
+		Command: []string{"mkdir", "-p", "/workspace/app"},
+	}}, {Container: corev1.Container{
+		Name:    "fetch-http-valid-mz4c7",
+		Image:   "curlimages/curl",
+		Command: []string{"curl"},
+		Args: []string{
+			"--fail", "--silent", "--show-error", "--output", "/workspace/app/app.tar.gz",
+			"--header", "X-Build: 42",
+			"--header", "Authorization: $(HTTP_HEADER_0)",
+			"https://artifacts.example.com/app.tar.gz",
+		},
+		Env: httpSecretEnv,
+	}}}
+
+	got, err := httpResourceWithSecret.GetInputTaskModifier(&v1alpha1.TaskSpec{}, "/workspace/app")
+	if err != nil {
+		t.Fatalf("GetInputTaskModifier() = %v", err)
+	}
+	if d := cmp.Diff(want, got.GetStepsToPrepend()); d != "" {
+		t.Errorf("Error mismatch between download containers spec: %s", d)
+	}
+}
+
+func Test_HTTPGetOutputTaskModifier(t *testing.T) {
+	names.TestingSeed()
+	want := []v1alpha1.Step{{Container: corev1.Container{
+		Name:    "upload-http-valid-9l9zj",
+		Image:   "curlimages/curl",
+		Command: []string{"curl"},
+		Args: []string{
+			"--fail", "--silent", "--show-error", "--request", "POST", "--upload-file", "/workspace/output/app/app.tar.gz",
+			"--header", "X-Build: 42",
+			"--header", "Authorization: $(HTTP_HEADER_0)",
+			"https://artifacts.example.com/app.tar.gz",
+		},
+		Env: httpSecretEnv,
+	}}}
+
+	got, err := httpResourceWithSecret.GetOutputTaskModifier(&v1alpha1.TaskSpec{}, "/workspace/output/app")
+	if err != nil {
+		t.Fatalf("GetOutputTaskModifier() = %v", err)
+	}
+	if d := cmp.Diff(want, got.GetStepsToAppend()); d != "" {
+		t.Errorf("Error mismatch between upload containers spec: %s", d)
+	}
+}
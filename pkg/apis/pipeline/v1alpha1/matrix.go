@@ -0,0 +1,58 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "github.com/tektoncd/pipeline/pkg/apis/config"
+
+// MaxMatrixCombinations is the maximum number of TaskRuns a PipelineTask can
+// be fanned out into with its matrix, unless maximum-matrix-combinations is
+// set in config-defaults.
+const MaxMatrixCombinations = config.DefaultMaximumMatrixCombinations
+
+// MatrixCombinations returns the combinations of the values of the matrix
+// params of the PipelineTask, as string params, varying the last matrix
+// param the fastest. It returns nil if the PipelineTask has no matrix.
+func (pt PipelineTask) MatrixCombinations() [][]Param {
+	if len(pt.Matrix) == 0 {
+		return nil
+	}
+	combinations := [][]Param{{}}
+	for _, m := range pt.Matrix {
+		var next [][]Param
+		for _, c := range combinations {
+			for _, v := range m.Value.ArrayVal {
+				combination := append(append([]Param{}, c...), Param{
+					Name:  m.Name,
+					Value: ArrayOrString{Type: ParamTypeString, StringVal: v},
+				})
+				next = append(next, combination)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// MatrixCombinationsCount returns the number of TaskRuns the matrix of the
+// PipelineTask fans it out into.
+func (pt PipelineTask) MatrixCombinationsCount() int {
+	count := 1
+	for _, m := range pt.Matrix {
+		count *= len(m.Value.ArrayVal)
+	}
+	return count
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+)
+
+func TestPipelineTask_MatrixCombinations(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		matrix []v1alpha1.Param
+		want   [][]v1alpha1.Param
+	}{{
+		name: "no matrix",
+	}, {
+		name: "one param",
+		matrix: []v1alpha1.Param{{
+			Name:  "platform",
+			Value: *tb.ArrayOrString("linux", "mac"),
+		}},
+		want: [][]v1alpha1.Param{
+			{{Name: "platform", Value: *tb.ArrayOrString("linux")}},
+			{{Name: "platform", Value: *tb.ArrayOrString("mac")}},
+		},
+	}, {
+		name: "two params",
+		matrix: []v1alpha1.Param{{
+			Name:  "platform",
+			Value: *tb.ArrayOrString("linux", "mac"),
+		}, {
+			Name:  "arch",
+			Value: *tb.ArrayOrString("amd64", "arm64"),
+		}},
+		want: [][]v1alpha1.Param{
+			{{Name: "platform", Value: *tb.ArrayOrString("linux")}, {Name: "arch", Value: *tb.ArrayOrString("amd64")}},
+			{{Name: "platform", Value: *tb.ArrayOrString("linux")}, {Name: "arch", Value: *tb.ArrayOrString("arm64")}},
+			{{Name: "platform", Value: *tb.ArrayOrString("mac")}, {Name: "arch", Value: *tb.ArrayOrString("amd64")}},
+			{{Name: "platform", Value: *tb.ArrayOrString("mac")}, {Name: "arch", Value: *tb.ArrayOrString("arm64")}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pt := v1alpha1.PipelineTask{Name: "build", Matrix: tc.matrix}
+			if d := cmp.Diff(tc.want, pt.MatrixCombinations()); d != "" {
+				t.Errorf("MatrixCombinations() diff -want, +got: %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// mavenUsernameFieldName and mavenPasswordFieldName are the field names
+	// of the secrets of a maven resource holding its credentials.
+	mavenUsernameFieldName = "username"
+	mavenPasswordFieldName = "password"
+)
+
+// MavenResource is an artifact of a Maven repository, downloaded from it when
+// it is an input of a Task, and deployed to it when it is an output.
+type MavenResource struct {
+	Name string               `json:"name"`
+	Type PipelineResourceType `json:"type"`
+	// Repository is the URL of the Maven repository.
+	Repository string `json:"repository"`
+	GroupID    string `json:"groupId"`
+	ArtifactID string `json:"artifactId"`
+	Version    string `json:"version"`
+	// Packaging is the extension of the artifact, jar by default.
+	Packaging  string `json:"packaging"`
+	Classifier string `json:"classifier"`
+	// Secrets hold the username and the password authenticating to the
+	// repository, with the field names "username" and "password".
+	Secrets []SecretParam `json:"secrets"`
+
+	ShellImage string `json:"-"`
+	CurlImage  string `json:"-"`
+}
+
+// NewMavenResource creates a new Maven resource to pass to a Task
+func NewMavenResource(images pipeline.Images, r *PipelineResource) (*MavenResource, error) {
+	if r.Spec.Type != PipelineResourceTypeMaven {
+		return nil, fmt.Errorf("MavenResource: Cannot create a Maven resource from a %s Pipeline Resource", r.Spec.Type)
+	}
+	s := &MavenResource{
+		Name:       r.Name,
+		Type:       r.Spec.Type,
+		Packaging:  "jar",
+		Secrets:    r.Spec.SecretParams,
+		ShellImage: images.ShellImage,
+		CurlImage:  images.CurlImage,
+	}
+	for _, param := range r.Spec.Params {
+		switch {
+		case strings.EqualFold(param.Name, "Repository"):
+			s.Repository = strings.TrimSuffix(param.Value, "/")
+		case strings.EqualFold(param.Name, "GroupID"):
+			s.GroupID = param.Value
+		case strings.EqualFold(param.Name, "ArtifactID"):
+			s.ArtifactID = param.Value
+		case strings.EqualFold(param.Name, "Version"):
+			s.Version = param.Value
+		case strings.EqualFold(param.Name, "Packaging"):
+			s.Packaging = param.Value
+		case strings.EqualFold(param.Name, "Classifier"):
+			s.Classifier = param.Value
+		}
+	}
+
+	for _, field := range [][2]string{
+		{"Repository", s.Repository},
+		{"GroupID", s.GroupID},
+		{"ArtifactID", s.ArtifactID},
+		{"Version", s.Version},
+		{"Packaging", s.Packaging},
+	} {
+		if field[1] == "" {
+			return nil, fmt.Errorf("MavenResource: Need %s to be specified in order to create Maven resource %s", field[0], r.Name)
+		}
+	}
+	if err := validateMavenSecrets(s.Secrets); err != nil {
+		return nil, fmt.Errorf("MavenResource: Invalid secrets of Maven resource %s: %w", r.Name, err)
+	}
+	return s, nil
+}
+
+// validateMavenSecrets checks that the secrets of a maven resource are
+// either none, or its username and its password.
+func validateMavenSecrets(secrets []SecretParam) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+	fields := map[string]struct{}{}
+	for _, sec := range secrets {
+		if sec.FieldName != mavenUsernameFieldName && sec.FieldName != mavenPasswordFieldName {
+			return fmt.Errorf("the field name %s should be %s or %s", sec.FieldName, mavenUsernameFieldName, mavenPasswordFieldName)
+		}
+		fields[sec.FieldName] = struct{}{}
+	}
+	if len(fields) != 2 || len(secrets) != 2 {
+		return fmt.Errorf("expected one %s and one %s", mavenUsernameFieldName, mavenPasswordFieldName)
+	}
+	return nil
+}
+
+// GetName returns the name of the resource
+func (s MavenResource) GetName() string {
+	return s.Name
+}
+
+// GetType returns the type of the resource, in this case "maven"
+func (s MavenResource) GetType() PipelineResourceType {
+	return PipelineResourceTypeMaven
+}
+
+// Filename returns the name of the artifact in the repository, and in the
+// directory of the resource.
+func (s *MavenResource) Filename() string {
+	name := s.ArtifactID + "-" + s.Version
+	if s.Classifier != "" {
+		name += "-" + s.Classifier
+	}
+	return name + "." + s.Packaging
+}
+
+// URL returns the URL of the artifact, in the layout of Maven repositories.
+func (s *MavenResource) URL() string {
+	return strings.Join([]string{s.Repository, strings.ReplaceAll(s.GroupID, ".", "/"), s.ArtifactID, s.Version, s.Filename()}, "/")
+}
+
+// Replacements is used for template replacement on a MavenResource inside of a Taskrun.
+func (s *MavenResource) Replacements() map[string]string {
+	return map[string]string{
+		"name":       s.Name,
+		"type":       string(s.Type),
+		"repository": s.Repository,
+		"groupId":    s.GroupID,
+		"artifactId": s.ArtifactID,
+		"version":    s.Version,
+		"packaging":  s.Packaging,
+		"classifier": s.Classifier,
+		"filename":   s.Filename(),
+		"url":        s.URL(),
+	}
+}
+
+// credentialsArgsAndEnv returns the curl arguments authenticating to the
+// repository, and the env vars they read the credentials from. The values of
+// the secrets are expanded by the kubelet, so that they don't appear in the
+// Pod.
+func (s *MavenResource) credentialsArgsAndEnv() ([]string, []corev1.EnvVar) {
+	if len(s.Secrets) == 0 {
+		return nil, nil
+	}
+	var envVars []corev1.EnvVar
+	credentials := map[string]string{}
+	for _, sec := range s.Secrets {
+		name := "MAVEN_" + strings.ToUpper(sec.FieldName)
+		credentials[sec.FieldName] = fmt.Sprintf("$(%s)", name)
+		envVars = append(envVars, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: sec.SecretName,
+					},
+					Key: sec.SecretKey,
+				},
+			},
+		})
+	}
+	return []string{"--user", credentials[mavenUsernameFieldName] + ":" + credentials[mavenPasswordFieldName]}, envVars
+}
+
+// GetInputTaskModifier returns the TaskModifier to be used when this resource is an input.
+func (s *MavenResource) GetInputTaskModifier(_ *TaskSpec, path string) (TaskModifier, error) {
+	if path == "" {
+		return nil, fmt.Errorf("MavenResource: Expect Destination Directory param to be set %s", s.Name)
+	}
+	credentialsArgs, envVars := s.credentialsArgsAndEnv()
+	args := append([]string{"--fail", "--silent", "--show-error", "--location", "--output", filepath.Join(path, s.Filename())}, credentialsArgs...)
+	steps := []Step{
+		CreateDirStep(s.ShellImage, s.Name, path),
+		{Container: corev1.Container{
+			Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("fetch-%s", s.Name)),
+			Image:   s.CurlImage,
+			Command: []string{"curl"},
+			Args:    append(args, s.URL()),
+			Env:     envVars,
+		}}}
+
+	return &InternalTaskModifier{
+		StepsToPrepend: steps,
+	}, nil
+}
+
+// GetOutputTaskModifier returns the TaskModifier to be used when this resource is an output.
+func (s *MavenResource) GetOutputTaskModifier(_ *TaskSpec, path string) (TaskModifier, error) {
+	credentialsArgs, envVars := s.credentialsArgsAndEnv()
+	args := append([]string{"--fail", "--silent", "--show-error", "--upload-file", filepath.Join(path, s.Filename())}, credentialsArgs...)
+	step := Step{Container: corev1.Container{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("deploy-%s", s.Name)),
+		Image:   s.CurlImage,
+		Command: []string{"curl"},
+		Args:    append(args, s.URL()),
+		Env:     envVars,
+	}}
+
+	return &InternalTaskModifier{
+		StepsToAppend: []Step{step},
+	}, nil
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_Invalid_NewMavenResource(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		pipelineResource *v1alpha1.PipelineResource
+	}{{
+		name: "wrong-resource-type",
+		pipelineResource: tb.PipelineResource("maven-resource", "default",
+			tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeGit),
+		),
+	}, {
+		name: "no repository",
+		pipelineResource: tb.PipelineResource("maven-resource", "default",
+			tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeMaven,
+				tb.PipelineResourceSpecParam("groupId", "com.example"),
+				tb.PipelineResourceSpecParam("artifactId", "app"),
+				tb.PipelineResourceSpecParam("version", "1.0.0"),
+			),
+		),
+	}, {
+		name: "no version",
+		pipelineResource: tb.PipelineResource("maven-resource", "default",
+			tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeMaven,
+				tb.PipelineResourceSpecParam("repository", "https://repo.example.com/releases"),
+				tb.PipelineResourceSpecParam("groupId", "com.example"),
+				tb.PipelineResourceSpecParam("artifactId", "app"),
+			),
+		),
+	}, {
+		name: "password without username",
+		pipelineResource: tb.PipelineResource("maven-resource", "default",
+			tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeMaven,
+				tb.PipelineResourceSpecParam("repository", "https://repo.example.com/releases"),
+				tb.PipelineResourceSpecParam("groupId", "com.example"),
+				tb.PipelineResourceSpecParam("artifactId", "app"),
+				tb.PipelineResourceSpecParam("version", "1.0.0"),
+				tb.PipelineResourceSpecSecretParam("password", "maven-credentials", "password"),
+			),
+		),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := v1alpha1.NewMavenResource(images, tc.pipelineResource); err == nil {
+				t.Error("Expected error creating Maven resource")
+			}
+		})
+	}
+}
+
+func Test_Valid_NewMavenResource(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		pipelineResource *v1alpha1.PipelineResource
+		want             *v1alpha1.MavenResource
+	}{{
+		name: "defaults",
+		pipelineResource: tb.PipelineResource("maven-resource", "default", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeMaven,
+			tb.PipelineResourceSpecParam("Repository", "https://repo.example.com/releases/"),
+			tb.PipelineResourceSpecParam("groupId", "com.example"),
+			tb.PipelineResourceSpecParam("artifactId", "app"),
+			tb.PipelineResourceSpecParam("version", "1.0.0"),
+		)),
+		want: &v1alpha1.MavenResource{
+			Name:       "maven-resource",
+			Type:       v1alpha1.PipelineResourceTypeMaven,
+			Repository: "https://repo.example.com/releases",
+			GroupID:    "com.example",
+			ArtifactID: "app",
+			Version:    "1.0.0",
+			Packaging:  "jar",
+			ShellImage: "busybox",
+			CurlImage:  "curlimages/curl",
+		},
+	}, {
+		name: "all params",
+		pipelineResource: tb.PipelineResource("maven-resource", "default", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeMaven,
+			tb.PipelineResourceSpecParam("repository", "https://repo.example.com/releases"),
+			tb.PipelineResourceSpecParam("groupId", "com.example"),
+			tb.PipelineResourceSpecParam("artifactId", "app"),
+			tb.PipelineResourceSpecParam("version", "1.0.0"),
+			tb.PipelineResourceSpecParam("packaging", "war"),
+			tb.PipelineResourceSpecParam("classifier", "sources"),
+			tb.PipelineResourceSpecSecretParam("username", "maven-credentials", "username"),
+			tb.PipelineResourceSpecSecretParam("password", "maven-credentials", "password"),
+		)),
+		want: &v1alpha1.MavenResource{
+			Name:       "maven-resource",
+			Type:       v1alpha1.PipelineResourceTypeMaven,
+			Repository: "https://repo.example.com/releases",
+			GroupID:    "com.example",
+			ArtifactID: "app",
+			Version:    "1.0.0",
+			Packaging:  "war",
+			Classifier: "sources",
+			Secrets: []v1alpha1.SecretParam{{
+				FieldName:  "username",
+				SecretName: "maven-credentials",
+				SecretKey:  "username",
+			}, {
+				FieldName:  "password",
+				SecretName: "maven-credentials",
+				SecretKey:  "password",
+			}},
+			ShellImage: "busybox",
+			CurlImage:  "curlimages/curl",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := v1alpha1.NewMavenResource(images, tc.pipelineResource)
+			if err != nil {
+				t.Fatalf("Unexpected error creating Maven resource: %s", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Mismatch of Maven resource: %s", d)
+			}
+		})
+	}
+}
+
+func Test_MavenGetReplacements(t *testing.T) {
+	mavenResource := &v1alpha1.MavenResource{
+		Name:       "maven-resource",
+		Type:       v1alpha1.PipelineResourceTypeMaven,
+		Repository: "https://repo.example.com/releases",
+		GroupID:    "com.example.apps",
+		ArtifactID: "app",
+		Version:    "1.0.0",
+		Packaging:  "jar",
+		Classifier: "sources",
+	}
+	expectedReplacementMap := map[string]string{
+		"name":       "maven-resource",
+		"type":       "maven",
+		"repository": "https://repo.example.com/releases",
+		"groupId":    "com.example.apps",
+		"artifactId": "app",
+		"version":    "1.0.0",
+		"packaging":  "jar",
+		"classifier": "sources",
+		"filename":   "app-1.0.0-sources.jar",
+		"url":        "https://repo.example.com/releases/com/example/apps/app/1.0.0/app-1.0.0-sources.jar",
+	}
+	if d := cmp.Diff(expectedReplacementMap, mavenResource.Replacements()); d != "" {
+		t.Errorf("Maven Replacement map mismatch: %s", d)
+	}
+}
+
+var mavenResourceWithSecrets = &v1alpha1.MavenResource{
+	Name:       "maven-valid",
+	Repository: "https://repo.example.com/releases",
+	GroupID:    "com.example",
+	ArtifactID: "app",
+	Version:    "1.0.0",
+	Packaging:  "jar",
+	Secrets: []v1alpha1.SecretParam{{
+		FieldName:  "username",
+		SecretName: "maven-credentials",
+		SecretKey:  "user",
+	}, {
+		FieldName:  "password",
+		SecretName: "maven-credentials",
+		SecretKey:  "token",
+	}},
+	ShellImage: "busybox",
+	CurlImage:  "curlimages/curl",
+}
+
+var mavenSecretEnv = []corev1.EnvVar{{
+	Name: "MAVEN_USERNAME",
+	ValueFrom: &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "maven-credentials"},
+			Key:                  "user",
+		},
+	},
+}, {
+	Name: "MAVEN_PASSWORD",
+	ValueFrom: &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "maven-credentials"},
+			Key:                  "token",
+		},
+	},
+}}
+
+func Test_MavenGetInputTaskModifier(t *testing.T) {
+	names.TestingSeed()
+	want := []v1alpha1.Step{{Container: corev1.Container{
+		Name:    "create-dir-maven-valid-9l9zj",
+		Image:   "busybox",
+		Command: []string{"mkdir", "-p", "/workspace/app"},
+	}}, {Container: corev1.Container{
+		Name:    "fetch-maven-valid-mz4c7",
+		Image:   "curlimages/curl",
+		Command: []string{"curl"},
+		Args: []string{
+			"--fail", "--silent", "--show-error", "--location", "--output", "/workspace/app/app-1.0.0.jar",
+			"--user", "$(MAVEN_USERNAME):$(MAVEN_PASSWORD)",
+			"https://repo.example.com/releases/com/example/app/1.0.0/app-1.0.0.jar",
+		},
+		Env: mavenSecretEnv,
+	}}}
+
+	got, err := mavenResourceWithSecrets.GetInputTaskModifier(&v1alpha1.TaskSpec{}, "/workspace/app")
+	if err != nil {
+		t.Fatalf("GetInputTaskModifier() = %v", err)
+	}
+	if d := cmp.Diff(want, got.GetStepsToPrepend()); d != "" {
+		t.Errorf("Error mismatch between download containers spec: %s", d)
+	}
+}
+
+func Test_MavenGetOutputTaskModifier(t *testing.T) {
+	names.TestingSeed()
+	want := []v1alpha1.Step{{Container: corev1.Container{
+		Name:    "deploy-maven-valid-9l9zj",
+		Image:   "curlimages/curl",
+		Command: []string{"curl"},
+		Args: []string{
+			"--fail", "--silent", "--show-error", "--upload-file", "/workspace/output/app/app-1.0.0.jar",
+			"--user", "$(MAVEN_USERNAME):$(MAVEN_PASSWORD)",
+			"https://repo.example.com/releases/com/example/app/1.0.0/app-1.0.0.jar",
+		},
+		Env: mavenSecretEnv,
+	}}}
+
+	got, err := mavenResourceWithSecrets.GetOutputTaskModifier(&v1alpha1.TaskSpec{}, "/workspace/output/app")
+	if err != nil {
+		t.Fatalf("GetOutputTaskModifier() = %v", err)
+	}
+	if d := cmp.Diff(want, got.GetStepsToAppend()); d != "" {
+		t.Errorf("Error mismatch between deploy containers spec: %s", d)
+	}
+}
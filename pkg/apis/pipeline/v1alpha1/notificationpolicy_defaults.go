@@ -0,0 +1,40 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// DefaultNotificationMessage is the message of the NotificationPolicies that
+// don't specify one.
+const DefaultNotificationMessage = "PipelineRun $(pipelineRun.namespace)/$(pipelineRun.name) $(pipelineRun.outcome) in $(pipelineRun.duration)"
+
+var _ apis.Defaultable = (*NotificationPolicy)(nil)
+
+func (np *NotificationPolicy) SetDefaults(ctx context.Context) {
+	defer recordDefaultedFields(ctx, np)()
+	np.Spec.SetDefaults(ctx)
+}
+
+func (nps *NotificationPolicySpec) SetDefaults(ctx context.Context) {
+	if nps.Message == "" {
+		nps.Message = DefaultNotificationMessage
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotificationPolicy sends a notification to a sink when the PipelineRuns it
+// selects complete.
+// +k8s:openapi-gen=true
+type NotificationPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the NotificationPolicy from the client
+	// +optional
+	Spec NotificationPolicySpec `json:"spec"`
+}
+
+// NotificationPolicySpec defines the desired state of the NotificationPolicy
+type NotificationPolicySpec struct {
+	// Selector selects the PipelineRuns to notify about. An empty selector
+	// selects every PipelineRun the NotificationPolicy applies to.
+	// +optional
+	Selector NotificationSelector `json:"selector,omitempty"`
+
+	// Sink is where the notifications are sent.
+	Sink NotificationSink `json:"sink"`
+
+	// Message is the template of the notifications. It can refer to the
+	// PipelineRun with variables such as $(pipelineRun.name) or
+	// $(tasks.<pipeline task>.results.<key>).
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// NotificationSelector selects PipelineRuns. A PipelineRun is selected if it
+// matches each of the non empty fields.
+type NotificationSelector struct {
+	// Pipelines are the names of the Pipelines whose PipelineRuns are
+	// selected.
+	// +optional
+	Pipelines []string `json:"pipelines,omitempty"`
+
+	// Namespaces are the namespaces whose PipelineRuns are selected. They only
+	// apply to NotificationPolicies in the namespace of the controller, the
+	// others only select PipelineRuns in their own namespace.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Outcomes are the outcomes of the PipelineRuns that are selected.
+	// +optional
+	Outcomes []NotificationOutcome `json:"outcomes,omitempty"`
+}
+
+// NotificationOutcome is how a PipelineRun completed.
+type NotificationOutcome string
+
+const (
+	// NotificationOutcomeSucceeded is the outcome of PipelineRuns that
+	// succeeded.
+	NotificationOutcomeSucceeded NotificationOutcome = "Succeeded"
+	// NotificationOutcomeFailed is the outcome of PipelineRuns that failed,
+	// including those that were cancelled or timed out.
+	NotificationOutcomeFailed NotificationOutcome = "Failed"
+)
+
+// NotificationSinkType is the kind of endpoint notifications are sent to.
+type NotificationSinkType string
+
+const (
+	// NotificationSinkTypeSlack sends the message to a Slack incoming webhook.
+	NotificationSinkTypeSlack NotificationSinkType = "slack"
+	// NotificationSinkTypeWebhook sends the message, along with the
+	// PipelineRun it is about, as JSON to a URL.
+	NotificationSinkTypeWebhook NotificationSinkType = "webhook"
+)
+
+// NotificationSink is where notifications are sent. Exactly one of URL and
+// URLSecretRef must be set.
+type NotificationSink struct {
+	// Type is the kind of endpoint the notifications are sent to.
+	Type NotificationSinkType `json:"type"`
+
+	// URL is the URL the notifications are sent to.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// URLSecretRef selects the key of a Secret, in the namespace of the
+	// NotificationPolicy, holding the URL the notifications are sent to.
+	// +optional
+	URLSecretRef *corev1.SecretKeySelector `json:"urlSecretRef,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotificationPolicyList contains a list of NotificationPolicies
+type NotificationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NotificationPolicy `json:"items"`
+}
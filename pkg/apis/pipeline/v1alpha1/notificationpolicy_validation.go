@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*NotificationPolicy)(nil)
+
+func (np *NotificationPolicy) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(np.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	return np.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (nps *NotificationPolicySpec) Validate(ctx context.Context) *apis.FieldError {
+	for i, o := range nps.Selector.Outcomes {
+		if o != NotificationOutcomeSucceeded && o != NotificationOutcomeFailed {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", o, NotificationOutcomeSucceeded, NotificationOutcomeFailed), fmt.Sprintf("selector.outcomes[%d]", i))
+		}
+	}
+	return nps.Sink.Validate(ctx).ViaField("sink")
+}
+
+func (ns *NotificationSink) Validate(ctx context.Context) *apis.FieldError {
+	switch ns.Type {
+	case NotificationSinkTypeSlack, NotificationSinkTypeWebhook:
+	case "":
+		return apis.ErrMissingField("type")
+	default:
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", ns.Type, NotificationSinkTypeSlack, NotificationSinkTypeWebhook), "type")
+	}
+
+	switch {
+	case ns.URL != "" && ns.URLSecretRef != nil:
+		return apis.ErrMultipleOneOf("url", "urlSecretRef")
+	case ns.URL == "" && ns.URLSecretRef == nil:
+		return apis.ErrMissingOneOf("url", "urlSecretRef")
+	case ns.URL != "":
+		if u, err := url.Parse(ns.URL); err != nil || !u.IsAbs() {
+			return apis.ErrInvalidValue(ns.URL, "url")
+		}
+	case ns.URLSecretRef.Name == "":
+		return apis.ErrMissingField("urlSecretRef.name")
+	case ns.URLSecretRef.Key == "":
+		return apis.ErrMissingField("urlSecretRef.key")
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestNotificationPolicy_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec v1alpha1.NotificationPolicySpec
+	}{{
+		name: "slack url",
+		spec: v1alpha1.NotificationPolicySpec{
+			Selector: v1alpha1.NotificationSelector{
+				Pipelines: []string{"release"},
+				Outcomes:  []v1alpha1.NotificationOutcome{v1alpha1.NotificationOutcomeFailed},
+			},
+			Sink: v1alpha1.NotificationSink{
+				Type: v1alpha1.NotificationSinkTypeSlack,
+				URL:  "https://hooks.slack.com/services/T0/B0/X",
+			},
+		},
+	}, {
+		name: "webhook url from secret",
+		spec: v1alpha1.NotificationPolicySpec{
+			Sink: v1alpha1.NotificationSink{
+				Type: v1alpha1.NotificationSinkTypeWebhook,
+				URLSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+					Key:                  "url",
+				},
+			},
+			Message: "$(pipelineRun.name) $(pipelineRun.outcome)",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			np := &v1alpha1.NotificationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "np", Namespace: "foo"},
+				Spec:       tc.spec,
+			}
+			if err := np.Validate(context.Background()); err != nil {
+				t.Errorf("NotificationPolicy.Validate() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestNotificationPolicy_Invalidate(t *testing.T) {
+	slack := v1alpha1.NotificationSink{
+		Type: v1alpha1.NotificationSinkTypeSlack,
+		URL:  "https://hooks.slack.com/services/T0/B0/X",
+	}
+	for _, tc := range []struct {
+		name          string
+		spec          v1alpha1.NotificationPolicySpec
+		expectedError apis.FieldError
+	}{{
+		name: "invalid outcome",
+		spec: v1alpha1.NotificationPolicySpec{
+			Selector: v1alpha1.NotificationSelector{
+				Outcomes: []v1alpha1.NotificationOutcome{v1alpha1.NotificationOutcomeSucceeded, "Cancelled"},
+			},
+			Sink: slack,
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: Cancelled should be Succeeded or Failed",
+			Paths:   []string{"spec.selector.outcomes[1]"},
+		},
+	}, {
+		name: "no sink type",
+		spec: v1alpha1.NotificationPolicySpec{
+			Sink: v1alpha1.NotificationSink{URL: "https://example.com"},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.sink.type"},
+		},
+	}, {
+		name: "invalid sink type",
+		spec: v1alpha1.NotificationPolicySpec{
+			Sink: v1alpha1.NotificationSink{Type: "email", URL: "https://example.com"},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: email should be slack or webhook",
+			Paths:   []string{"spec.sink.type"},
+		},
+	}, {
+		name: "no url",
+		spec: v1alpha1.NotificationPolicySpec{
+			Sink: v1alpha1.NotificationSink{Type: v1alpha1.NotificationSinkTypeWebhook},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got neither",
+			Paths:   []string{"spec.sink.url", "spec.sink.urlSecretRef"},
+		},
+	}, {
+		name: "url and url secret",
+		spec: v1alpha1.NotificationPolicySpec{
+			Sink: v1alpha1.NotificationSink{
+				Type: v1alpha1.NotificationSinkTypeWebhook,
+				URL:  "https://example.com",
+				URLSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+					Key:                  "url",
+				},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"spec.sink.url", "spec.sink.urlSecretRef"},
+		},
+	}, {
+		name: "relative url",
+		spec: v1alpha1.NotificationPolicySpec{
+			Sink: v1alpha1.NotificationSink{Type: v1alpha1.NotificationSinkTypeWebhook, URL: "/notify"},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: /notify",
+			Paths:   []string{"spec.sink.url"},
+		},
+	}, {
+		name: "url secret without key",
+		spec: v1alpha1.NotificationPolicySpec{
+			Sink: v1alpha1.NotificationSink{
+				Type: v1alpha1.NotificationSinkTypeWebhook,
+				URLSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+				},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.sink.urlSecretRef.key"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			np := &v1alpha1.NotificationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "np", Namespace: "foo"},
+				Spec:       tc.spec,
+			}
+			err := np.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an Error, got nothing for %v", tc)
+			}
+			if d := cmp.Diff(tc.expectedError, *err, cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("NotificationPolicy.Validate() errors diff -want, +got: %v", d)
+			}
+		})
+	}
+}
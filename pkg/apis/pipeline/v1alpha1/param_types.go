@@ -17,7 +17,11 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
+	"knative.dev/pkg/apis"
 )
 
 // ParamSpec defines arbitrary parameters needed beyond typed inputs (such as
@@ -25,6 +29,9 @@ import (
 // or PipelineRun.
 type ParamSpec = v1alpha2.ParamSpec
 
+// PropertySpec declares a key of an object parameter.
+type PropertySpec = v1alpha2.PropertySpec
+
 // ResourceParam declares a string value to use for the parameter called Name, and is used in
 // the specific context of PipelineResources.
 type ResourceParam = v1alpha2.ResourceParam
@@ -33,13 +40,15 @@ type ResourceParam = v1alpha2.ResourceParam
 type Param = v1alpha2.Param
 
 // ParamType indicates the type of an input parameter;
-// Used to distinguish between a single string and an array of strings.
+// Used to distinguish between a single string, an array of strings and an
+// object of strings.
 type ParamType = v1alpha2.ParamType
 
 // Valid ParamTypes:
 const (
 	ParamTypeString ParamType = v1alpha2.ParamTypeString
 	ParamTypeArray  ParamType = v1alpha2.ParamTypeArray
+	ParamTypeObject ParamType = v1alpha2.ParamTypeObject
 )
 
 // AllParamTypes can be used for ParamType validation.
@@ -47,7 +56,37 @@ var AllParamTypes = v1alpha2.AllParamTypes
 
 // ArrayOrString is modeled after IntOrString in kubernetes/apimachinery:
 
-// ArrayOrString is a type that can hold a single string or string array.
+// ArrayOrString is a type that can hold a single string, a string array or
+// an object of strings.
 // Used in JSON unmarshalling so that a single JSON field can accept
-// either an individual string or an array of strings.
+// either an individual string, an array of strings or an object.
 type ArrayOrString = v1alpha2.ArrayOrString
+
+// validateParamValues checks that the values of the params declared in specs
+// have their type and, for objects, their properties as keys, and are one of
+// their enum values.
+func validateParamValues(specs []ParamSpec, params []Param, path string) *apis.FieldError {
+	declared := map[string]ParamSpec{}
+	for _, s := range specs {
+		declared[s.Name] = s
+	}
+	for i, p := range params {
+		s, ok := declared[p.Name]
+		if !ok {
+			continue
+		}
+		if !s.Matches(p.Value) {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("value of param %q doesn't match its declared type %q", p.Name, s.Type),
+				Paths:   []string{fmt.Sprintf("%s[%d].value", path, i)},
+			}
+		}
+		if !s.Allows(p.Value) {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("value %q of param %q isn't one of its enum values %s", p.Value.StringVal, p.Name, strings.Join(s.Enum, ", ")),
+				Paths:   []string{fmt.Sprintf("%s[%d].value", path, i)},
+			}
+		}
+	}
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 var _ apis.Defaultable = (*Pipeline)(nil)
 
 func (p *Pipeline) SetDefaults(ctx context.Context) {
+	defer recordDefaultedFields(ctx, p)()
 	p.Spec.SetDefaults(ctx)
 }
 
@@ -28,9 +28,50 @@ type PipelineSpec struct {
 	Resources []PipelineDeclaredResource `json:"resources,omitempty"`
 	// Tasks declares the graph of Tasks that execute when this Pipeline is run.
 	Tasks []PipelineTask `json:"tasks,omitempty"`
+	// Finally declares the Tasks that execute in parallel once all of the
+	// Tasks are done, whether they succeeded or not.
+	// +optional
+	Finally []PipelineTask `json:"finally,omitempty"`
 	// Params declares a list of input parameters that must be supplied when
 	// this Pipeline is run.
 	Params []ParamSpec `json:"params,omitempty"`
+	// Workspaces declares the workspaces the PipelineRun binds, that the
+	// PipelineTasks pass to their Tasks.
+	// +optional
+	Workspaces []PipelineWorkspaceDeclaration `json:"workspaces,omitempty"`
+	// ChangeDetection designates one of the Tasks which runs first and
+	// reports whether there are changes for the other ones: they are all
+	// skipped when there aren't.
+	// +optional
+	ChangeDetection *PipelineChangeDetection `json:"changeDetection,omitempty"`
+	// ExpectedDuration is how long PipelineRuns are expected to run for. The
+	// ones running longer, even if they don't time out, are reported with a
+	// Warning event and a metric.
+	// +optional
+	ExpectedDuration *metav1.Duration `json:"expectedDuration,omitempty"`
+	// ParamsHook computes the values of params of the PipelineRuns, once
+	// when they start, from the values of the other ones.
+	// +optional
+	ParamsHook *PipelineParamsHook `json:"paramsHook,omitempty"`
+}
+
+// PipelineParamsHook is a webhook computing the values of params of
+// PipelineRuns before their tasks are resolved.
+type PipelineParamsHook struct {
+	// URL is the absolute URL the values of the params of a PipelineRun are
+	// POSTed to. It responds with the values of the params it computes.
+	URL string `json:"url"`
+}
+
+// PipelineChangeDetection designates the PipelineTask, and its result, which
+// detect whether a PipelineRun has changes to process.
+type PipelineChangeDetection struct {
+	// Task is the name of the PipelineTask, which can't depend on the other
+	// ones.
+	Task string `json:"task"`
+	// Result is the name of the result of the Task, "false" when there are no
+	// changes. Any other value runs the other Tasks.
+	Result string `json:"result"`
 }
 
 // Check that Pipeline may be validated and defaulted.
@@ -87,10 +128,24 @@ type PipelineTask struct {
 	// +optional
 	Conditions []PipelineTaskCondition `json:"conditions,omitempty"`
 
+	// WhenExpressions is a list of expressions that need to be true for the
+	// task to run. Unlike Conditions, they are evaluated without running Pods.
+	// +optional
+	WhenExpressions WhenExpressions `json:"when,omitempty"`
+
+	// Workspaces pass workspaces of the Pipeline to the Task.
+	// +optional
+	Workspaces []WorkspacePipelineTaskBinding `json:"workspaces,omitempty"`
+
 	// Retries represents how many times this task should be retried in case of task failure: ConditionSucceeded set to False
 	// +optional
 	Retries int `json:"retries,omitempty"`
 
+	// Timeout is the timeout of the TaskRun of this task, within the
+	// timeouts of the PipelineRun.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
 	// RunAfter is the list of PipelineTask names that should be executed before
 	// this Task executes. (Used to force a specific ordering in graph execution.)
 	// +optional
@@ -103,6 +158,12 @@ type PipelineTask struct {
 	// Parameters declares parameters passed to this task.
 	// +optional
 	Params []Param `json:"params,omitempty"`
+
+	// Matrix declares array parameters the task is fanned out over: it runs
+	// one TaskRun per combination of their values, each passed as a string
+	// parameter.
+	// +optional
+	Matrix []Param `json:"matrix,omitempty"`
 }
 
 func (pt PipelineTask) HashKey() string {
@@ -117,6 +178,12 @@ func (pt PipelineTask) Deps() []string {
 			deps = append(deps, rd.From...)
 		}
 	}
+	// The tasks whose results are used must run first.
+	for _, ref := range pt.ResultRefs() {
+		if !containsString(deps, ref.PipelineTask) {
+			deps = append(deps, ref.PipelineTask)
+		}
+	}
 	return deps
 }
 
@@ -217,6 +284,9 @@ type TaskRef struct {
 	// API version of the referent
 	// +optional
 	APIVersion string `json:"apiVersion,omitempty"`
+	// ResolverRef fetches the Task with a resolver instead of looking it up
+	// by Name.
+	ResolverRef `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -19,13 +19,16 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
@@ -43,7 +46,7 @@ func (p *Pipeline) Validate(ctx context.Context) *apis.FieldError {
 
 func validateDeclaredResources(ps *PipelineSpec) error {
 	required := []string{}
-	for _, t := range ps.Tasks {
+	for _, t := range append(append([]PipelineTask{}, ps.Tasks...), ps.Finally...) {
 		if t.Resources != nil {
 			for _, input := range t.Resources.Inputs {
 				required = append(required, input.Resource)
@@ -127,6 +130,11 @@ func (ps *PipelineSpec) Validate(ctx context.Context) *apis.FieldError {
 		return apis.ErrMissingField(apis.CurrentField)
 	}
 
+	// Runaway generated Pipelines are rejected before anything else
+	if err := validatePipelineSize(ctx, ps); err != nil {
+		return err
+	}
+
 	// Names cannot be duplicated
 	taskNames := map[string]struct{}{}
 	for i, t := range ps.Tasks {
@@ -135,15 +143,47 @@ func (ps *PipelineSpec) Validate(ctx context.Context) *apis.FieldError {
 		if errSlice := validation.IsQualifiedName(t.Name); len(errSlice) != 0 {
 			return apis.ErrInvalidValue(strings.Join(errSlice, ","), fmt.Sprintf("spec.tasks[%d].name", i))
 		}
-		// TaskRef name must be a valid k8s name
-		if errSlice := validation.IsQualifiedName(t.TaskRef.Name); len(errSlice) != 0 {
+		// TaskRef name must be a valid k8s name, unless a resolver fetches
+		// the Task
+		if t.TaskRef.Resolver != "" {
+			if err := t.TaskRef.validateResolver(); err != nil {
+				return err.ViaField("taskRef").ViaIndex(i).ViaField("spec.tasks")
+			}
+		} else if errSlice := validation.IsQualifiedName(t.TaskRef.Name); len(errSlice) != 0 {
 			return apis.ErrInvalidValue(strings.Join(errSlice, ","), fmt.Sprintf("spec.tasks[%d].taskRef.name", i))
 		}
+		if err := validateCustomTask(t); err != nil {
+			return err.ViaIndex(i).ViaField("spec.tasks")
+		}
+		if t.Retries < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", t.Retries), fmt.Sprintf("spec.tasks[%d].retries", i))
+		}
+		if t.Timeout != nil && t.Timeout.Duration < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", t.Timeout.Duration.String()), fmt.Sprintf("spec.tasks[%d].timeout", i))
+		}
+		if err := validateWhenExpressions(t.WhenExpressions); err != nil {
+			return err.ViaField("when").ViaIndex(i).ViaField("spec.tasks")
+		}
+		if err := validateMatrix(ctx, t); err != nil {
+			return err.ViaIndex(i).ViaField("spec.tasks")
+		}
 		if _, ok := taskNames[t.Name]; ok {
 			return apis.ErrMultipleOneOf(fmt.Sprintf("spec.tasks[%d].name", i))
 		}
 		taskNames[t.Name] = struct{}{}
 	}
+	for i, t := range ps.Finally {
+		if err := validateFinallyTask(t); err != nil {
+			return err.ViaIndex(i).ViaField("spec.finally")
+		}
+		if err := validateMatrix(ctx, t); err != nil {
+			return err.ViaIndex(i).ViaField("spec.finally")
+		}
+		if _, ok := taskNames[t.Name]; ok {
+			return apis.ErrMultipleOneOf(fmt.Sprintf("spec.finally[%d].name", i))
+		}
+		taskNames[t.Name] = struct{}{}
+	}
 
 	// All declared resources should be used, and the Pipeline shouldn't try to use any resources
 	// that aren't declared
@@ -156,22 +196,322 @@ func (ps *PipelineSpec) Validate(ctx context.Context) *apis.FieldError {
 		return apis.ErrInvalidValue(err.Error(), "spec.tasks.resources.inputs.from")
 	}
 
+	// The results used should be those of the tasks of the Pipeline
+	if err := validateResultRefs(ps.Tasks); err != nil {
+		return err.ViaField("spec.tasks")
+	}
+
 	// Validate the pipeline task graph
 	if err := validateGraph(ps.Tasks); err != nil {
 		return apis.ErrInvalidValue(err.Error(), "spec.tasks")
 	}
 
+	// A task fanned out by its matrix has no single value for its results
+	if err := validateMatrixResults(ps.Tasks); err != nil {
+		return err.ViaField("spec.tasks")
+	}
+
 	// The parameter variables should be valid
-	if err := validatePipelineParameterVariables(ps.Tasks, ps.Params); err != nil {
+	if err := validatePipelineParameterVariables(append(append([]PipelineTask{}, ps.Tasks...), ps.Finally...), ps.Params); err != nil {
+		return err
+	}
+
+	// The PipelineTasks should only use declared workspaces
+	if err := validatePipelineWorkspaces(ps.Workspaces, ps.Tasks, ps.Finally); err != nil {
 		return err
 	}
 
+	if ps.ChangeDetection != nil {
+		if err := validateChangeDetection(ps.ChangeDetection, ps.Tasks); err != nil {
+			return err.ViaField("spec.changeDetection")
+		}
+	}
+
+	if ps.ParamsHook != nil {
+		if u, err := url.Parse(ps.ParamsHook.URL); err != nil || !u.IsAbs() {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s isn't an absolute URL", ps.ParamsHook.URL), "spec.paramsHook.url")
+		}
+	}
+
+	if ps.ExpectedDuration != nil && ps.ExpectedDuration.Duration <= 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", ps.ExpectedDuration.Duration.String()), "spec.expectedDuration")
+	}
+
+	return nil
+}
+
+// validatePipelineSize checks that the Pipeline doesn't exceed the maximum
+// number of tasks, params and result references of config-defaults, which
+// protect etcd and the reconciler from generated Pipelines running away.
+func validatePipelineSize(ctx context.Context, ps *PipelineSpec) *apis.FieldError {
+	cfg := config.FromContextOrDefaults(ctx).Defaults
+	if count := len(ps.Tasks) + len(ps.Finally); cfg.MaximumPipelineTasks > 0 && count > cfg.MaximumPipelineTasks {
+		return exceedsMaximum(fmt.Sprintf("%d tasks", count), cfg.MaximumPipelineTasks, config.MaximumPipelineTasksKey, "spec.tasks")
+	}
+	if count := len(ps.Params); cfg.MaximumPipelineParams > 0 && count > cfg.MaximumPipelineParams {
+		return exceedsMaximum(fmt.Sprintf("%d params", count), cfg.MaximumPipelineParams, config.MaximumPipelineParamsKey, "spec.params")
+	}
+	if cfg.MaximumPipelineResultRefs > 0 {
+		count := 0
+		for _, t := range append(append([]PipelineTask{}, ps.Tasks...), ps.Finally...) {
+			count += len(t.ResultRefs())
+		}
+		if count > cfg.MaximumPipelineResultRefs {
+			return exceedsMaximum(fmt.Sprintf("%d references to results", count), cfg.MaximumPipelineResultRefs, config.MaximumPipelineResultRefsKey, "spec.tasks")
+		}
+	}
+	return nil
+}
+
+// exceedsMaximum returns the error of what exceeds the maximum of key in
+// config-defaults.
+func exceedsMaximum(what string, maximum int, key, path string) *apis.FieldError {
+	return &apis.FieldError{
+		Message: fmt.Sprintf("%s exceed the maximum of %d", what, maximum),
+		Paths:   []string{path},
+		Details: fmt.Sprintf("see %s in %s", key, config.DefaultsConfigName),
+	}
+}
+
+// validateChangeDetection checks that the change detection task is one of the
+// tasks, which can run first: it doesn't depend on the others, and its result
+// has a single value.
+func validateChangeDetection(cd *PipelineChangeDetection, tasks []PipelineTask) *apis.FieldError {
+	if cd.Task == "" {
+		return apis.ErrMissingField("task")
+	}
+	if cd.Result == "" {
+		return apis.ErrMissingField("result")
+	}
+	for _, t := range tasks {
+		if t.Name != cd.Task {
+			continue
+		}
+		if len(t.Deps()) > 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s depends on other tasks", t.Name), "task")
+		}
+		if len(t.Conditions) > 0 || len(t.WhenExpressions) > 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s can be skipped", t.Name), "task")
+		}
+		if len(t.Matrix) > 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s is fanned out by its matrix", t.Name), "task")
+		}
+		return nil
+	}
+	return apis.ErrInvalidValue(fmt.Sprintf("%s isn't one of the tasks", cd.Task), "task")
+}
+
+// validateFinallyTask checks that a finally task doesn't depend on the
+// other tasks: it runs once all of them are done, whether they succeeded or
+// not.
+func validateFinallyTask(t PipelineTask) *apis.FieldError {
+	if errSlice := validation.IsQualifiedName(t.Name); len(errSlice) != 0 {
+		return apis.ErrInvalidValue(strings.Join(errSlice, ","), "name")
+	}
+	if t.TaskRef.Resolver != "" {
+		if err := t.TaskRef.validateResolver(); err != nil {
+			return err.ViaField("taskRef")
+		}
+	} else if errSlice := validation.IsQualifiedName(t.TaskRef.Name); len(errSlice) != 0 {
+		return apis.ErrInvalidValue(strings.Join(errSlice, ","), "taskRef.name")
+	}
+	if err := validateCustomTask(t); err != nil {
+		return err
+	}
+	if t.Retries < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", t.Retries), "retries")
+	}
+	if t.Timeout != nil && t.Timeout.Duration < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", t.Timeout.Duration.String()), "timeout")
+	}
+	if len(t.RunAfter) > 0 {
+		return apis.ErrDisallowedFields("runAfter")
+	}
+	if len(t.Conditions) > 0 {
+		return apis.ErrDisallowedFields("conditions")
+	}
+	if len(t.WhenExpressions) > 0 {
+		return apis.ErrDisallowedFields("when")
+	}
+	if t.Resources != nil {
+		for j, input := range t.Resources.Inputs {
+			if len(input.From) > 0 {
+				return apis.ErrDisallowedFields("from").ViaIndex(j).ViaField("resources.inputs")
+			}
+		}
+	}
+	if len(t.ResultRefs()) > 0 {
+		return apis.ErrInvalidValue("finally tasks can't use the results of other PipelineTasks", "params")
+	}
+	return nil
+}
+
+// validateCustomTask checks that a PipelineTask referencing a custom task only
+// uses what its Run passes on: the controller of the custom task gets its
+// params, but no resources, workspaces or conditions of the Pipeline.
+func validateCustomTask(t PipelineTask) *apis.FieldError {
+	if !t.TaskRef.IsCustomTask() {
+		return nil
+	}
+	if t.TaskRef.Resolver != "" {
+		return apis.ErrMultipleOneOf("taskRef.apiVersion", "taskRef.resolver")
+	}
+	if t.Resources != nil {
+		return apis.ErrDisallowedFields("resources")
+	}
+	if len(t.Workspaces) > 0 {
+		return apis.ErrDisallowedFields("workspaces")
+	}
+	if len(t.Conditions) > 0 {
+		return apis.ErrDisallowedFields("conditions")
+	}
+	if len(t.Matrix) > 0 {
+		return apis.ErrDisallowedFields("matrix")
+	}
+	return nil
+}
+
+// validateMatrix checks that the matrix params of t are non-empty arrays, that
+// they don't shadow its params, and that they don't fan it out into too many
+// TaskRuns.
+func validateMatrix(ctx context.Context, t PipelineTask) *apis.FieldError {
+	if len(t.Matrix) == 0 {
+		return nil
+	}
+	if len(t.Conditions) > 0 {
+		return apis.ErrMultipleOneOf("conditions", "matrix")
+	}
+	names := map[string]struct{}{}
+	for _, p := range t.Params {
+		names[p.Name] = struct{}{}
+	}
+	for i, p := range t.Matrix {
+		if _, ok := names[p.Name]; ok {
+			return apis.ErrMultipleOneOf("name").ViaIndex(i).ViaField("matrix")
+		}
+		names[p.Name] = struct{}{}
+		if p.Value.Type != ParamTypeArray {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be an array", p.Value.Type), "value").ViaIndex(i).ViaField("matrix")
+		}
+		if len(p.Value.ArrayVal) == 0 {
+			return apis.ErrMissingField("value").ViaIndex(i).ViaField("matrix")
+		}
+	}
+	maximum := config.FromContextOrDefaults(ctx).Defaults.MaximumMatrixCombinations
+	if maximum == 0 {
+		maximum = MaxMatrixCombinations
+	}
+	if count := t.MatrixCombinationsCount(); count > maximum {
+		return exceedsMaximum(fmt.Sprintf("%d combinations", count), maximum, config.MaximumMatrixCombinationsKey, "matrix")
+	}
+	return nil
+}
+
+// validateMatrixResults checks that none of the tasks use the results of a
+// task fanned out by its matrix.
+func validateMatrixResults(tasks []PipelineTask) *apis.FieldError {
+	matrixTasks := map[string]struct{}{}
+	for _, t := range tasks {
+		if len(t.Matrix) > 0 {
+			matrixTasks[t.Name] = struct{}{}
+		}
+	}
+	for i, t := range tasks {
+		for _, ref := range t.ResultRefs() {
+			if _, ok := matrixTasks[ref.PipelineTask]; ok {
+				return apis.ErrInvalidValue(fmt.Sprintf("%s uses the results of %s, which is fanned out by its matrix", t.Name, ref.PipelineTask), "params").ViaIndex(i)
+			}
+		}
+	}
+	return nil
+}
+
+// validateResultRefs checks that the variables of the PipelineTasks starting
+// with tasks. are references to results, $(tasks.<pipeline task>.results.<result>),
+// of PipelineTasks of the Pipeline.
+func validateResultRefs(tasks []PipelineTask) *apis.FieldError {
+	names := map[string]struct{}{}
+	for _, t := range tasks {
+		names[t.Name] = struct{}{}
+	}
+	for i, t := range tasks {
+		err := validatePipelineTaskFields([]PipelineTask{t}, func(name, value string) *apis.FieldError {
+			for _, v := range taskVariableFormat.FindAllString(value, -1) {
+				match := resultRefFormat.FindStringSubmatch(v)
+				if match == nil || match[0] != v {
+					return apis.ErrInvalidValue(fmt.Sprintf("%s should be $(tasks.<pipeline task>.results.<result>)", v), name)
+				}
+				if _, ok := names[match[1]]; !ok {
+					return apis.ErrInvalidValue(fmt.Sprintf("%s references the result of a non-existent PipelineTask %s", v, match[1]), name)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err.ViaIndex(i)
+		}
+	}
+	return nil
+}
+
+func validatePipelineWorkspaces(workspaces []PipelineWorkspaceDeclaration, tasks, finally []PipelineTask) *apis.FieldError {
+	declared := map[string]struct{}{}
+	for i, w := range workspaces {
+		if err := validateWorkspaceName(w.Name); err != nil {
+			return err.ViaIndex(i).ViaField("spec.workspaces")
+		}
+		if _, ok := declared[w.Name]; ok {
+			return apis.ErrMultipleOneOf("name").ViaIndex(i).ViaField("spec.workspaces")
+		}
+		declared[w.Name] = struct{}{}
+	}
+	if err := validatePipelineTaskWorkspaces(declared, tasks); err != nil {
+		return err.ViaField("spec.tasks")
+	}
+	if err := validatePipelineTaskWorkspaces(declared, finally); err != nil {
+		return err.ViaField("spec.finally")
+	}
+	return nil
+}
+
+func validatePipelineTaskWorkspaces(declared map[string]struct{}, tasks []PipelineTask) *apis.FieldError {
+	for i, t := range tasks {
+		names := map[string]struct{}{}
+		for j, w := range t.Workspaces {
+			if w.Name == "" {
+				return apis.ErrMissingField("name").ViaIndex(j).ViaField("workspaces").ViaIndex(i)
+			}
+			if _, ok := names[w.Name]; ok {
+				return apis.ErrMultipleOneOf("name").ViaIndex(j).ViaField("workspaces").ViaIndex(i)
+			}
+			names[w.Name] = struct{}{}
+			if _, ok := declared[w.Workspace]; !ok {
+				return apis.ErrInvalidValue(fmt.Sprintf("%q is not a workspace of the Pipeline", w.Workspace), "workspace").ViaIndex(j).ViaField("workspaces").ViaIndex(i)
+			}
+		}
+	}
+	return nil
+}
+
+func validateWhenExpressions(wes WhenExpressions) *apis.FieldError {
+	for i, we := range wes {
+		if we.Input == "" {
+			return apis.ErrMissingField("input").ViaIndex(i)
+		}
+		if we.Operator != selection.In && we.Operator != selection.NotIn {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be one of %v", we.Operator, AllowedWhenOperators), "operator").ViaIndex(i)
+		}
+		if len(we.Values) == 0 {
+			return apis.ErrMissingField("values").ViaIndex(i)
+		}
+	}
 	return nil
 }
 
 func validatePipelineParameterVariables(tasks []PipelineTask, params []ParamSpec) *apis.FieldError {
 	parameterNames := map[string]struct{}{}
 	arrayParameterNames := map[string]struct{}{}
+	objectParameterKeys := map[string]map[string]struct{}{}
 
 	for _, p := range params {
 		// Verify that p is a valid type.
@@ -196,15 +536,71 @@ func validatePipelineParameterVariables(tasks []PipelineTask, params []ParamSpec
 				},
 			}
 		}
+		if err := p.ValidateProperties(); err != nil {
+			return err.ViaField(fmt.Sprintf("spec.params.%s", p.Name))
+		}
+		if err := p.ValidateEnum(); err != nil {
+			return err.ViaField(fmt.Sprintf("spec.params.%s", p.Name))
+		}
 
-		// Add parameter name to parameterNames, and to arrayParameterNames if type is array.
+		// Add parameter name to parameterNames, and to arrayParameterNames
+		// or objectParameterKeys if type is array or object.
 		parameterNames[p.Name] = struct{}{}
-		if p.Type == ParamTypeArray {
+		switch p.Type {
+		case ParamTypeArray:
 			arrayParameterNames[p.Name] = struct{}{}
+		case ParamTypeObject:
+			objectParameterKeys[p.Name] = map[string]struct{}{}
+			for k := range p.Properties {
+				objectParameterKeys[p.Name][k] = struct{}{}
+			}
 		}
 	}
 
-	return validatePipelineVariables(tasks, "params", parameterNames, arrayParameterNames)
+	if err := validatePipelineVariables(tasks, "params", parameterNames, arrayParameterNames); err != nil {
+		return err
+	}
+	return validatePipelineTaskFields(tasks, func(name, value string) *apis.FieldError {
+		if err := substitution.ValidateVariableStarred(name, value, "params", "", "task parameter", "pipelinespec.params", arrayParameterNames); err != nil {
+			return err
+		}
+		return substitution.ValidateObjectKeys(name, value, "params", "", "task parameter", "pipelinespec.params", objectParameterKeys)
+	})
+}
+
+// validatePipelineTaskFields calls validate with each of the values of the
+// params, matrix and when expressions of the tasks, by name, until it returns
+// an error.
+func validatePipelineTaskFields(tasks []PipelineTask, validate func(name, value string) *apis.FieldError) *apis.FieldError {
+	for _, task := range tasks {
+		var fields [][2]string
+		for _, param := range task.Params {
+			name := fmt.Sprintf("param[%s]", param.Name)
+			fields = append(fields, [2]string{name, param.Value.StringVal})
+			for _, v := range param.Value.ArrayVal {
+				fields = append(fields, [2]string{name, v})
+			}
+			for _, v := range param.Value.ObjectVal {
+				fields = append(fields, [2]string{name, v})
+			}
+		}
+		for _, param := range task.Matrix {
+			for _, v := range param.Value.ArrayVal {
+				fields = append(fields, [2]string{fmt.Sprintf("matrix[%s]", param.Name), v})
+			}
+		}
+		for _, we := range task.WhenExpressions {
+			for _, v := range append([]string{we.Input}, we.Values...) {
+				fields = append(fields, [2]string{"when", v})
+			}
+		}
+		for _, f := range fields {
+			if err := validate(f[0], f[1]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func validatePipelineVariables(tasks []PipelineTask, prefix string, paramNames map[string]struct{}, arrayParamNames map[string]struct{}) *apis.FieldError {
@@ -217,6 +613,15 @@ func validatePipelineVariables(tasks []PipelineTask, prefix string, paramNames m
 				if err := validatePipelineNoArrayReferenced(fmt.Sprintf("param[%s]", param.Name), param.Value.StringVal, prefix, arrayParamNames); err != nil {
 					return err
 				}
+			} else if param.Value.Type == ParamTypeObject {
+				for _, v := range param.Value.ObjectVal {
+					if err := validatePipelineVariable(fmt.Sprintf("param[%s]", param.Name), v, prefix, paramNames); err != nil {
+						return err
+					}
+					if err := validatePipelineNoArrayReferenced(fmt.Sprintf("param[%s]", param.Name), v, prefix, arrayParamNames); err != nil {
+						return err
+					}
+				}
 			} else {
 				for _, arrayElement := range param.Value.ArrayVal {
 					if err := validatePipelineVariable(fmt.Sprintf("param[%s]", param.Name), arrayElement, prefix, paramNames); err != nil {
@@ -228,6 +633,26 @@ func validatePipelineVariables(tasks []PipelineTask, prefix string, paramNames m
 				}
 			}
 		}
+		for _, param := range task.Matrix {
+			for _, arrayElement := range param.Value.ArrayVal {
+				if err := validatePipelineVariable(fmt.Sprintf("matrix[%s]", param.Name), arrayElement, prefix, paramNames); err != nil {
+					return err
+				}
+				if err := validatePipelineArraysIsolated(fmt.Sprintf("matrix[%s]", param.Name), arrayElement, prefix, arrayParamNames); err != nil {
+					return err
+				}
+			}
+		}
+		for _, we := range task.WhenExpressions {
+			for _, value := range append([]string{we.Input}, we.Values...) {
+				if err := validatePipelineVariable("when", value, prefix, paramNames); err != nil {
+					return err
+				}
+				if err := validatePipelineNoArrayReferenced("when", value, prefix, arrayParamNames); err != nil {
+					return err
+				}
+			}
+		}
 	}
 	return nil
 }
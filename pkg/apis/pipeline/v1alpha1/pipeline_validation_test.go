@@ -19,9 +19,14 @@ package v1alpha1_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	tb "github.com/tektoncd/pipeline/test/builder"
+	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/apis"
 )
 
 func TestPipeline_Validate(t *testing.T) {
@@ -111,6 +116,150 @@ func TestPipelineSpec_Validate(t *testing.T) {
 			tb.PipelineTask("bar", "bar-task"),
 		)),
 		failureExpected: false,
+	}, {
+		name: "valid retries",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task", tb.Retries(3)),
+		)),
+		failureExpected: false,
+	}, {
+		name: "valid retries of a custom task",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("wait", "wait", tb.PipelineTaskCustomTask("example.dev/v0", "Wait"), tb.Retries(2)),
+		)),
+		failureExpected: false,
+	}, {
+		name: "valid expected duration",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task"),
+			tb.PipelineExpectedDuration(10*time.Minute),
+		)),
+		failureExpected: false,
+	}, {
+		name: "valid params hook",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task"),
+			tb.PipelineParamsHook("https://hooks.example.com/params"),
+		)),
+		failureExpected: false,
+	}, {
+		name: "run number",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task",
+				tb.PipelineTaskParam("version", "1.0.$(context.pipelineRun.number)")),
+		)),
+		failureExpected: false,
+	}, {
+		name: "custom task",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task"),
+			tb.PipelineTask("wait", "wait", tb.PipelineTaskCustomTask("example.dev/v0", "Wait"),
+				tb.PipelineTaskParam("duration", "10s"), tb.RunAfter("foo")),
+		)),
+		failureExpected: false,
+	}, {
+		name: "custom task with workspaces",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineWorkspaceDeclaration("source"),
+			tb.PipelineTask("wait", "wait", tb.PipelineTaskCustomTask("example.dev/v0", "Wait"),
+				tb.PipelineTaskWorkspaceBinding("source", "source")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "custom task with a resolver",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("wait", "", tb.PipelineTaskCustomTask("example.dev/v0", "Wait"),
+				tb.PipelineTaskResolver("git", v1alpha1.Param{Name: "url", Value: *tb.ArrayOrString("https://github.com/tektoncd/catalog")})),
+		)),
+		failureExpected: true,
+	}, {
+		name: "finally custom task with a matrix",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task"),
+			tb.FinallyTask("wait", "wait", tb.PipelineTaskCustomTask("example.dev/v0", "Wait"),
+				tb.PipelineTaskMatrix("duration", "10s", "20s")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "taskref resolver",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "", tb.PipelineTaskResolver("git",
+				v1alpha1.Param{Name: "url", Value: *tb.ArrayOrString("https://github.com/tektoncd/catalog")},
+				v1alpha1.Param{Name: "path", Value: *tb.ArrayOrString("task/git-clone/0.1/git-clone.yaml")},
+			)),
+		)),
+		failureExpected: false,
+	}, {
+		name: "taskref name and resolver",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task", tb.PipelineTaskResolver("git")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "clustertask resolver",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "", tb.PipelineTaskRefKind(v1alpha1.ClusterTaskKind), tb.PipelineTaskResolver("git")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "negative retries",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task", tb.Retries(-1)),
+		)),
+		failureExpected: true,
+	}, {
+		name: "valid timeout",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task", tb.PipelineTaskTimeout(10*time.Minute)),
+		)),
+		failureExpected: false,
+	}, {
+		name: "negative timeout",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task", tb.PipelineTaskTimeout(-time.Minute)),
+		)),
+		failureExpected: true,
+	}, {
+		name: "valid when expressions",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("branch", v1alpha1.ParamTypeString),
+			tb.PipelineTask("foo", "foo-task",
+				tb.PipelineTaskWhenExpression("$(params.branch)", selection.In, "main", "release"),
+				tb.PipelineTaskWhenExpression("$(config.environment)", selection.NotIn, "production"),
+			),
+		)),
+		failureExpected: false,
+	}, {
+		name: "when expression with an invalid operator",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task", tb.PipelineTaskWhenExpression("main", selection.Equals, "main")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "when expression without input",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task", tb.PipelineTaskWhenExpression("", selection.In, "main")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "when expression without values",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task", tb.PipelineTaskWhenExpression("main", selection.In)),
+		)),
+		failureExpected: true,
+	}, {
+		name: "when expression referencing an undeclared param",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task", tb.PipelineTaskWhenExpression("$(params.branch)", selection.In, "main")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "when expression referencing an array param",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("branches", v1alpha1.ParamTypeArray),
+			tb.PipelineTask("foo", "foo-task", tb.PipelineTaskWhenExpression("main", selection.In, "$(params.branches)")),
+		)),
+		failureExpected: true,
 	}, {
 		// Adding this case because `task.Resources` is a pointer, explicitly making sure this is handled
 		name: "task without resources",
@@ -162,6 +311,39 @@ func TestPipelineSpec_Validate(t *testing.T) {
 				tb.PipelineTaskParam("a-param", "$(baz)", "and", "$(foo-is-baz)")),
 		)),
 		failureExpected: false,
+	}, {
+		name: "change detection",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineChangeDetection("detect", "changed"),
+			tb.PipelineTask("detect", "detect-task"),
+			tb.PipelineTask("build", "build-task"),
+		)),
+		failureExpected: false,
+	}, {
+		name: "valid starred array parameter variable",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("baz", v1alpha1.ParamTypeArray),
+			tb.PipelineTask("bar", "bar-task",
+				tb.PipelineTaskParam("a-param", "$(params.baz[*])", "last")),
+		)),
+		failureExpected: false,
+	}, {
+		name: "valid enum parameter",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("arch", v1alpha1.ParamTypeString, tb.ParamSpecEnum("amd64", "arm64"), tb.ParamSpecDefault("amd64")),
+			tb.PipelineTask("bar", "bar-task",
+				tb.PipelineTaskParam("arch", "$(params.arch)")),
+		)),
+		failureExpected: false,
+	}, {
+		name: "valid object parameter variables",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("repo", v1alpha1.ParamTypeObject, tb.ParamSpecProperties("url", "revision")),
+			tb.PipelineTask("bar", "bar-task",
+				tb.PipelineTaskParam("url", "$(params.repo.url)"),
+				tb.PipelineTaskParam("revision", "$(params.repo.revision)")),
+		)),
+		failureExpected: false,
 	}, {
 		name: "pipeline parameter nested in task parameter",
 		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
@@ -170,6 +352,49 @@ func TestPipelineSpec_Validate(t *testing.T) {
 				tb.PipelineTaskParam("a-param", "$(input.workspace.$(baz))")),
 		)),
 		failureExpected: false,
+	}, {
+		name: "valid result reference",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("bar", "bar-task"),
+			tb.PipelineTask("foo", "foo-task",
+				tb.PipelineTaskParam("commit", "$(tasks.bar.results.commit)"),
+				tb.PipelineTaskWhenExpression("$(tasks.bar.results.branch)", selection.In, "main")),
+		)),
+		failureExpected: false,
+	}, {
+		name: "valid workspaces",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineWorkspaceDeclaration("source", "cache"),
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskWorkspaceBinding("src", "source"),
+				tb.PipelineTaskWorkspaceBinding("cache", "cache")),
+			tb.PipelineTask("test", "test-task",
+				tb.PipelineTaskWorkspaceBinding("src", "source")),
+		)),
+		failureExpected: false,
+	}, {
+		name: "valid finally tasks",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("channel", v1alpha1.ParamTypeString),
+			tb.PipelineWorkspaceDeclaration("source"),
+			tb.PipelineTask("build", "build-task"),
+			tb.FinallyTask("cleanup", "cleanup-task", tb.Retries(1),
+				tb.PipelineTaskWorkspaceBinding("src", "source")),
+			tb.FinallyTask("notify", "notify-task",
+				tb.PipelineTaskParam("channel", "$(params.channel)")),
+		)),
+		failureExpected: false,
+	}, {
+		name: "valid matrix",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("platforms", v1alpha1.ParamTypeArray),
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskParam("revision", "main"),
+				tb.PipelineTaskMatrix("platform", "$(params.platforms)"),
+				tb.PipelineTaskMatrix("arch", "amd64", "arm64")),
+			tb.PipelineTask("release", "release-task", tb.RunAfter("build")),
+		)),
+		failureExpected: false,
 	}, {
 		name: "duplicate tasks",
 		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
@@ -194,6 +419,136 @@ func TestPipelineSpec_Validate(t *testing.T) {
 				tb.PipelineTaskInputResource("the-resource", "great-resource", tb.From("bar"))),
 		)),
 		failureExpected: true,
+	}, {
+		name: "result of task that doesnt exist",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task",
+				tb.PipelineTaskParam("commit", "$(tasks.bar.results.commit)")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "result of task used by itself",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task",
+				tb.PipelineTaskParam("commit", "$(tasks.foo.results.commit)")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "undeclared workspace",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineWorkspaceDeclaration("source"),
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskWorkspaceBinding("src", "sources")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "duplicate workspace declaration",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineWorkspaceDeclaration("source", "source"),
+			tb.PipelineTask("build", "build-task"),
+		)),
+		failureExpected: true,
+	}, {
+		name: "task workspace bound twice",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineWorkspaceDeclaration("source", "cache"),
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskWorkspaceBinding("src", "source"),
+				tb.PipelineTaskWorkspaceBinding("src", "cache")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "finally task with the name of a task",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"),
+			tb.FinallyTask("build", "cleanup-task"),
+		)),
+		failureExpected: true,
+	}, {
+		name: "finally task running after a task",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"),
+			tb.FinallyTask("cleanup", "cleanup-task", tb.RunAfter("build")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "finally task with a when expression",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"),
+			tb.FinallyTask("cleanup", "cleanup-task", tb.PipelineTaskWhenExpression("foo", selection.In, "foo")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "finally task using the result of a task",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"),
+			tb.FinallyTask("notify", "notify-task",
+				tb.PipelineTaskParam("image", "$(tasks.build.results.image)")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "finally task using an undeclared param",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"),
+			tb.FinallyTask("notify", "notify-task",
+				tb.PipelineTaskParam("channel", "$(params.channel)")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "finally task using an undeclared workspace",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"),
+			tb.FinallyTask("cleanup", "cleanup-task",
+				tb.PipelineTaskWorkspaceBinding("src", "source")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "matrix param with the name of a param",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskParam("platform", "linux"),
+				tb.PipelineTaskMatrix("platform", "linux", "mac")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "matrix param without values",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskMatrix("platform")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "matrix with too many combinations",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskMatrix("a", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17"),
+				tb.PipelineTaskMatrix("b", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "matrix with conditions",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskCondition("cond-1"),
+				tb.PipelineTaskMatrix("platform", "linux", "mac")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "matrix using an undeclared param",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskMatrix("platform", "$(params.platforms)")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "task using the result of a matrix task",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskMatrix("platform", "linux", "mac")),
+			tb.PipelineTask("release", "release-task",
+				tb.PipelineTaskParam("image", "$(tasks.build.results.image)")),
+		)),
+		failureExpected: true,
 	}, {
 		name: "output resources missing from declaration",
 		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
@@ -285,6 +640,105 @@ func TestPipelineSpec_Validate(t *testing.T) {
 				tb.PipelineTaskParam("a-param", "first", "value: $(params.baz)", "last")),
 		)),
 		failureExpected: true,
+	}, {
+		name: "[*] used on a string parameter",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("baz", v1alpha1.ParamTypeString),
+			tb.PipelineTask("bar", "bar-task",
+				tb.PipelineTaskParam("a-param", "$(params.baz[*])", "last")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "object parameter without properties",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("repo", v1alpha1.ParamTypeObject),
+			tb.PipelineTask("bar", "bar-task"),
+		)),
+		failureExpected: true,
+	}, {
+		name: "change detection task not in tasks",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineChangeDetection("detect", "changed"),
+			tb.PipelineTask("build", "build-task"),
+		)),
+		failureExpected: true,
+	}, {
+		name: "change detection without a result",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineChangeDetection("detect", ""),
+			tb.PipelineTask("detect", "detect-task"),
+		)),
+		failureExpected: true,
+	}, {
+		name: "change detection task with dependencies",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineChangeDetection("detect", "changed"),
+			tb.PipelineTask("build", "build-task"),
+			tb.PipelineTask("detect", "detect-task", tb.RunAfter("build")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "change detection task with when expressions",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("branch", v1alpha1.ParamTypeString),
+			tb.PipelineChangeDetection("detect", "changed"),
+			tb.PipelineTask("detect", "detect-task",
+				tb.PipelineTaskWhenExpression("$(params.branch)", selection.In, "main")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "enum on an array parameter",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("arches", v1alpha1.ParamTypeArray, tb.ParamSpecEnum("amd64", "arm64")),
+			tb.PipelineTask("bar", "bar-task"),
+		)),
+		failureExpected: true,
+	}, {
+		name: "object parameter used as a whole",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("repo", v1alpha1.ParamTypeObject, tb.ParamSpecProperties("url")),
+			tb.PipelineTask("bar", "bar-task",
+				tb.PipelineTaskParam("a-param", "$(params.repo)")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "non-existent object parameter key",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("repo", v1alpha1.ParamTypeObject, tb.ParamSpecProperties("url")),
+			tb.PipelineTask("bar", "bar-task",
+				tb.PipelineTaskParam("a-param", "$(params.repo.revision)")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "zero expected duration",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task"),
+			tb.PipelineExpectedDuration(0),
+		)),
+		failureExpected: true,
+	}, {
+		name: "malformed result reference",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task"),
+			tb.PipelineTask("bar", "bar-task",
+				tb.PipelineTaskParam("commit", "$(tasks.foo.result.commit)")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "result of a non-existent task",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task"),
+			tb.PipelineTask("bar", "bar-task",
+				tb.PipelineTaskParam("commit", "$(tasks.fooo.results.commit)")),
+		)),
+		failureExpected: true,
+	}, {
+		name: "relative params hook URL",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("foo", "foo-task"),
+			tb.PipelineParamsHook("/params"),
+		)),
+		failureExpected: true,
 	}, {
 		name: "invalid dependency graph between the tasks",
 		p: tb.Pipeline("foo", "namespace", tb.PipelineSpec(
@@ -306,3 +760,107 @@ func TestPipelineSpec_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineSpec_ValidateMaximumSize(t *testing.T) {
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{
+			MaximumPipelineTasks:      2,
+			MaximumPipelineParams:     1,
+			MaximumMatrixCombinations: 4,
+			MaximumPipelineResultRefs: 1,
+		},
+	})
+	for _, tc := range []struct {
+		name    string
+		p       *v1alpha1.Pipeline
+		wantErr *apis.FieldError
+	}{{
+		name: "within maximums",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("revision", v1alpha1.ParamTypeString),
+			tb.PipelineTask("build", "build-task"),
+			tb.PipelineTask("deploy", "deploy-task",
+				tb.PipelineTaskParam("image", "$(tasks.build.results.image)"),
+				tb.PipelineTaskMatrix("platform", "linux", "darwin"),
+				tb.PipelineTaskMatrix("arch", "amd64", "arm64")),
+		)),
+	}, {
+		name: "too many tasks",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"),
+			tb.PipelineTask("test", "test-task"),
+			tb.FinallyTask("notify", "notify-task"),
+		)),
+		wantErr: &apis.FieldError{
+			Message: "3 tasks exceed the maximum of 2",
+			Paths:   []string{"spec.tasks"},
+			Details: "see maximum-pipeline-tasks in config-defaults",
+		},
+	}, {
+		name: "too many params",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineParamSpec("revision", v1alpha1.ParamTypeString),
+			tb.PipelineParamSpec("url", v1alpha1.ParamTypeString),
+			tb.PipelineTask("build", "build-task"),
+		)),
+		wantErr: &apis.FieldError{
+			Message: "2 params exceed the maximum of 1",
+			Paths:   []string{"spec.params"},
+			Details: "see maximum-pipeline-params in config-defaults",
+		},
+	}, {
+		name: "too many matrix combinations",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskMatrix("platform", "linux", "darwin", "windows"),
+				tb.PipelineTaskMatrix("arch", "amd64", "arm64")),
+		)),
+		wantErr: &apis.FieldError{
+			Message: "6 combinations exceed the maximum of 4",
+			Paths:   []string{"spec.tasks[0].matrix"},
+			Details: "see maximum-matrix-combinations in config-defaults",
+		},
+	}, {
+		name: "too many result references",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"),
+			tb.PipelineTask("deploy", "deploy-task",
+				tb.PipelineTaskParam("image", "$(tasks.build.results.image)"),
+				tb.PipelineTaskParam("digest", "$(tasks.build.results.digest)")),
+		)),
+		wantErr: &apis.FieldError{
+			Message: "2 references to results exceed the maximum of 1",
+			Paths:   []string{"spec.tasks"},
+			Details: "see maximum-pipeline-result-references in config-defaults",
+		},
+	}, {
+		name: "too many result references with finally",
+		p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"),
+			tb.FinallyTask("notify", "notify-task",
+				tb.PipelineTaskParam("image", "$(tasks.build.results.image)"),
+				tb.PipelineTaskParam("digest", "$(tasks.build.results.digest)")),
+		)),
+		wantErr: &apis.FieldError{
+			Message: "2 references to results exceed the maximum of 1",
+			Paths:   []string{"spec.tasks"},
+			Details: "see maximum-pipeline-result-references in config-defaults",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.p.Spec.Validate(ctx)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Errorf("PipelineSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineSpec.Validate() did not return error, wanted error")
+			}
+			if d := cmp.Diff(tc.wantErr.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineSpec.Validate() errors diff -want, +got: %v", d)
+			}
+		})
+	}
+}
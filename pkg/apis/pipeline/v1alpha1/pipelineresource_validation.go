@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -41,75 +42,126 @@ func (rs *PipelineResourceSpec) Validate(ctx context.Context) *apis.FieldError {
 	if equality.Semantic.DeepEqual(rs, &PipelineResourceSpec{}) {
 		return apis.ErrMissingField(apis.CurrentField)
 	}
-	if rs.Type == PipelineResourceTypeCluster {
-		var authFound, cadataFound, isInsecure bool
-		for _, param := range rs.Params {
-			switch {
-			case strings.EqualFold(param.Name, "URL"):
-				if err := validateURL(param.Value, "URL"); err != nil {
-					return err
-				}
-			case strings.EqualFold(param.Name, "Username"):
-				authFound = true
-			case strings.EqualFold(param.Name, "CAData"):
-				authFound = true
-				cadataFound = true
-			case strings.EqualFold(param.Name, "Token"):
-				authFound = true
-			case strings.EqualFold(param.Name, "insecure"):
-				b, _ := strconv.ParseBool(param.Value)
-				isInsecure = b
-			}
-		}
+	rt, ok := GetResourceType(rs.Type)
+	if !ok {
+		return apis.ErrInvalidValue("spec.type", string(rs.Type))
+	}
+	return rt.Validate(ctx, rs)
+}
 
-		for _, secret := range rs.SecretParams {
-			switch {
-			case strings.EqualFold(secret.FieldName, "Username"):
-				authFound = true
-			case strings.EqualFold(secret.FieldName, "CAData"):
-				authFound = true
-				cadataFound = true
+// validateClusterResource validates the params of a cluster PipelineResource.
+func validateClusterResource(rs *PipelineResourceSpec) *apis.FieldError {
+	var authFound, cadataFound, isInsecure bool
+	for _, param := range rs.Params {
+		switch {
+		case strings.EqualFold(param.Name, "URL"):
+			if err := validateURL(param.Value, "URL"); err != nil {
+				return err
 			}
+		case strings.EqualFold(param.Name, "Username"):
+			authFound = true
+		case strings.EqualFold(param.Name, "CAData"):
+			authFound = true
+			cadataFound = true
+		case strings.EqualFold(param.Name, "Token"):
+			authFound = true
+		case strings.EqualFold(param.Name, "insecure"):
+			b, _ := strconv.ParseBool(param.Value)
+			isInsecure = b
 		}
+	}
 
-		// One auth method must be supplied
-		if !(authFound) {
-			return apis.ErrMissingField("username or CAData  or token param")
-		}
-		if !cadataFound && !isInsecure {
-			return apis.ErrMissingField("CAData param")
+	for _, secret := range rs.SecretParams {
+		switch {
+		case strings.EqualFold(secret.FieldName, "Username"):
+			authFound = true
+		case strings.EqualFold(secret.FieldName, "CAData"):
+			authFound = true
+			cadataFound = true
 		}
 	}
-	if rs.Type == PipelineResourceTypeStorage {
-		foundTypeParam := false
-		var location string
-		for _, param := range rs.Params {
-			switch {
-			case strings.EqualFold(param.Name, "type"):
-				if !AllowedStorageType(param.Value) {
-					return apis.ErrInvalidValue(param.Value, "spec.params.type")
-				}
-				foundTypeParam = true
-			case strings.EqualFold(param.Name, "Location"):
-				location = param.Value
+
+	// One auth method must be supplied
+	if !(authFound) {
+		return apis.ErrMissingField("username or CAData  or token param")
+	}
+	if !cadataFound && !isInsecure {
+		return apis.ErrMissingField("CAData param")
+	}
+	return nil
+}
+
+// validateStorageResource validates the params of a storage PipelineResource.
+func validateStorageResource(rs *PipelineResourceSpec) *apis.FieldError {
+	foundTypeParam := false
+	var location string
+	for _, param := range rs.Params {
+		switch {
+		case strings.EqualFold(param.Name, "type"):
+			if !AllowedStorageType(param.Value) {
+				return apis.ErrInvalidValue(param.Value, "spec.params.type")
 			}
+			foundTypeParam = true
+		case strings.EqualFold(param.Name, "Location"):
+			location = param.Value
 		}
+	}
 
-		if !foundTypeParam {
-			return apis.ErrMissingField("spec.params.type")
-		}
-		if location == "" {
-			return apis.ErrMissingField("spec.params.location")
-		}
+	if !foundTypeParam {
+		return apis.ErrMissingField("spec.params.type")
+	}
+	if location == "" {
+		return apis.ErrMissingField("spec.params.location")
 	}
+	return nil
+}
 
-	for _, allowedType := range AllResourceTypes {
-		if allowedType == rs.Type {
-			return nil
+// validateHTTPResource validates the params of an http PipelineResource.
+func validateHTTPResource(rs *PipelineResourceSpec) *apis.FieldError {
+	var resourceURL string
+	for _, param := range rs.Params {
+		switch {
+		case strings.EqualFold(param.Name, "URL"):
+			resourceURL = param.Value
+		case strings.EqualFold(param.Name, "Method"):
+			if method := strings.ToUpper(param.Value); method != http.MethodPut && method != http.MethodPost {
+				return apis.ErrInvalidValue(param.Value, "spec.params.method")
+			}
 		}
 	}
 
-	return apis.ErrInvalidValue("spec.type", string(rs.Type))
+	if resourceURL == "" {
+		return apis.ErrMissingField("spec.params.url")
+	}
+	if u, err := url.ParseRequestURI(resourceURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return apis.ErrInvalidValue(resourceURL, "spec.params.url")
+	}
+	return nil
+}
+
+// validateMavenResource validates the params and the secrets of a maven
+// PipelineResource.
+func validateMavenResource(rs *PipelineResourceSpec) *apis.FieldError {
+	params := map[string]string{}
+	for _, param := range rs.Params {
+		params[strings.ToLower(param.Name)] = param.Value
+	}
+	for _, name := range []string{"repository", "groupId", "artifactId", "version"} {
+		if params[strings.ToLower(name)] == "" {
+			return apis.ErrMissingField("spec.params." + name)
+		}
+	}
+	repository := params["repository"]
+	if u, err := url.ParseRequestURI(repository); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return apis.ErrInvalidValue(repository, "spec.params.repository")
+	}
+	if packaging, ok := params["packaging"]; ok && packaging == "" {
+		return apis.ErrInvalidValue(packaging, "spec.params.packaging")
+	}
+	if err := validateMavenSecrets(rs.SecretParams); err != nil {
+		return apis.ErrInvalidValue(err.Error(), "spec.secrets")
+	}
+	return nil
 }
 
 func AllowedStorageType(gotType string) bool {
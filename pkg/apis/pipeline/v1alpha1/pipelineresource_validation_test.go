@@ -93,6 +93,58 @@ func TestResourceValidation_Invalid(t *testing.T) {
 				tb.PipelineResourceSpecParam("location", ""),
 			)),
 			want: apis.ErrMissingField("spec.params.location"),
+		}, {
+			name: "http with no url",
+			res: tb.PipelineResource("http-resource", "foo", tb.PipelineResourceSpec(
+				v1alpha1.PipelineResourceTypeHTTP,
+				tb.PipelineResourceSpecParam("filename", "app.tar.gz"),
+			)),
+			want: apis.ErrMissingField("spec.params.url"),
+		}, {
+			name: "http with invalid url",
+			res: tb.PipelineResource("http-resource", "foo", tb.PipelineResourceSpec(
+				v1alpha1.PipelineResourceTypeHTTP,
+				tb.PipelineResourceSpecParam("url", "ftp://artifacts.example.com/app.tar.gz"),
+			)),
+			want: apis.ErrInvalidValue("ftp://artifacts.example.com/app.tar.gz", "spec.params.url"),
+		}, {
+			name: "http with unsupported method",
+			res: tb.PipelineResource("http-resource", "foo", tb.PipelineResourceSpec(
+				v1alpha1.PipelineResourceTypeHTTP,
+				tb.PipelineResourceSpecParam("url", "https://artifacts.example.com/app.tar.gz"),
+				tb.PipelineResourceSpecParam("method", "DELETE"),
+			)),
+			want: apis.ErrInvalidValue("DELETE", "spec.params.method"),
+		}, {
+			name: "maven with no artifactId",
+			res: tb.PipelineResource("maven-resource", "foo", tb.PipelineResourceSpec(
+				v1alpha1.PipelineResourceTypeMaven,
+				tb.PipelineResourceSpecParam("repository", "https://repo.example.com/releases"),
+				tb.PipelineResourceSpecParam("groupId", "com.example"),
+				tb.PipelineResourceSpecParam("version", "1.0.0"),
+			)),
+			want: apis.ErrMissingField("spec.params.artifactId"),
+		}, {
+			name: "maven with invalid repository",
+			res: tb.PipelineResource("maven-resource", "foo", tb.PipelineResourceSpec(
+				v1alpha1.PipelineResourceTypeMaven,
+				tb.PipelineResourceSpecParam("repository", "repo.example.com/releases"),
+				tb.PipelineResourceSpecParam("groupId", "com.example"),
+				tb.PipelineResourceSpecParam("artifactId", "app"),
+				tb.PipelineResourceSpecParam("version", "1.0.0"),
+			)),
+			want: apis.ErrInvalidValue("repo.example.com/releases", "spec.params.repository"),
+		}, {
+			name: "maven with unknown secret",
+			res: tb.PipelineResource("maven-resource", "foo", tb.PipelineResourceSpec(
+				v1alpha1.PipelineResourceTypeMaven,
+				tb.PipelineResourceSpecParam("repository", "https://repo.example.com/releases"),
+				tb.PipelineResourceSpecParam("groupId", "com.example"),
+				tb.PipelineResourceSpecParam("artifactId", "app"),
+				tb.PipelineResourceSpecParam("version", "1.0.0"),
+				tb.PipelineResourceSpecSecretParam("token", "maven-credentials", "token"),
+			)),
+			want: apis.ErrInvalidValue("the field name token should be username or password", "spec.secrets"),
 		}, {
 			name: "invalid resource type",
 			res: &v1alpha1.PipelineResource{
@@ -153,6 +205,32 @@ func TestClusterResourceValidation_Valid(t *testing.T) {
 	}
 }
 
+func TestHTTPResourceValidation_Valid(t *testing.T) {
+	res := tb.PipelineResource("http-resource", "foo", tb.PipelineResourceSpec(
+		v1alpha1.PipelineResourceTypeHTTP,
+		tb.PipelineResourceSpecParam("url", "https://artifacts.example.com/app.tar.gz"),
+		tb.PipelineResourceSpecParam("method", "post"),
+	))
+	if err := res.Validate(context.Background()); err != nil {
+		t.Errorf("Unexpected PipelineResource.Validate() error = %v", err)
+	}
+}
+
+func TestMavenResourceValidation_Valid(t *testing.T) {
+	res := tb.PipelineResource("maven-resource", "foo", tb.PipelineResourceSpec(
+		v1alpha1.PipelineResourceTypeMaven,
+		tb.PipelineResourceSpecParam("repository", "https://repo.example.com/releases"),
+		tb.PipelineResourceSpecParam("groupId", "com.example"),
+		tb.PipelineResourceSpecParam("artifactId", "app"),
+		tb.PipelineResourceSpecParam("version", "1.0.0"),
+		tb.PipelineResourceSpecSecretParam("username", "maven-credentials", "username"),
+		tb.PipelineResourceSpecSecretParam("password", "maven-credentials", "password"),
+	))
+	if err := res.Validate(context.Background()); err != nil {
+		t.Errorf("Unexpected PipelineResource.Validate() error = %v", err)
+	}
+}
+
 func TestAllowedGCSStorageType(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -29,11 +29,15 @@ import (
 var _ apis.Defaultable = (*PipelineRun)(nil)
 
 func (pr *PipelineRun) SetDefaults(ctx context.Context) {
+	defer recordDefaultedFields(ctx, pr)()
 	pr.Spec.SetDefaults(ctx)
 }
 
 func (prs *PipelineRunSpec) SetDefaults(ctx context.Context) {
 	cfg := config.FromContextOrDefaults(ctx)
+	if prs.Timeout == nil && prs.Timeouts != nil && prs.Timeouts.Pipeline != nil {
+		prs.Timeout = prs.Timeouts.Pipeline.DeepCopy()
+	}
 	if prs.Timeout == nil {
 		var timeout *metav1.Duration
 		if contexts.IsUpgradeViaDefaulting(ctx) {
@@ -46,9 +50,27 @@ func (prs *PipelineRunSpec) SetDefaults(ctx context.Context) {
 		}
 		prs.Timeout = timeout
 	}
+	prs.Timeout = clampTimeout(ctx, prs.Timeout)
+	if prs.Timeouts != nil && prs.Timeouts.Pipeline != nil {
+		prs.Timeouts.Pipeline = clampTimeout(ctx, prs.Timeouts.Pipeline)
+	}
 
 	defaultSA := cfg.Defaults.DefaultServiceAccount
-	if prs.ServiceAccountName == "" && defaultSA != "" {
+	if prs.ServiceAccountName == "" && prs.TaskRunTemplate.ServiceAccountName == "" && defaultSA != "" {
 		prs.ServiceAccountName = defaultSA
 	}
 }
+
+// clampTimeout lowers timeout to the maximum timeout if the operator asked
+// for longer timeouts to be clamped. Only runs being created are clamped, so
+// that the timeout of existing runs doesn't change under them.
+func clampTimeout(ctx context.Context, timeout *metav1.Duration) *metav1.Duration {
+	cfg := config.FromContextOrDefaults(ctx)
+	if cfg.Defaults.MaximumTimeoutPolicy != config.MaximumTimeoutPolicyClamp || apis.IsInUpdate(ctx) || contexts.IsUpgradeViaDefaulting(ctx) {
+		return timeout
+	}
+	if !cfg.Defaults.ExceedsMaximumTimeout(timeout.Duration) {
+		return timeout
+	}
+	return &metav1.Duration{Duration: cfg.Defaults.MaximumTimeout()}
+}
@@ -57,6 +57,20 @@ func TestPipelineRunSpec_SetDefaults(t *testing.T) {
 				Timeout: &metav1.Duration{Duration: 500 * time.Millisecond},
 			},
 		},
+		{
+			desc: "timeout set by timeouts.pipeline",
+			prs: &v1alpha1.PipelineRunSpec{
+				Timeouts: &v1alpha1.PipelineRunTimeouts{
+					Pipeline: &metav1.Duration{Duration: 2 * time.Hour},
+				},
+			},
+			want: &v1alpha1.PipelineRunSpec{
+				Timeout: &metav1.Duration{Duration: 2 * time.Hour},
+				Timeouts: &v1alpha1.PipelineRunTimeouts{
+					Pipeline: &metav1.Duration{Duration: 2 * time.Hour},
+				},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -151,6 +165,61 @@ func TestPipelineRunDefaulting(t *testing.T) {
 			})
 			return s.ToContext(ctx)
 		},
+	}, {
+		name: "PipelineRef default config context with taskRunTemplate sa",
+		in: &v1alpha1.PipelineRun{
+			Spec: v1alpha1.PipelineRunSpec{
+				PipelineRef:     &v1alpha1.PipelineRef{Name: "foo"},
+				TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{ServiceAccountName: "builder"},
+			},
+		},
+		want: &v1alpha1.PipelineRun{
+			Spec: v1alpha1.PipelineRunSpec{
+				PipelineRef:     &v1alpha1.PipelineRef{Name: "foo"},
+				Timeout:         &metav1.Duration{Duration: 5 * time.Minute},
+				TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{ServiceAccountName: "builder"},
+			},
+		},
+		wc: func(ctx context.Context) context.Context {
+			s := config.NewStore(logtesting.TestLogger(t))
+			s.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: config.DefaultsConfigName,
+				},
+				Data: map[string]string{
+					"default-timeout-minutes": "5",
+					"default-service-account": "tekton",
+				},
+			})
+			return s.ToContext(ctx)
+		},
+	}, {
+		name: "PipelineRef timeout clamped to maximum timeout",
+		in: &v1alpha1.PipelineRun{
+			Spec: v1alpha1.PipelineRunSpec{
+				PipelineRef: &v1alpha1.PipelineRef{Name: "foo"},
+				Timeout:     &metav1.Duration{Duration: 3 * time.Hour},
+			},
+		},
+		want: &v1alpha1.PipelineRun{
+			Spec: v1alpha1.PipelineRunSpec{
+				PipelineRef: &v1alpha1.PipelineRef{Name: "foo"},
+				Timeout:     &metav1.Duration{Duration: 2 * time.Hour},
+			},
+		},
+		wc: func(ctx context.Context) context.Context {
+			s := config.NewStore(logtesting.TestLogger(t))
+			s.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: config.DefaultsConfigName,
+				},
+				Data: map[string]string{
+					"maximum-timeout-minutes": "120",
+					"maximum-timeout-policy":  "clamp",
+				},
+			})
+			return s.ToContext(ctx)
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -49,20 +49,44 @@ type PipelineRunSpec struct {
 	Resources []PipelineResourceBinding `json:"resources,omitempty"`
 	// Params is a list of parameter names and values.
 	Params []Param `json:"params,omitempty"`
+	// Workspaces provide the volumes of the workspaces the Pipeline
+	// declares.
+	// +optional
+	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+	// ServiceAccountName is deprecated, use TaskRunTemplate.ServiceAccountName.
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// ServiceAccountNames is deprecated, use the ServiceAccountName of
+	// TaskRunSpecs.
 	// +optional
 	ServiceAccountNames []PipelineRunSpecServiceAccountName `json:"serviceAccountNames,omitempty"`
-	// Used for cancelling a pipelinerun (and maybe more later on)
+	// TaskRunTemplate configures every TaskRun of the PipelineRun, unless
+	// TaskRunSpecs overrides it for a PipelineTask.
+	// +optional
+	TaskRunTemplate PipelineTaskRunTemplate `json:"taskRunTemplate,omitempty"`
+	// TaskRunSpecs configures the TaskRuns of specific PipelineTasks
+	// +optional
+	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// Used for cancelling or gracefully stopping a pipelinerun
 	// +optional
 	Status PipelineRunSpecStatus `json:"status,omitempty"`
 	// Time after which the Pipeline times out. Defaults to never.
 	// Refer to Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Timeouts sets separate timeouts for the tasks and the finally tasks of
+	// the Pipeline, within Timeout.
+	// +optional
+	Timeouts *PipelineRunTimeouts `json:"timeouts,omitempty"`
 
-	// PodTemplate holds pod specific configuration
+	// PodTemplate is deprecated, use TaskRunTemplate.PodTemplate.
 	PodTemplate PodTemplate `json:"podTemplate,omitempty"`
+	// CloudEventsSink is the URL the cloud events about the PipelineRun and
+	// its TaskRuns are sent to, instead of the sink of its namespace or the
+	// default-cloud-events-sink. It is ignored unless the
+	// enable-run-cloud-events-sink feature flag is on.
+	// +optional
+	CloudEventsSink string `json:"cloudEventsSink,omitempty"`
 }
 
 // PipelineRunSpecStatus defines the pipelinerun spec status the user can provide
@@ -72,6 +96,11 @@ const (
 	// PipelineRunSpecStatusCancelled indicates that the user wants to cancel the task,
 	// if not already cancelled or terminated
 	PipelineRunSpecStatusCancelled = "PipelineRunCancelled"
+
+	// PipelineRunSpecStatusStoppedRunFinally indicates that the user wants to
+	// stop the pipelinerun: the running tasks complete, but no other task
+	// starts, apart from the finally tasks
+	PipelineRunSpecStatusStoppedRunFinally = "StoppedRunFinally"
 )
 
 // PipelineResourceRef can be used to refer to a specific instance of a Resource
@@ -83,6 +112,24 @@ type PipelineResourceRef struct {
 	APIVersion string `json:"apiVersion,omitempty"`
 }
 
+// PipelineRunTimeouts are the timeouts of the parts of a PipelineRun. A
+// duration of 0 means no timeout.
+type PipelineRunTimeouts struct {
+	// Pipeline is the timeout of the whole PipelineRun, which Timeout is set
+	// to.
+	// +optional
+	Pipeline *metav1.Duration `json:"pipeline,omitempty"`
+	// Tasks is the time the tasks of the Pipeline have to complete in, from
+	// the start of the PipelineRun. Once it expired, no other task starts and
+	// the finally tasks run.
+	// +optional
+	Tasks *metav1.Duration `json:"tasks,omitempty"`
+	// Finally is the time the finally tasks have to complete in, from when
+	// they start.
+	// +optional
+	Finally *metav1.Duration `json:"finally,omitempty"`
+}
+
 // PipelineRef can be used to refer to a specific instance of a Pipeline.
 // Copied from CrossVersionObjectReference: https://github.com/kubernetes/kubernetes/blob/169df7434155cbbc22f1532cba8e0a9588e29ad8/pkg/apis/autoscaling/types.go#L64
 type PipelineRef struct {
@@ -91,6 +138,9 @@ type PipelineRef struct {
 	// API version of the referent
 	// +optional
 	APIVersion string `json:"apiVersion,omitempty"`
+	// ResolverRef fetches the Pipeline with a resolver instead of looking it
+	// up by Name.
+	ResolverRef `json:",inline"`
 }
 
 // PipelineRunStatus defines the observed state of PipelineRun
@@ -113,15 +163,140 @@ type PipelineRunStatusFields struct {
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
+	// FinallyStartTime is the time the finally tasks of the PipelineRun
+	// started.
+	// +optional
+	FinallyStartTime *metav1.Time `json:"finallyStartTime,omitempty"`
+
+	// EffectiveTimeout is the timeout the PipelineRun is subject to once
+	// defaults have been applied. A duration of 0 means the PipelineRun has no
+	// timeout.
+	// +optional
+	EffectiveTimeout *metav1.Duration `json:"effectiveTimeout,omitempty"`
+
 	// map of PipelineRunTaskRunStatus with the taskRun name as the key
 	// +optional
 	TaskRuns map[string]*PipelineRunTaskRunStatus `json:"taskRuns,omitempty"`
+
+	// map of PipelineRunRunStatus with the name of the Run of a custom task
+	// as the key
+	// +optional
+	Runs map[string]*PipelineRunRunStatus `json:"runs,omitempty"`
+
+	// Graph is the graph of the PipelineTasks, as resolved by the controller
+	// to schedule them, along with their state.
+	// +optional
+	Graph *PipelineRunGraph `json:"graph,omitempty"`
+
+	// RequestedResources is the sum of the RequestedResources of the
+	// completed TaskRuns of the PipelineRun.
+	// +optional
+	RequestedResources *ResourceSeconds `json:"requestedResources,omitempty"`
+
+	// Number is the number of the PipelineRun among those of its Pipeline,
+	// set when the Pipeline uses the $(context.pipelineRun.number) variable.
+	// +optional
+	Number int64 `json:"number,omitempty"`
+
+	// ExceededExpectedDuration is true once the PipelineRun ran for longer
+	// than the ExpectedDuration of its Pipeline.
+	// +optional
+	ExceededExpectedDuration bool `json:"exceededExpectedDuration,omitempty"`
+
+	// ParamsHook holds the values of the params the params hook of the
+	// Pipeline computed, once it was called.
+	// +optional
+	ParamsHook *PipelineRunParamsHookStatus `json:"paramsHook,omitempty"`
+}
+
+// PipelineRunParamsHookStatus holds the values of the params the params hook
+// of the Pipeline computed for a PipelineRun.
+type PipelineRunParamsHookStatus struct {
+	// Params are the params the hook computed.
+	// +optional
+	Params []Param `json:"params,omitempty"`
+}
+
+// PipelineRunGraph is the graph of the PipelineTasks of a PipelineRun.
+type PipelineRunGraph struct {
+	// Nodes are the PipelineTasks, in the order of the Pipeline.
+	// +optional
+	Nodes []PipelineRunGraphNode `json:"nodes,omitempty"`
+	// Edges are the dependencies between the PipelineTasks.
+	// +optional
+	Edges []PipelineRunGraphEdge `json:"edges,omitempty"`
+}
+
+// PipelineTaskState is the state of a PipelineTask in a PipelineRun.
+type PipelineTaskState string
+
+const (
+	// PipelineTaskStatePending is the state of a PipelineTask that doesn't
+	// have a TaskRun yet.
+	PipelineTaskStatePending PipelineTaskState = "Pending"
+	// PipelineTaskStateRunning is the state of a PipelineTask whose TaskRun
+	// hasn't finished yet, including while it's being retried.
+	PipelineTaskStateRunning PipelineTaskState = "Running"
+	// PipelineTaskStateSucceeded is the state of a PipelineTask whose TaskRun
+	// succeeded.
+	PipelineTaskStateSucceeded PipelineTaskState = "Succeeded"
+	// PipelineTaskStateFailed is the state of a PipelineTask whose TaskRun
+	// failed and won't be retried.
+	PipelineTaskStateFailed PipelineTaskState = "Failed"
+	// PipelineTaskStateSkipped is the state of a PipelineTask that won't run,
+	// because its conditions or the conditions of a PipelineTask it depends
+	// on failed.
+	PipelineTaskStateSkipped PipelineTaskState = "Skipped"
+)
+
+// PipelineRunGraphNode is a PipelineTask of a PipelineRunGraph.
+type PipelineRunGraphNode struct {
+	// PipelineTaskName is the name of the PipelineTask.
+	PipelineTaskName string `json:"pipelineTaskName"`
+	// TaskRunName is the name of the TaskRun of the PipelineTask.
+	// +optional
+	TaskRunName string `json:"taskRunName,omitempty"`
+	// RunName is the name of the Run of the PipelineTask, if it references
+	// a custom task.
+	// +optional
+	RunName string `json:"runName,omitempty"`
+	// State is the state of the PipelineTask.
+	State PipelineTaskState `json:"state"`
+}
+
+// PipelineRunGraphEdgeType is what makes a PipelineTask depend on another.
+type PipelineRunGraphEdgeType string
+
+const (
+	// PipelineRunGraphEdgeRunAfter is a dependency declared with runAfter.
+	PipelineRunGraphEdgeRunAfter PipelineRunGraphEdgeType = "runAfter"
+	// PipelineRunGraphEdgeFrom is a dependency declared with the from
+	// clause of an input resource.
+	PipelineRunGraphEdgeFrom PipelineRunGraphEdgeType = "from"
+	// PipelineRunGraphEdgeResult is a dependency on the result of a
+	// PipelineTask, used as $(tasks.<name>.results.<result>).
+	PipelineRunGraphEdgeResult PipelineRunGraphEdgeType = "result"
+)
+
+// PipelineRunGraphEdge is a dependency between two PipelineTasks of a
+// PipelineRunGraph: To only runs after From.
+type PipelineRunGraphEdge struct {
+	// From is the name of the PipelineTask that runs first.
+	From string `json:"from"`
+	// To is the name of the PipelineTask that depends on From.
+	To string `json:"to"`
+	// Type is what declares the dependency.
+	Type PipelineRunGraphEdgeType `json:"type"`
 }
 
 // PipelineRunTaskRunStatus contains the name of the PipelineTask for this TaskRun and the TaskRun's Status
 type PipelineRunTaskRunStatus struct {
 	// PipelineTaskName is the name of the PipelineTask.
 	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// Matrix is the combination of the matrix parameters of the PipelineTask
+	// the TaskRun runs with.
+	// +optional
+	Matrix []Param `json:"matrix,omitempty"`
 	// Status is the TaskRunStatus for the corresponding TaskRun
 	// +optional
 	Status *TaskRunStatus `json:"status,omitempty"`
@@ -130,6 +305,16 @@ type PipelineRunTaskRunStatus struct {
 	ConditionChecks map[string]*PipelineRunConditionCheckStatus `json:"conditionChecks,omitempty"`
 }
 
+// PipelineRunRunStatus contains the name of the PipelineTask of a custom task
+// and the Status of its Run
+type PipelineRunRunStatus struct {
+	// PipelineTaskName is the name of the PipelineTask.
+	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// Status is the RunStatus for the corresponding Run
+	// +optional
+	Status *RunStatus `json:"status,omitempty"`
+}
+
 type PipelineRunConditionCheckStatus struct {
 	// ConditionName is the name of the Condition
 	ConditionName string `json:"conditionName,omitempty"`
@@ -164,6 +349,51 @@ type PipelineRunSpecServiceAccountName struct {
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 }
 
+// PipelineTaskRunTemplate configures every TaskRun of a PipelineRun
+type PipelineTaskRunTemplate struct {
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+	// Metadata is added to the TaskRuns, and through them to their Pods
+	// +optional
+	Metadata PipelineTaskMetadata `json:"metadata,omitempty"`
+}
+
+// PipelineTaskRunSpec configures the TaskRun of a PipelineTask, overriding
+// the TaskRunTemplate of the PipelineRun
+type PipelineTaskRunSpec struct {
+	PipelineTaskName string `json:"pipelineTaskName"`
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// PodTemplate replaces the pod template of the TaskRunTemplate
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+	// Metadata is added to the TaskRun, and through it to its Pod. Its labels
+	// and annotations take precedence over those of the TaskRunTemplate.
+	// +optional
+	Metadata PipelineTaskMetadata `json:"metadata,omitempty"`
+	// StepOverrides are the stepOverrides of the TaskRun.
+	// +optional
+	StepOverrides []TaskRunStepOverride `json:"stepOverrides,omitempty"`
+	// SidecarOverrides are the sidecarOverrides of the TaskRun.
+	// +optional
+	SidecarOverrides []TaskRunSidecarOverride `json:"sidecarOverrides,omitempty"`
+}
+
+// PipelineTaskMetadata is the metadata of the TaskRun of a PipelineTask
+type PipelineTaskMetadata struct {
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// GenerateName is the prefix of the name of the TaskRun, which is
+	// followed by a random suffix. It defaults to
+	// <pipelineRun>-<pipelineTask>-.
+	// +optional
+	GenerateName string `json:"generateName,omitempty"`
+}
+
 // SetCondition sets the condition, unsetting previous conditions with the same
 // type as necessary.
 func (pr *PipelineRunStatus) SetCondition(newCond *apis.Condition) {
@@ -242,6 +472,12 @@ func (pr *PipelineRun) IsCancelled() bool {
 	return pr.Spec.Status == PipelineRunSpecStatusCancelled
 }
 
+// IsGracefullyStopped returns true if the PipelineRun's spec status is set to
+// StoppedRunFinally state
+func (pr *PipelineRun) IsGracefullyStopped() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusStoppedRunFinally
+}
+
 // GetRunKey return the pipelinerun key for timeout handler map
 func (pr *PipelineRun) GetRunKey() string {
 	// The address of the pointer is a threadsafe unique identifier for the pipelinerun
@@ -266,10 +502,33 @@ func (pr *PipelineRun) IsTimedOut() bool {
 	return false
 }
 
+// HasTasksTimedOut returns true if the tasks of the pipelinerun have exceeded
+// their spec.timeouts.tasks.
+func (pr *PipelineRun) HasTasksTimedOut() bool {
+	if pr.Spec.Timeouts == nil || pr.Spec.Timeouts.Tasks == nil || pr.Status.StartTime.IsZero() {
+		return false
+	}
+	timeout := pr.Spec.Timeouts.Tasks.Duration
+	return timeout != config.NoTimeoutDuration && time.Since(pr.Status.StartTime.Time) > timeout
+}
+
+// GetDefaultServiceAccountName returns the service account name of the
+// TaskRunTemplate, or the PipelineRun's serviceAccountName if it isn't set.
+func (pr *PipelineRun) GetDefaultServiceAccountName() string {
+	if pr.Spec.TaskRunTemplate.ServiceAccountName != "" {
+		return pr.Spec.TaskRunTemplate.ServiceAccountName
+	}
+	return pr.Spec.ServiceAccountName
+}
+
 // GetServiceAccountName returns the service account name for a given
-// PipelineTask if configured, otherwise it returns the PipelineRun's serviceAccountName.
+// PipelineTask if configured in taskRunSpecs or serviceAccountNames,
+// otherwise it returns the default service account name of the PipelineRun.
 func (pr *PipelineRun) GetServiceAccountName(pipelineTaskName string) string {
-	serviceAccountName := pr.Spec.ServiceAccountName
+	if sa := pr.GetTaskRunSpec(pipelineTaskName).ServiceAccountName; sa != "" {
+		return sa
+	}
+	serviceAccountName := pr.GetDefaultServiceAccountName()
 	for _, sa := range pr.Spec.ServiceAccountNames {
 		if sa.TaskName == pipelineTaskName {
 			serviceAccountName = sa.ServiceAccountName
@@ -277,3 +536,61 @@ func (pr *PipelineRun) GetServiceAccountName(pipelineTaskName string) string {
 	}
 	return serviceAccountName
 }
+
+// GetPodTemplate returns the pod template for a given PipelineTask: the one
+// configured in taskRunSpecs, otherwise the one of the TaskRunTemplate,
+// otherwise the PipelineRun's podTemplate.
+func (pr *PipelineRun) GetPodTemplate(pipelineTaskName string) PodTemplate {
+	if t := pr.GetTaskRunSpec(pipelineTaskName).PodTemplate; t != nil {
+		return *t
+	}
+	if t := pr.Spec.TaskRunTemplate.PodTemplate; t != nil {
+		return *t
+	}
+	return pr.Spec.PodTemplate
+}
+
+// GetTaskRunMetadata returns the metadata of the TaskRun of a given
+// PipelineTask: the labels and annotations of the TaskRunTemplate, overridden
+// key by key by those configured in taskRunSpecs, and the generateName
+// configured in taskRunSpecs, otherwise the one of the TaskRunTemplate.
+func (pr *PipelineRun) GetTaskRunMetadata(pipelineTaskName string) PipelineTaskMetadata {
+	template := pr.Spec.TaskRunTemplate.Metadata
+	task := pr.GetTaskRunSpec(pipelineTaskName).Metadata
+	metadata := PipelineTaskMetadata{
+		Labels:       mergeStringMaps(template.Labels, task.Labels),
+		Annotations:  mergeStringMaps(template.Annotations, task.Annotations),
+		GenerateName: task.GenerateName,
+	}
+	if metadata.GenerateName == "" {
+		metadata.GenerateName = template.GenerateName
+	}
+	return metadata
+}
+
+// mergeStringMaps returns the entries of base and overrides, those of
+// overrides taking precedence, or nil if both are empty.
+func mergeStringMaps(base, overrides map[string]string) map[string]string {
+	if len(base) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+// GetTaskRunSpec returns the configuration of the TaskRun of the PipelineTask
+// pipelineTaskName, empty if it isn't configured.
+func (pr *PipelineRun) GetTaskRunSpec(pipelineTaskName string) PipelineTaskRunSpec {
+	for _, s := range pr.Spec.TaskRunSpecs {
+		if s.PipelineTaskName == pipelineTaskName {
+			return s
+		}
+	}
+	return PipelineTaskRunSpec{PipelineTaskName: pipelineTaskName}
+}
@@ -253,6 +253,28 @@ func TestPipelineRunGetServiceAccountName(t *testing.T) {
 				"task2":   "task2SA",
 			},
 		},
+		{
+			"taskRunTemplate SA",
+			&v1alpha1.PipelineRun{Spec: v1alpha1.PipelineRunSpec{
+				TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{ServiceAccountName: "templateSA"},
+				ServiceAccountNames: []v1alpha1.PipelineRunSpecServiceAccountName{{
+					TaskName:           "task1",
+					ServiceAccountName: "task1SA",
+				}},
+				TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{
+					PipelineTaskName:   "task2",
+					ServiceAccountName: "task2SA",
+				}, {
+					PipelineTaskName: "task3",
+				}},
+			}},
+			map[string]string{
+				"unknown": "templateSA",
+				"task1":   "task1SA",
+				"task2":   "task2SA",
+				"task3":   "templateSA",
+			},
+		},
 	} {
 		for taskName, expected := range tt.saNames {
 			sa := tt.pr.GetServiceAccountName(taskName)
@@ -262,3 +284,111 @@ func TestPipelineRunGetServiceAccountName(t *testing.T) {
 		}
 	}
 }
+
+func TestPipelineRunGetTaskRunSpec(t *testing.T) {
+	metadata := v1alpha1.PipelineTaskMetadata{
+		Labels:       map[string]string{"team": "build"},
+		GenerateName: "build-",
+	}
+	pr := tb.PipelineRun("pr", "ns", tb.PipelineRunSpec("prs",
+		tb.PipelineRunTaskRunMetadata("build", metadata),
+	))
+	for _, tc := range []struct {
+		pipelineTask string
+		expected     v1alpha1.PipelineTaskRunSpec
+	}{{
+		pipelineTask: "build",
+		expected:     v1alpha1.PipelineTaskRunSpec{PipelineTaskName: "build", Metadata: metadata},
+	}, {
+		pipelineTask: "test",
+		expected:     v1alpha1.PipelineTaskRunSpec{PipelineTaskName: "test"},
+	}} {
+		t.Run(tc.pipelineTask, func(t *testing.T) {
+			if d := cmp.Diff(tc.expected, pr.GetTaskRunSpec(tc.pipelineTask)); d != "" {
+				t.Errorf("GetTaskRunSpec(%q) -want, +got: %s", tc.pipelineTask, d)
+			}
+		})
+	}
+}
+
+func TestPipelineRunGetPodTemplate(t *testing.T) {
+	prTemplate := v1alpha1.PodTemplate{NodeSelector: map[string]string{"pool": "pipelinerun"}}
+	runTemplate := v1alpha1.PodTemplate{NodeSelector: map[string]string{"pool": "template"}}
+	taskTemplate := v1alpha1.PodTemplate{NodeSelector: map[string]string{"pool": "task"}}
+	for _, tc := range []struct {
+		name     string
+		spec     v1alpha1.PipelineRunSpec
+		expected v1alpha1.PodTemplate
+	}{{
+		name:     "podTemplate",
+		spec:     v1alpha1.PipelineRunSpec{PodTemplate: prTemplate},
+		expected: prTemplate,
+	}, {
+		name: "taskRunTemplate",
+		spec: v1alpha1.PipelineRunSpec{
+			TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{PodTemplate: &runTemplate},
+			TaskRunSpecs:    []v1alpha1.PipelineTaskRunSpec{{PipelineTaskName: "build"}},
+		},
+		expected: runTemplate,
+	}, {
+		name: "taskRunSpecs",
+		spec: v1alpha1.PipelineRunSpec{
+			TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{PodTemplate: &runTemplate},
+			TaskRunSpecs:    []v1alpha1.PipelineTaskRunSpec{{PipelineTaskName: "build", PodTemplate: &taskTemplate}},
+		},
+		expected: taskTemplate,
+	}, {
+		name: "taskRunSpecs replacing the template with an empty one",
+		spec: v1alpha1.PipelineRunSpec{
+			TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{PodTemplate: &runTemplate},
+			TaskRunSpecs:    []v1alpha1.PipelineTaskRunSpec{{PipelineTaskName: "build", PodTemplate: &v1alpha1.PodTemplate{}}},
+		},
+		expected: v1alpha1.PodTemplate{},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1alpha1.PipelineRun{Spec: tc.spec}
+			if d := cmp.Diff(tc.expected, pr.GetPodTemplate("build")); d != "" {
+				t.Errorf("GetPodTemplate -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestPipelineRunGetTaskRunMetadata(t *testing.T) {
+	pr := &v1alpha1.PipelineRun{Spec: v1alpha1.PipelineRunSpec{
+		TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{
+			Metadata: v1alpha1.PipelineTaskMetadata{
+				Labels:       map[string]string{"team": "ci", "tier": "shared"},
+				Annotations:  map[string]string{"owner": "ci"},
+				GenerateName: "run-",
+			},
+		},
+		TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{
+			PipelineTaskName: "build",
+			Metadata: v1alpha1.PipelineTaskMetadata{
+				Labels:       map[string]string{"tier": "build"},
+				GenerateName: "build-",
+			},
+		}},
+	}}
+	for _, tc := range []struct {
+		pipelineTask string
+		expected     v1alpha1.PipelineTaskMetadata
+	}{{
+		pipelineTask: "build",
+		expected: v1alpha1.PipelineTaskMetadata{
+			Labels:       map[string]string{"team": "ci", "tier": "build"},
+			Annotations:  map[string]string{"owner": "ci"},
+			GenerateName: "build-",
+		},
+	}, {
+		pipelineTask: "test",
+		expected:     pr.Spec.TaskRunTemplate.Metadata,
+	}} {
+		t.Run(tc.pipelineTask, func(t *testing.T) {
+			if d := cmp.Diff(tc.expected, pr.GetTaskRunMetadata(tc.pipelineTask)); d != "" {
+				t.Errorf("GetTaskRunMetadata(%q) -want, +got: %s", tc.pipelineTask, d)
+			}
+		})
+	}
+}
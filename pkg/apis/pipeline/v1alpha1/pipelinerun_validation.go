@@ -19,9 +19,16 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
@@ -42,28 +49,195 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 	}
 
 	// can't have both pipelineRef and pipelineSpec at the same time
-	if (ps.PipelineRef != nil && ps.PipelineRef.Name != "") && ps.PipelineSpec != nil {
+	if (ps.PipelineRef != nil && (ps.PipelineRef.Name != "" || ps.PipelineRef.Resolver != "")) && ps.PipelineSpec != nil {
 		return apis.ErrDisallowedFields("spec.pipelineref", "spec.pipelinespec")
 	}
 
 	// Check that one of PipelineRef and PipelineSpec is present
-	if (ps.PipelineRef == nil || (ps.PipelineRef != nil && ps.PipelineRef.Name == "")) && ps.PipelineSpec == nil {
+	if (ps.PipelineRef == nil || (ps.PipelineRef != nil && ps.PipelineRef.Name == "" && ps.PipelineRef.Resolver == "")) && ps.PipelineSpec == nil {
 		return apis.ErrMissingField("spec.pipelineref.name", "spec.pipelinespec")
 	}
 
+	if ps.PipelineRef != nil {
+		if ps.PipelineRef.Name != "" && ps.PipelineRef.Resolver != "" {
+			return apis.ErrMultipleOneOf("spec.pipelineref.name", "spec.pipelineref.resolver")
+		}
+		if err := ps.PipelineRef.ResolverRef.validate(); err != nil {
+			return err.ViaField("spec.pipelineref")
+		}
+	}
+
 	// Validate PipelineSpec if it's present
 	if ps.PipelineSpec != nil {
 		if err := ps.PipelineSpec.Validate(ctx); err != nil {
 			return err
 		}
+		if err := validateParamValues(ps.PipelineSpec.Params, ps.Params, "spec.params"); err != nil {
+			return err
+		}
 	}
 
 	if ps.Timeout != nil {
-		// timeout should be a valid duration of at least 0.
-		if ps.Timeout.Duration < 0 {
-			return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", ps.Timeout.Duration.String()), "spec.timeout")
+		if err := validateTimeout(ctx, ps.Timeout.Duration); err != nil {
+			return err.ViaField("spec")
+		}
+	}
+	switch ps.Status {
+	case "", PipelineRunSpecStatusCancelled, PipelineRunSpecStatusStoppedRunFinally:
+	default:
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", ps.Status, PipelineRunSpecStatusCancelled, PipelineRunSpecStatusStoppedRunFinally), "spec.status")
+	}
+	if ps.Timeouts != nil {
+		if ps.Timeout != nil && ps.Timeouts.Pipeline != nil && ps.Timeout.Duration != ps.Timeouts.Pipeline.Duration {
+			return apis.ErrMultipleOneOf("spec.timeout", "spec.timeouts.pipeline")
+		}
+		if err := validateTimeouts(ps.Timeout, ps.Timeouts); err != nil {
+			return err.ViaField("spec.timeouts")
+		}
+	}
+
+	// The deprecated fields can't be combined with the TaskRunTemplate
+	// replacing them, which would make it ambiguous which one applies.
+	if ps.ServiceAccountName != "" && ps.TaskRunTemplate.ServiceAccountName != "" {
+		return apis.ErrMultipleOneOf("spec.serviceAccountName", "spec.taskRunTemplate.serviceAccountName")
+	}
+	if ps.TaskRunTemplate.PodTemplate != nil && !equality.Semantic.DeepEqual(ps.PodTemplate, PodTemplate{}) {
+		return apis.ErrMultipleOneOf("spec.podTemplate", "spec.taskRunTemplate.podTemplate")
+	}
+	if err := ps.PodTemplate.validate(); err != nil {
+		return err.ViaField("spec.podTemplate")
+	}
+	if err := validateWorkspaceBindings(ps.Workspaces); err != nil {
+		return err.ViaField("spec.workspaces")
+	}
+	if err := ps.TaskRunTemplate.PodTemplate.validate(); err != nil {
+		return err.ViaField("spec.taskRunTemplate.podTemplate")
+	}
+	if err := ps.TaskRunTemplate.Metadata.validate(); err != nil {
+		return err.ViaField("spec.taskRunTemplate.metadata")
+	}
+	if err := validateCloudEventsSink(ps.CloudEventsSink); err != nil {
+		return err.ViaField("spec")
+	}
+
+	serviceAccountNames := map[string]bool{}
+	for _, sa := range ps.ServiceAccountNames {
+		serviceAccountNames[sa.TaskName] = true
+	}
+	seen := map[string]bool{}
+	for i, s := range ps.TaskRunSpecs {
+		if s.PipelineTaskName == "" {
+			return apis.ErrMissingField("pipelineTaskName").ViaFieldIndex("taskRunSpecs", i).ViaField("spec")
+		}
+		if seen[s.PipelineTaskName] {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s is configured more than once", s.PipelineTaskName), "pipelineTaskName").ViaFieldIndex("taskRunSpecs", i).ViaField("spec")
+		}
+		seen[s.PipelineTaskName] = true
+		if s.ServiceAccountName != "" && serviceAccountNames[s.PipelineTaskName] {
+			return apis.ErrMultipleOneOf(fmt.Sprintf("spec.serviceAccountNames[%s]", s.PipelineTaskName), fmt.Sprintf("spec.taskRunSpecs[%d].serviceAccountName", i))
+		}
+		if err := s.PodTemplate.validate(); err != nil {
+			return err.ViaField("podTemplate").ViaFieldIndex("taskRunSpecs", i).ViaField("spec")
+		}
+		if err := s.Metadata.validate(); err != nil {
+			return err.ViaField("metadata").ViaFieldIndex("taskRunSpecs", i).ViaField("spec")
+		}
+		if err := validateOverrides(s.StepOverrides, s.SidecarOverrides); err != nil {
+			return err.ViaFieldIndex("taskRunSpecs", i).ViaField("spec")
 		}
 	}
 
 	return nil
 }
+
+func (m *PipelineTaskMetadata) validate() *apis.FieldError {
+	for _, k := range sortedKeys(m.Labels) {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return apis.ErrInvalidKeyName(k, "labels", errs...)
+		}
+		// The controller sets the tekton.dev labels to find the TaskRuns
+		// and Pods of a PipelineRun.
+		if strings.HasPrefix(k, pipeline.GroupName+"/") {
+			return apis.ErrInvalidKeyName(k, "labels", "the "+pipeline.GroupName+"/ labels are set by the controller")
+		}
+		if errs := validation.IsValidLabelValue(m.Labels[k]); len(errs) > 0 {
+			return apis.ErrInvalidValue(m.Labels[k], "labels."+k)
+		}
+	}
+	for _, k := range sortedKeys(m.Annotations) {
+		if errs := validation.IsQualifiedName(strings.ToLower(k)); len(errs) > 0 {
+			return apis.ErrInvalidKeyName(k, "annotations", errs...)
+		}
+	}
+	if m.GenerateName != "" {
+		// The name must also be valid for validate.ObjectMetadata, which
+		// rejects dots.
+		if errs := validation.IsDNS1123Label(strings.TrimSuffix(m.GenerateName, "-")); len(errs) > 0 {
+			return apis.ErrInvalidValue(m.GenerateName, "generateName")
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateTimeouts checks that the timeouts of the tasks and of the finally
+// tasks fit in the timeout of the PipelineRun.
+func validateTimeouts(timeout *metav1.Duration, timeouts *PipelineRunTimeouts) *apis.FieldError {
+	var tasks, finally time.Duration
+	if timeouts.Tasks != nil {
+		tasks = timeouts.Tasks.Duration
+		if tasks < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", tasks.String()), "tasks")
+		}
+	}
+	if timeouts.Finally != nil {
+		finally = timeouts.Finally.Duration
+		if finally < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", finally.String()), "finally")
+		}
+	}
+	if timeout == nil || timeout.Duration == config.NoTimeoutDuration {
+		return nil
+	}
+	if tasks > timeout.Duration {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be at most the pipeline timeout of %s", tasks.String(), timeout.Duration.String()), "tasks")
+	}
+	if tasks+finally > timeout.Duration {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be at most the pipeline timeout of %s minus the tasks timeout", finally.String(), timeout.Duration.String()), "finally")
+	}
+	return nil
+}
+
+// validateTimeout validates the timeout of a run, which must be a duration
+// of at least 0. Runs being created must also stay within the
+// operator-configured maximum, and may only disable their timeout if the
+// operator allows it; existing runs aren't rejected, so that changing the
+// config doesn't prevent the controller from updating them.
+func validateTimeout(ctx context.Context, timeout time.Duration) *apis.FieldError {
+	if timeout < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", timeout.String()), "timeout")
+	}
+	if apis.IsInUpdate(ctx) {
+		return nil
+	}
+	cfg := config.FromContextOrDefaults(ctx)
+	if timeout == config.NoTimeoutDuration && !cfg.Defaults.AllowNoTimeout {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("invalid value: %s disables the timeout, which is not allowed", timeout.String()),
+			Paths:   []string{"timeout"},
+			Details: "omit the timeout to use the default timeout",
+		}
+	}
+	if cfg.Defaults.ExceedsMaximumTimeout(timeout) {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s exceeds the maximum timeout of %s", timeout.String(), cfg.Defaults.MaximumTimeout().String()), "timeout")
+	}
+	return nil
+}
@@ -22,7 +22,9 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -78,6 +80,74 @@ func TestPipelineRun_Invalidate(t *testing.T) {
 				},
 			},
 			want: apis.ErrInvalidValue("-48h0m0s should be >= 0", "spec.timeout"),
+		}, {
+			name: "timeout and timeouts.pipeline differ",
+			pr: v1alpha1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: v1alpha1.PipelineRunSpec{
+					PipelineRef: &v1alpha1.PipelineRef{
+						Name: "prname",
+					},
+					Timeout: &metav1.Duration{Duration: time.Hour},
+					Timeouts: &v1alpha1.PipelineRunTimeouts{
+						Pipeline: &metav1.Duration{Duration: 2 * time.Hour},
+					},
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.timeout", "spec.timeouts.pipeline"),
+		}, {
+			name: "negative tasks timeout",
+			pr: v1alpha1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: v1alpha1.PipelineRunSpec{
+					PipelineRef: &v1alpha1.PipelineRef{
+						Name: "prname",
+					},
+					Timeouts: &v1alpha1.PipelineRunTimeouts{
+						Tasks: &metav1.Duration{Duration: -time.Hour},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("-1h0m0s should be >= 0", "spec.timeouts.tasks"),
+		}, {
+			name: "tasks timeout longer than the pipeline timeout",
+			pr: v1alpha1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: v1alpha1.PipelineRunSpec{
+					PipelineRef: &v1alpha1.PipelineRef{
+						Name: "prname",
+					},
+					Timeout: &metav1.Duration{Duration: time.Hour},
+					Timeouts: &v1alpha1.PipelineRunTimeouts{
+						Tasks: &metav1.Duration{Duration: 2 * time.Hour},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("2h0m0s should be at most the pipeline timeout of 1h0m0s", "spec.timeouts.tasks"),
+		}, {
+			name: "tasks and finally timeouts longer than the pipeline timeout",
+			pr: v1alpha1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: v1alpha1.PipelineRunSpec{
+					PipelineRef: &v1alpha1.PipelineRef{
+						Name: "prname",
+					},
+					Timeout: &metav1.Duration{Duration: time.Hour},
+					Timeouts: &v1alpha1.PipelineRunTimeouts{
+						Tasks:   &metav1.Duration{Duration: 40 * time.Minute},
+						Finally: &metav1.Duration{Duration: 30 * time.Minute},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("30m0s should be at most the pipeline timeout of 1h0m0s minus the tasks timeout", "spec.timeouts.finally"),
 		},
 	}
 
@@ -121,6 +191,23 @@ func TestPipelineRun_Validate(t *testing.T) {
 					Timeout: &metav1.Duration{Duration: 0},
 				},
 			},
+		}, {
+			name: "tasks and finally timeouts",
+			pr: v1alpha1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: v1alpha1.PipelineRunSpec{
+					PipelineRef: &v1alpha1.PipelineRef{
+						Name: "prname",
+					},
+					Timeout: &metav1.Duration{Duration: time.Hour},
+					Timeouts: &v1alpha1.PipelineRunTimeouts{
+						Tasks:   &metav1.Duration{Duration: 40 * time.Minute},
+						Finally: &metav1.Duration{Duration: 20 * time.Minute},
+					},
+				},
+			},
 		},
 	}
 
@@ -134,6 +221,7 @@ func TestPipelineRun_Validate(t *testing.T) {
 }
 
 func TestPipelineRunSpec_Invalidate(t *testing.T) {
+	negativeSeconds := int64(-1)
 	tests := []struct {
 		name    string
 		spec    v1alpha1.PipelineRunSpec
@@ -163,6 +251,174 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 				}}},
 		},
 		wantErr: apis.ErrDisallowedFields("spec.pipelinespec", "spec.pipelineref"),
+	}, {
+		name: "unknown status",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			Status:      "Paused",
+		},
+		wantErr: apis.ErrInvalidValue("Paused should be PipelineRunCancelled or StoppedRunFinally", "spec.status"),
+	}, {
+		name: "taskRunSpecs without pipelineTaskName",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef:  &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{}},
+		},
+		wantErr: apis.ErrMissingField("spec.taskRunSpecs[0].pipelineTaskName"),
+	}, {
+		name: "pipelineTask configured twice",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{
+				{PipelineTaskName: "build"},
+				{PipelineTaskName: "build"},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("build is configured more than once", "spec.taskRunSpecs[1].pipelineTaskName"),
+	}, {
+		name: "invalid label key",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build",
+				Metadata:         v1alpha1.PipelineTaskMetadata{Labels: map[string]string{"-team": "ci"}},
+			}},
+		},
+		wantErr: apis.ErrInvalidKeyName("-team", "spec.taskRunSpecs[0].metadata.labels",
+			"name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')"),
+	}, {
+		name: "tekton.dev label",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build",
+				Metadata:         v1alpha1.PipelineTaskMetadata{Labels: map[string]string{"tekton.dev/pipelineRun": "other"}},
+			}},
+		},
+		wantErr: apis.ErrInvalidKeyName("tekton.dev/pipelineRun", "spec.taskRunSpecs[0].metadata.labels", "the tekton.dev/ labels are set by the controller"),
+	}, {
+		name: "invalid label value",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build",
+				Metadata:         v1alpha1.PipelineTaskMetadata{Labels: map[string]string{"team": "c i"}},
+			}},
+		},
+		wantErr: apis.ErrInvalidValue("c i", "spec.taskRunSpecs[0].metadata.labels.team"),
+	}, {
+		name: "invalid annotation key",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build",
+				Metadata:         v1alpha1.PipelineTaskMetadata{Annotations: map[string]string{"a/b/c": "value"}},
+			}},
+		},
+		wantErr: apis.ErrInvalidKeyName("a/b/c", "spec.taskRunSpecs[0].metadata.annotations",
+			"a qualified name must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]') with an optional DNS subdomain prefix and '/' (e.g. 'example.com/MyName')"),
+	}, {
+		name: "invalid generateName",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build",
+				Metadata:         v1alpha1.PipelineTaskMetadata{GenerateName: "team.build-"},
+			}},
+		},
+		wantErr: apis.ErrInvalidValue("team.build-", "spec.taskRunSpecs[0].metadata.generateName"),
+	}, {
+		name: "invalid podTemplate",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			PodTemplate: v1alpha1.PodTemplate{SchedulerName: "Batch Scheduler"},
+		},
+		wantErr: apis.ErrInvalidValue("Batch Scheduler", "spec.podTemplate.schedulerName"),
+	}, {
+		name: "invalid taskRunTemplate podTemplate",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef:     &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{PodTemplate: &v1alpha1.PodTemplate{ActiveDeadlineSeconds: &negativeSeconds}},
+		},
+		wantErr: apis.ErrInvalidValue(-1, "spec.taskRunTemplate.podTemplate.activeDeadlineSeconds"),
+	}, {
+		name: "invalid taskRunSpecs podTemplate",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build",
+				PodTemplate:      &v1alpha1.PodTemplate{ActiveDeadlineSeconds: &negativeSeconds},
+			}},
+		},
+		wantErr: apis.ErrInvalidValue(-1, "spec.taskRunSpecs[0].podTemplate.activeDeadlineSeconds"),
+	}, {
+		name: "serviceAccountName and taskRunTemplate serviceAccountName together",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef:        &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			ServiceAccountName: "sa",
+			TaskRunTemplate:    v1alpha1.PipelineTaskRunTemplate{ServiceAccountName: "template-sa"},
+		},
+		wantErr: apis.ErrMultipleOneOf("spec.serviceAccountName", "spec.taskRunTemplate.serviceAccountName"),
+	}, {
+		name: "podTemplate and taskRunTemplate podTemplate together",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef:     &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			PodTemplate:     v1alpha1.PodTemplate{NodeSelector: map[string]string{"pool": "build"}},
+			TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{PodTemplate: &v1alpha1.PodTemplate{}},
+		},
+		wantErr: apis.ErrMultipleOneOf("spec.podTemplate", "spec.taskRunTemplate.podTemplate"),
+	}, {
+		name: "invalid taskRunTemplate metadata",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{
+				Metadata: v1alpha1.PipelineTaskMetadata{Labels: map[string]string{"tekton.dev/pipelineRun": "other"}},
+			},
+		},
+		wantErr: apis.ErrInvalidKeyName("tekton.dev/pipelineRun", "spec.taskRunTemplate.metadata.labels", "the tekton.dev/ labels are set by the controller"),
+	}, {
+		name: "serviceAccountNames and taskRunSpecs serviceAccountName for the same pipelineTask",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			ServiceAccountNames: []v1alpha1.PipelineRunSpecServiceAccountName{{
+				TaskName:           "build",
+				ServiceAccountName: "sa",
+			}},
+			TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{
+				PipelineTaskName:   "build",
+				ServiceAccountName: "build-sa",
+			}},
+		},
+		wantErr: apis.ErrMultipleOneOf("spec.serviceAccountNames[build]", "spec.taskRunSpecs[0].serviceAccountName"),
+	}, {
+		name: "workspace without a volume",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			Workspaces:  []v1alpha1.WorkspaceBinding{{Name: "source"}},
+		},
+		wantErr: apis.ErrMissingOneOf("spec.workspaces[0].persistentVolumeClaim", "spec.workspaces[0].emptyDir", "spec.workspaces[0].configMap", "spec.workspaces[0].secret"),
+	}, {
+		name: "pipelineRef name and resolver",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname", ResolverRef: v1alpha1.ResolverRef{Resolver: "git"}},
+		},
+		wantErr: apis.ErrMultipleOneOf("spec.pipelineref.name", "spec.pipelineref.resolver"),
+	}, {
+		name: "pipelineRef resolver param without name",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{ResolverRef: v1alpha1.ResolverRef{
+				Resolver: "git",
+				Params:   []v1alpha1.Param{{Value: *tb.ArrayOrString("https://github.com/tektoncd/catalog")}},
+			}},
+		},
+		wantErr: apis.ErrMissingField("spec.pipelineref.params[0].name"),
+	}, {
+		name: "relative cloudEventsSink",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef:     &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			CloudEventsSink: "events",
+		},
+		wantErr: apis.ErrInvalidValue("events isn't an absolute URL", "spec.cloudEventsSink"),
 	}}
 	for _, ps := range tests {
 		t.Run(ps.name, func(t *testing.T) {
@@ -174,11 +430,37 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 	}
 }
 
+func TestPipelineRunSpec_InvalidateMaximumTimeout(t *testing.T) {
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{
+			DefaultTimeoutMinutes: 30,
+			MaximumTimeoutMinutes: 60,
+			MaximumTimeoutPolicy:  config.MaximumTimeoutPolicyReject,
+			AllowNoTimeout:        true,
+		},
+	})
+	spec := v1alpha1.PipelineRunSpec{
+		PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+		Timeout:     &metav1.Duration{Duration: 0},
+	}
+	want := apis.ErrInvalidValue("0s exceeds the maximum timeout of 1h0m0s", "spec.timeout")
+	err := spec.Validate(ctx)
+	if d := cmp.Diff(want.Error(), err.Error()); d != "" {
+		t.Errorf("PipelineRunSpec.Validate (-want, +got) = %v", d)
+	}
+}
+
 func TestPipelineRunSpec_Validate(t *testing.T) {
 	tests := []struct {
 		name string
 		spec v1alpha1.PipelineRunSpec
 	}{{
+		name: "PipelineRun with a cloudEventsSink",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef:     &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			CloudEventsSink: "http://events.example.com",
+		},
+	}, {
 		name: "PipelineRun without pipelineRef",
 		spec: v1alpha1.PipelineRunSpec{
 			PipelineSpec: &v1alpha1.PipelineSpec{
@@ -190,6 +472,53 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "PipelineRun with a pipelineRef resolver",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{ResolverRef: v1alpha1.ResolverRef{
+				Resolver: "hub",
+				Params: []v1alpha1.Param{
+					{Name: "kind", Value: *tb.ArrayOrString("pipeline")},
+					{Name: "name", Value: *tb.ArrayOrString("buildpacks")},
+					{Name: "version", Value: *tb.ArrayOrString("0.1")},
+				},
+			}},
+		},
+	}, {
+		name: "PipelineRun with TaskRun metadata",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build",
+				Metadata: v1alpha1.PipelineTaskMetadata{
+					Labels:       map[string]string{"example.com/team": "ci"},
+					Annotations:  map[string]string{"example.com/Owner": "Team CI"},
+					GenerateName: "build-",
+				},
+			}, {
+				PipelineTaskName: "deploy",
+				Metadata:         v1alpha1.PipelineTaskMetadata{GenerateName: "deploy"},
+			}},
+		},
+	}, {
+		name: "PipelineRun with TaskRun template",
+		spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "pipelinerefname"},
+			TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{
+				ServiceAccountName: "sa",
+				PodTemplate:        &v1alpha1.PodTemplate{NodeSelector: map[string]string{"pool": "build"}},
+				Metadata:           v1alpha1.PipelineTaskMetadata{Labels: map[string]string{"example.com/team": "ci"}},
+			},
+			ServiceAccountNames: []v1alpha1.PipelineRunSpecServiceAccountName{{
+				TaskName:           "deploy",
+				ServiceAccountName: "deploy-sa",
+			}},
+			TaskRunSpecs: []v1alpha1.PipelineTaskRunSpec{{
+				PipelineTaskName:   "build",
+				ServiceAccountName: "build-sa",
+				PodTemplate:        &v1alpha1.PodTemplate{},
+			}},
+		},
 	}}
 	for _, ps := range tests {
 		t.Run(ps.name, func(t *testing.T) {
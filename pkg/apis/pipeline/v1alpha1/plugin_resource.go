@@ -0,0 +1,141 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+// PluginResourceType is a ResourceType implemented by an exec plugin: an
+// image run as a step before the steps of the Tasks a PipelineResource of
+// that type is an input of, and after the steps of those it is an output of.
+type PluginResourceType struct {
+	// Image is the image of the plugin. Its entrypoint is run with the
+	// arguments "-mode", either "input" or "output", "-path", where the
+	// resource is fetched to or uploaded from, and "-resource", the JSON of
+	// the PluginResource.
+	Image string
+}
+
+// Validate accepts any params: the plugin validates them when it runs.
+func (t PluginResourceType) Validate(_ context.Context, _ *PipelineResourceSpec) *apis.FieldError {
+	return nil
+}
+
+// New returns the PluginResource of r.
+func (t PluginResourceType) New(r *PipelineResource, _ pipeline.Images) (PipelineResourceInterface, error) {
+	pr := &PluginResource{
+		Name:    r.Name,
+		Type:    r.Spec.Type,
+		Params:  map[string]string{},
+		Secrets: r.Spec.SecretParams,
+		Image:   t.Image,
+	}
+	for _, param := range r.Spec.Params {
+		pr.Params[strings.ToLower(param.Name)] = param.Value
+	}
+	return pr, nil
+}
+
+// PluginResource is a PipelineResource of a PluginResourceType.
+type PluginResource struct {
+	Name string               `json:"name"`
+	Type PipelineResourceType `json:"type"`
+	// Params are the params of the PipelineResource, by lowercase name.
+	Params map[string]string `json:"params"`
+	// Secrets are exposed to the plugin as environment variables named after
+	// the uppercase FieldName.
+	Secrets []SecretParam `json:"secrets,omitempty"`
+
+	Image string `json:"-"`
+}
+
+// GetName returns the name of the resource
+func (s PluginResource) GetName() string {
+	return s.Name
+}
+
+// GetType returns the type of the resource
+func (s PluginResource) GetType() PipelineResourceType {
+	return s.Type
+}
+
+// Replacements is used for template replacement on a PluginResource inside
+// of a Taskrun: its name, type and params.
+func (s *PluginResource) Replacements() map[string]string {
+	replacements := map[string]string{
+		"name": s.Name,
+		"type": string(s.Type),
+	}
+	for k, v := range s.Params {
+		if _, ok := replacements[k]; !ok {
+			replacements[k] = v
+		}
+	}
+	return replacements
+}
+
+// GetInputTaskModifier returns the TaskModifier running the plugin in input
+// mode before the steps.
+func (s *PluginResource) GetInputTaskModifier(_ *TaskSpec, path string) (TaskModifier, error) {
+	return &InternalTaskModifier{
+		StepsToPrepend: []Step{s.step("input", path)},
+	}, nil
+}
+
+// GetOutputTaskModifier returns the TaskModifier running the plugin in output
+// mode after the steps.
+func (s *PluginResource) GetOutputTaskModifier(_ *TaskSpec, path string) (TaskModifier, error) {
+	return &InternalTaskModifier{
+		StepsToAppend: []Step{s.step("output", path)},
+	}, nil
+}
+
+func (s *PluginResource) step(mode, path string) Step {
+	var envVars []corev1.EnvVar
+	for _, sec := range s.Secrets {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: strings.ToUpper(sec.FieldName),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: sec.SecretName,
+					},
+					Key: sec.SecretKey,
+				},
+			},
+		})
+	}
+	return Step{Container: corev1.Container{
+		Name:  names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(mode + "-" + s.Name),
+		Image: s.Image,
+		Args:  []string{"-mode", mode, "-path", path, "-resource", s.String()},
+		Env:   envVars,
+	}}
+}
+
+func (s PluginResource) String() string {
+	json, _ := json.Marshal(s)
+	return string(json)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+
+	tb "github.com/tektoncd/pipeline/test/builder"
+)
+
+func TestPluginResource(t *testing.T) {
+	names.TestingSeed()
+	v1alpha1.RegisterResourceType("artifactory", v1alpha1.PluginResourceType{Image: "example.com/artifactory-plugin"})
+	defer v1alpha1.UnregisterResourceType("artifactory")
+
+	r := tb.PipelineResource("jar", "default", tb.PipelineResourceSpec(
+		"artifactory",
+		tb.PipelineResourceSpecParam("Path", "libs/app.jar"),
+		tb.PipelineResourceSpecSecretParam("token", "artifactory-creds", "token"),
+	))
+	if err := r.Validate(context.Background()); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	resource, err := v1alpha1.ResourceFromType(r, pipeline.Images{})
+	if err != nil {
+		t.Fatalf("ResourceFromType() = %v", err)
+	}
+	if d := cmp.Diff(map[string]string{"name": "jar", "type": "artifactory", "path": "libs/app.jar"}, resource.Replacements()); d != "" {
+		t.Errorf("Replacements diff -want, +got: %s", d)
+	}
+
+	resourceJSON := `{"name":"jar","type":"artifactory","params":{"path":"libs/app.jar"},"secrets":[{"fieldName":"token","secretKey":"token","secretName":"artifactory-creds"}]}`
+	env := []corev1.EnvVar{{
+		Name: "TOKEN",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "artifactory-creds"},
+				Key:                  "token",
+			},
+		},
+	}}
+	input, err := resource.GetInputTaskModifier(&v1alpha1.TaskSpec{}, "/workspace/jar")
+	if err != nil {
+		t.Fatalf("GetInputTaskModifier() = %v", err)
+	}
+	if d := cmp.Diff([]v1alpha1.Step{{Container: corev1.Container{
+		Name:  "input-jar-9l9zj",
+		Image: "example.com/artifactory-plugin",
+		Args:  []string{"-mode", "input", "-path", "/workspace/jar", "-resource", resourceJSON},
+		Env:   env,
+	}}}, input.GetStepsToPrepend()); d != "" {
+		t.Errorf("Input steps diff -want, +got: %s", d)
+	}
+	output, err := resource.GetOutputTaskModifier(&v1alpha1.TaskSpec{}, "/workspace/output/jar")
+	if err != nil {
+		t.Fatalf("GetOutputTaskModifier() = %v", err)
+	}
+	if d := cmp.Diff([]v1alpha1.Step{{Container: corev1.Container{
+		Name:  "output-jar-mz4c7",
+		Image: "example.com/artifactory-plugin",
+		Args:  []string{"-mode", "output", "-path", "/workspace/output/jar", "-resource", resourceJSON},
+		Env:   env,
+	}}}, output.GetStepsToAppend()); d != "" {
+		t.Errorf("Output steps diff -want, +got: %s", d)
+	}
+}
+
+func TestUnregisteredResourceType(t *testing.T) {
+	v1alpha1.RegisterResourceType("artifactory", v1alpha1.PluginResourceType{Image: "example.com/artifactory-plugin"})
+	v1alpha1.UnregisterResourceType("artifactory")
+
+	r := tb.PipelineResource("jar", "default", tb.PipelineResourceSpec("artifactory"))
+	if err := r.Validate(context.Background()); err == nil {
+		t.Error("Validate() of a PipelineResource of an unregistered type should fail")
+	}
+	if _, err := v1alpha1.ResourceFromType(r, pipeline.Images{}); err == nil {
+		t.Error("ResourceFromType() of a PipelineResource of an unregistered type should fail")
+	}
+}
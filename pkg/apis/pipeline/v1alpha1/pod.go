@@ -18,6 +18,8 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
 )
 
 // PodTemplate holds pod specific configuration
@@ -55,4 +57,50 @@ type PodTemplate struct {
 	// This is a beta feature as of Kubernetes v1.14.
 	// +optional
 	RuntimeClassName *string `json:"runtimeClassName,omitempty" protobuf:"bytes,2,opt,name=runtimeClassName"`
+
+	// ActiveDeadlineSeconds is the duration in seconds the pod may be active
+	// on the node before the kubelet kills it. Unlike the timeout of the
+	// TaskRun it doesn't include the time the pod is pending.
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// EnableServiceLinks indicates whether information about services should
+	// be injected into the pod's environment variables, matching the syntax
+	// of Docker links. Defaults to true.
+	// +optional
+	EnableServiceLinks *bool `json:"enableServiceLinks,omitempty"`
+
+	// AutomountServiceAccountToken indicates whether the token of the service
+	// account should be mounted in the pod.
+	// +optional
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+
+	// SchedulerName is the name of the scheduler dispatching the pod. If
+	// unset, the pod is dispatched by the default scheduler.
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// ImagePullSecrets are the names of the Secrets, in the namespace of the
+	// pod, used to pull the images of its containers, in addition to those
+	// of its service account.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
+
+// validate checks the fields of the pod template that the API server would
+// otherwise only reject when the pod is created, long after the run was
+// accepted.
+func (pt *PodTemplate) validate() *apis.FieldError {
+	if pt == nil {
+		return nil
+	}
+	if pt.ActiveDeadlineSeconds != nil && *pt.ActiveDeadlineSeconds <= 0 {
+		return apis.ErrInvalidValue(*pt.ActiveDeadlineSeconds, "activeDeadlineSeconds")
+	}
+	if pt.SchedulerName != "" {
+		if errs := validation.IsDNS1123Subdomain(pt.SchedulerName); len(errs) > 0 {
+			return apis.ErrInvalidValue(pt.SchedulerName, "schedulerName")
+		}
+	}
+	return nil
 }
@@ -40,7 +40,7 @@ type PullRequestResource struct {
 	// URL pointing to the pull request.
 	// Example: https://github.com/owner/repo/pulls/1
 	URL string `json:"url"`
-	// SCM provider (github or gitlab today). This will be guessed from URL if not set.
+	// SCM provider (github, gitlab or gerrit). This will be guessed from URL if not set.
 	Provider string `json:"provider"`
 	// Secrets holds a struct to indicate a field name and corresponding secret name to populate it.
 	Secrets []SecretParam `json:"secrets"`
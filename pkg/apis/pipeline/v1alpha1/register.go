@@ -50,6 +50,16 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TaskList{},
 		&Condition{},
 		&ConditionList{},
+		&NotificationPolicy{},
+		&NotificationPolicyList{},
+		&StorageMigration{},
+		&StorageMigrationList{},
+		&TektonPipelineConfig{},
+		&TektonPipelineConfigList{},
+		&ResolutionRequest{},
+		&ResolutionRequestList{},
+		&Run{},
+		&RunList{},
 		&ClusterTask{},
 		&ClusterTaskList{},
 		&TaskRun{},
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+// ResolverRef references a resource a resolver fetches from outside the
+// cluster, e.g. a Task in a git repository.
+type ResolverRef struct {
+	// Resolver is the name of the resolver fetching the resource, e.g. git.
+	// +optional
+	Resolver string `json:"resolver,omitempty"`
+	// Params are the parameters of the Resolver, e.g. the url of the git
+	// repository and the path of the resource in it.
+	// +optional
+	Params []Param `json:"params,omitempty"`
+}
+
+// ParamsMap returns the values of the Params by name.
+func (rr ResolverRef) ParamsMap() map[string]string {
+	params := make(map[string]string, len(rr.Params))
+	for _, p := range rr.Params {
+		params[p.Name] = p.Value.StringVal
+	}
+	return params
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResolutionRequest asks a resolver for a remote resource, e.g. the Task of a
+// TaskRun whose taskRef has a resolver. The controller of the resolver
+// writes the resource to its status.
+// +k8s:openapi-gen=true
+type ResolutionRequest struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the ResolutionRequest from the client
+	// +optional
+	Spec ResolutionRequestSpec `json:"spec"`
+	// +optional
+	Status ResolutionRequestStatus `json:"status"`
+}
+
+// ResolutionRequestSpec defines the desired state of the ResolutionRequest
+type ResolutionRequestSpec struct {
+	ResolverRef `json:",inline"`
+}
+
+var resolutionRequestCondSet = apis.NewBatchConditionSet()
+
+// ResolutionRequestStatus defines the observed state of the
+// ResolutionRequest
+type ResolutionRequestStatus struct {
+	duckv1beta1.Status `json:",inline"`
+
+	// Data is the resolved resource, in YAML.
+	// +optional
+	Data string `json:"data,omitempty"`
+}
+
+// GetCondition returns the Condition matching the given type.
+func (rrs *ResolutionRequestStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return resolutionRequestCondSet.Manage(rrs).GetCondition(t)
+}
+
+// InitializeConditions sets the Succeeded condition to unknown.
+func (rrs *ResolutionRequestStatus) InitializeConditions() {
+	resolutionRequestCondSet.Manage(rrs).InitializeConditions()
+}
+
+// SetCondition sets the condition, unsetting previous conditions with the same
+// type as necessary.
+func (rrs *ResolutionRequestStatus) SetCondition(newCond *apis.Condition) {
+	if newCond != nil {
+		resolutionRequestCondSet.Manage(rrs).SetCondition(*newCond)
+	}
+}
+
+// IsDone returns true if the ResolutionRequest was resolved or failed.
+func (rr *ResolutionRequest) IsDone() bool {
+	return !rr.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResolutionRequestList contains a list of ResolutionRequests
+type ResolutionRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResolutionRequest `json:"items"`
+}
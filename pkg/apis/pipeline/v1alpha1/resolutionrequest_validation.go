@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*ResolutionRequest)(nil)
+
+func (rr *ResolutionRequest) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(rr.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	if rr.Spec.Resolver == "" {
+		return apis.ErrMissingField("spec.resolver")
+	}
+	return rr.Spec.ResolverRef.validate().ViaField("spec")
+}
+
+// validate checks that the Resolver is a DNS label and that the Params are
+// uniquely named strings, when a Resolver is set.
+func (rr ResolverRef) validate() *apis.FieldError {
+	if rr.Resolver == "" {
+		if len(rr.Params) > 0 {
+			return apis.ErrMissingField("resolver")
+		}
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(rr.Resolver); len(errs) > 0 {
+		return apis.ErrInvalidValue(strings.Join(errs, ","), "resolver")
+	}
+	names := map[string]struct{}{}
+	for i, p := range rr.Params {
+		if p.Name == "" {
+			return apis.ErrMissingField(fmt.Sprintf("params[%d].name", i))
+		}
+		if _, ok := names[p.Name]; ok {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s is declared more than once", p.Name), fmt.Sprintf("params[%d].name", i))
+		}
+		names[p.Name] = struct{}{}
+		if p.Value.Type != ParamTypeString {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be a string", p.Name), fmt.Sprintf("params[%d].value", i))
+		}
+	}
+	return nil
+}
+
+// validateResolver checks that a TaskRef with a Resolver doesn't also name a
+// Task or reference a ClusterTask.
+func (tr TaskRef) validateResolver() *apis.FieldError {
+	if tr.Resolver == "" {
+		return tr.ResolverRef.validate()
+	}
+	if tr.Name != "" {
+		return apis.ErrMultipleOneOf("name", "resolver")
+	}
+	if tr.Kind == ClusterTaskKind {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s can't be fetched by a resolver", tr.Kind), "kind")
+	}
+	return tr.ResolverRef.validate()
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestResolutionRequest_Validate(t *testing.T) {
+	rr := &v1alpha1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "rr"},
+		Spec: v1alpha1.ResolutionRequestSpec{ResolverRef: v1alpha1.ResolverRef{
+			Resolver: "git",
+			Params: []v1alpha1.Param{
+				{Name: "url", Value: *tb.ArrayOrString("https://github.com/tektoncd/catalog")},
+				{Name: "path", Value: *tb.ArrayOrString("task/git-clone/0.1/git-clone.yaml")},
+			},
+		}},
+	}
+	if err := rr.Validate(context.Background()); err != nil {
+		t.Errorf("ResolutionRequest.Validate() unexpected error = %v", err)
+	}
+}
+
+func TestResolutionRequest_Invalidate(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		ref           v1alpha1.ResolverRef
+		expectedError apis.FieldError
+	}{{
+		name: "no resolver",
+		ref:  v1alpha1.ResolverRef{},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.resolver"},
+		},
+	}, {
+		name: "invalid resolver",
+		ref:  v1alpha1.ResolverRef{Resolver: "Git_Hub"},
+		expectedError: apis.FieldError{
+			Message: `invalid value: a DNS-1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`,
+			Paths:   []string{"spec.resolver"},
+		},
+	}, {
+		name: "param without name",
+		ref: v1alpha1.ResolverRef{Resolver: "git", Params: []v1alpha1.Param{
+			{Value: *tb.ArrayOrString("https://github.com/tektoncd/catalog")},
+		}},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.params[0].name"},
+		},
+	}, {
+		name: "duplicate param",
+		ref: v1alpha1.ResolverRef{Resolver: "git", Params: []v1alpha1.Param{
+			{Name: "url", Value: *tb.ArrayOrString("https://github.com/tektoncd/catalog")},
+			{Name: "url", Value: *tb.ArrayOrString("https://github.com/tektoncd/pipeline")},
+		}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: url is declared more than once",
+			Paths:   []string{"spec.params[1].name"},
+		},
+	}, {
+		name: "array param",
+		ref: v1alpha1.ResolverRef{Resolver: "git", Params: []v1alpha1.Param{
+			{Name: "path", Value: *tb.ArrayOrString("a.yaml", "b.yaml")},
+		}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: path should be a string",
+			Paths:   []string{"spec.params[0].value"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := &v1alpha1.ResolutionRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "rr"},
+				Spec:       v1alpha1.ResolutionRequestSpec{ResolverRef: tc.ref},
+			}
+			err := rr.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an Error, got nothing for %v", tc)
+			}
+			if d := cmp.Diff(tc.expectedError, *err, cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ResolutionRequest.Validate() errors diff -want, +got: %v", d)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 var _ apis.Defaultable = (*PipelineResource)(nil)
 
 func (t *PipelineResource) SetDefaults(ctx context.Context) {
+	defer recordDefaultedFields(ctx, t)()
 	t.Spec.SetDefaults(ctx)
 }
 
@@ -0,0 +1,138 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"knative.dev/pkg/apis"
+)
+
+// ResourceType implements a type of PipelineResource: how a PipelineResource
+// of that type is validated, and the steps it adds to the Tasks it is an input
+// or an output of.
+type ResourceType interface {
+	// Validate returns the error in the spec of a PipelineResource of this
+	// type, if any.
+	Validate(ctx context.Context, rs *PipelineResourceSpec) *apis.FieldError
+	// New returns the implementation of r, a PipelineResource of this type.
+	New(r *PipelineResource, images pipeline.Images) (PipelineResourceInterface, error)
+}
+
+// builtinResourceType is a ResourceType implemented by Tekton Pipelines.
+type builtinResourceType struct {
+	validate func(rs *PipelineResourceSpec) *apis.FieldError
+	new      func(r *PipelineResource, images pipeline.Images) (PipelineResourceInterface, error)
+}
+
+func (t builtinResourceType) Validate(_ context.Context, rs *PipelineResourceSpec) *apis.FieldError {
+	if t.validate == nil {
+		return nil
+	}
+	return t.validate(rs)
+}
+
+func (t builtinResourceType) New(r *PipelineResource, images pipeline.Images) (PipelineResourceInterface, error) {
+	return t.new(r, images)
+}
+
+var (
+	resourceTypesMu sync.RWMutex
+	resourceTypes   = map[PipelineResourceType]ResourceType{
+		PipelineResourceTypeGit: builtinResourceType{
+			new: func(r *PipelineResource, images pipeline.Images) (PipelineResourceInterface, error) {
+				return NewGitResource(images.GitImage, r)
+			},
+		},
+		PipelineResourceTypeImage: builtinResourceType{
+			new: func(r *PipelineResource, _ pipeline.Images) (PipelineResourceInterface, error) {
+				return NewImageResource(r)
+			},
+		},
+		PipelineResourceTypeCluster: builtinResourceType{
+			validate: validateClusterResource,
+			new: func(r *PipelineResource, images pipeline.Images) (PipelineResourceInterface, error) {
+				return NewClusterResource(images.KubeconfigWriterImage, r)
+			},
+		},
+		PipelineResourceTypeStorage: builtinResourceType{
+			validate: validateStorageResource,
+			new: func(r *PipelineResource, images pipeline.Images) (PipelineResourceInterface, error) {
+				return NewStorageResource(images, r)
+			},
+		},
+		PipelineResourceTypePullRequest: builtinResourceType{
+			new: func(r *PipelineResource, images pipeline.Images) (PipelineResourceInterface, error) {
+				return NewPullRequestResource(images.PRImage, r)
+			},
+		},
+		PipelineResourceTypeCloudEvent: builtinResourceType{
+			new: func(r *PipelineResource, _ pipeline.Images) (PipelineResourceInterface, error) {
+				return NewCloudEventResource(r)
+			},
+		},
+		PipelineResourceTypeHTTP: builtinResourceType{
+			validate: validateHTTPResource,
+			new: func(r *PipelineResource, images pipeline.Images) (PipelineResourceInterface, error) {
+				return NewHTTPResource(images, r)
+			},
+		},
+		PipelineResourceTypeMaven: builtinResourceType{
+			validate: validateMavenResource,
+			new: func(r *PipelineResource, images pipeline.Images) (PipelineResourceInterface, error) {
+				return NewMavenResource(images, r)
+			},
+		},
+	}
+)
+
+// RegisterResourceType makes the PipelineResources of type t implemented by
+// rt, replacing the ResourceType t had, if any.
+func RegisterResourceType(t PipelineResourceType, rt ResourceType) {
+	resourceTypesMu.Lock()
+	defer resourceTypesMu.Unlock()
+	resourceTypes[t] = rt
+}
+
+// UnregisterResourceType removes the ResourceType of t: PipelineResources of
+// type t are then rejected.
+func UnregisterResourceType(t PipelineResourceType) {
+	resourceTypesMu.Lock()
+	defer resourceTypesMu.Unlock()
+	delete(resourceTypes, t)
+}
+
+// GetResourceType returns the ResourceType implementing the PipelineResources
+// of type t, and false if there is none.
+func GetResourceType(t PipelineResourceType) (ResourceType, bool) {
+	resourceTypesMu.RLock()
+	defer resourceTypesMu.RUnlock()
+	rt, ok := resourceTypes[t]
+	return rt, ok
+}
+
+// IsBuiltinResourceType returns true if t is implemented by Tekton Pipelines.
+func IsBuiltinResourceType(t PipelineResourceType) bool {
+	for _, builtin := range AllResourceTypes {
+		if builtin == t {
+			return true
+		}
+	}
+	return false
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -52,6 +53,12 @@ const (
 
 	// PipelineResourceTypeCloudEvent indicates that this source is a cloud event URI
 	PipelineResourceTypeCloudEvent PipelineResourceType = v1alpha2.PipelineResourceTypeCloudEvent
+
+	// PipelineResourceTypeHTTP indicates that this source is an artifact downloaded from, or uploaded to, a URL.
+	PipelineResourceTypeHTTP PipelineResourceType = v1alpha2.PipelineResourceTypeHTTP
+
+	// PipelineResourceTypeMaven indicates that this source is an artifact downloaded from, or deployed to, a Maven repository.
+	PipelineResourceTypeMaven PipelineResourceType = v1alpha2.PipelineResourceTypeMaven
 )
 
 // AllResourceTypes can be used for validation to check if a provided Resource type is one of the known types.
@@ -92,7 +99,8 @@ func checkStepNotAlreadyAdded(s Step, steps []Step) error {
 // ApplyTaskModifier applies a modifier to the task by appending and prepending steps and volumes.
 // If steps with the same name exist in ts an error will be returned. If identical Volumes have
 // been added, they will not be added again. If Volumes with the same name but different contents
-// have been added, an error will be returned.
+// have been added, an error will be returned. The extensions of the modifier are applied too,
+// see ExtendedTaskModifier.
 // FIXME(vdemeester) de-duplicate this
 func ApplyTaskModifier(ts *TaskSpec, tm TaskModifier) error {
 	steps := tm.GetStepsToPrepend()
@@ -111,7 +119,14 @@ func ApplyTaskModifier(ts *TaskSpec, tm TaskModifier) error {
 	}
 	ts.Steps = append(ts.Steps, steps...)
 
-	volumes := tm.GetVolumes()
+	if err := addVolumes(ts, tm.GetVolumes()); err != nil {
+		return err
+	}
+	return applyTaskModifierExtensions(ts, ExtendTaskModifier(tm))
+}
+
+// addVolumes adds the volumes that haven't been added to ts yet.
+func addVolumes(ts *TaskSpec, volumes []corev1.Volume) error {
 	for _, volume := range volumes {
 		var alreadyAdded bool
 		for _, v := range ts.Volumes {
@@ -189,8 +204,24 @@ type PipelineResourceResult struct {
 	Key         string              `json:"key"`
 	Value       string              `json:"value"`
 	ResourceRef PipelineResourceRef `json:"resourceRef,omitempty"`
+	// ResultType identifies what produced the result. It is empty in
+	// results written before the termination message schema was versioned.
+	// +optional
+	ResultType ResultType `json:"type,omitempty"`
 }
 
+// ResultType indicates what produced a PipelineResourceResult.
+type ResultType string
+
+const (
+	// PipelineResourceResultType is the ResultType of results reported by a
+	// PipelineResource, e.g. the digest of a built image.
+	PipelineResourceResultType ResultType = "PipelineResourceResult"
+	// TaskRunResultType is the ResultType of the results a Task declares,
+	// which the entrypoint reads from the files its steps wrote.
+	TaskRunResultType ResultType = "TaskRunResult"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // PipelineResourceList contains a list of PipelineResources
@@ -210,21 +241,11 @@ type ResourceDeclaration = v1alpha2.ResourceDeclaration
 
 // ResourceFromType returns an instance of the correct PipelineResource object type which can be
 // used to add input and ouput containers as well as volumes to a TaskRun's pod in order to realize
-// a PipelineResource in a pod.
+// a PipelineResource in a pod. It uses the ResourceType registered for the
+// type of r.
 func ResourceFromType(r *PipelineResource, images pipeline.Images) (PipelineResourceInterface, error) {
-	switch r.Spec.Type {
-	case PipelineResourceTypeGit:
-		return NewGitResource(images.GitImage, r)
-	case PipelineResourceTypeImage:
-		return NewImageResource(r)
-	case PipelineResourceTypeCluster:
-		return NewClusterResource(images.KubeconfigWriterImage, r)
-	case PipelineResourceTypeStorage:
-		return NewStorageResource(images, r)
-	case PipelineResourceTypePullRequest:
-		return NewPullRequestResource(images.PRImage, r)
-	case PipelineResourceTypeCloudEvent:
-		return NewCloudEventResource(r)
+	if rt, ok := GetResourceType(r.Spec.Type); ok {
+		return rt.New(r, images)
 	}
 	return nil, fmt.Errorf("%s is an invalid or unimplemented PipelineResource", r.Spec.Type)
 }
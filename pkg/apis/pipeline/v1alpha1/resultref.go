@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var resultRefFormat = regexp.MustCompile(`\$\(tasks\.([^.)]+)\.results\.([^.)]+)\)`)
+
+// taskVariableFormat matches all the variables starting with tasks., which
+// should be result references.
+var taskVariableFormat = regexp.MustCompile(`\$\(tasks\.[^)]*\)`)
+
+// ResultRef is a reference to a result of the TaskRun of a PipelineTask,
+// written $(tasks.<pipeline task>.results.<result>).
+type ResultRef struct {
+	PipelineTask string
+	Result       string
+}
+
+// Variable returns the variable that r is written as, without the enclosing
+// $().
+func (r ResultRef) Variable() string {
+	return fmt.Sprintf("tasks.%s.results.%s", r.PipelineTask, r.Result)
+}
+
+// ResultRefs returns the results the params, the matrix and the
+// WhenExpressions of the PipelineTask reference.
+func (pt PipelineTask) ResultRefs() []ResultRef {
+	var values []string
+	for _, p := range append(append([]Param{}, pt.Params...), pt.Matrix...) {
+		values = append(values, p.Value.StringVal)
+		values = append(values, p.Value.ArrayVal...)
+	}
+	for _, we := range pt.WhenExpressions {
+		values = append(values, we.Input)
+		values = append(values, we.Values...)
+	}
+	var refs []ResultRef
+	for _, v := range values {
+		for _, match := range resultRefFormat.FindAllStringSubmatch(v, -1) {
+			refs = append(refs, ResultRef{PipelineTask: match[1], Result: match[2]})
+		}
+	}
+	return refs
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
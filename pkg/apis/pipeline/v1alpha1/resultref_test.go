@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+func TestPipelineTask_ResultRefs(t *testing.T) {
+	pt := v1alpha1.PipelineTask{
+		Name: "deploy",
+		Params: []v1alpha1.Param{{
+			Name:  "image",
+			Value: *tb.ArrayOrString("registry/app@$(tasks.build.results.digest)"),
+		}, {
+			Name:  "args",
+			Value: *tb.ArrayOrString("--commit=$(tasks.clone.results.commit)", "$(params.extra)"),
+		}},
+		WhenExpressions: v1alpha1.WhenExpressions{{
+			Input:    "$(tasks.clone.results.branch)",
+			Operator: selection.In,
+			Values:   []string{"main"},
+		}},
+	}
+	want := []v1alpha1.ResultRef{
+		{PipelineTask: "build", Result: "digest"},
+		{PipelineTask: "clone", Result: "commit"},
+		{PipelineTask: "clone", Result: "branch"},
+	}
+	if d := cmp.Diff(want, pt.ResultRefs()); d != "" {
+		t.Errorf("ResultRefs() diff -want, +got: %s", d)
+	}
+}
+
+func TestPipelineTask_DepsWithResultRefs(t *testing.T) {
+	pt := v1alpha1.PipelineTask{
+		Name:     "deploy",
+		RunAfter: []string{"clone"},
+		Params: []v1alpha1.Param{{
+			Name:  "image",
+			Value: *tb.ArrayOrString("$(tasks.build.results.digest)"),
+		}, {
+			Name:  "commit",
+			Value: *tb.ArrayOrString("$(tasks.clone.results.commit)"),
+		}},
+	}
+	want := []string{"clone", "build"}
+	if d := cmp.Diff(want, pt.Deps()); d != "" {
+		t.Errorf("Deps() diff -want, +got: %s", d)
+	}
+}
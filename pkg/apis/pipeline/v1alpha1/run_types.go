@@ -0,0 +1,137 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+// RunSpecStatusCancelled indicates that the user wants to cancel the Run,
+// if not already cancelled or terminated
+const RunSpecStatusCancelled = "RunCancelled"
+
+// IsCustomTask returns true if tr references a custom task, by the apiVersion
+// and kind of another API than the Tasks and ClusterTasks of Tekton.
+func (tr TaskRef) IsCustomTask() bool {
+	if tr.APIVersion == "" || tr.Kind == "" {
+		return false
+	}
+	return !strings.HasPrefix(tr.APIVersion, pipeline.GroupName+"/") || tr.Kind != NamespacedTaskKind && tr.Kind != ClusterTaskKind
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Run runs a custom task: a kind of another API than Tekton's, which the
+// taskRef of a PipelineTask references. Tekton doesn't run it: the controller
+// of the custom task reconciles the Run and updates its status.
+// +k8s:openapi-gen=true
+type Run struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the Run from the client
+	// +optional
+	Spec RunSpec `json:"spec"`
+	// +optional
+	Status RunStatus `json:"status"`
+}
+
+// RunSpec defines the desired state of the Run
+type RunSpec struct {
+	// Ref references the custom task the Run runs, by apiVersion, kind and
+	// name.
+	Ref *TaskRef `json:"ref,omitempty"`
+	// Params are the parameters of the custom task.
+	// +optional
+	Params []Param `json:"params,omitempty"`
+	// Status is used to cancel the Run, which the controller of the custom
+	// task is expected to stop.
+	// +optional
+	Status string `json:"status,omitempty"`
+	// Timeout is the time the Run may take, which the controller of the
+	// custom task is expected to enforce.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+var runCondSet = apis.NewBatchConditionSet()
+
+// RunStatus defines the observed state of the Run, which the controller of
+// the custom task updates.
+type RunStatus struct {
+	duckv1beta1.Status `json:",inline"`
+
+	// StartTime is the time the custom task started running.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the custom task completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Results are the results the custom task reported, which the other
+	// PipelineTasks can use as those of TaskRuns.
+	// +optional
+	Results []TaskRunResult `json:"results,omitempty"`
+	// RetriesStatus contains the history of the statuses of the Run, once
+	// for each time the PipelineRun retried it.
+	// +optional
+	RetriesStatus []RunStatus `json:"retriesStatus,omitempty"`
+}
+
+// GetCondition returns the Condition matching the given type.
+func (rs *RunStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return runCondSet.Manage(rs).GetCondition(t)
+}
+
+// InitializeConditions sets the Succeeded condition to unknown.
+func (rs *RunStatus) InitializeConditions() {
+	runCondSet.Manage(rs).InitializeConditions()
+}
+
+// SetCondition sets the condition, unsetting previous conditions with the same
+// type as necessary.
+func (rs *RunStatus) SetCondition(newCond *apis.Condition) {
+	if newCond != nil {
+		runCondSet.Manage(rs).SetCondition(*newCond)
+	}
+}
+
+// IsDone returns true if the custom task of the Run succeeded or failed.
+func (r *Run) IsDone() bool {
+	return !r.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
+}
+
+// IsCancelled returns true if the Run's spec status is set to Cancelled state
+func (r *Run) IsCancelled() bool {
+	return r.Spec.Status == RunSpecStatusCancelled
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RunList contains a list of Runs
+type RunList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Run `json:"items"`
+}
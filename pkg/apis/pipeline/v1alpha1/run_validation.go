@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*Run)(nil)
+
+func (r *Run) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(r.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	return r.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate checks that the RunSpec references a custom task and that its
+// Params are uniquely named.
+func (rs *RunSpec) Validate(ctx context.Context) *apis.FieldError {
+	if rs.Ref == nil {
+		return apis.ErrMissingField("ref")
+	}
+	if rs.Ref.APIVersion == "" {
+		return apis.ErrMissingField("ref.apiVersion")
+	}
+	if rs.Ref.Kind == "" {
+		return apis.ErrMissingField("ref.kind")
+	}
+	if rs.Ref.Name == "" {
+		return apis.ErrMissingField("ref.name")
+	}
+	if !rs.Ref.IsCustomTask() {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s is run by a TaskRun", rs.Ref.Kind), "ref.kind")
+	}
+	names := map[string]struct{}{}
+	for i, p := range rs.Params {
+		if _, ok := names[p.Name]; ok {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s is declared more than once", p.Name), fmt.Sprintf("params[%d].name", i))
+		}
+		names[p.Name] = struct{}{}
+	}
+	if rs.Status != "" && rs.Status != RunSpecStatusCancelled {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", rs.Status, RunSpecStatusCancelled), "status")
+	}
+	if rs.Timeout != nil && rs.Timeout.Duration < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", rs.Timeout.Duration.String()), "timeout")
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestRun_Validate(t *testing.T) {
+	r := &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{Name: "run"},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait", Name: "wait"},
+			Params: []v1alpha1.Param{
+				{Name: "duration", Value: *tb.ArrayOrString("10s")},
+			},
+			Timeout: &metav1.Duration{Duration: time.Minute},
+		},
+	}
+	if err := r.Validate(context.Background()); err != nil {
+		t.Errorf("Run.Validate() unexpected error = %v", err)
+	}
+}
+
+func TestRun_Invalidate(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		spec          v1alpha1.RunSpec
+		expectedError apis.FieldError
+	}{{
+		name: "no ref",
+		spec: v1alpha1.RunSpec{},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.ref"},
+		},
+	}, {
+		name: "no apiVersion",
+		spec: v1alpha1.RunSpec{Ref: &v1alpha1.TaskRef{Kind: "Wait", Name: "wait"}},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.ref.apiVersion"},
+		},
+	}, {
+		name: "no kind",
+		spec: v1alpha1.RunSpec{Ref: &v1alpha1.TaskRef{APIVersion: "example.dev/v0", Name: "wait"}},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.ref.kind"},
+		},
+	}, {
+		name: "no name",
+		spec: v1alpha1.RunSpec{Ref: &v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait"}},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.ref.name"},
+		},
+	}, {
+		name: "tekton task",
+		spec: v1alpha1.RunSpec{Ref: &v1alpha1.TaskRef{APIVersion: "tekton.dev/v1alpha1", Kind: v1alpha1.NamespacedTaskKind, Name: "build"}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: Task is run by a TaskRun",
+			Paths:   []string{"spec.ref.kind"},
+		},
+	}, {
+		name: "duplicate param",
+		spec: v1alpha1.RunSpec{
+			Ref: &v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait", Name: "wait"},
+			Params: []v1alpha1.Param{
+				{Name: "duration", Value: *tb.ArrayOrString("10s")},
+				{Name: "duration", Value: *tb.ArrayOrString("20s")},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: duration is declared more than once",
+			Paths:   []string{"spec.params[1].name"},
+		},
+	}, {
+		name: "invalid status",
+		spec: v1alpha1.RunSpec{
+			Ref:    &v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait", Name: "wait"},
+			Status: "Paused",
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: Paused should be RunCancelled",
+			Paths:   []string{"spec.status"},
+		},
+	}, {
+		name: "negative timeout",
+		spec: v1alpha1.RunSpec{
+			Ref:     &v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait", Name: "wait"},
+			Timeout: &metav1.Duration{Duration: -time.Minute},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1m0s should be >= 0",
+			Paths:   []string{"spec.timeout"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &v1alpha1.Run{
+				ObjectMeta: metav1.ObjectMeta{Name: "run"},
+				Spec:       tc.spec,
+			}
+			err := r.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an Error, got nothing for %v", tc)
+			}
+			if d := cmp.Diff(tc.expectedError, *err, cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("Run.Validate() errors diff -want, +got: %v", d)
+			}
+		})
+	}
+}
+
+func TestTaskRef_IsCustomTask(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ref  v1alpha1.TaskRef
+		want bool
+	}{{
+		name: "task",
+		ref:  v1alpha1.TaskRef{Name: "build"},
+	}, {
+		name: "cluster task",
+		ref:  v1alpha1.TaskRef{Name: "build", Kind: v1alpha1.ClusterTaskKind},
+	}, {
+		name: "task with apiVersion",
+		ref:  v1alpha1.TaskRef{Name: "build", APIVersion: "tekton.dev/v1alpha1", Kind: v1alpha1.NamespacedTaskKind},
+	}, {
+		name: "apiVersion without kind",
+		ref:  v1alpha1.TaskRef{Name: "wait", APIVersion: "example.dev/v0"},
+	}, {
+		name: "custom task",
+		ref:  v1alpha1.TaskRef{Name: "wait", APIVersion: "example.dev/v0", Kind: "Wait"},
+		want: true,
+	}, {
+		name: "custom task named Task",
+		ref:  v1alpha1.TaskRef{Name: "build", APIVersion: "example.dev/v0", Kind: v1alpha1.NamespacedTaskKind},
+		want: true,
+	}, {
+		name: "custom kind of tekton.dev",
+		ref:  v1alpha1.TaskRef{Name: "wait", APIVersion: "tekton.dev/v1alpha1", Kind: "Wait"},
+		want: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.ref.IsCustomTask(); got != tc.want {
+				t.Errorf("IsCustomTask() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
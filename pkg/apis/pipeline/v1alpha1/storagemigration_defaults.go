@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// DefaultStorageMigrationPageSize is the number of objects listed at once
+	// by the StorageMigrations that don't specify it.
+	DefaultStorageMigrationPageSize = 500
+
+	// DefaultStorageMigrationObjectsPerSecond is the rate of the
+	// StorageMigrations that don't specify it.
+	DefaultStorageMigrationObjectsPerSecond = 20
+)
+
+var _ apis.Defaultable = (*StorageMigration)(nil)
+
+func (sm *StorageMigration) SetDefaults(ctx context.Context) {
+	defer recordDefaultedFields(ctx, sm)()
+	sm.Spec.SetDefaults(ctx)
+}
+
+func (sms *StorageMigrationSpec) SetDefaults(ctx context.Context) {
+	if sms.PageSize == 0 {
+		sms.PageSize = DefaultStorageMigrationPageSize
+	}
+	if sms.ObjectsPerSecond == 0 {
+		sms.ObjectsPerSecond = DefaultStorageMigrationObjectsPerSecond
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StorageMigration rewrites every object of a resource of the tekton.dev
+// group, so that they are all stored in the current storage version of its
+// CustomResourceDefinition.
+// +k8s:openapi-gen=true
+type StorageMigration struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the StorageMigration from the client
+	// +optional
+	Spec StorageMigrationSpec `json:"spec"`
+	// +optional
+	Status StorageMigrationStatus `json:"status"`
+}
+
+// StorageMigrationResources are the resources that can be migrated.
+var StorageMigrationResources = []string{
+	"clustertasks",
+	"conditions",
+	"notificationpolicies",
+	"pipelineresources",
+	"pipelineruns",
+	"pipelines",
+	"taskruns",
+	"tasks",
+}
+
+// StorageMigrationSpec defines the desired state of the StorageMigration
+type StorageMigrationSpec struct {
+	// Resource is the plural name of the resource to migrate, for example
+	// taskruns.
+	Resource string `json:"resource"`
+
+	// PageSize is the number of objects that are listed at once.
+	// +optional
+	PageSize int64 `json:"pageSize,omitempty"`
+
+	// ObjectsPerSecond is the maximum number of objects rewritten every
+	// second.
+	// +optional
+	ObjectsPerSecond int32 `json:"objectsPerSecond,omitempty"`
+}
+
+var storageMigrationCondSet = apis.NewBatchConditionSet()
+
+// StorageMigrationStatus defines the observed state of the StorageMigration
+type StorageMigrationStatus struct {
+	duckv1beta1.Status `json:",inline"`
+
+	// StartTime is the time the migration started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time every object was migrated.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Continue is the token listing the objects that remain to be migrated.
+	// The migration resumes from it when the controller restarts.
+	// +optional
+	Continue string `json:"continue,omitempty"`
+
+	// MigratedObjects is the number of objects migrated so far.
+	// +optional
+	MigratedObjects int64 `json:"migratedObjects,omitempty"`
+
+	// FailedObjects are the objects, as "namespace/name" or "name" for
+	// cluster-scoped ones, that couldn't be rewritten. They are skipped.
+	// +optional
+	FailedObjects []string `json:"failedObjects,omitempty"`
+}
+
+// GetCondition returns the Condition matching the given type.
+func (sms *StorageMigrationStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return storageMigrationCondSet.Manage(sms).GetCondition(t)
+}
+
+// InitializeConditions sets the Succeeded condition to unknown and the start
+// time to the current time.
+func (sms *StorageMigrationStatus) InitializeConditions() {
+	if sms.StartTime.IsZero() {
+		sms.StartTime = &metav1.Time{Time: time.Now()}
+	}
+	storageMigrationCondSet.Manage(sms).InitializeConditions()
+}
+
+// SetCondition sets the condition, unsetting previous conditions with the same
+// type as necessary.
+func (sms *StorageMigrationStatus) SetCondition(newCond *apis.Condition) {
+	if newCond != nil {
+		storageMigrationCondSet.Manage(sms).SetCondition(*newCond)
+	}
+}
+
+// IsDone returns true if the StorageMigration completed or failed.
+func (sm *StorageMigration) IsDone() bool {
+	return !sm.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StorageMigrationList contains a list of StorageMigrations
+type StorageMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StorageMigration `json:"items"`
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*StorageMigration)(nil)
+
+func (sm *StorageMigration) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(sm.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	return sm.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (sms *StorageMigrationSpec) Validate(ctx context.Context) *apis.FieldError {
+	if sms.Resource == "" {
+		return apis.ErrMissingField("resource")
+	}
+	supported := false
+	for _, r := range StorageMigrationResources {
+		supported = supported || r == sms.Resource
+	}
+	if !supported {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be one of %s", sms.Resource, strings.Join(StorageMigrationResources, ", ")), "resource")
+	}
+	if sms.PageSize < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%d should be positive", sms.PageSize), "pageSize")
+	}
+	if sms.ObjectsPerSecond < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%d should be positive", sms.ObjectsPerSecond), "objectsPerSecond")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestStorageMigration_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec v1alpha1.StorageMigrationSpec
+	}{{
+		name: "resource",
+		spec: v1alpha1.StorageMigrationSpec{Resource: "taskruns"},
+	}, {
+		name: "page size and rate",
+		spec: v1alpha1.StorageMigrationSpec{Resource: "pipelineruns", PageSize: 100, ObjectsPerSecond: 50},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sm := &v1alpha1.StorageMigration{
+				ObjectMeta: metav1.ObjectMeta{Name: "sm"},
+				Spec:       tc.spec,
+			}
+			if err := sm.Validate(context.Background()); err != nil {
+				t.Errorf("StorageMigration.Validate() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStorageMigration_Invalidate(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		spec          v1alpha1.StorageMigrationSpec
+		expectedError apis.FieldError
+	}{{
+		name: "no resource",
+		spec: v1alpha1.StorageMigrationSpec{},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.resource"},
+		},
+	}, {
+		name: "unsupported resource",
+		spec: v1alpha1.StorageMigrationSpec{Resource: "pods"},
+		expectedError: apis.FieldError{
+			Message: "invalid value: pods should be one of clustertasks, conditions, notificationpolicies, pipelineresources, pipelineruns, pipelines, taskruns, tasks",
+			Paths:   []string{"spec.resource"},
+		},
+	}, {
+		name: "negative page size",
+		spec: v1alpha1.StorageMigrationSpec{Resource: "taskruns", PageSize: -1},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1 should be positive",
+			Paths:   []string{"spec.pageSize"},
+		},
+	}, {
+		name: "negative rate",
+		spec: v1alpha1.StorageMigrationSpec{Resource: "taskruns", ObjectsPerSecond: -1},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1 should be positive",
+			Paths:   []string{"spec.objectsPerSecond"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sm := &v1alpha1.StorageMigration{
+				ObjectMeta: metav1.ObjectMeta{Name: "sm"},
+				Spec:       tc.spec,
+			}
+			err := sm.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an Error, got nothing for %v", tc)
+			}
+			if d := cmp.Diff(tc.expectedError, *err, cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("StorageMigration.Validate() errors diff -want, +got: %v", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
+	"knative.dev/pkg/apis"
+)
+
+// TaskSpecAnnotationKey is the annotation of a v1alpha2 Task converted from a
+// v1alpha1 Task holding, as JSON, the fields of its spec v1alpha2 doesn't
+// have, so that converting it back to v1alpha1 doesn't lose them.
+const TaskSpecAnnotationKey = pipeline.GroupName + "/v1alpha1-task-spec"
+
+var _ apis.Convertible = (*Task)(nil)
+
+// ConvertUp implements apis.Convertible
+func (t *Task) ConvertUp(ctx context.Context, to apis.Convertible) error {
+	switch sink := to.(type) {
+	case *v1alpha2.Task:
+		sink.ObjectMeta = *t.ObjectMeta.DeepCopy()
+		spec := t.Spec.DeepCopy()
+		sink.Spec = v1alpha2.TaskSpec{
+			Steps:        spec.Steps,
+			Volumes:      spec.Volumes,
+			StepTemplate: spec.StepTemplate,
+			Sidecars:     spec.Sidecars,
+		}
+		var resources v1alpha2.TaskResources
+		if spec.Inputs != nil {
+			sink.Spec.Params = spec.Inputs.Params
+			resources.Inputs = spec.Inputs.Resources
+		}
+		if spec.Outputs != nil {
+			resources.Outputs = spec.Outputs.Resources
+		}
+		if len(resources.Inputs) > 0 || len(resources.Outputs) > 0 {
+			sink.Spec.Resources = &resources
+		}
+
+		stashed := TaskSpec{
+			Capabilities:  spec.Capabilities,
+			MemoryVolumes: spec.MemoryVolumes,
+			Results:       spec.Results,
+			Workspaces:    spec.Workspaces,
+			Platforms:     spec.Platforms,
+		}
+		if spec.Outputs != nil && len(spec.Outputs.Results) > 0 {
+			stashed.Outputs = &Outputs{Results: spec.Outputs.Results}
+		}
+		b, err := json.Marshal(stashed)
+		if err != nil {
+			return fmt.Errorf("failed to stash the fields of Task %s missing from v1alpha2: %w", t.Name, err)
+		}
+		if string(b) == "{}" {
+			return nil
+		}
+		if sink.Annotations == nil {
+			sink.Annotations = map[string]string{}
+		}
+		sink.Annotations[TaskSpecAnnotationKey] = string(b)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// ConvertDown implements apis.Convertible
+func (t *Task) ConvertDown(ctx context.Context, from apis.Convertible) error {
+	switch source := from.(type) {
+	case *v1alpha2.Task:
+		t.ObjectMeta = *source.ObjectMeta.DeepCopy()
+		spec := source.Spec.DeepCopy()
+		t.Spec = TaskSpec{
+			Steps:        spec.Steps,
+			Volumes:      spec.Volumes,
+			StepTemplate: spec.StepTemplate,
+			Sidecars:     spec.Sidecars,
+		}
+		var resources v1alpha2.TaskResources
+		if spec.Resources != nil {
+			resources = *spec.Resources
+		}
+		if len(spec.Params) > 0 || len(resources.Inputs) > 0 {
+			t.Spec.Inputs = &Inputs{Params: spec.Params, Resources: resources.Inputs}
+		}
+		if len(resources.Outputs) > 0 {
+			t.Spec.Outputs = &Outputs{Resources: resources.Outputs}
+		}
+
+		raw, ok := t.Annotations[TaskSpecAnnotationKey]
+		if !ok {
+			return nil
+		}
+		delete(t.Annotations, TaskSpecAnnotationKey)
+		if len(t.Annotations) == 0 {
+			t.Annotations = nil
+		}
+		var stashed TaskSpec
+		if err := json.Unmarshal([]byte(raw), &stashed); err != nil {
+			return fmt.Errorf("failed to restore the fields of Task %s stashed in %s: %w", t.Name, TaskSpecAnnotationKey, err)
+		}
+		t.Spec.Capabilities = stashed.Capabilities
+		t.Spec.MemoryVolumes = stashed.MemoryVolumes
+		t.Spec.Results = stashed.Results
+		t.Spec.Workspaces = stashed.Workspaces
+		t.Spec.Platforms = stashed.Platforms
+		if stashed.Outputs != nil && len(stashed.Outputs.Results) > 0 {
+			if t.Spec.Outputs == nil {
+				t.Spec.Outputs = &Outputs{}
+			}
+			t.Spec.Outputs.Results = stashed.Outputs.Results
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTaskConversion(t *testing.T) {
+	steps := []v1alpha1.Step{{Container: corev1.Container{Name: "build", Image: "golang"}}}
+	params := []v1alpha1.ParamSpec{{Name: "package", Type: v1alpha1.ParamTypeString}}
+	inputResources := []v1alpha1.TaskResource{{ResourceDeclaration: v1alpha1.ResourceDeclaration{Name: "source", Type: v1alpha1.PipelineResourceTypeGit}}}
+	outputResources := []v1alpha1.TaskResource{{ResourceDeclaration: v1alpha1.ResourceDeclaration{Name: "image", Type: v1alpha1.PipelineResourceTypeImage}}}
+
+	for _, tc := range []struct {
+		name string
+		in   *v1alpha1.Task
+		want *v1alpha2.Task
+	}{{
+		name: "fields in both versions",
+		in: &v1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo"},
+			Spec: v1alpha1.TaskSpec{
+				Inputs:  &v1alpha1.Inputs{Params: params, Resources: inputResources},
+				Outputs: &v1alpha1.Outputs{Resources: outputResources},
+				Steps:   steps,
+			},
+		},
+		want: &v1alpha2.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo"},
+			Spec: v1alpha2.TaskSpec{
+				Params:    params,
+				Resources: &v1alpha2.TaskResources{Inputs: inputResources, Outputs: outputResources},
+				Steps:     steps,
+			},
+		},
+	}, {
+		name: "fields missing from v1alpha2",
+		in: &v1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo", Annotations: map[string]string{"team": "ci"}},
+			Spec: v1alpha1.TaskSpec{
+				Outputs:    &v1alpha1.Outputs{Results: []v1alpha1.TestResult{{Name: "tests", Format: "junit", Path: "/workspace/report.xml"}}},
+				Steps:      steps,
+				Results:    []v1alpha1.TaskResult{{Name: "digest"}},
+				Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "cache"}},
+				Platforms:  []string{"linux/arm64"},
+			},
+		},
+		want: &v1alpha2.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo", Annotations: map[string]string{
+				"team":                         "ci",
+				v1alpha1.TaskSpecAnnotationKey: `{"outputs":{"results":[{"name":"tests","format":"junit","path":"/workspace/report.xml"}]},"results":[{"name":"digest"}],"workspaces":[{"name":"cache"}],"platforms":["linux/arm64"]}`,
+			}},
+			Spec: v1alpha2.TaskSpec{
+				Steps: steps,
+			},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := &v1alpha2.Task{}
+			if err := tc.in.ConvertUp(context.Background(), got); err != nil {
+				t.Fatalf("ConvertUp() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Unexpected v1alpha2 Task (-want, +got): %s", d)
+			}
+
+			back := &v1alpha1.Task{}
+			if err := back.ConvertDown(context.Background(), got); err != nil {
+				t.Fatalf("ConvertDown() = %v", err)
+			}
+			if d := cmp.Diff(tc.in, back); d != "" {
+				t.Errorf("Unexpected v1alpha1 Task converted back (-want, +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestTaskConversionInvalidAnnotation(t *testing.T) {
+	task := &v1alpha1.Task{}
+	err := task.ConvertDown(context.Background(), &v1alpha2.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Annotations: map[string]string{v1alpha1.TaskSpecAnnotationKey: "{"}},
+	})
+	if err == nil {
+		t.Error("Expected ConvertDown to fail restoring an invalid annotation")
+	}
+}
@@ -25,6 +25,7 @@ import (
 var _ apis.Defaultable = (*Task)(nil)
 
 func (t *Task) SetDefaults(ctx context.Context) {
+	defer recordDefaultedFields(ctx, t)()
 	t.Spec.SetDefaults(ctx)
 }
 
@@ -0,0 +1,143 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// TaskModifierConflictPolicy is how ApplyTaskModifiers handles a step of a
+// modifier named like a step already in the Task.
+type TaskModifierConflictPolicy string
+
+const (
+	// TaskModifierConflictError fails, as ApplyTaskModifier does.
+	TaskModifierConflictError TaskModifierConflictPolicy = ""
+	// TaskModifierConflictRename prefixes the name of the step with the name
+	// of its modifier.
+	TaskModifierConflictRename TaskModifierConflictPolicy = "Rename"
+	// TaskModifierConflictMerge adds a step identical to one already in the
+	// Task only once, and renames the steps that differ.
+	TaskModifierConflictMerge TaskModifierConflictPolicy = "Merge"
+)
+
+// NamedTaskModifier is a TaskModifier in a chain applied by
+// ApplyTaskModifiers.
+// +k8s:deepcopy-gen=false
+type NamedTaskModifier struct {
+	TaskModifier
+	// Name namespaces the steps of the modifier when they are renamed, for
+	// example the name of the resource providing it.
+	Name string
+	// Priority orders the chain: modifiers with a higher priority are applied
+	// first. Modifiers with the same priority are applied in order.
+	Priority int
+	// ConflictPolicy is how the steps named like a step already in the Task
+	// are handled.
+	ConflictPolicy TaskModifierConflictPolicy
+}
+
+// ApplyTaskModifiers applies a chain of modifiers to the task, and their
+// extensions once all of their steps are added. The steps to
+// prepend of the chain run before the steps of the Task in the order of the
+// chain, and its steps to append after them, in the order of the chain too.
+// The steps named like a step already added are handled according to the
+// ConflictPolicy of their modifier. Identical Volumes are added once, and
+// Volumes with the same name but different contents return an error.
+func ApplyTaskModifiers(ts *TaskSpec, modifiers ...NamedTaskModifier) error {
+	chain := append([]NamedTaskModifier{}, modifiers...)
+	sort.SliceStable(chain, func(i, j int) bool { return chain[i].Priority > chain[j].Priority })
+
+	// added holds every step of the Task and of the modifiers applied so far,
+	// so that steps of different modifiers don't conflict either.
+	added := append([]Step{}, ts.Steps...)
+	var prepend, appended []Step
+	for _, m := range chain {
+		for _, step := range m.GetStepsToPrepend() {
+			s, ok, err := resolveStepConflict(step, added, m)
+			if err != nil {
+				return err
+			}
+			if ok {
+				added = append(added, s)
+				prepend = append(prepend, s)
+			}
+		}
+		for _, step := range m.GetStepsToAppend() {
+			s, ok, err := resolveStepConflict(step, added, m)
+			if err != nil {
+				return err
+			}
+			if ok {
+				added = append(added, s)
+				appended = append(appended, s)
+			}
+		}
+	}
+	ts.Steps = append(append(prepend, ts.Steps...), appended...)
+
+	for _, m := range chain {
+		if err := addVolumes(ts, m.GetVolumes()); err != nil {
+			return err
+		}
+	}
+	for _, m := range chain {
+		if err := applyTaskModifierExtensions(ts, ExtendTaskModifier(m.TaskModifier)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveStepConflict returns the step to add according to the ConflictPolicy
+// of m, and false if an identical step has already been added.
+func resolveStepConflict(s Step, steps []Step, m NamedTaskModifier) (Step, bool, error) {
+	existing := findStep(s.Name, steps)
+	if existing == nil {
+		return s, true, nil
+	}
+	switch m.ConflictPolicy {
+	case TaskModifierConflictMerge:
+		if cmp.Equal(s, *existing) {
+			return s, false, nil
+		}
+	case TaskModifierConflictRename:
+	default:
+		return s, false, checkStepNotAlreadyAdded(s, steps)
+	}
+	if m.Name == "" {
+		return s, false, fmt.Errorf("Step %s cannot be added again by an unnamed modifier", s.Name)
+	}
+	renamed := *s.DeepCopy()
+	renamed.Name = fmt.Sprintf("%s-%s", m.Name, s.Name)
+	if err := checkStepNotAlreadyAdded(renamed, steps); err != nil {
+		return s, false, err
+	}
+	return renamed, true, nil
+}
+
+func findStep(name string, steps []Step) *Step {
+	for i := range steps {
+		if steps[i].Name == name {
+			return &steps[i]
+		}
+	}
+	return nil
+}
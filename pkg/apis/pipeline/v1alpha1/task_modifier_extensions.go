@@ -0,0 +1,223 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The TaskModifiers of the resources can implement these interfaces to
+// contribute more than steps and volumes to the Task.
+
+// StepEnvTaskModifier is a TaskModifier that adds env vars to the steps.
+type StepEnvTaskModifier interface {
+	TaskModifier
+	GetStepEnv() []corev1.EnvVar
+}
+
+// SidecarTaskModifier is a TaskModifier that adds sidecars to the Task.
+type SidecarTaskModifier interface {
+	TaskModifier
+	GetSidecars() []corev1.Container
+}
+
+// WorkspaceTaskModifier is a TaskModifier that declares workspaces of the
+// Task.
+type WorkspaceTaskModifier interface {
+	TaskModifier
+	GetWorkspaces() []WorkspaceDeclaration
+}
+
+// StepVolumeMountTaskModifier is a TaskModifier that mounts volumes in the
+// steps.
+type StepVolumeMountTaskModifier interface {
+	TaskModifier
+	GetStepVolumeMounts() []StepVolumeMount
+}
+
+// StepVolumeMount is a VolumeMount added to steps of the Task.
+type StepVolumeMount struct {
+	corev1.VolumeMount
+	// Steps are the names of the steps the volume is mounted in, every step
+	// if empty.
+	Steps []string
+}
+
+// ExtendedTaskModifier implements every extension of TaskModifier for
+// resources that are built-in to Tekton Pipelines.
+type ExtendedTaskModifier struct {
+	InternalTaskModifier
+	// StepEnv is added to every step, unless the step sets the same name.
+	StepEnv []corev1.EnvVar
+	// Sidecars are added to the Task. Identical sidecars are added once.
+	Sidecars []corev1.Container
+	// Workspaces are declared by the Task. Identical workspaces are declared
+	// once.
+	Workspaces []WorkspaceDeclaration
+	// StepVolumeMounts are added to the steps they target.
+	StepVolumeMounts []StepVolumeMount
+}
+
+// GetStepEnv returns the env vars to add to the steps.
+func (tm *ExtendedTaskModifier) GetStepEnv() []corev1.EnvVar {
+	return tm.StepEnv
+}
+
+// GetSidecars returns the sidecars to add to the Task.
+func (tm *ExtendedTaskModifier) GetSidecars() []corev1.Container {
+	return tm.Sidecars
+}
+
+// GetWorkspaces returns the workspaces to declare in the Task.
+func (tm *ExtendedTaskModifier) GetWorkspaces() []WorkspaceDeclaration {
+	return tm.Workspaces
+}
+
+// GetStepVolumeMounts returns the volume mounts to add to the steps.
+func (tm *ExtendedTaskModifier) GetStepVolumeMounts() []StepVolumeMount {
+	return tm.StepVolumeMounts
+}
+
+// ExtendTaskModifier adapts tm to an ExtendedTaskModifier, whose extensions
+// are those of the interfaces tm implements.
+func ExtendTaskModifier(tm TaskModifier) *ExtendedTaskModifier {
+	if e, ok := tm.(*ExtendedTaskModifier); ok {
+		return e
+	}
+	e := &ExtendedTaskModifier{InternalTaskModifier: InternalTaskModifier{
+		StepsToPrepend: tm.GetStepsToPrepend(),
+		StepsToAppend:  tm.GetStepsToAppend(),
+		Volumes:        tm.GetVolumes(),
+	}}
+	if m, ok := tm.(StepEnvTaskModifier); ok {
+		e.StepEnv = m.GetStepEnv()
+	}
+	if m, ok := tm.(SidecarTaskModifier); ok {
+		e.Sidecars = m.GetSidecars()
+	}
+	if m, ok := tm.(WorkspaceTaskModifier); ok {
+		e.Workspaces = m.GetWorkspaces()
+	}
+	if m, ok := tm.(StepVolumeMountTaskModifier); ok {
+		e.StepVolumeMounts = m.GetStepVolumeMounts()
+	}
+	return e
+}
+
+// applyTaskModifierExtensions applies the extensions of tm to the steps that
+// are in ts, including those tm added.
+func applyTaskModifierExtensions(ts *TaskSpec, tm *ExtendedTaskModifier) error {
+	for i := range ts.Steps {
+		for _, env := range tm.StepEnv {
+			if !hasEnv(ts.Steps[i].Env, env.Name) {
+				ts.Steps[i].Env = append(ts.Steps[i].Env, env)
+			}
+		}
+	}
+
+	if err := addSidecars(ts, tm.Sidecars); err != nil {
+		return err
+	}
+	if err := addWorkspaces(ts, tm.Workspaces); err != nil {
+		return err
+	}
+
+	for _, m := range tm.StepVolumeMounts {
+		for _, name := range m.Steps {
+			if findStep(name, ts.Steps) == nil {
+				return fmt.Errorf("tried to mount volume %s in step %s, which doesn't exist", m.Name, name)
+			}
+		}
+		for i := range ts.Steps {
+			if len(m.Steps) > 0 && !contains(m.Steps, ts.Steps[i].Name) {
+				continue
+			}
+			if !hasVolumeMount(ts.Steps[i].VolumeMounts, m.VolumeMount) {
+				ts.Steps[i].VolumeMounts = append(ts.Steps[i].VolumeMounts, m.VolumeMount)
+			}
+		}
+	}
+	return nil
+}
+
+// addSidecars adds the sidecars that haven't been added to ts yet.
+func addSidecars(ts *TaskSpec, sidecars []corev1.Container) error {
+	for _, sidecar := range sidecars {
+		var alreadyAdded bool
+		for _, s := range ts.Sidecars {
+			if sidecar.Name == s.Name {
+				if !cmp.Equal(sidecar, s) {
+					return fmt.Errorf("tried to add sidecar %s already added but with different contents", sidecar.Name)
+				}
+				alreadyAdded = true
+			}
+		}
+		if !alreadyAdded {
+			ts.Sidecars = append(ts.Sidecars, sidecar)
+		}
+	}
+	return nil
+}
+
+// addWorkspaces declares the workspaces that haven't been declared by ts yet.
+func addWorkspaces(ts *TaskSpec, workspaces []WorkspaceDeclaration) error {
+	for _, workspace := range workspaces {
+		var alreadyAdded bool
+		for _, w := range ts.Workspaces {
+			if workspace.Name == w.Name {
+				if !cmp.Equal(workspace, w) {
+					return fmt.Errorf("tried to add workspace %s already declared but with different contents", workspace.Name)
+				}
+				alreadyAdded = true
+			}
+		}
+		if !alreadyAdded {
+			ts.Workspaces = append(ts.Workspaces, workspace)
+		}
+	}
+	return nil
+}
+
+func hasEnv(env []corev1.EnvVar, name string) bool {
+	for _, e := range env {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolumeMount(mounts []corev1.VolumeMount, m corev1.VolumeMount) bool {
+	for _, existing := range mounts {
+		if cmp.Equal(existing, m) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
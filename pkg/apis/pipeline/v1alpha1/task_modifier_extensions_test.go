@@ -0,0 +1,126 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type envTaskModifier struct {
+	v1alpha1.InternalTaskModifier
+	env []corev1.EnvVar
+}
+
+func (tm *envTaskModifier) GetStepEnv() []corev1.EnvVar {
+	return tm.env
+}
+
+func TestApplyTaskModifier_Extensions(t *testing.T) {
+	mount := corev1.VolumeMount{Name: "magic-volume", MountPath: "/magic"}
+	proxy := corev1.Container{Name: "proxy", Image: "proxy"}
+	cache := v1alpha1.WorkspaceDeclaration{Name: "cache", MountPath: "/cache"}
+	ts := v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name: "build",
+		}}, {Container: corev1.Container{
+			Name: "test",
+			Env:  []corev1.EnvVar{{Name: "PROXY", Value: "none"}},
+		}}},
+		Sidecars:   []corev1.Container{proxy},
+		Workspaces: []v1alpha1.WorkspaceDeclaration{cache},
+	}
+	tm := &v1alpha1.ExtendedTaskModifier{
+		InternalTaskModifier: v1alpha1.InternalTaskModifier{
+			StepsToPrepend: []v1alpha1.Step{{Container: corev1.Container{Name: "fetch"}}},
+		},
+		StepEnv:          []corev1.EnvVar{{Name: "PROXY", Value: "localhost:3128"}},
+		Sidecars:         []corev1.Container{proxy},
+		Workspaces:       []v1alpha1.WorkspaceDeclaration{cache, {Name: "output"}},
+		StepVolumeMounts: []v1alpha1.StepVolumeMount{{VolumeMount: mount, Steps: []string{"build"}}},
+	}
+	if err := v1alpha1.ApplyTaskModifier(&ts, tm); err != nil {
+		t.Fatalf("ApplyTaskModifier: %v", err)
+	}
+
+	want := v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name: "fetch",
+			Env:  []corev1.EnvVar{{Name: "PROXY", Value: "localhost:3128"}},
+		}}, {Container: corev1.Container{
+			Name:         "build",
+			Env:          []corev1.EnvVar{{Name: "PROXY", Value: "localhost:3128"}},
+			VolumeMounts: []corev1.VolumeMount{mount},
+		}}, {Container: corev1.Container{
+			Name: "test",
+			Env:  []corev1.EnvVar{{Name: "PROXY", Value: "none"}},
+		}}},
+		Sidecars:   []corev1.Container{proxy},
+		Workspaces: []v1alpha1.WorkspaceDeclaration{cache, {Name: "output"}},
+	}
+	if d := cmp.Diff(want, ts); d != "" {
+		t.Errorf("TaskSpec was not modified as expected (-want, +got): %s", d)
+	}
+}
+
+func TestExtendTaskModifier(t *testing.T) {
+	env := []corev1.EnvVar{{Name: "FOO", Value: "bar"}}
+	got := v1alpha1.ExtendTaskModifier(&envTaskModifier{
+		InternalTaskModifier: v1alpha1.InternalTaskModifier{Volumes: []corev1.Volume{volume}},
+		env:                  env,
+	})
+	want := &v1alpha1.ExtendedTaskModifier{
+		InternalTaskModifier: v1alpha1.InternalTaskModifier{Volumes: []corev1.Volume{volume}},
+		StepEnv:              env,
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ExtendTaskModifier (-want, +got): %s", d)
+	}
+}
+
+func TestApplyTaskModifier_ExtensionsConflict(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		tm   *v1alpha1.ExtendedTaskModifier
+	}{{
+		name: "sidecar with same name but diff content",
+		tm:   &v1alpha1.ExtendedTaskModifier{Sidecars: []corev1.Container{{Name: "proxy", Image: "other"}}},
+	}, {
+		name: "workspace with same name but diff content",
+		tm:   &v1alpha1.ExtendedTaskModifier{Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "cache", ReadOnly: true}}},
+	}, {
+		name: "volume mounted in an unknown step",
+		tm: &v1alpha1.ExtendedTaskModifier{StepVolumeMounts: []v1alpha1.StepVolumeMount{{
+			VolumeMount: corev1.VolumeMount{Name: "magic-volume", MountPath: "/magic"},
+			Steps:       []string{"missing"},
+		}}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := v1alpha1.TaskSpec{
+				Steps:      []v1alpha1.Step{{Container: corev1.Container{Name: "build"}}},
+				Sidecars:   []corev1.Container{{Name: "proxy", Image: "proxy"}},
+				Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "cache"}},
+			}
+			if err := v1alpha1.ApplyTaskModifier(&ts, tc.tm); err == nil {
+				t.Errorf("Expected an error applying conflicting extensions but got none")
+			}
+		})
+	}
+}
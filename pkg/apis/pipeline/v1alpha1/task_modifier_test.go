@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestApplyTaskModifiers(t *testing.T) {
+	taskStep := v1alpha1.Step{Container: corev1.Container{Name: "build", Image: "builder"}}
+	setup := v1alpha1.Step{Container: corev1.Container{Name: "setup", Image: "setup"}}
+	otherSetup := v1alpha1.Step{Container: corev1.Container{Name: "setup", Image: "other-setup"}}
+	for _, tc := range []struct {
+		name      string
+		modifiers []v1alpha1.NamedTaskModifier
+		want      v1alpha1.TaskSpec
+	}{{
+		name: "ordered by priority",
+		modifiers: []v1alpha1.NamedTaskModifier{{
+			Name: "low",
+			TaskModifier: &v1alpha1.InternalTaskModifier{
+				StepsToPrepend: []v1alpha1.Step{{Container: corev1.Container{Name: "low-prepend"}}},
+				StepsToAppend:  []v1alpha1.Step{{Container: corev1.Container{Name: "low-append"}}},
+			},
+		}, {
+			Name:     "high",
+			Priority: 1,
+			TaskModifier: &v1alpha1.InternalTaskModifier{
+				StepsToPrepend: []v1alpha1.Step{{Container: corev1.Container{Name: "high-prepend"}}},
+				StepsToAppend:  []v1alpha1.Step{{Container: corev1.Container{Name: "high-append"}}},
+			},
+		}},
+		want: v1alpha1.TaskSpec{Steps: []v1alpha1.Step{
+			{Container: corev1.Container{Name: "high-prepend"}},
+			{Container: corev1.Container{Name: "low-prepend"}},
+			taskStep,
+			{Container: corev1.Container{Name: "high-append"}},
+			{Container: corev1.Container{Name: "low-append"}},
+		}},
+	}, {
+		name: "identical steps and volumes merged",
+		modifiers: []v1alpha1.NamedTaskModifier{{
+			Name:           "first",
+			ConflictPolicy: v1alpha1.TaskModifierConflictMerge,
+			TaskModifier:   &v1alpha1.InternalTaskModifier{StepsToPrepend: []v1alpha1.Step{setup}, Volumes: []corev1.Volume{volume}},
+		}, {
+			Name:           "second",
+			ConflictPolicy: v1alpha1.TaskModifierConflictMerge,
+			TaskModifier:   &v1alpha1.InternalTaskModifier{StepsToPrepend: []v1alpha1.Step{setup}, Volumes: []corev1.Volume{volume}},
+		}},
+		want: v1alpha1.TaskSpec{
+			Steps:   []v1alpha1.Step{setup, taskStep},
+			Volumes: []corev1.Volume{volume},
+		},
+	}, {
+		name: "different steps renamed when merged",
+		modifiers: []v1alpha1.NamedTaskModifier{{
+			Name:           "first",
+			ConflictPolicy: v1alpha1.TaskModifierConflictMerge,
+			TaskModifier:   &v1alpha1.InternalTaskModifier{StepsToPrepend: []v1alpha1.Step{setup}},
+		}, {
+			Name:           "second",
+			ConflictPolicy: v1alpha1.TaskModifierConflictMerge,
+			TaskModifier:   &v1alpha1.InternalTaskModifier{StepsToPrepend: []v1alpha1.Step{otherSetup}},
+		}},
+		want: v1alpha1.TaskSpec{Steps: []v1alpha1.Step{
+			setup,
+			{Container: corev1.Container{Name: "second-setup", Image: "other-setup"}},
+			taskStep,
+		}},
+	}, {
+		name: "identical steps renamed",
+		modifiers: []v1alpha1.NamedTaskModifier{{
+			Name:         "first",
+			TaskModifier: &v1alpha1.InternalTaskModifier{StepsToAppend: []v1alpha1.Step{setup}},
+		}, {
+			Name:           "second",
+			ConflictPolicy: v1alpha1.TaskModifierConflictRename,
+			TaskModifier:   &v1alpha1.InternalTaskModifier{StepsToAppend: []v1alpha1.Step{setup}},
+		}},
+		want: v1alpha1.TaskSpec{Steps: []v1alpha1.Step{
+			taskStep,
+			setup,
+			{Container: corev1.Container{Name: "second-setup", Image: "setup"}},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := v1alpha1.TaskSpec{Steps: []v1alpha1.Step{taskStep}}
+			if err := v1alpha1.ApplyTaskModifiers(&ts, tc.modifiers...); err != nil {
+				t.Fatalf("ApplyTaskModifiers: %v", err)
+			}
+			if d := cmp.Diff(tc.want, ts); d != "" {
+				t.Errorf("TaskSpec was not modified as expected (-want, +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestApplyTaskModifiers_Conflict(t *testing.T) {
+	setup := v1alpha1.Step{Container: corev1.Container{Name: "setup", Image: "setup"}}
+	for _, tc := range []struct {
+		name      string
+		modifiers []v1alpha1.NamedTaskModifier
+	}{{
+		name: "step added twice",
+		modifiers: []v1alpha1.NamedTaskModifier{{
+			Name:         "first",
+			TaskModifier: &v1alpha1.InternalTaskModifier{StepsToPrepend: []v1alpha1.Step{setup}},
+		}, {
+			Name:         "second",
+			TaskModifier: &v1alpha1.InternalTaskModifier{StepsToPrepend: []v1alpha1.Step{setup}},
+		}},
+	}, {
+		name: "unnamed modifier renaming a step",
+		modifiers: []v1alpha1.NamedTaskModifier{{
+			TaskModifier: &v1alpha1.InternalTaskModifier{StepsToPrepend: []v1alpha1.Step{setup}},
+		}, {
+			ConflictPolicy: v1alpha1.TaskModifierConflictRename,
+			TaskModifier:   &v1alpha1.InternalTaskModifier{StepsToPrepend: []v1alpha1.Step{setup}},
+		}},
+	}, {
+		name: "volume with same name but diff content",
+		modifiers: []v1alpha1.NamedTaskModifier{{
+			Name:           "first",
+			ConflictPolicy: v1alpha1.TaskModifierConflictMerge,
+			TaskModifier:   &v1alpha1.InternalTaskModifier{Volumes: []corev1.Volume{volume}},
+		}, {
+			Name:           "second",
+			ConflictPolicy: v1alpha1.TaskModifierConflictMerge,
+			TaskModifier: &v1alpha1.InternalTaskModifier{Volumes: []corev1.Volume{{
+				Name:         "magic-volume",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}}},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := v1alpha1.ApplyTaskModifiers(&v1alpha1.TaskSpec{}, tc.modifiers...); err == nil {
+				t.Errorf("Expected an error applying conflicting modifiers but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"knative.dev/pkg/apis"
+)
+
+// enforcedTaskPolicy returns the operator-configured TaskPolicy if it applies
+// to the objects of namespace, nil otherwise. The policy is enforced on
+// create, and on update when restrictedChanged returns true: the objects
+// which existed before it was configured can still be updated, e.g. by the
+// controller, as long as the fields it restricts don't change.
+func enforcedTaskPolicy(ctx context.Context, namespace string, restrictedChanged func() bool) *config.TaskPolicy {
+	policy := config.FromContextOrDefaults(ctx).TaskPolicy
+	if policy.Exempts(namespace) {
+		return nil
+	}
+	if apis.IsInCreate(ctx) || (apis.IsInUpdate(ctx) && restrictedChanged()) {
+		return policy
+	}
+	return nil
+}
+
+// validateTaskPolicy returns the fields of spec that the TaskPolicy forbids in
+// namespace. old is the spec being updated, nil if there is none.
+func validateTaskPolicy(ctx context.Context, namespace string, spec, old *TaskSpec) *apis.FieldError {
+	if spec == nil {
+		return nil
+	}
+	policy := enforcedTaskPolicy(ctx, namespace, func() bool {
+		return old == nil || !equality.Semantic.DeepEqual(taskSpecRestrictedFields(old), taskSpecRestrictedFields(spec))
+	})
+	if policy == nil {
+		return nil
+	}
+	return taskSpecPolicyErrors(policy, spec)
+}
+
+// taskSpecRestrictedFields returns a copy of spec with only the fields the
+// TaskPolicy restricts.
+func taskSpecRestrictedFields(spec *TaskSpec) *TaskSpec {
+	return &TaskSpec{
+		Steps:        spec.Steps,
+		StepTemplate: spec.StepTemplate,
+		Sidecars:     spec.Sidecars,
+		Capabilities: spec.Capabilities,
+		Volumes:      spec.Volumes,
+	}
+}
+
+// ValidateTaskPolicy returns the fields of spec that the TaskPolicy forbids in
+// namespace. Unlike the validation of the objects the webhook admits, it
+// applies outside of create: the controller checks the Tasks it resolves
+// remotely with it, which are never admitted.
+func ValidateTaskPolicy(ctx context.Context, namespace string, spec *TaskSpec) *apis.FieldError {
+	policy := config.FromContextOrDefaults(ctx).TaskPolicy
+	if policy.Exempts(namespace) || spec == nil {
+		return nil
+	}
+	return taskSpecPolicyErrors(policy, spec)
+}
+
+func taskSpecPolicyErrors(policy *config.TaskPolicy, spec *TaskSpec) *apis.FieldError {
+	var errs *apis.FieldError
+	// The step template is checked through the steps it is merged into; the
+	// merge was validated with the spec.
+	steps, _ := MergeStepsWithStepTemplate(spec.StepTemplate, spec.DeepCopy().Steps)
+	for i, s := range steps {
+		errs = errs.Also(validateContainerPolicy(policy, s.Container).ViaFieldIndex("steps", i))
+	}
+	for i, s := range spec.Sidecars {
+		errs = errs.Also(validateContainerPolicy(policy, s).ViaFieldIndex("sidecars", i))
+	}
+	if policy.DisallowPrivileged && len(spec.Capabilities) > 0 {
+		// Every capability is provided by a privileged sidecar.
+		errs = errs.Also(&apis.FieldError{
+			Message: "capabilities run a privileged sidecar, which the task policy disallows",
+			Paths:   []string{"capabilities"},
+		})
+	}
+	return errs.Also(validateVolumesPolicy(policy, spec.Volumes).ViaField("volumes"))
+}
+
+// validatePodTemplatePolicy returns the fields of podTemplate that the
+// TaskPolicy forbids in namespace. old is the pod template being updated, nil
+// if there is none.
+func validatePodTemplatePolicy(ctx context.Context, namespace string, podTemplate PodTemplate, old *PodTemplate) *apis.FieldError {
+	policy := enforcedTaskPolicy(ctx, namespace, func() bool {
+		return old == nil || !equality.Semantic.DeepEqual(old.Volumes, podTemplate.Volumes)
+	})
+	if policy == nil {
+		return nil
+	}
+	return validateVolumesPolicy(policy, podTemplate.Volumes).ViaField("volumes")
+}
+
+func validateContainerPolicy(policy *config.TaskPolicy, c corev1.Container) *apis.FieldError {
+	var errs *apis.FieldError
+	if policy.DisallowPrivileged && c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+		errs = errs.Also(&apis.FieldError{
+			Message: "privileged containers are disallowed by the task policy",
+			Paths:   []string{"securityContext.privileged"},
+		})
+	}
+	if c.Image != "" && policy.ImageDisallowed(c.Image) {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("image %q is disallowed by the task policy", c.Image),
+			Paths:   []string{"image"},
+		})
+	}
+	// An image set by params or resources is only known once they are
+	// substituted: the controller checks it when the TaskRun runs.
+	if c.Image != "" && !strings.Contains(c.Image, "$(") && policy.ImageNotPinned(c.Image) {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("image %q isn't pinned to a digest, which the task policy requires", c.Image),
+			Paths:   []string{"image"},
+		})
+	}
+	return errs
+}
+
+func validateVolumesPolicy(policy *config.TaskPolicy, volumes []corev1.Volume) *apis.FieldError {
+	if !policy.DisallowHostPath {
+		return nil
+	}
+	var errs *apis.FieldError
+	for i, v := range volumes {
+		if v.HostPath != nil {
+			err := &apis.FieldError{
+				Message: fmt.Sprintf("hostPath volume %q is disallowed by the task policy", v.Name),
+				Paths:   []string{"hostPath"},
+			}
+			errs = errs.Also(err.ViaIndex(i))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestTaskPolicy(t *testing.T) {
+	privileged := true
+	policy := &config.TaskPolicy{
+		DisallowPrivileged: true,
+		DisallowHostPath:   true,
+		DisallowedImages:   []string{"gcr.io/untrusted/*"},
+		ExemptNamespaces:   []string{"exempt"},
+	}
+	hostPath := corev1.Volume{
+		Name:         "docker-socket",
+		VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}},
+	}
+	for _, tc := range []struct {
+		name      string
+		namespace string
+		// baseline is the Task being updated, if any.
+		baseline *v1alpha1.Task
+		spec     v1alpha1.TaskSpec
+		wantErr  string
+	}{{
+		name: "allowed",
+		spec: v1alpha1.TaskSpec{Steps: validSteps},
+	}, {
+		name: "privileged step",
+		spec: v1alpha1.TaskSpec{Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name:            "build",
+			Image:           "myimage",
+			SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+		}}}},
+		wantErr: "privileged containers are disallowed by the task policy: spec.steps[0].securityContext.privileged",
+	}, {
+		name: "privileged step template",
+		spec: v1alpha1.TaskSpec{
+			// MergeStepsWithStepTemplate updates the steps in place.
+			Steps:        []v1alpha1.Step{{Container: corev1.Container{Name: "build", Image: "myimage"}}},
+			StepTemplate: &corev1.Container{SecurityContext: &corev1.SecurityContext{Privileged: &privileged}},
+		},
+		wantErr: "privileged containers are disallowed by the task policy: spec.steps[0].securityContext.privileged",
+	}, {
+		name: "disallowed sidecar image",
+		spec: v1alpha1.TaskSpec{
+			Steps:    validSteps,
+			Sidecars: []corev1.Container{{Name: "proxy", Image: "gcr.io/untrusted/proxy"}},
+		},
+		wantErr: `image "gcr.io/untrusted/proxy" is disallowed by the task policy: spec.sidecars[0].image`,
+	}, {
+		name: "capabilities",
+		spec: v1alpha1.TaskSpec{
+			Steps:        validSteps,
+			Capabilities: []v1alpha1.TaskCapability{v1alpha1.TaskCapabilityDocker},
+		},
+		wantErr: "capabilities run a privileged sidecar, which the task policy disallows: spec.capabilities",
+	}, {
+		name: "hostPath volume",
+		spec: v1alpha1.TaskSpec{
+			Steps:   validSteps,
+			Volumes: []corev1.Volume{hostPath},
+		},
+		wantErr: `hostPath volume "docker-socket" is disallowed by the task policy: spec.volumes[0].hostPath`,
+	}, {
+		name:      "exempt namespace",
+		namespace: "exempt",
+		spec: v1alpha1.TaskSpec{
+			Steps:   validSteps,
+			Volumes: []corev1.Volume{hostPath},
+		},
+	}, {
+		name: "update without changing the restricted fields",
+		baseline: &v1alpha1.Task{Spec: v1alpha1.TaskSpec{
+			Steps:   validSteps,
+			Volumes: []corev1.Volume{hostPath},
+		}},
+		spec: v1alpha1.TaskSpec{
+			Inputs:  &v1alpha1.Inputs{Params: []v1alpha1.ParamSpec{{Name: "new", Type: v1alpha1.ParamTypeString}}},
+			Steps:   validSteps,
+			Volumes: []corev1.Volume{hostPath},
+		},
+	}, {
+		name:     "update adding a hostPath volume",
+		baseline: &v1alpha1.Task{Spec: v1alpha1.TaskSpec{Steps: validSteps}},
+		spec: v1alpha1.TaskSpec{
+			Steps:   validSteps,
+			Volumes: []corev1.Volume{hostPath},
+		},
+		wantErr: `hostPath volume "docker-socket" is disallowed by the task policy: spec.volumes[0].hostPath`,
+	}, {
+		name: "update changing the steps of a Task with a hostPath volume",
+		baseline: &v1alpha1.Task{Spec: v1alpha1.TaskSpec{
+			Steps:   validSteps,
+			Volumes: []corev1.Volume{hostPath},
+		}},
+		spec: v1alpha1.TaskSpec{
+			Steps:   []v1alpha1.Step{{Container: corev1.Container{Name: "other", Image: "myimage"}}},
+			Volumes: []corev1.Volume{hostPath},
+		},
+		wantErr: `hostPath volume "docker-socket" is disallowed by the task policy: spec.volumes[0].hostPath`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.FromContextOrDefaults(context.Background())
+			cfg.TaskPolicy = policy
+			ctx := config.ToContext(context.Background(), cfg)
+			if tc.baseline != nil {
+				ctx = apis.WithinUpdate(ctx, tc.baseline)
+			} else {
+				ctx = apis.WithinCreate(ctx)
+			}
+			if tc.namespace == "" {
+				tc.namespace = "default"
+			}
+			task := &v1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: tc.namespace},
+				Spec:       tc.spec,
+			}
+			var gotErr string
+			if err := task.Validate(ctx); err != nil {
+				gotErr = err.Error()
+			}
+			if d := cmp.Diff(tc.wantErr, gotErr); d != "" {
+				t.Errorf("Task.Validate() errors diff -want, +got: %v", d)
+			}
+		})
+	}
+}
+
+func TestTaskRunPolicy(t *testing.T) {
+	cfg := config.FromContextOrDefaults(context.Background())
+	cfg.TaskPolicy = &config.TaskPolicy{DisallowHostPath: true}
+	ctx := apis.WithinCreate(config.ToContext(context.Background(), cfg))
+	hostPath := corev1.Volume{
+		Name:         "docker-socket",
+		VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}},
+	}
+	tr := &v1alpha1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "default"},
+		Spec: v1alpha1.TaskRunSpec{
+			TaskSpec: &v1alpha1.TaskSpec{
+				Steps:   validSteps,
+				Volumes: []corev1.Volume{hostPath},
+			},
+			PodTemplate: v1alpha1.PodTemplate{Volumes: []corev1.Volume{hostPath}},
+		},
+	}
+	want := `hostPath volume "docker-socket" is disallowed by the task policy: spec.podTemplate.volumes[0].hostPath, spec.taskSpec.volumes[0].hostPath`
+	err := tr.Validate(ctx)
+	if err == nil {
+		t.Fatalf("TaskRun.Validate() = nil, want %q", want)
+	}
+	if d := cmp.Diff(want, err.Error()); d != "" {
+		t.Errorf("TaskRun.Validate() errors diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskPolicyImageDigests(t *testing.T) {
+	cfg := config.FromContextOrDefaults(context.Background())
+	cfg.TaskPolicy = &config.TaskPolicy{RequireImageDigests: true}
+	ctx := apis.WithinCreate(config.ToContext(context.Background(), cfg))
+	task := &v1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: "default"},
+		Spec: v1alpha1.TaskSpec{
+			Inputs: &v1alpha1.Inputs{
+				Params: []v1alpha1.ParamSpec{{Name: "builder-image", Type: v1alpha1.ParamTypeString}},
+			},
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:  "pinned",
+				Image: "busybox@sha256:895ab622e92e18d6b461d671081757af7dbaa3b00e3e28e12505af7817f73649",
+			}}, {Container: corev1.Container{
+				// Checked by the controller once the param is substituted.
+				Name:  "parameterized",
+				Image: "$(inputs.params.builder-image)",
+			}}, {Container: corev1.Container{
+				Name:  "tagged",
+				Image: "busybox:1.31",
+			}}},
+			Sidecars: []corev1.Container{{Name: "proxy", Image: "envoyproxy/envoy"}},
+		},
+	}
+	want := `image "busybox:1.31" isn't pinned to a digest, which the task policy requires: spec.steps[2].image` + "\n" +
+		`image "envoyproxy/envoy" isn't pinned to a digest, which the task policy requires: spec.sidecars[0].image`
+	err := task.Validate(ctx)
+	if err == nil {
+		t.Fatalf("Task.Validate() = nil, want %q", want)
+	}
+	if d := cmp.Diff(want, err.Error()); d != "" {
+		t.Errorf("Task.Validate() errors diff -want, +got: %v", d)
+	}
+}
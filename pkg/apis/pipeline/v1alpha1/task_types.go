@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
@@ -61,12 +62,101 @@ type TaskSpec struct {
 	// Sidecars are run alongside the Task's step containers. They begin before
 	// the steps start and end after the steps complete.
 	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+
+	// Capabilities is a list of named, operator-configured features that are
+	// added to the Task's Pod, such as a Docker daemon sidecar that steps can
+	// reach via DOCKER_HOST.
+	// +optional
+	Capabilities []TaskCapability `json:"capabilities,omitempty"`
+
+	// MemoryVolumes are memory-backed (tmpfs) volumes shared by the steps,
+	// for example to provide a larger /dev/shm.
+	// +optional
+	MemoryVolumes []MemoryVolume `json:"memoryVolumes,omitempty"`
+
+	// Results are values that the steps write to files under
+	// /tekton/results, which are reported in the TaskRun's status so that
+	// the PipelineTasks after this one can use them.
+	// +optional
+	Results []TaskResult `json:"results,omitempty"`
+
+	// Workspaces are the directories the steps use, whose volumes are
+	// provided by the TaskRun.
+	// +optional
+	Workspaces []WorkspaceDeclaration `json:"workspaces,omitempty"`
+
+	// Platforms are the platforms, written <os>/<arch> such as linux/arm64,
+	// of the Nodes the Task's Pod can be scheduled to.
+	// +optional
+	Platforms []string `json:"platforms,omitempty"`
+}
+
+// TaskResult declares a result of a Task. Steps write it to the file
+// $(results.<name>.path).
+type TaskResult struct {
+	// Name of the result. It must only contain alphanumeric characters, '-'
+	// and '_'.
+	Name string `json:"name"`
+	// Description of the result.
+	// +optional
+	Description string `json:"description,omitempty"`
 }
 
+// ResultsDir is the directory of the files the steps write results to.
+const ResultsDir = "/tekton/results"
+
+// Path returns the path of the file the steps write the result to.
+func (r TaskResult) Path() string {
+	return ResultsDir + "/" + r.Name
+}
+
+// MemoryVolume is a shorthand for an emptyDir volume with the Memory medium,
+// mounted into the steps of a Task.
+type MemoryVolume struct {
+	// Name of the volume. It must not be the name of one of the Volumes.
+	Name string `json:"name"`
+	// MountPath is where the volume is mounted in the steps.
+	MountPath string `json:"mountPath"`
+	// Size limits the memory the volume may use. It counts towards the
+	// memory limit of the steps that write to it.
+	Size resource.Quantity `json:"size"`
+	// Steps are the names of the steps the volume is mounted into, every
+	// step if empty.
+	// +optional
+	Steps []string `json:"steps,omitempty"`
+}
+
+// TaskCapability is the name of a feature which the controller knows how to
+// provide to the steps of a Task.
+type TaskCapability string
+
+const (
+	// TaskCapabilityDocker adds a Docker-in-Docker daemon sidecar and points
+	// DOCKER_HOST in every step at its socket.
+	TaskCapabilityDocker TaskCapability = "docker"
+	// TaskCapabilityBuildkit adds a buildkitd sidecar and points BUILDKIT_HOST
+	// in every step at its socket.
+	TaskCapabilityBuildkit TaskCapability = "buildkit"
+)
+
+// AllTaskCapabilities is a list of all the supported TaskCapabilities.
+var AllTaskCapabilities = []TaskCapability{TaskCapabilityDocker, TaskCapabilityBuildkit}
+
 // Step embeds the Container type, which allows it to include fields not
 // provided by Container.
 type Step = v1alpha2.Step
 
+// OnErrorType is what to do when the command of a Step fails.
+type OnErrorType = v1alpha2.OnErrorType
+
+const (
+	// StepOnErrorStopAndFail fails the Step and the TaskRun, and skips the
+	// Steps after it.
+	StepOnErrorStopAndFail OnErrorType = v1alpha2.StepOnErrorStopAndFail
+	// StepOnErrorContinue ignores the failure of the Step.
+	StepOnErrorContinue OnErrorType = v1alpha2.StepOnErrorContinue
+)
+
 // +genclient
 // +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
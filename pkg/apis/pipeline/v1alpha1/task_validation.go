@@ -19,12 +19,16 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
@@ -35,7 +39,14 @@ func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	if err := validate.ObjectMetadata(t.GetObjectMeta()); err != nil {
 		return err.ViaField("metadata")
 	}
-	return t.Spec.Validate(ctx)
+	if err := t.Spec.Validate(ctx); err != nil {
+		return err
+	}
+	var old *TaskSpec
+	if base, ok := apis.GetBaseline(ctx).(*Task); ok && base != nil {
+		old = &base.Spec
+	}
+	return validateTaskPolicy(ctx, t.Namespace, &t.Spec, old).ViaField("spec")
 }
 
 func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
@@ -99,15 +110,64 @@ func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if err := validateCapabilities(ts.Capabilities).ViaField("capabilities"); err != nil {
+		return err
+	}
+
+	if err := validateMemoryVolumes(ctx, ts.MemoryVolumes, ts.Volumes, ts.Steps).ViaField("memoryVolumes"); err != nil {
+		return err
+	}
+
+	if err := validateResults(ts.Results).ViaField("results"); err != nil {
+		return err
+	}
+
+	if err := validateWorkspaceDeclarations(ts.Workspaces).ViaField("workspaces"); err != nil {
+		return err
+	}
+
+	if ts.Inputs != nil {
+		if err := validateTargetPaths(ts.Inputs.Resources, ts.Workspaces); err != nil {
+			return err
+		}
+	}
+
+	if err := validatePlatforms(ts.Platforms).ViaField("platforms"); err != nil {
+		return err
+	}
+
 	if err := validateInputParameterVariables(ts.Steps, ts.Inputs); err != nil {
 		return err
 	}
 	if err := validateResourceVariables(ts.Steps, ts.Inputs, ts.Outputs); err != nil {
 		return err
 	}
+	if err := validateWorkspaceAndResultVariables(ts.Steps, ts.Workspaces, ts.Results); err != nil {
+		return err
+	}
 	return nil
 }
 
+// validateWorkspaceAndResultVariables checks that the steps only reference the
+// declared workspaces, with $(workspaces.<name>.path), and results, with
+// $(results.<name>.path).
+func validateWorkspaceAndResultVariables(steps []Step, workspaces []WorkspaceDeclaration, results []TaskResult) *apis.FieldError {
+	workspaceNames := map[string]struct{}{}
+	for _, w := range workspaces {
+		workspaceNames[w.Name] = struct{}{}
+	}
+	resultNames := map[string]struct{}{}
+	for _, r := range results {
+		resultNames[r.Name] = struct{}{}
+	}
+	return validateStepFields(steps, func(name, value string) *apis.FieldError {
+		if err := validateTaskVariable(name, value, "", "workspaces", workspaceNames); err != nil {
+			return err
+		}
+		return validateTaskVariable(name, value, "", "results", resultNames)
+	})
+}
+
 func ValidateVolumes(volumes []corev1.Volume) *apis.FieldError {
 	// Task must not have duplicate volume names.
 	vols := map[string]struct{}{}
@@ -123,6 +183,127 @@ func ValidateVolumes(volumes []corev1.Volume) *apis.FieldError {
 	return nil
 }
 
+func validateCapabilities(capabilities []TaskCapability) *apis.FieldError {
+	seen := map[TaskCapability]struct{}{}
+	for _, c := range capabilities {
+		if _, ok := seen[c]; ok {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("capability %q specified more than once", c),
+				Paths:   []string{apis.CurrentField},
+			}
+		}
+		seen[c] = struct{}{}
+		if !isKnownCapability(c) {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("unknown capability %q", c),
+				Paths:   []string{apis.CurrentField},
+				Details: fmt.Sprintf("capability must be one of %v", AllTaskCapabilities),
+			}
+		}
+	}
+	return nil
+}
+
+var resultNameFormat = regexp.MustCompile(`^[a-zA-Z0-9]([-_a-zA-Z0-9]*[a-zA-Z0-9])?$`)
+
+func validateResults(results []TaskResult) *apis.FieldError {
+	names := map[string]struct{}{}
+	for i, r := range results {
+		if !resultNameFormat.MatchString(r.Name) {
+			return (&apis.FieldError{
+				Message: fmt.Sprintf("invalid result name %q", r.Name),
+				Paths:   []string{"name"},
+				Details: "Result names must only contain alphanumeric characters, '-' and '_', and start and end with an alphanumeric character",
+			}).ViaIndex(i)
+		}
+		if _, ok := names[r.Name]; ok {
+			return apis.ErrMultipleOneOf("name").ViaIndex(i)
+		}
+		names[r.Name] = struct{}{}
+	}
+	return nil
+}
+
+var platformFormat = regexp.MustCompile(`^[a-z0-9]+/[a-z0-9_]+$`)
+
+func validatePlatforms(platforms []string) *apis.FieldError {
+	seen := map[string]struct{}{}
+	for i, p := range platforms {
+		if !platformFormat.MatchString(p) {
+			return (&apis.FieldError{
+				Message: fmt.Sprintf("invalid platform %q", p),
+				Paths:   []string{apis.CurrentField},
+				Details: "Platforms must be written <os>/<arch>, for example linux/amd64",
+			}).ViaIndex(i)
+		}
+		if _, ok := seen[p]; ok {
+			return (&apis.FieldError{
+				Message: fmt.Sprintf("platform %q specified more than once", p),
+				Paths:   []string{apis.CurrentField},
+			}).ViaIndex(i)
+		}
+		seen[p] = struct{}{}
+	}
+	return nil
+}
+
+func isKnownCapability(c TaskCapability) bool {
+	for _, known := range AllTaskCapabilities {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
+func validateMemoryVolumes(ctx context.Context, memoryVolumes []MemoryVolume, volumes []corev1.Volume, steps []Step) *apis.FieldError {
+	names := map[string]struct{}{}
+	for _, v := range volumes {
+		names[v.Name] = struct{}{}
+	}
+	stepNames := map[string]struct{}{}
+	for _, s := range steps {
+		stepNames[s.Name] = struct{}{}
+	}
+	var total resource.Quantity
+	for i, v := range memoryVolumes {
+		if v.Name == "" {
+			return apis.ErrMissingField("name").ViaIndex(i)
+		}
+		if _, ok := names[v.Name]; ok {
+			return (&apis.FieldError{
+				Message: fmt.Sprintf("multiple volumes with same name %q", v.Name),
+				Paths:   []string{"name"},
+			}).ViaIndex(i)
+		}
+		names[v.Name] = struct{}{}
+		if !filepath.IsAbs(v.MountPath) {
+			return apis.ErrInvalidValue(v.MountPath, "mountPath").ViaIndex(i)
+		}
+		if v.Size.Sign() <= 0 {
+			return apis.ErrInvalidValue(v.Size.String(), "size").ViaIndex(i)
+		}
+		for _, s := range v.Steps {
+			if _, ok := stepNames[s]; s == "" || !ok {
+				return (&apis.FieldError{
+					Message: fmt.Sprintf("memory volume %q is mounted into unknown step %q", v.Name, s),
+					Paths:   []string{"steps"},
+				}).ViaIndex(i)
+			}
+		}
+		total.Add(v.Size)
+	}
+	maximum := config.FromContextOrDefaults(ctx).Defaults.MaximumMemoryVolumesBytes
+	if maximum > 0 && total.Value() > maximum {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("memory volumes of %s exceed the maximum of %s", total.String(), resource.NewQuantity(maximum, resource.BinarySI)),
+			Paths:   []string{apis.CurrentField},
+			Details: "the nodes can't allocate more memory to the memory volumes of a pod; see maximum-memory-volumes-size in config-defaults",
+		}
+	}
+	return nil
+}
+
 func validateSteps(steps []Step) *apis.FieldError {
 	// Task must not have duplicate step names.
 	names := map[string]struct{}{}
@@ -140,7 +321,33 @@ func validateSteps(steps []Step) *apis.FieldError {
 			}
 		}
 
+		if s.Retries < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", s.Retries), "retries")
+		}
+		if s.RetryBackoff != nil {
+			if s.Retries == 0 {
+				return &apis.FieldError{
+					Message: "retryBackoff can only be used with retries",
+					Paths:   []string{"retryBackoff"},
+				}
+			}
+			if s.RetryBackoff.Duration < 0 {
+				return apis.ErrInvalidValue(s.RetryBackoff.Duration.String()+" should be >= 0", "retryBackoff")
+			}
+		}
+		switch s.OnError {
+		case "", StepOnErrorStopAndFail, StepOnErrorContinue:
+		default:
+			return apis.ErrInvalidValue(string(s.OnError), "onError")
+		}
+
 		if s.Name == "" {
+			if s.AlwaysRun {
+				return &apis.FieldError{
+					Message: "always-run steps must be named",
+					Paths:   []string{"name"},
+				}
+			}
 			continue
 		}
 		if _, ok := names[s.Name]; ok {
@@ -175,6 +382,12 @@ func validateInputParameterTypes(inputs *Inputs) *apis.FieldError {
 				},
 			}
 		}
+		if err := p.ValidateProperties(); err != nil {
+			return err.ViaField(fmt.Sprintf("taskspec.inputs.params.%s", p.Name))
+		}
+		if err := p.ValidateEnum(); err != nil {
+			return err.ViaField(fmt.Sprintf("taskspec.inputs.params.%s", p.Name))
+		}
 	}
 	return nil
 }
@@ -182,35 +395,91 @@ func validateInputParameterTypes(inputs *Inputs) *apis.FieldError {
 func validateInputParameterVariables(steps []Step, inputs *Inputs) *apis.FieldError {
 	parameterNames := map[string]struct{}{}
 	arrayParameterNames := map[string]struct{}{}
+	objectParameterKeys := map[string]map[string]struct{}{}
 
 	if inputs != nil {
 		for _, p := range inputs.Params {
 			parameterNames[p.Name] = struct{}{}
-			if p.Type == ParamTypeArray {
+			switch p.Type {
+			case ParamTypeArray:
 				arrayParameterNames[p.Name] = struct{}{}
+			case ParamTypeObject:
+				objectParameterKeys[p.Name] = map[string]struct{}{}
+				for k := range p.Properties {
+					objectParameterKeys[p.Name][k] = struct{}{}
+				}
 			}
 		}
 	}
 
-	if err := validateVariables(steps, "params", parameterNames); err != nil {
+	if err := validateVariables(steps, "(?:inputs|outputs).", "params", parameterNames); err != nil {
 		return err
 	}
-	return validateArrayUsage(steps, "params", arrayParameterNames)
+	if err := validateArrayUsage(steps, "params", arrayParameterNames); err != nil {
+		return err
+	}
+	return validateStepFields(steps, func(name, value string) *apis.FieldError {
+		if err := substitution.ValidateVariableStarred(name, value, "params", "(?:inputs|outputs).", "step", "taskspec.steps", arrayParameterNames); err != nil {
+			return err
+		}
+		return substitution.ValidateObjectKeys(name, value, "params", "(?:inputs|outputs).", "step", "taskspec.steps", objectParameterKeys)
+	})
+}
+
+// validateStepFields calls validate with each of the fields of the steps
+// which can reference variables, by name, until it returns an error.
+func validateStepFields(steps []Step, validate func(name, value string) *apis.FieldError) *apis.FieldError {
+	for _, step := range steps {
+		fields := [][2]string{{"name", step.Name}, {"image", step.Image}, {"workingDir", step.WorkingDir}, {"script", step.Script}}
+		for i, cmd := range step.Command {
+			fields = append(fields, [2]string{fmt.Sprintf("command[%d]", i), cmd})
+		}
+		for i, arg := range step.Args {
+			fields = append(fields, [2]string{fmt.Sprintf("arg[%d]", i), arg})
+		}
+		for _, env := range step.Env {
+			fields = append(fields, [2]string{fmt.Sprintf("env[%s]", env.Name), env.Value})
+		}
+		for i, v := range step.VolumeMounts {
+			fields = append(fields,
+				[2]string{fmt.Sprintf("volumeMount[%d].Name", i), v.Name},
+				[2]string{fmt.Sprintf("volumeMount[%d].MountPath", i), v.MountPath},
+				[2]string{fmt.Sprintf("volumeMount[%d].SubPath", i), v.SubPath})
+		}
+		for _, f := range fields {
+			if err := validate(f[0], f[1]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func validateResourceVariables(steps []Step, inputs *Inputs, outputs *Outputs) *apis.FieldError {
 	resourceNames := map[string]struct{}{}
+	inputNames := map[string]struct{}{}
+	outputNames := map[string]struct{}{}
 	if inputs != nil {
 		for _, r := range inputs.Resources {
 			resourceNames[r.Name] = struct{}{}
+			inputNames[r.Name] = struct{}{}
 		}
 	}
 	if outputs != nil {
 		for _, r := range outputs.Resources {
 			resourceNames[r.Name] = struct{}{}
+			outputNames[r.Name] = struct{}{}
 		}
 	}
-	return validateVariables(steps, "resources", resourceNames)
+	if err := validateVariables(steps, "(?:inputs|outputs).", "resources", resourceNames); err != nil {
+		return err
+	}
+	// $(resources.inputs.<name>.<key>) and $(resources.outputs.<name>.<key>)
+	// only refer to the resources of their direction.
+	if err := validateVariables(steps, "resources.", "inputs", inputNames); err != nil {
+		return err
+	}
+	return validateVariables(steps, "resources.", "outputs", outputNames)
 }
 
 func validateArrayUsage(steps []Step, prefix string, vars map[string]struct{}) *apis.FieldError {
@@ -254,40 +523,43 @@ func validateArrayUsage(steps []Step, prefix string, vars map[string]struct{}) *
 	return nil
 }
 
-func validateVariables(steps []Step, prefix string, vars map[string]struct{}) *apis.FieldError {
+func validateVariables(steps []Step, contextPrefix, prefix string, vars map[string]struct{}) *apis.FieldError {
 	for _, step := range steps {
-		if err := validateTaskVariable("name", step.Name, prefix, vars); err != nil {
+		if err := validateTaskVariable("name", step.Name, contextPrefix, prefix, vars); err != nil {
 			return err
 		}
-		if err := validateTaskVariable("image", step.Image, prefix, vars); err != nil {
+		if err := validateTaskVariable("image", step.Image, contextPrefix, prefix, vars); err != nil {
 			return err
 		}
-		if err := validateTaskVariable("workingDir", step.WorkingDir, prefix, vars); err != nil {
+		if err := validateTaskVariable("workingDir", step.WorkingDir, contextPrefix, prefix, vars); err != nil {
+			return err
+		}
+		if err := validateTaskVariable("script", step.Script, contextPrefix, prefix, vars); err != nil {
 			return err
 		}
 		for i, cmd := range step.Command {
-			if err := validateTaskVariable(fmt.Sprintf("command[%d]", i), cmd, prefix, vars); err != nil {
+			if err := validateTaskVariable(fmt.Sprintf("command[%d]", i), cmd, contextPrefix, prefix, vars); err != nil {
 				return err
 			}
 		}
 		for i, arg := range step.Args {
-			if err := validateTaskVariable(fmt.Sprintf("arg[%d]", i), arg, prefix, vars); err != nil {
+			if err := validateTaskVariable(fmt.Sprintf("arg[%d]", i), arg, contextPrefix, prefix, vars); err != nil {
 				return err
 			}
 		}
 		for _, env := range step.Env {
-			if err := validateTaskVariable(fmt.Sprintf("env[%s]", env.Name), env.Value, prefix, vars); err != nil {
+			if err := validateTaskVariable(fmt.Sprintf("env[%s]", env.Name), env.Value, contextPrefix, prefix, vars); err != nil {
 				return err
 			}
 		}
 		for i, v := range step.VolumeMounts {
-			if err := validateTaskVariable(fmt.Sprintf("volumeMount[%d].Name", i), v.Name, prefix, vars); err != nil {
+			if err := validateTaskVariable(fmt.Sprintf("volumeMount[%d].Name", i), v.Name, contextPrefix, prefix, vars); err != nil {
 				return err
 			}
-			if err := validateTaskVariable(fmt.Sprintf("volumeMount[%d].MountPath", i), v.MountPath, prefix, vars); err != nil {
+			if err := validateTaskVariable(fmt.Sprintf("volumeMount[%d].MountPath", i), v.MountPath, contextPrefix, prefix, vars); err != nil {
 				return err
 			}
-			if err := validateTaskVariable(fmt.Sprintf("volumeMount[%d].SubPath", i), v.SubPath, prefix, vars); err != nil {
+			if err := validateTaskVariable(fmt.Sprintf("volumeMount[%d].SubPath", i), v.SubPath, contextPrefix, prefix, vars); err != nil {
 				return err
 			}
 		}
@@ -295,8 +567,8 @@ func validateVariables(steps []Step, prefix string, vars map[string]struct{}) *a
 	return nil
 }
 
-func validateTaskVariable(name, value, prefix string, vars map[string]struct{}) *apis.FieldError {
-	return substitution.ValidateVariable(name, value, prefix, "(?:inputs|outputs).", "step", "taskspec.steps", vars)
+func validateTaskVariable(name, value, contextPrefix, prefix string, vars map[string]struct{}) *apis.FieldError {
+	return substitution.ValidateVariable(name, value, prefix, contextPrefix, "step", "taskspec.steps", vars)
 }
 
 func validateTaskNoArrayReferenced(name, value, prefix string, arrayNames map[string]struct{}) *apis.FieldError {
@@ -318,11 +590,52 @@ func checkForDuplicates(resources []TaskResource, path string) *apis.FieldError
 	return nil
 }
 
-func validateResourceType(r TaskResource, path string) *apis.FieldError {
-	for _, allowed := range AllResourceTypes {
-		if r.Type == allowed {
-			return nil
+// validateTargetPaths checks that the input resources are fetched to
+// directories that don't overlap with each other, nor with the mount path of
+// a workspace, where one would overwrite the other.
+func validateTargetPaths(inputs []TaskResource, workspaces []WorkspaceDeclaration) *apis.FieldError {
+	type target struct {
+		desc string
+		path string
+	}
+	var targets []target
+	for _, w := range workspaces {
+		targets = append(targets, target{
+			desc: fmt.Sprintf("workspace %s", w.Name),
+			path: filepath.Clean(w.GetMountPath()),
+		})
+	}
+	for _, r := range inputs {
+		path := r.TargetPath
+		if path == "" {
+			path = r.Name
+		}
+		t := target{
+			desc: fmt.Sprintf("input resource %s", r.Name),
+			path: filepath.Join(WorkspaceDir, path),
+		}
+		for _, other := range targets {
+			if pathsOverlap(t.path, other.path) {
+				return &apis.FieldError{
+					Message: fmt.Sprintf("%s is fetched to %s, which overlaps with %s at %s", t.desc, t.path, other.desc, other.path),
+					Paths:   []string{fmt.Sprintf("taskspec.Inputs.Resources.%s.TargetPath", r.Name)},
+				}
+			}
 		}
+		targets = append(targets, t)
+	}
+	return nil
+}
+
+// pathsOverlap returns true if a and b are the same directory, or if one is
+// in the other.
+func pathsOverlap(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
+func validateResourceType(r TaskResource, path string) *apis.FieldError {
+	if _, ok := GetResourceType(r.Type); ok {
+		return nil
 	}
 	return apis.ErrInvalidValue(string(r.Type), path)
 }
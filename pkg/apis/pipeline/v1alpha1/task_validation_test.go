@@ -18,13 +18,18 @@ package v1alpha1_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/test/builder"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -61,10 +66,15 @@ var invalidSteps = []v1alpha1.Step{{Container: corev1.Container{
 
 func TestTaskSpecValidate(t *testing.T) {
 	type fields struct {
-		Inputs       *v1alpha1.Inputs
-		Outputs      *v1alpha1.Outputs
-		Steps        []v1alpha1.Step
-		StepTemplate *corev1.Container
+		Inputs        *v1alpha1.Inputs
+		Outputs       *v1alpha1.Outputs
+		Steps         []v1alpha1.Step
+		StepTemplate  *corev1.Container
+		Capabilities  []v1alpha1.TaskCapability
+		MemoryVolumes []v1alpha1.MemoryVolume
+		Results       []v1alpha1.TaskResult
+		Workspaces    []v1alpha1.WorkspaceDeclaration
+		Platforms     []string
 	}
 	tests := []struct {
 		name   string
@@ -156,6 +166,21 @@ func TestTaskSpecValidate(t *testing.T) {
 				WorkingDir: "/foo/bar/$(outputs.resources.source)",
 			}}},
 		},
+	}, {
+		name: "valid resources variables",
+		fields: fields{
+			Inputs: &v1alpha1.Inputs{
+				Resources: []v1alpha1.TaskResource{validImageResource},
+			},
+			Outputs: &v1alpha1.Outputs{
+				Resources: []v1alpha1.TaskResource{validResource},
+			},
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:       "mystep",
+				Image:      "$(resources.inputs.source.url)@$(resources.inputs.source.digest)",
+				WorkingDir: "$(resources.outputs.source.path)",
+			}}},
+		},
 	}, {
 		name: "valid array template variable",
 		fields: fields{
@@ -180,6 +205,41 @@ func TestTaskSpecValidate(t *testing.T) {
 				WorkingDir: "/foo/bar/$(outputs.resources.source)",
 			}}},
 		},
+	}, {
+		name: "valid starred array template variable",
+		fields: fields{
+			Inputs: &v1alpha1.Inputs{
+				Params: []v1alpha1.ParamSpec{{
+					Name: "baz",
+					Type: v1alpha1.ParamTypeArray,
+				}},
+			},
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(inputs.params.baz[*])"},
+			}}},
+		},
+	}, {
+		name: "valid object template variable",
+		fields: fields{
+			Inputs: &v1alpha1.Inputs{
+				Params: []v1alpha1.ParamSpec{{
+					Name: "repo",
+					Type: v1alpha1.ParamTypeObject,
+					Properties: map[string]v1alpha1.PropertySpec{
+						"url":      {Type: v1alpha1.ParamTypeString},
+						"revision": {Type: v1alpha1.ParamTypeString},
+					},
+				}},
+			},
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:       "mystep",
+				Image:      "myimage",
+				Args:       []string{"clone", "$(inputs.params.repo.url)", "--revision=$(inputs.params.repo.revision)"},
+				WorkingDir: "/workspace/$(inputs.params.repo.revision)",
+			}}},
+		},
 	}, {
 		name: "step template included in validation",
 		fields: fields{
@@ -192,6 +252,23 @@ func TestTaskSpecValidate(t *testing.T) {
 				Image: "some-image",
 			},
 		},
+	}, {
+		name: "step with retries",
+		fields: fields{
+			Steps: []v1alpha1.Step{{
+				Container:    corev1.Container{Image: "my-image"},
+				Retries:      3,
+				RetryBackoff: &metav1.Duration{Duration: 10 * time.Second},
+			}},
+		},
+	}, {
+		name: "step continuing on error",
+		fields: fields{
+			Steps: []v1alpha1.Step{{
+				Container: corev1.Container{Image: "my-image"},
+				OnError:   v1alpha1.StepOnErrorContinue,
+			}},
+		},
 	}, {
 		name: "valid step with script",
 		fields: fields{
@@ -217,14 +294,96 @@ func TestTaskSpecValidate(t *testing.T) {
 				hello $1`,
 			}},
 		},
+	}, {
+		name: "valid capabilities",
+		fields: fields{
+			Steps:        validSteps,
+			Capabilities: []v1alpha1.TaskCapability{v1alpha1.TaskCapabilityDocker, v1alpha1.TaskCapabilityBuildkit},
+		},
+	}, {
+		name: "valid memory volumes",
+		fields: fields{
+			Steps: validSteps,
+			MemoryVolumes: []v1alpha1.MemoryVolume{{
+				Name:      "shm",
+				MountPath: "/dev/shm",
+				Size:      resource.MustParse("1Gi"),
+				Steps:     []string{"mystep"},
+			}, {
+				Name:      "scratch",
+				MountPath: "/scratch",
+				Size:      resource.MustParse("100Mi"),
+			}},
+		},
+	}, {
+		name: "valid results",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1alpha1.TaskResult{{
+				Name:        "commit",
+				Description: "The commit that was built",
+			}, {
+				Name: "image_digest",
+			}},
+		},
+	}, {
+		name: "valid workspaces",
+		fields: fields{
+			Steps: validSteps,
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{
+				Name:        "source",
+				Description: "The sources to build",
+			}, {
+				Name:      "cache",
+				MountPath: "/cache",
+			}},
+		},
+	}, {
+		name: "workspace and result variables",
+		fields: fields{
+			Steps: []v1alpha1.Step{{
+				Container: corev1.Container{
+					Name:       "build",
+					Image:      "myimage",
+					WorkingDir: "$(workspaces.source.path)",
+				},
+				Script: "git rev-parse HEAD > $(results.commit.path)",
+			}},
+			Results:    []v1alpha1.TaskResult{{Name: "commit"}},
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "source"}},
+		},
+	}, {
+		name: "input resources and workspaces in separate directories",
+		fields: fields{
+			Steps: validSteps,
+			Inputs: &v1alpha1.Inputs{
+				Resources: []v1alpha1.TaskResource{validResource, {ResourceDeclaration: v1alpha1.ResourceDeclaration{
+					Name:       "tools",
+					Type:       "git",
+					TargetPath: "sourcetools",
+				}}},
+			},
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "cache"}},
+		},
+	}, {
+		name: "valid platforms",
+		fields: fields{
+			Steps:     validSteps,
+			Platforms: []string{"linux/amd64", "linux/arm64", "windows/amd64"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1alpha1.TaskSpec{
-				Inputs:       tt.fields.Inputs,
-				Outputs:      tt.fields.Outputs,
-				Steps:        tt.fields.Steps,
-				StepTemplate: tt.fields.StepTemplate,
+				Inputs:        tt.fields.Inputs,
+				Outputs:       tt.fields.Outputs,
+				Steps:         tt.fields.Steps,
+				StepTemplate:  tt.fields.StepTemplate,
+				Capabilities:  tt.fields.Capabilities,
+				MemoryVolumes: tt.fields.MemoryVolumes,
+				Results:       tt.fields.Results,
+				Workspaces:    tt.fields.Workspaces,
+				Platforms:     tt.fields.Platforms,
 			}
 			ctx := context.Background()
 			ts.SetDefaults(ctx)
@@ -237,10 +396,15 @@ func TestTaskSpecValidate(t *testing.T) {
 
 func TestTaskSpecValidateError(t *testing.T) {
 	type fields struct {
-		Inputs  *v1alpha1.Inputs
-		Outputs *v1alpha1.Outputs
-		Steps   []v1alpha1.Step
-		Volumes []corev1.Volume
+		Inputs        *v1alpha1.Inputs
+		Outputs       *v1alpha1.Outputs
+		Steps         []v1alpha1.Step
+		Volumes       []corev1.Volume
+		Capabilities  []v1alpha1.TaskCapability
+		MemoryVolumes []v1alpha1.MemoryVolume
+		Results       []v1alpha1.TaskResult
+		Workspaces    []v1alpha1.WorkspaceDeclaration
+		Platforms     []string
 	}
 	tests := []struct {
 		name          string
@@ -468,6 +632,120 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: `variable type invalid in "$(inputs.params.baz)" for step image`,
 			Paths:   []string{"taskspec.steps.image"},
 		},
+	}, {
+		name: "[*] used on a string",
+		fields: fields{
+			Inputs: &v1alpha1.Inputs{
+				Params: []v1alpha1.ParamSpec{{
+					Name: "baz",
+					Type: v1alpha1.ParamTypeString,
+				}},
+			},
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(inputs.params.baz[*])"},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `[*] used on a variable that isn't an array in "$(inputs.params.baz[*])" for step arg[0]`,
+			Paths:   []string{"taskspec.steps.arg[0]"},
+		},
+	}, {
+		name: "object without properties",
+		fields: fields{
+			Inputs: &v1alpha1.Inputs{
+				Params: []v1alpha1.ParamSpec{{
+					Name: "repo",
+					Type: v1alpha1.ParamTypeObject,
+				}},
+			},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"taskspec.inputs.params.repo.properties"},
+		},
+	}, {
+		name: "object default missing a key",
+		fields: fields{
+			Inputs: &v1alpha1.Inputs{
+				Params: []v1alpha1.ParamSpec{{
+					Name: "repo",
+					Type: v1alpha1.ParamTypeObject,
+					Properties: map[string]v1alpha1.PropertySpec{
+						"url":      {Type: v1alpha1.ParamTypeString},
+						"revision": {Type: v1alpha1.ParamTypeString},
+					},
+					Default: &v1alpha1.ArrayOrString{
+						Type:      v1alpha1.ParamTypeObject,
+						ObjectVal: map[string]string{"url": "https://github.com/tektoncd/pipeline"},
+					},
+				}},
+			},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `default must have exactly the keys revision, url`,
+			Paths:   []string{"taskspec.inputs.params.repo.default"},
+		},
+	}, {
+		name: "default not in enum",
+		fields: fields{
+			Inputs: &v1alpha1.Inputs{
+				Params: []v1alpha1.ParamSpec{{
+					Name:    "arch",
+					Type:    v1alpha1.ParamTypeString,
+					Enum:    []string{"amd64", "arm64"},
+					Default: builder.ArrayOrString("s390x"),
+				}},
+			},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `default "s390x" isn't one of the enum values amd64, arm64`,
+			Paths:   []string{"taskspec.inputs.params.arch.default"},
+		},
+	}, {
+		name: "object referenced as a whole",
+		fields: fields{
+			Inputs: &v1alpha1.Inputs{
+				Params: []v1alpha1.ParamSpec{{
+					Name:       "repo",
+					Type:       v1alpha1.ParamTypeObject,
+					Properties: map[string]v1alpha1.PropertySpec{"url": {Type: v1alpha1.ParamTypeString}},
+				}},
+			},
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(inputs.params.repo)"},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `object variable must be referenced by key in "$(inputs.params.repo)" for step arg[0]`,
+			Paths:   []string{"taskspec.steps.arg[0]"},
+		},
+	}, {
+		name: "non-existent object key",
+		fields: fields{
+			Inputs: &v1alpha1.Inputs{
+				Params: []v1alpha1.ParamSpec{{
+					Name:       "repo",
+					Type:       v1alpha1.ParamTypeObject,
+					Properties: map[string]v1alpha1.PropertySpec{"url": {Type: v1alpha1.ParamTypeString}},
+				}},
+			},
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(inputs.params.repo.revision)"},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent key in "$(inputs.params.repo.revision)" for step arg[0]`,
+			Paths:   []string{"taskspec.steps.arg[0]"},
+		},
 	}, {
 		name: "array not properly isolated",
 		fields: fields{
@@ -595,6 +873,21 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: `non-existent variable in "/foo/bar/$(outputs.resources.inexistent)" for step workingDir`,
 			Paths:   []string{"taskspec.steps.workingDir"},
 		},
+	}, {
+		name: "resources variable of the wrong direction",
+		fields: fields{
+			Inputs: &v1alpha1.Inputs{
+				Resources: []v1alpha1.TaskResource{validImageResource},
+			},
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage@$(resources.outputs.source.digest)",
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "myimage@$(resources.outputs.source.digest)" for step image`,
+			Paths:   []string{"taskspec.steps.image"},
+		},
 	}, {
 		name: "Inexistent param variable with existing",
 		fields: fields{
@@ -646,14 +939,311 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: "script cannot be used with command",
 			Paths:   []string{"steps.script"},
 		},
+	}, {
+		name: "negative step retries",
+		fields: fields{
+			Steps: []v1alpha1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				Retries:   -1,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1 should be >= 0`,
+			Paths:   []string{"steps.retries"},
+		},
+	}, {
+		name: "step retry backoff without retries",
+		fields: fields{
+			Steps: []v1alpha1.Step{{
+				Container:    corev1.Container{Image: "myimage"},
+				RetryBackoff: &metav1.Duration{Duration: time.Second},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "retryBackoff can only be used with retries",
+			Paths:   []string{"steps.retryBackoff"},
+		},
+	}, {
+		name: "negative step retry backoff",
+		fields: fields{
+			Steps: []v1alpha1.Step{{
+				Container:    corev1.Container{Image: "myimage"},
+				Retries:      2,
+				RetryBackoff: &metav1.Duration{Duration: -time.Second},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1s should be >= 0`,
+			Paths:   []string{"steps.retryBackoff"},
+		},
+	}, {
+		name: "unknown step onError",
+		fields: fields{
+			Steps: []v1alpha1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				OnError:   "ignore",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: ignore`,
+			Paths:   []string{"steps.onError"},
+		},
+	}, {
+		name: "unnamed always-run step",
+		fields: fields{
+			Steps: []v1alpha1.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				AlwaysRun: true,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "always-run steps must be named",
+			Paths:   []string{"steps.name"},
+		},
+	}, {
+		name: "unknown capability",
+		fields: fields{
+			Steps:        validSteps,
+			Capabilities: []v1alpha1.TaskCapability{"gpu"},
+		},
+		expectedError: apis.FieldError{
+			Message: `unknown capability "gpu"`,
+			Paths:   []string{"capabilities"},
+			Details: "capability must be one of [docker buildkit]",
+		},
+	}, {
+		name: "duplicate capability",
+		fields: fields{
+			Steps:        validSteps,
+			Capabilities: []v1alpha1.TaskCapability{v1alpha1.TaskCapabilityDocker, v1alpha1.TaskCapabilityDocker},
+		},
+		expectedError: apis.FieldError{
+			Message: `capability "docker" specified more than once`,
+			Paths:   []string{"capabilities"},
+		},
+	}, {
+		name: "memory volume with the name of a volume",
+		fields: fields{
+			Steps: validSteps,
+			Volumes: []corev1.Volume{{
+				Name: "shm",
+			}},
+			MemoryVolumes: []v1alpha1.MemoryVolume{{
+				Name:      "shm",
+				MountPath: "/dev/shm",
+				Size:      resource.MustParse("1Gi"),
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `multiple volumes with same name "shm"`,
+			Paths:   []string{"memoryVolumes[0].name"},
+		},
+	}, {
+		name: "memory volume with a relative mount path",
+		fields: fields{
+			Steps: validSteps,
+			MemoryVolumes: []v1alpha1.MemoryVolume{{
+				Name:      "shm",
+				MountPath: "shm",
+				Size:      resource.MustParse("1Gi"),
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: shm`,
+			Paths:   []string{"memoryVolumes[0].mountPath"},
+		},
+	}, {
+		name: "memory volume without a size",
+		fields: fields{
+			Steps: validSteps,
+			MemoryVolumes: []v1alpha1.MemoryVolume{{
+				Name:      "shm",
+				MountPath: "/dev/shm",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: 0`,
+			Paths:   []string{"memoryVolumes[0].size"},
+		},
+	}, {
+		name: "memory volume mounted into an unknown step",
+		fields: fields{
+			Steps: validSteps,
+			MemoryVolumes: []v1alpha1.MemoryVolume{{
+				Name:      "shm",
+				MountPath: "/dev/shm",
+				Size:      resource.MustParse("1Gi"),
+				Steps:     []string{"nostep"},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `memory volume "shm" is mounted into unknown step "nostep"`,
+			Paths:   []string{"memoryVolumes[0].steps"},
+		},
+	}, {
+		name: "invalid result name",
+		fields: fields{
+			Steps:   validSteps,
+			Results: []v1alpha1.TaskResult{{Name: "commit.sha"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid result name "commit.sha"`,
+			Paths:   []string{"results[0].name"},
+			Details: "Result names must only contain alphanumeric characters, '-' and '_', and start and end with an alphanumeric character",
+		},
+	}, {
+		name: "duplicate result name",
+		fields: fields{
+			Steps:   validSteps,
+			Results: []v1alpha1.TaskResult{{Name: "commit"}, {Name: "commit"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"results[1].name"},
+		},
+	}, {
+		name: "invalid workspace name",
+		fields: fields{
+			Steps:      validSteps,
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "Source_Code"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid workspace name "Source_Code"`,
+			Paths:   []string{"workspaces[0].name"},
+			Details: "Workspace names must be valid DNS Labels, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		},
+	}, {
+		name: "duplicate workspace name",
+		fields: fields{
+			Steps:      validSteps,
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "source"}, {Name: "source", MountPath: "/other"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"workspaces[1].name"},
+		},
+	}, {
+		name: "workspaces mounted at the same path",
+		fields: fields{
+			Steps:      validSteps,
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "source"}, {Name: "other", MountPath: "/workspace/source/"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"workspaces[1].mountPath"},
+		},
+	}, {
+		name: "input resources fetched to overlapping directories",
+		fields: fields{
+			Steps: validSteps,
+			Inputs: &v1alpha1.Inputs{
+				Resources: []v1alpha1.TaskResource{validResource, {ResourceDeclaration: v1alpha1.ResourceDeclaration{
+					Name:       "vendor",
+					Type:       "git",
+					TargetPath: "source/vendor",
+				}}},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: "input resource vendor is fetched to /workspace/source/vendor, which overlaps with input resource source at /workspace/source",
+			Paths:   []string{"taskspec.Inputs.Resources.vendor.TargetPath"},
+		},
+	}, {
+		name: "input resource fetched to the mount path of a workspace",
+		fields: fields{
+			Steps: validSteps,
+			Inputs: &v1alpha1.Inputs{
+				Resources: []v1alpha1.TaskResource{validResource},
+			},
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "checkout", MountPath: "/workspace/source"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "input resource source is fetched to /workspace/source, which overlaps with workspace checkout at /workspace/source",
+			Paths:   []string{"taskspec.Inputs.Resources.source.TargetPath"},
+		},
+	}, {
+		name: "relative workspace mount path",
+		fields: fields{
+			Steps:      validSteps,
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "source", MountPath: "source"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: source",
+			Paths:   []string{"workspaces[0].mountPath"},
+		},
+	}, {
+		name: "non-existent workspace variable",
+		fields: fields{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:       "mystep",
+				Image:      "myimage",
+				WorkingDir: "$(workspaces.sources.path)",
+			}}},
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "source"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(workspaces.sources.path)" for step workingDir`,
+			Paths:   []string{"taskspec.steps.workingDir"},
+		},
+	}, {
+		name: "non-existent result variable",
+		fields: fields{
+			Steps: []v1alpha1.Step{{
+				Container: corev1.Container{Name: "mystep", Image: "myimage"},
+				Script:    "git rev-parse HEAD > $(results.comit.path)",
+			}},
+			Results: []v1alpha1.TaskResult{{Name: "commit"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "git rev-parse HEAD > $(results.comit.path)" for step script`,
+			Paths:   []string{"taskspec.steps.script"},
+		},
+	}, {
+		name: "non-existent param variable in script",
+		fields: fields{
+			Steps: []v1alpha1.Step{{
+				Container: corev1.Container{Name: "mystep", Image: "myimage"},
+				Script:    "echo $(inputs.params.inexistent)",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "echo $(inputs.params.inexistent)" for step script`,
+			Paths:   []string{"taskspec.steps.script"},
+		},
+	}, {
+		name: "invalid platform",
+		fields: fields{
+			Steps:     validSteps,
+			Platforms: []string{"linux/amd64", "arm64"},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid platform "arm64"`,
+			Paths:   []string{"platforms[1]"},
+			Details: "Platforms must be written <os>/<arch>, for example linux/amd64",
+		},
+	}, {
+		name: "duplicate platform",
+		fields: fields{
+			Steps:     validSteps,
+			Platforms: []string{"linux/amd64", "linux/amd64"},
+		},
+		expectedError: apis.FieldError{
+			Message: `platform "linux/amd64" specified more than once`,
+			Paths:   []string{"platforms[1]"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1alpha1.TaskSpec{
-				Inputs:  tt.fields.Inputs,
-				Outputs: tt.fields.Outputs,
-				Steps:   tt.fields.Steps,
-				Volumes: tt.fields.Volumes,
+				Inputs:        tt.fields.Inputs,
+				Outputs:       tt.fields.Outputs,
+				Steps:         tt.fields.Steps,
+				Volumes:       tt.fields.Volumes,
+				Capabilities:  tt.fields.Capabilities,
+				MemoryVolumes: tt.fields.MemoryVolumes,
+				Results:       tt.fields.Results,
+				Workspaces:    tt.fields.Workspaces,
+				Platforms:     tt.fields.Platforms,
 			}
 			ctx := context.Background()
 			ts.SetDefaults(ctx)
@@ -667,3 +1257,40 @@ func TestTaskSpecValidateError(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskSpecValidateMaximumMemoryVolumesSize(t *testing.T) {
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{MaximumMemoryVolumesBytes: 2 << 30},
+	})
+	for _, tc := range []struct {
+		name    string
+		sizes   []string
+		wantErr *apis.FieldError
+	}{{
+		name:  "within maximum",
+		sizes: []string{"1Gi", "1Gi"},
+	}, {
+		name:  "exceeds maximum",
+		sizes: []string{"1Gi", "1536Mi"},
+		wantErr: &apis.FieldError{
+			Message: "memory volumes of 2560Mi exceed the maximum of 2Gi",
+			Paths:   []string{"memoryVolumes"},
+			Details: "the nodes can't allocate more memory to the memory volumes of a pod; see maximum-memory-volumes-size in config-defaults",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := &v1alpha1.TaskSpec{Steps: validSteps}
+			for i, size := range tc.sizes {
+				ts.MemoryVolumes = append(ts.MemoryVolumes, v1alpha1.MemoryVolume{
+					Name:      fmt.Sprintf("memory-%d", i),
+					MountPath: fmt.Sprintf("/memory-%d", i),
+					Size:      resource.MustParse(size),
+				})
+			}
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tc.wantErr, err, cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff -want, +got: %v", d)
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 var _ apis.Defaultable = (*TaskRun)(nil)
 
 func (tr *TaskRun) SetDefaults(ctx context.Context) {
+	defer recordDefaultedFields(ctx, tr)()
 	tr.Spec.SetDefaults(ctx)
 }
 
@@ -50,6 +51,7 @@ func (trs *TaskRunSpec) SetDefaults(ctx context.Context) {
 		}
 		trs.Timeout = timeout
 	}
+	trs.Timeout = clampTimeout(ctx, trs.Timeout)
 
 	defaultSA := cfg.Defaults.DefaultServiceAccount
 	if trs.ServiceAccountName == "" && defaultSA != "" {
@@ -51,6 +51,39 @@ type TaskRunSpec struct {
 
 	// PodTemplate holds pod specific configuration
 	PodTemplate PodTemplate `json:"podTemplate,omitempty"`
+	// Workspaces provide the volumes of the workspaces the Task declares.
+	// +optional
+	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+	// StepOverrides replace the compute resources of steps of the Task.
+	// +optional
+	StepOverrides []TaskRunStepOverride `json:"stepOverrides,omitempty"`
+	// SidecarOverrides replace the compute resources of sidecars of the
+	// Task.
+	// +optional
+	SidecarOverrides []TaskRunSidecarOverride `json:"sidecarOverrides,omitempty"`
+	// CloudEventsSink is the URL the cloud events about the TaskRun are sent
+	// to, instead of the sink of its namespace or the
+	// default-cloud-events-sink. It is ignored unless the
+	// enable-run-cloud-events-sink feature flag is on.
+	// +optional
+	CloudEventsSink string `json:"cloudEventsSink,omitempty"`
+}
+
+// TaskRunStepOverride replaces the compute resources of a step of the Task.
+type TaskRunStepOverride struct {
+	// Name is the name of the step.
+	Name string `json:"name"`
+	// Resources replace the compute resources of the step.
+	Resources corev1.ResourceRequirements `json:"resources"`
+}
+
+// TaskRunSidecarOverride replaces the compute resources of a sidecar of the
+// Task.
+type TaskRunSidecarOverride struct {
+	// Name is the name of the sidecar.
+	Name string `json:"name"`
+	// Resources replace the compute resources of the sidecar.
+	Resources corev1.ResourceRequirements `json:"resources"`
 }
 
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
@@ -112,6 +145,11 @@ type TaskRunStatusFields struct {
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
+	// EffectiveTimeout is the timeout the TaskRun is subject to once defaults
+	// have been applied. A duration of 0 means the TaskRun has no timeout.
+	// +optional
+	EffectiveTimeout *metav1.Duration `json:"effectiveTimeout,omitempty"`
+
 	// Steps describes the state of each build step container.
 	// +optional
 	Steps []StepState `json:"steps,omitempty"`
@@ -130,9 +168,37 @@ type TaskRunStatusFields struct {
 	// optional
 	ResourcesResult []PipelineResourceResult `json:"resourcesResult,omitempty"`
 
+	// TaskRunResults are the results the steps of the Task wrote, set once
+	// the TaskRun succeeded.
+	// +optional
+	TaskRunResults []TaskRunResult `json:"taskResults,omitempty"`
+
+	// MissingResults are the names of the results the Task declares that
+	// none of its steps wrote, set once the TaskRun succeeded. They don't
+	// fail the TaskRun, e.g. when the step writing them continued on error.
+	// +optional
+	MissingResults []string `json:"missingResults,omitempty"`
+
 	// The list has one entry per sidecar in the manifest. Each entry is
 	// represents the imageid of the corresponding sidecar.
 	Sidecars []SidecarState `json:"sidecars,omitempty"`
+
+	// ContainerImages lists the image of every container of the pod that
+	// started, including the init containers and the containers injected by
+	// the controller.
+	// +optional
+	ContainerImages []ContainerImage `json:"containerImages,omitempty"`
+
+	// StepCommands lists what the entrypoint of each step executes, once
+	// params and resources have been substituted. It is only recorded when
+	// the record-step-commands feature flag is on.
+	// +optional
+	StepCommands []StepCommand `json:"stepCommands,omitempty"`
+
+	// RequestedResources is what the pod of the TaskRun requested over the
+	// time the TaskRun ran for, set once it completed.
+	// +optional
+	RequestedResources *ResourceSeconds `json:"requestedResources,omitempty"`
 }
 
 // GetCondition returns the Condition matching the given type.
@@ -165,12 +231,66 @@ type StepState struct {
 	ImageID       string `json:"imageID,omitempty"`
 }
 
+// TaskRunResult is the value of a result the Task declares.
+type TaskRunResult struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
 // SidecarState reports the results of sidecar in the Task.
 type SidecarState struct {
 	Name    string `json:"name,omitempty"`
 	ImageID string `json:"imageID,omitempty"`
 }
 
+// ContainerImage reports the image a container of the TaskRun's pod ran.
+type ContainerImage struct {
+	// Container is the name of the container in the pod.
+	Container string `json:"container"`
+	// Image is the image of the container, as specified in the pod.
+	Image string `json:"image"`
+	// ImageID is the image the container runtime resolved Image to, usually
+	// including its digest.
+	ImageID string `json:"imageID"`
+}
+
+// ResourceSeconds is an amount of resources requested over some time, e.g.
+// to attribute the cost of a run.
+type ResourceSeconds struct {
+	// CPUMilliSeconds is the CPU requested, in millicores, times the seconds
+	// it was requested for.
+	CPUMilliSeconds int64 `json:"cpuMilliSeconds"`
+	// MemoryByteSeconds is the memory requested, in bytes, times the seconds
+	// it was requested for.
+	MemoryByteSeconds int64 `json:"memoryByteSeconds"`
+}
+
+// Add adds o to r.
+func (r *ResourceSeconds) Add(o ResourceSeconds) {
+	r.CPUMilliSeconds += o.CPUMilliSeconds
+	r.MemoryByteSeconds += o.MemoryByteSeconds
+}
+
+// StepCommand is what the entrypoint of a step executes, so that the step
+// can be replayed outside of the cluster.
+type StepCommand struct {
+	// Name is the name of the step.
+	Name string `json:"name"`
+	// Image is the image of the step container.
+	Image string `json:"image"`
+	// Command is the argv the entrypoint executes.
+	Command []string `json:"command"`
+	// Env and EnvFrom are the environment of the step container. The values
+	// they reference, e.g. of Secrets, aren't resolved.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+	// WorkingDir is the working directory of the step container.
+	// +optional
+	WorkingDir string `json:"workingDir,omitempty"`
+}
+
 // CloudEventDelivery is the target of a cloud event along with the state of
 // delivery.
 type CloudEventDelivery struct {
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
@@ -33,7 +34,16 @@ func (tr *TaskRun) Validate(ctx context.Context) *apis.FieldError {
 	if err := validate.ObjectMetadata(tr.GetObjectMeta()).ViaField("metadata"); err != nil {
 		return err
 	}
-	return tr.Spec.Validate(ctx)
+	if err := tr.Spec.Validate(ctx); err != nil {
+		return err
+	}
+	var oldTaskSpec *TaskSpec
+	var oldPodTemplate *PodTemplate
+	if base, ok := apis.GetBaseline(ctx).(*TaskRun); ok && base != nil {
+		oldTaskSpec, oldPodTemplate = base.Spec.TaskSpec, &base.Spec.PodTemplate
+	}
+	return validateTaskPolicy(ctx, tr.Namespace, tr.Spec.TaskSpec, oldTaskSpec).ViaField("spec.taskSpec").Also(
+		validatePodTemplatePolicy(ctx, tr.Namespace, tr.Spec.PodTemplate, oldPodTemplate).ViaField("spec.podTemplate"))
 }
 
 // Validate taskrun spec
@@ -43,20 +53,31 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) *apis.FieldError {
 	}
 
 	// can't have both taskRef and taskSpec at the same time
-	if (ts.TaskRef != nil && ts.TaskRef.Name != "") && ts.TaskSpec != nil {
+	if (ts.TaskRef != nil && (ts.TaskRef.Name != "" || ts.TaskRef.Resolver != "")) && ts.TaskSpec != nil {
 		return apis.ErrDisallowedFields("spec.taskref", "spec.taskspec")
 	}
 
 	// Check that one of TaskRef and TaskSpec is present
-	if (ts.TaskRef == nil || (ts.TaskRef != nil && ts.TaskRef.Name == "")) && ts.TaskSpec == nil {
+	if (ts.TaskRef == nil || (ts.TaskRef != nil && ts.TaskRef.Name == "" && ts.TaskRef.Resolver == "")) && ts.TaskSpec == nil {
 		return apis.ErrMissingField("spec.taskref.name", "spec.taskspec")
 	}
 
+	if ts.TaskRef != nil {
+		if err := ts.TaskRef.validateResolver(); err != nil {
+			return err.ViaField("spec.taskref")
+		}
+	}
+
 	// Validate TaskSpec if it's present
 	if ts.TaskSpec != nil {
 		if err := ts.TaskSpec.Validate(ctx); err != nil {
 			return err
 		}
+		if ts.TaskSpec.Inputs != nil {
+			if err := validateParamValues(ts.TaskSpec.Inputs.Params, ts.Inputs.Params, "spec.inputs.params"); err != nil {
+				return err
+			}
+		}
 	}
 
 	// check for input resources
@@ -70,12 +91,65 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) *apis.FieldError {
 	}
 
 	if ts.Timeout != nil {
-		// timeout should be a valid duration of at least 0.
-		if ts.Timeout.Duration < 0 {
-			return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", ts.Timeout.Duration.String()), "spec.timeout")
+		if err := validateTimeout(ctx, ts.Timeout.Duration); err != nil {
+			return err.ViaField("spec")
 		}
 	}
 
+	if err := ts.PodTemplate.validate(); err != nil {
+		return err.ViaField("spec.podTemplate")
+	}
+
+	if err := validateWorkspaceBindings(ts.Workspaces); err != nil {
+		return err.ViaField("spec.workspaces")
+	}
+
+	if err := validateOverrides(ts.StepOverrides, ts.SidecarOverrides); err != nil {
+		return err.ViaField("spec")
+	}
+
+	if err := validateCloudEventsSink(ts.CloudEventsSink); err != nil {
+		return err.ViaField("spec")
+	}
+
+	return nil
+}
+
+// validateCloudEventsSink checks that the cloud events sink of a run, if any,
+// is an absolute URL.
+func validateCloudEventsSink(sink string) *apis.FieldError {
+	if sink == "" {
+		return nil
+	}
+	if u, err := url.Parse(sink); err != nil || !u.IsAbs() {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s isn't an absolute URL", sink), "cloudEventsSink")
+	}
+	return nil
+}
+
+// validateOverrides checks that each step and sidecar is overridden at most
+// once, by name.
+func validateOverrides(steps []TaskRunStepOverride, sidecars []TaskRunSidecarOverride) *apis.FieldError {
+	stepNames := map[string]struct{}{}
+	for i, o := range steps {
+		if o.Name == "" {
+			return apis.ErrMissingField("name").ViaFieldIndex("stepOverrides", i)
+		}
+		if _, ok := stepNames[o.Name]; ok {
+			return apis.ErrMultipleOneOf("name").ViaFieldIndex("stepOverrides", i)
+		}
+		stepNames[o.Name] = struct{}{}
+	}
+	sidecarNames := map[string]struct{}{}
+	for i, o := range sidecars {
+		if o.Name == "" {
+			return apis.ErrMissingField("name").ViaFieldIndex("sidecarOverrides", i)
+		}
+		if _, ok := sidecarNames[o.Name]; ok {
+			return apis.ErrMultipleOneOf("name").ViaFieldIndex("sidecarOverrides", i)
+		}
+		sidecarNames[o.Name] = struct{}{}
+	}
 	return nil
 }
 
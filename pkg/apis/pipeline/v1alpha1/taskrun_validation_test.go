@@ -22,10 +22,12 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/test/builder"
 	tb "github.com/tektoncd/pipeline/test/builder"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -67,6 +69,7 @@ func TestTaskRun_Validate(t *testing.T) {
 }
 
 func TestTaskRunSpec_Invalidate(t *testing.T) {
+	zeroSeconds := int64(0)
 	tests := []struct {
 		name    string
 		spec    v1alpha1.TaskRunSpec
@@ -119,6 +122,153 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			Paths:   []string{"taskspec.steps.name"},
 			Details: "Task step name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
 		},
+	}, {
+		name: "non-positive activeDeadlineSeconds",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef:     &v1alpha1.TaskRef{Name: "taskrefname"},
+			PodTemplate: v1alpha1.PodTemplate{ActiveDeadlineSeconds: &zeroSeconds},
+		},
+		wantErr: apis.ErrInvalidValue(0, "spec.podTemplate.activeDeadlineSeconds"),
+	}, {
+		name: "invalid schedulerName",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef:     &v1alpha1.TaskRef{Name: "taskrefname"},
+			PodTemplate: v1alpha1.PodTemplate{SchedulerName: "Batch Scheduler"},
+		},
+		wantErr: apis.ErrInvalidValue("Batch Scheduler", "spec.podTemplate.schedulerName"),
+	}, {
+		name: "workspace without a volume",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef:    &v1alpha1.TaskRef{Name: "taskrefname"},
+			Workspaces: []v1alpha1.WorkspaceBinding{{Name: "source"}},
+		},
+		wantErr: apis.ErrMissingOneOf("spec.workspaces[0].persistentVolumeClaim", "spec.workspaces[0].emptyDir", "spec.workspaces[0].configMap", "spec.workspaces[0].secret"),
+	}, {
+		name: "workspace with two volumes",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef: &v1alpha1.TaskRef{Name: "taskrefname"},
+			Workspaces: []v1alpha1.WorkspaceBinding{{
+				Name:                  "source",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+				EmptyDir:              &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+		wantErr: apis.ErrMultipleOneOf("spec.workspaces[0].persistentVolumeClaim", "spec.workspaces[0].emptyDir"),
+	}, {
+		name: "workspace bound twice",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef: &v1alpha1.TaskRef{Name: "taskrefname"},
+			Workspaces: []v1alpha1.WorkspaceBinding{{
+				Name:     "source",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}, {
+				Name:     "source",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+		wantErr: apis.ErrMultipleOneOf("spec.workspaces[1].name"),
+	}, {
+		name: "absolute workspace subPath",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef: &v1alpha1.TaskRef{Name: "taskrefname"},
+			Workspaces: []v1alpha1.WorkspaceBinding{{
+				Name:     "source",
+				SubPath:  "/src",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+		wantErr: apis.ErrInvalidValue("/src", "spec.workspaces[0].subPath"),
+	}, {
+		name: "step override without a name",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef:       &v1alpha1.TaskRef{Name: "taskrefname"},
+			StepOverrides: []v1alpha1.TaskRunStepOverride{{}},
+		},
+		wantErr: apis.ErrMissingField("spec.stepOverrides[0].name"),
+	}, {
+		name: "sidecar overridden twice",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef: &v1alpha1.TaskRef{Name: "taskrefname"},
+			SidecarOverrides: []v1alpha1.TaskRunSidecarOverride{{
+				Name: "proxy",
+			}, {
+				Name: "proxy",
+			}},
+		},
+		wantErr: apis.ErrMultipleOneOf("spec.sidecarOverrides[1].name"),
+	}, {
+		name: "taskref name and resolver",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef: &v1alpha1.TaskRef{Name: "taskrefname", ResolverRef: v1alpha1.ResolverRef{Resolver: "git"}},
+		},
+		wantErr: apis.ErrMultipleOneOf("spec.taskref.name", "spec.taskref.resolver"),
+	}, {
+		name: "clustertask resolver",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef: &v1alpha1.TaskRef{Kind: v1alpha1.ClusterTaskKind, ResolverRef: v1alpha1.ResolverRef{Resolver: "git"}},
+		},
+		wantErr: apis.ErrInvalidValue("ClusterTask can't be fetched by a resolver", "spec.taskref.kind"),
+	}, {
+		name: "object param missing a key of the embedded spec",
+		spec: v1alpha1.TaskRunSpec{
+			Inputs: v1alpha1.TaskRunInputs{
+				Params: []v1alpha1.Param{{
+					Name:  "repo",
+					Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeObject, ObjectVal: map[string]string{"url": "https://github.com/tektoncd/pipeline"}},
+				}},
+			},
+			TaskSpec: &v1alpha1.TaskSpec{
+				Inputs: &v1alpha1.Inputs{
+					Params: []v1alpha1.ParamSpec{{
+						Name:       "repo",
+						Type:       v1alpha1.ParamTypeObject,
+						Properties: map[string]v1alpha1.PropertySpec{"url": {Type: v1alpha1.ParamTypeString}, "revision": {Type: v1alpha1.ParamTypeString}},
+					}},
+				},
+				Steps: []v1alpha1.Step{{Container: corev1.Container{
+					Name:  "mystep",
+					Image: "myimage",
+				}}},
+			},
+		},
+		wantErr: &apis.FieldError{
+			Message: `value of param "repo" doesn't match its declared type "object"`,
+			Paths:   []string{"spec.inputs.params[0].value"},
+		},
+	}, {
+		name: "param value not in the enum of the embedded spec",
+		spec: v1alpha1.TaskRunSpec{
+			Inputs: v1alpha1.TaskRunInputs{
+				Params: []v1alpha1.Param{{
+					Name:  "arch",
+					Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeString, StringVal: "s390x"},
+				}},
+			},
+			TaskSpec: &v1alpha1.TaskSpec{
+				Inputs: &v1alpha1.Inputs{
+					Params: []v1alpha1.ParamSpec{{
+						Name: "arch",
+						Type: v1alpha1.ParamTypeString,
+						Enum: []string{"amd64", "arm64"},
+					}},
+				},
+				Steps: []v1alpha1.Step{{Container: corev1.Container{
+					Name:  "mystep",
+					Image: "myimage",
+				}}},
+			},
+		},
+		wantErr: &apis.FieldError{
+			Message: `value "s390x" of param "arch" isn't one of its enum values amd64, arm64`,
+			Paths:   []string{"spec.inputs.params[0].value"},
+		},
+	}, {
+		name: "relative cloudEventsSink",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef:         &v1alpha1.TaskRef{Name: "taskrefname"},
+			CloudEventsSink: "events",
+		},
+		wantErr: apis.ErrInvalidValue("events isn't an absolute URL", "spec.cloudEventsSink"),
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -135,6 +285,12 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 		name string
 		spec v1alpha1.TaskRunSpec
 	}{{
+		name: "cloudEventsSink",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef:         &v1alpha1.TaskRef{Name: "taskrefname"},
+			CloudEventsSink: "http://events.example.com",
+		},
+	}, {
 		name: "taskspec without a taskRef",
 		spec: v1alpha1.TaskRunSpec{
 			TaskSpec: &v1alpha1.TaskSpec{
@@ -144,6 +300,46 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 				}}},
 			},
 		},
+	}, {
+		name: "workspaces",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef: &v1alpha1.TaskRef{Name: "taskrefname"},
+			Workspaces: []v1alpha1.WorkspaceBinding{{
+				Name:                  "source",
+				SubPath:               "src",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+			}, {
+				Name: "settings",
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "maven-settings"},
+				},
+			}},
+		},
+	}, {
+		name: "step and sidecar overrides",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef: &v1alpha1.TaskRef{Name: "taskrefname"},
+			StepOverrides: []v1alpha1.TaskRunStepOverride{{
+				Name: "build",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+				},
+			}},
+			SidecarOverrides: []v1alpha1.TaskRunSidecarOverride{{
+				Name: "proxy",
+			}},
+		},
+	}, {
+		name: "taskref resolver",
+		spec: v1alpha1.TaskRunSpec{
+			TaskRef: &v1alpha1.TaskRef{ResolverRef: v1alpha1.ResolverRef{
+				Resolver: "git",
+				Params: []v1alpha1.Param{
+					{Name: "url", Value: *tb.ArrayOrString("https://github.com/tektoncd/catalog")},
+					{Name: "path", Value: *tb.ArrayOrString("task/git-clone/0.1/git-clone.yaml")},
+				},
+			}},
+		},
 	}, {
 		name: "no timeout",
 		spec: v1alpha1.TaskRunSpec{
@@ -165,6 +361,64 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 	}
 }
 
+func TestTaskRunSpec_ValidateMaximumTimeout(t *testing.T) {
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{
+			DefaultTimeoutMinutes: 30,
+			MaximumTimeoutMinutes: 60,
+			MaximumTimeoutPolicy:  config.MaximumTimeoutPolicyReject,
+			AllowNoTimeout:        true,
+		},
+	})
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		timeout time.Duration
+		wantErr *apis.FieldError
+	}{{
+		name:    "within maximum",
+		ctx:     ctx,
+		timeout: time.Hour,
+	}, {
+		name:    "exceeds maximum",
+		ctx:     ctx,
+		timeout: 2 * time.Hour,
+		wantErr: apis.ErrInvalidValue("2h0m0s exceeds the maximum timeout of 1h0m0s", "spec.timeout"),
+	}, {
+		name:    "no timeout exceeds maximum",
+		ctx:     ctx,
+		timeout: 0,
+		wantErr: apis.ErrInvalidValue("0s exceeds the maximum timeout of 1h0m0s", "spec.timeout"),
+	}, {
+		name: "no timeout not allowed",
+		ctx: config.ToContext(context.Background(), &config.Config{
+			Defaults: &config.Defaults{DefaultTimeoutMinutes: 30},
+		}),
+		timeout: 0,
+		wantErr: &apis.FieldError{
+			Message: "invalid value: 0s disables the timeout, which is not allowed",
+			Paths:   []string{"spec.timeout"},
+			Details: "omit the timeout to use the default timeout",
+		},
+	}, {
+		name:    "existing run exceeding maximum",
+		ctx:     apis.WithinUpdate(ctx, &v1alpha1.TaskRun{}),
+		timeout: 2 * time.Hour,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := v1alpha1.TaskRunSpec{
+				TaskRef: &v1alpha1.TaskRef{Name: "taskrefname"},
+				Timeout: &metav1.Duration{Duration: tc.timeout},
+			}
+			err := spec.Validate(tc.ctx)
+			if d := cmp.Diff(tc.wantErr.Error(), err.Error()); d != "" {
+				t.Errorf("TaskRunSpec.Validate (-want, +got) = %v", d)
+			}
+		})
+	}
+}
+
 func TestInput_Validate(t *testing.T) {
 	i := v1alpha1.TaskRunInputs{
 		Params: []v1alpha1.Param{{
@@ -0,0 +1,42 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// DefaultPruningInterval is the time between two prunings of the
+// TektonPipelineConfigs that don't specify it.
+const DefaultPruningInterval = time.Hour
+
+var _ apis.Defaultable = (*TektonPipelineConfig)(nil)
+
+func (tpc *TektonPipelineConfig) SetDefaults(ctx context.Context) {
+	defer recordDefaultedFields(ctx, tpc)()
+	tpc.Spec.SetDefaults(ctx)
+}
+
+func (tpcs *TektonPipelineConfigSpec) SetDefaults(ctx context.Context) {
+	if tpcs.Pruning != nil && tpcs.Pruning.Interval == nil {
+		tpcs.Pruning.Interval = &metav1.Duration{Duration: DefaultPruningInterval}
+	}
+}
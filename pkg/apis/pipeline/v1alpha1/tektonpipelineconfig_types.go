@@ -0,0 +1,253 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+// TektonPipelineConfigName is the name of the only TektonPipelineConfig the
+// controller applies.
+const TektonPipelineConfigName = "config"
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TektonPipelineConfig configures the controller and the webhook. The
+// controller writes each of its sections to the ConfigMap they read it from,
+// so that it is validated as a whole before any of it is applied.
+// +k8s:openapi-gen=true
+type TektonPipelineConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the TektonPipelineConfig from the client
+	// +optional
+	Spec TektonPipelineConfigSpec `json:"spec"`
+	// +optional
+	Status TektonPipelineConfigStatus `json:"status"`
+}
+
+// TektonPipelineConfigSpec defines the desired state of the
+// TektonPipelineConfig. The ConfigMaps of the sections that aren't set are
+// left as they are.
+type TektonPipelineConfigSpec struct {
+	// Defaults replaces the config-defaults ConfigMap.
+	// +optional
+	Defaults *PipelineConfigDefaults `json:"defaults,omitempty"`
+
+	// FeatureFlags replaces the feature-flags ConfigMap.
+	// +optional
+	FeatureFlags *PipelineConfigFeatureFlags `json:"featureFlags,omitempty"`
+
+	// ArtifactStorage replaces the config-artifact-bucket and
+	// config-artifact-pvc ConfigMaps.
+	// +optional
+	ArtifactStorage *PipelineConfigArtifactStorage `json:"artifactStorage,omitempty"`
+
+	// Metrics replaces the config-observability ConfigMap.
+	// +optional
+	Metrics *PipelineConfigMetrics `json:"metrics,omitempty"`
+
+	// Pruning deletes the completed runs that exceed the history to keep.
+	// +optional
+	Pruning *PipelineConfigPruning `json:"pruning,omitempty"`
+}
+
+// PipelineConfigDefaults holds the keys of the config-defaults ConfigMap.
+type PipelineConfigDefaults struct {
+	// +optional
+	TimeoutMinutes *int `json:"timeoutMinutes,omitempty"`
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// +optional
+	MaximumTimeoutMinutes int `json:"maximumTimeoutMinutes,omitempty"`
+	// MaximumTimeoutPolicy is either reject or clamp.
+	// +optional
+	MaximumTimeoutPolicy string `json:"maximumTimeoutPolicy,omitempty"`
+	// +optional
+	AllowNoTimeout *bool `json:"allowNoTimeout,omitempty"`
+	// +optional
+	MaximumPodVolumes int `json:"maximumPodVolumes,omitempty"`
+	// +optional
+	MaximumMemoryVolumesSize *resource.Quantity `json:"maximumMemoryVolumesSize,omitempty"`
+	// +optional
+	StepsStartTimeout *metav1.Duration `json:"stepsStartTimeout,omitempty"`
+	// +optional
+	MaximumPipelineTasks int `json:"maximumPipelineTasks,omitempty"`
+	// +optional
+	MaximumPipelineParams int `json:"maximumPipelineParams,omitempty"`
+	// +optional
+	MaximumMatrixCombinations int `json:"maximumMatrixCombinations,omitempty"`
+	// +optional
+	MaximumPipelineResultReferences int `json:"maximumPipelineResultReferences,omitempty"`
+	// +optional
+	CloudEventsSink string `json:"cloudEventsSink,omitempty"`
+}
+
+// PipelineConfigFeatureFlags holds the keys of the feature-flags ConfigMap.
+type PipelineConfigFeatureFlags struct {
+	// +optional
+	DisableCredsInit bool `json:"disableCredsInit,omitempty"`
+	// +optional
+	CredsInitSecretLabelSelector string `json:"credsInitSecretLabelSelector,omitempty"`
+	// +optional
+	CredsInitSecretAnnotationSelector string `json:"credsInitSecretAnnotationSelector,omitempty"`
+	// +optional
+	EnableCleanupFinalizer bool `json:"enableCleanupFinalizer,omitempty"`
+	// +optional
+	RecordDefaultedFields bool `json:"recordDefaultedFields,omitempty"`
+	// +optional
+	RecordStepCommands bool `json:"recordStepCommands,omitempty"`
+	// +optional
+	AllowedOutputResources []PipelineResourceType `json:"allowedOutputResources,omitempty"`
+	// +optional
+	EnableRunCloudEventsSink bool `json:"enableRunCloudEventsSink,omitempty"`
+}
+
+// PipelineConfigArtifactStorage configures where the outputs of
+// PipelineTasks are stored for the PipelineTasks using them: in a bucket
+// when it is set, otherwise in a PersistentVolumeClaim.
+type PipelineConfigArtifactStorage struct {
+	// Bucket holds the keys of the config-artifact-bucket ConfigMap.
+	// +optional
+	Bucket *PipelineConfigArtifactBucket `json:"bucket,omitempty"`
+	// PVC holds the keys of the config-artifact-pvc ConfigMap.
+	// +optional
+	PVC *PipelineConfigArtifactPVC `json:"pvc,omitempty"`
+}
+
+// PipelineConfigArtifactBucket configures the bucket storing the artifacts.
+type PipelineConfigArtifactBucket struct {
+	// Location is the URL of the bucket, for example gs://my-bucket.
+	Location string `json:"location"`
+	// ServiceAccountSecret is the key of the Secret holding the credentials
+	// of the bucket.
+	// +optional
+	ServiceAccountSecret *corev1.SecretKeySelector `json:"serviceAccountSecret,omitempty"`
+	// ServiceAccountFieldName is the environment variable the path of the
+	// credentials is set in.
+	// +optional
+	ServiceAccountFieldName string `json:"serviceAccountFieldName,omitempty"`
+	// Provider is the tool copying the artifacts, gcs (gsutil) or s3 (the
+	// AWS CLI).
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// Region is the region of the bucket of the s3 provider.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Endpoint is the endpoint URL of the s3 provider, for S3-compatible
+	// storage.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// PipelineConfigArtifactPVC configures the PersistentVolumeClaims storing
+// the artifacts.
+type PipelineConfigArtifactPVC struct {
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+}
+
+// PipelineConfigMetrics holds the keys of the config-observability ConfigMap.
+type PipelineConfigMetrics struct {
+	// BackendDestination is either prometheus or stackdriver.
+	// +optional
+	BackendDestination string `json:"backendDestination,omitempty"`
+	// +optional
+	StackdriverProjectID string `json:"stackdriverProjectID,omitempty"`
+	// +optional
+	AllowStackdriverCustomMetrics bool `json:"allowStackdriverCustomMetrics,omitempty"`
+	// EnableProfiling serves the Go pprof profiles of the controller.
+	// +optional
+	EnableProfiling bool `json:"enableProfiling,omitempty"`
+}
+
+// PipelineConfigPruning configures how many completed runs are kept.
+type PipelineConfigPruning struct {
+	// Keep is the number of completed PipelineRuns, and of completed
+	// TaskRuns not run by a PipelineRun, kept in each namespace. The runs
+	// that completed first are deleted. It must be at least 1.
+	Keep int `json:"keep"`
+	// Interval is the time between two prunings.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+var tektonPipelineConfigCondSet = apis.NewLivingConditionSet()
+
+// TektonPipelineConfigStatus defines the observed state of the
+// TektonPipelineConfig
+type TektonPipelineConfigStatus struct {
+	duckv1beta1.Status `json:",inline"`
+
+	// ConfigMaps are the ConfigMaps last written, with the data they were
+	// written with.
+	// +optional
+	ConfigMaps []AppliedConfigMap `json:"configMaps,omitempty"`
+
+	// LastPruneTime is the last time the completed runs were pruned.
+	// +optional
+	LastPruneTime *metav1.Time `json:"lastPruneTime,omitempty"`
+
+	// PrunedRuns is the number of runs pruned so far.
+	// +optional
+	PrunedRuns int64 `json:"prunedRuns,omitempty"`
+}
+
+// AppliedConfigMap is a ConfigMap written from a TektonPipelineConfig.
+type AppliedConfigMap struct {
+	Name string `json:"name"`
+	// +optional
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// GetCondition returns the Condition matching the given type.
+func (tpcs *TektonPipelineConfigStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return tektonPipelineConfigCondSet.Manage(tpcs).GetCondition(t)
+}
+
+// InitializeConditions sets the Ready condition to unknown.
+func (tpcs *TektonPipelineConfigStatus) InitializeConditions() {
+	tektonPipelineConfigCondSet.Manage(tpcs).InitializeConditions()
+}
+
+// SetCondition sets the condition, unsetting previous conditions with the same
+// type as necessary.
+func (tpcs *TektonPipelineConfigStatus) SetCondition(newCond *apis.Condition) {
+	if newCond != nil {
+		tektonPipelineConfigCondSet.Manage(tpcs).SetCondition(*newCond)
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TektonPipelineConfigList contains a list of TektonPipelineConfigs
+type TektonPipelineConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TektonPipelineConfig `json:"items"`
+}
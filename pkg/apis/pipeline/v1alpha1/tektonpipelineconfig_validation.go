@@ -0,0 +1,179 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*TektonPipelineConfig)(nil)
+
+func (tpc *TektonPipelineConfig) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(tpc.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	// The controller only applies one configuration.
+	if tpc.Name != TektonPipelineConfigName {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", tpc.Name, TektonPipelineConfigName), "metadata.name")
+	}
+	return tpc.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (tpcs *TektonPipelineConfigSpec) Validate(ctx context.Context) *apis.FieldError {
+	if tpcs.Defaults != nil {
+		if err := tpcs.Defaults.validate(); err != nil {
+			return err.ViaField("defaults")
+		}
+	}
+	if tpcs.FeatureFlags != nil {
+		if err := tpcs.FeatureFlags.validate(); err != nil {
+			return err.ViaField("featureFlags")
+		}
+	}
+	if tpcs.ArtifactStorage != nil {
+		if err := tpcs.ArtifactStorage.validate(); err != nil {
+			return err.ViaField("artifactStorage")
+		}
+	}
+	if tpcs.Metrics != nil {
+		if err := tpcs.Metrics.validate(); err != nil {
+			return err.ViaField("metrics")
+		}
+	}
+	if tpcs.Pruning != nil {
+		// Keeping no run would delete every completed run of the cluster.
+		if tpcs.Pruning.Keep < 1 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%d should be at least 1", tpcs.Pruning.Keep), "pruning.keep")
+		}
+		if tpcs.Pruning.Interval != nil && tpcs.Pruning.Interval.Duration <= 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be positive", tpcs.Pruning.Interval.Duration), "pruning.interval")
+		}
+	}
+	return nil
+}
+
+// validate checks the defaults as NewDefaultsFromMap parses them.
+func (d *PipelineConfigDefaults) validate() *apis.FieldError {
+	timeoutMinutes := config.DefaultTimeoutMinutes
+	if d.TimeoutMinutes != nil {
+		timeoutMinutes = *d.TimeoutMinutes
+	}
+	if timeoutMinutes < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%d should be positive", timeoutMinutes), "timeoutMinutes")
+	}
+	if d.MaximumTimeoutMinutes < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%d should be positive", d.MaximumTimeoutMinutes), "maximumTimeoutMinutes")
+	}
+	switch config.MaximumTimeoutPolicy(d.MaximumTimeoutPolicy) {
+	case "", config.MaximumTimeoutPolicyReject, config.MaximumTimeoutPolicyClamp:
+	default:
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", d.MaximumTimeoutPolicy, config.MaximumTimeoutPolicyReject, config.MaximumTimeoutPolicyClamp), "maximumTimeoutPolicy")
+	}
+	if d.AllowNoTimeout != nil && !*d.AllowNoTimeout && timeoutMinutes == 0 {
+		return apis.ErrInvalidValue("0 isn't allowed when allowNoTimeout is false", "timeoutMinutes")
+	}
+	if d.MaximumTimeoutMinutes > 0 && (timeoutMinutes == 0 || timeoutMinutes > d.MaximumTimeoutMinutes) {
+		return apis.ErrInvalidValue(fmt.Sprintf("%d exceeds maximumTimeoutMinutes %d", timeoutMinutes, d.MaximumTimeoutMinutes), "timeoutMinutes")
+	}
+	if d.MaximumPodVolumes < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%d should be positive", d.MaximumPodVolumes), "maximumPodVolumes")
+	}
+	if d.MaximumMemoryVolumesSize != nil && d.MaximumMemoryVolumesSize.Sign() < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be positive", d.MaximumMemoryVolumesSize), "maximumMemoryVolumesSize")
+	}
+	if d.StepsStartTimeout != nil && d.StepsStartTimeout.Duration < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be positive", d.StepsStartTimeout.Duration), "stepsStartTimeout")
+	}
+	for _, m := range []struct {
+		field   string
+		maximum int
+	}{
+		{"maximumPipelineTasks", d.MaximumPipelineTasks},
+		{"maximumPipelineParams", d.MaximumPipelineParams},
+		{"maximumMatrixCombinations", d.MaximumMatrixCombinations},
+		{"maximumPipelineResultReferences", d.MaximumPipelineResultReferences},
+	} {
+		if m.maximum < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%d should be positive", m.maximum), m.field)
+		}
+	}
+	if d.CloudEventsSink != "" {
+		if u, err := url.Parse(d.CloudEventsSink); err != nil || !u.IsAbs() {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be an absolute URL", d.CloudEventsSink), "cloudEventsSink")
+		}
+	}
+	return nil
+}
+
+func (ff *PipelineConfigFeatureFlags) validate() *apis.FieldError {
+	if _, err := labels.Parse(ff.CredsInitSecretLabelSelector); err != nil {
+		return apis.ErrInvalidValue(err.Error(), "credsInitSecretLabelSelector")
+	}
+	if _, err := config.NewFeatureFlagsFromMap(map[string]string{config.CredsInitSecretAnnotationSelectorKey: ff.CredsInitSecretAnnotationSelector}); err != nil {
+		return apis.ErrInvalidValue(err.Error(), "credsInitSecretAnnotationSelector")
+	}
+	for _, t := range ff.AllowedOutputResources {
+		if _, err := config.NewFeatureFlagsFromMap(map[string]string{config.AllowedOutputResourcesKey: string(t)}); err != nil {
+			return apis.ErrInvalidValue(fmt.Sprintf("the outputs of %q resources can't be copied to the next Tasks", t), "allowedOutputResources")
+		}
+	}
+	return nil
+}
+
+func (as *PipelineConfigArtifactStorage) validate() *apis.FieldError {
+	if as.Bucket != nil {
+		if as.Bucket.Location == "" {
+			return apis.ErrMissingField("bucket.location")
+		}
+		if u, err := url.Parse(as.Bucket.Location); err != nil || u.Scheme == "" {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be the URL of a bucket, for example gs://my-bucket", as.Bucket.Location), "bucket.location")
+		}
+		if s := as.Bucket.ServiceAccountSecret; s != nil && (s.Name == "" || s.Key == "") {
+			return apis.ErrMissingField("bucket.serviceAccountSecret.name", "bucket.serviceAccountSecret.key")
+		}
+		switch as.Bucket.Provider {
+		case "", ArtifactBucketProviderGCS:
+		case ArtifactBucketProviderS3:
+			if !strings.HasPrefix(as.Bucket.Location, "s3://") {
+				return apis.ErrInvalidValue(fmt.Sprintf("%s should be an s3:// URL with the s3 provider", as.Bucket.Location), "bucket.location")
+			}
+		default:
+			return apis.ErrInvalidValue(as.Bucket.Provider, "bucket.provider")
+		}
+	}
+	if as.PVC != nil && as.PVC.Size != nil && as.PVC.Size.Sign() <= 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be positive", as.PVC.Size), "pvc.size")
+	}
+	return nil
+}
+
+func (m *PipelineConfigMetrics) validate() *apis.FieldError {
+	switch m.BackendDestination {
+	case "", "prometheus", "stackdriver":
+	default:
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be prometheus or stackdriver", m.BackendDestination), "backendDestination")
+	}
+	return nil
+}
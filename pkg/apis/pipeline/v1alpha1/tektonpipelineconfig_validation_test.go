@@ -0,0 +1,216 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestTektonPipelineConfig_Validate(t *testing.T) {
+	timeout, noTimeout := 30, false
+	size := resource.MustParse("10Gi")
+	for _, tc := range []struct {
+		name string
+		spec v1alpha1.TektonPipelineConfigSpec
+	}{{
+		name: "empty",
+		spec: v1alpha1.TektonPipelineConfigSpec{},
+	}, {
+		name: "every section",
+		spec: v1alpha1.TektonPipelineConfigSpec{
+			Defaults: &v1alpha1.PipelineConfigDefaults{
+				TimeoutMinutes:        &timeout,
+				MaximumTimeoutMinutes: 120,
+				MaximumTimeoutPolicy:  "clamp",
+				AllowNoTimeout:        &noTimeout,
+				StepsStartTimeout:     &metav1.Duration{Duration: 5 * time.Minute},
+				MaximumPipelineTasks:  100,
+				CloudEventsSink:       "http://events.example.com",
+			},
+			FeatureFlags: &v1alpha1.PipelineConfigFeatureFlags{
+				DisableCredsInit:             true,
+				CredsInitSecretLabelSelector: "tekton.dev/creds-init=allowed",
+				AllowedOutputResources:       []v1alpha1.PipelineResourceType{v1alpha1.PipelineResourceTypeImage},
+			},
+			ArtifactStorage: &v1alpha1.PipelineConfigArtifactStorage{
+				Bucket: &v1alpha1.PipelineConfigArtifactBucket{
+					Location: "gs://my-bucket",
+					ServiceAccountSecret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "bucket-sa"},
+						Key:                  "service_account.json",
+					},
+				},
+				PVC: &v1alpha1.PipelineConfigArtifactPVC{Size: &size},
+			},
+			Metrics: &v1alpha1.PipelineConfigMetrics{BackendDestination: "stackdriver", StackdriverProjectID: "my-project"},
+			Pruning: &v1alpha1.PipelineConfigPruning{Keep: 10},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tpc := &v1alpha1.TektonPipelineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "config"},
+				Spec:       tc.spec,
+			}
+			if err := tpc.Validate(context.Background()); err != nil {
+				t.Errorf("TektonPipelineConfig.Validate() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestTektonPipelineConfig_Invalidate(t *testing.T) {
+	zero, tooLong, noTimeout := 0, 90, false
+	for _, tc := range []struct {
+		name          string
+		configName    string
+		spec          v1alpha1.TektonPipelineConfigSpec
+		expectedError apis.FieldError
+	}{{
+		name:       "not the controller's name",
+		configName: "my-config",
+		expectedError: apis.FieldError{
+			Message: "invalid value: my-config should be config",
+			Paths:   []string{"metadata.name"},
+		},
+	}, {
+		name: "unknown maximum timeout policy",
+		spec: v1alpha1.TektonPipelineConfigSpec{Defaults: &v1alpha1.PipelineConfigDefaults{MaximumTimeoutPolicy: "rejcet"}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: rejcet should be reject or clamp",
+			Paths:   []string{"spec.defaults.maximumTimeoutPolicy"},
+		},
+	}, {
+		name: "default timeout exceeds the maximum",
+		spec: v1alpha1.TektonPipelineConfigSpec{Defaults: &v1alpha1.PipelineConfigDefaults{TimeoutMinutes: &tooLong, MaximumTimeoutMinutes: 60}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: 90 exceeds maximumTimeoutMinutes 60",
+			Paths:   []string{"spec.defaults.timeoutMinutes"},
+		},
+	}, {
+		name: "no default timeout when runs must have one",
+		spec: v1alpha1.TektonPipelineConfigSpec{Defaults: &v1alpha1.PipelineConfigDefaults{TimeoutMinutes: &zero, AllowNoTimeout: &noTimeout}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: 0 isn't allowed when allowNoTimeout is false",
+			Paths:   []string{"spec.defaults.timeoutMinutes"},
+		},
+	}, {
+		name: "relative cloud events sink",
+		spec: v1alpha1.TektonPipelineConfigSpec{Defaults: &v1alpha1.PipelineConfigDefaults{CloudEventsSink: "events"}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: events should be an absolute URL",
+			Paths:   []string{"spec.defaults.cloudEventsSink"},
+		},
+	}, {
+		name: "invalid creds init selector",
+		spec: v1alpha1.TektonPipelineConfigSpec{FeatureFlags: &v1alpha1.PipelineConfigFeatureFlags{CredsInitSecretLabelSelector: "a b"}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: unable to parse requirement: found 'b', expected: '=', '!=', '==', 'in', notin'`,
+			Paths:   []string{"spec.featureFlags.credsInitSecretLabelSelector"},
+		},
+	}, {
+		name: "output resources that can't be copied",
+		spec: v1alpha1.TektonPipelineConfigSpec{FeatureFlags: &v1alpha1.PipelineConfigFeatureFlags{AllowedOutputResources: []v1alpha1.PipelineResourceType{v1alpha1.PipelineResourceTypePullRequest}}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: the outputs of "pullRequest" resources can't be copied to the next Tasks`,
+			Paths:   []string{"spec.featureFlags.allowedOutputResources"},
+		},
+	}, {
+		name: "bucket without location",
+		spec: v1alpha1.TektonPipelineConfigSpec{ArtifactStorage: &v1alpha1.PipelineConfigArtifactStorage{Bucket: &v1alpha1.PipelineConfigArtifactBucket{}}},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"spec.artifactStorage.bucket.location"},
+		},
+	}, {
+		name: "bucket location isn't a URL",
+		spec: v1alpha1.TektonPipelineConfigSpec{ArtifactStorage: &v1alpha1.PipelineConfigArtifactStorage{Bucket: &v1alpha1.PipelineConfigArtifactBucket{Location: "my-bucket"}}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: my-bucket should be the URL of a bucket, for example gs://my-bucket",
+			Paths:   []string{"spec.artifactStorage.bucket.location"},
+		},
+	}, {
+		name: "unknown bucket provider",
+		spec: v1alpha1.TektonPipelineConfigSpec{ArtifactStorage: &v1alpha1.PipelineConfigArtifactStorage{Bucket: &v1alpha1.PipelineConfigArtifactBucket{Location: "gs://my-bucket", Provider: "azure"}}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: azure",
+			Paths:   []string{"spec.artifactStorage.bucket.provider"},
+		},
+	}, {
+		name: "s3 provider with a gs bucket",
+		spec: v1alpha1.TektonPipelineConfigSpec{ArtifactStorage: &v1alpha1.PipelineConfigArtifactStorage{Bucket: &v1alpha1.PipelineConfigArtifactBucket{Location: "gs://my-bucket", Provider: "s3"}}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: gs://my-bucket should be an s3:// URL with the s3 provider",
+			Paths:   []string{"spec.artifactStorage.bucket.location"},
+		},
+	}, {
+		name: "unknown metrics backend",
+		spec: v1alpha1.TektonPipelineConfigSpec{Metrics: &v1alpha1.PipelineConfigMetrics{BackendDestination: "prometeus"}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: prometeus should be prometheus or stackdriver",
+			Paths:   []string{"spec.metrics.backendDestination"},
+		},
+	}, {
+		name: "negative maximum pipeline tasks",
+		spec: v1alpha1.TektonPipelineConfigSpec{Defaults: &v1alpha1.PipelineConfigDefaults{MaximumPipelineTasks: -1}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1 should be positive",
+			Paths:   []string{"spec.defaults.maximumPipelineTasks"},
+		},
+	}, {
+		name: "negative pruning keep",
+		spec: v1alpha1.TektonPipelineConfigSpec{Pruning: &v1alpha1.PipelineConfigPruning{Keep: -1}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1 should be at least 1",
+			Paths:   []string{"spec.pruning.keep"},
+		},
+	}, {
+		name: "pruning without keep",
+		spec: v1alpha1.TektonPipelineConfigSpec{Pruning: &v1alpha1.PipelineConfigPruning{}},
+		expectedError: apis.FieldError{
+			Message: "invalid value: 0 should be at least 1",
+			Paths:   []string{"spec.pruning.keep"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			name := tc.configName
+			if name == "" {
+				name = "config"
+			}
+			tpc := &v1alpha1.TektonPipelineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       tc.spec,
+			}
+			err := tpc.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Expected an Error, got nothing for %v", tc)
+			}
+			if d := cmp.Diff(tc.expectedError, *err, cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TektonPipelineConfig.Validate() errors diff -want, +got: %v", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// UnreachableTask is a PipelineTask which can never run, whatever the params
+// and results of a PipelineRun.
+type UnreachableTask struct {
+	// Name is the name of the PipelineTask.
+	Name string
+	// Reason describes why it can never run.
+	Reason string
+}
+
+// UnreachableTasks returns the tasks of the Pipeline which can never run:
+// their WhenExpressions can't all be true, or they depend on a task which can
+// never run, and is therefore always skipped along with the tasks depending
+// on it. Such tasks don't make the Pipeline invalid, but are most likely a
+// mistake.
+func (ps *PipelineSpec) UnreachableTasks() []UnreachableTask {
+	tasks := map[string]PipelineTask{}
+	for _, t := range ps.Tasks {
+		tasks[t.Name] = t
+	}
+	reasons := map[string]string{}
+	var reason func(name string, visiting map[string]struct{}) string
+	reason = func(name string, visiting map[string]struct{}) string {
+		if r, ok := reasons[name]; ok {
+			return r
+		}
+		t, ok := tasks[name]
+		if !ok {
+			return ""
+		}
+		if _, ok := visiting[name]; ok {
+			return ""
+		}
+		visiting[name] = struct{}{}
+		r := t.WhenExpressions.contradiction()
+		if r == "" {
+			for _, dep := range t.Deps() {
+				if reason(dep, visiting) != "" {
+					r = fmt.Sprintf("it depends on %s, which can never run", dep)
+					break
+				}
+			}
+		}
+		reasons[name] = r
+		return r
+	}
+
+	var unreachable []UnreachableTask
+	for _, t := range ps.Tasks {
+		if r := reason(t.Name, map[string]struct{}{}); r != "" {
+			unreachable = append(unreachable, UnreachableTask{Name: t.Name, Reason: r})
+		}
+	}
+	return unreachable
+}
+
+// contradiction returns why the expressions can't all be true, whatever the
+// values of the variables they use, or "" if they can.
+func (wes WhenExpressions) contradiction() string {
+	// The values each input must be one of, and the values it mustn't be,
+	// among those which don't use variables.
+	var inputs []string
+	allowed := map[string]map[string]struct{}{}
+	excluded := map[string]map[string]struct{}{}
+	for i := range wes {
+		we := &wes[i]
+		if !hasVariables(we.Input) && !hasVariables(we.Values...) && !we.isTrue() {
+			return fmt.Sprintf("its when expression %q %s %v is always false", we.Input, we.Operator, we.Values)
+		}
+		if _, ok := excluded[we.Input]; !ok {
+			inputs = append(inputs, we.Input)
+			excluded[we.Input] = map[string]struct{}{}
+		}
+		switch we.Operator {
+		case selection.In:
+			if hasVariables(we.Values...) {
+				continue
+			}
+			values := map[string]struct{}{}
+			for _, v := range we.Values {
+				if _, ok := allowed[we.Input]; !ok {
+					values[v] = struct{}{}
+				} else if _, ok := allowed[we.Input][v]; ok {
+					values[v] = struct{}{}
+				}
+			}
+			allowed[we.Input] = values
+		case selection.NotIn:
+			for _, v := range we.Values {
+				if !hasVariables(v) {
+					excluded[we.Input][v] = struct{}{}
+				}
+			}
+		}
+	}
+	for _, input := range inputs {
+		values, ok := allowed[input]
+		if !ok {
+			continue
+		}
+		possible := false
+		for v := range values {
+			if _, ok := excluded[input][v]; !ok {
+				possible = true
+				break
+			}
+		}
+		if !possible {
+			return fmt.Sprintf("its when expressions on %q can't all be true", input)
+		}
+	}
+	return ""
+}
+
+func hasVariables(values ...string) bool {
+	for _, v := range values {
+		if strings.Contains(v, "$(") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+func TestPipelineSpec_UnreachableTasks(t *testing.T) {
+	branchIn := func(values ...string) v1alpha1.WhenExpression {
+		return v1alpha1.WhenExpression{Input: "$(params.branch)", Operator: selection.In, Values: values}
+	}
+	branchNotIn := func(values ...string) v1alpha1.WhenExpression {
+		return v1alpha1.WhenExpression{Input: "$(params.branch)", Operator: selection.NotIn, Values: values}
+	}
+	stringParam := func(name, value string) v1alpha1.Param {
+		return v1alpha1.Param{Name: name, Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeString, StringVal: value}}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		tasks []v1alpha1.PipelineTask
+		want  []v1alpha1.UnreachableTask
+	}{{
+		name: "no when expressions",
+		tasks: []v1alpha1.PipelineTask{
+			{Name: "build"},
+			{Name: "deploy", RunAfter: []string{"build"}},
+		},
+	}, {
+		name: "when expressions which can be true",
+		tasks: []v1alpha1.PipelineTask{{
+			Name: "deploy",
+			WhenExpressions: v1alpha1.WhenExpressions{
+				branchIn("main", "release"),
+				branchIn("release", "hotfix"),
+				branchNotIn("main"),
+				{Input: "$(params.environment)", Operator: selection.In, Values: []string{"$(params.branch)"}},
+			},
+		}},
+	}, {
+		name: "when expression always false",
+		tasks: []v1alpha1.PipelineTask{{
+			Name:            "deploy",
+			WhenExpressions: v1alpha1.WhenExpressions{{Input: "main", Operator: selection.NotIn, Values: []string{"main"}}},
+		}},
+		want: []v1alpha1.UnreachableTask{{Name: "deploy", Reason: `its when expression "main" notin [main] is always false`}},
+	}, {
+		name: "contradictory in expressions",
+		tasks: []v1alpha1.PipelineTask{{
+			Name:            "deploy",
+			WhenExpressions: v1alpha1.WhenExpressions{branchIn("main"), branchIn("release")},
+		}},
+		want: []v1alpha1.UnreachableTask{{Name: "deploy", Reason: `its when expressions on "$(params.branch)" can't all be true`}},
+	}, {
+		name: "in expression excluded by notin expressions",
+		tasks: []v1alpha1.PipelineTask{{
+			Name:            "deploy",
+			WhenExpressions: v1alpha1.WhenExpressions{branchNotIn("main"), branchIn("main", "release"), branchNotIn("release")},
+		}},
+		want: []v1alpha1.UnreachableTask{{Name: "deploy", Reason: `its when expressions on "$(params.branch)" can't all be true`}},
+	}, {
+		name: "tasks depending on a task which can never run",
+		tasks: []v1alpha1.PipelineTask{{
+			Name: "notify",
+			Params: []v1alpha1.Param{
+				stringParam("image", "$(tasks.deploy.results.image)"),
+			},
+		}, {
+			Name:            "deploy",
+			RunAfter:        []string{"build"},
+			WhenExpressions: v1alpha1.WhenExpressions{branchIn("main"), branchNotIn("main")},
+		}, {
+			Name: "build",
+		}, {
+			Name:            "verify",
+			WhenExpressions: v1alpha1.WhenExpressions{{Input: "$(tasks.notify.results.sent)", Operator: selection.In, Values: []string{"true"}}},
+		}},
+		want: []v1alpha1.UnreachableTask{
+			{Name: "notify", Reason: "it depends on deploy, which can never run"},
+			{Name: "deploy", Reason: `its when expressions on "$(params.branch)" can't all be true`},
+			{Name: "verify", Reason: "it depends on notify, which can never run"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := &v1alpha1.PipelineSpec{Tasks: tc.tasks}
+			if d := cmp.Diff(tc.want, ps.UnreachableTasks()); d != "" {
+				t.Errorf("Unexpected unreachable tasks (-want, +got): %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// AllowedWhenOperators are the operators of WhenExpressions.
+var AllowedWhenOperators = []selection.Operator{selection.In, selection.NotIn}
+
+// WhenExpression allows a PipelineTask to run only if Input is, or isn't, one
+// of Values. Unlike Conditions, they are evaluated by the controller, without
+// running a Pod.
+type WhenExpression struct {
+	// Input is the string the expression is evaluated over, for example
+	// "$(params.branch)".
+	Input string `json:"input"`
+	// Operator is either "in" or "notin".
+	Operator selection.Operator `json:"operator"`
+	// Values are the strings Input is compared to.
+	Values []string `json:"values"`
+}
+
+func (we *WhenExpression) isTrue() bool {
+	in := false
+	for _, v := range we.Values {
+		if v == we.Input {
+			in = true
+			break
+		}
+	}
+	if we.Operator == selection.NotIn {
+		return !in
+	}
+	return in
+}
+
+func (we *WhenExpression) applyReplacements(replacements map[string]string) {
+	we.Input = substitution.ApplyReplacements(we.Input, replacements)
+	for i, v := range we.Values {
+		we.Values[i] = substitution.ApplyReplacements(v, replacements)
+	}
+}
+
+// WhenExpressions are the WhenExpressions of a PipelineTask, which runs only
+// if all of them are true.
+type WhenExpressions []WhenExpression
+
+// AllowsExecution returns true if every expression is true, once the
+// variables they use are substituted.
+func (wes WhenExpressions) AllowsExecution() bool {
+	for i := range wes {
+		if !wes[i].isTrue() {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyReplacements replaces the variables of the inputs and values of the
+// expressions with replacements.
+func (wes WhenExpressions) ApplyReplacements(replacements map[string]string) {
+	for i := range wes {
+		wes[i].applyReplacements(replacements)
+	}
+}
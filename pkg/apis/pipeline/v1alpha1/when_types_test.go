@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+func TestWhenExpressions_AllowsExecution(t *testing.T) {
+	tests := []struct {
+		name     string
+		wes      v1alpha1.WhenExpressions
+		expected bool
+	}{{
+		name:     "no expressions",
+		expected: true,
+	}, {
+		name: "in",
+		wes: v1alpha1.WhenExpressions{{
+			Input:    "main",
+			Operator: selection.In,
+			Values:   []string{"main", "release"},
+		}},
+		expected: true,
+	}, {
+		name: "not in",
+		wes: v1alpha1.WhenExpressions{{
+			Input:    "main",
+			Operator: selection.In,
+			Values:   []string{"release"},
+		}},
+		expected: false,
+	}, {
+		name: "notin",
+		wes: v1alpha1.WhenExpressions{{
+			Input:    "main",
+			Operator: selection.NotIn,
+			Values:   []string{"release"},
+		}},
+		expected: true,
+	}, {
+		name: "one of several false",
+		wes: v1alpha1.WhenExpressions{{
+			Input:    "main",
+			Operator: selection.In,
+			Values:   []string{"main"},
+		}, {
+			Input:    "production",
+			Operator: selection.NotIn,
+			Values:   []string{"production"},
+		}},
+		expected: false,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.wes.AllowsExecution(); got != tc.expected {
+				t.Errorf("AllowsExecution() = %t, expected %t", got, tc.expected)
+			}
+		})
+	}
+}
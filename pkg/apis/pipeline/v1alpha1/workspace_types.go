@@ -0,0 +1,101 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// WorkspaceDeclaration declares a directory that the steps of a Task use,
+// whose volume is provided by the TaskRun.
+type WorkspaceDeclaration struct {
+	// Name is the name the TaskRun binds the workspace by.
+	Name string `json:"name"`
+	// Description is what the Task uses the workspace for.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// MountPath is where the workspace is mounted in the steps, /workspace/<name>
+	// if empty.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+	// ReadOnly mounts the workspace read-only.
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// GetMountPath returns where the workspace is mounted in the steps.
+func (w WorkspaceDeclaration) GetMountPath() string {
+	if w.MountPath != "" {
+		return w.MountPath
+	}
+	return filepath.Join(WorkspaceDir, w.Name)
+}
+
+// WorkspaceBinding provides the volume of a workspace. Exactly one of
+// PersistentVolumeClaim, EmptyDir, ConfigMap and Secret must be set.
+type WorkspaceBinding struct {
+	// Name is the name of the workspace the volume is provided for.
+	Name string `json:"name"`
+	// SubPath is the directory of the volume that is mounted, the root of
+	// the volume if empty.
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+	// PersistentVolumeClaim is an existing claim. Use it to share the
+	// workspace between the TaskRuns of a PipelineRun.
+	// +optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+	// EmptyDir is a directory that is deleted with the Pod of the TaskRun.
+	// +optional
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+	// ConfigMap provides the keys of a ConfigMap as files.
+	// +optional
+	ConfigMap *corev1.ConfigMapVolumeSource `json:"configMap,omitempty"`
+	// Secret provides the keys of a Secret as files.
+	// +optional
+	Secret *corev1.SecretVolumeSource `json:"secret,omitempty"`
+}
+
+// VolumeSource returns the source of the volume of the workspace.
+func (b WorkspaceBinding) VolumeSource() corev1.VolumeSource {
+	return corev1.VolumeSource{
+		PersistentVolumeClaim: b.PersistentVolumeClaim,
+		EmptyDir:              b.EmptyDir,
+		ConfigMap:             b.ConfigMap,
+		Secret:                b.Secret,
+	}
+}
+
+// PipelineWorkspaceDeclaration declares a workspace that the PipelineRun
+// binds and that the PipelineTasks pass to their Tasks.
+type PipelineWorkspaceDeclaration struct {
+	// Name is the name the PipelineRun binds the workspace by.
+	Name string `json:"name"`
+	// Description is what the Pipeline uses the workspace for.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// WorkspacePipelineTaskBinding passes a workspace of the Pipeline to a
+// workspace of the Task of a PipelineTask.
+type WorkspacePipelineTaskBinding struct {
+	// Name is the name of the workspace the Task declares.
+	Name string `json:"name"`
+	// Workspace is the name of the workspace the Pipeline declares.
+	Workspace string `json:"workspace"`
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// validateWorkspaceDeclarations validates the workspaces of a Task: their
+// names are unique DNS labels, as they name the volumes of the Pod, and they
+// are mounted at distinct paths.
+func validateWorkspaceDeclarations(workspaces []WorkspaceDeclaration) *apis.FieldError {
+	names := map[string]struct{}{}
+	mountPaths := map[string]struct{}{}
+	for i, w := range workspaces {
+		if err := validateWorkspaceName(w.Name); err != nil {
+			return err.ViaIndex(i)
+		}
+		if _, ok := names[w.Name]; ok {
+			return apis.ErrMultipleOneOf("name").ViaIndex(i)
+		}
+		names[w.Name] = struct{}{}
+
+		if w.MountPath != "" && !filepath.IsAbs(w.MountPath) {
+			return apis.ErrInvalidValue(w.MountPath, "mountPath").ViaIndex(i)
+		}
+		mountPath := filepath.Clean(w.GetMountPath())
+		if _, ok := mountPaths[mountPath]; ok {
+			return apis.ErrMultipleOneOf("mountPath").ViaIndex(i)
+		}
+		mountPaths[mountPath] = struct{}{}
+	}
+	return nil
+}
+
+// validateWorkspaceBindings validates that each workspace is bound once, to
+// exactly one kind of volume.
+func validateWorkspaceBindings(bindings []WorkspaceBinding) *apis.FieldError {
+	names := map[string]struct{}{}
+	for i, b := range bindings {
+		if b.Name == "" {
+			return apis.ErrMissingField("name").ViaIndex(i)
+		}
+		if _, ok := names[b.Name]; ok {
+			return apis.ErrMultipleOneOf("name").ViaIndex(i)
+		}
+		names[b.Name] = struct{}{}
+
+		var sources []string
+		if b.PersistentVolumeClaim != nil {
+			sources = append(sources, "persistentVolumeClaim")
+		}
+		if b.EmptyDir != nil {
+			sources = append(sources, "emptyDir")
+		}
+		if b.ConfigMap != nil {
+			sources = append(sources, "configMap")
+		}
+		if b.Secret != nil {
+			sources = append(sources, "secret")
+		}
+		switch len(sources) {
+		case 0:
+			return apis.ErrMissingOneOf("persistentVolumeClaim", "emptyDir", "configMap", "secret").ViaIndex(i)
+		case 1:
+		default:
+			return apis.ErrMultipleOneOf(sources...).ViaIndex(i)
+		}
+		if filepath.IsAbs(b.SubPath) {
+			return apis.ErrInvalidValue(b.SubPath, "subPath").ViaIndex(i)
+		}
+	}
+	return nil
+}
+
+func validateWorkspaceName(name string) *apis.FieldError {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("invalid workspace name %q", name),
+			Paths:   []string{"name"},
+			Details: "Workspace names must be valid DNS Labels, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		}
+	}
+	return nil
+}
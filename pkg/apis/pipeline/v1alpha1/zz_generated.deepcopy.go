@@ -27,6 +27,29 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedConfigMap) DeepCopyInto(out *AppliedConfigMap) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedConfigMap.
+func (in *AppliedConfigMap) DeepCopy() *AppliedConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactBucket) DeepCopyInto(out *ArtifactBucket) {
 	*out = *in
@@ -146,6 +169,18 @@ func (in *ClusterResource) DeepCopyInto(out *ClusterResource) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExecArgs != nil {
+		in, out := &in.ExecArgs, &out.ExecArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExecEnv != nil {
+		in, out := &in.ExecEnv, &out.ExecEnv
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Secrets != nil {
 		in, out := &in.Secrets, &out.Secrets
 		*out = make([]SecretParam, len(*in))
@@ -376,6 +411,65 @@ func (in *ConditionSpec) DeepCopy() *ConditionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerImage) DeepCopyInto(out *ContainerImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerImage.
+func (in *ContainerImage) DeepCopy() *ContainerImage {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedTaskModifier) DeepCopyInto(out *ExtendedTaskModifier) {
+	*out = *in
+	in.InternalTaskModifier.DeepCopyInto(&out.InternalTaskModifier)
+	if in.StepEnv != nil {
+		in, out := &in.StepEnv, &out.StepEnv
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspaceDeclaration, len(*in))
+		copy(*out, *in)
+	}
+	if in.StepVolumeMounts != nil {
+		in, out := &in.StepVolumeMounts, &out.StepVolumeMounts
+		*out = make([]StepVolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedTaskModifier.
+func (in *ExtendedTaskModifier) DeepCopy() *ExtendedTaskModifier {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedTaskModifier)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCSResource) DeepCopyInto(out *GCSResource) {
 	*out = *in
@@ -413,6 +507,32 @@ func (in *GitResource) DeepCopy() *GitResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPResource) DeepCopyInto(out *HTTPResource) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPResource.
+func (in *HTTPResource) DeepCopy() *HTTPResource {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageResource) DeepCopyInto(out *ImageResource) {
 	*out = *in
@@ -458,33 +578,29 @@ func (in *Inputs) DeepCopy() *Inputs {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Outputs) DeepCopyInto(out *Outputs) {
+func (in *MemoryVolume) DeepCopyInto(out *MemoryVolume) {
 	*out = *in
-	if in.Results != nil {
-		in, out := &in.Results, &out.Results
-		*out = make([]TestResult, len(*in))
-		copy(*out, *in)
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]v1alpha2.TaskResource, len(*in))
+	out.Size = in.Size.DeepCopy()
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Outputs.
-func (in *Outputs) DeepCopy() *Outputs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryVolume.
+func (in *MemoryVolume) DeepCopy() *MemoryVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(Outputs)
+	out := new(MemoryVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Pipeline) DeepCopyInto(out *Pipeline) {
+func (in *NotificationPolicy) DeepCopyInto(out *NotificationPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -492,18 +608,18 @@ func (in *Pipeline) DeepCopyInto(out *Pipeline) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pipeline.
-func (in *Pipeline) DeepCopy() *Pipeline {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationPolicy.
+func (in *NotificationPolicy) DeepCopy() *NotificationPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(Pipeline)
+	out := new(NotificationPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Pipeline) DeepCopyObject() runtime.Object {
+func (in *NotificationPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -511,88 +627,136 @@ func (in *Pipeline) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineConditionResource) DeepCopyInto(out *PipelineConditionResource) {
+func (in *NotificationPolicyList) DeepCopyInto(out *NotificationPolicyList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NotificationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineConditionResource.
-func (in *PipelineConditionResource) DeepCopy() *PipelineConditionResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationPolicyList.
+func (in *NotificationPolicyList) DeepCopy() *NotificationPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineConditionResource)
+	out := new(NotificationPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NotificationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineDeclaredResource) DeepCopyInto(out *PipelineDeclaredResource) {
+func (in *NotificationPolicySpec) DeepCopyInto(out *NotificationPolicySpec) {
 	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	in.Sink.DeepCopyInto(&out.Sink)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineDeclaredResource.
-func (in *PipelineDeclaredResource) DeepCopy() *PipelineDeclaredResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationPolicySpec.
+func (in *NotificationPolicySpec) DeepCopy() *NotificationPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineDeclaredResource)
+	out := new(NotificationPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineList) DeepCopyInto(out *PipelineList) {
+func (in *NotificationSelector) DeepCopyInto(out *NotificationSelector) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	out.ListMeta = in.ListMeta
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Pipeline, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Pipelines != nil {
+		in, out := &in.Pipelines, &out.Pipelines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]NotificationOutcome, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineList.
-func (in *PipelineList) DeepCopy() *PipelineList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSelector.
+func (in *NotificationSelector) DeepCopy() *NotificationSelector {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineList)
+	out := new(NotificationSelector)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PipelineList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSink) DeepCopyInto(out *NotificationSink) {
+	*out = *in
+	if in.URLSecretRef != nil {
+		in, out := &in.URLSecretRef, &out.URLSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
-	return nil
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSink.
+func (in *NotificationSink) DeepCopy() *NotificationSink {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationSink)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRef) DeepCopyInto(out *PipelineRef) {
+func (in *Outputs) DeepCopyInto(out *Outputs) {
 	*out = *in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]TestResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]v1alpha2.TaskResource, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRef.
-func (in *PipelineRef) DeepCopy() *PipelineRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Outputs.
+func (in *Outputs) DeepCopy() *Outputs {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRef)
+	out := new(Outputs)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResource) DeepCopyInto(out *PipelineResource) {
+func (in *Pipeline) DeepCopyInto(out *Pipeline) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -600,18 +764,18 @@ func (in *PipelineResource) DeepCopyInto(out *PipelineResource) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResource.
-func (in *PipelineResource) DeepCopy() *PipelineResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pipeline.
+func (in *Pipeline) DeepCopy() *Pipeline {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResource)
+	out := new(Pipeline)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PipelineResource) DeepCopyObject() runtime.Object {
+func (in *Pipeline) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -619,180 +783,223 @@ func (in *PipelineResource) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResourceBinding) DeepCopyInto(out *PipelineResourceBinding) {
+func (in *PipelineChangeDetection) DeepCopyInto(out *PipelineChangeDetection) {
 	*out = *in
-	if in.ResourceRef != nil {
-		in, out := &in.ResourceRef, &out.ResourceRef
-		*out = new(PipelineResourceRef)
-		**out = **in
-	}
-	if in.ResourceSpec != nil {
-		in, out := &in.ResourceSpec, &out.ResourceSpec
-		*out = new(PipelineResourceSpec)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceBinding.
-func (in *PipelineResourceBinding) DeepCopy() *PipelineResourceBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineChangeDetection.
+func (in *PipelineChangeDetection) DeepCopy() *PipelineChangeDetection {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResourceBinding)
+	out := new(PipelineChangeDetection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResourceList) DeepCopyInto(out *PipelineResourceList) {
+func (in *PipelineConditionResource) DeepCopyInto(out *PipelineConditionResource) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	out.ListMeta = in.ListMeta
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PipelineResource, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceList.
-func (in *PipelineResourceList) DeepCopy() *PipelineResourceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineConditionResource.
+func (in *PipelineConditionResource) DeepCopy() *PipelineConditionResource {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResourceList)
+	out := new(PipelineConditionResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PipelineResourceList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResourceRef) DeepCopyInto(out *PipelineResourceRef) {
+func (in *PipelineConfigArtifactBucket) DeepCopyInto(out *PipelineConfigArtifactBucket) {
 	*out = *in
+	if in.ServiceAccountSecret != nil {
+		in, out := &in.ServiceAccountSecret, &out.ServiceAccountSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceRef.
-func (in *PipelineResourceRef) DeepCopy() *PipelineResourceRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineConfigArtifactBucket.
+func (in *PipelineConfigArtifactBucket) DeepCopy() *PipelineConfigArtifactBucket {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResourceRef)
+	out := new(PipelineConfigArtifactBucket)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResourceResult) DeepCopyInto(out *PipelineResourceResult) {
+func (in *PipelineConfigArtifactPVC) DeepCopyInto(out *PipelineConfigArtifactPVC) {
 	*out = *in
-	out.ResourceRef = in.ResourceRef
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceResult.
-func (in *PipelineResourceResult) DeepCopy() *PipelineResourceResult {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineConfigArtifactPVC.
+func (in *PipelineConfigArtifactPVC) DeepCopy() *PipelineConfigArtifactPVC {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResourceResult)
+	out := new(PipelineConfigArtifactPVC)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineResourceSpec) DeepCopyInto(out *PipelineResourceSpec) {
+func (in *PipelineConfigArtifactStorage) DeepCopyInto(out *PipelineConfigArtifactStorage) {
 	*out = *in
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]v1alpha2.ResourceParam, len(*in))
-		copy(*out, *in)
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(PipelineConfigArtifactBucket)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.SecretParams != nil {
-		in, out := &in.SecretParams, &out.SecretParams
-		*out = make([]SecretParam, len(*in))
-		copy(*out, *in)
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(PipelineConfigArtifactPVC)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceSpec.
-func (in *PipelineResourceSpec) DeepCopy() *PipelineResourceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineConfigArtifactStorage.
+func (in *PipelineConfigArtifactStorage) DeepCopy() *PipelineConfigArtifactStorage {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineResourceSpec)
+	out := new(PipelineConfigArtifactStorage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRun) DeepCopyInto(out *PipelineRun) {
+func (in *PipelineConfigDefaults) DeepCopyInto(out *PipelineConfigDefaults) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.TimeoutMinutes != nil {
+		in, out := &in.TimeoutMinutes, &out.TimeoutMinutes
+		*out = new(int)
+		**out = **in
+	}
+	if in.AllowNoTimeout != nil {
+		in, out := &in.AllowNoTimeout, &out.AllowNoTimeout
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaximumMemoryVolumesSize != nil {
+		in, out := &in.MaximumMemoryVolumesSize, &out.MaximumMemoryVolumesSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StepsStartTimeout != nil {
+		in, out := &in.StepsStartTimeout, &out.StepsStartTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRun.
-func (in *PipelineRun) DeepCopy() *PipelineRun {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineConfigDefaults.
+func (in *PipelineConfigDefaults) DeepCopy() *PipelineConfigDefaults {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRun)
+	out := new(PipelineConfigDefaults)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PipelineRun) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineConfigFeatureFlags) DeepCopyInto(out *PipelineConfigFeatureFlags) {
+	*out = *in
+	if in.AllowedOutputResources != nil {
+		in, out := &in.AllowedOutputResources, &out.AllowedOutputResources
+		*out = make([]v1alpha2.PipelineResourceType, len(*in))
+		copy(*out, *in)
 	}
-	return nil
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineConfigFeatureFlags.
+func (in *PipelineConfigFeatureFlags) DeepCopy() *PipelineConfigFeatureFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineConfigFeatureFlags)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunConditionCheckStatus) DeepCopyInto(out *PipelineRunConditionCheckStatus) {
+func (in *PipelineConfigMetrics) DeepCopyInto(out *PipelineConfigMetrics) {
 	*out = *in
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = new(ConditionCheckStatus)
-		(*in).DeepCopyInto(*out)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineConfigMetrics.
+func (in *PipelineConfigMetrics) DeepCopy() *PipelineConfigMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineConfigMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineConfigPruning) DeepCopyInto(out *PipelineConfigPruning) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunConditionCheckStatus.
-func (in *PipelineRunConditionCheckStatus) DeepCopy() *PipelineRunConditionCheckStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineConfigPruning.
+func (in *PipelineConfigPruning) DeepCopy() *PipelineConfigPruning {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRunConditionCheckStatus)
+	out := new(PipelineConfigPruning)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunList) DeepCopyInto(out *PipelineRunList) {
+func (in *PipelineDeclaredResource) DeepCopyInto(out *PipelineDeclaredResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineDeclaredResource.
+func (in *PipelineDeclaredResource) DeepCopy() *PipelineDeclaredResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineDeclaredResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineList) DeepCopyInto(out *PipelineList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PipelineRun, len(*in))
+		*out = make([]Pipeline, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -800,18 +1007,18 @@ func (in *PipelineRunList) DeepCopyInto(out *PipelineRunList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunList.
-func (in *PipelineRunList) DeepCopy() *PipelineRunList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineList.
+func (in *PipelineList) DeepCopy() *PipelineList {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRunList)
+	out := new(PipelineList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PipelineRunList) DeepCopyObject() runtime.Object {
+func (in *PipelineList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -819,224 +1026,1325 @@ func (in *PipelineRunList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
+func (in *PipelineParamsHook) DeepCopyInto(out *PipelineParamsHook) {
 	*out = *in
-	if in.PipelineRef != nil {
-		in, out := &in.PipelineRef, &out.PipelineRef
-		*out = new(PipelineRef)
-		**out = **in
-	}
-	if in.PipelineSpec != nil {
-		in, out := &in.PipelineSpec, &out.PipelineSpec
-		*out = new(PipelineSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]PipelineResourceBinding, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]v1alpha2.Param, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ServiceAccountNames != nil {
-		in, out := &in.ServiceAccountNames, &out.ServiceAccountNames
-		*out = make([]PipelineRunSpecServiceAccountName, len(*in))
-		copy(*out, *in)
-	}
-	if in.Timeout != nil {
-		in, out := &in.Timeout, &out.Timeout
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunSpec.
-func (in *PipelineRunSpec) DeepCopy() *PipelineRunSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineParamsHook.
+func (in *PipelineParamsHook) DeepCopy() *PipelineParamsHook {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRunSpec)
+	out := new(PipelineParamsHook)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunSpecServiceAccountName) DeepCopyInto(out *PipelineRunSpecServiceAccountName) {
+func (in *PipelineRef) DeepCopyInto(out *PipelineRef) {
 	*out = *in
+	in.ResolverRef.DeepCopyInto(&out.ResolverRef)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunSpecServiceAccountName.
-func (in *PipelineRunSpecServiceAccountName) DeepCopy() *PipelineRunSpecServiceAccountName {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRef.
+func (in *PipelineRef) DeepCopy() *PipelineRef {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRunSpecServiceAccountName)
+	out := new(PipelineRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunStatus) DeepCopyInto(out *PipelineRunStatus) {
+func (in *PipelineResource) DeepCopyInto(out *PipelineResource) {
 	*out = *in
-	in.Status.DeepCopyInto(&out.Status)
-	in.PipelineRunStatusFields.DeepCopyInto(&out.PipelineRunStatusFields)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunStatus.
-func (in *PipelineRunStatus) DeepCopy() *PipelineRunStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResource.
+func (in *PipelineResource) DeepCopy() *PipelineResource {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRunStatus)
+	out := new(PipelineResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PipelineResource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
+func (in *PipelineResourceBinding) DeepCopyInto(out *PipelineResourceBinding) {
 	*out = *in
-	if in.StartTime != nil {
-		in, out := &in.StartTime, &out.StartTime
-		*out = (*in).DeepCopy()
-	}
-	if in.CompletionTime != nil {
-		in, out := &in.CompletionTime, &out.CompletionTime
-		*out = (*in).DeepCopy()
+	if in.ResourceRef != nil {
+		in, out := &in.ResourceRef, &out.ResourceRef
+		*out = new(PipelineResourceRef)
+		**out = **in
 	}
-	if in.TaskRuns != nil {
-		in, out := &in.TaskRuns, &out.TaskRuns
-		*out = make(map[string]*PipelineRunTaskRunStatus, len(*in))
-		for key, val := range *in {
-			var outVal *PipelineRunTaskRunStatus
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = new(PipelineRunTaskRunStatus)
-				(*in).DeepCopyInto(*out)
-			}
-			(*out)[key] = outVal
-		}
+	if in.ResourceSpec != nil {
+		in, out := &in.ResourceSpec, &out.ResourceSpec
+		*out = new(PipelineResourceSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunStatusFields.
-func (in *PipelineRunStatusFields) DeepCopy() *PipelineRunStatusFields {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceBinding.
+func (in *PipelineResourceBinding) DeepCopy() *PipelineResourceBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRunStatusFields)
+	out := new(PipelineResourceBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunTaskRunStatus) DeepCopyInto(out *PipelineRunTaskRunStatus) {
+func (in *PipelineResourceList) DeepCopyInto(out *PipelineResourceList) {
 	*out = *in
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = new(TaskRunStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ConditionChecks != nil {
-		in, out := &in.ConditionChecks, &out.ConditionChecks
-		*out = make(map[string]*PipelineRunConditionCheckStatus, len(*in))
-		for key, val := range *in {
-			var outVal *PipelineRunConditionCheckStatus
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = new(PipelineRunConditionCheckStatus)
-				(*in).DeepCopyInto(*out)
-			}
-			(*out)[key] = outVal
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PipelineResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunTaskRunStatus.
-func (in *PipelineRunTaskRunStatus) DeepCopy() *PipelineRunTaskRunStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceList.
+func (in *PipelineResourceList) DeepCopy() *PipelineResourceList {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineRunTaskRunStatus)
+	out := new(PipelineResourceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PipelineResourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
+func (in *PipelineResourceRef) DeepCopyInto(out *PipelineResourceRef) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]PipelineDeclaredResource, len(*in))
-		copy(*out, *in)
-	}
-	if in.Tasks != nil {
-		in, out := &in.Tasks, &out.Tasks
-		*out = make([]PipelineTask, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]v1alpha2.ParamSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineSpec.
-func (in *PipelineSpec) DeepCopy() *PipelineSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceRef.
+func (in *PipelineResourceRef) DeepCopy() *PipelineResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineResourceResult) DeepCopyInto(out *PipelineResourceResult) {
+	*out = *in
+	out.ResourceRef = in.ResourceRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceResult.
+func (in *PipelineResourceResult) DeepCopy() *PipelineResourceResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineResourceResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineResourceSpec) DeepCopyInto(out *PipelineResourceSpec) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]v1alpha2.ResourceParam, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretParams != nil {
+		in, out := &in.SecretParams, &out.SecretParams
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResourceSpec.
+func (in *PipelineResourceSpec) DeepCopy() *PipelineResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRun) DeepCopyInto(out *PipelineRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRun.
+func (in *PipelineRun) DeepCopy() *PipelineRun {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PipelineRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunConditionCheckStatus) DeepCopyInto(out *PipelineRunConditionCheckStatus) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ConditionCheckStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunConditionCheckStatus.
+func (in *PipelineRunConditionCheckStatus) DeepCopy() *PipelineRunConditionCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunConditionCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunGraph) DeepCopyInto(out *PipelineRunGraph) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]PipelineRunGraphNode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Edges != nil {
+		in, out := &in.Edges, &out.Edges
+		*out = make([]PipelineRunGraphEdge, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunGraph.
+func (in *PipelineRunGraph) DeepCopy() *PipelineRunGraph {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunGraph)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunGraphEdge) DeepCopyInto(out *PipelineRunGraphEdge) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunGraphEdge.
+func (in *PipelineRunGraphEdge) DeepCopy() *PipelineRunGraphEdge {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunGraphEdge)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunGraphNode) DeepCopyInto(out *PipelineRunGraphNode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunGraphNode.
+func (in *PipelineRunGraphNode) DeepCopy() *PipelineRunGraphNode {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunGraphNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunList) DeepCopyInto(out *PipelineRunList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PipelineRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunList.
+func (in *PipelineRunList) DeepCopy() *PipelineRunList {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PipelineRunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunParamsHookStatus) DeepCopyInto(out *PipelineRunParamsHookStatus) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunParamsHookStatus.
+func (in *PipelineRunParamsHookStatus) DeepCopy() *PipelineRunParamsHookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunParamsHookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunRunStatus) DeepCopyInto(out *PipelineRunRunStatus) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(RunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunRunStatus.
+func (in *PipelineRunRunStatus) DeepCopy() *PipelineRunRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
+	*out = *in
+	if in.PipelineRef != nil {
+		in, out := &in.PipelineRef, &out.PipelineRef
+		*out = new(PipelineRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PipelineSpec != nil {
+		in, out := &in.PipelineSpec, &out.PipelineSpec
+		*out = new(PipelineSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PipelineResourceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]v1alpha2.Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspaceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceAccountNames != nil {
+		in, out := &in.ServiceAccountNames, &out.ServiceAccountNames
+		*out = make([]PipelineRunSpecServiceAccountName, len(*in))
+		copy(*out, *in)
+	}
+	in.TaskRunTemplate.DeepCopyInto(&out.TaskRunTemplate)
+	if in.TaskRunSpecs != nil {
+		in, out := &in.TaskRunSpecs, &out.TaskRunSpecs
+		*out = make([]PipelineTaskRunSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(PipelineRunTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunSpec.
+func (in *PipelineRunSpec) DeepCopy() *PipelineRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunSpecServiceAccountName) DeepCopyInto(out *PipelineRunSpecServiceAccountName) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunSpecServiceAccountName.
+func (in *PipelineRunSpecServiceAccountName) DeepCopy() *PipelineRunSpecServiceAccountName {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunSpecServiceAccountName)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunStatus) DeepCopyInto(out *PipelineRunStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	in.PipelineRunStatusFields.DeepCopyInto(&out.PipelineRunStatusFields)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunStatus.
+func (in *PipelineRunStatus) DeepCopy() *PipelineRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FinallyStartTime != nil {
+		in, out := &in.FinallyStartTime, &out.FinallyStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EffectiveTimeout != nil {
+		in, out := &in.EffectiveTimeout, &out.EffectiveTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TaskRuns != nil {
+		in, out := &in.TaskRuns, &out.TaskRuns
+		*out = make(map[string]*PipelineRunTaskRunStatus, len(*in))
+		for key, val := range *in {
+			var outVal *PipelineRunTaskRunStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PipelineRunTaskRunStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Runs != nil {
+		in, out := &in.Runs, &out.Runs
+		*out = make(map[string]*PipelineRunRunStatus, len(*in))
+		for key, val := range *in {
+			var outVal *PipelineRunRunStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PipelineRunRunStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Graph != nil {
+		in, out := &in.Graph, &out.Graph
+		*out = new(PipelineRunGraph)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestedResources != nil {
+		in, out := &in.RequestedResources, &out.RequestedResources
+		*out = new(ResourceSeconds)
+		**out = **in
+	}
+	if in.ParamsHook != nil {
+		in, out := &in.ParamsHook, &out.ParamsHook
+		*out = new(PipelineRunParamsHookStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunStatusFields.
+func (in *PipelineRunStatusFields) DeepCopy() *PipelineRunStatusFields {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunStatusFields)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunTaskRunStatus) DeepCopyInto(out *PipelineRunTaskRunStatus) {
+	*out = *in
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = make([]v1alpha2.Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(TaskRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConditionChecks != nil {
+		in, out := &in.ConditionChecks, &out.ConditionChecks
+		*out = make(map[string]*PipelineRunConditionCheckStatus, len(*in))
+		for key, val := range *in {
+			var outVal *PipelineRunConditionCheckStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PipelineRunConditionCheckStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunTaskRunStatus.
+func (in *PipelineRunTaskRunStatus) DeepCopy() *PipelineRunTaskRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunTaskRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunTimeouts) DeepCopyInto(out *PipelineRunTimeouts) {
+	*out = *in
+	if in.Pipeline != nil {
+		in, out := &in.Pipeline, &out.Pipeline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Finally != nil {
+		in, out := &in.Finally, &out.Finally
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunTimeouts.
+func (in *PipelineRunTimeouts) DeepCopy() *PipelineRunTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PipelineDeclaredResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]PipelineTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Finally != nil {
+		in, out := &in.Finally, &out.Finally
+		*out = make([]PipelineTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]v1alpha2.ParamSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]PipelineWorkspaceDeclaration, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangeDetection != nil {
+		in, out := &in.ChangeDetection, &out.ChangeDetection
+		*out = new(PipelineChangeDetection)
+		**out = **in
+	}
+	if in.ExpectedDuration != nil {
+		in, out := &in.ExpectedDuration, &out.ExpectedDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ParamsHook != nil {
+		in, out := &in.ParamsHook, &out.ParamsHook
+		*out = new(PipelineParamsHook)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineSpec.
+func (in *PipelineSpec) DeepCopy() *PipelineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
+	*out = *in
+	in.TaskRef.DeepCopyInto(&out.TaskRef)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]PipelineTaskCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WhenExpressions != nil {
+		in, out := &in.WhenExpressions, &out.WhenExpressions
+		*out = make(WhenExpressions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspacePipelineTaskBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RunAfter != nil {
+		in, out := &in.RunAfter, &out.RunAfter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(PipelineTaskResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]v1alpha2.Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = make([]v1alpha2.Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTask.
+func (in *PipelineTask) DeepCopy() *PipelineTask {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskCondition) DeepCopyInto(out *PipelineTaskCondition) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]v1alpha2.Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PipelineConditionResource, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskCondition.
+func (in *PipelineTaskCondition) DeepCopy() *PipelineTaskCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskInputResource) DeepCopyInto(out *PipelineTaskInputResource) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskInputResource.
+func (in *PipelineTaskInputResource) DeepCopy() *PipelineTaskInputResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskInputResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PipelineTaskList) DeepCopyInto(out *PipelineTaskList) {
+	{
+		in := &in
+		*out = make(PipelineTaskList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskList.
+func (in PipelineTaskList) DeepCopy() PipelineTaskList {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskList)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskMetadata) DeepCopyInto(out *PipelineTaskMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskMetadata.
+func (in *PipelineTaskMetadata) DeepCopy() *PipelineTaskMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskOutputResource) DeepCopyInto(out *PipelineTaskOutputResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskOutputResource.
+func (in *PipelineTaskOutputResource) DeepCopy() *PipelineTaskOutputResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskOutputResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskParam) DeepCopyInto(out *PipelineTaskParam) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskParam.
+func (in *PipelineTaskParam) DeepCopy() *PipelineTaskParam {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskResources) DeepCopyInto(out *PipelineTaskResources) {
+	*out = *in
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = make([]PipelineTaskInputResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]PipelineTaskOutputResource, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskResources.
+func (in *PipelineTaskResources) DeepCopy() *PipelineTaskResources {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskRun) DeepCopyInto(out *PipelineTaskRun) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRun.
+func (in *PipelineTaskRun) DeepCopy() *PipelineTaskRun {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskRunSpec) DeepCopyInto(out *PipelineTaskRunSpec) {
+	*out = *in
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(PodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	if in.StepOverrides != nil {
+		in, out := &in.StepOverrides, &out.StepOverrides
+		*out = make([]TaskRunStepOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarOverrides != nil {
+		in, out := &in.SidecarOverrides, &out.SidecarOverrides
+		*out = make([]TaskRunSidecarOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRunSpec.
+func (in *PipelineTaskRunSpec) DeepCopy() *PipelineTaskRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskRunTemplate) DeepCopyInto(out *PipelineTaskRunTemplate) {
+	*out = *in
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(PodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRunTemplate.
+func (in *PipelineTaskRunTemplate) DeepCopy() *PipelineTaskRunTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskRunTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineWorkspaceDeclaration) DeepCopyInto(out *PipelineWorkspaceDeclaration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineWorkspaceDeclaration.
+func (in *PipelineWorkspaceDeclaration) DeepCopy() *PipelineWorkspaceDeclaration {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineWorkspaceDeclaration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginResource) DeepCopyInto(out *PluginResource) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginResource.
+func (in *PluginResource) DeepCopy() *PluginResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginResourceType) DeepCopyInto(out *PluginResourceType) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginResourceType.
+func (in *PluginResourceType) DeepCopy() *PluginResourceType {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginResourceType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EnableServiceLinks != nil {
+		in, out := &in.EnableServiceLinks, &out.EnableServiceLinks
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
+func (in *PodTemplate) DeepCopy() *PodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestResource) DeepCopyInto(out *PullRequestResource) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestResource.
+func (in *PullRequestResource) DeepCopy() *PullRequestResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolutionRequest) DeepCopyInto(out *ResolutionRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolutionRequest.
+func (in *ResolutionRequest) DeepCopy() *ResolutionRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolutionRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResolutionRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolutionRequestList) DeepCopyInto(out *ResolutionRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResolutionRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolutionRequestList.
+func (in *ResolutionRequestList) DeepCopy() *ResolutionRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolutionRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResolutionRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolutionRequestSpec) DeepCopyInto(out *ResolutionRequestSpec) {
+	*out = *in
+	in.ResolverRef.DeepCopyInto(&out.ResolverRef)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolutionRequestSpec.
+func (in *ResolutionRequestSpec) DeepCopy() *ResolutionRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolutionRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolutionRequestStatus) DeepCopyInto(out *ResolutionRequestStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolutionRequestStatus.
+func (in *ResolutionRequestStatus) DeepCopy() *ResolutionRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolutionRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolverRef) DeepCopyInto(out *ResolverRef) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]v1alpha2.Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolverRef.
+func (in *ResolverRef) DeepCopy() *ResolverRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolverRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSeconds) DeepCopyInto(out *ResourceSeconds) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSeconds.
+func (in *ResourceSeconds) DeepCopy() *ResourceSeconds {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSeconds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultRef) DeepCopyInto(out *ResultRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultRef.
+func (in *ResultRef) DeepCopy() *ResultRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Run) DeepCopyInto(out *Run) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Run.
+func (in *Run) DeepCopy() *Run {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineSpec)
+	out := new(Run)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Run) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
+func (in *RunList) DeepCopyInto(out *RunList) {
 	*out = *in
-	out.TaskRef = in.TaskRef
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]PipelineTaskCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.RunAfter != nil {
-		in, out := &in.RunAfter, &out.RunAfter
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(PipelineTaskResources)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]v1alpha2.Param, len(*in))
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Run, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1044,19 +2352,32 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTask.
-func (in *PipelineTask) DeepCopy() *PipelineTask {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunList.
+func (in *RunList) DeepCopy() *RunList {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTask)
+	out := new(RunList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskCondition) DeepCopyInto(out *PipelineTaskCondition) {
+func (in *RunSpec) DeepCopyInto(out *RunSpec) {
 	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(TaskRef)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]v1alpha2.Param, len(*in))
@@ -1064,261 +2385,270 @@ func (in *PipelineTaskCondition) DeepCopyInto(out *PipelineTaskCondition) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]PipelineConditionResource, len(*in))
-		copy(*out, *in)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskCondition.
-func (in *PipelineTaskCondition) DeepCopy() *PipelineTaskCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunSpec.
+func (in *RunSpec) DeepCopy() *RunSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskCondition)
+	out := new(RunSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskInputResource) DeepCopyInto(out *PipelineTaskInputResource) {
+func (in *RunStatus) DeepCopyInto(out *RunStatus) {
 	*out = *in
-	if in.From != nil {
-		in, out := &in.From, &out.From
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.Status.DeepCopyInto(&out.Status)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskInputResource.
-func (in *PipelineTaskInputResource) DeepCopy() *PipelineTaskInputResource {
-	if in == nil {
-		return nil
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
-	out := new(PipelineTaskInputResource)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in PipelineTaskList) DeepCopyInto(out *PipelineTaskList) {
-	{
-		in := &in
-		*out = make(PipelineTaskList, len(*in))
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]TaskRunResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetriesStatus != nil {
+		in, out := &in.RetriesStatus, &out.RetriesStatus
+		*out = make([]RunStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
-		return
 	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskList.
-func (in PipelineTaskList) DeepCopy() PipelineTaskList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunStatus.
+func (in *RunStatus) DeepCopy() *RunStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskList)
+	out := new(RunStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskOutputResource) DeepCopyInto(out *PipelineTaskOutputResource) {
+func (in *SecretParam) DeepCopyInto(out *SecretParam) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskOutputResource.
-func (in *PipelineTaskOutputResource) DeepCopy() *PipelineTaskOutputResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretParam.
+func (in *SecretParam) DeepCopy() *SecretParam {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskOutputResource)
+	out := new(SecretParam)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskParam) DeepCopyInto(out *PipelineTaskParam) {
+func (in *SidecarState) DeepCopyInto(out *SidecarState) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskParam.
-func (in *PipelineTaskParam) DeepCopy() *PipelineTaskParam {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarState.
+func (in *SidecarState) DeepCopy() *SidecarState {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskParam)
+	out := new(SidecarState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskResources) DeepCopyInto(out *PipelineTaskResources) {
+func (in *StepCommand) DeepCopyInto(out *StepCommand) {
 	*out = *in
-	if in.Inputs != nil {
-		in, out := &in.Inputs, &out.Inputs
-		*out = make([]PipelineTaskInputResource, len(*in))
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Outputs != nil {
-		in, out := &in.Outputs, &out.Outputs
-		*out = make([]PipelineTaskOutputResource, len(*in))
-		copy(*out, *in)
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskResources.
-func (in *PipelineTaskResources) DeepCopy() *PipelineTaskResources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepCommand.
+func (in *StepCommand) DeepCopy() *StepCommand {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskResources)
+	out := new(StepCommand)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskRun) DeepCopyInto(out *PipelineTaskRun) {
+func (in *StepState) DeepCopyInto(out *StepState) {
 	*out = *in
+	in.ContainerState.DeepCopyInto(&out.ContainerState)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRun.
-func (in *PipelineTaskRun) DeepCopy() *PipelineTaskRun {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepState.
+func (in *StepState) DeepCopy() *StepState {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskRun)
+	out := new(StepState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+func (in *StepVolumeMount) DeepCopyInto(out *StepVolumeMount) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.PodSecurityContext)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.RuntimeClassName != nil {
-		in, out := &in.RuntimeClassName, &out.RuntimeClassName
-		*out = new(string)
-		**out = **in
+	in.VolumeMount.DeepCopyInto(&out.VolumeMount)
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
-func (in *PodTemplate) DeepCopy() *PodTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepVolumeMount.
+func (in *StepVolumeMount) DeepCopy() *StepVolumeMount {
 	if in == nil {
 		return nil
 	}
-	out := new(PodTemplate)
+	out := new(StepVolumeMount)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PullRequestResource) DeepCopyInto(out *PullRequestResource) {
+func (in *StorageMigration) DeepCopyInto(out *StorageMigration) {
 	*out = *in
-	if in.Secrets != nil {
-		in, out := &in.Secrets, &out.Secrets
-		*out = make([]SecretParam, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestResource.
-func (in *PullRequestResource) DeepCopy() *PullRequestResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigration.
+func (in *StorageMigration) DeepCopy() *StorageMigration {
 	if in == nil {
 		return nil
 	}
-	out := new(PullRequestResource)
+	out := new(StorageMigration)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretParam) DeepCopyInto(out *SecretParam) {
+func (in *StorageMigrationList) DeepCopyInto(out *StorageMigrationList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StorageMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretParam.
-func (in *SecretParam) DeepCopy() *SecretParam {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationList.
+func (in *StorageMigrationList) DeepCopy() *StorageMigrationList {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretParam)
+	out := new(StorageMigrationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SidecarState) DeepCopyInto(out *SidecarState) {
+func (in *StorageMigrationSpec) DeepCopyInto(out *StorageMigrationSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarState.
-func (in *SidecarState) DeepCopy() *SidecarState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationSpec.
+func (in *StorageMigrationSpec) DeepCopy() *StorageMigrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SidecarState)
+	out := new(StorageMigrationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StepState) DeepCopyInto(out *StepState) {
+func (in *StorageMigrationStatus) DeepCopyInto(out *StorageMigrationStatus) {
 	*out = *in
-	in.ContainerState.DeepCopyInto(&out.ContainerState)
+	in.Status.DeepCopyInto(&out.Status)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FailedObjects != nil {
+		in, out := &in.FailedObjects, &out.FailedObjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepState.
-func (in *StepState) DeepCopy() *StepState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationStatus.
+func (in *StorageMigrationStatus) DeepCopy() *StorageMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(StepState)
+	out := new(StorageMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1386,6 +2716,7 @@ func (in *TaskList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRef) DeepCopyInto(out *TaskRef) {
 	*out = *in
+	in.ResolverRef.DeepCopyInto(&out.ResolverRef)
 	return
 }
 
@@ -1411,12 +2742,28 @@ func (in *TaskResourceBinding) DeepCopyInto(out *TaskResourceBinding) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskResourceBinding.
-func (in *TaskResourceBinding) DeepCopy() *TaskResourceBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskResourceBinding.
+func (in *TaskResourceBinding) DeepCopy() *TaskResourceBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskResourceBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskResult) DeepCopyInto(out *TaskResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskResult.
+func (in *TaskResult) DeepCopy() *TaskResult {
 	if in == nil {
 		return nil
 	}
-	out := new(TaskResourceBinding)
+	out := new(TaskResult)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1535,6 +2882,39 @@ func (in *TaskRunOutputs) DeepCopy() *TaskRunOutputs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunResult) DeepCopyInto(out *TaskRunResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunResult.
+func (in *TaskRunResult) DeepCopy() *TaskRunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunSidecarOverride) DeepCopyInto(out *TaskRunSidecarOverride) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunSidecarOverride.
+func (in *TaskRunSidecarOverride) DeepCopy() *TaskRunSidecarOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunSidecarOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 	*out = *in
@@ -1543,7 +2923,7 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 	if in.TaskRef != nil {
 		in, out := &in.TaskRef, &out.TaskRef
 		*out = new(TaskRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.TaskSpec != nil {
 		in, out := &in.TaskSpec, &out.TaskSpec
@@ -1556,6 +2936,27 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 		**out = **in
 	}
 	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspaceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StepOverrides != nil {
+		in, out := &in.StepOverrides, &out.StepOverrides
+		*out = make([]TaskRunStepOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarOverrides != nil {
+		in, out := &in.SidecarOverrides, &out.SidecarOverrides
+		*out = make([]TaskRunSidecarOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1598,6 +2999,11 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.EffectiveTimeout != nil {
+		in, out := &in.EffectiveTimeout, &out.EffectiveTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Steps != nil {
 		in, out := &in.Steps, &out.Steps
 		*out = make([]StepState, len(*in))
@@ -1624,11 +3030,38 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 		*out = make([]PipelineResourceResult, len(*in))
 		copy(*out, *in)
 	}
+	if in.TaskRunResults != nil {
+		in, out := &in.TaskRunResults, &out.TaskRunResults
+		*out = make([]TaskRunResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingResults != nil {
+		in, out := &in.MissingResults, &out.MissingResults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Sidecars != nil {
 		in, out := &in.Sidecars, &out.Sidecars
 		*out = make([]SidecarState, len(*in))
 		copy(*out, *in)
 	}
+	if in.ContainerImages != nil {
+		in, out := &in.ContainerImages, &out.ContainerImages
+		*out = make([]ContainerImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.StepCommands != nil {
+		in, out := &in.StepCommands, &out.StepCommands
+		*out = make([]StepCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RequestedResources != nil {
+		in, out := &in.RequestedResources, &out.RequestedResources
+		*out = new(ResourceSeconds)
+		**out = **in
+	}
 	return
 }
 
@@ -1642,6 +3075,23 @@ func (in *TaskRunStatusFields) DeepCopy() *TaskRunStatusFields {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunStepOverride) DeepCopyInto(out *TaskRunStepOverride) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunStepOverride.
+func (in *TaskRunStepOverride) DeepCopy() *TaskRunStepOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunStepOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	*out = *in
@@ -1681,6 +3131,33 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]TaskCapability, len(*in))
+		copy(*out, *in)
+	}
+	if in.MemoryVolumes != nil {
+		in, out := &in.MemoryVolumes, &out.MemoryVolumes
+		*out = make([]MemoryVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]TaskResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspaceDeclaration, len(*in))
+		copy(*out, *in)
+	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1694,6 +3171,136 @@ func (in *TaskSpec) DeepCopy() *TaskSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TektonPipelineConfig) DeepCopyInto(out *TektonPipelineConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TektonPipelineConfig.
+func (in *TektonPipelineConfig) DeepCopy() *TektonPipelineConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TektonPipelineConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TektonPipelineConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TektonPipelineConfigList) DeepCopyInto(out *TektonPipelineConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TektonPipelineConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TektonPipelineConfigList.
+func (in *TektonPipelineConfigList) DeepCopy() *TektonPipelineConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(TektonPipelineConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TektonPipelineConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TektonPipelineConfigSpec) DeepCopyInto(out *TektonPipelineConfigSpec) {
+	*out = *in
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(PipelineConfigDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FeatureFlags != nil {
+		in, out := &in.FeatureFlags, &out.FeatureFlags
+		*out = new(PipelineConfigFeatureFlags)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ArtifactStorage != nil {
+		in, out := &in.ArtifactStorage, &out.ArtifactStorage
+		*out = new(PipelineConfigArtifactStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(PipelineConfigMetrics)
+		**out = **in
+	}
+	if in.Pruning != nil {
+		in, out := &in.Pruning, &out.Pruning
+		*out = new(PipelineConfigPruning)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TektonPipelineConfigSpec.
+func (in *TektonPipelineConfigSpec) DeepCopy() *TektonPipelineConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TektonPipelineConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TektonPipelineConfigStatus) DeepCopyInto(out *TektonPipelineConfigStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]AppliedConfigMap, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastPruneTime != nil {
+		in, out := &in.LastPruneTime, &out.LastPruneTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TektonPipelineConfigStatus.
+func (in *TektonPipelineConfigStatus) DeepCopy() *TektonPipelineConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TektonPipelineConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestResult) DeepCopyInto(out *TestResult) {
 	*out = *in
@@ -1709,3 +3316,114 @@ func (in *TestResult) DeepCopy() *TestResult {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenExpression) DeepCopyInto(out *WhenExpression) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenExpression.
+func (in *WhenExpression) DeepCopy() *WhenExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in WhenExpressions) DeepCopyInto(out *WhenExpressions) {
+	{
+		in := &in
+		*out = make(WhenExpressions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenExpressions.
+func (in WhenExpressions) DeepCopy() WhenExpressions {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenExpressions)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceBinding) DeepCopyInto(out *WorkspaceBinding) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(v1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(v1.EmptyDirVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceBinding.
+func (in *WorkspaceBinding) DeepCopy() *WorkspaceBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceDeclaration) DeepCopyInto(out *WorkspaceDeclaration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceDeclaration.
+func (in *WorkspaceDeclaration) DeepCopy() *WorkspaceDeclaration {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceDeclaration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacePipelineTaskBinding) DeepCopyInto(out *WorkspacePipelineTaskBinding) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacePipelineTaskBinding.
+func (in *WorkspacePipelineTaskBinding) DeepCopy() *WorkspacePipelineTaskBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacePipelineTaskBinding)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -84,8 +84,9 @@ func MergeStepsWithStepTemplate(template *v1.Container, steps []Step) ([]Step, e
 			merged.Args = []string{}
 		}
 
-		// Pass through original step Script, for later conversion.
-		steps[i] = Step{Container: *merged, Script: s.Script}
+		// Pass through original step Script, for later conversion, and the
+		// fields that aren't part of the Container.
+		steps[i] = Step{Container: *merged, Script: s.Script, AlwaysRun: s.AlwaysRun, Retries: s.Retries, RetryBackoff: s.RetryBackoff, Timeout: s.Timeout, OnError: s.OnError}
 	}
 	return steps, nil
 }
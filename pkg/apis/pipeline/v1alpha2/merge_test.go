@@ -18,10 +18,12 @@ package v1alpha2
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestMergeStepsWithStepTemplate(t *testing.T) {
@@ -100,6 +102,22 @@ func TestMergeStepsWithStepTemplate(t *testing.T) {
 				Value: "NEW_VALUE",
 			}},
 		}}},
+	}, {
+		name: "step-timeout",
+		template: &corev1.Container{
+			Image: "some-image",
+		},
+		steps: []Step{{
+			Container: corev1.Container{Command: []string{"/somecmd"}},
+			Timeout:   &metav1.Duration{Duration: 10 * time.Minute},
+		}},
+		expected: []Step{{
+			Container: corev1.Container{
+				Command: []string{"/somecmd"},
+				Image:   "some-image",
+			},
+			Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			result, err := MergeStepsWithStepTemplate(tc.template, tc.steps)
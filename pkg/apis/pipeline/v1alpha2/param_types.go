@@ -20,6 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+
+	"knative.dev/pkg/apis"
 )
 
 // ParamSpec defines arbitrary parameters needed beyond typed inputs (such as
@@ -29,9 +33,14 @@ type ParamSpec struct {
 	// Name declares the name by which a parameter is referenced.
 	Name string `json:"name"`
 	// Type is the user-specified type of the parameter. The possible types
-	// are currently "string" and "array", and "string" is the default.
+	// are currently "string", "array" and "object", and "string" is the
+	// default unless Properties are set.
 	// +optional
 	Type ParamType `json:"type,omitempty"`
+	// Properties are the keys of an object parameter, which its values must
+	// all have.
+	// +optional
+	Properties map[string]PropertySpec `json:"properties,omitempty"`
 	// Description is a user-facing description of the parameter that may be
 	// used to populate a UI.
 	// +optional
@@ -41,6 +50,17 @@ type ParamSpec struct {
 	// parameter.
 	// +optional
 	Default *ArrayOrString `json:"default,omitempty"`
+	// Enum is the list of values a string parameter can take. Any value is
+	// allowed when it is empty.
+	// +optional
+	Enum []string `json:"enum,omitempty"`
+}
+
+// PropertySpec declares a key of an object parameter.
+type PropertySpec struct {
+	// Type of the values of the key, only "string" is supported.
+	// +optional
+	Type ParamType `json:"type,omitempty"`
 }
 
 func (pp *ParamSpec) SetDefaults(ctx context.Context) {
@@ -48,11 +68,122 @@ func (pp *ParamSpec) SetDefaults(ctx context.Context) {
 		if pp.Default != nil {
 			// propagate the parsed ArrayOrString's type to the parent ParamSpec's type
 			pp.Type = pp.Default.Type
+		} else if len(pp.Properties) > 0 {
+			pp.Type = ParamTypeObject
 		} else {
 			// ParamTypeString is the default value (when no type can be inferred from the default value)
 			pp.Type = ParamTypeString
 		}
 	}
+	if pp != nil {
+		for k, p := range pp.Properties {
+			if p.Type == "" {
+				p.Type = ParamTypeString
+				pp.Properties[k] = p
+			}
+		}
+	}
+}
+
+// ValidateProperties checks that only object parameters have properties,
+// that all their properties are strings, and that their default has a value
+// for each of them. The paths of the error are relative to the parameter.
+func (pp *ParamSpec) ValidateProperties() *apis.FieldError {
+	if pp.Type != ParamTypeObject {
+		if len(pp.Properties) > 0 {
+			return apis.ErrDisallowedFields("properties")
+		}
+		return nil
+	}
+	if len(pp.Properties) == 0 {
+		return apis.ErrMissingField("properties")
+	}
+	for _, k := range pp.propertyNames() {
+		if t := pp.Properties[k].Type; t != "" && t != ParamTypeString {
+			return apis.ErrInvalidValue(t, "properties."+k+".type")
+		}
+	}
+	if pp.Default != nil && pp.Default.Type == ParamTypeObject && !pp.Matches(*pp.Default) {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("default must have exactly the keys %s", strings.Join(pp.propertyNames(), ", ")),
+			Paths:   []string{"default"},
+		}
+	}
+	return nil
+}
+
+// Matches returns true if value has the type of the parameter and, for an
+// object parameter, exactly its properties as keys.
+func (pp *ParamSpec) Matches(value ArrayOrString) bool {
+	if value.Type != pp.Type {
+		return false
+	}
+	if pp.Type != ParamTypeObject {
+		return true
+	}
+	if len(value.ObjectVal) != len(pp.Properties) {
+		return false
+	}
+	for k := range value.ObjectVal {
+		if _, ok := pp.Properties[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateEnum checks that only string parameters have an enum, that its
+// values are unique, and that the default is one of them. The paths of the
+// error are relative to the parameter.
+func (pp *ParamSpec) ValidateEnum() *apis.FieldError {
+	if len(pp.Enum) == 0 {
+		return nil
+	}
+	if pp.Type != ParamTypeString {
+		return apis.ErrDisallowedFields("enum")
+	}
+	seen := map[string]struct{}{}
+	for i, v := range pp.Enum {
+		if _, ok := seen[v]; ok {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("duplicate enum value %q", v),
+				Paths:   []string{fmt.Sprintf("enum[%d]", i)},
+			}
+		}
+		seen[v] = struct{}{}
+	}
+	if pp.Default != nil && !pp.Allows(*pp.Default) {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("default %q isn't one of the enum values %s", pp.Default.StringVal, strings.Join(pp.Enum, ", ")),
+			Paths:   []string{"default"},
+		}
+	}
+	return nil
+}
+
+// Allows returns true if the parameter has no enum, or if value is one of its
+// values. Values which reference variables are allowed, as they can only be
+// checked once they are substituted, as are values of another type, which
+// Matches rejects.
+func (pp *ParamSpec) Allows(value ArrayOrString) bool {
+	if len(pp.Enum) == 0 || value.Type != ParamTypeString || strings.Contains(value.StringVal, "$(") {
+		return true
+	}
+	for _, v := range pp.Enum {
+		if v == value.StringVal {
+			return true
+		}
+	}
+	return false
+}
+
+func (pp *ParamSpec) propertyNames() []string {
+	var names []string
+	for k := range pp.Properties {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // ResourceParam declares a string value to use for the parameter called Name, and is used in
@@ -69,34 +200,42 @@ type Param struct {
 }
 
 // ParamType indicates the type of an input parameter;
-// Used to distinguish between a single string and an array of strings.
+// Used to distinguish between a single string, an array of strings and an
+// object of strings.
 type ParamType string
 
 // Valid ParamTypes:
 const (
 	ParamTypeString ParamType = "string"
 	ParamTypeArray  ParamType = "array"
+	ParamTypeObject ParamType = "object"
 )
 
 // AllParamTypes can be used for ParamType validation.
-var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray}
+var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject}
 
 // ArrayOrString is modeled after IntOrString in kubernetes/apimachinery:
 
-// ArrayOrString is a type that can hold a single string or string array.
+// ArrayOrString is a type that can hold a single string, a string array or
+// an object of strings.
 // Used in JSON unmarshalling so that a single JSON field can accept
-// either an individual string or an array of strings.
+// either an individual string, an array of strings or an object.
 type ArrayOrString struct {
 	Type      ParamType // Represents the stored type of ArrayOrString.
 	StringVal string
 	ArrayVal  []string
+	ObjectVal map[string]string
 }
 
 // UnmarshalJSON implements the json.Unmarshaller interface.
 func (arrayOrString *ArrayOrString) UnmarshalJSON(value []byte) error {
-	if value[0] == '"' {
+	switch value[0] {
+	case '"':
 		arrayOrString.Type = ParamTypeString
 		return json.Unmarshal(value, &arrayOrString.StringVal)
+	case '{':
+		arrayOrString.Type = ParamTypeObject
+		return json.Unmarshal(value, &arrayOrString.ObjectVal)
 	}
 	arrayOrString.Type = ParamTypeArray
 	return json.Unmarshal(value, &arrayOrString.ArrayVal)
@@ -109,15 +248,39 @@ func (arrayOrString ArrayOrString) MarshalJSON() ([]byte, error) {
 		return json.Marshal(arrayOrString.StringVal)
 	case ParamTypeArray:
 		return json.Marshal(arrayOrString.ArrayVal)
+	case ParamTypeObject:
+		return json.Marshal(arrayOrString.ObjectVal)
 	default:
 		return []byte{}, fmt.Errorf("impossible ArrayOrString.Type: %q", arrayOrString.Type)
 	}
 }
 
+// AddReplacements adds the replacements of the variable name which holds
+// the value: $(name) for a string, $(name) and $(name[*]) for an array, and
+// $(name.key) for each key of an object.
+func (arrayOrString ArrayOrString) AddReplacements(name string, stringReplacements map[string]string, arrayReplacements map[string][]string) {
+	switch arrayOrString.Type {
+	case ParamTypeString:
+		stringReplacements[name] = arrayOrString.StringVal
+	case ParamTypeArray:
+		arrayReplacements[name] = arrayOrString.ArrayVal
+		arrayReplacements[name+"[*]"] = arrayOrString.ArrayVal
+	case ParamTypeObject:
+		for k, v := range arrayOrString.ObjectVal {
+			stringReplacements[name+"."+k] = v
+		}
+	}
+}
+
 func (arrayOrString *ArrayOrString) ApplyReplacements(stringReplacements map[string]string, arrayReplacements map[string][]string) {
-	if arrayOrString.Type == ParamTypeString {
+	switch arrayOrString.Type {
+	case ParamTypeString:
 		arrayOrString.StringVal = ApplyReplacements(arrayOrString.StringVal, stringReplacements)
-	} else {
+	case ParamTypeObject:
+		for k, v := range arrayOrString.ObjectVal {
+			arrayOrString.ObjectVal[k] = ApplyReplacements(v, stringReplacements)
+		}
+	default:
 		var newArrayVal []string
 		for _, v := range arrayOrString.ArrayVal {
 			newArrayVal = append(newArrayVal, ApplyArrayReplacements(v, stringReplacements, arrayReplacements)...)
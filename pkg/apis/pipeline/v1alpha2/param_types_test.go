@@ -66,6 +66,17 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 			Description: "a description",
 			Default:     builder.ArrayOrString("an", "array"),
 		},
+	}, {
+		name: "inferred object type from properties",
+		before: &v1alpha2.ParamSpec{
+			Name:       "parametername",
+			Properties: map[string]v1alpha2.PropertySpec{"url": {}, "revision": {Type: v1alpha2.ParamTypeString}},
+		},
+		defaultsApplied: &v1alpha2.ParamSpec{
+			Name:       "parametername",
+			Type:       v1alpha2.ParamTypeObject,
+			Properties: map[string]v1alpha2.PropertySpec{"url": {Type: v1alpha2.ParamTypeString}, "revision": {Type: v1alpha2.ParamTypeString}},
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -128,6 +139,13 @@ func TestArrayOrString_ApplyReplacements(t *testing.T) {
 			arrayReplacements:  map[string][]string{"arraykey": {}},
 		},
 		expectedOutput: builder.ArrayOrString("firstvalue", "lastvalue"),
+	}, {
+		name: "string replacements on object",
+		args: args{
+			input:              &v1alpha2.ArrayOrString{Type: v1alpha2.ParamTypeObject, ObjectVal: map[string]string{"url": "$(some)", "revision": "master"}},
+			stringReplacements: map[string]string{"some": "value"},
+		},
+		expectedOutput: &v1alpha2.ArrayOrString{Type: v1alpha2.ParamTypeObject, ObjectVal: map[string]string{"url": "value", "revision": "master"}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -153,6 +171,7 @@ func TestArrayOrString_UnmarshalJSON(t *testing.T) {
 		{"{\"val\":[]}", v1alpha2.ArrayOrString{Type: v1alpha2.ParamTypeArray, ArrayVal: []string{}}},
 		{"{\"val\":[\"oneelement\"]}", v1alpha2.ArrayOrString{Type: v1alpha2.ParamTypeArray, ArrayVal: []string{"oneelement"}}},
 		{"{\"val\":[\"multiple\", \"elements\"]}", v1alpha2.ArrayOrString{Type: v1alpha2.ParamTypeArray, ArrayVal: []string{"multiple", "elements"}}},
+		{"{\"val\":{\"url\": \"a\", \"revision\": \"b\"}}", v1alpha2.ArrayOrString{Type: v1alpha2.ParamTypeObject, ObjectVal: map[string]string{"url": "a", "revision": "b"}}},
 	}
 
 	for _, c := range cases {
@@ -174,6 +193,7 @@ func TestArrayOrString_MarshalJSON(t *testing.T) {
 		{*builder.ArrayOrString("123"), "{\"val\":\"123\"}"},
 		{*builder.ArrayOrString("123", "1234"), "{\"val\":[\"123\",\"1234\"]}"},
 		{*builder.ArrayOrString("a", "a", "a"), "{\"val\":[\"a\",\"a\",\"a\"]}"},
+		{v1alpha2.ArrayOrString{Type: v1alpha2.ParamTypeObject, ObjectVal: map[string]string{"url": "a", "revision": "b"}}, "{\"val\":{\"revision\":\"b\",\"url\":\"a\"}}"},
 	}
 
 	for _, c := range cases {
@@ -187,3 +207,210 @@ func TestArrayOrString_MarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestArrayOrString_AddReplacements(t *testing.T) {
+	tests := []struct {
+		name               string
+		value              v1alpha2.ArrayOrString
+		stringReplacements map[string]string
+		arrayReplacements  map[string][]string
+	}{{
+		name:               "string",
+		value:              *builder.ArrayOrString("foo"),
+		stringReplacements: map[string]string{"params.p": "foo"},
+		arrayReplacements:  map[string][]string{},
+	}, {
+		name:               "array",
+		value:              *builder.ArrayOrString("foo", "bar"),
+		stringReplacements: map[string]string{},
+		arrayReplacements:  map[string][]string{"params.p": {"foo", "bar"}, "params.p[*]": {"foo", "bar"}},
+	}, {
+		name:               "object",
+		value:              v1alpha2.ArrayOrString{Type: v1alpha2.ParamTypeObject, ObjectVal: map[string]string{"url": "a", "revision": "b"}},
+		stringReplacements: map[string]string{"params.p.url": "a", "params.p.revision": "b"},
+		arrayReplacements:  map[string][]string{},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stringReplacements := map[string]string{}
+			arrayReplacements := map[string][]string{}
+			tc.value.AddReplacements("params.p", stringReplacements, arrayReplacements)
+			if d := cmp.Diff(tc.stringReplacements, stringReplacements); d != "" {
+				t.Errorf("string replacements (-want, +got) = %v", d)
+			}
+			if d := cmp.Diff(tc.arrayReplacements, arrayReplacements); d != "" {
+				t.Errorf("array replacements (-want, +got) = %v", d)
+			}
+		})
+	}
+}
+
+func TestParamSpec_ValidateProperties(t *testing.T) {
+	properties := map[string]v1alpha2.PropertySpec{"url": {Type: v1alpha2.ParamTypeString}, "revision": {Type: v1alpha2.ParamTypeString}}
+	tests := []struct {
+		name    string
+		spec    v1alpha2.ParamSpec
+		wantErr bool
+	}{{
+		name: "string",
+		spec: v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeString},
+	}, {
+		name: "object",
+		spec: v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeObject, Properties: properties},
+	}, {
+		name: "object with default",
+		spec: v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeObject, Properties: properties, Default: &v1alpha2.ArrayOrString{
+			Type: v1alpha2.ParamTypeObject, ObjectVal: map[string]string{"url": "a", "revision": "b"},
+		}},
+	}, {
+		name:    "properties on a string",
+		spec:    v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeString, Properties: properties},
+		wantErr: true,
+	}, {
+		name:    "object without properties",
+		spec:    v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeObject},
+		wantErr: true,
+	}, {
+		name:    "array property",
+		spec:    v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeObject, Properties: map[string]v1alpha2.PropertySpec{"url": {Type: v1alpha2.ParamTypeArray}}},
+		wantErr: true,
+	}, {
+		name: "default with a missing key",
+		spec: v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeObject, Properties: properties, Default: &v1alpha2.ArrayOrString{
+			Type: v1alpha2.ParamTypeObject, ObjectVal: map[string]string{"url": "a"},
+		}},
+		wantErr: true,
+	}, {
+		name: "default with an extra key",
+		spec: v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeObject, Properties: properties, Default: &v1alpha2.ArrayOrString{
+			Type: v1alpha2.ParamTypeObject, ObjectVal: map[string]string{"url": "a", "revision": "b", "depth": "1"},
+		}},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.spec.ValidateProperties()
+			if tc.wantErr && err == nil {
+				t.Error("expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestParamSpec_Matches(t *testing.T) {
+	object := v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeObject, Properties: map[string]v1alpha2.PropertySpec{"url": {}}}
+	tests := []struct {
+		name  string
+		spec  v1alpha2.ParamSpec
+		value v1alpha2.ArrayOrString
+		want  bool
+	}{{
+		name:  "string",
+		spec:  v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeString},
+		value: *builder.ArrayOrString("foo"),
+		want:  true,
+	}, {
+		name:  "array given for a string",
+		spec:  v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeString},
+		value: *builder.ArrayOrString("foo", "bar"),
+	}, {
+		name:  "object",
+		spec:  object,
+		value: v1alpha2.ArrayOrString{Type: v1alpha2.ParamTypeObject, ObjectVal: map[string]string{"url": "a"}},
+		want:  true,
+	}, {
+		name:  "object with another key",
+		spec:  object,
+		value: v1alpha2.ArrayOrString{Type: v1alpha2.ParamTypeObject, ObjectVal: map[string]string{"revision": "a"}},
+	}, {
+		name:  "string given for an object",
+		spec:  object,
+		value: *builder.ArrayOrString("foo"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.spec.Matches(tc.value); got != tc.want {
+				t.Errorf("Matches() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParamSpec_ValidateEnum(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    v1alpha2.ParamSpec
+		wantErr bool
+	}{{
+		name: "no enum",
+		spec: v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeString},
+	}, {
+		name: "enum",
+		spec: v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeString, Enum: []string{"amd64", "arm64"}},
+	}, {
+		name: "enum with default",
+		spec: v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeString, Enum: []string{"amd64", "arm64"}, Default: builder.ArrayOrString("arm64")},
+	}, {
+		name:    "enum on an array",
+		spec:    v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeArray, Enum: []string{"amd64", "arm64"}},
+		wantErr: true,
+	}, {
+		name:    "duplicate enum value",
+		spec:    v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeString, Enum: []string{"amd64", "amd64"}},
+		wantErr: true,
+	}, {
+		name:    "default not in enum",
+		spec:    v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeString, Enum: []string{"amd64", "arm64"}, Default: builder.ArrayOrString("s390x")},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.spec.ValidateEnum()
+			if tc.wantErr && err == nil {
+				t.Error("expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestParamSpec_Allows(t *testing.T) {
+	spec := v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeString, Enum: []string{"amd64", "arm64"}}
+	tests := []struct {
+		name  string
+		spec  v1alpha2.ParamSpec
+		value v1alpha2.ArrayOrString
+		want  bool
+	}{{
+		name:  "no enum",
+		spec:  v1alpha2.ParamSpec{Name: "p", Type: v1alpha2.ParamTypeString},
+		value: *builder.ArrayOrString("s390x"),
+		want:  true,
+	}, {
+		name:  "enum value",
+		spec:  spec,
+		value: *builder.ArrayOrString("arm64"),
+		want:  true,
+	}, {
+		name:  "variable",
+		spec:  spec,
+		value: *builder.ArrayOrString("$(params.arch)"),
+		want:  true,
+	}, {
+		name:  "other value",
+		spec:  spec,
+		value: *builder.ArrayOrString("s390x"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.spec.Allows(tc.value); got != tc.want {
+				t.Errorf("Allows() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
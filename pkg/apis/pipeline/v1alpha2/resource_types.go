@@ -29,6 +29,9 @@ import (
 type PipelineResourceType string
 
 var (
+	// AllowedOutputResources are the types of the output PipelineResources
+	// that are copied to the next Tasks of a Pipeline, unless the
+	// allowed-output-resources feature flag lists others.
 	AllowedOutputResources = map[PipelineResourceType]bool{
 		PipelineResourceTypeStorage: true,
 		PipelineResourceTypeGit:     true,
@@ -53,10 +56,16 @@ const (
 
 	// PipelineResourceTypeCloudEvent indicates that this source is a cloud event URI
 	PipelineResourceTypeCloudEvent PipelineResourceType = "cloudEvent"
+
+	// PipelineResourceTypeHTTP indicates that this source is an artifact downloaded from, or uploaded to, a URL.
+	PipelineResourceTypeHTTP PipelineResourceType = "http"
+
+	// PipelineResourceTypeMaven indicates that this source is an artifact downloaded from, or deployed to, a Maven repository.
+	PipelineResourceTypeMaven PipelineResourceType = "maven"
 )
 
 // AllResourceTypes can be used for validation to check if a provided Resource type is one of the known types.
-var AllResourceTypes = []PipelineResourceType{PipelineResourceTypeGit, PipelineResourceTypeStorage, PipelineResourceTypeImage, PipelineResourceTypeCluster, PipelineResourceTypePullRequest, PipelineResourceTypeCloudEvent}
+var AllResourceTypes = []PipelineResourceType{PipelineResourceTypeGit, PipelineResourceTypeStorage, PipelineResourceTypeImage, PipelineResourceTypeCluster, PipelineResourceTypePullRequest, PipelineResourceTypeCloudEvent, PipelineResourceTypeHTTP, PipelineResourceTypeMaven}
 
 // TaskResources allows a Pipeline to declare how its DeclaredPipelineResources
 // should be provided to a Task as its inputs and outputs.
@@ -93,8 +93,46 @@ type Step struct {
 	//
 	// If Script is not empty, the Step cannot have an Command or Args.
 	Script string `json:"script,omitempty"`
+
+	// AlwaysRun runs the Step even if a previous Step failed, once it
+	// completed. The Steps after it still don't run. An always-run Step must
+	// be named.
+	// +optional
+	AlwaysRun bool `json:"alwaysRun,omitempty"`
+
+	// Retries is how many times the Step is run again if its command exits
+	// with a non-zero code, before the Step fails.
+	// +optional
+	Retries int `json:"retries,omitempty"`
+
+	// RetryBackoff is how long to wait before the first retry of the Step.
+	// The wait doubles for each of the next retries.
+	// +optional
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+
+	// Timeout is how long the command of the Step can run for, retries
+	// included, before it is killed and the Step fails.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// OnError is what to do when the command of the Step fails, retries
+	// and timeout included: stopAndFail, the default, or continue.
+	// +optional
+	OnError OnErrorType `json:"onError,omitempty"`
 }
 
+// OnErrorType is what to do when the command of a Step fails.
+type OnErrorType string
+
+const (
+	// StepOnErrorStopAndFail fails the Step and the TaskRun, and skips the
+	// Steps after it.
+	StepOnErrorStopAndFail OnErrorType = "stopAndFail"
+	// StepOnErrorContinue ignores the failure: the results the Step wrote
+	// are still reported and the Steps after it run.
+	StepOnErrorContinue OnErrorType = "continue"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // TaskList contains a list of Task
 type TaskList struct {
@@ -128,7 +128,36 @@ func validateSteps(steps []Step) *apis.FieldError {
 			}
 		}
 
+		if s.Retries < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", s.Retries), "retries")
+		}
+		if s.RetryBackoff != nil {
+			if s.Retries == 0 {
+				return &apis.FieldError{
+					Message: "retryBackoff can only be used with retries",
+					Paths:   []string{"retryBackoff"},
+				}
+			}
+			if s.RetryBackoff.Duration < 0 {
+				return apis.ErrInvalidValue(s.RetryBackoff.Duration.String()+" should be >= 0", "retryBackoff")
+			}
+		}
+		switch s.OnError {
+		case "", StepOnErrorStopAndFail, StepOnErrorContinue:
+		default:
+			return apis.ErrInvalidValue(string(s.OnError), "onError")
+		}
+		if s.Timeout != nil && s.Timeout.Duration < 0 {
+			return apis.ErrInvalidValue(s.Timeout.Duration.String()+" should be >= 0", "timeout")
+		}
+
 		if s.Name == "" {
+			if s.AlwaysRun {
+				return &apis.FieldError{
+					Message: "always-run steps must be named",
+					Paths:   []string{"name"},
+				}
+			}
 			continue
 		}
 		if _, ok := names[s.Name]; ok {
@@ -163,6 +192,12 @@ func validateParameterTypes(params []ParamSpec) *apis.FieldError {
 				},
 			}
 		}
+		if err := p.ValidateProperties(); err != nil {
+			return err.ViaField(fmt.Sprintf("taskspec.params.%s", p.Name))
+		}
+		if err := p.ValidateEnum(); err != nil {
+			return err.ViaField(fmt.Sprintf("taskspec.params.%s", p.Name))
+		}
 	}
 	return nil
 }
@@ -19,12 +19,14 @@ package v1alpha2_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
 	"github.com/tektoncd/pipeline/test/builder"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -153,6 +155,31 @@ func TestTaskSpecValidate(t *testing.T) {
 				Image: "some-image",
 			},
 		},
+	}, {
+		name: "step with retries",
+		fields: fields{
+			Steps: []v1alpha2.Step{{
+				Container:    corev1.Container{Image: "my-image"},
+				Retries:      3,
+				RetryBackoff: &metav1.Duration{Duration: 10 * time.Second},
+			}},
+		},
+	}, {
+		name: "step with timeout",
+		fields: fields{
+			Steps: []v1alpha2.Step{{
+				Container: corev1.Container{Image: "my-image"},
+				Timeout:   &metav1.Duration{Duration: 10 * time.Minute},
+			}},
+		},
+	}, {
+		name: "step continuing on error",
+		fields: fields{
+			Steps: []v1alpha2.Step{{
+				Container: corev1.Container{Image: "my-image"},
+				OnError:   v1alpha2.StepOnErrorContinue,
+			}},
+		},
 	}, {
 		name: "valid step with script",
 		fields: fields{
@@ -548,6 +575,79 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Message: "script cannot be used with args or command",
 			Paths:   []string{"steps.script"},
 		},
+	}, {
+		name: "negative step retries",
+		fields: fields{
+			Steps: []v1alpha2.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				Retries:   -1,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1 should be >= 0`,
+			Paths:   []string{"steps.retries"},
+		},
+	}, {
+		name: "step retry backoff without retries",
+		fields: fields{
+			Steps: []v1alpha2.Step{{
+				Container:    corev1.Container{Image: "myimage"},
+				RetryBackoff: &metav1.Duration{Duration: time.Second},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "retryBackoff can only be used with retries",
+			Paths:   []string{"steps.retryBackoff"},
+		},
+	}, {
+		name: "negative step retry backoff",
+		fields: fields{
+			Steps: []v1alpha2.Step{{
+				Container:    corev1.Container{Image: "myimage"},
+				Retries:      2,
+				RetryBackoff: &metav1.Duration{Duration: -time.Second},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1s should be >= 0`,
+			Paths:   []string{"steps.retryBackoff"},
+		},
+	}, {
+		name: "negative step timeout",
+		fields: fields{
+			Steps: []v1alpha2.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				Timeout:   &metav1.Duration{Duration: -time.Minute},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1m0s should be >= 0`,
+			Paths:   []string{"steps.timeout"},
+		},
+	}, {
+		name: "unknown step onError",
+		fields: fields{
+			Steps: []v1alpha2.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				OnError:   "ignore",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: ignore`,
+			Paths:   []string{"steps.onError"},
+		},
+	}, {
+		name: "unnamed always-run step",
+		fields: fields{
+			Steps: []v1alpha2.Step{{
+				Container: corev1.Container{Image: "myimage"},
+				AlwaysRun: true,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "always-run steps must be named",
+			Paths:   []string{"steps.name"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -17,6 +17,7 @@ limitations under the License.
 package artifacts
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -41,6 +42,7 @@ var (
 		KubeconfigWriterImage:    "override-with-kubeconfig-writer:latest",
 		ShellImage:               "busybox",
 		GsutilImage:              "google/cloud-sdk",
+		AwsCliImage:              "amazon/aws-cli",
 		BuildGCSFetcherImage:     "gcr.io/cloud-builders/gcs-fetcher:latest",
 		PRImage:                  "override-with-pr:latest",
 		ImageDigestExporterImage: "override-with-imagedigest-exporter-image:latest",
@@ -97,7 +99,12 @@ var (
 
 func GetPersistentVolumeClaim(size string, storageClassName *string) *corev1.PersistentVolumeClaim {
 	pvc := &corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{Name: "pipelineruntest-pvc", Namespace: pipelinerun.Namespace, OwnerReferences: pipelinerun.GetOwnerReference()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "pipelineruntest-pvc",
+			Namespace:       pipelinerun.Namespace,
+			OwnerReferences: pipelinerun.GetOwnerReference(),
+			Labels:          map[string]string{"tekton.dev/pipelineRun": pipelinerun.Name},
+		},
 		Spec: corev1.PersistentVolumeClaimSpec{
 			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
 			Resources:        corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}},
@@ -252,6 +259,7 @@ func TestInitializeArtifactStorageWithConfigMap(t *testing.T) {
 			}},
 			ShellImage:  "busybox",
 			GsutilImage: "google/cloud-sdk",
+			AwsCliImage: "amazon/aws-cli",
 		},
 		storagetype: "bucket",
 	}, {
@@ -320,6 +328,7 @@ func TestInitializeArtifactStorageWithConfigMap(t *testing.T) {
 			Location:    "gs://fake-bucket",
 			ShellImage:  "busybox",
 			GsutilImage: "google/cloud-sdk",
+			AwsCliImage: "amazon/aws-cli",
 		},
 		storagetype: "bucket",
 	}, {
@@ -340,6 +349,7 @@ func TestInitializeArtifactStorageWithConfigMap(t *testing.T) {
 			Location:    "s3://fake-bucket",
 			ShellImage:  "busybox",
 			GsutilImage: "google/cloud-sdk",
+			AwsCliImage: "amazon/aws-cli",
 			Secrets: []v1alpha1.SecretParam{{
 				FieldName:  "BOTO_CONFIG",
 				SecretKey:  "sakey",
@@ -350,7 +360,7 @@ func TestInitializeArtifactStorageWithConfigMap(t *testing.T) {
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			fakekubeclient := fakek8s.NewSimpleClientset(c.configMap)
-			artifactStorage, err := InitializeArtifactStorage(images, pipelinerun, &pipelineWithTasksWithFrom.Spec, fakekubeclient, logger)
+			artifactStorage, err := InitializeArtifactStorage(context.Background(), images, pipelinerun, &pipelineWithTasksWithFrom.Spec, fakekubeclient, logger)
 			if err != nil {
 				t.Fatalf("Somehow had error initializing artifact storage run out of fake client: %s", err)
 			}
@@ -479,7 +489,7 @@ func TestInitializeArtifactStorageNoStorageNeeded(t *testing.T) {
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			fakekubeclient := fakek8s.NewSimpleClientset(c.configMap)
-			artifactStorage, err := InitializeArtifactStorage(images, pipelinerun, &pipeline.Spec, fakekubeclient, logger)
+			artifactStorage, err := InitializeArtifactStorage(context.Background(), images, pipelinerun, &pipeline.Spec, fakekubeclient, logger)
 			if err != nil {
 				t.Fatalf("Somehow had error initializing artifact storage run out of fake client: %s", err)
 			}
@@ -564,7 +574,7 @@ func TestInitializeArtifactStorageWithoutConfigMap(t *testing.T) {
 	logger := logtesting.TestLogger(t)
 	fakekubeclient := fakek8s.NewSimpleClientset()
 
-	pvc, err := InitializeArtifactStorage(images, pipelinerun, &pipelineWithTasksWithFrom.Spec, fakekubeclient, logger)
+	pvc, err := InitializeArtifactStorage(context.Background(), images, pipelinerun, &pipelineWithTasksWithFrom.Spec, fakekubeclient, logger)
 	if err != nil {
 		t.Fatalf("Somehow had error initializing artifact storage run out of fake client: %s", err)
 	}
@@ -580,6 +590,64 @@ func TestInitializeArtifactStorageWithoutConfigMap(t *testing.T) {
 	}
 }
 
+func TestInitializeArtifactStorageWithDeletedPVC(t *testing.T) {
+	ran := v1alpha1.PipelineRunStatus{
+		TaskRuns: map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"pipelineruntest-task1": {PipelineTaskName: "task1"},
+		},
+	}
+	now := metav1.Now()
+	for _, tc := range []struct {
+		name     string
+		status   v1alpha1.PipelineRunStatus
+		pvcs     []*corev1.PersistentVolumeClaim
+		wantLost bool
+	}{{
+		name: "first tasks",
+	}, {
+		name:   "pvc exists",
+		status: ran,
+		pvcs: []*corev1.PersistentVolumeClaim{{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipelineruntest-pvc", Namespace: "foo"},
+		}},
+	}, {
+		name:     "pvc deleted",
+		status:   ran,
+		wantLost: true,
+	}, {
+		name:   "pvc being deleted",
+		status: ran,
+		pvcs: []*corev1.PersistentVolumeClaim{{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipelineruntest-pvc", Namespace: "foo", DeletionTimestamp: &now},
+		}},
+		wantLost: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pipelinerun := &v1alpha1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipelineruntest",
+					Namespace: "foo",
+				},
+				Status: tc.status,
+			}
+			fakekubeclient := fakek8s.NewSimpleClientset()
+			for _, pvc := range tc.pvcs {
+				if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(pvc); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := InitializeArtifactStorage(context.Background(), images, pipelinerun, &pipelineWithTasksWithFrom.Spec, fakekubeclient, logtesting.TestLogger(t))
+			if got := IsArtifactPVCLost(err); got != tc.wantLost {
+				t.Errorf("IsArtifactPVCLost(%v) = %t, want %t", err, got, tc.wantLost)
+			}
+			if !tc.wantLost && err != nil {
+				t.Errorf("InitializeArtifactStorage() = %v", err)
+			}
+		})
+	}
+}
+
 func TestGetArtifactStorageWithConfigMap(t *testing.T) {
 	pipelinerun := &v1alpha1.PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -614,6 +682,37 @@ func TestGetArtifactStorageWithConfigMap(t *testing.T) {
 			}},
 			ShellImage:  "busybox",
 			GsutilImage: "google/cloud-sdk",
+			AwsCliImage: "amazon/aws-cli",
+		},
+	}, {
+		desc: "s3 bucket",
+		configMap: &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: system.GetNamespace(),
+				Name:      GetBucketConfigName(),
+			},
+			Data: map[string]string{
+				BucketLocationKey:              "s3://fake-bucket",
+				BucketProviderKey:              "s3",
+				BucketRegionKey:                "eu-west-1",
+				BucketEndpointKey:              "https://minio.example.com",
+				BucketServiceAccountSecretName: "secret1",
+				BucketServiceAccountSecretKey:  "credentials",
+			},
+		},
+		expectedArtifactStorage: &v1alpha1.ArtifactBucket{
+			Location: "s3://fake-bucket",
+			Secrets: []v1alpha1.SecretParam{{
+				FieldName:  "AWS_SHARED_CREDENTIALS_FILE",
+				SecretKey:  "credentials",
+				SecretName: "secret1",
+			}},
+			Provider:    "s3",
+			Region:      "eu-west-1",
+			Endpoint:    "https://minio.example.com",
+			ShellImage:  "busybox",
+			GsutilImage: "google/cloud-sdk",
+			AwsCliImage: "amazon/aws-cli",
 		},
 	}, {
 		desc: "location empty",
@@ -676,6 +775,30 @@ func TestGetArtifactStorageWithConfigMap(t *testing.T) {
 	}
 }
 
+func TestGetArtifactStorageInvalidBucketProvider(t *testing.T) {
+	logger := logtesting.TestLogger(t)
+	for _, c := range []struct {
+		desc string
+		data map[string]string
+	}{{
+		desc: "unknown provider",
+		data: map[string]string{BucketLocationKey: "gs://fake-bucket", BucketProviderKey: "azure"},
+	}, {
+		desc: "s3 provider with a gs bucket",
+		data: map[string]string{BucketLocationKey: "gs://fake-bucket", BucketProviderKey: "s3"},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fakekubeclient := fakek8s.NewSimpleClientset(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: GetBucketConfigName()},
+				Data:       c.data,
+			})
+			if _, err := GetArtifactStorage(images, pipelinerun.Name, fakekubeclient, logger); err == nil {
+				t.Error("Expected an error getting the artifact storage")
+			}
+		})
+	}
+}
+
 func TestGetArtifactStorageWithoutConfigMap(t *testing.T) {
 	logger := logtesting.TestLogger(t)
 	fakekubeclient := fakek8s.NewSimpleClientset()
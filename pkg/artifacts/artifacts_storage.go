@@ -17,10 +17,12 @@ limitations under the License.
 package artifacts
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/system"
@@ -61,6 +63,19 @@ const (
 	// the field name that should be used for the service account.
 	// Valid values: GOOGLE_APPLICATION_CREDENTIALS, BOTO_CONFIG. Defaults to GOOGLE_APPLICATION_CREDENTIALS.
 	BucketServiceAccountFieldName = "bucket.service.account.field.name"
+
+	// BucketProviderKey is the name of the configmap entry that specifies
+	// the tool copying the artifacts to and from the bucket, gcs (gsutil) or
+	// s3 (the AWS CLI). Defaults to gcs.
+	BucketProviderKey = "bucket.provider"
+
+	// BucketRegionKey is the name of the configmap entry that specifies the
+	// region of the bucket of the s3 provider.
+	BucketRegionKey = "bucket.region"
+
+	// BucketEndpointKey is the name of the configmap entry that specifies
+	// the endpoint URL of the s3 provider, for S3-compatible storage.
+	BucketEndpointKey = "bucket.endpoint"
 )
 
 // GetBucketConfigName returns the name of the configmap containing all
@@ -122,16 +137,17 @@ func (a *ArtifactStorageNone) StorageBasePath(pr *v1alpha1.PipelineRun) string {
 
 // InitializeArtifactStorage will check if there is there is a
 // bucket configured, create a PVC or return nil if no storage is required.
-func InitializeArtifactStorage(images pipeline.Images, pr *v1alpha1.PipelineRun, ps *v1alpha1.PipelineSpec, c kubernetes.Interface, logger *zap.SugaredLogger) (ArtifactStorageInterface, error) {
+func InitializeArtifactStorage(ctx context.Context, images pipeline.Images, pr *v1alpha1.PipelineRun, ps *v1alpha1.PipelineSpec, c kubernetes.Interface, logger *zap.SugaredLogger) (ArtifactStorageInterface, error) {
 	// Artifact storage is needed under the following condition:
 	//  Any Task in the pipeline contains an Output resource
 	//  AND that Output resource is one of the AllowedOutputResource types.
 
 	needStorage := false
+	featureFlags := config.FromContextOrDefaults(ctx).FeatureFlags
 	// Build an index of resources used in the pipeline that are an AllowedOutputResource
 	possibleOutputs := map[string]struct{}{}
 	for _, r := range ps.Resources {
-		if _, ok := v1alpha1.AllowedOutputResources[r.Type]; ok {
+		if featureFlags.OutputResourceAllowed(r.Type) {
 			possibleOutputs[r.Name] = struct{}{}
 		}
 	}
@@ -230,6 +246,7 @@ func NewArtifactBucketConfigFromConfigMap(images pipeline.Images) func(configMap
 		c := &v1alpha1.ArtifactBucket{
 			ShellImage:  images.ShellImage,
 			GsutilImage: images.GsutilImage,
+			AwsCliImage: images.AwsCliImage,
 		}
 
 		if configMap.Data == nil {
@@ -240,10 +257,25 @@ func NewArtifactBucketConfigFromConfigMap(images pipeline.Images) func(configMap
 		} else {
 			c.Location = location
 		}
+		switch provider := configMap.Data[BucketProviderKey]; provider {
+		case "", v1alpha1.ArtifactBucketProviderGCS:
+		case v1alpha1.ArtifactBucketProviderS3:
+			if !strings.HasPrefix(c.Location, "s3://") {
+				return nil, fmt.Errorf("%q must be an s3:// URL with the %s %q, got %q", BucketLocationKey, BucketProviderKey, provider, c.Location)
+			}
+			c.Provider = provider
+			c.Region = configMap.Data[BucketRegionKey]
+			c.Endpoint = configMap.Data[BucketEndpointKey]
+		default:
+			return nil, fmt.Errorf("unknown %s %q, must be %q or %q", BucketProviderKey, provider, v1alpha1.ArtifactBucketProviderGCS, v1alpha1.ArtifactBucketProviderS3)
+		}
 		sp := v1alpha1.SecretParam{}
 		if secretName, ok := configMap.Data[BucketServiceAccountSecretName]; ok {
 			if secretKey, ok := configMap.Data[BucketServiceAccountSecretKey]; ok {
 				sp.FieldName = "GOOGLE_APPLICATION_CREDENTIALS"
+				if c.Provider == v1alpha1.ArtifactBucketProviderS3 {
+					sp.FieldName = "AWS_SHARED_CREDENTIALS_FILE"
+				}
 				if fieldName, ok := configMap.Data[BucketServiceAccountFieldName]; ok {
 					sp.FieldName = fieldName
 				}
@@ -256,9 +288,37 @@ func NewArtifactBucketConfigFromConfigMap(images pipeline.Images) func(configMap
 	}
 }
 
+// artifactPVCLostError is returned when the PVC of a PipelineRun was deleted
+// after some of its Tasks ran, losing the artifacts they stored in it.
+type artifactPVCLostError struct {
+	name        string
+	terminating bool
+}
+
+func (e *artifactPVCLostError) Error() string {
+	if e.terminating {
+		return fmt.Sprintf("the PVC %q holding the artifacts of the Tasks that already ran is being deleted", e.name)
+	}
+	return fmt.Sprintf("the PVC %q holding the artifacts of the Tasks that already ran was deleted", e.name)
+}
+
+// IsArtifactPVCLost returns true if err is returned by
+// InitializeArtifactStorage because the PVC of the PipelineRun was deleted
+// while it ran. The remaining Tasks of the PipelineRun can't run, as the
+// artifacts they need are lost.
+func IsArtifactPVCLost(err error) bool {
+	_, ok := err.(*artifactPVCLostError)
+	return ok
+}
+
 func createPVC(pr *v1alpha1.PipelineRun, c kubernetes.Interface) (*corev1.PersistentVolumeClaim, error) {
-	if _, err := c.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(GetPVCName(pr), metav1.GetOptions{}); err != nil {
+	if pvc, err := c.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(GetPVCName(pr), metav1.GetOptions{}); err != nil {
 		if errors.IsNotFound(err) {
+			// The PVC is created before the first TaskRun: recreating it
+			// would hide that the artifacts of the Tasks that ran are lost.
+			if len(pr.Status.TaskRuns) > 0 {
+				return nil, &artifactPVCLostError{name: GetPVCName(pr)}
+			}
 
 			configMap, err := c.CoreV1().ConfigMaps(system.GetNamespace()).Get(GetPVCConfigName(), metav1.GetOptions{})
 			if err != nil && !errors.IsNotFound(err) {
@@ -292,6 +352,10 @@ func createPVC(pr *v1alpha1.PipelineRun, c kubernetes.Interface) (*corev1.Persis
 			return pvc, nil
 		}
 		return nil, fmt.Errorf("failed to get claim Persistent Volume %q due to error: %w", pr.Name, err)
+	} else if pvc.DeletionTimestamp != nil {
+		// The Pods of the remaining Tasks wouldn't start with a PVC that is
+		// being deleted.
+		return nil, &artifactPVCLostError{name: pvc.Name, terminating: true}
 	}
 	return nil, nil
 }
@@ -314,6 +378,9 @@ func GetPVCSpec(pr *v1alpha1.PipelineRun, pvcSize resource.Quantity, storageClas
 			Namespace:       pr.Namespace,
 			Name:            GetPVCName(pr),
 			OwnerReferences: pr.GetOwnerReference(),
+			// The label finds the PVC again if the PipelineRun is deleted
+			// without deleting its dependents.
+			Labels: map[string]string{pipeline.GroupName + pipeline.PipelineRunLabelKey: pr.Name},
 		},
 		Spec: corev1.PersistentVolumeClaimSpec{
 			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
@@ -36,6 +36,10 @@ func (c *FakeTektonV1alpha1) Conditions(namespace string) v1alpha1.ConditionInte
 	return &FakeConditions{c, namespace}
 }
 
+func (c *FakeTektonV1alpha1) NotificationPolicies(namespace string) v1alpha1.NotificationPolicyInterface {
+	return &FakeNotificationPolicies{c, namespace}
+}
+
 func (c *FakeTektonV1alpha1) Pipelines(namespace string) v1alpha1.PipelineInterface {
 	return &FakePipelines{c, namespace}
 }
@@ -48,6 +52,18 @@ func (c *FakeTektonV1alpha1) PipelineRuns(namespace string) v1alpha1.PipelineRun
 	return &FakePipelineRuns{c, namespace}
 }
 
+func (c *FakeTektonV1alpha1) ResolutionRequests(namespace string) v1alpha1.ResolutionRequestInterface {
+	return &FakeResolutionRequests{c, namespace}
+}
+
+func (c *FakeTektonV1alpha1) Runs(namespace string) v1alpha1.RunInterface {
+	return &FakeRuns{c, namespace}
+}
+
+func (c *FakeTektonV1alpha1) StorageMigrations() v1alpha1.StorageMigrationInterface {
+	return &FakeStorageMigrations{c}
+}
+
 func (c *FakeTektonV1alpha1) Tasks(namespace string) v1alpha1.TaskInterface {
 	return &FakeTasks{c, namespace}
 }
@@ -56,6 +72,10 @@ func (c *FakeTektonV1alpha1) TaskRuns(namespace string) v1alpha1.TaskRunInterfac
 	return &FakeTaskRuns{c, namespace}
 }
 
+func (c *FakeTektonV1alpha1) TektonPipelineConfigs() v1alpha1.TektonPipelineConfigInterface {
+	return &FakeTektonPipelineConfigs{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeTektonV1alpha1) RESTClient() rest.Interface {
@@ -22,12 +22,22 @@ type ClusterTaskExpansion interface{}
 
 type ConditionExpansion interface{}
 
+type NotificationPolicyExpansion interface{}
+
 type PipelineExpansion interface{}
 
 type PipelineResourceExpansion interface{}
 
 type PipelineRunExpansion interface{}
 
+type ResolutionRequestExpansion interface{}
+
+type RunExpansion interface{}
+
+type StorageMigrationExpansion interface{}
+
 type TaskExpansion interface{}
 
 type TaskRunExpansion interface{}
+
+type TektonPipelineConfigExpansion interface{}
@@ -29,11 +29,16 @@ type TektonV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClusterTasksGetter
 	ConditionsGetter
+	NotificationPoliciesGetter
 	PipelinesGetter
 	PipelineResourcesGetter
 	PipelineRunsGetter
+	ResolutionRequestsGetter
+	RunsGetter
+	StorageMigrationsGetter
 	TasksGetter
 	TaskRunsGetter
+	TektonPipelineConfigsGetter
 }
 
 // TektonV1alpha1Client is used to interact with features provided by the tekton.dev group.
@@ -49,6 +54,10 @@ func (c *TektonV1alpha1Client) Conditions(namespace string) ConditionInterface {
 	return newConditions(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) NotificationPolicies(namespace string) NotificationPolicyInterface {
+	return newNotificationPolicies(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Pipelines(namespace string) PipelineInterface {
 	return newPipelines(c, namespace)
 }
@@ -61,6 +70,18 @@ func (c *TektonV1alpha1Client) PipelineRuns(namespace string) PipelineRunInterfa
 	return newPipelineRuns(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) ResolutionRequests(namespace string) ResolutionRequestInterface {
+	return newResolutionRequests(c, namespace)
+}
+
+func (c *TektonV1alpha1Client) Runs(namespace string) RunInterface {
+	return newRuns(c, namespace)
+}
+
+func (c *TektonV1alpha1Client) StorageMigrations() StorageMigrationInterface {
+	return newStorageMigrations(c)
+}
+
 func (c *TektonV1alpha1Client) Tasks(namespace string) TaskInterface {
 	return newTasks(c, namespace)
 }
@@ -69,6 +90,10 @@ func (c *TektonV1alpha1Client) TaskRuns(namespace string) TaskRunInterface {
 	return newTaskRuns(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) TektonPipelineConfigs() TektonPipelineConfigInterface {
+	return newTektonPipelineConfigs(c)
+}
+
 // NewForConfig creates a new TektonV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*TektonV1alpha1Client, error) {
 	config := *c
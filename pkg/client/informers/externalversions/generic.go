@@ -58,16 +58,26 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().ClusterTasks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("conditions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().Conditions().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("notificationpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().NotificationPolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("pipelines"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().Pipelines().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("pipelineresources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().PipelineResources().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("pipelineruns"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().PipelineRuns().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("resolutionrequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().ResolutionRequests().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("runs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().Runs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("storagemigrations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().StorageMigrations().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("tasks"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().Tasks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("taskruns"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().TaskRuns().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("tektonpipelineconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().TektonPipelineConfigs().Informer()}, nil
 
 		// Group=tekton.dev, Version=v1alpha2
 	case v1alpha2.SchemeGroupVersion.WithResource("tasks"):
@@ -28,16 +28,26 @@ type Interface interface {
 	ClusterTasks() ClusterTaskInformer
 	// Conditions returns a ConditionInformer.
 	Conditions() ConditionInformer
+	// NotificationPolicies returns a NotificationPolicyInformer.
+	NotificationPolicies() NotificationPolicyInformer
 	// Pipelines returns a PipelineInformer.
 	Pipelines() PipelineInformer
 	// PipelineResources returns a PipelineResourceInformer.
 	PipelineResources() PipelineResourceInformer
 	// PipelineRuns returns a PipelineRunInformer.
 	PipelineRuns() PipelineRunInformer
+	// ResolutionRequests returns a ResolutionRequestInformer.
+	ResolutionRequests() ResolutionRequestInformer
+	// Runs returns a RunInformer.
+	Runs() RunInformer
+	// StorageMigrations returns a StorageMigrationInformer.
+	StorageMigrations() StorageMigrationInformer
 	// Tasks returns a TaskInformer.
 	Tasks() TaskInformer
 	// TaskRuns returns a TaskRunInformer.
 	TaskRuns() TaskRunInformer
+	// TektonPipelineConfigs returns a TektonPipelineConfigInformer.
+	TektonPipelineConfigs() TektonPipelineConfigInformer
 }
 
 type version struct {
@@ -61,6 +71,11 @@ func (v *version) Conditions() ConditionInformer {
 	return &conditionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// NotificationPolicies returns a NotificationPolicyInformer.
+func (v *version) NotificationPolicies() NotificationPolicyInformer {
+	return &notificationPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Pipelines returns a PipelineInformer.
 func (v *version) Pipelines() PipelineInformer {
 	return &pipelineInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -76,6 +91,21 @@ func (v *version) PipelineRuns() PipelineRunInformer {
 	return &pipelineRunInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ResolutionRequests returns a ResolutionRequestInformer.
+func (v *version) ResolutionRequests() ResolutionRequestInformer {
+	return &resolutionRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Runs returns a RunInformer.
+func (v *version) Runs() RunInformer {
+	return &runInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// StorageMigrations returns a StorageMigrationInformer.
+func (v *version) StorageMigrations() StorageMigrationInformer {
+	return &storageMigrationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Tasks returns a TaskInformer.
 func (v *version) Tasks() TaskInformer {
 	return &taskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -85,3 +115,8 @@ func (v *version) Tasks() TaskInformer {
 func (v *version) TaskRuns() TaskRunInformer {
 	return &taskRunInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// TektonPipelineConfigs returns a TektonPipelineConfigInformer.
+func (v *version) TektonPipelineConfigs() TektonPipelineConfigInformer {
+	return &tektonPipelineConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -30,6 +30,14 @@ type ConditionListerExpansion interface{}
 // ConditionNamespaceLister.
 type ConditionNamespaceListerExpansion interface{}
 
+// NotificationPolicyListerExpansion allows custom methods to be added to
+// NotificationPolicyLister.
+type NotificationPolicyListerExpansion interface{}
+
+// NotificationPolicyNamespaceListerExpansion allows custom methods to be added to
+// NotificationPolicyNamespaceLister.
+type NotificationPolicyNamespaceListerExpansion interface{}
+
 // PipelineListerExpansion allows custom methods to be added to
 // PipelineLister.
 type PipelineListerExpansion interface{}
@@ -54,6 +62,26 @@ type PipelineRunListerExpansion interface{}
 // PipelineRunNamespaceLister.
 type PipelineRunNamespaceListerExpansion interface{}
 
+// ResolutionRequestListerExpansion allows custom methods to be added to
+// ResolutionRequestLister.
+type ResolutionRequestListerExpansion interface{}
+
+// ResolutionRequestNamespaceListerExpansion allows custom methods to be added to
+// ResolutionRequestNamespaceLister.
+type ResolutionRequestNamespaceListerExpansion interface{}
+
+// RunListerExpansion allows custom methods to be added to
+// RunLister.
+type RunListerExpansion interface{}
+
+// RunNamespaceListerExpansion allows custom methods to be added to
+// RunNamespaceLister.
+type RunNamespaceListerExpansion interface{}
+
+// StorageMigrationListerExpansion allows custom methods to be added to
+// StorageMigrationLister.
+type StorageMigrationListerExpansion interface{}
+
 // TaskListerExpansion allows custom methods to be added to
 // TaskLister.
 type TaskListerExpansion interface{}
@@ -69,3 +97,7 @@ type TaskRunListerExpansion interface{}
 // TaskRunNamespaceListerExpansion allows custom methods to be added to
 // TaskRunNamespaceLister.
 type TaskRunNamespaceListerExpansion interface{}
+
+// TektonPipelineConfigListerExpansion allows custom methods to be added to
+// TektonPipelineConfigLister.
+type TektonPipelineConfigListerExpansion interface{}
@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compat checks that the objects stored at v1alpha1 convert to the
+// newest version of their kind and back without losing any field, so that
+// operators can check the objects of a cluster before bumping the storage
+// version of a CustomResourceDefinition.
+package compat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+// newest returns an empty object of the newest version of the kind of obj,
+// or nil if v1alpha1 is the newest version of the kind.
+func newest(obj runtime.Object) apis.Convertible {
+	switch obj.(type) {
+	case *v1alpha1.Task:
+		return &v1alpha2.Task{}
+	}
+	return nil
+}
+
+// CheckRoundTrip converts obj, a v1alpha1 object, to the newest version of its
+// kind, stores it as JSON like the API server does, and converts it back to
+// v1alpha1. It returns an error describing the fields that differ from obj,
+// including the fields stashed in annotations by the conversion. It returns
+// nil if nothing is lost, or if v1alpha1 is the newest version of the kind.
+func CheckRoundTrip(ctx context.Context, obj runtime.Object) error {
+	kinds, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return err
+	}
+	if kinds[0].GroupVersion() != v1alpha1.SchemeGroupVersion {
+		return fmt.Errorf("%s isn't a %s object", kinds[0], v1alpha1.SchemeGroupVersion)
+	}
+	up := newest(obj)
+	if up == nil {
+		return nil
+	}
+	source, ok := obj.(apis.Convertible)
+	if !ok {
+		return fmt.Errorf("%s can't be converted to %T", kinds[0].Kind, up)
+	}
+	if err := source.ConvertUp(ctx, up); err != nil {
+		return fmt.Errorf("failed to convert %s to %T: %w", kinds[0].Kind, up, err)
+	}
+
+	b, err := json.Marshal(up)
+	if err != nil {
+		return err
+	}
+	stored := newest(obj)
+	if err := json.Unmarshal(b, stored); err != nil {
+		return fmt.Errorf("failed to decode %T: %w", stored, err)
+	}
+
+	down := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(apis.Convertible)
+	if err := down.ConvertDown(ctx, stored); err != nil {
+		return fmt.Errorf("failed to convert %T back to %s: %w", stored, kinds[0].Kind, err)
+	}
+
+	want, err := normalize(obj)
+	if err != nil {
+		return err
+	}
+	got, err := normalize(down)
+	if err != nil {
+		return err
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		name := ""
+		if m, err := meta.Accessor(obj); err == nil {
+			name = m.GetNamespace() + "/" + m.GetName()
+		}
+		return fmt.Errorf("%s %s changes converting it to %T and back (-want, +got): %s", kinds[0].Kind, name, stored, diff)
+	}
+	return nil
+}
+
+// CheckRoundTripEncoded is CheckRoundTrip for an object encoded in JSON or
+// YAML, as returned by the API server or by kubectl get -o yaml.
+func CheckRoundTripEncoded(ctx context.Context, data []byte) error {
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to decode the object: %w", err)
+	}
+	return CheckRoundTrip(ctx, obj)
+}
+
+// normalize returns the JSON of obj, without its type and without the empty
+// fields, which JSON can't tell apart from the missing ones.
+func normalize(obj interface{}) (interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var o map[string]interface{}
+	if err := json.Unmarshal(b, &o); err != nil {
+		return nil, err
+	}
+	delete(o, "apiVersion")
+	delete(o, "kind")
+	return prune(o), nil
+}
+
+// prune removes the empty maps, lists and strings and the null values from v.
+func prune(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if e = prune(e); e == nil {
+				delete(v, k)
+			} else {
+				v[k] = e
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		return v
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		for i, e := range v {
+			v[i] = prune(e)
+		}
+		return v
+	case string:
+		if v == "" {
+			return nil
+		}
+	}
+	return v
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCheckRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		obj  runtime.Object
+	}{{
+		name: "task",
+		obj: tb.Task("build", "foo", tb.TaskSpec(
+			tb.TaskInputs(
+				tb.InputsParamSpec("package", v1alpha1.ParamTypeString),
+				tb.InputsResource("source", v1alpha1.PipelineResourceTypeGit),
+			),
+			tb.TaskOutputs(tb.OutputsResource("image", v1alpha1.PipelineResourceTypeImage)),
+			tb.Step("build", "golang"),
+		)),
+	}, {
+		name: "task with fields missing from v1alpha2",
+		obj: &v1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo", Annotations: map[string]string{"team": "ci"}},
+			Spec: v1alpha1.TaskSpec{
+				Inputs:        &v1alpha1.Inputs{},
+				Outputs:       &v1alpha1.Outputs{Results: []v1alpha1.TestResult{{Name: "tests", Format: "junit", Path: "/workspace/report.xml"}}},
+				Steps:         []v1alpha1.Step{{Container: corev1.Container{Name: "build", Image: "golang"}, Script: "go build ./..."}},
+				Capabilities:  []v1alpha1.TaskCapability{v1alpha1.TaskCapabilityDocker},
+				MemoryVolumes: []v1alpha1.MemoryVolume{{Name: "shm", MountPath: "/dev/shm"}},
+				Results:       []v1alpha1.TaskResult{{Name: "digest"}},
+				Workspaces:    []v1alpha1.WorkspaceDeclaration{{Name: "cache"}},
+				Platforms:     []string{"linux/arm64"},
+			},
+		},
+	}, {
+		name: "kind without a newer version",
+		obj:  tb.TaskRun("build-run", "foo", tb.TaskRunSpec(tb.TaskRunTaskRef("build"))),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := CheckRoundTrip(context.Background(), tc.obj); err != nil {
+				t.Errorf("CheckRoundTrip() = %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckRoundTripErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		obj  runtime.Object
+	}{{
+		name: "v1alpha2 object",
+		obj:  &v1alpha2.Task{},
+	}, {
+		name: "task annotated with the stashed fields",
+		obj: &v1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Annotations: map[string]string{v1alpha1.TaskSpecAnnotationKey: "stashed"}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := CheckRoundTrip(context.Background(), tc.obj); err == nil {
+				t.Error("Expected CheckRoundTrip to fail")
+			}
+		})
+	}
+}
+
+func TestCheckRoundTripEncoded(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{{
+		name: "task",
+		data: `
+apiVersion: tekton.dev/v1alpha1
+kind: Task
+metadata:
+  name: build
+  namespace: foo
+spec:
+  inputs:
+    params:
+    - name: package
+  steps:
+  - name: build
+    image: golang
+  workspaces:
+  - name: cache
+`,
+	}, {
+		name: "pipeline",
+		data: `{"apiVersion": "tekton.dev/v1alpha1", "kind": "Pipeline", "metadata": {"name": "release"}, "spec": {"tasks": [{"name": "build", "taskRef": {"name": "build"}}]}}`,
+	}, {
+		name:    "unknown kind",
+		data:    `{"apiVersion": "tekton.dev/v1alpha1", "kind": "Build", "metadata": {"name": "build"}}`,
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckRoundTripEncoded(context.Background(), []byte(tc.data))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("CheckRoundTripEncoded() = %v, wanted an error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
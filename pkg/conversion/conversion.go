@@ -0,0 +1,217 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversion implements the conversion webhook of the
+// CustomResourceDefinitions served at several versions: the API server calls
+// it to convert the objects between the version they are stored at and the
+// version the clients use.
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+)
+
+// Path is the path the conversion webhook is served at.
+const Path = "/convert"
+
+// reviewAPIVersion is the version of the ConversionReviews the API server
+// sends, set in conversionReviewVersions of the CustomResourceDefinitions.
+const reviewAPIVersion = "apiextensions.k8s.io/v1beta1"
+
+// versions are the versions of the kinds served at several versions, from the
+// oldest to the newest. Each version converts up to the next one and down
+// from it, so that a new version only has to convert from and to the
+// previous newest one.
+var versions = map[string][]schema.GroupVersion{
+	"Task": {v1alpha1.SchemeGroupVersion, v1alpha2.SchemeGroupVersion},
+}
+
+// CRDs are the names of the CustomResourceDefinitions converted by the
+// webhook.
+var CRDs = []string{"tasks.tekton.dev"}
+
+// review is a ConversionReview of apiextensions.k8s.io/v1beta1.
+type review struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *request  `json:"request,omitempty"`
+	Response        *response `json:"response,omitempty"`
+}
+
+type request struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type response struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+// Convert converts raw, the JSON of an object, to desiredAPIVersion, through
+// each of the versions of its kind in between.
+func Convert(ctx context.Context, raw []byte, desiredAPIVersion string) ([]byte, error) {
+	var tm metav1.TypeMeta
+	if err := json.Unmarshal(raw, &tm); err != nil {
+		return nil, fmt.Errorf("failed to decode the object: %w", err)
+	}
+	chain, ok := versions[tm.Kind]
+	if !ok {
+		return nil, fmt.Errorf("%s isn't served at several versions", tm.Kind)
+	}
+	from, to := indexOf(chain, tm.APIVersion), indexOf(chain, desiredAPIVersion)
+	if from < 0 {
+		return nil, fmt.Errorf("unknown version of %s: %s", tm.Kind, tm.APIVersion)
+	}
+	if to < 0 {
+		return nil, fmt.Errorf("unknown version of %s: %s", tm.Kind, desiredAPIVersion)
+	}
+
+	obj, err := newObject(chain[from].WithKind(tm.Kind))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return nil, fmt.Errorf("failed to decode the %s %s: %w", tm.APIVersion, tm.Kind, err)
+	}
+	for ; from < to; from++ {
+		next, err := newObject(chain[from+1].WithKind(tm.Kind))
+		if err != nil {
+			return nil, err
+		}
+		if err := obj.ConvertUp(ctx, next); err != nil {
+			return nil, err
+		}
+		obj = next
+	}
+	for ; from > to; from-- {
+		previous, err := newObject(chain[from-1].WithKind(tm.Kind))
+		if err != nil {
+			return nil, err
+		}
+		if err := previous.ConvertDown(ctx, obj); err != nil {
+			return nil, err
+		}
+		obj = previous
+	}
+	obj.(runtime.Object).GetObjectKind().SetGroupVersionKind(chain[to].WithKind(tm.Kind))
+	return json.Marshal(obj)
+}
+
+func indexOf(chain []schema.GroupVersion, apiVersion string) int {
+	for i, gv := range chain {
+		if gv.String() == apiVersion {
+			return i
+		}
+	}
+	return -1
+}
+
+func newObject(gvk schema.GroupVersionKind) (apis.Convertible, error) {
+	obj, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := obj.(apis.Convertible)
+	if !ok {
+		return nil, fmt.Errorf("%s can't be converted", gvk)
+	}
+	return c, nil
+}
+
+// handler serves the ConversionReviews of the API server.
+type handler struct {
+	logger *zap.SugaredLogger
+}
+
+// NewHandler returns the handler of the conversion webhook.
+func NewHandler(logger *zap.SugaredLogger) http.Handler {
+	return &handler{logger: logger}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var in review
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		http.Error(w, fmt.Sprintf("could not decode body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if in.Request == nil {
+		http.Error(w, "the ConversionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	resp := &response{
+		UID:    in.Request.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, obj := range in.Request.Objects {
+		converted, err := Convert(r.Context(), obj.Raw, in.Request.DesiredAPIVersion)
+		if err != nil {
+			h.logger.Errorw("Failed to convert an object to "+in.Request.DesiredAPIVersion, zap.Error(err))
+			resp.ConvertedObjects = nil
+			resp.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+
+	out := review{
+		TypeMeta: metav1.TypeMeta{APIVersion: reviewAPIVersion, Kind: "ConversionReview"},
+		Response: resp,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		http.Error(w, fmt.Sprintf("could not encode response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// Register sets caCert as the CA bundle of the conversion webhook of the
+// CRDs, so that the API server trusts the certificate the webhook serves.
+// patch applies a JSON merge patch to the CustomResourceDefinition name.
+func Register(patch func(name string, data []byte) error, caCert []byte) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"conversion": map[string]interface{}{
+				"webhookClientConfig": map[string]interface{}{
+					"caBundle": caCert,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range CRDs {
+		if err := patch(name, data); err != nil {
+			return fmt.Errorf("failed to register the conversion webhook of %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+var v1alpha1Task = &v1alpha1.Task{
+	TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1alpha1", Kind: "Task"},
+	ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo"},
+	Spec: v1alpha1.TaskSpec{
+		Inputs: &v1alpha1.Inputs{Params: []v1alpha1.ParamSpec{{Name: "package", Type: v1alpha1.ParamTypeString}}},
+		Steps:  []v1alpha1.Step{{Container: corev1.Container{Name: "build", Image: "golang"}}},
+		// Workspaces are missing from v1alpha2, they're kept in an
+		// annotation.
+		Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "source"}},
+	},
+}
+
+func mustMarshal(t *testing.T, obj interface{}) []byte {
+	t.Helper()
+	b, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestConvert(t *testing.T) {
+	ctx := context.Background()
+	up, err := Convert(ctx, mustMarshal(t, v1alpha1Task), "tekton.dev/v1alpha2")
+	if err != nil {
+		t.Fatalf("Convert() to v1alpha2 = %v", err)
+	}
+	var task v1alpha2.Task
+	if err := json.Unmarshal(up, &task); err != nil {
+		t.Fatal(err)
+	}
+	if task.APIVersion != "tekton.dev/v1alpha2" || task.Kind != "Task" {
+		t.Errorf("Converted object is a %s %s, want a tekton.dev/v1alpha2 Task", task.APIVersion, task.Kind)
+	}
+	if len(task.Spec.Params) != 1 || task.Annotations[v1alpha1.TaskSpecAnnotationKey] == "" {
+		t.Errorf("Unexpected v1alpha2 Task: %+v", task)
+	}
+
+	down, err := Convert(ctx, up, "tekton.dev/v1alpha1")
+	if err != nil {
+		t.Fatalf("Convert() back to v1alpha1 = %v", err)
+	}
+	var got v1alpha1.Task
+	if err := json.Unmarshal(down, &got); err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff(v1alpha1Task, &got); d != "" {
+		t.Errorf("Task changed converting it to v1alpha2 and back (-want, +got): %s", d)
+	}
+
+	same, err := Convert(ctx, mustMarshal(t, v1alpha1Task), "tekton.dev/v1alpha1")
+	if err != nil {
+		t.Fatalf("Convert() to v1alpha1 = %v", err)
+	}
+	if d := cmp.Diff(string(mustMarshal(t, v1alpha1Task)), string(same)); d != "" {
+		t.Errorf("Task changed converting it to its own version (-want, +got): %s", d)
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		raw     string
+		desired string
+	}{{
+		name:    "kind with a single version",
+		raw:     `{"apiVersion": "tekton.dev/v1alpha1", "kind": "TaskRun"}`,
+		desired: "tekton.dev/v1alpha2",
+	}, {
+		name:    "unknown version",
+		raw:     `{"apiVersion": "tekton.dev/v1alpha3", "kind": "Task"}`,
+		desired: "tekton.dev/v1alpha1",
+	}, {
+		name:    "unknown desired version",
+		raw:     `{"apiVersion": "tekton.dev/v1alpha1", "kind": "Task"}`,
+		desired: "tekton.dev/v1beta1",
+	}, {
+		name:    "invalid JSON",
+		raw:     `{"apiVersion": "tekton.dev/v1alpha1", "kind": "Task", "spec": []}`,
+		desired: "tekton.dev/v1alpha2",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Convert(context.Background(), []byte(tc.raw), tc.desired); err == nil {
+				t.Error("Expected an error converting the object")
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		objects    []runtime.RawExtension
+		wantStatus string
+		wantKinds  []string
+	}{{
+		name:       "converted",
+		objects:    []runtime.RawExtension{{Raw: mustMarshal(t, v1alpha1Task)}, {Raw: mustMarshal(t, v1alpha1Task)}},
+		wantStatus: metav1.StatusSuccess,
+		wantKinds:  []string{"tekton.dev/v1alpha2", "tekton.dev/v1alpha2"},
+	}, {
+		name:       "failed",
+		objects:    []runtime.RawExtension{{Raw: mustMarshal(t, v1alpha1Task)}, {Raw: []byte(`{"apiVersion": "tekton.dev/v1alpha1", "kind": "Pipeline"}`)}},
+		wantStatus: metav1.StatusFailure,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			body := mustMarshal(t, review{
+				TypeMeta: metav1.TypeMeta{APIVersion: reviewAPIVersion, Kind: "ConversionReview"},
+				Request: &request{
+					UID:               "1234",
+					DesiredAPIVersion: "tekton.dev/v1alpha2",
+					Objects:           tc.objects,
+				},
+			})
+			w := httptest.NewRecorder()
+			NewHandler(logtesting.TestLogger(t)).ServeHTTP(w, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body)))
+			if w.Code != http.StatusOK {
+				t.Fatalf("Unexpected status %d: %s", w.Code, w.Body)
+			}
+
+			var out review
+			if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+				t.Fatal(err)
+			}
+			if out.Response == nil || out.Response.UID != "1234" {
+				t.Fatalf("Unexpected response: %+v", out.Response)
+			}
+			if out.Response.Result.Status != tc.wantStatus {
+				t.Errorf("Result status = %s, want %s: %s", out.Response.Result.Status, tc.wantStatus, out.Response.Result.Message)
+			}
+			var kinds []string
+			for _, obj := range out.Response.ConvertedObjects {
+				var tm metav1.TypeMeta
+				if err := json.Unmarshal(obj.Raw, &tm); err != nil {
+					t.Fatal(err)
+				}
+				kinds = append(kinds, tm.APIVersion)
+			}
+			if d := cmp.Diff(tc.wantKinds, kinds); d != "" {
+				t.Errorf("Converted objects -want +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	patches := map[string]string{}
+	patch := func(name string, data []byte) error {
+		patches[name] = string(data)
+		return nil
+	}
+	if err := Register(patch, []byte("ca")); err != nil {
+		t.Fatalf("Register() = %v", err)
+	}
+	want := map[string]string{
+		"tasks.tekton.dev": `{"spec":{"conversion":{"webhookClientConfig":{"caBundle":"Y2E="}}}}`,
+	}
+	if d := cmp.Diff(want, patches); d != "" {
+		t.Errorf("Patches -want +got: %s", d)
+	}
+}
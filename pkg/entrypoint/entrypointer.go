@@ -17,9 +17,44 @@ limitations under the License.
 package entrypoint
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 )
 
+// ErrSkipPreviousStepFailed is returned by a Waiter when the step it waits
+// for failed, so that the step waiting for it is skipped.
+var ErrSkipPreviousStepFailed = errors.New("error file present, bail and skip the step")
+
+// ErrWaitTimeout is returned by a Waiter when the file it waits for didn't
+// appear in time.
+var ErrWaitTimeout = errors.New("timed out waiting for the file")
+
+// ErrStepTimeout is returned when the command was killed because it exceeded
+// the timeout of the step.
+var ErrStepTimeout = errors.New("step timed out")
+
+// AttemptEnvVar is the environment variable that tells the command which
+// attempt of the step it is, starting at 1.
+const AttemptEnvVar = "TEKTON_STEP_ATTEMPT"
+
+// DeadlineEnvVar is the environment variable that tells the command the time,
+// in RFC3339, it is killed at because the TaskRun or the step times out,
+// whichever comes first. The Pod sets it to the deadline of the TaskRun.
+const DeadlineEnvVar = "TEKTON_DEADLINE"
+
+// RemainingSecondsEnvVar is the environment variable that tells the command
+// how many seconds it has left until DeadlineEnvVar, as of when it started.
+const RemainingSecondsEnvVar = "TEKTON_REMAINING_SECONDS"
+
 // Entrypointer holds fields for running commands with redirected
 // entrypoints.
 type Entrypointer struct {
@@ -36,6 +71,29 @@ type Entrypointer struct {
 	// PostFile is the file to write when complete. If not specified, no
 	// file is written.
 	PostFile string
+	// AlwaysRun runs the command even if a previous step failed, once it
+	// completed. The post file still signals the failure to the next steps.
+	AlwaysRun bool
+	// Retries is how many times the command is run again if it fails.
+	Retries int
+	// RetryBackoff is how long to wait before the first retry, doubled for
+	// each of the next ones.
+	RetryBackoff time.Duration
+	// Timeout is how long the command can run for, retries included, before
+	// it is killed. It is not limited if 0.
+	Timeout time.Duration
+	// OnError is what to do when the command fails, retries and timeout
+	// included. If it is v1alpha1.StepOnErrorContinue, the failure is
+	// logged, the results are still reported and the post file signals the
+	// next steps to run.
+	OnError v1alpha1.OnErrorType
+	// Deadline is the time the TaskRun times out at. It is not known if
+	// zero.
+	Deadline time.Time
+	// Results are the paths of the files the steps write the results of the
+	// Task to. Those that exist once the command succeeded, or failed and
+	// OnError continues, are reported.
+	Results []string
 
 	// Waiter encapsulates waiting for files to exist.
 	Waiter Waiter
@@ -43,6 +101,8 @@ type Entrypointer struct {
 	Runner Runner
 	// PostWriter encapsulates writing files when complete.
 	PostWriter PostWriter
+	// ResultsWriter encapsulates reporting results.
+	ResultsWriter ResultsWriter
 }
 
 // Waiter encapsulates waiting for files to exist.
@@ -53,7 +113,8 @@ type Waiter interface {
 
 // Runner encapsulates running commands.
 type Runner interface {
-	Run(args ...string) error
+	// Run runs the command, killing it once ctx is done.
+	Run(ctx context.Context, args ...string) error
 }
 
 // PostWriter encapsulates writing a file when complete.
@@ -62,11 +123,22 @@ type PostWriter interface {
 	Write(file string)
 }
 
-// Go optionally waits for a file, runs the command, and writes a
-// post file.
+// ResultsWriter encapsulates reporting the results of a Task.
+type ResultsWriter interface {
+	// Write adds the results to the termination message.
+	Write(results []v1alpha1.PipelineResourceResult) error
+}
+
+// Go optionally waits for a file, runs the command, retrying it if it fails,
+// reports results, and writes a post file.
 func (e Entrypointer) Go() error {
+	var previousStepFailed error
 	for _, f := range e.WaitFiles {
 		if err := e.Waiter.Wait(f, e.WaitFileContent); err != nil {
+			if e.AlwaysRun && errors.Is(err, ErrSkipPreviousStepFailed) {
+				previousStepFailed = err
+				continue
+			}
 			// An error happened while waiting, so we bail
 			// *but* we write postfile to make next steps bail too.
 			e.WritePostFile(e.PostFile, err)
@@ -78,14 +150,75 @@ func (e Entrypointer) Go() error {
 		e.Args = append([]string{e.Entrypoint}, e.Args...)
 	}
 
-	err := e.Runner.Run(e.Args...)
+	err := e.run()
+	if err != nil && e.OnError == v1alpha1.StepOnErrorContinue {
+		log.Printf("The step failed, continuing: %v", err)
+		err = nil
+	}
+	if err == nil && len(e.Results) > 0 {
+		err = e.writeResults()
+	}
 
-	// Write the post file *no matter what*
-	e.WritePostFile(e.PostFile, err)
+	// Write the post file *no matter what*. The steps after an always-run
+	// step still bail if a step before it failed.
+	if err == nil && previousStepFailed != nil {
+		e.WritePostFile(e.PostFile, previousStepFailed)
+	} else {
+		e.WritePostFile(e.PostFile, err)
+	}
 
 	return err
 }
 
+// run runs the command until it succeeds, was retried Retries times or
+// exceeded the Timeout.
+func (e Entrypointer) run() error {
+	ctx := context.Background()
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+	deadline := e.Deadline
+	if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		deadline = d
+	}
+	backoff := e.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		if err := os.Setenv(AttemptEnvVar, strconv.Itoa(attempt)); err != nil {
+			return err
+		}
+		if !deadline.IsZero() {
+			if err := setDeadlineEnv(deadline); err != nil {
+				return err
+			}
+		}
+		err := e.Runner.Run(ctx, e.Args...)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %s", ErrStepTimeout, e.Timeout)
+		}
+		if err == nil || attempt > e.Retries {
+			return err
+		}
+		log.Printf("Attempt %d of the step failed, retrying in %s: %v", attempt, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// setDeadlineEnv tells the command about to run the time it is killed at, and
+// how many seconds it has left until then.
+func setDeadlineEnv(deadline time.Time) error {
+	if err := os.Setenv(DeadlineEnvVar, deadline.UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	remaining := int(time.Until(deadline).Seconds())
+	if remaining < 0 {
+		remaining = 0
+	}
+	return os.Setenv(RemainingSecondsEnvVar, strconv.Itoa(remaining))
+}
+
 func (e Entrypointer) WritePostFile(postFile string, err error) {
 	if err != nil && postFile != "" {
 		postFile = fmt.Sprintf("%s.err", postFile)
@@ -94,3 +227,26 @@ func (e Entrypointer) WritePostFile(postFile string, err error) {
 		e.PostWriter.Write(postFile)
 	}
 }
+
+// writeResults reports the content of each of the result files that exist.
+func (e Entrypointer) writeResults() error {
+	results := []v1alpha1.PipelineResourceResult{}
+	for _, path := range e.Results {
+		b, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading result %s: %w", path, err)
+		}
+		results = append(results, v1alpha1.PipelineResourceResult{
+			Key:        filepath.Base(path),
+			Value:      string(b),
+			ResultType: v1alpha1.TaskRunResultType,
+		})
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return e.ResultsWriter.Write(results)
+}
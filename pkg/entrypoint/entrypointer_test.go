@@ -17,11 +17,19 @@ limitations under the License.
 package entrypoint
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 )
 
 func TestEntrypointerFailures(t *testing.T) {
@@ -177,6 +185,335 @@ func TestEntrypointer(t *testing.T) {
 	}
 }
 
+func TestEntrypointerAlwaysRun(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		alwaysRun    bool
+		runner       Runner
+		wantRun      bool
+		wantError    error
+		wantPostFile string
+	}{{
+		desc:         "skipped after a failed step",
+		runner:       &fakeRunner{},
+		wantError:    ErrSkipPreviousStepFailed,
+		wantPostFile: "writeme.err",
+	}, {
+		desc:         "always-run after a failed step",
+		alwaysRun:    true,
+		runner:       &fakeRunner{},
+		wantRun:      true,
+		wantPostFile: "writeme.err",
+	}, {
+		desc:         "failing always-run after a failed step",
+		alwaysRun:    true,
+		runner:       &fakeErrorRunner{},
+		wantRun:      true,
+		wantError:    errors.New("runner failed"),
+		wantPostFile: "writeme.err",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fpw := &fakePostWriter{}
+			err := Entrypointer{
+				Entrypoint: "echo",
+				WaitFiles:  []string{"waitforme"},
+				PostFile:   "writeme",
+				AlwaysRun:  c.alwaysRun,
+				Waiter:     &fakeSkipWaiter{},
+				Runner:     c.runner,
+				PostWriter: fpw,
+			}.Go()
+			if d := cmp.Diff(fmt.Sprint(c.wantError), fmt.Sprint(err)); d != "" {
+				t.Errorf("Entrypointer error diff -want, +got: %v", d)
+			}
+
+			var ran bool
+			switch r := c.runner.(type) {
+			case *fakeRunner:
+				ran = r.args != nil
+			case *fakeErrorRunner:
+				ran = r.args != nil
+			}
+			if ran != c.wantRun {
+				t.Errorf("Ran command: %t, want %t", ran, c.wantRun)
+			}
+
+			if fpw.wrote == nil {
+				t.Error("Wanted post file written, got nil")
+			} else if *fpw.wrote != c.wantPostFile {
+				t.Errorf("Wrote post file %q, want %q", *fpw.wrote, c.wantPostFile)
+			}
+		})
+	}
+}
+
+func TestEntrypointerResults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "results")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "commit"), []byte("abc123"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		desc        string
+		runner      Runner
+		onError     v1alpha1.OnErrorType
+		wantResults []v1alpha1.PipelineResourceResult
+	}{{
+		desc:   "results that exist are reported",
+		runner: &fakeRunner{},
+		wantResults: []v1alpha1.PipelineResourceResult{{
+			Key:        "commit",
+			Value:      "abc123",
+			ResultType: v1alpha1.TaskRunResultType,
+		}},
+	}, {
+		desc:   "no results if the command failed",
+		runner: &fakeErrorRunner{},
+	}, {
+		desc:    "results reported if the command failed and the step continues",
+		runner:  &fakeErrorRunner{},
+		onError: v1alpha1.StepOnErrorContinue,
+		wantResults: []v1alpha1.PipelineResourceResult{{
+			Key:        "commit",
+			Value:      "abc123",
+			ResultType: v1alpha1.TaskRunResultType,
+		}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			frw := &fakeResultsWriter{}
+			_ = Entrypointer{
+				Entrypoint:    "echo",
+				Results:       []string{filepath.Join(dir, "commit"), filepath.Join(dir, "digest")},
+				OnError:       c.onError,
+				Waiter:        &fakeWaiter{},
+				Runner:        c.runner,
+				PostWriter:    &fakePostWriter{},
+				ResultsWriter: frw,
+			}.Go()
+			if d := cmp.Diff(c.wantResults, frw.results); d != "" {
+				t.Errorf("Results diff -want, +got: %v", d)
+			}
+		})
+	}
+}
+
+func TestEntrypointerRetries(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		retries      int
+		failures     int
+		wantAttempts []string
+		wantError    error
+		wantPostFile string
+	}{{
+		desc:         "no retries",
+		failures:     1,
+		wantAttempts: []string{"1"},
+		wantError:    errors.New("attempt 1 failed"),
+		wantPostFile: "writeme.err",
+	}, {
+		desc:         "succeeds on a retry",
+		retries:      3,
+		failures:     2,
+		wantAttempts: []string{"1", "2", "3"},
+		wantPostFile: "writeme",
+	}, {
+		desc:         "fails every retry",
+		retries:      2,
+		failures:     5,
+		wantAttempts: []string{"1", "2", "3"},
+		wantError:    errors.New("attempt 3 failed"),
+		wantPostFile: "writeme.err",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fr := &fakeFlakyRunner{failures: c.failures}
+			fpw := &fakePostWriter{}
+			err := Entrypointer{
+				Entrypoint:   "echo",
+				PostFile:     "writeme",
+				Retries:      c.retries,
+				RetryBackoff: time.Millisecond,
+				Waiter:       &fakeWaiter{},
+				Runner:       fr,
+				PostWriter:   fpw,
+			}.Go()
+			if d := cmp.Diff(fmt.Sprint(c.wantError), fmt.Sprint(err)); d != "" {
+				t.Errorf("Entrypointer error diff -want, +got: %v", d)
+			}
+			if d := cmp.Diff(c.wantAttempts, fr.attempts); d != "" {
+				t.Errorf("Attempts diff -want, +got: %v", d)
+			}
+			if fpw.wrote == nil {
+				t.Error("Wanted post file written, got nil")
+			} else if *fpw.wrote != c.wantPostFile {
+				t.Errorf("Wrote post file %q, want %q", *fpw.wrote, c.wantPostFile)
+			}
+		})
+	}
+}
+
+func TestEntrypointerTimeout(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		retries      int
+		runner       Runner
+		wantError    error
+		wantPostFile string
+	}{{
+		desc:         "command completes in time",
+		runner:       &fakeRunner{},
+		wantPostFile: "writeme",
+	}, {
+		desc:         "command killed",
+		runner:       &fakeBlockingRunner{},
+		wantError:    ErrStepTimeout,
+		wantPostFile: "writeme.err",
+	}, {
+		desc:         "retries killed",
+		retries:      100,
+		runner:       &fakeFlakyRunner{failures: 1000},
+		wantError:    ErrStepTimeout,
+		wantPostFile: "writeme.err",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fpw := &fakePostWriter{}
+			err := Entrypointer{
+				Entrypoint:   "echo",
+				PostFile:     "writeme",
+				Retries:      c.retries,
+				RetryBackoff: time.Millisecond,
+				Timeout:      10 * time.Millisecond,
+				Waiter:       &fakeWaiter{},
+				Runner:       c.runner,
+				PostWriter:   fpw,
+			}.Go()
+			if c.wantError == nil && err != nil {
+				t.Errorf("Entrypointer failed: %v", err)
+			} else if c.wantError != nil && !errors.Is(err, c.wantError) {
+				t.Errorf("Entrypointer returned %v, want %v", err, c.wantError)
+			}
+			if fpw.wrote == nil {
+				t.Error("Wanted post file written, got nil")
+			} else if *fpw.wrote != c.wantPostFile {
+				t.Errorf("Wrote post file %q, want %q", *fpw.wrote, c.wantPostFile)
+			}
+		})
+	}
+}
+
+func TestEntrypointerOnError(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		onError      v1alpha1.OnErrorType
+		runner       Runner
+		timeout      time.Duration
+		wantError    bool
+		wantPostFile string
+	}{{
+		desc:         "stop and fail",
+		onError:      v1alpha1.StepOnErrorStopAndFail,
+		runner:       &fakeErrorRunner{},
+		wantError:    true,
+		wantPostFile: "writeme.err",
+	}, {
+		desc:         "continue",
+		onError:      v1alpha1.StepOnErrorContinue,
+		runner:       &fakeErrorRunner{},
+		wantPostFile: "writeme",
+	}, {
+		desc:         "continue once killed",
+		onError:      v1alpha1.StepOnErrorContinue,
+		runner:       &fakeBlockingRunner{},
+		timeout:      10 * time.Millisecond,
+		wantPostFile: "writeme",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fpw := &fakePostWriter{}
+			err := Entrypointer{
+				Entrypoint: "echo",
+				PostFile:   "writeme",
+				Timeout:    c.timeout,
+				OnError:    c.onError,
+				Waiter:     &fakeWaiter{},
+				Runner:     c.runner,
+				PostWriter: fpw,
+			}.Go()
+			if c.wantError && err == nil {
+				t.Error("Entrypointer succeeded, want an error")
+			} else if !c.wantError && err != nil {
+				t.Errorf("Entrypointer failed: %v", err)
+			}
+			if fpw.wrote == nil {
+				t.Error("Wanted post file written, got nil")
+			} else if *fpw.wrote != c.wantPostFile {
+				t.Errorf("Wrote post file %q, want %q", *fpw.wrote, c.wantPostFile)
+			}
+		})
+	}
+}
+
+func TestEntrypointerDeadline(t *testing.T) {
+	deadline := time.Now().Add(time.Hour).Truncate(time.Second)
+	for _, c := range []struct {
+		desc         string
+		deadline     time.Time
+		timeout      time.Duration
+		wantDeadline string
+		wantMinimum  int
+		wantMaximum  int
+	}{{
+		desc: "no deadline",
+	}, {
+		desc:         "deadline of the TaskRun",
+		deadline:     deadline,
+		wantDeadline: deadline.UTC().Format(time.RFC3339),
+		wantMinimum:  3500,
+		wantMaximum:  3600,
+	}, {
+		desc:        "step times out first",
+		deadline:    deadline,
+		timeout:     time.Minute,
+		wantMinimum: 50,
+		wantMaximum: 60,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			os.Unsetenv(DeadlineEnvVar)
+			os.Unsetenv(RemainingSecondsEnvVar)
+			fr := &fakeEnvRunner{names: []string{DeadlineEnvVar, RemainingSecondsEnvVar}}
+			if err := (Entrypointer{
+				Entrypoint: "echo",
+				Deadline:   c.deadline,
+				Timeout:    c.timeout,
+				Waiter:     &fakeWaiter{},
+				Runner:     fr,
+				PostWriter: &fakePostWriter{},
+			}.Go()); err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+			if c.deadline.IsZero() {
+				if d := cmp.Diff(map[string]string{}, fr.env); d != "" {
+					t.Errorf("Env diff -want, +got: %v", d)
+				}
+				return
+			}
+			if c.wantDeadline != "" && fr.env[DeadlineEnvVar] != c.wantDeadline {
+				t.Errorf("%s is %q, want %q", DeadlineEnvVar, fr.env[DeadlineEnvVar], c.wantDeadline)
+			}
+			if _, err := time.Parse(time.RFC3339, fr.env[DeadlineEnvVar]); err != nil {
+				t.Errorf("%s is not RFC3339: %v", DeadlineEnvVar, err)
+			}
+			remaining, err := strconv.Atoi(fr.env[RemainingSecondsEnvVar])
+			if err != nil || remaining < c.wantMinimum || remaining > c.wantMaximum {
+				t.Errorf("%s is %q, want between %d and %d", RemainingSecondsEnvVar, fr.env[RemainingSecondsEnvVar], c.wantMinimum, c.wantMaximum)
+			}
+		})
+	}
+}
+
 type fakeWaiter struct{ waited []string }
 
 func (f *fakeWaiter) Wait(file string, _ bool) error {
@@ -186,7 +523,7 @@ func (f *fakeWaiter) Wait(file string, _ bool) error {
 
 type fakeRunner struct{ args *[]string }
 
-func (f *fakeRunner) Run(args ...string) error {
+func (f *fakeRunner) Run(_ context.Context, args ...string) error {
 	f.args = &args
 	return nil
 }
@@ -195,6 +532,15 @@ type fakePostWriter struct{ wrote *string }
 
 func (f *fakePostWriter) Write(file string) { f.wrote = &file }
 
+type fakeResultsWriter struct {
+	results []v1alpha1.PipelineResourceResult
+}
+
+func (f *fakeResultsWriter) Write(results []v1alpha1.PipelineResourceResult) error {
+	f.results = append(f.results, results...)
+	return nil
+}
+
 type fakeErrorWaiter struct{ waited *string }
 
 func (f *fakeErrorWaiter) Wait(file string, expectContent bool) error {
@@ -202,9 +548,53 @@ func (f *fakeErrorWaiter) Wait(file string, expectContent bool) error {
 	return errors.New("waiter failed")
 }
 
+type fakeSkipWaiter struct{}
+
+func (f *fakeSkipWaiter) Wait(string, bool) error { return ErrSkipPreviousStepFailed }
+
 type fakeErrorRunner struct{ args *[]string }
 
-func (f *fakeErrorRunner) Run(args ...string) error {
+func (f *fakeErrorRunner) Run(_ context.Context, args ...string) error {
 	f.args = &args
 	return errors.New("runner failed")
 }
+
+// fakeFlakyRunner fails the first failures runs, and records the attempt each
+// run was told it is.
+type fakeFlakyRunner struct {
+	failures int
+	attempts []string
+}
+
+func (f *fakeFlakyRunner) Run(_ context.Context, args ...string) error {
+	attempt := os.Getenv(AttemptEnvVar)
+	f.attempts = append(f.attempts, attempt)
+	if len(f.attempts) <= f.failures {
+		return fmt.Errorf("attempt %s failed", attempt)
+	}
+	return nil
+}
+
+// fakeEnvRunner records the env vars of names that are set when it runs.
+type fakeEnvRunner struct {
+	names []string
+	env   map[string]string
+}
+
+func (f *fakeEnvRunner) Run(_ context.Context, _ ...string) error {
+	f.env = map[string]string{}
+	for _, n := range f.names {
+		if v, ok := os.LookupEnv(n); ok {
+			f.env[n] = v
+		}
+	}
+	return nil
+}
+
+// fakeBlockingRunner runs until it is killed.
+type fakeBlockingRunner struct{}
+
+func (f *fakeBlockingRunner) Run(ctx context.Context, _ ...string) error {
+	<-ctx.Done()
+	return errors.New("signal: killed")
+}
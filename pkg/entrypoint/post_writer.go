@@ -0,0 +1,21 @@
+package entrypoint
+
+import (
+	"log"
+	"os"
+)
+
+// FilePostWriter writes the post files on the local filesystem.
+type FilePostWriter struct{}
+
+var _ PostWriter = (*FilePostWriter)(nil)
+
+// Write creates file, exiting the process if it can't.
+func (*FilePostWriter) Write(file string) {
+	if file == "" {
+		return
+	}
+	if _, err := os.Create(file); err != nil {
+		log.Fatalf("Creating %q: %v", file, err)
+	}
+}
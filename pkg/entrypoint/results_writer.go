@@ -0,0 +1,19 @@
+package entrypoint
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/termination"
+)
+
+// TerminationResultsWriter adds results to the termination message written
+// at Path, where the controller reads them from.
+type TerminationResultsWriter struct {
+	Path string
+}
+
+var _ ResultsWriter = (*TerminationResultsWriter)(nil)
+
+// Write appends results to the termination message.
+func (w *TerminationResultsWriter) Write(results []v1alpha1.PipelineResourceResult) error {
+	return termination.AppendResults(w.Path, results)
+}
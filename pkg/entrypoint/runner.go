@@ -0,0 +1,41 @@
+package entrypoint
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+)
+
+// CommandRunner runs the commands as sub-processes.
+type CommandRunner struct {
+	// Stdout and Stderr receive the output of the commands, os.Stdout and
+	// os.Stderr if nil.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+var _ Runner = (*CommandRunner)(nil)
+
+// Run runs the command args[0] with the arguments args[1:], killing it
+// when ctx is done. An empty args is a no-op.
+func (r *CommandRunner) Run(ctx context.Context, args ...string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	name, args := args[0], args[1:]
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout, cmd.Stderr = r.Stdout, r.Stderr
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"testing"
+)
+
+func TestCommandRunner(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	r := CommandRunner{Stdout: &stdout, Stderr: &stderr}
+	if err := r.Run(context.Background(), "sh", "-c", "echo out; echo err >&2"); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got, want := stdout.String(), "out\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+	if got, want := stderr.String(), "err\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestCommandRunnerExitCode(t *testing.T) {
+	r := CommandRunner{}
+	err := r.Run(context.Background(), "sh", "-c", "exit 3")
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		t.Fatalf("expected an *exec.ExitError, got %v", err)
+	}
+	if got := exitErr.ExitCode(); got != 3 {
+		t.Errorf("exit code = %d, want 3", got)
+	}
+}
+
+func TestCommandRunnerNoArgs(t *testing.T) {
+	r := CommandRunner{}
+	if err := r.Run(context.Background()); err != nil {
+		t.Errorf("expected no error without a command, got %v", err)
+	}
+}
@@ -1,17 +1,20 @@
-package main
+package entrypoint
 
 import (
 	"fmt"
 	"os"
 	"time"
-
-	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
-// realWaiter actually waits for files, by polling.
-type realWaiter struct{}
+var waitPollingInterval = time.Second
+
+// FileWaiter waits for files on the local filesystem, by polling.
+type FileWaiter struct {
+	// Timeout is how long Wait waits for a file, forever if 0.
+	Timeout time.Duration
+}
 
-var _ entrypoint.Waiter = (*realWaiter)(nil)
+var _ Waiter = (*FileWaiter)(nil)
 
 // Wait watches a file and returns when either a) the file exists and, if
 // the expectContent argument is true, the file has non-zero size or b) there
@@ -21,11 +24,16 @@ var _ entrypoint.Waiter = (*realWaiter)(nil)
 // immediately.
 //
 // If a file of the same name with a ".err" extension exists then this Wait
-// will end with a skipError.
-func (*realWaiter) Wait(file string, expectContent bool) error {
+// will end with ErrSkipPreviousStepFailed. If the file isn't there
+// after the timeout of the waiter, it ends with ErrWaitTimeout.
+func (rw *FileWaiter) Wait(file string, expectContent bool) error {
 	if file == "" {
 		return nil
 	}
+	var deadline time.Time
+	if rw.Timeout > 0 {
+		deadline = time.Now().Add(rw.Timeout)
+	}
 	for ; ; time.Sleep(waitPollingInterval) {
 		if info, err := os.Stat(file); err == nil {
 			if !expectContent || info.Size() > 0 {
@@ -35,13 +43,10 @@ func (*realWaiter) Wait(file string, expectContent bool) error {
 			return fmt.Errorf("waiting for %q: %w", file, err)
 		}
 		if _, err := os.Stat(file + ".err"); err == nil {
-			return skipError("error file present, bail and skip the step")
+			return ErrSkipPreviousStepFailed
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return fmt.Errorf("waiting %s for %q: %w", rw.Timeout, file, ErrWaitTimeout)
 		}
 	}
 }
-
-type skipError string
-
-func (e skipError) Error() string {
-	return string(e)
-}
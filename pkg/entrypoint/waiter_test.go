@@ -14,16 +14,17 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package main
+package entrypoint
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"testing"
 	"time"
 )
 
-func TestRealWaiterWaitMissingFile(t *testing.T) {
+func TestFileWaiterWaitMissingFile(t *testing.T) {
 	// Create a temp file and then immediately delete it to get
 	// a legitimate tmp path and ensure the file doesnt exist
 	// prior to testing Wait().
@@ -32,7 +33,7 @@ func TestRealWaiterWaitMissingFile(t *testing.T) {
 		t.Errorf("error creating temp file: %v", err)
 	}
 	os.Remove(tmp.Name())
-	rw := realWaiter{}
+	rw := FileWaiter{}
 	doneCh := make(chan struct{})
 	go func() {
 		err := rw.Wait(tmp.Name(), false)
@@ -49,13 +50,13 @@ func TestRealWaiterWaitMissingFile(t *testing.T) {
 	}
 }
 
-func TestRealWaiterWaitWithFile(t *testing.T) {
+func TestFileWaiterWaitWithFile(t *testing.T) {
 	tmp, err := ioutil.TempFile("", "real_waiter_test_file")
 	if err != nil {
 		t.Errorf("error creating temp file: %v", err)
 	}
 	defer os.Remove(tmp.Name())
-	rw := realWaiter{}
+	rw := FileWaiter{}
 	doneCh := make(chan struct{})
 	go func() {
 		err := rw.Wait(tmp.Name(), false)
@@ -72,13 +73,13 @@ func TestRealWaiterWaitWithFile(t *testing.T) {
 	}
 }
 
-func TestRealWaiterWaitMissingContent(t *testing.T) {
+func TestFileWaiterWaitMissingContent(t *testing.T) {
 	tmp, err := ioutil.TempFile("", "real_waiter_test_file")
 	if err != nil {
 		t.Errorf("error creating temp file: %v", err)
 	}
 	defer os.Remove(tmp.Name())
-	rw := realWaiter{}
+	rw := FileWaiter{}
 	doneCh := make(chan struct{})
 	go func() {
 		err := rw.Wait(tmp.Name(), true)
@@ -95,13 +96,13 @@ func TestRealWaiterWaitMissingContent(t *testing.T) {
 	}
 }
 
-func TestRealWaiterWaitWithContent(t *testing.T) {
+func TestFileWaiterWaitWithContent(t *testing.T) {
 	tmp, err := ioutil.TempFile("", "real_waiter_test_file")
 	if err != nil {
 		t.Errorf("error creating temp file: %v", err)
 	}
 	defer os.Remove(tmp.Name())
-	rw := realWaiter{}
+	rw := FileWaiter{}
 	doneCh := make(chan struct{})
 	go func() {
 		err := rw.Wait(tmp.Name(), true)
@@ -120,3 +121,24 @@ func TestRealWaiterWaitWithContent(t *testing.T) {
 		t.Errorf("expected Wait() to have detected a non-zero file size by now")
 	}
 }
+
+func TestFileWaiterWaitTimeout(t *testing.T) {
+	tmp, err := ioutil.TempFile("", "real_waiter_test_file")
+	if err != nil {
+		t.Errorf("error creating temp file: %v", err)
+	}
+	os.Remove(tmp.Name())
+	rw := FileWaiter{Timeout: waitPollingInterval}
+	doneCh := make(chan error)
+	go func() {
+		doneCh <- rw.Wait(tmp.Name(), false)
+	}()
+	select {
+	case err := <-doneCh:
+		if !errors.Is(err, ErrWaitTimeout) {
+			t.Errorf("expected Wait() to time out, got %v", err)
+		}
+	case <-time.After(4 * waitPollingInterval):
+		t.Errorf("expected Wait() to have timed out by now")
+	}
+}
@@ -18,8 +18,10 @@ package git
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -49,6 +51,12 @@ type FetchSpec struct {
 	Revision string
 	Path     string
 	Depth    uint
+	// Refspec is fetched instead of the revision, which is then checked out.
+	// Several refspecs can be separated by spaces.
+	Refspec string
+	// SparseCheckoutDirectories is a comma separated list of the directories
+	// to check out, all of them if empty.
+	SparseCheckoutDirectories string
 }
 
 // Fetch fetches the specified git repository at the revision into path.
@@ -74,12 +82,27 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 	if _, err := run(logger, "", "remote", "add", "origin", trimmedURL); err != nil {
 		return err
 	}
+	if spec.SparseCheckoutDirectories != "" {
+		if err := configSparseCheckout(logger, spec.SparseCheckoutDirectories); err != nil {
+			return err
+		}
+	}
 
 	fetchArgs := []string{"fetch", "--recurse-submodules=yes"}
 	if spec.Depth > 0 {
 		fetchArgs = append(fetchArgs, fmt.Sprintf("--depth=%d", spec.Depth))
 	}
-	fetchArgs = append(fetchArgs, "origin", spec.Revision)
+	// Fetch the revision and check out FETCH_HEAD, or fetch the refspec and
+	// check out the revision among the refs it fetched.
+	checkout := "FETCH_HEAD"
+	fetchArgs = append(fetchArgs, "origin")
+	if spec.Refspec != "" {
+		fetchArgs = append(fetchArgs, "--update-head-ok", "--force")
+		fetchArgs = append(fetchArgs, strings.Fields(spec.Refspec)...)
+		checkout = spec.Revision
+	} else {
+		fetchArgs = append(fetchArgs, spec.Revision)
+	}
 
 	if _, err := run(logger, "", fetchArgs...); err != nil {
 		// Fetch can fail if an old commitid was used so try git pull, performing regardless of error
@@ -90,13 +113,31 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 		if _, err := run(logger, "", "checkout", spec.Revision); err != nil {
 			return err
 		}
-	} else if _, err := run(logger, "", "reset", "--hard", "FETCH_HEAD"); err != nil {
+	} else if _, err := run(logger, "", "reset", "--hard", checkout); err != nil {
 		return err
 	}
 	logger.Infof("Successfully cloned %s @ %s in path %s", trimmedURL, spec.Revision, spec.Path)
 	return nil
 }
 
+// configSparseCheckout enables sparse checkout in the current repository,
+// limited to the given comma separated directories.
+func configSparseCheckout(logger *zap.SugaredLogger, directories string) error {
+	if _, err := run(logger, "", "config", "core.sparseCheckout", "true"); err != nil {
+		return err
+	}
+	var patterns strings.Builder
+	for _, dir := range strings.Split(directories, ",") {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			patterns.WriteString(dir + "\n")
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(".git", "info", "sparse-checkout"), []byte(patterns.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write the sparse checkout directories; err: %w", err)
+	}
+	return nil
+}
+
 func Commit(logger *zap.SugaredLogger, revision, path string) (string, error) {
 	output, err := run(logger, path, "rev-parse", "HEAD")
 	if err != nil {
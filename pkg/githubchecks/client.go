@@ -0,0 +1,169 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package githubchecks is a minimal client for the GitHub Checks API, used to
+// report the state of PipelineRuns on the commits they run for.
+package githubchecks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// DefaultURL is the URL of the GitHub API.
+const DefaultURL = "https://api.github.com"
+
+// Status is the status of a check run.
+type Status string
+
+const (
+	StatusQueued     Status = "queued"
+	StatusInProgress Status = "in_progress"
+	StatusCompleted  Status = "completed"
+)
+
+// Conclusion is the conclusion of a completed check run.
+type Conclusion string
+
+const (
+	ConclusionSuccess Conclusion = "success"
+	ConclusionFailure Conclusion = "failure"
+	ConclusionSkipped Conclusion = "skipped"
+)
+
+// Output is the output of a check run, rendered as Markdown by GitHub.
+type Output struct {
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+}
+
+// CheckRun is a check run reported on a commit.
+type CheckRun struct {
+	ID          int64      `json:"id,omitempty"`
+	Name        string     `json:"name"`
+	HeadSHA     string     `json:"head_sha,omitempty"`
+	ExternalID  string     `json:"external_id,omitempty"`
+	Status      Status     `json:"status"`
+	Conclusion  Conclusion `json:"conclusion,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Output      *Output    `json:"output,omitempty"`
+}
+
+// Client reports check runs to the repository repo, of the form "owner/name".
+type Client interface {
+	// FindCheckRun returns the ID of the check run of the commit sha with the
+	// given name and external ID, or false if there is none.
+	FindCheckRun(ctx context.Context, repo, sha, name, externalID string) (int64, bool, error)
+	// CreateCheckRun creates cr and returns its ID.
+	CreateCheckRun(ctx context.Context, repo string, cr CheckRun) (int64, error)
+	// UpdateCheckRun updates the check run id with cr.
+	UpdateCheckRun(ctx context.Context, repo string, id int64, cr CheckRun) error
+}
+
+type client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client for the GitHub API at baseURL, DefaultURL if
+// empty, authenticating with token.
+func NewClient(baseURL, token string) Client {
+	if baseURL == "" {
+		baseURL = DefaultURL
+	}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	return &client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: oauth2.NewClient(context.Background(), ts),
+	}
+}
+
+func (c *client) FindCheckRun(ctx context.Context, repo, sha, name, externalID string) (int64, bool, error) {
+	var list struct {
+		CheckRuns []CheckRun `json:"check_runs"`
+	}
+	path := fmt.Sprintf("/repos/%s/commits/%s/check-runs?check_name=%s", repo, sha, url.QueryEscape(name))
+	if err := c.do(ctx, http.MethodGet, path, nil, &list); err != nil {
+		return 0, false, err
+	}
+	for _, cr := range list.CheckRuns {
+		if cr.ExternalID == externalID {
+			return cr.ID, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func (c *client) CreateCheckRun(ctx context.Context, repo string, cr CheckRun) (int64, error) {
+	var created CheckRun
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/check-runs", repo), cr, &created); err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+func (c *client) UpdateCheckRun(ctx context.Context, repo string, id int64, cr CheckRun) error {
+	// The commit of a check run can't be changed.
+	cr.HeadSHA = ""
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/check-runs/%d", repo, id), cr, nil)
+}
+
+func (c *client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: unexpected status %d: %s", method, path, resp.StatusCode, b)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(b, out)
+}
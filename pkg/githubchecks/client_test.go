@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package githubchecks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type request struct {
+	Method string
+	Path   string
+	Body   map[string]interface{}
+}
+
+// newServer returns a server recording the requests it receives and replying
+// to them with responses, keyed by method and path.
+func newServer(t *testing.T, responses map[string]string) (*httptest.Server, *[]request) {
+	t.Helper()
+	var requests []request
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Expected the token to be sent, got Authorization %q", got)
+		}
+		req := request{Method: r.Method, Path: r.URL.RequestURI()}
+		if r.Body != nil && r.Method != http.MethodGet {
+			if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+				t.Errorf("Invalid request body: %v", err)
+			}
+		}
+		requests = append(requests, req)
+		resp, ok := responses[r.Method+" "+r.URL.RequestURI()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Not Found"}`)
+			return
+		}
+		fmt.Fprint(w, resp)
+	}))
+	return s, &requests
+}
+
+func TestFindCheckRun(t *testing.T) {
+	s, _ := newServer(t, map[string]string{
+		"GET /repos/owner/repo/commits/abc/check-runs?check_name=build": `{"check_runs": [
+			{"id": 1, "name": "build", "external_id": "ns/other-run/build"},
+			{"id": 2, "name": "build", "external_id": "ns/run/build"}
+		]}`,
+	})
+	defer s.Close()
+	c := NewClient(s.URL, "token")
+
+	id, found, err := c.FindCheckRun(context.Background(), "owner/repo", "abc", "build", "ns/run/build")
+	if err != nil {
+		t.Fatalf("FindCheckRun: %v", err)
+	}
+	if !found || id != 2 {
+		t.Errorf("FindCheckRun() = %d, %t, want 2, true", id, found)
+	}
+
+	if _, found, err := c.FindCheckRun(context.Background(), "owner/repo", "abc", "build", "ns/new-run/build"); err != nil || found {
+		t.Errorf("FindCheckRun() = %t, %v, want false, nil", found, err)
+	}
+}
+
+func TestCreateAndUpdateCheckRun(t *testing.T) {
+	s, requests := newServer(t, map[string]string{
+		"POST /repos/owner/repo/check-runs":     `{"id": 42}`,
+		"PATCH /repos/owner/repo/check-runs/42": `{"id": 42}`,
+	})
+	defer s.Close()
+	c := NewClient(s.URL+"/", "token")
+
+	id, err := c.CreateCheckRun(context.Background(), "owner/repo", CheckRun{
+		Name:       "build",
+		HeadSHA:    "abc",
+		ExternalID: "ns/run/build",
+		Status:     StatusInProgress,
+	})
+	if err != nil {
+		t.Fatalf("CreateCheckRun: %v", err)
+	}
+	if id != 42 {
+		t.Errorf("CreateCheckRun() = %d, want 42", id)
+	}
+	if err := c.UpdateCheckRun(context.Background(), "owner/repo", id, CheckRun{
+		Name:       "build",
+		HeadSHA:    "abc",
+		ExternalID: "ns/run/build",
+		Status:     StatusCompleted,
+		Conclusion: ConclusionSuccess,
+		Output:     &Output{Title: "Succeeded", Summary: "All Steps have completed executing"},
+	}); err != nil {
+		t.Fatalf("UpdateCheckRun: %v", err)
+	}
+
+	expected := []request{{
+		Method: http.MethodPost,
+		Path:   "/repos/owner/repo/check-runs",
+		Body: map[string]interface{}{
+			"name":        "build",
+			"head_sha":    "abc",
+			"external_id": "ns/run/build",
+			"status":      "in_progress",
+		},
+	}, {
+		Method: http.MethodPatch,
+		Path:   "/repos/owner/repo/check-runs/42",
+		Body: map[string]interface{}{
+			"name":        "build",
+			"external_id": "ns/run/build",
+			"status":      "completed",
+			"conclusion":  "success",
+			"output": map[string]interface{}{
+				"title":   "Succeeded",
+				"summary": "All Steps have completed executing",
+			},
+		},
+	}}
+	if d := cmp.Diff(expected, *requests); d != "" {
+		t.Errorf("Unexpected requests (-want, +got): %s", d)
+	}
+}
+
+func TestCreateCheckRunError(t *testing.T) {
+	s, _ := newServer(t, map[string]string{})
+	defer s.Close()
+	c := NewClient(s.URL, "token")
+
+	if _, err := c.CreateCheckRun(context.Background(), "owner/repo", CheckRun{Name: "build"}); err == nil {
+		t.Error("CreateCheckRun() expected an error")
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health serves the liveness and readiness of the controller and the
+// webhook, from checks of the dependencies they need to do their job.
+package health
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// LivenessPath is the path under which Checks.ServeHTTP serves whether
+	// the process is up.
+	LivenessPath = "/healthz"
+	// ReadinessPath is the path under which Checks.ServeHTTP serves whether
+	// each of the checks passes.
+	ReadinessPath = "/readyz"
+)
+
+// Check returns an error if a dependency isn't ready.
+type Check func() error
+
+// Checks holds the named checks that must pass for a process to be ready.
+type Checks struct {
+	mu     sync.Mutex
+	checks map[string]Check
+}
+
+// DefaultChecks are the checks of the readiness of the process.
+var DefaultChecks = NewChecks()
+
+// NewChecks returns Checks without any check, which are always ready.
+func NewChecks() *Checks {
+	return &Checks{checks: map[string]Check{}}
+}
+
+// Add adds check as name, replacing the check previously added as name.
+func (c *Checks) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// Run runs each check and returns the error of each check that failed, by
+// name.
+func (c *Checks) Run() map[string]error {
+	c.mu.Lock()
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.Unlock()
+
+	failed := map[string]error{}
+	for name, check := range checks {
+		if err := check(); err != nil {
+			failed[name] = err
+		}
+	}
+	return failed
+}
+
+// ServeHTTP serves whether the process is up at LivenessPath, and at
+// ReadinessPath whether all the checks pass. If not, it fails with 503
+// Service Unavailable and lists the checks that failed.
+func (c *Checks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	switch r.URL.Path {
+	case LivenessPath:
+		fmt.Fprintln(w, "ok")
+	case ReadinessPath:
+		failed := c.Run()
+		if len(failed) == 0 {
+			fmt.Fprintln(w, "ok")
+			return
+		}
+		names := make([]string, 0, len(failed))
+		for name := range failed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var msg strings.Builder
+		for _, name := range names {
+			fmt.Fprintf(&msg, "%s: %v\n", name, failed[name])
+		}
+		http.Error(w, strings.TrimSuffix(msg.String(), "\n"), http.StatusServiceUnavailable)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// Handle registers c on mux for LivenessPath and ReadinessPath.
+func (c *Checks) Handle(mux *http.ServeMux) {
+	mux.Handle(LivenessPath, c)
+	mux.Handle(ReadinessPath, c)
+}
+
+// InformersSynced returns a check that fails until the caches of all the
+// informers synced.
+func InformersSynced(synced ...cache.InformerSynced) Check {
+	return func() error {
+		for _, s := range synced {
+			if !s() {
+				return errors.New("informer caches not synced")
+			}
+		}
+		return nil
+	}
+}
+
+// CertificateValid returns a check that fails unless the PEM encoded
+// certificate returned by getCert is valid now, and for at least minValidity
+// more: the webhook isn't ready shortly before its certificate expires, so
+// that its admission traffic is routed to a replica with a fresh one. The
+// certificate is only parsed again when it changes.
+func CertificateValid(getCert func() ([]byte, error), minValidity time.Duration, now func() time.Time) Check {
+	var mu sync.Mutex
+	var parsedPEM []byte
+	var parsed *x509.Certificate
+	var parseErr error
+	return func() error {
+		data, err := getCert()
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		if parsedPEM == nil || !bytes.Equal(data, parsedPEM) {
+			parsedPEM = data
+			parsed, parseErr = parseCertificate(data)
+		}
+		cert, err := parsed, parseErr
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+		t := now()
+		switch {
+		case t.Before(cert.NotBefore):
+			return fmt.Errorf("certificate not valid before %s", cert.NotBefore.UTC().Format(time.RFC3339))
+		case t.Add(minValidity).After(cert.NotAfter):
+			return fmt.Errorf("certificate expires at %s, in less than %s", cert.NotAfter.UTC().Format(time.RFC3339), minValidity)
+		}
+		return nil
+	}
+}
+
+// parseCertificate parses the first certificate of the PEM encoded data.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestChecksServeHTTP(t *testing.T) {
+	passing := func() error { return nil }
+	failing := func() error { return errors.New("not synced") }
+	for _, tc := range []struct {
+		name       string
+		checks     map[string]Check
+		path       string
+		wantStatus int
+		wantBody   string
+	}{{
+		name:       "live",
+		checks:     map[string]Check{"informers": failing},
+		path:       LivenessPath,
+		wantStatus: http.StatusOK,
+		wantBody:   "ok\n",
+	}, {
+		name:       "ready without checks",
+		path:       ReadinessPath,
+		wantStatus: http.StatusOK,
+		wantBody:   "ok\n",
+	}, {
+		name:       "ready",
+		checks:     map[string]Check{"informers": passing, "config": passing},
+		path:       ReadinessPath,
+		wantStatus: http.StatusOK,
+		wantBody:   "ok\n",
+	}, {
+		name:       "not ready",
+		checks:     map[string]Check{"informers": failing, "config": passing, "certificate": failing},
+		path:       ReadinessPath,
+		wantStatus: http.StatusServiceUnavailable,
+		wantBody:   "certificate: not synced\ninformers: not synced\n",
+	}, {
+		name:       "unknown path",
+		path:       "/metrics",
+		wantStatus: http.StatusNotFound,
+		wantBody:   "404 page not found\n",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewChecks()
+			for name, check := range tc.checks {
+				c.Add(name, check)
+			}
+			w := httptest.NewRecorder()
+			c.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if w.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			if d := cmp.Diff(tc.wantBody, w.Body.String()); d != "" {
+				t.Errorf("body diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestInformersSynced(t *testing.T) {
+	synced := func() bool { return true }
+	notSynced := func() bool { return false }
+	if err := InformersSynced(synced, synced)(); err != nil {
+		t.Errorf("InformersSynced() = %v, want nil", err)
+	}
+	if err := InformersSynced(synced, notSynced)(); err == nil {
+		t.Error("InformersSynced() = nil, want an error")
+	}
+}
+
+func TestCertificateValid(t *testing.T) {
+	notBefore := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(30 * 24 * time.Hour)
+	cert := testCertificate(t, notBefore, notAfter)
+	for _, tc := range []struct {
+		name    string
+		cert    []byte
+		now     time.Time
+		wantErr string
+	}{{
+		name: "valid",
+		cert: cert,
+		now:  notBefore.Add(time.Hour),
+	}, {
+		name:    "not valid yet",
+		cert:    cert,
+		now:     notBefore.Add(-time.Hour),
+		wantErr: "certificate not valid before 2019-01-01T00:00:00Z",
+	}, {
+		name:    "expiring",
+		cert:    cert,
+		now:     notAfter.Add(-time.Hour),
+		wantErr: "certificate expires at 2019-01-31T00:00:00Z, in less than 24h0m0s",
+	}, {
+		name:    "expired",
+		cert:    cert,
+		now:     notAfter.Add(time.Hour),
+		wantErr: "certificate expires at 2019-01-31T00:00:00Z, in less than 24h0m0s",
+	}, {
+		name:    "not PEM",
+		cert:    []byte("not a certificate"),
+		now:     notBefore.Add(time.Hour),
+		wantErr: "no PEM encoded certificate",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			check := CertificateValid(func() ([]byte, error) { return tc.cert, nil }, 24*time.Hour, func() time.Time { return tc.now })
+			var gotErr string
+			if err := check(); err != nil {
+				gotErr = err.Error()
+			}
+			if d := cmp.Diff(tc.wantErr, gotErr); d != "" {
+				t.Errorf("error diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestCertificateValid_Rotated(t *testing.T) {
+	notBefore := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	cert := testCertificate(t, notBefore, notBefore.Add(30*24*time.Hour))
+	rotated := testCertificate(t, notBefore, notBefore.Add(60*24*time.Hour))
+	served := cert
+	check := CertificateValid(func() ([]byte, error) { return served, nil }, 24*time.Hour, func() time.Time { return notBefore.Add(40 * 24 * time.Hour) })
+
+	if err := check(); err == nil {
+		t.Error("check() of an expired certificate = nil, want an error")
+	}
+	served = rotated
+	if err := check(); err != nil {
+		t.Errorf("check() of the rotated certificate = %v, want nil", err)
+	}
+}
+
+func testCertificate(t *testing.T, notBefore, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,25 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package localrun runs TaskSpecs with a local container runtime, such as
+// docker or podman, without a Kubernetes cluster.
+//
+// Each step runs in its own container, one after the other, with the
+// workspaces of the Task mounted from directories of the host. The steps are
+// ordered, retried, timed out and skipped by the same
+// github.com/tektoncd/pipeline/pkg/entrypoint code that runs them in a Pod,
+// so that a Task behaves the same locally as on a cluster.
+package localrun
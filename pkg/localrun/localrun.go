@@ -0,0 +1,335 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+)
+
+const (
+	workspaceDir = "/workspace"
+	homeDir      = "/tekton/home"
+	scriptsDir   = "/tekton/scripts"
+
+	// defaultScriptPreamble is added to the scripts without a shebang, as
+	// in a Pod.
+	defaultScriptPreamble = "#!/bin/sh\nset -xe\n"
+)
+
+// Options are the inputs of a local run of a TaskSpec.
+type Options struct {
+	// Params are the values of the params of the Task. The others take
+	// their default value.
+	Params []v1alpha1.Param
+	// Workspaces are the directories of the host bound to the workspaces
+	// of the Task, by workspace name.
+	Workspaces map[string]string
+	// Stdout and Stderr receive the output of the steps, os.Stdout and
+	// os.Stderr if nil.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// StepResult is the outcome of a step.
+type StepResult struct {
+	// Name of the step.
+	Name string
+	// Err is why the step failed or was skipped, nil if it succeeded.
+	Err error
+}
+
+// Skipped returns true if the step didn't run because a step before it
+// failed.
+func (s StepResult) Skipped() bool {
+	return errors.Is(s.Err, entrypoint.ErrSkipPreviousStepFailed)
+}
+
+// Result is the outcome of a local run of a TaskSpec.
+type Result struct {
+	// Steps are the outcomes of the steps, in order.
+	Steps []StepResult
+	// TaskRunResults are the results the steps wrote, set once all the
+	// steps succeeded.
+	TaskRunResults []v1alpha1.TaskRunResult
+}
+
+// Engine runs TaskSpecs with a container Runtime.
+type Engine struct {
+	Runtime Runtime
+}
+
+// Run runs the steps of spec one after the other, and returns their outcome.
+// The error is the one of the first step that failed, or why spec can't be
+// run locally.
+func (e *Engine) Run(ctx context.Context, spec v1alpha1.TaskSpec, opts Options) (*Result, error) {
+	if err := checkLocal(spec, opts); err != nil {
+		return nil, err
+	}
+	var defaults []v1alpha1.ParamSpec
+	if spec.Inputs != nil {
+		defaults = spec.Inputs.Params
+	}
+	tr := &v1alpha1.TaskRun{Spec: v1alpha1.TaskRunSpec{Inputs: v1alpha1.TaskRunInputs{Params: opts.Params}}}
+	ts := resources.ApplyParameters(&spec, tr, defaults...)
+	ts = resources.ApplyResults(ts)
+	ts = resources.ApplyWorkspaces(ts)
+	steps, err := v1alpha1.MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "tekton-localrun")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	sb := &sandbox{dir: dir}
+	mounts, err := sb.mounts(*ts, opts.Workspaces)
+	if err != nil {
+		return nil, err
+	}
+	var resultFiles []string
+	for _, r := range ts.Results {
+		resultFiles = append(resultFiles, filepath.Join(dir, "results", r.Name))
+	}
+
+	result := &Result{}
+	var firstErr error
+	for i, s := range steps {
+		c, err := sb.container(i, s, mounts, opts)
+		if err != nil {
+			return nil, err
+		}
+		ep := entrypoint.Entrypointer{
+			PostFile:      filepath.Join(dir, "tools", strconv.Itoa(i)),
+			AlwaysRun:     s.AlwaysRun,
+			Retries:       s.Retries,
+			Waiter:        &entrypoint.FileWaiter{},
+			Runner:        &stepRunner{ctx: ctx, runtime: e.Runtime, container: c},
+			PostWriter:    &entrypoint.FilePostWriter{},
+			ResultsWriter: &resultsWriter{result: result},
+		}
+		if i > 0 {
+			ep.WaitFiles = []string{filepath.Join(dir, "tools", strconv.Itoa(i-1))}
+		}
+		if i == len(steps)-1 {
+			ep.Results = resultFiles
+		}
+		if s.RetryBackoff != nil {
+			ep.RetryBackoff = s.RetryBackoff.Duration
+		}
+		if s.Timeout != nil {
+			ep.Timeout = s.Timeout.Duration
+		}
+		if d, ok := ctx.Deadline(); ok {
+			ep.Deadline = d
+		}
+		err = ep.Go()
+		result.Steps = append(result.Steps, StepResult{Name: s.Name, Err: err})
+		if err != nil && firstErr == nil && !errors.Is(err, entrypoint.ErrSkipPreviousStepFailed) {
+			firstErr = fmt.Errorf("step %d %q failed: %w", i, s.Name, err)
+		}
+	}
+	return result, firstErr
+}
+
+// checkLocal returns an error if spec uses features that need a Pod, or if
+// opts doesn't bind all of its workspaces.
+func checkLocal(spec v1alpha1.TaskSpec, opts Options) error {
+	var unsupported []string
+	if spec.Inputs != nil && len(spec.Inputs.Resources) > 0 {
+		unsupported = append(unsupported, "inputs.resources")
+	}
+	if spec.Outputs != nil && len(spec.Outputs.Resources) > 0 {
+		unsupported = append(unsupported, "outputs.resources")
+	}
+	if len(spec.Volumes) > 0 {
+		unsupported = append(unsupported, "volumes")
+	}
+	if len(spec.Sidecars) > 0 {
+		unsupported = append(unsupported, "sidecars")
+	}
+	if len(spec.Capabilities) > 0 {
+		unsupported = append(unsupported, "capabilities")
+	}
+	if len(spec.MemoryVolumes) > 0 {
+		unsupported = append(unsupported, "memoryVolumes")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("can't run locally a Task with %s", strings.Join(unsupported, ", "))
+	}
+	for _, w := range spec.Workspaces {
+		if _, ok := opts.Workspaces[w.Name]; !ok {
+			return fmt.Errorf("workspace %q isn't bound to a directory", w.Name)
+		}
+	}
+	return nil
+}
+
+// sandbox holds the directories of the host shared by the steps of a local
+// run, under dir.
+type sandbox struct {
+	dir string
+}
+
+// mounts creates the directories of the host shared by the steps, and
+// returns where they're mounted along with the directories bound to the
+// workspaces.
+func (sb *sandbox) mounts(ts v1alpha1.TaskSpec, workspaces map[string]string) ([]Mount, error) {
+	for _, d := range []string{"workspace", "home", "results", "scripts", "tools"} {
+		if err := os.Mkdir(filepath.Join(sb.dir, d), 0777); err != nil {
+			return nil, err
+		}
+	}
+	var mounts []Mount
+	implicit := []Mount{{
+		Source: filepath.Join(sb.dir, "workspace"),
+		Target: workspaceDir,
+	}, {
+		Source: filepath.Join(sb.dir, "home"),
+		Target: homeDir,
+	}}
+	if len(ts.Results) > 0 {
+		implicit = append(implicit, Mount{Source: filepath.Join(sb.dir, "results"), Target: v1alpha1.ResultsDir})
+	}
+	for _, w := range ts.Workspaces {
+		source, err := filepath.Abs(workspaces[w.Name])
+		if err != nil {
+			return nil, err
+		}
+		mounts = append(mounts, Mount{
+			Source:   source,
+			Target:   w.GetMountPath(),
+			ReadOnly: w.ReadOnly,
+		})
+	}
+	// As in a Pod, a workspace mounted at the path of an implicit
+	// directory replaces it.
+	for _, imp := range implicit {
+		replaced := false
+		for _, m := range mounts {
+			replaced = replaced || filepath.Clean(m.Target) == imp.Target
+		}
+		if !replaced {
+			mounts = append(mounts, imp)
+		}
+	}
+	return mounts, nil
+}
+
+// container returns the container running the step s, the i-th one.
+func (sb *sandbox) container(i int, s v1alpha1.Step, mounts []Mount, opts Options) (Container, error) {
+	if len(s.VolumeMounts) > 0 {
+		return Container{}, fmt.Errorf("step %d %q: can't run locally a step with volumeMounts", i, s.Name)
+	}
+	if len(s.EnvFrom) > 0 {
+		return Container{}, fmt.Errorf("step %d %q: can't run locally a step with envFrom", i, s.Name)
+	}
+	c := Container{
+		Image:      s.Image,
+		Command:    s.Command,
+		Args:       s.Args,
+		WorkingDir: s.WorkingDir,
+		Env:        []string{"HOME=" + homeDir},
+		Mounts:     mounts,
+		Stdout:     opts.Stdout,
+		Stderr:     opts.Stderr,
+	}
+	if c.WorkingDir == "" {
+		c.WorkingDir = workspaceDir
+	}
+	for _, e := range s.Env {
+		if e.ValueFrom != nil {
+			return Container{}, fmt.Errorf("step %d %q: can't run locally the env var %s, it has a valueFrom", i, s.Name, e.Name)
+		}
+		c.Env = append(c.Env, e.Name+"="+e.Value)
+	}
+	if s.Script != "" {
+		script := s.Script
+		if !strings.HasPrefix(strings.TrimSpace(script), "#!") {
+			script = defaultScriptPreamble + script
+		}
+		name := fmt.Sprintf("script-%d", i)
+		if err := ioutil.WriteFile(filepath.Join(sb.dir, "scripts", name), []byte(script), 0755); err != nil {
+			return Container{}, err
+		}
+		c.Command, c.Args = []string{scriptsDir + "/" + name}, nil
+		c.Mounts = append(append([]Mount{}, mounts...), Mount{Source: filepath.Join(sb.dir, "scripts"), Target: scriptsDir, ReadOnly: true})
+	}
+	return c, nil
+}
+
+// stepRunner runs the container of a step each time the Entrypointer runs
+// the step, ignoring the args it passes: the command is the one of the
+// container.
+type stepRunner struct {
+	// ctx is the context of the whole run: the container is killed once
+	// either it or the context of the attempt is done.
+	ctx       context.Context
+	runtime   Runtime
+	container Container
+}
+
+var _ entrypoint.Runner = (*stepRunner)(nil)
+
+func (r *stepRunner) Run(ctx context.Context, _ ...string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-r.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	c := r.container
+	// The Entrypointer sets the attempt and the deadline in its own
+	// environment, which isn't the one of the container.
+	c.Env = append(append([]string{}, c.Env...), entrypoint.AttemptEnvVar+"="+os.Getenv(entrypoint.AttemptEnvVar))
+	for _, name := range []string{entrypoint.DeadlineEnvVar, entrypoint.RemainingSecondsEnvVar} {
+		if v, ok := os.LookupEnv(name); ok {
+			c.Env = append(c.Env, name+"="+v)
+		}
+	}
+	return r.runtime.Run(ctx, c)
+}
+
+// resultsWriter records the results of the Task in a Result.
+type resultsWriter struct {
+	result *Result
+}
+
+var _ entrypoint.ResultsWriter = (*resultsWriter)(nil)
+
+func (w *resultsWriter) Write(results []v1alpha1.PipelineResourceResult) error {
+	for _, r := range results {
+		w.result.TaskRunResults = append(w.result.TaskRunResults, v1alpha1.TaskRunResult{Name: r.Key, Value: r.Value})
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localrun
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeRuntime records the containers it runs, with the directory of the run
+// replaced by $DIR in their mounts, and runs them with run.
+type fakeRuntime struct {
+	containers []Container
+	run        func(ctx context.Context, c Container) error
+}
+
+func (f *fakeRuntime) Run(ctx context.Context, c Container) error {
+	var dir string
+	for _, m := range c.Mounts {
+		if m.Target == homeDir {
+			dir = filepath.Dir(m.Source)
+		}
+	}
+	recorded := c
+	recorded.Mounts = nil
+	for _, m := range c.Mounts {
+		m.Source = strings.Replace(m.Source, dir, "$DIR", 1)
+		recorded.Mounts = append(recorded.Mounts, m)
+	}
+	f.containers = append(f.containers, recorded)
+	if f.run == nil {
+		return nil
+	}
+	return f.run(ctx, c)
+}
+
+// source returns the directory of the host mounted at target in c.
+func source(c Container, target string) string {
+	for _, m := range c.Mounts {
+		if m.Target == target {
+			return m.Source
+		}
+	}
+	return ""
+}
+
+func TestRun(t *testing.T) {
+	spec := v1alpha1.TaskSpec{
+		Inputs: &v1alpha1.Inputs{
+			Params: []v1alpha1.ParamSpec{{
+				Name:    "greeting",
+				Type:    v1alpha1.ParamTypeString,
+				Default: &v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeString, StringVal: "hello"},
+			}, {
+				Name: "name",
+				Type: v1alpha1.ParamTypeString,
+			}},
+		},
+		StepTemplate: &corev1.Container{
+			Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+		},
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name:    "greet",
+			Image:   "busybox",
+			Command: []string{"echo"},
+			Args:    []string{"$(inputs.params.greeting) $(inputs.params.name)"},
+		}}, {
+			Container: corev1.Container{Name: "list", Image: "busybox"},
+			Script:    "ls $(workspaces.src.path) > $(results.files.path)",
+		}},
+		Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "src", ReadOnly: true}},
+		Results:    []v1alpha1.TaskResult{{Name: "files"}},
+	}
+	var script string
+	rt := &fakeRuntime{run: func(_ context.Context, c Container) error {
+		if len(c.Command) > 0 && strings.HasPrefix(c.Command[0], scriptsDir) {
+			b, err := ioutil.ReadFile(filepath.Join(source(c, scriptsDir), filepath.Base(c.Command[0])))
+			if err != nil {
+				return err
+			}
+			script = string(b)
+			return ioutil.WriteFile(filepath.Join(source(c, v1alpha1.ResultsDir), "files"), []byte("main.go"), 0666)
+		}
+		return nil
+	}}
+	e := Engine{Runtime: rt}
+	result, err := e.Run(context.Background(), spec, Options{
+		Params:     []v1alpha1.Param{{Name: "name", Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeString, StringVal: "world"}}},
+		Workspaces: map[string]string{"src": "/tmp/src"},
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	mounts := []Mount{{
+		Source:   "/tmp/src",
+		Target:   "/workspace/src",
+		ReadOnly: true,
+	}, {
+		Source: "$DIR/workspace",
+		Target: "/workspace",
+	}, {
+		Source: "$DIR/home",
+		Target: "/tekton/home",
+	}, {
+		Source: "$DIR/results",
+		Target: "/tekton/results",
+	}}
+	wantContainers := []Container{{
+		Image:      "busybox",
+		Command:    []string{"echo"},
+		Args:       []string{"hello world"},
+		WorkingDir: "/workspace",
+		Env:        []string{"HOME=/tekton/home", "FOO=bar", "TEKTON_STEP_ATTEMPT=1"},
+		Mounts:     mounts,
+	}, {
+		Image:      "busybox",
+		Command:    []string{"/tekton/scripts/script-1"},
+		WorkingDir: "/workspace",
+		Env:        []string{"HOME=/tekton/home", "FOO=bar", "TEKTON_STEP_ATTEMPT=1"},
+		Mounts: append(append([]Mount{}, mounts...), Mount{
+			Source:   "$DIR/scripts",
+			Target:   "/tekton/scripts",
+			ReadOnly: true,
+		}),
+	}}
+	if d := cmp.Diff(wantContainers, rt.containers); d != "" {
+		t.Errorf("containers -want, +got: %s", d)
+	}
+	if d := cmp.Diff("#!/bin/sh\nset -xe\nls /workspace/src > /tekton/results/files", script); d != "" {
+		t.Errorf("script -want, +got: %s", d)
+	}
+	wantResult := &Result{
+		Steps:          []StepResult{{Name: "greet"}, {Name: "list"}},
+		TaskRunResults: []v1alpha1.TaskRunResult{{Name: "files", Value: "main.go"}},
+	}
+	if d := cmp.Diff(wantResult, result); d != "" {
+		t.Errorf("result -want, +got: %s", d)
+	}
+}
+
+func TestRun_Failure(t *testing.T) {
+	step := func(name string, alwaysRun bool) v1alpha1.Step {
+		return v1alpha1.Step{Container: corev1.Container{Name: name, Image: name}, AlwaysRun: alwaysRun}
+	}
+	spec := v1alpha1.TaskSpec{
+		Steps:   []v1alpha1.Step{step("fail", false), step("skipped", false), step("cleanup", true), step("last", false)},
+		Results: []v1alpha1.TaskResult{{Name: "digest"}},
+	}
+	var ran []string
+	e := Engine{Runtime: &fakeRuntime{run: func(_ context.Context, c Container) error {
+		ran = append(ran, c.Image)
+		if err := ioutil.WriteFile(filepath.Join(source(c, v1alpha1.ResultsDir), "digest"), []byte("sha256:abc"), 0666); err != nil {
+			return err
+		}
+		if c.Image == "fail" {
+			return errors.New("exit status 1")
+		}
+		return nil
+	}}}
+	result, err := e.Run(context.Background(), spec, Options{})
+	if err == nil || err.Error() != `step 0 "fail" failed: exit status 1` {
+		t.Errorf("expected the failure of the first step, got %v", err)
+	}
+	if d := cmp.Diff([]string{"fail", "cleanup"}, ran); d != "" {
+		t.Errorf("steps ran -want, +got: %s", d)
+	}
+	var skipped []string
+	for _, s := range result.Steps {
+		if s.Skipped() {
+			skipped = append(skipped, s.Name)
+		}
+	}
+	if d := cmp.Diff([]string{"skipped", "last"}, skipped); d != "" {
+		t.Errorf("steps skipped -want, +got: %s", d)
+	}
+	if len(result.TaskRunResults) != 0 {
+		t.Errorf("expected no results once a step failed, got %v", result.TaskRunResults)
+	}
+}
+
+func TestRun_Retries(t *testing.T) {
+	spec := v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{Name: "flaky", Image: "busybox"}, Retries: 2}},
+	}
+	var attempts []string
+	e := Engine{Runtime: &fakeRuntime{run: func(_ context.Context, c Container) error {
+		attempts = append(attempts, c.Env[len(c.Env)-1])
+		if len(attempts) == 1 {
+			return errors.New("exit status 1")
+		}
+		return nil
+	}}}
+	if _, err := e.Run(context.Background(), spec, Options{}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if d := cmp.Diff([]string{"TEKTON_STEP_ATTEMPT=1", "TEKTON_STEP_ATTEMPT=2"}, attempts); d != "" {
+		t.Errorf("attempts -want, +got: %s", d)
+	}
+}
+
+func TestRun_Timeout(t *testing.T) {
+	spec := v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{
+			Container: corev1.Container{Name: "slow", Image: "busybox"},
+			Timeout:   &metav1.Duration{Duration: 10 * time.Millisecond},
+		}},
+	}
+	e := Engine{Runtime: &fakeRuntime{run: func(ctx context.Context, _ Container) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}}
+	if _, err := e.Run(context.Background(), spec, Options{}); !errors.Is(err, entrypoint.ErrStepTimeout) {
+		t.Errorf("expected the step to time out, got %v", err)
+	}
+}
+
+func TestRun_Invalid(t *testing.T) {
+	for _, c := range []struct {
+		desc    string
+		spec    v1alpha1.TaskSpec
+		opts    Options
+		wantErr string
+	}{{
+		desc: "sidecars and capabilities",
+		spec: v1alpha1.TaskSpec{
+			Steps:        []v1alpha1.Step{{Container: corev1.Container{Image: "busybox"}}},
+			Sidecars:     []corev1.Container{{Name: "db", Image: "postgres"}},
+			Capabilities: []v1alpha1.TaskCapability{v1alpha1.TaskCapabilityDocker},
+		},
+		wantErr: "can't run locally a Task with sidecars, capabilities",
+	}, {
+		desc: "unbound workspace",
+		spec: v1alpha1.TaskSpec{
+			Steps:      []v1alpha1.Step{{Container: corev1.Container{Image: "busybox"}}},
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "src"}},
+		},
+		wantErr: `workspace "src" isn't bound to a directory`,
+	}, {
+		desc: "env valueFrom",
+		spec: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:  "push",
+				Image: "busybox",
+				Env: []corev1.EnvVar{{
+					Name:      "TOKEN",
+					ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{Key: "token"}},
+				}},
+			}}},
+		},
+		wantErr: `step 0 "push": can't run locally the env var TOKEN, it has a valueFrom`,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			rt := &fakeRuntime{}
+			e := Engine{Runtime: rt}
+			_, err := e.Run(context.Background(), c.spec, c.opts)
+			if err == nil || err.Error() != c.wantErr {
+				t.Errorf("expected error %q, got %v", c.wantErr, err)
+			}
+			if len(rt.containers) != 0 {
+				t.Errorf("expected no container to run, got %v", rt.containers)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package localruntest provides helpers for the tests of Tasks, which run
+// them with a local container runtime.
+package localruntest
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/localrun"
+)
+
+// RuntimeEnvVar is the environment variable naming the command line of the
+// container runtime the tests use, docker if unset.
+const RuntimeEnvVar = "TEKTON_LOCALRUN_RUNTIME"
+
+// Run runs spec locally and returns the outcome of its steps. It skips the
+// test if the container runtime isn't installed, and fails it if spec can't
+// be run locally.
+func Run(t testing.TB, spec v1alpha1.TaskSpec, opts localrun.Options) *localrun.Result {
+	t.Helper()
+	binary := os.Getenv(RuntimeEnvVar)
+	if binary == "" {
+		binary = "docker"
+	}
+	if _, err := exec.LookPath(binary); err != nil {
+		t.Skipf("Container runtime %q isn't installed: %v", binary, err)
+	}
+	e := localrun.Engine{Runtime: &localrun.CLI{Binary: binary}}
+	result, err := e.Run(context.Background(), spec, opts)
+	if result == nil {
+		t.Fatalf("Error running the Task locally: %v", err)
+	}
+	return result
+}
+
+// Dir creates a temporary directory holding files, by path relative to it,
+// to bind to a workspace. The returned func removes it.
+func Dir(t testing.TB, files map[string]string) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "localruntest")
+	if err != nil {
+		t.Fatalf("Error creating a directory: %v", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	for path, content := range files {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			cleanup()
+			t.Fatalf("Error creating the directory of %s: %v", path, err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0666); err != nil {
+			cleanup()
+			t.Fatalf("Error writing %s: %v", path, err)
+		}
+	}
+	return dir, cleanup
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localrun
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/tektoncd/pipeline/pkg/names"
+)
+
+// Container is a container for a Runtime to run to completion.
+type Container struct {
+	// Image is the image the container runs.
+	Image string
+	// Command overrides the entrypoint of the image, if not empty.
+	Command []string
+	// Args are the arguments of the command.
+	Args []string
+	// WorkingDir is the directory the command runs in.
+	WorkingDir string
+	// Env is the environment of the command, as KEY=value.
+	Env []string
+	// Mounts are the directories of the host mounted into the container.
+	Mounts []Mount
+	// Stdout and Stderr receive the output of the command.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Mount is a directory of the host mounted into a container.
+type Mount struct {
+	// Source is the path of the directory on the host.
+	Source string
+	// Target is where the directory is mounted in the container.
+	Target string
+	// ReadOnly mounts the directory read-only.
+	ReadOnly bool
+}
+
+// Runtime runs containers.
+type Runtime interface {
+	// Run runs the container until its command exits, returning an error if
+	// it didn't exit successfully. The container is killed once ctx is done.
+	Run(ctx context.Context, c Container) error
+}
+
+// RuntimeFunc is a function used as a Runtime, for example to fake one in
+// tests.
+type RuntimeFunc func(ctx context.Context, c Container) error
+
+var _ Runtime = (RuntimeFunc)(nil)
+
+// Run calls f(ctx, c).
+func (f RuntimeFunc) Run(ctx context.Context, c Container) error { return f(ctx, c) }
+
+// CLI is a Runtime running the containers with a command line compatible
+// with the one of docker, such as podman's.
+type CLI struct {
+	// Binary is the command line, docker if empty.
+	Binary string
+}
+
+var _ Runtime = (*CLI)(nil)
+
+// Run runs the container with `<binary> run`, and removes it once it
+// exited or ctx is done.
+func (r *CLI) Run(ctx context.Context, c Container) error {
+	name := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("tekton-localrun")
+	cmd := exec.Command(r.binary(), runArgs(name, c)...)
+	cmd.Stdout, cmd.Stderr = c.Stdout, c.Stderr
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		// Killing the command line wouldn't stop the container, the
+		// runtime has to.
+		_ = exec.Command(r.binary(), "rm", "--force", name).Run()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (r *CLI) binary() string {
+	if r.Binary == "" {
+		return "docker"
+	}
+	return r.Binary
+}
+
+// runArgs returns the arguments of the run command running c in a container
+// named name.
+func runArgs(name string, c Container) []string {
+	args := []string{"run", "--rm", "--name", name}
+	for _, m := range c.Mounts {
+		v := m.Source + ":" + m.Target
+		if m.ReadOnly {
+			v += ":ro"
+		}
+		args = append(args, "--volume", v)
+	}
+	for _, e := range c.Env {
+		args = append(args, "--env", e)
+	}
+	if c.WorkingDir != "" {
+		args = append(args, "--workdir", c.WorkingDir)
+	}
+	cmdArgs := c.Args
+	if len(c.Command) > 0 {
+		// The entrypoint flag only takes the executable, the rest of
+		// the command goes before the arguments.
+		args = append(args, "--entrypoint", c.Command[0])
+		cmdArgs = append(append([]string{}, c.Command[1:]...), c.Args...)
+	}
+	args = append(args, c.Image)
+	return append(args, cmdArgs...)
+}
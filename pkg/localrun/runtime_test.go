@@ -0,0 +1,69 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localrun
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunArgs(t *testing.T) {
+	for _, c := range []struct {
+		desc      string
+		container Container
+		want      []string
+	}{{
+		desc:      "image only",
+		container: Container{Image: "busybox"},
+		want:      []string{"run", "--rm", "--name", "name", "busybox"},
+	}, {
+		desc: "everything",
+		container: Container{
+			Image:      "busybox",
+			Command:    []string{"sh", "-c"},
+			Args:       []string{"echo hello"},
+			WorkingDir: "/workspace",
+			Env:        []string{"HOME=/tekton/home"},
+			Mounts: []Mount{{
+				Source: "/tmp/src",
+				Target: "/workspace/src",
+			}, {
+				Source:   "/tmp/scripts",
+				Target:   "/tekton/scripts",
+				ReadOnly: true,
+			}},
+		},
+		want: []string{"run", "--rm", "--name", "name",
+			"--volume", "/tmp/src:/workspace/src",
+			"--volume", "/tmp/scripts:/tekton/scripts:ro",
+			"--env", "HOME=/tekton/home",
+			"--workdir", "/workspace",
+			"--entrypoint", "sh",
+			"busybox", "-c", "echo hello"},
+	}, {
+		desc:      "args without command",
+		container: Container{Image: "busybox", Args: []string{"echo", "hello"}},
+		want:      []string{"run", "--rm", "--name", "name", "busybox", "echo", "hello"},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			if d := cmp.Diff(c.want, runArgs("name", c.container)); d != "" {
+				t.Errorf("runArgs() -want, +got: %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// LevelPath is the path under which Levels.ServeHTTP serves the log
+	// levels of the loggers registered with Levels.Named.
+	LevelPath = "/debug/loglevel/"
+
+	// levelKeyPrefix is the prefix of the keys of the logging ConfigMap
+	// holding the levels of loggers.
+	levelKeyPrefix = "loglevel."
+
+	// noLevel is the effective level of loggers whose level is neither
+	// overridden nor configured.
+	noLevel = int32(zapcore.DebugLevel) - 1
+)
+
+// LevelSource is where the effective level of a logger comes from.
+type LevelSource string
+
+const (
+	// LevelSourceOverride is the source of the levels set with Levels.Set.
+	LevelSourceOverride LevelSource = "override"
+	// LevelSourceConfig is the source of the levels set in the logging
+	// ConfigMap for a logger.
+	LevelSourceConfig LevelSource = "config"
+	// LevelSourceDefault is the source of the levels of loggers logging at
+	// the level they were created with.
+	LevelSourceDefault LevelSource = "default"
+)
+
+// EffectiveLevel is the level a logger logs at.
+type EffectiveLevel struct {
+	Level  string      `json:"level"`
+	Source LevelSource `json:"source"`
+}
+
+// Levels holds the log levels of the loggers registered with Named. The
+// level of a logger is, by order of precedence:
+// - overridden at runtime with Set,
+// - configured in the logging ConfigMap with the "loglevel.<logger>" key,
+// - the level of the logger it was registered from.
+type Levels struct {
+	mu      sync.Mutex
+	loggers map[string]*loggerLevel
+}
+
+// loggerLevel holds the level of a logger.
+type loggerLevel struct {
+	// override, configured and base are guarded by the mutex of Levels.
+	override   *zapcore.Level
+	configured *zapcore.Level
+	base       zapcore.LevelEnabler
+
+	// effective is the level of override or configured, or noLevel.
+	effective int32
+}
+
+func (ll *loggerLevel) get() (zapcore.Level, bool) {
+	level := atomic.LoadInt32(&ll.effective)
+	return zapcore.Level(level), level != noLevel
+}
+
+// update updates the effective level of ll after override or configured
+// changed.
+func (ll *loggerLevel) update() {
+	level := noLevel
+	if ll.override != nil {
+		level = int32(*ll.override)
+	} else if ll.configured != nil {
+		level = int32(*ll.configured)
+	}
+	atomic.StoreInt32(&ll.effective, level)
+}
+
+// DefaultLevels are the log levels of the loggers of the controller.
+var DefaultLevels = NewLevels()
+
+// NewLevels returns Levels that don't change the level of any logger.
+func NewLevels() *Levels {
+	return &Levels{loggers: map[string]*loggerLevel{}}
+}
+
+// Named returns logger.Named(name), whose level can be changed with Set or
+// UpdateFromConfigMap.
+func (l *Levels) Named(logger *zap.SugaredLogger, name string) *zap.SugaredLogger {
+	l.mu.Lock()
+	ll, ok := l.loggers[name]
+	if !ok {
+		ll = &loggerLevel{effective: noLevel}
+		l.loggers[name] = ll
+	}
+	l.mu.Unlock()
+	return logger.Desugar().WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		l.mu.Lock()
+		ll.base = c
+		l.mu.Unlock()
+		return &levelCore{Core: c, level: ll}
+	})).Named(name).Sugar()
+}
+
+// Set overrides the level of the logger name. It fails if no logger was
+// registered as name.
+func (l *Levels) Set(name string, level zapcore.Level) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ll, ok := l.loggers[name]
+	if !ok {
+		return fmt.Errorf("unknown logger %q", name)
+	}
+	ll.override = &level
+	ll.update()
+	return nil
+}
+
+// Reset removes the override of the level of the logger name.
+func (l *Levels) Reset(name string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ll, ok := l.loggers[name]
+	if !ok {
+		return fmt.Errorf("unknown logger %q", name)
+	}
+	ll.override = nil
+	ll.update()
+	return nil
+}
+
+// UpdateFromConfigMap returns an observer of the logging ConfigMap setting the
+// level of each registered logger to the level of its "loglevel.<logger>"
+// key, if any.
+func (l *Levels) UpdateFromConfigMap(logger *zap.SugaredLogger) func(*corev1.ConfigMap) {
+	return func(cm *corev1.ConfigMap) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for name, ll := range l.loggers {
+			var configured *zapcore.Level
+			if value, ok := cm.Data[levelKeyPrefix+name]; ok {
+				var level zapcore.Level
+				if err := level.UnmarshalText([]byte(value)); err != nil {
+					logger.Errorf("Invalid level %q of logger %q, keeping its current level: %v", value, name, err)
+					continue
+				}
+				configured = &level
+			}
+			if !sameLevel(ll.configured, configured) {
+				logger.Infof("Level of logger %q configured to %s", name, levelString(configured))
+			}
+			ll.configured = configured
+			ll.update()
+		}
+	}
+}
+
+func sameLevel(a, b *zapcore.Level) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func levelString(level *zapcore.Level) string {
+	if level == nil {
+		return "the default level"
+	}
+	return level.String()
+}
+
+// Get returns the effective level of each registered logger.
+func (l *Levels) Get() map[string]EffectiveLevel {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	levels := make(map[string]EffectiveLevel, len(l.loggers))
+	for name, ll := range l.loggers {
+		switch {
+		case ll.override != nil:
+			levels[name] = EffectiveLevel{Level: ll.override.String(), Source: LevelSourceOverride}
+		case ll.configured != nil:
+			levels[name] = EffectiveLevel{Level: ll.configured.String(), Source: LevelSourceConfig}
+		default:
+			levels[name] = EffectiveLevel{Level: enabledLevel(ll.base).String(), Source: LevelSourceDefault}
+		}
+	}
+	return levels
+}
+
+// enabledLevel returns the lowest level enabled by enabler.
+func enabledLevel(enabler zapcore.LevelEnabler) zapcore.Level {
+	level := zapcore.DebugLevel
+	for enabler != nil && level < zapcore.FatalLevel && !enabler.Enabled(level) {
+		level++
+	}
+	return level
+}
+
+// ServeHTTP serves the log levels of the registered loggers:
+// - GET LevelPath returns the effective level of each logger, as JSON.
+// - PUT LevelPath<logger>?level=<level> overrides the level of a logger.
+// - DELETE LevelPath<logger> removes the override of a logger.
+func (l *Levels) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, LevelPath)
+	switch {
+	case r.Method == http.MethodGet && name == "":
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(l.Get())
+	case r.Method == http.MethodPut && name != "":
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(r.URL.Query().Get("level"))); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := l.Set(name, level); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		}
+	case r.Method == http.MethodDelete && name != "":
+		if err := l.Reset(name); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// levelCore is a zapcore.Core logging at the effective level of its logger,
+// if any, or at the level of the Core it wraps.
+type levelCore struct {
+	zapcore.Core
+	level *loggerLevel
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	if effective, ok := c.level.get(); ok {
+		return effective.Enabled(level)
+	}
+	return c.Core.Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	effective, ok := c.level.get()
+	if !ok {
+		return c.Core.Check(ent, ce)
+	}
+	if effective.Enabled(ent.Level) {
+		// Bypass the level of the wrapped Core, its Write doesn't check it.
+		return ce.AddCore(ent, c.Core)
+	}
+	return ce
+}
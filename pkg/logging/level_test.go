@@ -0,0 +1,175 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func messages(logs *observer.ObservedLogs) []string {
+	var m []string
+	for _, e := range logs.TakeAll() {
+		m = append(m, e.Message)
+	}
+	return m
+}
+
+func TestLevels(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	levels := NewLevels()
+	logger := levels.Named(zap.New(core).Sugar(), "taskrun-controller").With("key", "foo/bar")
+	other := levels.Named(zap.New(core).Sugar(), "pipeline-controller")
+
+	logger.Debug("debug before override")
+	logger.Info("info before override")
+	if err := levels.Set("taskrun-controller", zapcore.DebugLevel); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	logger.Debug("debug with override")
+	other.Debug("debug of other logger")
+	if err := levels.Reset("taskrun-controller"); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	logger.Debug("debug after reset")
+
+	expected := []string{"info before override", "debug with override"}
+	if d := cmp.Diff(expected, messages(logs)); d != "" {
+		t.Errorf("Unexpected logs: %s", d)
+	}
+
+	if err := levels.Set("unknown", zapcore.DebugLevel); err == nil {
+		t.Error("Expected an error overriding the level of an unknown logger")
+	}
+}
+
+func TestLevels_UpdateFromConfigMap(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	levels := NewLevels()
+	taskRunLogger := levels.Named(zap.New(core).Sugar(), "taskrun-controller")
+	pipelineRunLogger := levels.Named(zap.New(core).Sugar(), "pipeline-controller")
+	update := levels.UpdateFromConfigMap(zap.NewNop().Sugar())
+
+	update(&corev1.ConfigMap{Data: map[string]string{
+		"loglevel.taskrun-controller":  "debug",
+		"loglevel.pipeline-controller": "error",
+	}})
+	taskRunLogger.Debug("taskrun debug")
+	pipelineRunLogger.Info("pipelinerun info")
+	pipelineRunLogger.Error("pipelinerun error")
+
+	// An override takes precedence over config-logging.
+	if err := levels.Set("taskrun-controller", zapcore.WarnLevel); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	taskRunLogger.Info("taskrun info with override")
+	if err := levels.Reset("taskrun-controller"); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+
+	// Invalid levels are ignored, removed ones go back to the default.
+	update(&corev1.ConfigMap{Data: map[string]string{
+		"loglevel.taskrun-controller": "verbose",
+	}})
+	taskRunLogger.Debug("taskrun debug after invalid level")
+	pipelineRunLogger.Info("pipelinerun info after removal")
+
+	expected := []string{"taskrun debug", "pipelinerun error", "taskrun debug after invalid level", "pipelinerun info after removal"}
+	if d := cmp.Diff(expected, messages(logs)); d != "" {
+		t.Errorf("Unexpected logs: %s", d)
+	}
+}
+
+func TestLevels_ServeHTTP(t *testing.T) {
+	core, _ := observer.New(zapcore.InfoLevel)
+	levels := NewLevels()
+	levels.Named(zap.New(core).Sugar(), "taskrun-controller")
+	levels.Named(zap.New(core).Sugar(), "pipeline-controller")
+	levels.Named(zap.New(core).Sugar(), "githubchecks-controller")
+	levels.UpdateFromConfigMap(zap.NewNop().Sugar())(&corev1.ConfigMap{Data: map[string]string{
+		"loglevel.pipeline-controller": "warn",
+	}})
+
+	for _, tc := range []struct {
+		method, url string
+		status      int
+	}{
+		{http.MethodPut, LevelPath + "taskrun-controller?level=debug", http.StatusOK},
+		{http.MethodPut, LevelPath + "taskrun-controller?level=verbose", http.StatusBadRequest},
+		{http.MethodPut, LevelPath + "unknown?level=debug", http.StatusNotFound},
+		{http.MethodPost, LevelPath + "taskrun-controller?level=debug", http.StatusMethodNotAllowed},
+		{http.MethodDelete, LevelPath + "unknown", http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		levels.ServeHTTP(w, httptest.NewRequest(tc.method, tc.url, nil))
+		if w.Code != tc.status {
+			t.Errorf("%s %s: expected status %d, got %d", tc.method, tc.url, tc.status, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	levels.ServeHTTP(w, httptest.NewRequest(http.MethodGet, LevelPath, nil))
+	var got map[string]EffectiveLevel
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("Decoding levels: %v", err)
+	}
+	expected := map[string]EffectiveLevel{
+		"taskrun-controller":      {Level: "debug", Source: LevelSourceOverride},
+		"pipeline-controller":     {Level: "warn", Source: LevelSourceConfig},
+		"githubchecks-controller": {Level: "info", Source: LevelSourceDefault},
+	}
+	if d := cmp.Diff(expected, got); d != "" {
+		t.Errorf("Unexpected levels: %s", d)
+	}
+}
+
+func TestTraceLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core).Sugar()
+
+	logger.Debug("not traced")
+	TraceLogger(logger).Debug("traced")
+
+	if d := cmp.Diff([]string{"traced"}, messages(logs)); d != "" {
+		t.Errorf("Unexpected logs: %s", d)
+	}
+}
+
+func TestTraced(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		expected    bool
+	}{
+		{nil, false},
+		{map[string]string{TraceAnnotation: "true"}, true},
+		{map[string]string{TraceAnnotation: "false"}, false},
+		{map[string]string{TraceAnnotation: "yes please"}, false},
+	} {
+		if got := Traced(&metav1.ObjectMeta{Annotations: tc.annotations}); got != tc.expected {
+			t.Errorf("Traced(%v): expected %t, got %t", tc.annotations, tc.expected, got)
+		}
+	}
+}
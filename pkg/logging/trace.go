@@ -0,0 +1,44 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TraceAnnotation is the annotation of a TaskRun or a PipelineRun that makes
+// the controller log its reconciles at debug level, whatever the level of its
+// loggers.
+const TraceAnnotation = "tekton.dev/trace-reconciles"
+
+// Traced returns whether obj has TraceAnnotation set to true.
+func Traced(obj metav1.Object) bool {
+	traced, _ := strconv.ParseBool(obj.GetAnnotations()[TraceAnnotation])
+	return traced
+}
+
+// TraceLogger returns a copy of logger logging at debug level.
+func TraceLogger(logger *zap.SugaredLogger) *zap.SugaredLogger {
+	debug := &loggerLevel{effective: int32(zapcore.DebugLevel)}
+	return logger.Desugar().WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return &levelCore{Core: c, level: debug}
+	})).Sugar()
+}
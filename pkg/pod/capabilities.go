@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	dockerSocketVolumeName  = "tekton-internal-docker-socket"
+	dockerStorageVolumeName = "tekton-internal-docker-storage"
+	dockerSocketDir         = "/tekton/docker"
+
+	buildkitSocketVolumeName = "tekton-internal-buildkit-socket"
+	buildkitSocketDir        = "/tekton/buildkit"
+)
+
+// capability holds everything that is added to a Pod to provide a
+// TaskCapability to its steps.
+type capability struct {
+	// sidecar is run alongside the steps, stopped with the other sidecars.
+	sidecar corev1.Container
+	// volumes are added to the Pod.
+	volumes []corev1.Volume
+	// stepEnv is added to every step, unless the step sets the same name.
+	stepEnv []corev1.EnvVar
+	// stepVolumeMounts are added to every step.
+	stepVolumeMounts []corev1.VolumeMount
+}
+
+func emptyDirVolume(name string) corev1.Volume {
+	return corev1.Volume{
+		Name:         name,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+}
+
+// getCapability returns the capability the controller provides for c, using
+// the operator-configured images.
+func getCapability(images pipeline.Images, c v1alpha1.TaskCapability) (*capability, error) {
+	privileged := true
+	switch c {
+	case v1alpha1.TaskCapabilityDocker:
+		return &capability{
+			sidecar: corev1.Container{
+				Name:  "docker-daemon",
+				Image: images.DockerDaemonImage,
+				// Only listen on the shared socket; the daemon is not
+				// reachable over the network.
+				Args: []string{"--host=unix://" + dockerSocketDir + "/docker.sock"},
+				Env: []corev1.EnvVar{{
+					Name:  "DOCKER_TLS_CERTDIR",
+					Value: "",
+				}},
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      dockerSocketVolumeName,
+					MountPath: dockerSocketDir,
+				}, {
+					Name:      dockerStorageVolumeName,
+					MountPath: "/var/lib/docker",
+				}},
+			},
+			volumes: []corev1.Volume{emptyDirVolume(dockerSocketVolumeName), emptyDirVolume(dockerStorageVolumeName)},
+			stepEnv: []corev1.EnvVar{{
+				Name:  "DOCKER_HOST",
+				Value: "unix://" + dockerSocketDir + "/docker.sock",
+			}},
+			stepVolumeMounts: []corev1.VolumeMount{{
+				Name:      dockerSocketVolumeName,
+				MountPath: dockerSocketDir,
+			}},
+		}, nil
+	case v1alpha1.TaskCapabilityBuildkit:
+		return &capability{
+			sidecar: corev1.Container{
+				Name:            "buildkit-daemon",
+				Image:           images.BuildkitDaemonImage,
+				Args:            []string{"--addr", "unix://" + buildkitSocketDir + "/buildkitd.sock"},
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      buildkitSocketVolumeName,
+					MountPath: buildkitSocketDir,
+				}},
+			},
+			volumes: []corev1.Volume{emptyDirVolume(buildkitSocketVolumeName)},
+			stepEnv: []corev1.EnvVar{{
+				Name:  "BUILDKIT_HOST",
+				Value: "unix://" + buildkitSocketDir + "/buildkitd.sock",
+			}},
+			stepVolumeMounts: []corev1.VolumeMount{{
+				Name:      buildkitSocketVolumeName,
+				MountPath: buildkitSocketDir,
+			}},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown capability %q", c)
+	}
+}
+
+// applyCapabilities adds the env and volume mounts of each requested
+// capability to the steps, and returns the sidecars and volumes that must be
+// added to the Pod to provide them.
+func applyCapabilities(images pipeline.Images, capabilities []v1alpha1.TaskCapability, steps []corev1.Container) ([]corev1.Container, []corev1.Volume, error) {
+	var sidecars []corev1.Container
+	var volumes []corev1.Volume
+	for _, c := range capabilities {
+		provided, err := getCapability(images, c)
+		if err != nil {
+			return nil, nil, err
+		}
+		sidecars = append(sidecars, provided.sidecar)
+		volumes = append(volumes, provided.volumes...)
+		for i, s := range steps {
+			// Prepend the env so that a value set explicitly on the step
+			// takes precedence.
+			steps[i].Env = append(append([]corev1.EnvVar{}, provided.stepEnv...), s.Env...)
+			steps[i].VolumeMounts = append(steps[i].VolumeMounts, provided.stepVolumeMounts...)
+		}
+	}
+	return sidecars, volumes, nil
+}
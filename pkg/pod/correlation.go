@@ -0,0 +1,56 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// CorrelationIDAnnotation is the annotation holding the identity of the
+	// request that created a run, for example the ID of a webhook delivery.
+	CorrelationIDAnnotation = "tekton.dev/correlation-id"
+	// TraceparentAnnotation holds a W3C Trace Context traceparent header.
+	TraceparentAnnotation = "tekton.dev/traceparent"
+	// TracestateAnnotation holds a W3C Trace Context tracestate header.
+	TracestateAnnotation = "tekton.dev/tracestate"
+)
+
+// correlationEnv maps the correlation annotations to the env vars they're
+// exposed to the steps as. TRACEPARENT and TRACESTATE are the names the
+// OpenTelemetry SDKs read a parent context from.
+var correlationEnv = []struct {
+	annotation string
+	env        string
+}{
+	{CorrelationIDAnnotation, "TEKTON_CORRELATION_ID"},
+	{TraceparentAnnotation, "TRACEPARENT"},
+	{TracestateAnnotation, "TRACESTATE"},
+}
+
+// correlationEnvVars returns the env vars exposing the correlation
+// annotations of the TaskRun, which it inherits from its PipelineRun, to the
+// steps.
+func correlationEnvVars(annotations map[string]string) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, c := range correlationEnv {
+		if v := annotations[c.annotation]; v != "" {
+			env = append(env, corev1.EnvVar{Name: c.env, Value: v})
+		}
+	}
+	return env
+}
@@ -17,26 +17,68 @@ limitations under the License.
 package pod
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/credentials"
 	"github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
 	"github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+// DisableCredsInitAnnotation is the annotation that, set to "true" on a
+// TaskRun or on its Namespace, stops its Pod from initializing credentials
+// from the Secrets of its ServiceAccount.
+const DisableCredsInitAnnotation = "tekton.dev/disable-creds-init"
+
+// credsInitDisabled returns true if creds init is disabled for taskRun, by the
+// "disable-creds-init" feature flag or by DisableCredsInitAnnotation on the
+// TaskRun or its Namespace.
+func credsInitDisabled(ctx context.Context, taskRun *v1alpha1.TaskRun, kubeclient kubernetes.Interface) (bool, error) {
+	if config.FromContextOrDefaults(ctx).FeatureFlags.DisableCredsInit {
+		return true, nil
+	}
+	if disabled, err := annotationDisablesCredsInit(taskRun.Annotations); disabled || err != nil {
+		return disabled, err
+	}
+	ns, err := kubeclient.CoreV1().Namespaces().Get(taskRun.Namespace, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return annotationDisablesCredsInit(ns.Annotations)
+}
+
+func annotationDisablesCredsInit(annotations map[string]string) (bool, error) {
+	v, ok := annotations[DisableCredsInitAnnotation]
+	if !ok {
+		return false, nil
+	}
+	disabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q for annotation %q: %v", v, DisableCredsInitAnnotation, err)
+	}
+	return disabled, nil
+}
+
 // credsInit returns an init container that initializes credentials based on
-// annotated secrets available to the service account.
+// annotated secrets available to the service account. Secrets that don't
+// match the creds init secret selectors of the feature flags are ignored.
 //
 // If no such secrets are found, it returns a nil container, and no creds init
 // process is necessary.
 //
 // If it finds secrets, it also returns a set of Volumes to attach to the Pod
 // to provide those secrets to this initialization.
-func credsInit(credsImage string, serviceAccountName, namespace string, kubeclient kubernetes.Interface, volumeMounts []corev1.VolumeMount, implicitEnvVars []corev1.EnvVar) (*corev1.Container, []corev1.Volume, error) {
+func credsInit(ctx context.Context, credsImage string, serviceAccountName, namespace string, kubeclient kubernetes.Interface, volumeMounts []corev1.VolumeMount, implicitEnvVars []corev1.EnvVar) (*corev1.Container, []corev1.Volume, error) {
 	if serviceAccountName == "" {
 		serviceAccountName = "default"
 	}
@@ -46,6 +88,7 @@ func credsInit(credsImage string, serviceAccountName, namespace string, kubeclie
 		return nil, nil, err
 	}
 
+	featureFlags := config.FromContextOrDefaults(ctx).FeatureFlags
 	builders := []credentials.Builder{dockercreds.NewBuilder(), gitcreds.NewBuilder()}
 
 	var volumes []corev1.Volume
@@ -55,6 +98,9 @@ func credsInit(credsImage string, serviceAccountName, namespace string, kubeclie
 		if err != nil {
 			return nil, nil, err
 		}
+		if !featureFlags.CredsInitSecretMatches(secret) {
+			continue
+		}
 
 		matched := false
 		for _, b := range builders {
@@ -17,9 +17,12 @@ limitations under the License.
 package pod
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/test/names"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,8 +44,39 @@ func TestCredsInit(t *testing.T) {
 		Value: "bar",
 	}}
 
+	annotatedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-creds",
+			Namespace: namespace,
+			Labels:    map[string]string{"tekton.dev/creds-init": "allowed"},
+			Annotations: map[string]string{
+				"tekton.dev/git-0": "github.com",
+			},
+		},
+		Type: "kubernetes.io/basic-auth",
+		Data: map[string][]byte{
+			"username": []byte("foo"),
+			"password": []byte("BestEver"),
+		},
+	}
+	serviceAccountWithSecret := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceAccountName, Namespace: namespace},
+		Secrets: []corev1.ObjectReference{{
+			Name: "my-creds",
+		}},
+	}
+	withSelectors := func(labelSelector, annotationSelector string) context.Context {
+		cfg := config.FromContextOrDefaults(context.Background())
+		cfg.FeatureFlags = &config.FeatureFlags{
+			CredsInitSecretLabelSelector:      labelSelector,
+			CredsInitSecretAnnotationSelector: annotationSelector,
+		}
+		return config.ToContext(context.Background(), cfg)
+	}
+
 	for _, c := range []struct {
 		desc string
+		ctx  context.Context
 		want *corev1.Container
 		objs []runtime.Object
 	}{{
@@ -114,11 +148,40 @@ func TestCredsInit(t *testing.T) {
 				MountPath: "/var/build-secrets/my-creds",
 			}),
 		},
+	}, {
+		desc: "annotated secret matches selectors; initialize creds",
+		ctx:  withSelectors("tekton.dev/creds-init=allowed", "tekton.dev/git-0"),
+		objs: []runtime.Object{serviceAccountWithSecret, annotatedSecret},
+		want: &corev1.Container{
+			Name:    "credential-initializer-mz4c7",
+			Image:   images.CredsImage,
+			Command: []string{"/ko-app/creds-init"},
+			Args:    []string{"-basic-git=my-creds=github.com"},
+			Env:     envVars,
+			VolumeMounts: append(volumeMounts, corev1.VolumeMount{
+				Name:      "secret-volume-my-creds-9l9zj",
+				MountPath: "/var/build-secrets/my-creds",
+			}),
+		},
+	}, {
+		desc: "annotated secret doesn't match label selector; nothing to initialize",
+		ctx:  withSelectors("tekton.dev/creds-init=forbidden", ""),
+		objs: []runtime.Object{serviceAccountWithSecret, annotatedSecret},
+		want: nil,
+	}, {
+		desc: "annotated secret doesn't match annotation selector; nothing to initialize",
+		ctx:  withSelectors("", "tekton.dev/docker-0"),
+		objs: []runtime.Object{serviceAccountWithSecret, annotatedSecret},
+		want: nil,
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			names.TestingSeed()
+			ctx := c.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
 			kubeclient := fakek8s.NewSimpleClientset(c.objs...)
-			got, volumes, err := credsInit(images.CredsImage, serviceAccountName, namespace, kubeclient, volumeMounts, envVars)
+			got, volumes, err := credsInit(ctx, images.CredsImage, serviceAccountName, namespace, kubeclient, volumeMounts, envVars)
 			if err != nil {
 				t.Fatalf("credsInit: %v", err)
 			}
@@ -131,3 +194,76 @@ func TestCredsInit(t *testing.T) {
 		})
 	}
 }
+
+func TestCredsInitDisabled(t *testing.T) {
+	withFeatureFlags := func(disableCredsInit bool) context.Context {
+		cfg := config.FromContextOrDefaults(context.Background())
+		cfg.FeatureFlags = &config.FeatureFlags{DisableCredsInit: disableCredsInit}
+		return config.ToContext(context.Background(), cfg)
+	}
+	for _, c := range []struct {
+		desc        string
+		ctx         context.Context
+		annotations map[string]string
+		objs        []runtime.Object
+		want        bool
+	}{{
+		desc: "enabled by default",
+		ctx:  context.Background(),
+		want: false,
+	}, {
+		desc: "disabled by feature flag",
+		ctx:  withFeatureFlags(true),
+		want: true,
+	}, {
+		desc:        "disabled by taskrun annotation",
+		ctx:         context.Background(),
+		annotations: map[string]string{DisableCredsInitAnnotation: "true"},
+		want:        true,
+	}, {
+		desc:        "enabled by taskrun annotation",
+		ctx:         context.Background(),
+		annotations: map[string]string{DisableCredsInitAnnotation: "false"},
+		want:        false,
+	}, {
+		desc: "disabled by namespace annotation",
+		ctx:  context.Background(),
+		objs: []runtime.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        namespace,
+			Annotations: map[string]string{DisableCredsInitAnnotation: "true"},
+		}}},
+		want: true,
+	}, {
+		desc:        "taskrun annotation can't enable what the feature flag disables",
+		ctx:         withFeatureFlags(true),
+		annotations: map[string]string{DisableCredsInitAnnotation: "false"},
+		want:        true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			kubeclient := fakek8s.NewSimpleClientset(c.objs...)
+			tr := &v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{
+				Name:        "taskrun",
+				Namespace:   namespace,
+				Annotations: c.annotations,
+			}}
+			got, err := credsInitDisabled(c.ctx, tr, kubeclient)
+			if err != nil {
+				t.Fatalf("credsInitDisabled: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("credsInitDisabled() = %t, want %t", got, c.want)
+			}
+		})
+	}
+}
+
+func TestCredsInitDisabledInvalidAnnotation(t *testing.T) {
+	tr := &v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{
+		Name:        "taskrun",
+		Namespace:   namespace,
+		Annotations: map[string]string{DisableCredsInitAnnotation: "yes please"},
+	}}
+	if _, err := credsInitDisabled(context.Background(), tr, fakek8s.NewSimpleClientset()); err == nil {
+		t.Error("credsInitDisabled() expected an error")
+	}
+}
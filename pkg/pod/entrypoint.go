@@ -20,8 +20,12 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -74,6 +78,16 @@ var (
 	}
 )
 
+// stepOptions are the fields of a Step that change how the entrypoint runs
+// its command.
+type stepOptions struct {
+	alwaysRun    bool
+	retries      int
+	retryBackoff time.Duration
+	timeout      time.Duration
+	onError      v1alpha1.OnErrorType
+}
+
 // orderContainers returns the specified steps, modified so that they are
 // executed in order by overriding the entrypoint binary. It also returns the
 // init container that places the entrypoint binary pulled from the
@@ -83,8 +97,14 @@ var (
 // command, we must have fetched the image's ENTRYPOINT before calling this
 // method, using entrypoint_lookup.go.
 //
+// The options of a step are looked up by its index.
+// If startTimeout isn't 0, the first step fails if the Downward volume file
+// signalling that the steps can start doesn't appear in time.
+// The last step reports the resultFiles the steps wrote in its termination
+// message.
+//
 // TODO(#1605): Also use entrypoint injection to order sidecar start/stop.
-func orderContainers(entrypointImage string, steps []corev1.Container) (corev1.Container, []corev1.Container, error) {
+func orderContainers(entrypointImage string, steps []corev1.Container, options map[int]stepOptions, startTimeout time.Duration, resultFiles []string) (corev1.Container, []corev1.Container, error) {
 	toolsInit := corev1.Container{
 		Name:         "place-tools",
 		Image:        entrypointImage,
@@ -107,6 +127,9 @@ func orderContainers(entrypointImage string, steps []corev1.Container) (corev1.C
 				// Start next step.
 				"-post_file", filepath.Join(mountPoint, fmt.Sprintf("%d", i)),
 			}
+			if startTimeout > 0 {
+				argsForEntrypoint = append(argsForEntrypoint, "-wait_file_timeout", startTimeout.String())
+			}
 		default:
 			// All other steps wait for previous file, write next file.
 			argsForEntrypoint = []string{
@@ -114,6 +137,32 @@ func orderContainers(entrypointImage string, steps []corev1.Container) (corev1.C
 				"-post_file", filepath.Join(mountPoint, fmt.Sprintf("%d", i)),
 			}
 		}
+		reportsResults := i == len(steps)-1 && len(resultFiles) > 0
+		if reportsResults {
+			argsForEntrypoint = append(argsForEntrypoint, "-results", strings.Join(resultFiles, ","))
+		}
+		if (i == 0 && startTimeout > 0) || reportsResults || options[i].timeout > 0 {
+			terminationPath := s.TerminationMessagePath
+			if terminationPath == "" {
+				terminationPath = corev1.TerminationMessagePathDefault
+			}
+			argsForEntrypoint = append(argsForEntrypoint, "-termination_path", terminationPath)
+		}
+		if options[i].alwaysRun {
+			argsForEntrypoint = append(argsForEntrypoint, "-always_run")
+		}
+		if options[i].retries > 0 {
+			argsForEntrypoint = append(argsForEntrypoint, "-retries", strconv.Itoa(options[i].retries))
+			if options[i].retryBackoff > 0 {
+				argsForEntrypoint = append(argsForEntrypoint, "-retry_backoff", options[i].retryBackoff.String())
+			}
+		}
+		if options[i].timeout > 0 {
+			argsForEntrypoint = append(argsForEntrypoint, "-timeout", options[i].timeout.String())
+		}
+		if options[i].onError == v1alpha1.StepOnErrorContinue {
+			argsForEntrypoint = append(argsForEntrypoint, "-on_error", string(options[i].onError))
+		}
 
 		cmd, args := s.Command, s.Args
 		if len(cmd) == 0 {
@@ -191,6 +240,11 @@ func StopSidecars(nopImage string, kubeclient kubernetes.Interface, pod corev1.P
 	return nil
 }
 
+// stepContainerName returns the name of the container of the step named name.
+func stepContainerName(name string) string {
+	return names.SimpleNameGenerator.RestrictLength(stepPrefix + name)
+}
+
 // isContainerStep returns true if the container name indicates that it
 // represents a step.
 func isContainerStep(name string) bool { return strings.HasPrefix(name, stepPrefix) }
@@ -25,6 +25,7 @@ import (
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	lru "github.com/hashicorp/golang-lru"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -48,7 +49,7 @@ func NewEntrypointCache(kubeclient kubernetes.Interface) (EntrypointCache, error
 	}, nil
 }
 
-func (e *entrypointCache) Get(ref name.Reference, namespace, serviceAccountName string) (v1.Image, error) {
+func (e *entrypointCache) Get(ref name.Reference, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference) (v1.Image, error) {
 	// If image is specified by digest, check the local cache.
 	if digest, ok := ref.(name.Digest); ok {
 		if img, ok := e.lru.Get(digest.String()); ok {
@@ -59,9 +60,14 @@ func (e *entrypointCache) Get(ref name.Reference, namespace, serviceAccountName
 	// If the image wasn't specified by digest, or if the entrypoint
 	// wasn't found, we have to consult the remote registry, using
 	// imagePullSecrets.
+	var pullSecrets []string
+	for _, s := range imagePullSecrets {
+		pullSecrets = append(pullSecrets, s.Name)
+	}
 	kc, err := k8schain.New(e.kubeclient, k8schain.Options{
 		Namespace:          namespace,
 		ServiceAccountName: serviceAccountName,
+		ImagePullSecrets:   pullSecrets,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error creating k8schain: %v", err)
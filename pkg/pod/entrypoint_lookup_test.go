@@ -52,7 +52,7 @@ func TestResolveEntrypoints(t *testing.T) {
 		"gcr.io/my/image:latest":          &data{img: img},
 	}
 
-	got, err := resolveEntrypoints(cache, "namespace", "serviceAccountName", []corev1.Container{{
+	got, err := resolveEntrypoints(cache, "namespace", "serviceAccountName", nil, []corev1.Container{{
 		// This step specifies its command, so there's nothing to
 		// resolve.
 		Image:   "fully-specified",
@@ -109,7 +109,7 @@ type data struct {
 	seen bool // Whether the image has been looked up before.
 }
 
-func (f fakeCache) Get(ref name.Reference, _, _ string) (v1.Image, error) {
+func (f fakeCache) Get(ref name.Reference, _, _ string, _ []corev1.LocalObjectReference) (v1.Image, error) {
 	if d, ok := ref.(name.Digest); ok {
 		if data, found := f[d.String()]; found {
 			return data.img, nil
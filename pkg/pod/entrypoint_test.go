@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
@@ -79,7 +80,7 @@ func TestOrderContainers(t *testing.T) {
 		},
 		VolumeMounts: []corev1.VolumeMount{toolsMount},
 	}}
-	gotInit, got, err := orderContainers(images.EntrypointImage, steps)
+	gotInit, got, err := orderContainers(images.EntrypointImage, steps, nil, 0, nil)
 	if err != nil {
 		t.Fatalf("orderContainers: %v", err)
 	}
@@ -98,6 +99,244 @@ func TestOrderContainers(t *testing.T) {
 	}
 }
 
+func TestOrderContainersAlwaysRun(t *testing.T) {
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-2",
+		Command: []string{"cleanup"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount, downwardMount},
+	}, {
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/0",
+			"-post_file", "/tekton/tools/1",
+			"-always_run",
+			"-entrypoint", "cleanup", "--",
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount},
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, steps, map[int]stepOptions{1: {alwaysRun: true}}, 0, nil)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff (-want, +got): %s", d)
+	}
+}
+
+func TestOrderContainersRetries(t *testing.T) {
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-2",
+		Command: []string{"flaky-test"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-retries", "2",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount, downwardMount},
+	}, {
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/0",
+			"-post_file", "/tekton/tools/1",
+			"-retries", "3",
+			"-retry_backoff", "10s",
+			"-entrypoint", "flaky-test", "--",
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount},
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, steps, map[int]stepOptions{
+		0: {retries: 2},
+		1: {retries: 3, retryBackoff: 10 * time.Second},
+	}, 0, nil)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff (-want, +got): %s", d)
+	}
+}
+
+func TestOrderContainersTimeout(t *testing.T) {
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Image:                  "step-2",
+		Command:                []string{"slow-test"},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount, downwardMount},
+	}, {
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/0",
+			"-post_file", "/tekton/tools/1",
+			"-termination_path", "/tekton/termination",
+			"-timeout", "10m0s",
+			"-entrypoint", "slow-test", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, steps, map[int]stepOptions{
+		1: {timeout: 10 * time.Minute},
+	}, 0, nil)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff (-want, +got): %s", d)
+	}
+}
+
+func TestOrderContainersOnError(t *testing.T) {
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"lint"},
+	}, {
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-on_error", "continue",
+			"-entrypoint", "lint", "--",
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount, downwardMount},
+	}, {
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/0",
+			"-post_file", "/tekton/tools/1",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount},
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, steps, map[int]stepOptions{
+		0: {onError: v1alpha1.StepOnErrorContinue},
+		1: {onError: v1alpha1.StepOnErrorStopAndFail},
+	}, 0, nil)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff (-want, +got): %s", d)
+	}
+}
+
+func TestOrderContainersStartTimeout(t *testing.T) {
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Image:                  "step-2",
+		Command:                []string{"cmd"},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-wait_file_timeout", "5m0s",
+			"-termination_path", "/dev/termination-log",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts: []corev1.VolumeMount{toolsMount, downwardMount},
+	}, {
+		// Only the first step waits for the Pod to start, the others wait
+		// as long as the steps before them run.
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/tools/0",
+			"-post_file", "/tekton/tools/1",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, steps, nil, 5*time.Minute, nil)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff (-want, +got): %s", d)
+	}
+}
+
+func TestOrderContainersResults(t *testing.T) {
+	steps := []corev1.Container{{
+		Image:                  "step-1",
+		Command:                []string{"cmd"},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/tools/0",
+			"-wait_file_timeout", "5m0s",
+			"-results", "/tekton/results/commit,/tekton/results/digest",
+			"-termination_path", "/tekton/termination",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{toolsMount, downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	_, got, err := orderContainers(images.EntrypointImage, steps, nil, 5*time.Minute, []string{"/tekton/results/commit", "/tekton/results/digest"})
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff (-want, +got): %s", d)
+	}
+}
+
 func TestUpdateReady(t *testing.T) {
 	for _, c := range []struct {
 		desc            string
@@ -0,0 +1,52 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// applyMemoryVolumes mounts each of the memoryVolumes into the steps it
+// names, or into every step if it names none, and returns the emptyDir
+// volumes that must be added to the Pod to back them.
+func applyMemoryVolumes(memoryVolumes []v1alpha1.MemoryVolume, steps []corev1.Container) []corev1.Volume {
+	var volumes []corev1.Volume
+	for _, mv := range memoryVolumes {
+		size := mv.Size
+		volumes = append(volumes, corev1.Volume{
+			Name: mv.Name,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium:    corev1.StorageMediumMemory,
+				SizeLimit: &size,
+			}},
+		})
+		mountInto := map[string]bool{}
+		for _, s := range mv.Steps {
+			mountInto[s] = true
+		}
+		for i, s := range steps {
+			if len(mountInto) == 0 || mountInto[s.Name] {
+				steps[i].VolumeMounts = append(steps[i].VolumeMounts, corev1.VolumeMount{
+					Name:      mv.Name,
+					MountPath: mv.MountPath,
+				})
+			}
+		}
+	}
+	return volumes
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// applyStepOverrides replaces the compute resources of the steps named by the
+// overrides of the TaskRun.
+func applyStepOverrides(steps []v1alpha1.Step, overrides []v1alpha1.TaskRunStepOverride) error {
+	for _, o := range overrides {
+		found := false
+		for i := range steps {
+			if steps[i].Name == o.Name {
+				steps[i].Resources = o.Resources
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("stepOverrides: no step named %q", o.Name)
+		}
+	}
+	return nil
+}
+
+// applySidecarOverrides returns a copy of the sidecars in which the compute
+// resources of the sidecars named by the overrides of the TaskRun are
+// replaced. The sidecars of the Task itself are left untouched.
+func applySidecarOverrides(sidecars []corev1.Container, overrides []v1alpha1.TaskRunSidecarOverride) ([]corev1.Container, error) {
+	if len(overrides) == 0 {
+		return sidecars, nil
+	}
+	overridden := make([]corev1.Container, len(sidecars))
+	for i, s := range sidecars {
+		overridden[i] = *s.DeepCopy()
+	}
+	for _, o := range overrides {
+		found := false
+		for i := range overridden {
+			if overridden[i].Name == o.Name {
+				overridden[i].Resources = o.Resources
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("sidecarOverrides: no sidecar named %q", o.Name)
+		}
+	}
+	return overridden, nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// nodeOSLabel and nodeArchLabel are the labels the kubelet sets on its
+	// Node to the operating system and the architecture it runs on.
+	nodeOSLabel   = "kubernetes.io/os"
+	nodeArchLabel = "kubernetes.io/arch"
+)
+
+// PlatformNodeLabels returns the labels of the Nodes that run platform,
+// written <os>/<arch>.
+func PlatformNodeLabels(platform string) labels.Set {
+	parts := strings.SplitN(platform, "/", 2)
+	l := labels.Set{nodeOSLabel: parts[0]}
+	if len(parts) == 2 {
+		l[nodeArchLabel] = parts[1]
+	}
+	return l
+}
+
+// platformAffinity returns affinity with its required node affinity
+// restricted to the Nodes that run one of the platforms. The node selector
+// terms of affinity, if any, must still match.
+func platformAffinity(platforms []string, affinity *corev1.Affinity) *corev1.Affinity {
+	if len(platforms) == 0 {
+		return affinity
+	}
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	} else {
+		affinity = affinity.DeepCopy()
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil {
+		required = &corev1.NodeSelector{}
+	}
+	// The terms are ORed and the expressions of a term ANDed: each existing
+	// term is combined with each platform.
+	existing := required.NodeSelectorTerms
+	if len(existing) == 0 {
+		existing = []corev1.NodeSelectorTerm{{}}
+	}
+	var terms []corev1.NodeSelectorTerm
+	for _, term := range existing {
+		for _, platform := range platforms {
+			t := *term.DeepCopy()
+			l := PlatformNodeLabels(platform)
+			for _, key := range []string{nodeOSLabel, nodeArchLabel} {
+				if value, ok := l[key]; ok {
+					t.MatchExpressions = append(t.MatchExpressions, corev1.NodeSelectorRequirement{
+						Key:      key,
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{value},
+					})
+				}
+			}
+			terms = append(terms, t)
+		}
+	}
+	required.NodeSelectorTerms = terms
+	affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required
+	return affinity
+}
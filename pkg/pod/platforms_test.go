@@ -0,0 +1,85 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPlatformAffinity(t *testing.T) {
+	platform := func(os, arch string, extra ...corev1.NodeSelectorRequirement) corev1.NodeSelectorTerm {
+		return corev1.NodeSelectorTerm{MatchExpressions: append(extra, corev1.NodeSelectorRequirement{
+			Key:      nodeOSLabel,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{os},
+		}, corev1.NodeSelectorRequirement{
+			Key:      nodeArchLabel,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{arch},
+		})}
+	}
+	gpu := corev1.NodeSelectorRequirement{
+		Key:      "gpu",
+		Operator: corev1.NodeSelectorOpExists,
+	}
+	preferred := []corev1.PreferredSchedulingTerm{{
+		Weight:     1,
+		Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{gpu}},
+	}}
+	for _, tc := range []struct {
+		name      string
+		platforms []string
+		affinity  *corev1.Affinity
+		want      *corev1.Affinity
+	}{{
+		name:     "no platforms",
+		affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{PreferredDuringSchedulingIgnoredDuringExecution: preferred}},
+		want:     &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{PreferredDuringSchedulingIgnoredDuringExecution: preferred}},
+	}, {
+		name:      "platforms",
+		platforms: []string{"linux/amd64", "linux/arm64"},
+		want: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{platform("linux", "amd64"), platform("linux", "arm64")},
+			},
+		}},
+	}, {
+		name:      "platforms and pod template affinity",
+		platforms: []string{"linux/amd64", "linux/arm64"},
+		affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{gpu}}},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}},
+		want: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{platform("linux", "amd64", gpu), platform("linux", "arm64", gpu)},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, platformAffinity(tc.platforms, tc.affinity)); d != "" {
+				t.Errorf("platformAffinity() diff -want, +got: %s", d)
+			}
+		})
+	}
+}
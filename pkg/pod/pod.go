@@ -17,11 +17,16 @@ limitations under the License.
 package pod
 
 import (
+	"context"
 	"fmt"
+	"hash/fnv"
 	"path/filepath"
+	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +41,10 @@ const (
 	taskRunLabelKey     = pipeline.GroupName + pipeline.TaskRunLabelKey
 	ManagedByLabelKey   = "app.kubernetes.io/managed-by"
 	ManagedByLabelValue = "tekton-pipelines"
+
+	// maxPodNameBaseLength is the maximum length of the name of a Pod before
+	// the 5 characters suffix added by PodName.
+	maxPodNameBaseLength = 63 - 6
 )
 
 // These are effectively const, but Go doesn't have such an annotation.
@@ -66,21 +75,41 @@ var (
 	}}
 )
 
+// deadlineEnvVars returns the env var telling the steps the time the TaskRun
+// times out at, which the entrypoint also tells them the remaining seconds
+// until. There is none if the TaskRun has no timeout.
+func deadlineEnvVars(tr *v1alpha1.TaskRun) []corev1.EnvVar {
+	if tr.Status.StartTime == nil || tr.Spec.Timeout == nil || tr.Spec.Timeout.Duration <= 0 {
+		return nil
+	}
+	deadline := tr.Status.StartTime.Add(tr.Spec.Timeout.Duration)
+	return []corev1.EnvVar{{
+		Name:  entrypoint.DeadlineEnvVar,
+		Value: deadline.UTC().Format(time.RFC3339),
+	}}
+}
+
 // MakePod converts TaskRun and TaskSpec objects to a Pod which implements the taskrun specified
 // by the supplied CRD.
-func MakePod(images pipeline.Images, taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient kubernetes.Interface, entrypointCache EntrypointCache) (*corev1.Pod, error) {
+func MakePod(ctx context.Context, images pipeline.Images, taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient kubernetes.Interface, entrypointCache EntrypointCache) (*corev1.Pod, error) {
 	var initContainers []corev1.Container
-	var volumes []corev1.Volume
+	var volumes, credsVolumes []corev1.Volume
 
 	// Add our implicit volumes first, so they can be overridden by the user if they prefer.
 	volumes = append(volumes, implicitVolumes...)
 
-	// Inititalize any credentials found in annotated Secrets.
-	if credsInitContainer, secretsVolumes, err := credsInit(images.CredsImage, taskRun.Spec.ServiceAccountName, taskRun.Namespace, kubeclient, implicitVolumeMounts, implicitEnvVars); err != nil {
+	// Inititalize any credentials found in annotated Secrets, unless creds
+	// init is disabled for this TaskRun.
+	if disabled, err := credsInitDisabled(ctx, taskRun, kubeclient); err != nil {
 		return nil, err
-	} else if credsInitContainer != nil {
-		initContainers = append(initContainers, *credsInitContainer)
-		volumes = append(volumes, secretsVolumes...)
+	} else if !disabled {
+		if credsInitContainer, secretsVolumes, err := credsInit(ctx, images.CredsImage, taskRun.Spec.ServiceAccountName, taskRun.Namespace, kubeclient, implicitVolumeMounts, implicitEnvVars); err != nil {
+			return nil, err
+		} else if credsInitContainer != nil {
+			initContainers = append(initContainers, *credsInitContainer)
+			volumes = append(volumes, secretsVolumes...)
+			credsVolumes = secretsVolumes
+		}
 	}
 
 	// Merge step template with steps.
@@ -89,6 +118,13 @@ func MakePod(images pipeline.Images, taskRun *v1alpha1.TaskRun, taskSpec v1alpha
 	if err != nil {
 		return nil, err
 	}
+	if err := applyStepOverrides(steps, taskRun.Spec.StepOverrides); err != nil {
+		return nil, err
+	}
+	sidecars, err := applySidecarOverrides(taskSpec.Sidecars, taskRun.Spec.SidecarOverrides)
+	if err != nil {
+		return nil, err
+	}
 
 	// Convert any steps with Script to command+args.
 	// If any are found, append an init container to initialize scripts.
@@ -104,14 +140,33 @@ func MakePod(images pipeline.Images, taskRun *v1alpha1.TaskRun, taskSpec v1alpha
 	}
 
 	// Resolve entrypoint for any steps that don't specify command.
-	stepContainers, err = resolveEntrypoints(entrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, stepContainers)
+	stepContainers, err = resolveEntrypoints(entrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, taskRun.Spec.PodTemplate.ImagePullSecrets, stepContainers)
 	if err != nil {
 		return nil, err
 	}
 
 	// Rewrite steps with entrypoint binary. Append the entrypoint init
 	// container to place the entrypoint binary.
-	entrypointInit, stepContainers, err := orderContainers(images.EntrypointImage, stepContainers)
+	options := map[int]stepOptions{}
+	for i, s := range steps {
+		o := stepOptions{alwaysRun: s.AlwaysRun, retries: s.Retries, onError: s.OnError}
+		if s.RetryBackoff != nil {
+			o.retryBackoff = s.RetryBackoff.Duration
+		}
+		if s.Timeout != nil {
+			o.timeout = s.Timeout.Duration
+		}
+		options[i] = o
+	}
+	// Mount the directory the steps write the results to; the last step
+	// reports them.
+	resultFiles, resultsVolume := applyResults(taskSpec.Results, stepContainers)
+	if resultsVolume != nil {
+		volumes = append(volumes, *resultsVolume)
+	}
+
+	startTimeout := config.FromContextOrDefaults(ctx).Defaults.StepsStartTimeout
+	entrypointInit, stepContainers, err := orderContainers(images.EntrypointImage, stepContainers, options, startTimeout, resultFiles)
 	if err != nil {
 		return nil, err
 	}
@@ -121,11 +176,32 @@ func MakePod(images pipeline.Images, taskRun *v1alpha1.TaskRun, taskSpec v1alpha
 	// Zero out non-max resource requests, move max resource requests to the last step.
 	stepContainers = resolveResourceRequests(stepContainers)
 
-	// Add implicit env vars.
-	// They're prepended to the list, so that if the user specified any
-	// themselves their value takes precedence.
+	// Add the sidecars, volumes and step env for any requested capabilities.
+	capabilitySidecars, capabilityVolumes, err := applyCapabilities(images, taskSpec.Capabilities, stepContainers)
+	if err != nil {
+		return nil, err
+	}
+	volumes = append(volumes, capabilityVolumes...)
+
+	// Add the memory volumes, and mount them into the steps that use them.
+	memoryVolumes := applyMemoryVolumes(taskSpec.MemoryVolumes, stepContainers)
+	volumes = append(volumes, memoryVolumes...)
+
+	// Add the volumes the TaskRun binds to the workspaces, and mount them
+	// into the steps.
+	workspaceVolumes, err := applyWorkspaces(taskSpec.Workspaces, taskRun.Spec.Workspaces, stepContainers)
+	if err != nil {
+		return nil, err
+	}
+	volumes = append(volumes, workspaceVolumes...)
+
+	// Add implicit env vars, and those exposing the correlation annotations
+	// and the deadline. They're prepended to the list, so that if the user
+	// specified any themselves their value takes precedence.
+	stepEnv := append(append([]corev1.EnvVar{}, implicitEnvVars...), correlationEnvVars(taskRun.Annotations)...)
+	stepEnv = append(stepEnv, deadlineEnvVars(taskRun)...)
 	for i, s := range stepContainers {
-		env := append(implicitEnvVars, s.Env...)
+		env := append(append([]corev1.EnvVar{}, stepEnv...), s.Env...)
 		stepContainers[i].Env = env
 	}
 
@@ -158,7 +234,7 @@ func MakePod(images pipeline.Images, taskRun *v1alpha1.TaskRun, taskSpec v1alpha
 		if s.Name == "" {
 			stepContainers[i].Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%sunnamed-%d", stepPrefix, i))
 		} else {
-			stepContainers[i].Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%s%s", stepPrefix, s.Name))
+			stepContainers[i].Name = stepContainerName(s.Name)
 		}
 	}
 
@@ -169,10 +245,19 @@ func MakePod(images pipeline.Images, taskRun *v1alpha1.TaskRun, taskSpec v1alpha
 	if err := v1alpha1.ValidateVolumes(volumes); err != nil {
 		return nil, err
 	}
+	if err := checkVolumeLimit(ctx, len(volumes),
+		volumeSource{addedBy: "credentials of the ServiceAccount", volumes: credsVolumes},
+		volumeSource{addedBy: "Task and its resources", volumes: taskSpec.Volumes},
+		volumeSource{addedBy: "memoryVolumes of the Task", volumes: memoryVolumes},
+		volumeSource{addedBy: "workspaces of the TaskRun", volumes: workspaceVolumes},
+		volumeSource{addedBy: "podTemplate", volumes: taskRun.Spec.PodTemplate.Volumes},
+	); err != nil {
+		return nil, err
+	}
 
 	// Merge sidecar containers with step containers.
 	mergedPodContainers := stepContainers
-	for _, sc := range taskSpec.Sidecars {
+	for _, sc := range append(capabilitySidecars, sidecars...) {
 		sc.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, sc.Name))
 		mergedPodContainers = append(mergedPodContainers, sc)
 	}
@@ -182,11 +267,7 @@ func MakePod(images pipeline.Images, taskRun *v1alpha1.TaskRun, taskSpec v1alpha
 			// We execute the build's pod in the same namespace as where the build was
 			// created so that it can access colocated resources.
 			Namespace: taskRun.Namespace,
-			// Generate a unique name based on the build's name.
-			// Add a unique suffix to avoid confusion when a build
-			// is deleted and re-created with the same name.
-			// We don't use RestrictLengthWithRandomSuffix here because k8s fakes don't support it.
-			Name: names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-pod", taskRun.Name)),
+			Name:      PodName(taskRun),
 			// If our parent TaskRun is deleted, then we should be as well.
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(taskRun, groupVersionKind),
@@ -202,13 +283,35 @@ func MakePod(images pipeline.Images, taskRun *v1alpha1.TaskRun, taskSpec v1alpha
 			Volumes:            volumes,
 			NodeSelector:       taskRun.Spec.PodTemplate.NodeSelector,
 			Tolerations:        taskRun.Spec.PodTemplate.Tolerations,
-			Affinity:           taskRun.Spec.PodTemplate.Affinity,
+			Affinity:           platformAffinity(taskSpec.Platforms, taskRun.Spec.PodTemplate.Affinity),
 			SecurityContext:    taskRun.Spec.PodTemplate.SecurityContext,
 			RuntimeClassName:   taskRun.Spec.PodTemplate.RuntimeClassName,
+
+			ActiveDeadlineSeconds:        taskRun.Spec.PodTemplate.ActiveDeadlineSeconds,
+			EnableServiceLinks:           taskRun.Spec.PodTemplate.EnableServiceLinks,
+			AutomountServiceAccountToken: taskRun.Spec.PodTemplate.AutomountServiceAccountToken,
+			SchedulerName:                taskRun.Spec.PodTemplate.SchedulerName,
+			ImagePullSecrets:             taskRun.Spec.PodTemplate.ImagePullSecrets,
 		},
 	}, nil
 }
 
+// PodName returns the name of the Pod running the current attempt of
+// taskRun. It is based on the name of taskRun, with a suffix derived from its
+// UID and its number of retries: it is the same each time the controller
+// creates the Pod of an attempt, so that a Pod created by a controller that
+// crashed before recording it is found again, but differs when a TaskRun is
+// deleted and re-created with the same name.
+func PodName(taskRun *v1alpha1.TaskRun) string {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s/%d", taskRun.UID, len(taskRun.Status.RetriesStatus))
+	base := fmt.Sprintf("%s-pod", taskRun.Name)
+	if len(base) > maxPodNameBaseLength {
+		base = base[:maxPodNameBaseLength]
+	}
+	return fmt.Sprintf("%s-%05x", base, h.Sum32()&0xfffff)
+}
+
 // makeLabels constructs the labels we will propagate from TaskRuns to Pods.
 func makeLabels(s *v1alpha1.TaskRun) map[string]string {
 	labels := make(map[string]string, len(s.ObjectMeta.Labels)+1)
@@ -17,10 +17,12 @@ limitations under the License.
 package pod
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -34,9 +36,10 @@ import (
 
 var (
 	images = pipeline.Images{
-		EntrypointImage: "entrypoint-image",
-		CredsImage:      "override-with-creds:latest",
-		ShellImage:      "busybox",
+		EntrypointImage:   "entrypoint-image",
+		CredsImage:        "override-with-creds:latest",
+		ShellImage:        "busybox",
+		DockerDaemonImage: "docker:dind",
 	}
 )
 
@@ -60,9 +63,13 @@ func TestMakePod(t *testing.T) {
 	}
 
 	runtimeClassName := "gvisor"
+	activeDeadlineSeconds := int64(3600)
+	disabled := false
+	privileged := true
 
 	for _, c := range []struct {
 		desc            string
+		trAnnotations   map[string]string
 		trs             v1alpha1.TaskRunSpec
 		ts              v1alpha1.TaskSpec
 		want            *corev1.PodSpec
@@ -151,6 +158,86 @@ func TestMakePod(t *testing.T) {
 			}},
 			Volumes: append(implicitVolumes, secretsVolume, toolsVolume, downwardVolume),
 		},
+	}, {
+		desc:          "with service account and creds init disabled",
+		trAnnotations: map[string]string{DisableCredsInitAnnotation: "true"},
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}}},
+		},
+		trs: v1alpha1.TaskRunSpec{
+			ServiceAccountName: "service-account",
+		},
+		want: &corev1.PodSpec{
+			ServiceAccountName: "service-account",
+			RestartPolicy:      corev1.RestartPolicyNever,
+			InitContainers:     []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env:          implicitEnvVars,
+				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount}, implicitVolumeMounts...),
+				WorkingDir:   workspaceDir,
+				Resources:    corev1.ResourceRequirements{Requests: allZeroQty()},
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume),
+		},
+	}, {
+		desc: "correlation annotations",
+		trAnnotations: map[string]string{
+			CorrelationIDAnnotation: "delivery-1234",
+			TraceparentAnnotation:   "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		},
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+				Env:     []corev1.EnvVar{{Name: "TRACEPARENT", Value: "set-by-step"}},
+			}}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env: append(append([]corev1.EnvVar{}, implicitEnvVars...),
+					corev1.EnvVar{Name: "TEKTON_CORRELATION_ID", Value: "delivery-1234"},
+					corev1.EnvVar{Name: "TRACEPARENT", Value: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+					corev1.EnvVar{Name: "TRACEPARENT", Value: "set-by-step"},
+				),
+				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount}, implicitVolumeMounts...),
+				WorkingDir:   workspaceDir,
+				Resources:    corev1.ResourceRequirements{Requests: allZeroQty()},
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume),
+		},
 	}, {
 		desc: "with-pod-template",
 		ts: v1alpha1.TaskSpec{
@@ -167,7 +254,12 @@ func TestMakePod(t *testing.T) {
 						{Name: "net.ipv4.tcp_syncookies", Value: "1"},
 					},
 				},
-				RuntimeClassName: &runtimeClassName,
+				RuntimeClassName:             &runtimeClassName,
+				ActiveDeadlineSeconds:        &activeDeadlineSeconds,
+				EnableServiceLinks:           &disabled,
+				AutomountServiceAccountToken: &disabled,
+				SchedulerName:                "batch-scheduler",
+				ImagePullSecrets:             []corev1.LocalObjectReference{{Name: "registry-credentials"}},
 			},
 		},
 		want: &corev1.PodSpec{
@@ -198,7 +290,12 @@ func TestMakePod(t *testing.T) {
 					{Name: "net.ipv4.tcp_syncookies", Value: "1"},
 				},
 			},
-			RuntimeClassName: &runtimeClassName,
+			RuntimeClassName:             &runtimeClassName,
+			ActiveDeadlineSeconds:        &activeDeadlineSeconds,
+			EnableServiceLinks:           &disabled,
+			AutomountServiceAccountToken: &disabled,
+			SchedulerName:                "batch-scheduler",
+			ImagePullSecrets:             []corev1.LocalObjectReference{{Name: "registry-credentials"}},
 		},
 	}, {
 		desc: "very long step name",
@@ -353,6 +450,340 @@ func TestMakePod(t *testing.T) {
 			}},
 			Volumes: append(implicitVolumes, toolsVolume, downwardVolume),
 		},
+	}, {
+		desc: "step and sidecar overrides",
+		trs: v1alpha1.TaskRunSpec{
+			StepOverrides: []v1alpha1.TaskRunStepOverride{{
+				Name: "primary-name",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+				},
+			}},
+			SidecarOverrides: []v1alpha1.TaskRunSidecarOverride{{
+				Name: "sc-name",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			}},
+		},
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:    "primary-name",
+				Image:   "primary-image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			}}},
+			Sidecars: []corev1.Container{{
+				Name:  "sc-name",
+				Image: "sidecar-image",
+			}},
+		},
+		wantAnnotations: map[string]string{},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-primary-name",
+				Image:   "primary-image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env:          implicitEnvVars,
+				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount}, implicitVolumeMounts...),
+				WorkingDir:   workspaceDir,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("4"),
+						corev1.ResourceMemory:           zeroQty,
+						corev1.ResourceEphemeralStorage: zeroQty,
+					},
+				},
+			}, {
+				Name:  "sidecar-sc-name",
+				Image: "sidecar-image",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume),
+		},
+	}, {
+		desc: "docker capability",
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:    "primary-name",
+				Image:   "primary-image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}}},
+			Capabilities: []v1alpha1.TaskCapability{v1alpha1.TaskCapabilityDocker},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-primary-name",
+				Image:   "primary-image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env: append(implicitEnvVars, corev1.EnvVar{
+					Name:  "DOCKER_HOST",
+					Value: "unix:///tekton/docker/docker.sock",
+				}),
+				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount, {
+					Name:      "tekton-internal-docker-socket",
+					MountPath: "/tekton/docker",
+				}}, implicitVolumeMounts...),
+				WorkingDir: workspaceDir,
+				Resources:  corev1.ResourceRequirements{Requests: allZeroQty()},
+			}, {
+				Name:  "sidecar-docker-daemon",
+				Image: images.DockerDaemonImage,
+				Args:  []string{"--host=unix:///tekton/docker/docker.sock"},
+				Env: []corev1.EnvVar{{
+					Name:  "DOCKER_TLS_CERTDIR",
+					Value: "",
+				}},
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "tekton-internal-docker-socket",
+					MountPath: "/tekton/docker",
+				}, {
+					Name:      "tekton-internal-docker-storage",
+					MountPath: "/var/lib/docker",
+				}},
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume, corev1.Volume{
+				Name:         "tekton-internal-docker-socket",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}, corev1.Volume{
+				Name:         "tekton-internal-docker-storage",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}),
+		},
+	}, {
+		desc: "memory volume",
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:    "test",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}}, {Container: corev1.Container{
+				Name:    "report",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}}},
+			MemoryVolumes: []v1alpha1.MemoryVolume{{
+				Name:      "shm",
+				MountPath: "/dev/shm",
+				Size:      resource.MustParse("1Gi"),
+				Steps:     []string{"test"},
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-test",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env: implicitEnvVars,
+				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount, {
+					Name:      "shm",
+					MountPath: "/dev/shm",
+				}}, implicitVolumeMounts...),
+				WorkingDir: workspaceDir,
+				Resources:  corev1.ResourceRequirements{Requests: allZeroQty()},
+			}, {
+				Name:    "step-report",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/tools/0",
+					"-post_file",
+					"/tekton/tools/1",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env:          implicitEnvVars,
+				VolumeMounts: append([]corev1.VolumeMount{toolsMount}, implicitVolumeMounts...),
+				WorkingDir:   workspaceDir,
+				Resources:    corev1.ResourceRequirements{Requests: allZeroQty()},
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume, corev1.Volume{
+				Name: "shm",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium:    corev1.StorageMediumMemory,
+					SizeLimit: func() *resource.Quantity { q := resource.MustParse("1Gi"); return &q }(),
+				}},
+			}),
+		},
+	}, {
+		desc: "results",
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:    "build",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}}, {Container: corev1.Container{
+				Name:    "report",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}}},
+			Results: []v1alpha1.TaskResult{{Name: "commit"}, {Name: "digest"}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-build",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env: implicitEnvVars,
+				VolumeMounts: append([]corev1.VolumeMount{{
+					Name:      "tekton-internal-results",
+					MountPath: "/tekton/results",
+				}, toolsMount, downwardMount}, implicitVolumeMounts...),
+				WorkingDir: workspaceDir,
+				Resources:  corev1.ResourceRequirements{Requests: allZeroQty()},
+			}, {
+				Name:    "step-report",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/tools/0",
+					"-post_file",
+					"/tekton/tools/1",
+					"-results",
+					"/tekton/results/commit,/tekton/results/digest",
+					"-termination_path",
+					"/dev/termination-log",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env: implicitEnvVars,
+				VolumeMounts: append([]corev1.VolumeMount{{
+					Name:      "tekton-internal-results",
+					MountPath: "/tekton/results",
+				}, toolsMount}, implicitVolumeMounts...),
+				WorkingDir: workspaceDir,
+				Resources:  corev1.ResourceRequirements{Requests: allZeroQty()},
+			}},
+			Volumes: append(implicitVolumes, corev1.Volume{
+				Name:         "tekton-internal-results",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}, toolsVolume, downwardVolume),
+		},
+	}, {
+		desc: "workspaces",
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:    "build",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}}},
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{
+				Name: "source",
+			}, {
+				Name:      "settings",
+				MountPath: "/etc/settings",
+				ReadOnly:  true,
+			}},
+		},
+		trs: v1alpha1.TaskRunSpec{
+			Workspaces: []v1alpha1.WorkspaceBinding{{
+				Name:                  "source",
+				SubPath:               "src",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+			}, {
+				Name: "settings",
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+				},
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{placeToolsInit},
+			Containers: []corev1.Container{{
+				Name:    "step-build",
+				Image:   "image",
+				Command: []string{"/tekton/tools/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/tools/0",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				Env: implicitEnvVars,
+				VolumeMounts: append([]corev1.VolumeMount{toolsMount, downwardMount, {
+					Name:      "tekton-internal-workspace-0",
+					MountPath: "/workspace/source",
+					SubPath:   "src",
+				}, {
+					Name:      "tekton-internal-workspace-1",
+					MountPath: "/etc/settings",
+					ReadOnly:  true,
+				}}, implicitVolumeMounts...),
+				WorkingDir: workspaceDir,
+				Resources:  corev1.ResourceRequirements{Requests: allZeroQty()},
+			}},
+			Volumes: append(implicitVolumes, toolsVolume, downwardVolume, corev1.Volume{
+				Name:         "tekton-internal-workspace-0",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"}},
+			}, corev1.Volume{
+				Name: "tekton-internal-workspace-1",
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+				}},
+			}),
+		},
 	}, {
 		desc: "resource request",
 		ts: v1alpha1.TaskSpec{
@@ -568,7 +999,8 @@ script-heredoc-randomly-generated-6nl7g
 			)
 			tr := &v1alpha1.TaskRun{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: "taskrun-name",
+					Name:        "taskrun-name",
+					Annotations: c.trAnnotations,
 				},
 				Spec: c.trs,
 			}
@@ -576,7 +1008,7 @@ script-heredoc-randomly-generated-6nl7g
 			// No entrypoints should be looked up.
 			entrypointCache := fakeCache{}
 
-			got, err := MakePod(images, tr, c.ts, kubeclient, entrypointCache)
+			got, err := MakePod(context.Background(), images, tr, c.ts, kubeclient, entrypointCache)
 			if err != nil {
 				t.Fatalf("MakePod: %v", err)
 			}
@@ -592,6 +1024,43 @@ script-heredoc-randomly-generated-6nl7g
 	}
 }
 
+func TestMakePodUnknownOverride(t *testing.T) {
+	ts := v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name:    "primary-name",
+			Image:   "primary-image",
+			Command: []string{"cmd"}, // avoid entrypoint lookup.
+		}}},
+		Sidecars: []corev1.Container{{
+			Name:  "sc-name",
+			Image: "sidecar-image",
+		}},
+	}
+	for _, c := range []struct {
+		desc string
+		trs  v1alpha1.TaskRunSpec
+	}{{
+		desc: "unknown step",
+		trs:  v1alpha1.TaskRunSpec{StepOverrides: []v1alpha1.TaskRunStepOverride{{Name: "missing"}}},
+	}, {
+		desc: "unknown sidecar",
+		trs:  v1alpha1.TaskRunSpec{SidecarOverrides: []v1alpha1.TaskRunSidecarOverride{{Name: "missing"}}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			tr := &v1alpha1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name"},
+				Spec:       c.trs,
+			}
+			if _, err := MakePod(context.Background(), images, tr, ts, kubeclient, fakeCache{}); err == nil {
+				t.Error("MakePod: expected an error, got none")
+			}
+		})
+	}
+}
+
 func TestMakeLabels(t *testing.T) {
 	taskRunName := "task-run-name"
 	for _, c := range []struct {
@@ -636,3 +1105,67 @@ func TestMakeLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestPodName(t *testing.T) {
+	tr := &v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", UID: "uid"}}
+	name := PodName(tr)
+	if !strings.HasPrefix(name, "taskrun-name-pod-") || len(name) != len("taskrun-name-pod-")+5 {
+		t.Errorf("Pod name %q should be 'taskrun-name-pod-' followed by 5 characters", name)
+	}
+	if again := PodName(tr.DeepCopy()); again != name {
+		t.Errorf("Expected the same pod name for the same TaskRun, got %q and %q", name, again)
+	}
+
+	recreated := tr.DeepCopy()
+	recreated.UID = "other-uid"
+	if PodName(recreated) == name {
+		t.Errorf("Expected a different pod name for a re-created TaskRun, got %q", name)
+	}
+	retried := tr.DeepCopy()
+	retried.Status.RetriesStatus = []v1alpha1.TaskRunStatus{{}}
+	if PodName(retried) == name {
+		t.Errorf("Expected a different pod name for a retry, got %q", name)
+	}
+
+	long := &v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 63)}}
+	if got := PodName(long); len(got) != 63 {
+		t.Errorf("Expected the pod name of a TaskRun with a long name to be 63 characters, got %q", got)
+	}
+}
+
+func TestDeadlineEnvVars(t *testing.T) {
+	start := metav1.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		desc string
+		tr   *v1alpha1.TaskRun
+		want []corev1.EnvVar
+	}{{
+		desc: "not started",
+		tr: &v1alpha1.TaskRun{Spec: v1alpha1.TaskRunSpec{
+			Timeout: &metav1.Duration{Duration: time.Hour},
+		}},
+	}, {
+		desc: "no timeout",
+		tr: &v1alpha1.TaskRun{
+			Spec: v1alpha1.TaskRunSpec{Timeout: &metav1.Duration{}},
+			Status: v1alpha1.TaskRunStatus{TaskRunStatusFields: v1alpha1.TaskRunStatusFields{
+				StartTime: &start,
+			}},
+		},
+	}, {
+		desc: "deadline",
+		tr: &v1alpha1.TaskRun{
+			Spec: v1alpha1.TaskRunSpec{Timeout: &metav1.Duration{Duration: 90 * time.Minute}},
+			Status: v1alpha1.TaskRunStatus{TaskRunStatusFields: v1alpha1.TaskRunStatusFields{
+				StartTime: &start,
+			}},
+		},
+		want: []corev1.EnvVar{{Name: "TEKTON_DEADLINE", Value: "2020-04-01T13:30:00Z"}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			if d := cmp.Diff(c.want, deadlineEnvVars(c.tr)); d != "" {
+				t.Errorf("Diff(-want, +got): %s", d)
+			}
+		})
+	}
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package pod
 
 import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var zeroQty = resource.MustParse("0")
@@ -50,3 +52,40 @@ func resolveResourceRequests(containers []corev1.Container) []corev1.Container {
 	containers[len(containers)-1].Resources.Requests = max
 	return containers
 }
+
+// podRequests returns the CPU, in millicores, and the memory, in bytes, that
+// the scheduler reserves for pod: the requests of its containers, or of its
+// largest init container if that is more.
+func podRequests(pod *corev1.Pod) (int64, int64) {
+	var cpu, memory int64
+	for _, c := range pod.Spec.Containers {
+		cpu += c.Resources.Requests.Cpu().MilliValue()
+		memory += c.Resources.Requests.Memory().Value()
+	}
+	for _, c := range pod.Spec.InitContainers {
+		if v := c.Resources.Requests.Cpu().MilliValue(); v > cpu {
+			cpu = v
+		}
+		if v := c.Resources.Requests.Memory().Value(); v > memory {
+			memory = v
+		}
+	}
+	return cpu, memory
+}
+
+// requestedResourceSeconds returns the resources pod requested between start
+// and end, nil if either is unknown.
+func requestedResourceSeconds(pod *corev1.Pod, start, end *metav1.Time) *v1alpha1.ResourceSeconds {
+	if start == nil || end == nil {
+		return nil
+	}
+	seconds := int64(end.Sub(start.Time).Seconds())
+	if seconds < 0 {
+		seconds = 0
+	}
+	cpu, memory := podRequests(pod)
+	return &v1alpha1.ResourceSeconds{
+		CPUMilliSeconds:   cpu * seconds,
+		MemoryByteSeconds: memory * seconds,
+	}
+}
@@ -18,10 +18,13 @@ package pod
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var resourceQuantityCmp = cmp.Comparer(func(x, y resource.Quantity) bool {
@@ -102,3 +105,51 @@ func TestResolveResourceRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestedResourceSeconds(t *testing.T) {
+	requests := func(cpu, memory string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}}
+	}
+	start := metav1.NewTime(time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(time.Minute))
+	for _, c := range []struct {
+		desc       string
+		pod        corev1.Pod
+		start, end *metav1.Time
+		want       *v1alpha1.ResourceSeconds
+	}{{
+		desc: "containers",
+		pod: corev1.Pod{Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Resources: requests("100m", "64Mi")}},
+			Containers:     []corev1.Container{{Resources: requests("500m", "1Gi")}, {Resources: requests("250m", "0")}},
+		}},
+		start: &start,
+		end:   &end,
+		want:  &v1alpha1.ResourceSeconds{CPUMilliSeconds: 750 * 60, MemoryByteSeconds: 1 << 30 * 60},
+	}, {
+		desc: "larger init container",
+		pod: corev1.Pod{Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Resources: requests("2", "64Mi")}},
+			Containers:     []corev1.Container{{Resources: requests("500m", "1Gi")}},
+		}},
+		start: &start,
+		end:   &end,
+		want:  &v1alpha1.ResourceSeconds{CPUMilliSeconds: 2000 * 60, MemoryByteSeconds: 1 << 30 * 60},
+	}, {
+		desc: "not started",
+		pod: corev1.Pod{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Resources: requests("500m", "1Gi")}},
+		}},
+		end: &end,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got := requestedResourceSeconds(&c.pod, c.start, c.end)
+			if d := cmp.Diff(c.want, got); d != "" {
+				t.Errorf("Diff(-want, +got): %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const resultsVolumeName = "tekton-internal-results"
+
+// applyResults mounts the directory the steps write the results to into
+// every step, and returns the paths of the result files along with the
+// volume that must be added to the Pod to back the directory. It returns no
+// volume if the Task declares no results.
+func applyResults(results []v1alpha1.TaskResult, steps []corev1.Container) ([]string, *corev1.Volume) {
+	if len(results) == 0 {
+		return nil, nil
+	}
+	var files []string
+	for _, r := range results {
+		files = append(files, r.Path())
+	}
+	for i := range steps {
+		steps[i].VolumeMounts = append(steps[i].VolumeMounts, corev1.VolumeMount{
+			Name:      resultsVolumeName,
+			MountPath: v1alpha1.ResultsDir,
+		})
+	}
+	return files, &corev1.Volume{
+		Name:         resultsVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/termination"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
@@ -37,6 +38,10 @@ const (
 	// that references within the TaskRun could not be resolved
 	ReasonFailedResolution = "TaskRunResolutionFailed"
 
+	// ReasonResolvingTaskRef indicates that the TaskRun is waiting for the
+	// resolver of its taskRef to fetch the Task
+	ReasonResolvingTaskRef = "ResolvingTaskRef"
+
 	// reasonFailedValidation indicated that the reason for failure status is
 	// that taskrun failed runtime validation
 	ReasonFailedValidation = "TaskRunValidationFailed"
@@ -56,6 +61,30 @@ const (
 	// to resource constraints on the node
 	ReasonExceededNodeResources = "ExceededNodeResources"
 
+	// ReasonExceededVolumeLimit indicates that the TaskRun's pod would mount more
+	// volumes than the maximum-pod-volumes of config-defaults
+	ReasonExceededVolumeLimit = "ExceededVolumeLimit"
+
+	// ReasonPodDenied indicates that the pod policy of the controller denied
+	// the TaskRun's pod
+	ReasonPodDenied = "PodDenied"
+
+	// ReasonNoMatchingNodes indicates that no Node of the cluster runs one of
+	// the platforms of the TaskRun's Task
+	ReasonNoMatchingNodes = "NoMatchingNodes"
+
+	// ReasonStepsStartTimeout indicates that the TaskRun's pod didn't signal
+	// its steps to start within the steps-start-timeout of config-defaults
+	ReasonStepsStartTimeout = termination.ReasonStepsStartTimeout
+
+	// ReasonStepTimeout indicates that a step of the TaskRun's pod was killed
+	// because it exceeded its timeout
+	ReasonStepTimeout = termination.ReasonStepTimeout
+
+	// ReasonCancelled indicates that the TaskRun was cancelled, and the steps
+	// that hadn't completed were stopped
+	ReasonCancelled = "TaskRunCancelled"
+
 	// ReasonSucceeded indicates that the reason for the finished status is that all of the steps
 	// completed successfully
 	ReasonSucceeded = "Succeeded"
@@ -122,11 +151,13 @@ func MakeTaskRunStatus(tr v1alpha1.TaskRun, pod *corev1.Pod, taskSpec v1alpha1.T
 		}
 	}
 
+	trs.ContainerImages = getContainerImages(pod)
+
 	// Complete if we did not find a step that is not complete, or the pod is in a definitely complete phase
 	complete := areStepsComplete(pod) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
 
 	if complete {
-		updateCompletedTaskRun(trs, pod)
+		updateCompletedTaskRun(trs, pod, taskSpec)
 	} else {
 		updateIncompleteTaskRun(trs, pod)
 	}
@@ -137,9 +168,43 @@ func MakeTaskRunStatus(tr v1alpha1.TaskRun, pod *corev1.Pod, taskSpec v1alpha1.T
 	return *trs
 }
 
-func updateCompletedTaskRun(trs *v1alpha1.TaskRunStatus, pod *corev1.Pod) {
-	if didTaskRunFail(pod) {
-		msg := getFailureMessage(pod)
+// getContainerImages returns the images of the containers of pod that started,
+// init containers first, in the order of the pod status.
+func getContainerImages(pod *corev1.Pod) []v1alpha1.ContainerImage {
+	var images []v1alpha1.ContainerImage
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, s := range statuses {
+			// The image ID is only known once the container started.
+			if s.ImageID == "" {
+				continue
+			}
+			images = append(images, v1alpha1.ContainerImage{
+				Container: s.Name,
+				Image:     s.Image,
+				ImageID:   s.ImageID,
+			})
+		}
+	}
+	return images
+}
+
+func updateCompletedTaskRun(trs *v1alpha1.TaskRunStatus, pod *corev1.Pod, taskSpec v1alpha1.TaskSpec) {
+	if name, ok := stepTerminatedWith(pod, termination.ReasonStepsStartTimeout); ok {
+		trs.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonStepsStartTimeout,
+			Message: fmt.Sprintf("%q timed out waiting for the pod to signal the steps to start; for logs run: kubectl -n %s logs %s -c %s", name, pod.Namespace, pod.Name, name),
+		})
+	} else if name, ok := stepTerminatedWith(pod, termination.ReasonStepTimeout); ok {
+		trs.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonStepTimeout,
+			Message: fmt.Sprintf("%q exceeded its timeout; for logs run: kubectl -n %s logs %s -c %s", name, pod.Namespace, pod.Name, name),
+		})
+	} else if didTaskRunFail(pod) {
+		msg := getFailureMessage(pod, taskSpec)
 		trs.SetCondition(&apis.Condition{
 			Type:    apis.ConditionSucceeded,
 			Status:  corev1.ConditionFalse,
@@ -156,6 +221,7 @@ func updateCompletedTaskRun(trs *v1alpha1.TaskRunStatus, pod *corev1.Pod) {
 	}
 	// update tr completed time
 	trs.CompletionTime = &metav1.Time{Time: time.Now()}
+	trs.RequestedResources = requestedResourceSeconds(pod, trs.StartTime, trs.CompletionTime)
 }
 
 func updateIncompleteTaskRun(trs *v1alpha1.TaskRunStatus, pod *corev1.Pod) {
@@ -197,6 +263,18 @@ func didTaskRunFail(pod *corev1.Pod) bool {
 	return f
 }
 
+// stepTerminatedWith returns the name of the step whose termination message
+// has the reason, if one has.
+func stepTerminatedWith(pod *corev1.Pod, reason string) (string, bool) {
+	for _, s := range pod.Status.ContainerStatuses {
+		if isContainerStep(s.Name) && s.State.Terminated != nil &&
+			termination.Reason(s.State.Terminated.Message) == reason {
+			return s.Name, true
+		}
+	}
+	return "", false
+}
+
 func areStepsComplete(pod *corev1.Pod) bool {
 	stepsComplete := len(pod.Status.ContainerStatuses) > 0 && pod.Status.Phase == corev1.PodRunning
 	for _, s := range pod.Status.ContainerStatuses {
@@ -209,16 +287,37 @@ func areStepsComplete(pod *corev1.Pod) bool {
 	return stepsComplete
 }
 
-func getFailureMessage(pod *corev1.Pod) string {
-	// First, try to surface an error about the actual build step that failed.
+func getFailureMessage(pod *corev1.Pod, taskSpec v1alpha1.TaskSpec) string {
+	alwaysRun := map[string]bool{}
+	for _, s := range taskSpec.Steps {
+		if s.AlwaysRun {
+			alwaysRun[stepContainerName(s.Name)] = true
+		}
+	}
+	// First, try to surface an error about the actual build step that
+	// failed. The failures of the always-run steps are reported after it.
+	var stepFailure string
+	var alwaysRunFailures []string
 	for _, status := range pod.Status.ContainerStatuses {
 		term := status.State.Terminated
-		if term != nil && term.ExitCode != 0 {
-			return fmt.Sprintf("%q exited with code %d (image: %q); for logs run: kubectl -n %s logs %s -c %s",
-				status.Name, term.ExitCode, status.ImageID,
-				pod.Namespace, pod.Name, status.Name)
+		if term == nil || term.ExitCode == 0 {
+			continue
+		}
+		msg := fmt.Sprintf("%q exited with code %d (image: %q); for logs run: kubectl -n %s logs %s -c %s",
+			status.Name, term.ExitCode, status.ImageID,
+			pod.Namespace, pod.Name, status.Name)
+		if alwaysRun[status.Name] {
+			alwaysRunFailures = append(alwaysRunFailures, "always-run step "+msg)
+		} else if stepFailure == "" {
+			stepFailure = msg
 		}
 	}
+	if stepFailure != "" {
+		return strings.Join(append([]string{stepFailure}, alwaysRunFailures...), "; ")
+	}
+	if len(alwaysRunFailures) > 0 {
+		return strings.Join(alwaysRunFailures, "; ")
+	}
 	// Next, return the Pod's status message if it has one.
 	if pod.Status.Message != "" {
 		return pod.Status.Message
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -58,8 +59,10 @@ func TestMakeTaskRunStatus(t *testing.T) {
 		desc: "ignore-creds-init",
 		podStatus: corev1.PodStatus{
 			InitContainerStatuses: []corev1.ContainerStatus{{
-				// creds-init; ignored
-				ImageID: "ignored",
+				// creds-init; ignored in the steps.
+				Name:    "credential-initializer",
+				Image:   "override-with-creds:latest",
+				ImageID: "creds-image-id",
 			}},
 			ContainerStatuses: []corev1.ContainerStatus{{
 				Name:    "step-state-name",
@@ -85,20 +88,30 @@ func TestMakeTaskRunStatus(t *testing.T) {
 					ContainerName: "step-state-name",
 				}},
 				Sidecars: []v1alpha1.SidecarState{},
+				ContainerImages: []v1alpha1.ContainerImage{{
+					Container: "credential-initializer",
+					Image:     "override-with-creds:latest",
+					ImageID:   "creds-image-id",
+				}},
 			},
 		},
 	}, {
 		desc: "ignore-init-containers",
 		podStatus: corev1.PodStatus{
 			InitContainerStatuses: []corev1.ContainerStatus{{
-				// creds-init; ignored.
-				ImageID: "ignoreme",
+				// creds-init; ignored in the steps.
+				Name:    "credential-initializer",
+				Image:   "override-with-creds:latest",
+				ImageID: "creds-image-id",
 			}, {
-				// git-init; ignored.
-				ImageID: "ignoreme",
+				// place-tools; ignored in the steps.
+				Name:    "place-tools",
+				Image:   "override-with-entrypoint:latest",
+				ImageID: "entrypoint-image-id",
 			}},
 			ContainerStatuses: []corev1.ContainerStatus{{
 				Name:    "step-state-name",
+				Image:   "busybox",
 				ImageID: "image-id",
 				State: corev1.ContainerState{
 					Terminated: &corev1.ContainerStateTerminated{
@@ -122,6 +135,19 @@ func TestMakeTaskRunStatus(t *testing.T) {
 					ImageID:       "image-id",
 				}},
 				Sidecars: []v1alpha1.SidecarState{},
+				ContainerImages: []v1alpha1.ContainerImage{{
+					Container: "credential-initializer",
+					Image:     "override-with-creds:latest",
+					ImageID:   "creds-image-id",
+				}, {
+					Container: "place-tools",
+					Image:     "override-with-entrypoint:latest",
+					ImageID:   "entrypoint-image-id",
+				}, {
+					Container: "step-state-name",
+					Image:     "busybox",
+					ImageID:   "image-id",
+				}},
 			},
 		},
 	}, {
@@ -157,7 +183,8 @@ func TestMakeTaskRunStatus(t *testing.T) {
 					ContainerName: "step-step-push",
 					ImageID:       "image-id",
 				}},
-				Sidecars: []v1alpha1.SidecarState{},
+				Sidecars:        []v1alpha1.SidecarState{},
+				ContainerImages: []v1alpha1.ContainerImage{{Container: "step-step-push", ImageID: "image-id"}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
@@ -193,8 +220,9 @@ func TestMakeTaskRunStatus(t *testing.T) {
 		podStatus: corev1.PodStatus{
 			Phase: corev1.PodFailed,
 			InitContainerStatuses: []corev1.ContainerStatus{{
-				// creds-init status; ignored
-				ImageID: "ignore-me",
+				// creds-init status; ignored in the steps.
+				Name:    "credential-initializer",
+				ImageID: "creds-image-id",
 			}},
 			ContainerStatuses: []corev1.ContainerStatus{{
 				Name:    "step-failure",
@@ -227,6 +255,101 @@ func TestMakeTaskRunStatus(t *testing.T) {
 					ImageID:       "image-id",
 				}},
 				Sidecars: []v1alpha1.SidecarState{},
+				ContainerImages: []v1alpha1.ContainerImage{{
+					Container: "credential-initializer",
+					ImageID:   "creds-image-id",
+				}, {
+					Container: "step-failure",
+					ImageID:   "image-id",
+				}},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "steps-start-timeout",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "step-build",
+				ImageID: "image-id",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  `{"version":1,"results":[],"reason":"StepsStartTimeout"}`,
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Reason:  ReasonStepsStartTimeout,
+					Message: `"step-build" timed out waiting for the pod to signal the steps to start; for logs run: kubectl -n foo logs pod -c step-build`,
+				}},
+			},
+			TaskRunStatusFields: v1alpha1.TaskRunStatusFields{
+				Steps: []v1alpha1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  `{"version":1,"results":[],"reason":"StepsStartTimeout"}`,
+						}},
+					Name:          "build",
+					ContainerName: "step-build",
+					ImageID:       "image-id",
+				}},
+				Sidecars: []v1alpha1.SidecarState{},
+				ContainerImages: []v1alpha1.ContainerImage{{
+					Container: "step-build",
+					ImageID:   "image-id",
+				}},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "step-timeout",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "step-build",
+				ImageID: "image-id",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  `{"version":1,"results":[],"reason":"StepTimeout"}`,
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Reason:  ReasonStepTimeout,
+					Message: `"step-build" exceeded its timeout; for logs run: kubectl -n foo logs pod -c step-build`,
+				}},
+			},
+			TaskRunStatusFields: v1alpha1.TaskRunStatusFields{
+				Steps: []v1alpha1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  `{"version":1,"results":[],"reason":"StepTimeout"}`,
+						}},
+					Name:          "build",
+					ContainerName: "step-build",
+					ImageID:       "image-id",
+				}},
+				Sidecars: []v1alpha1.SidecarState{},
+				ContainerImages: []v1alpha1.ContainerImage{{
+					Container: "step-build",
+					ImageID:   "image-id",
+				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
@@ -428,6 +551,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 					Name:    "running",
 					ImageID: "image-id",
 				}},
+				ContainerImages: []v1alpha1.ContainerImage{{Container: "sidecar-running", ImageID: "image-id"}},
 			},
 		},
 	}} {
@@ -466,7 +590,9 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				}
 				return y != nil
 			})
-			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ensureTimeNotNil); d != "" {
+			// The requested resources are tested by TestRequestedResourceSeconds.
+			ignoreRequestedResources := cmpopts.IgnoreFields(v1alpha1.TaskRunStatusFields{}, "RequestedResources")
+			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ensureTimeNotNil, ignoreRequestedResources); d != "" {
 				t.Errorf("Diff(-want, +got): %s", d)
 			}
 			if tr.Status.StartTime.Time != c.want.StartTime.Time {
@@ -476,6 +602,54 @@ func TestMakeTaskRunStatus(t *testing.T) {
 	}
 }
 
+func TestGetFailureMessageAlwaysRun(t *testing.T) {
+	taskSpec := v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{
+			Container: corev1.Container{Name: "build"},
+		}, {
+			Container: corev1.Container{Name: "cleanup"},
+			AlwaysRun: true,
+		}},
+	}
+	terminated := func(name string, exitCode int32) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:    name,
+			ImageID: "image-id",
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode},
+			},
+		}
+	}
+	for _, c := range []struct {
+		desc     string
+		statuses []corev1.ContainerStatus
+		want     string
+	}{{
+		desc:     "step failed",
+		statuses: []corev1.ContainerStatus{terminated("step-build", 1), terminated("step-cleanup", 0)},
+		want:     `"step-build" exited with code 1 (image: "image-id"); for logs run: kubectl -n foo logs pod -c step-build`,
+	}, {
+		desc:     "always-run step failed",
+		statuses: []corev1.ContainerStatus{terminated("step-build", 0), terminated("step-cleanup", 2)},
+		want:     `always-run step "step-cleanup" exited with code 2 (image: "image-id"); for logs run: kubectl -n foo logs pod -c step-cleanup`,
+	}, {
+		desc:     "both failed",
+		statuses: []corev1.ContainerStatus{terminated("step-build", 1), terminated("step-cleanup", 2)},
+		want: `"step-build" exited with code 1 (image: "image-id"); for logs run: kubectl -n foo logs pod -c step-build; ` +
+			`always-run step "step-cleanup" exited with code 2 (image: "image-id"); for logs run: kubectl -n foo logs pod -c step-cleanup`,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Status:     corev1.PodStatus{ContainerStatuses: c.statuses},
+			}
+			if d := cmp.Diff(c.want, getFailureMessage(pod, taskSpec)); d != "" {
+				t.Errorf("Diff(-want, +got): %s", d)
+			}
+		})
+	}
+}
+
 func TestSidecarsReady(t *testing.T) {
 	for _, c := range []struct {
 		desc     string
@@ -0,0 +1,53 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// StepCommands returns what the entrypoint executes in each step container of
+// pod, as ordered by orderContainers, in the order of the steps.
+func StepCommands(pod *corev1.Pod) []v1alpha1.StepCommand {
+	var commands []v1alpha1.StepCommand
+	for _, c := range pod.Spec.Containers {
+		if !isContainerStep(c.Name) {
+			continue
+		}
+		commands = append(commands, v1alpha1.StepCommand{
+			Name:       trimStepPrefix(c.Name),
+			Image:      c.Image,
+			Command:    entrypointCommand(c.Args),
+			Env:        c.Env,
+			EnvFrom:    c.EnvFrom,
+			WorkingDir: c.WorkingDir,
+		})
+	}
+	return commands
+}
+
+// entrypointCommand returns the argv that the entrypoint, run with args,
+// executes: the -entrypoint flag followed by the args after "--".
+func entrypointCommand(args []string) []string {
+	for i := 0; i+2 < len(args); i++ {
+		if args[i] == "-entrypoint" && args[i+2] == "--" {
+			return append([]string{args[i+1]}, args[i+3:]...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestStepCommands(t *testing.T) {
+	env := []corev1.EnvVar{{Name: "GOFLAGS", Value: "-mod=vendor"}, {
+		Name: "TOKEN",
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+			Key:                  "token",
+		}},
+	}}
+	steps := []corev1.Container{{
+		Name:       "step-build",
+		Image:      "golang",
+		Command:    []string{"go", "build"},
+		Args:       []string{"./..."},
+		Env:        env,
+		WorkingDir: "/workspace/source",
+	}, {
+		Name:    "step-test",
+		Image:   "golang",
+		Command: []string{"go"},
+		Args:    []string{"test", "--", "-v"},
+	}}
+	_, steps, err := orderContainers(images.EntrypointImage, steps, nil, 0, nil)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		Containers: append(steps, corev1.Container{Name: "sidecar-proxy", Image: "envoy"}),
+	}}
+
+	want := []v1alpha1.StepCommand{{
+		Name:       "build",
+		Image:      "golang",
+		Command:    []string{"go", "build", "./..."},
+		Env:        env,
+		WorkingDir: "/workspace/source",
+	}, {
+		Name:    "test",
+		Image:   "golang",
+		Command: []string{"go", "test", "--", "-v"},
+	}}
+	if d := cmp.Diff(want, StepCommands(pod)); d != "" {
+		t.Errorf("StepCommands() diff -want, +got: %v", d)
+	}
+}
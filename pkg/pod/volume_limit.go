@@ -0,0 +1,79 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// volumeSource is a group of volumes of a Pod, and what added them.
+type volumeSource struct {
+	addedBy string
+	volumes []corev1.Volume
+}
+
+// volumeLimitError is returned by MakePod when the Pod would mount more
+// volumes than the maximum-pod-volumes of config-defaults.
+type volumeLimitError struct {
+	count   int
+	maximum int
+	sources []volumeSource
+}
+
+func (e *volumeLimitError) Error() string {
+	var bySource []string
+	for _, s := range e.sources {
+		if len(s.volumes) == 0 {
+			continue
+		}
+		names := make([]string, len(s.volumes))
+		for i, v := range s.volumes {
+			names[i] = v.Name
+		}
+		bySource = append(bySource, fmt.Sprintf("%s: %s", s.addedBy, strings.Join(names, ", ")))
+	}
+	if len(bySource) == 0 {
+		return fmt.Sprintf("the Pod would mount %d volumes, more than the maximum of %d, all of them used by Tekton", e.count, e.maximum)
+	}
+	return fmt.Sprintf("the Pod would mount %d volumes, more than the maximum of %d; besides the volumes used by Tekton, they are added by the %s",
+		e.count, e.maximum, strings.Join(bySource, "; "))
+}
+
+// IsVolumeLimitExceeded returns true if err is returned by MakePod because
+// the Pod would mount more volumes than allowed.
+func IsVolumeLimitExceeded(err error) bool {
+	var limitErr *volumeLimitError
+	return errors.As(err, &limitErr)
+}
+
+// checkVolumeLimit returns an error if a Pod mounting count volumes, of which
+// sources are the ones Tekton doesn't need, exceeds the maximum-pod-volumes of
+// config-defaults. This fails the TaskRun with a clear error rather than
+// leaving its Pod unable to start on the node.
+func checkVolumeLimit(ctx context.Context, count int, sources ...volumeSource) error {
+	maximum := config.FromContextOrDefaults(ctx).Defaults.MaximumPodVolumes
+	if maximum <= 0 || count <= maximum {
+		return nil
+	}
+	return &volumeLimitError{count: count, maximum: maximum, sources: sources}
+}
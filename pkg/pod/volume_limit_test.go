@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMakePod_VolumeLimit(t *testing.T) {
+	withMaximum := func(maximum int) context.Context {
+		cfg := config.FromContextOrDefaults(context.Background())
+		cfg.Defaults.MaximumPodVolumes = maximum
+		return config.ToContext(context.Background(), cfg)
+	}
+	emptyDir := func(name string) corev1.Volume {
+		return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+	}
+	steps := []v1alpha1.Step{{Container: corev1.Container{
+		Name:    "name",
+		Image:   "image",
+		Command: []string{"cmd"}, // avoid entrypoint lookup.
+	}}}
+
+	// The Pod mounts 4 volumes used by Tekton, and the volumes of the Task
+	// and of the podTemplate.
+	for _, c := range []struct {
+		desc      string
+		ctx       context.Context
+		noVolumes bool
+		wantErr   string
+	}{{
+		desc: "no maximum",
+		ctx:  context.Background(),
+	}, {
+		desc: "within maximum",
+		ctx:  withMaximum(7),
+	}, {
+		desc:    "exceeds maximum",
+		ctx:     withMaximum(6),
+		wantErr: "the Pod would mount 7 volumes, more than the maximum of 6; besides the volumes used by Tekton, they are added by the Task and its resources: task-volume, resource-volume; podTemplate: template-volume",
+	}, {
+		desc:      "exceeds maximum with only the volumes used by Tekton",
+		ctx:       withMaximum(3),
+		noVolumes: true,
+		wantErr:   "the Pod would mount 4 volumes, more than the maximum of 3, all of them used by Tekton",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			ts := v1alpha1.TaskSpec{Steps: steps}
+			tr := &v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"}}
+			if !c.noVolumes {
+				ts.Volumes = []corev1.Volume{emptyDir("task-volume"), emptyDir("resource-volume")}
+				tr.Spec.PodTemplate.Volumes = []corev1.Volume{emptyDir("template-volume")}
+			}
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			_, err := MakePod(c.ctx, images, tr, ts, kubeclient, fakeCache{})
+			if c.wantErr == "" {
+				if err != nil {
+					t.Fatalf("MakePod: %v", err)
+				}
+				return
+			}
+			if !IsVolumeLimitExceeded(err) {
+				t.Fatalf("IsVolumeLimitExceeded(%v) = false", err)
+			}
+			if err.Error() != c.wantErr {
+				t.Errorf("MakePod error = %q, want %q", err, c.wantErr)
+			}
+		})
+	}
+}
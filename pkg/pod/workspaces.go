@@ -0,0 +1,59 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const workspaceVolumeNamePrefix = "tekton-internal-workspace-"
+
+// applyWorkspaces mounts each of the workspaces the Task declares into every
+// step, and returns the volumes that must be added to the Pod to back them,
+// as the bindings of the TaskRun provide them.
+func applyWorkspaces(workspaces []v1alpha1.WorkspaceDeclaration, bindings []v1alpha1.WorkspaceBinding, steps []corev1.Container) ([]corev1.Volume, error) {
+	bound := map[string]v1alpha1.WorkspaceBinding{}
+	for _, b := range bindings {
+		bound[b.Name] = b
+	}
+	var volumes []corev1.Volume
+	for i, w := range workspaces {
+		b, ok := bound[w.Name]
+		if !ok {
+			return nil, fmt.Errorf("workspace %q isn't bound by the TaskRun", w.Name)
+		}
+		// The names of the workspaces can be as long as a volume name, so
+		// the volumes are named after their index instead.
+		name := fmt.Sprintf("%s%d", workspaceVolumeNamePrefix, i)
+		volumes = append(volumes, corev1.Volume{
+			Name:         name,
+			VolumeSource: b.VolumeSource(),
+		})
+		for j := range steps {
+			steps[j].VolumeMounts = append(steps[j].VolumeMounts, corev1.VolumeMount{
+				Name:      name,
+				MountPath: w.GetMountPath(),
+				SubPath:   b.SubPath,
+				ReadOnly:  w.ReadOnly,
+			})
+		}
+	}
+	return volumes, nil
+}
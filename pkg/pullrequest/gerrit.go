@@ -0,0 +1,509 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pullrequest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"go.uber.org/zap"
+)
+
+// Gerrit has no pull requests, statuses, labels or comments, but changes,
+// votes on their labels, hashtags and messages, which the Gerrit client maps
+// them to.
+
+// gerritHandlerFromURL creates a Handler for the Gerrit change of the URL u,
+// https://<host>[/<prefix>]/c/<project>/+/<number>, or the same in the
+// fragment of the URL for older versions of Gerrit.
+func gerritHandlerFromURL(u *url.URL, token string, logger *zap.SugaredLogger) (*Handler, error) {
+	path := u.Path
+	if strings.HasPrefix(u.Fragment, "/c/") {
+		path = u.Fragment
+	}
+	i := strings.Index(path, "/c/")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid gerrit url: %s", u)
+	}
+	prefix := path[:i]
+	split := strings.SplitN(path[i+len("/c/"):], "/+/", 2)
+	if len(split) != 2 || split[0] == "" {
+		return nil, fmt.Errorf("could not determine project and change from URL: %v", u)
+	}
+	project := split[0]
+	change := strings.Split(split[1], "/")[0]
+	changeNum, err := strconv.Atoi(change)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing change number: %s", change)
+	}
+	logger = logger.With(
+		zap.String("project", project),
+		zap.String("pr", change),
+	)
+
+	client, err := newGerritClient(&url.URL{Scheme: u.Scheme, Host: u.Host, Path: prefix + "/"}, token)
+	if err != nil {
+		return nil, err
+	}
+	return NewHandler(logger, client, project, changeNum), nil
+}
+
+// newGerritClient creates a client of the Gerrit REST API at baseURL. The
+// token, if any, is the username and the HTTP password of an account,
+// separated by a colon.
+func newGerritClient(baseURL *url.URL, token string) (*scm.Client, error) {
+	client := &scm.Client{BaseURL: baseURL}
+	if token != "" {
+		split := strings.SplitN(token, ":", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf("the gerrit token should be <username>:<http password>")
+		}
+		// The authenticated API is under /a/.
+		client.BaseURL = baseURL.ResolveReference(&url.URL{Path: "a/"})
+		client.Client = &http.Client{
+			Transport: &gerritClient{
+				username:  split[0],
+				password:  split[1],
+				transport: http.DefaultTransport,
+			},
+		}
+	}
+	s := &gerritService{client: client, webURL: baseURL}
+	client.PullRequests = &gerritPullRequestService{s}
+	client.Repositories = &gerritRepositoryService{s}
+	return client, nil
+}
+
+// gerritClient wraps a normal http client, adding support for HTTP basic auth.
+type gerritClient struct {
+	username  string
+	password  string
+	transport http.RoundTripper
+}
+
+func (g *gerritClient) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.SetBasicAuth(g.username, g.password)
+	return g.transport.RoundTrip(r)
+}
+
+type gerritService struct {
+	client *scm.Client
+	// webURL is the URL of the web UI.
+	webURL *url.URL
+}
+
+// gerritMagicPrefix precedes the JSON responses of Gerrit, against XSSI.
+const gerritMagicPrefix = ")]}'"
+
+// do sends a request with the JSON body in to the Gerrit REST API, and
+// decodes its JSON response to out.
+func (s *gerritService) do(ctx context.Context, method, path string, in, out interface{}) (*scm.Response, error) {
+	req := &scm.Request{Method: method, Path: path, Header: http.Header{}}
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = bytes.NewReader(b)
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := s.client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return res, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if res.Status > 299 {
+		return res, fmt.Errorf("gerrit: %s %s: %d %s", method, path, res.Status, strings.TrimSpace(string(body)))
+	}
+	if out == nil {
+		return res, nil
+	}
+	return res, json.Unmarshal(bytes.TrimPrefix(body, []byte(gerritMagicPrefix)), out)
+}
+
+// changeID identifies the change of a project by its number.
+func changeID(project string, number int) string {
+	return fmt.Sprintf("%s~%d", url.PathEscape(project), number)
+}
+
+// findByCommit returns the change of the project with the revision sha.
+func (s *gerritService) findByCommit(ctx context.Context, project, sha string) (*gerritChange, *scm.Response, error) {
+	var changes []*gerritChange
+	path := fmt.Sprintf("changes/?q=commit:%s&o=LABELS", url.QueryEscape(sha))
+	res, err := s.do(ctx, http.MethodGet, path, nil, &changes)
+	if err != nil {
+		return nil, res, err
+	}
+	for _, c := range changes {
+		if c.Project == project {
+			return c, res, nil
+		}
+	}
+	return nil, res, fmt.Errorf("gerrit: no change of %s has the revision %s", project, sha)
+}
+
+type gerritChange struct {
+	Project         string                    `json:"project"`
+	Branch          string                    `json:"branch"`
+	Subject         string                    `json:"subject"`
+	Status          string                    `json:"status"`
+	Created         gerritTime                `json:"created"`
+	Updated         gerritTime                `json:"updated"`
+	Mergeable       bool                      `json:"mergeable"`
+	WorkInProgress  bool                      `json:"work_in_progress"`
+	Number          int                       `json:"_number"`
+	Owner           gerritAccount             `json:"owner"`
+	Labels          map[string]gerritLabel    `json:"labels"`
+	CurrentRevision string                    `json:"current_revision"`
+	Revisions       map[string]gerritRevision `json:"revisions"`
+}
+
+type gerritRevision struct {
+	Ref    string `json:"ref"`
+	Commit struct {
+		Parents []struct {
+			Commit string `json:"commit"`
+		} `json:"parents"`
+		Message string `json:"message"`
+	} `json:"commit"`
+}
+
+type gerritAccount struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
+
+// gerritLabel holds the most significant votes on a label of a change.
+type gerritLabel struct {
+	Approved    *gerritAccount `json:"approved"`
+	Rejected    *gerritAccount `json:"rejected"`
+	Recommended *gerritAccount `json:"recommended"`
+	Disliked    *gerritAccount `json:"disliked"`
+}
+
+type gerritMessage struct {
+	ID      string        `json:"id"`
+	Tag     string        `json:"tag"`
+	Author  gerritAccount `json:"author"`
+	Date    gerritTime    `json:"date"`
+	Message string        `json:"message"`
+}
+
+type gerritReviewInput struct {
+	Message string         `json:"message,omitempty"`
+	Tag     string         `json:"tag,omitempty"`
+	Labels  map[string]int `json:"labels,omitempty"`
+}
+
+type gerritHashtagsInput struct {
+	Add    []string `json:"add,omitempty"`
+	Remove []string `json:"remove,omitempty"`
+}
+
+// gerritTime is a timestamp of Gerrit, in UTC.
+type gerritTime struct {
+	time.Time
+}
+
+const gerritTimeLayout = "2006-01-02 15:04:05.000000000"
+
+func (t *gerritTime) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := time.Parse(gerritTimeLayout, s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (a gerritAccount) toUser() scm.User {
+	return scm.User{Login: a.Username, Name: a.Name, Email: a.Email}
+}
+
+// gerritTagPrefix is the prefix of the tags of the messages generated by
+// Gerrit and by bots, which aren't comments.
+const gerritTagPrefix = "autogenerated:"
+
+// gerritStatusTag is the tag of the messages of the votes of statuses.
+const gerritStatusTag = gerritTagPrefix + "tekton"
+
+// commentID returns an ID for the message of a change. The IDs of the messages
+// of Gerrit are strings.
+func commentID(messageID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(messageID))
+	return int(h.Sum32() & 0x7fffffff)
+}
+
+type gerritPullRequestService struct {
+	*gerritService
+}
+
+func (s *gerritPullRequestService) Find(ctx context.Context, repo string, number int) (*scm.PullRequest, *scm.Response, error) {
+	var c gerritChange
+	path := fmt.Sprintf("changes/%s?o=CURRENT_REVISION&o=CURRENT_COMMIT&o=DETAILED_ACCOUNTS", changeID(repo, number))
+	res, err := s.do(ctx, http.MethodGet, path, nil, &c)
+	if err != nil {
+		return nil, res, err
+	}
+	rev := c.Revisions[c.CurrentRevision]
+	var baseSha string
+	if len(rev.Commit.Parents) > 0 {
+		baseSha = rev.Commit.Parents[0].Commit
+	}
+	repository := scm.Repository{
+		Name:     c.Project[strings.LastIndex(c.Project, "/")+1:],
+		FullName: c.Project,
+		Branch:   c.Branch,
+		Clone:    s.webURL.ResolveReference(&url.URL{Path: c.Project}).String(),
+		Link:     s.webURL.ResolveReference(&url.URL{Path: "admin/repos/" + c.Project}).String(),
+	}
+	return &scm.PullRequest{
+		Number:    c.Number,
+		Title:     c.Subject,
+		Body:      rev.Commit.Message,
+		Sha:       c.CurrentRevision,
+		Ref:       rev.Ref,
+		Target:    c.Branch,
+		Base:      scm.PullRequestBranch{Ref: "refs/heads/" + c.Branch, Sha: baseSha, Repo: repository},
+		Head:      scm.PullRequestBranch{Ref: rev.Ref, Sha: c.CurrentRevision, Repo: repository},
+		Link:      s.webURL.ResolveReference(&url.URL{Path: fmt.Sprintf("c/%s/+/%d", c.Project, c.Number)}).String(),
+		State:     strings.ToLower(c.Status),
+		Closed:    c.Status != "NEW",
+		Draft:     c.WorkInProgress,
+		Merged:    c.Status == "MERGED",
+		Mergeable: c.Mergeable,
+		Author:    c.Owner.toUser(),
+		Created:   c.Created.Time,
+		Updated:   c.Updated.Time,
+	}, res, nil
+}
+
+func (s *gerritPullRequestService) ListComments(ctx context.Context, repo string, number int, _ scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+	var messages []gerritMessage
+	res, err := s.do(ctx, http.MethodGet, fmt.Sprintf("changes/%s/messages", changeID(repo, number)), nil, &messages)
+	if err != nil {
+		return nil, res, err
+	}
+	var comments []*scm.Comment
+	for _, m := range messages {
+		if strings.HasPrefix(m.Tag, gerritTagPrefix) {
+			continue
+		}
+		comments = append(comments, &scm.Comment{
+			ID:      commentID(m.ID),
+			Body:    m.Message,
+			Author:  m.Author.toUser(),
+			Created: m.Date.Time,
+			Updated: m.Date.Time,
+		})
+	}
+	return comments, res, nil
+}
+
+func (s *gerritPullRequestService) CreateComment(ctx context.Context, repo string, number int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("changes/%s/revisions/current/review", changeID(repo, number))
+	res, err := s.do(ctx, http.MethodPost, path, &gerritReviewInput{Message: input.Body}, nil)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.Comment{Body: input.Body}, res, nil
+}
+
+func (s *gerritPullRequestService) DeleteComment(ctx context.Context, repo string, number int, id int) (*scm.Response, error) {
+	var messages []gerritMessage
+	res, err := s.do(ctx, http.MethodGet, fmt.Sprintf("changes/%s/messages", changeID(repo, number)), nil, &messages)
+	if err != nil {
+		return res, err
+	}
+	for _, m := range messages {
+		if commentID(m.ID) == id {
+			return s.do(ctx, http.MethodDelete, fmt.Sprintf("changes/%s/messages/%s", changeID(repo, number), url.PathEscape(m.ID)), nil, nil)
+		}
+	}
+	return res, fmt.Errorf("gerrit: no message of change %d has the id %d", number, id)
+}
+
+// ListLabels returns the hashtags of the change.
+func (s *gerritPullRequestService) ListLabels(ctx context.Context, repo string, number int, _ scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	var hashtags []string
+	res, err := s.do(ctx, http.MethodGet, fmt.Sprintf("changes/%s/hashtags", changeID(repo, number)), nil, &hashtags)
+	if err != nil {
+		return nil, res, err
+	}
+	var labels []*scm.Label
+	for _, h := range hashtags {
+		labels = append(labels, &scm.Label{Name: h})
+	}
+	return labels, res, nil
+}
+
+// AddLabel adds a hashtag to the change.
+func (s *gerritPullRequestService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
+	return s.do(ctx, http.MethodPost, fmt.Sprintf("changes/%s/hashtags", changeID(repo, number)), &gerritHashtagsInput{Add: []string{label}}, nil)
+}
+
+// DeleteLabel removes a hashtag from the change.
+func (s *gerritPullRequestService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
+	return s.do(ctx, http.MethodPost, fmt.Sprintf("changes/%s/hashtags", changeID(repo, number)), &gerritHashtagsInput{Remove: []string{label}}, nil)
+}
+
+func (s *gerritPullRequestService) FindComment(context.Context, string, int, int) (*scm.Comment, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritPullRequestService) List(context.Context, string, scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritPullRequestService) ListChanges(context.Context, string, int, scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritPullRequestService) Merge(context.Context, string, int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *gerritPullRequestService) Close(context.Context, string, int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+type gerritRepositoryService struct {
+	*gerritService
+}
+
+// ListStatus returns the labels of the change with the revision ref, their
+// states reflecting their most significant votes.
+func (s *gerritRepositoryService) ListStatus(ctx context.Context, repo, ref string, _ scm.ListOptions) ([]*scm.Status, *scm.Response, error) {
+	c, res, err := s.findByCommit(ctx, repo, ref)
+	if err != nil {
+		return nil, res, err
+	}
+	var names []string
+	for name := range c.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var statuses []*scm.Status
+	for _, name := range names {
+		l := c.Labels[name]
+		state := scm.StatePending
+		switch {
+		case l.Rejected != nil, l.Disliked != nil:
+			state = scm.StateFailure
+		case l.Approved != nil, l.Recommended != nil:
+			state = scm.StateSuccess
+		}
+		statuses = append(statuses, &scm.Status{Label: name, State: state})
+	}
+	return statuses, res, nil
+}
+
+// CreateStatus votes on the label of the change with the revision ref: +1 for
+// a success, -1 for a failure or an error and 0 otherwise.
+func (s *gerritRepositoryService) CreateStatus(ctx context.Context, repo, ref string, input *scm.StatusInput) (*scm.Status, *scm.Response, error) {
+	c, res, err := s.findByCommit(ctx, repo, ref)
+	if err != nil {
+		return nil, res, err
+	}
+	vote := 0
+	switch input.State {
+	case scm.StateSuccess:
+		vote = 1
+	case scm.StateFailure, scm.StateError:
+		vote = -1
+	}
+	review := &gerritReviewInput{
+		Message: strings.TrimSpace(input.Desc + " " + input.Target),
+		Tag:     gerritStatusTag,
+		Labels:  map[string]int{input.Label: vote},
+	}
+	path := fmt.Sprintf("changes/%s/revisions/%s/review", changeID(repo, c.Number), ref)
+	if res, err := s.do(ctx, http.MethodPost, path, review, nil); err != nil {
+		return nil, res, err
+	}
+	return &scm.Status{Label: input.Label, State: input.State, Desc: input.Desc, Target: input.Target}, res, nil
+}
+
+func (s *gerritRepositoryService) Find(context.Context, string) (*scm.Repository, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritRepositoryService) FindHook(context.Context, string, string) (*scm.Hook, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritRepositoryService) FindPerms(context.Context, string) (*scm.Perm, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritRepositoryService) List(context.Context, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritRepositoryService) ListLabels(context.Context, string, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritRepositoryService) ListHooks(context.Context, string, scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritRepositoryService) FindCombinedStatus(context.Context, string, string) (*scm.CombinedStatus, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritRepositoryService) CreateHook(context.Context, string, *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritRepositoryService) DeleteHook(context.Context, string, string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *gerritRepositoryService) IsCollaborator(context.Context, string, string) (bool, *scm.Response, error) {
+	return false, nil, scm.ErrNotSupported
+}
+
+func (s *gerritRepositoryService) ListCollaborators(context.Context, string) ([]scm.User, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gerritRepositoryService) FindUserPermission(context.Context, string, string) (string, *scm.Response, error) {
+	return "", nil, scm.ErrNotSupported
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pullrequest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+const (
+	gerritChangeJSON = `{
+  "project": "foo/bar",
+  "branch": "master",
+  "subject": "Fix the build",
+  "status": "NEW",
+  "created": "2019-11-20 10:15:00.000000000",
+  "updated": "2019-11-21 08:30:00.000000000",
+  "mergeable": true,
+  "_number": 42,
+  "owner": {"name": "Jane Doe", "email": "jane@example.com", "username": "jane"},
+  "current_revision": "sha2",
+  "revisions": {
+    "sha2": {
+      "ref": "refs/changes/42/42/2",
+      "commit": {"parents": [{"commit": "sha1"}], "message": "Fix the build\n"}
+    }
+  }
+}`
+	gerritChangesByCommitJSON = `[{
+  "project": "foo/bar",
+  "_number": 42,
+  "labels": {
+    "Verified": {"approved": {"username": "tekton"}},
+    "Code-Review": {}
+  }
+}]`
+	gerritMessagesJSON = `[
+  {"id": "m1", "tag": "autogenerated:gerrit:newPatchSet", "message": "Uploaded patch set 2."},
+  {"id": "m2", "author": {"username": "jane"}, "date": "2019-11-21 08:30:00.000000000", "message": "Please review"}
+]`
+	gerritHashtagsJSON = `["ci"]`
+)
+
+// gerritRequest is a request received by the fake Gerrit server.
+type gerritRequest struct {
+	Method, Path, Body string
+}
+
+// newGerritServer starts a fake Gerrit server, recording the requests it
+// receives.
+func newGerritServer(t *testing.T, requests *[]gerritRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "tekton" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		path := r.URL.EscapedPath()
+		if r.URL.RawQuery != "" {
+			path += "?" + r.URL.RawQuery
+		}
+		*requests = append(*requests, gerritRequest{Method: r.Method, Path: path, Body: string(body)})
+
+		responses := map[string]string{
+			"GET /a/changes/foo%2Fbar~42?o=CURRENT_REVISION&o=CURRENT_COMMIT&o=DETAILED_ACCOUNTS": gerritChangeJSON,
+			"GET /a/changes/?q=commit:sha2&o=LABELS":                                              gerritChangesByCommitJSON,
+			"GET /a/changes/foo%2Fbar~42/messages":                                                gerritMessagesJSON,
+			"GET /a/changes/foo%2Fbar~42/hashtags":                                                gerritHashtagsJSON,
+		}
+		if r.Method != http.MethodGet {
+			w.Write([]byte(gerritMagicPrefix + "\n{}"))
+			return
+		}
+		response, ok := responses[r.Method+" "+path]
+		if !ok {
+			t.Errorf("Unexpected request %s %s", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(gerritMagicPrefix + "\n" + response))
+	}))
+}
+
+func newGerritHandler(t *testing.T, server *httptest.Server) *Handler {
+	logger := zaptest.NewLogger(t, zaptest.WrapOptions(zap.AddCaller())).Sugar()
+	h, err := NewSCMHandler(logger, server.URL+"/c/foo/bar/+/42", "gerrit", "tekton:secret")
+	if err != nil {
+		t.Fatalf("NewSCMHandler() = %v", err)
+	}
+	return h
+}
+
+func TestGerritDownload(t *testing.T) {
+	var requests []gerritRequest
+	server := newGerritServer(t, &requests)
+	defer server.Close()
+	h := newGerritHandler(t, server)
+
+	got, err := h.Download(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repository := scm.Repository{
+		Name:     "bar",
+		FullName: "foo/bar",
+		Branch:   "master",
+		Clone:    server.URL + "/foo/bar",
+		Link:     server.URL + "/admin/repos/foo/bar",
+	}
+	want := &Resource{
+		PR: &scm.PullRequest{
+			Number:    42,
+			Title:     "Fix the build",
+			Body:      "Fix the build\n",
+			Labels:    []*scm.Label{{Name: "ci"}},
+			Sha:       "sha2",
+			Ref:       "refs/changes/42/42/2",
+			Target:    "master",
+			Base:      scm.PullRequestBranch{Ref: "refs/heads/master", Sha: "sha1", Repo: repository},
+			Head:      scm.PullRequestBranch{Ref: "refs/changes/42/42/2", Sha: "sha2", Repo: repository},
+			Link:      server.URL + "/c/foo/bar/+/42",
+			State:     "new",
+			Mergeable: true,
+			Author:    scm.User{Login: "jane", Name: "Jane Doe", Email: "jane@example.com"},
+			Created:   time.Date(2019, 11, 20, 10, 15, 0, 0, time.UTC),
+			Updated:   time.Date(2019, 11, 21, 8, 30, 0, 0, time.UTC),
+		},
+		Statuses: []*scm.Status{
+			{Label: "Code-Review", State: scm.StatePending},
+			{Label: "Verified", State: scm.StateSuccess},
+		},
+		Comments: []*scm.Comment{{
+			ID:      commentID("m2"),
+			Body:    "Please review",
+			Author:  scm.User{Login: "jane"},
+			Created: time.Date(2019, 11, 21, 8, 30, 0, 0, time.UTC),
+			Updated: time.Date(2019, 11, 21, 8, 30, 0, 0, time.UTC),
+		}},
+	}
+	populateManifest(want)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Download() -want +got: %s", d)
+	}
+}
+
+func TestGerritUpload(t *testing.T) {
+	var requests []gerritRequest
+	server := newGerritServer(t, &requests)
+	defer server.Close()
+	h := newGerritHandler(t, server)
+
+	r, err := h.Download(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.PR.Labels = []*scm.Label{{Name: "tested"}}
+	r.Statuses = []*scm.Status{{Label: "Verified", State: scm.StateFailure, Desc: "Tests failed"}}
+	r.Comments = append(r.Comments, &scm.Comment{Body: "See the logs"})
+	requests = nil
+	if err := h.Upload(context.Background(), r); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []gerritRequest
+	for _, req := range requests {
+		if req.Method != http.MethodGet {
+			got = append(got, req)
+		}
+	}
+	want := []gerritRequest{{
+		Method: http.MethodPost,
+		Path:   "/a/changes/foo%2Fbar~42/hashtags",
+		Body:   `{"add":["tested"]}`,
+	}, {
+		Method: http.MethodPost,
+		Path:   "/a/changes/foo%2Fbar~42/hashtags",
+		Body:   `{"remove":["ci"]}`,
+	}, {
+		Method: http.MethodPost,
+		Path:   "/a/changes/foo%2Fbar~42/revisions/sha2/review",
+		Body:   `{"message":"Tests failed","tag":"autogenerated:tekton","labels":{"Verified":-1}}`,
+	}, {
+		Method: http.MethodPost,
+		Path:   "/a/changes/foo%2Fbar~42/revisions/current/review",
+		Body:   `{"message":"See the logs"}`,
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Upload() requests -want +got: %s", d)
+	}
+}
+
+func TestGerritHandlerFromURL(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		raw         string
+		token       string
+		wantBaseURL string
+		wantRepo    string
+		wantNum     int
+		wantErr     string
+	}{{
+		name:        "anonymous",
+		raw:         "https://review.example.com/c/foo/bar/+/42",
+		wantBaseURL: "https://review.example.com/",
+		wantRepo:    "foo/bar",
+		wantNum:     42,
+	}, {
+		name:        "authenticated with prefix and patchset",
+		raw:         "https://example.com/gerrit/c/foo/+/42/3",
+		token:       "tekton:secret",
+		wantBaseURL: "https://example.com/gerrit/a/",
+		wantRepo:    "foo",
+		wantNum:     42,
+	}, {
+		name:        "fragment",
+		raw:         "https://review.example.com/#/c/foo/+/42/",
+		wantBaseURL: "https://review.example.com/",
+		wantRepo:    "foo",
+		wantNum:     42,
+	}, {
+		name:    "no change number",
+		raw:     "https://review.example.com/c/foo/+/bar",
+		wantErr: "error parsing change number: bar",
+	}, {
+		name:    "no project",
+		raw:     "https://review.example.com/c/42",
+		wantErr: "could not determine project and change from URL: https://review.example.com/c/42",
+	}, {
+		name:    "token without password",
+		raw:     "https://review.example.com/c/foo/+/42",
+		token:   "secret",
+		wantErr: "the gerrit token should be <username>:<http password>",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := url.Parse(tc.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			logger := zaptest.NewLogger(t).Sugar()
+			h, err := gerritHandlerFromURL(u, tc.token, logger)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("gerritHandlerFromURL() = %v, want error %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("gerritHandlerFromURL() = %v", err)
+			}
+			if got := h.client.BaseURL.String(); got != tc.wantBaseURL {
+				t.Errorf("base URL = %s, want %s", got, tc.wantBaseURL)
+			}
+			if h.repo != tc.wantRepo || h.prNum != tc.wantNum {
+				t.Errorf("change = %s %d, want %s %d", h.repo, h.prNum, tc.wantRepo, tc.wantNum)
+			}
+		})
+	}
+}
@@ -52,6 +52,8 @@ func NewSCMHandler(logger *zap.SugaredLogger, raw, provider, token string) (*Han
 		handler, err = githubHandlerFromURL(u, token, logger)
 	case "gitlab":
 		handler, err = gitlabHandlerFromURL(u, token, logger)
+	case "gerrit":
+		handler, err = gerritHandlerFromURL(u, token, logger)
 	default:
 		return nil, fmt.Errorf("unsupported pr url: %s", raw)
 	}
@@ -138,6 +140,8 @@ func guessProvider(u *url.URL) (string, error) {
 		return "github", nil
 	case strings.Contains(u.Hostname(), "gitlab"):
 		return "gitlab", nil
+	case strings.Contains(u.Hostname(), "gerrit"), strings.Contains(u.Path, "/+/"), strings.Contains(u.Fragment, "/+/"):
+		return "gerrit", nil
 	}
 	return "", fmt.Errorf("unable to guess scm provider from url: %s", u)
 }
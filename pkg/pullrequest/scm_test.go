@@ -60,6 +60,20 @@ func TestNewSCMHandler(t *testing.T) {
 			wantNum:  3,
 			wantErr:  false,
 		},
+		{
+			name:     "gerrit",
+			raw:      "https://gerrit.example.com/c/foo/bar/+/4",
+			wantRepo: "foo/bar",
+			wantNum:  4,
+			wantErr:  false,
+		},
+		{
+			name:     "gerrit change path",
+			raw:      "https://review.example.com/c/foo/+/5/2",
+			wantRepo: "foo",
+			wantNum:  5,
+			wantErr:  false,
+		},
 		{
 			name:    "unsupported",
 			raw:     "https://unsupported.com/foo/baz/merge_requests/3",
@@ -114,6 +128,21 @@ func Test_guessProvider(t *testing.T) {
 			url:  "https://gitlab.foo.com/foo/bar",
 			want: "gitlab",
 		},
+		{
+			name: "gerrit",
+			url:  "https://gerrit.foo.com/c/foo/bar/+/1",
+			want: "gerrit",
+		},
+		{
+			name: "gerrit change path",
+			url:  "https://review.foo.com/c/foo/bar/+/1",
+			want: "gerrit",
+		},
+		{
+			name: "gerrit change fragment",
+			url:  "https://review.foo.com/#/c/foo/bar/+/1",
+			want: "gerrit",
+		},
 		{
 			name:    "err",
 			url:     "https://foo.com/foo/bar",
@@ -0,0 +1,54 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CleanupFinalizer is added to PipelineRuns and TaskRuns when the
+// enable-cleanup-finalizer feature flag is set. The controller removes it
+// once it has cleaned up what the run left outside of the cluster.
+const CleanupFinalizer = "tekton.dev/cleanup"
+
+// HasFinalizer returns true if obj has the finalizer.
+func HasFinalizer(obj metav1.Object, finalizer string) bool {
+	for _, f := range obj.GetFinalizers() {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// AddFinalizer adds the finalizer to obj, unless it already has it.
+func AddFinalizer(obj metav1.Object, finalizer string) {
+	if !HasFinalizer(obj, finalizer) {
+		obj.SetFinalizers(append(obj.GetFinalizers(), finalizer))
+	}
+}
+
+// RemoveFinalizer removes the finalizer from obj.
+func RemoveFinalizer(obj metav1.Object, finalizer string) {
+	var finalizers []string
+	for _, f := range obj.GetFinalizers() {
+		if f != finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	obj.SetFinalizers(finalizers)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFinalizers(t *testing.T) {
+	obj := &metav1.ObjectMeta{Finalizers: []string{"other"}}
+	if HasFinalizer(obj, CleanupFinalizer) {
+		t.Errorf("HasFinalizer() = true before AddFinalizer()")
+	}
+
+	AddFinalizer(obj, CleanupFinalizer)
+	AddFinalizer(obj, CleanupFinalizer)
+	if d := cmp.Diff([]string{"other", CleanupFinalizer}, obj.Finalizers); d != "" {
+		t.Errorf("AddFinalizer() diff -want, +got: %s", d)
+	}
+	if !HasFinalizer(obj, CleanupFinalizer) {
+		t.Errorf("HasFinalizer() = false after AddFinalizer()")
+	}
+
+	RemoveFinalizer(obj, CleanupFinalizer)
+	if d := cmp.Diff([]string{"other"}, obj.Finalizers); d != "" {
+		t.Errorf("RemoveFinalizer() diff -want, +got: %s", d)
+	}
+	if HasFinalizer(obj, CleanupFinalizer) {
+		t.Errorf("HasFinalizer() = true after RemoveFinalizer()")
+	}
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package githubchecks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/githubchecks"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// gitHubChecksAgentName defines logging agent name for the GitHub Checks Controller
+	gitHubChecksAgentName = "githubchecks-controller"
+
+	// RepoAnnotation is the annotation of a PipelineRun holding the GitHub
+	// repository, as "owner/name", its check runs are reported to.
+	RepoAnnotation = "github.checks.tekton.dev/repo"
+	// SHAAnnotation is the annotation of a PipelineRun holding the commit its
+	// check runs are reported on.
+	SHAAnnotation = "github.checks.tekton.dev/sha"
+	// SecretAnnotation is the annotation of a PipelineRun holding the name of
+	// the Secret, in the namespace of the PipelineRun, used to report its
+	// check runs.
+	SecretAnnotation = "github.checks.tekton.dev/secret"
+
+	// secretTokenKey is the key of the Secret holding the GitHub token.
+	secretTokenKey = "token"
+	// secretURLKey is the optional key of the Secret holding the URL of the
+	// GitHub API, for GitHub Enterprise.
+	secretURLKey = "url"
+
+	// logTailLines is the number of lines of the logs of a failed step
+	// included in the summary of its check run.
+	logTailLines = 20
+)
+
+// NewClient returns a githubchecks.Client for the GitHub API at url,
+// authenticating with token.
+type NewClient func(url, token string) githubchecks.Client
+
+// TailLogs returns the last lines of the logs of the container containerName
+// of the Pod podName.
+type TailLogs func(namespace, podName, containerName string) (string, error)
+
+// reportedCheckRun is the check run reported for a PipelineTask.
+type reportedCheckRun struct {
+	id    int64
+	state v1alpha1.PipelineTaskState
+}
+
+// Reconciler reports the PipelineTasks of the PipelineRuns annotated with
+// RepoAnnotation, SHAAnnotation and SecretAnnotation as GitHub check runs,
+// each time their state changes.
+type Reconciler struct {
+	*reconciler.Base
+
+	pipelineRunLister listers.PipelineRunLister
+	taskRunLister     listers.TaskRunLister
+	newClient         NewClient
+	tailLogs          TailLogs
+
+	mu sync.Mutex
+	// checkRuns holds the check runs reported by this controller, keyed by
+	// their external ID.
+	checkRuns map[string]*reportedCheckRun
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile reports the PipelineTasks of the PipelineRun key whose state
+// changed since they were last reported.
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		c.Logger.Errorf("invalid resource key: %s", key)
+		return nil
+	}
+
+	pr, err := c.pipelineRunLister.PipelineRuns(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		c.forget(key)
+		return nil
+	} else if err != nil {
+		c.Logger.Errorf("Error retrieving PipelineRun %q: %s", name, err)
+		return err
+	}
+
+	repo, sha, secretName := pr.Annotations[RepoAnnotation], pr.Annotations[SHAAnnotation], pr.Annotations[SecretAnnotation]
+	if repo == "" || sha == "" || secretName == "" || pr.Status.Graph == nil {
+		return nil
+	}
+
+	var changed []v1alpha1.PipelineRunGraphNode
+	for _, n := range pr.Status.Graph.Nodes {
+		if reported := c.reported(externalID(key, n.PipelineTaskName)); reported == nil || reported.state != n.State {
+			changed = append(changed, n)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	client, err := c.clientForSecret(namespace, secretName)
+	if err != nil {
+		c.Logger.Errorf("Couldn't report the check runs of PipelineRun %q: %v", key, err)
+		c.Recorder.Eventf(pr, corev1.EventTypeWarning, "GitHubChecksFailed", "Couldn't report the check runs: %v", err)
+		// Retrying won't help until the Secret is fixed, the PipelineRun is
+		// reported again the next time it changes.
+		return nil
+	}
+
+	var merr error
+	for _, n := range changed {
+		if err := c.report(ctx, client, pr, repo, sha, n); err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("reporting check run of PipelineTask %q: %w", n.PipelineTaskName, err))
+		}
+	}
+	return merr
+}
+
+func (c *Reconciler) report(ctx context.Context, client githubchecks.Client, pr *v1alpha1.PipelineRun, repo, sha string, n v1alpha1.PipelineRunGraphNode) error {
+	key := pr.Namespace + "/" + pr.Name
+	id := externalID(key, n.PipelineTaskName)
+	cr := c.checkRun(pr, sha, n)
+	cr.ExternalID = id
+
+	reported := c.reported(id)
+	if reported == nil {
+		// The check run may have been reported before the controller
+		// restarted.
+		crID, found, err := client.FindCheckRun(ctx, repo, sha, cr.Name, id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			if crID, err = client.CreateCheckRun(ctx, repo, cr); err != nil {
+				return err
+			}
+			c.record(id, &reportedCheckRun{id: crID, state: n.State})
+			return nil
+		}
+		reported = &reportedCheckRun{id: crID}
+	}
+	if err := client.UpdateCheckRun(ctx, repo, reported.id, cr); err != nil {
+		return err
+	}
+	c.record(id, &reportedCheckRun{id: reported.id, state: n.State})
+	return nil
+}
+
+// checkRun returns the check run reporting the PipelineTask n of pr.
+func (c *Reconciler) checkRun(pr *v1alpha1.PipelineRun, sha string, n v1alpha1.PipelineRunGraphNode) githubchecks.CheckRun {
+	cr := githubchecks.CheckRun{
+		Name:    n.PipelineTaskName,
+		HeadSHA: sha,
+		Output:  &githubchecks.Output{Title: string(n.State)},
+	}
+	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Name != "" {
+		cr.Name = pr.Spec.PipelineRef.Name + "/" + n.PipelineTaskName
+	}
+
+	switch n.State {
+	case v1alpha1.PipelineTaskStatePending:
+		cr.Status = githubchecks.StatusQueued
+	case v1alpha1.PipelineTaskStateRunning:
+		cr.Status = githubchecks.StatusInProgress
+	case v1alpha1.PipelineTaskStateSucceeded:
+		cr.Status, cr.Conclusion = githubchecks.StatusCompleted, githubchecks.ConclusionSuccess
+	case v1alpha1.PipelineTaskStateFailed:
+		cr.Status, cr.Conclusion = githubchecks.StatusCompleted, githubchecks.ConclusionFailure
+	case v1alpha1.PipelineTaskStateSkipped:
+		cr.Status, cr.Conclusion = githubchecks.StatusCompleted, githubchecks.ConclusionSkipped
+		cr.Output.Summary = "The conditions of this Task, or of a Task it depends on, failed."
+	}
+
+	if n.TaskRunName == "" {
+		return cr
+	}
+	tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(n.TaskRunName)
+	if err != nil {
+		// The TaskRun hasn't been created yet.
+		return cr
+	}
+	if tr.Status.StartTime != nil {
+		cr.StartedAt = &tr.Status.StartTime.Time
+	}
+	if cr.Status == githubchecks.StatusCompleted && tr.Status.CompletionTime != nil {
+		cr.CompletedAt = &tr.Status.CompletionTime.Time
+	}
+	cr.Output.Summary = c.summary(tr, n.State == v1alpha1.PipelineTaskStateFailed)
+	return cr
+}
+
+// summary returns the Markdown summary of tr: its status message, its results
+// and, if it failed, the tail of the logs of the steps that failed.
+func (c *Reconciler) summary(tr *v1alpha1.TaskRun, failed bool) string {
+	var b strings.Builder
+	if cond := tr.Status.GetCondition(apis.ConditionSucceeded); cond != nil && cond.Message != "" {
+		fmt.Fprintf(&b, "%s\n", cond.Message)
+	}
+	if len(tr.Status.ResourcesResult) > 0 {
+		b.WriteString("\n### Results\n\n")
+		for _, r := range tr.Status.ResourcesResult {
+			fmt.Fprintf(&b, "- `%s` `%s`: `%s`\n", r.ResourceRef.Name, r.Key, r.Value)
+		}
+	}
+	if !failed {
+		return b.String()
+	}
+	for _, s := range tr.Status.Steps {
+		if s.Terminated == nil || s.Terminated.ExitCode == 0 {
+			continue
+		}
+		logs, err := c.tailLogs(tr.Namespace, tr.Status.PodName, s.ContainerName)
+		if err != nil {
+			c.Logger.Warnf("Couldn't get the logs of step %q of TaskRun %s/%s: %v", s.Name, tr.Namespace, tr.Name, err)
+			continue
+		}
+		fmt.Fprintf(&b, "\n### Logs of step `%s`\n\n```\n%s\n```\n", s.Name, strings.TrimRight(logs, "\n"))
+	}
+	return b.String()
+}
+
+func (c *Reconciler) tailPodLogs(namespace, podName, containerName string) (string, error) {
+	tailLines := int64(logTailLines)
+	b, err := c.KubeClientSet.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: containerName,
+		TailLines: &tailLines,
+	}).DoRaw()
+	return string(b), err
+}
+
+func (c *Reconciler) clientForSecret(namespace, name string) (githubchecks.Client, error) {
+	secret, err := c.KubeClientSet.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	token, ok := secret.Data[secretTokenKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %q has no %q key", name, secretTokenKey)
+	}
+	return c.newClient(string(secret.Data[secretURLKey]), string(token)), nil
+}
+
+func (c *Reconciler) reported(id string) *reportedCheckRun {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.checkRuns[id]
+}
+
+func (c *Reconciler) record(id string, r *reportedCheckRun) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checkRuns[id] = r
+}
+
+// forget drops the check runs reported for the PipelineRun key.
+func (c *Reconciler) forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id := range c.checkRuns {
+		if strings.HasPrefix(id, key+"/") {
+			delete(c.checkRuns, id)
+		}
+	}
+}
+
+// externalID returns the external ID of the check run of the PipelineTask
+// pipelineTaskName of the PipelineRun key.
+func externalID(key, pipelineTaskName string) string {
+	return key + "/" + pipelineTaskName
+}
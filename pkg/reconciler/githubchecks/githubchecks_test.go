@@ -0,0 +1,286 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package githubchecks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/githubchecks"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/system"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/configmap"
+)
+
+// fakeClient records the check runs reported to it.
+type fakeClient struct {
+	url, token string
+	existing   map[string]int64
+	nextID     int64
+	created    []githubchecks.CheckRun
+	updated    map[int64]githubchecks.CheckRun
+}
+
+func (f *fakeClient) FindCheckRun(_ context.Context, repo, sha, name, externalID string) (int64, bool, error) {
+	id, ok := f.existing[externalID]
+	return id, ok, nil
+}
+
+func (f *fakeClient) CreateCheckRun(_ context.Context, repo string, cr githubchecks.CheckRun) (int64, error) {
+	f.nextID++
+	f.created = append(f.created, cr)
+	return f.nextID, nil
+}
+
+func (f *fakeClient) UpdateCheckRun(_ context.Context, repo string, id int64, cr githubchecks.CheckRun) error {
+	f.updated[id] = cr
+	return nil
+}
+
+var (
+	startTime      = metav1.NewTime(time.Date(2019, 12, 1, 10, 0, 0, 0, time.UTC))
+	completionTime = metav1.NewTime(time.Date(2019, 12, 1, 10, 5, 0, 0, time.UTC))
+
+	annotations = map[string]string{
+		RepoAnnotation:   "owner/repo",
+		SHAAnnotation:    "abc123",
+		SecretAnnotation: "github-token",
+	}
+
+	pr = &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo", Annotations: annotations},
+		Spec:       v1alpha1.PipelineRunSpec{PipelineRef: &v1alpha1.PipelineRef{Name: "ci"}},
+		Status: v1alpha1.PipelineRunStatus{PipelineRunStatusFields: v1alpha1.PipelineRunStatusFields{
+			Graph: &v1alpha1.PipelineRunGraph{
+				Nodes: []v1alpha1.PipelineRunGraphNode{{
+					PipelineTaskName: "build",
+					TaskRunName:      "pr-build",
+					State:            v1alpha1.PipelineTaskStateSucceeded,
+				}, {
+					PipelineTaskName: "test",
+					TaskRunName:      "pr-test",
+					State:            v1alpha1.PipelineTaskStateFailed,
+				}, {
+					PipelineTaskName: "deploy",
+					TaskRunName:      "pr-deploy",
+					State:            v1alpha1.PipelineTaskStatePending,
+				}},
+			},
+		}},
+	}
+
+	trs = []*v1alpha1.TaskRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr-build", Namespace: "foo"},
+		Status: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionTrue,
+				Message: "All Steps have completed executing",
+			}}},
+			TaskRunStatusFields: v1alpha1.TaskRunStatusFields{
+				StartTime:      &startTime,
+				CompletionTime: &completionTime,
+				ResourcesResult: []v1alpha1.PipelineResourceResult{{
+					Key:         "digest",
+					Value:       "sha256:1234",
+					ResourceRef: v1alpha1.PipelineResourceRef{Name: "image"},
+				}},
+			},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "pr-test", Namespace: "foo"},
+		Status: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Message: `"step-unit" exited with code 1`,
+			}}},
+			TaskRunStatusFields: v1alpha1.TaskRunStatusFields{
+				PodName:        "pr-test-pod",
+				StartTime:      &startTime,
+				CompletionTime: &completionTime,
+				Steps: []v1alpha1.StepState{{
+					Name:          "checkout",
+					ContainerName: "step-checkout",
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+					},
+				}, {
+					Name:          "unit",
+					ContainerName: "step-unit",
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+					},
+				}},
+			},
+		},
+	}}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-token", Namespace: "foo"},
+		Data: map[string][]byte{
+			"token": []byte("s3cr3t"),
+			"url":   []byte("https://github.example.com/api/v3"),
+		},
+	}
+)
+
+func getController(t *testing.T, d reconcilertest.Data) (*Reconciler, *fakeClient, func()) {
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
+	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
+	r := NewController()(ctx, configMapWatcher).Reconciler.(*Reconciler)
+	client := &fakeClient{updated: map[int64]githubchecks.CheckRun{}}
+	r.newClient = func(url, token string) githubchecks.Client {
+		client.url, client.token = url, token
+		return client
+	}
+	r.tailLogs = func(namespace, podName, containerName string) (string, error) {
+		return fmt.Sprintf("logs of %s/%s/%s\n", namespace, podName, containerName), nil
+	}
+	return r, client, cancel
+}
+
+func TestReconcile(t *testing.T) {
+	r, client, cancel := getController(t, reconcilertest.Data{
+		PipelineRuns: []*v1alpha1.PipelineRun{pr},
+		TaskRuns:     trs,
+		Secrets:      []*corev1.Secret{secret},
+	})
+	defer cancel()
+
+	if err := r.Reconcile(context.Background(), "foo/pr"); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	if client.url != "https://github.example.com/api/v3" || client.token != "s3cr3t" {
+		t.Errorf("Expected the client to be configured from the Secret, got url %q and token %q", client.url, client.token)
+	}
+	expected := []githubchecks.CheckRun{{
+		Name:        "ci/build",
+		HeadSHA:     "abc123",
+		ExternalID:  "foo/pr/build",
+		Status:      githubchecks.StatusCompleted,
+		Conclusion:  githubchecks.ConclusionSuccess,
+		StartedAt:   &startTime.Time,
+		CompletedAt: &completionTime.Time,
+		Output: &githubchecks.Output{
+			Title:   "Succeeded",
+			Summary: "All Steps have completed executing\n\n### Results\n\n- `image` `digest`: `sha256:1234`\n",
+		},
+	}, {
+		Name:        "ci/test",
+		HeadSHA:     "abc123",
+		ExternalID:  "foo/pr/test",
+		Status:      githubchecks.StatusCompleted,
+		Conclusion:  githubchecks.ConclusionFailure,
+		StartedAt:   &startTime.Time,
+		CompletedAt: &completionTime.Time,
+		Output: &githubchecks.Output{
+			Title:   "Failed",
+			Summary: "\"step-unit\" exited with code 1\n\n### Logs of step `unit`\n\n```\nlogs of foo/pr-test-pod/step-unit\n```\n",
+		},
+	}, {
+		Name:       "ci/deploy",
+		HeadSHA:    "abc123",
+		ExternalID: "foo/pr/deploy",
+		Status:     githubchecks.StatusQueued,
+		Output:     &githubchecks.Output{Title: "Pending"},
+	}}
+	if d := cmp.Diff(expected, client.created); d != "" {
+		t.Errorf("Unexpected check runs created (-want, +got): %s", d)
+	}
+
+	// Nothing changed, so nothing is reported again.
+	client.created = nil
+	if err := r.Reconcile(context.Background(), "foo/pr"); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(client.created) != 0 || len(client.updated) != 0 {
+		t.Errorf("Expected no check run to be reported again, got created %v and updated %v", client.created, client.updated)
+	}
+}
+
+func TestReconcile_UpdatesExistingCheckRun(t *testing.T) {
+	running := pr.DeepCopy()
+	running.Status.Graph.Nodes = []v1alpha1.PipelineRunGraphNode{{
+		PipelineTaskName: "deploy",
+		TaskRunName:      "pr-deploy",
+		State:            v1alpha1.PipelineTaskStatePending,
+	}}
+	r, client, cancel := getController(t, reconcilertest.Data{
+		PipelineRuns: []*v1alpha1.PipelineRun{running},
+		Secrets:      []*corev1.Secret{secret},
+	})
+	defer cancel()
+	// The check run was reported before the controller restarted.
+	client.existing = map[string]int64{"foo/pr/deploy": 7}
+
+	if err := r.Reconcile(context.Background(), "foo/pr"); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	if len(client.created) != 0 {
+		t.Errorf("Expected no check run to be created, got %v", client.created)
+	}
+	expected := map[int64]githubchecks.CheckRun{7: {
+		Name:       "ci/deploy",
+		HeadSHA:    "abc123",
+		ExternalID: "foo/pr/deploy",
+		Status:     githubchecks.StatusQueued,
+		Output:     &githubchecks.Output{Title: "Pending"},
+	}}
+	if d := cmp.Diff(expected, client.updated); d != "" {
+		t.Errorf("Unexpected check runs updated (-want, +got): %s", d)
+	}
+}
+
+func TestReconcile_NotReported(t *testing.T) {
+	notAnnotated := pr.DeepCopy()
+	notAnnotated.Name = "not-annotated"
+	notAnnotated.Annotations = nil
+	noSecret := pr.DeepCopy()
+	noSecret.Name = "no-secret"
+	noSecret.Annotations = map[string]string{
+		RepoAnnotation:   "owner/repo",
+		SHAAnnotation:    "abc123",
+		SecretAnnotation: "missing",
+	}
+	r, client, cancel := getController(t, reconcilertest.Data{
+		PipelineRuns: []*v1alpha1.PipelineRun{notAnnotated, noSecret},
+	})
+	defer cancel()
+
+	for _, key := range []string{"foo/not-annotated", "foo/no-secret", "foo/deleted"} {
+		if err := r.Reconcile(context.Background(), key); err != nil {
+			t.Errorf("Reconcile(%q): %v", key, err)
+		}
+	}
+	if len(client.created) != 0 || len(client.updated) != 0 {
+		t.Errorf("Expected no check run to be reported, got created %v and updated %v", client.created, client.updated)
+	}
+}
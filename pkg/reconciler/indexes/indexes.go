@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package indexes holds the informer indexes the reconcilers use to find the
+// objects of a run without listing every object of the namespace.
+package indexes
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// TaskRun indexes objects by the namespace and name of the TaskRun in
+	// their tekton.dev/taskRun label.
+	TaskRun = "taskRun"
+)
+
+// Key returns the index value of the run name in namespace.
+func Key(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// labelIndexFunc indexes objects by their namespace and the value of label,
+// if they have it.
+func labelIndexFunc(label string) cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		m, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := m.GetLabels()[label]; ok {
+			return []string{Key(m.GetNamespace(), v)}, nil
+		}
+		return nil, nil
+	}
+}
+
+// AddPodIndexes adds the Pod indexes to informer, unless they were already
+// added, for example by another controller sharing the informer.
+func AddPodIndexes(informer cache.SharedIndexInformer) error {
+	return add(informer, cache.Indexers{
+		TaskRun: labelIndexFunc(pipeline.GroupName + pipeline.TaskRunLabelKey),
+	})
+}
+
+func add(informer cache.SharedIndexInformer, indexers cache.Indexers) error {
+	existing := informer.GetIndexer().GetIndexers()
+	missing := cache.Indexers{}
+	for name, f := range indexers {
+		if _, ok := existing[name]; !ok {
+			missing[name] = f
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return informer.AddIndexers(missing)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package indexes
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestAddPodIndexes(t *testing.T) {
+	informer := cache.NewSharedIndexInformer(nil, &corev1.Pod{}, 0, cache.Indexers{})
+	if err := AddPodIndexes(informer); err != nil {
+		t.Fatalf("AddPodIndexes: %v", err)
+	}
+	// Adding them again, as a second controller sharing the informer does,
+	// is a no-op.
+	if err := AddPodIndexes(informer); err != nil {
+		t.Fatalf("AddPodIndexes a second time: %v", err)
+	}
+
+	pod := func(namespace, name, taskRun string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		if taskRun != "" {
+			p.Labels = map[string]string{"tekton.dev/taskRun": taskRun}
+		}
+		return p
+	}
+	for _, p := range []*corev1.Pod{
+		pod("foo", "run-1-pod-a", "run-1"),
+		pod("foo", "run-1-pod-b", "run-1"),
+		pod("foo", "run-2-pod", "run-2"),
+		pod("bar", "run-1-pod", "run-1"),
+		pod("foo", "unrelated", ""),
+	} {
+		if err := informer.GetIndexer().Add(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		key      string
+		expected []string
+	}{{
+		key:      Key("foo", "run-1"),
+		expected: []string{"run-1-pod-a", "run-1-pod-b"},
+	}, {
+		key:      Key("bar", "run-1"),
+		expected: []string{"run-1-pod"},
+	}, {
+		key: Key("foo", "run-3"),
+	}} {
+		t.Run(tc.key, func(t *testing.T) {
+			objs, err := informer.GetIndexer().ByIndex(TaskRun, tc.key)
+			if err != nil {
+				t.Fatalf("ByIndex: %v", err)
+			}
+			var names []string
+			for _, o := range objs {
+				names = append(names, o.(*corev1.Pod).Name)
+			}
+			sort.Strings(names)
+			if d := cmp.Diff(tc.expected, names); d != "" {
+				t.Errorf("Unexpected pods: %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mattbaird/jsonpatch"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	"github.com/tektoncd/pipeline/pkg/system"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// notificationAgentName defines logging agent name for the Notification Controller
+	notificationAgentName = "notification-controller"
+
+	// SentAnnotation is the annotation of a PipelineRun holding the
+	// NotificationPolicies, as comma separated "namespace/name", whose
+	// notification about it was sent.
+	SentAnnotation = "notifications.tekton.dev/sent"
+)
+
+// Reconciler sends the notifications of the NotificationPolicies selecting a
+// PipelineRun once it completes. Each NotificationPolicy notifies at most once
+// about a PipelineRun.
+type Reconciler struct {
+	*reconciler.Base
+
+	pipelineRunLister        listers.PipelineRunLister
+	notificationPolicyLister listers.NotificationPolicyLister
+	httpClient               reconciler.HTTPDoer
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile sends the notifications about the PipelineRun key that haven't
+// been sent yet, if it is done.
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		c.Logger.Errorf("invalid resource key: %s", key)
+		return nil
+	}
+
+	pr, err := c.pipelineRunLister.PipelineRuns(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		c.Logger.Errorf("Error retrieving PipelineRun %q: %s", name, err)
+		return err
+	}
+	if !pr.IsDone() {
+		return nil
+	}
+
+	policies, err := c.policiesFor(pr)
+	if err != nil {
+		return err
+	}
+	sent := sentPolicies(pr)
+	var pending []*v1alpha1.NotificationPolicy
+	for _, np := range policies {
+		if !sent[np.Namespace+"/"+np.Name] && appliesTo(np, pr) {
+			pending = append(pending, np)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// The notifications are recorded as sent before being sent, so that
+	// failing to record them can't send them twice. Those that fail to send
+	// are then removed from the record to be retried.
+	ids := make([]string, 0, len(pending))
+	for _, np := range pending {
+		ids = append(ids, np.Namespace+"/"+np.Name)
+	}
+	recorded := sentAnnotation(pr, ids)
+	if err := c.recordSent(pr, pr.Annotations[SentAnnotation], recorded); err != nil {
+		return err
+	}
+
+	var newlySent []string
+	var merr error
+	for _, np := range pending {
+		id := np.Namespace + "/" + np.Name
+		if err := c.notify(ctx, np, pr); err != nil {
+			c.Logger.Errorf("Couldn't send the notification of NotificationPolicy %q about PipelineRun %q: %v", id, key, err)
+			c.Recorder.Eventf(pr, corev1.EventTypeWarning, "NotificationFailed", "Couldn't send the notification of NotificationPolicy %q: %v", id, err)
+			merr = multierror.Append(merr, fmt.Errorf("sending notification of NotificationPolicy %q: %w", id, err))
+			continue
+		}
+		newlySent = append(newlySent, id)
+	}
+
+	if len(newlySent) < len(pending) {
+		if err := c.recordSent(pr, recorded, sentAnnotation(pr, newlySent)); err != nil {
+			c.Logger.Errorf("Couldn't record the notifications that failed to send about PipelineRun %q, they won't be retried: %v", key, err)
+			merr = multierror.Append(merr, err)
+		}
+	}
+	return merr
+}
+
+// policiesFor returns the NotificationPolicies that may select pr: those in
+// its namespace and those in the namespace of the controller, sorted by
+// namespace and name.
+func (c *Reconciler) policiesFor(pr *v1alpha1.PipelineRun) ([]*v1alpha1.NotificationPolicy, error) {
+	policies, err := c.notificationPolicyLister.NotificationPolicies(pr.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("error listing NotificationPolicies in namespace %q: %w", pr.Namespace, err)
+	}
+	if ns := system.GetNamespace(); ns != pr.Namespace {
+		global, err := c.notificationPolicyLister.NotificationPolicies(ns).List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("error listing NotificationPolicies in namespace %q: %w", ns, err)
+		}
+		policies = append(policies, global...)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Namespace != policies[j].Namespace {
+			return policies[i].Namespace < policies[j].Namespace
+		}
+		return policies[i].Name < policies[j].Name
+	})
+	return policies, nil
+}
+
+func (c *Reconciler) notify(ctx context.Context, np *v1alpha1.NotificationPolicy, pr *v1alpha1.PipelineRun) error {
+	url, err := c.sinkURL(np)
+	if err != nil {
+		return err
+	}
+	message := np.Spec.Message
+	if message == "" {
+		message = v1alpha1.DefaultNotificationMessage
+	}
+	n := notification{
+		Namespace: pr.Namespace,
+		Name:      pr.Name,
+		Pipeline:  pipelineName(pr),
+		Outcome:   outcome(pr),
+		Duration:  duration(pr.Status.StartTime, pr.Status.CompletionTime),
+		Message:   substitution.ApplyReplacements(message, replacements(pr)),
+	}
+	return c.send(ctx, np.Spec.Sink.Type, url, n)
+}
+
+func (c *Reconciler) sinkURL(np *v1alpha1.NotificationPolicy) (string, error) {
+	ref := np.Spec.Sink.URLSecretRef
+	if ref == nil {
+		return np.Spec.Sink.URL, nil
+	}
+	secret, err := c.KubeClientSet.CoreV1().Secrets(np.Namespace).Get(ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	url, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %q has no %q key", ref.Name, ref.Key)
+	}
+	return strings.TrimSpace(string(url)), nil
+}
+
+// recordSent replaces the SentAnnotation of pr, current, with updated. It
+// patches only the annotation, and fails rather than overwriting it if it
+// isn't current anymore.
+func (c *Reconciler) recordSent(pr *v1alpha1.PipelineRun, current, updated string) error {
+	var patch []jsonpatch.JsonPatchOperation
+	switch {
+	case current != "":
+		patch = append(patch, jsonpatch.JsonPatchOperation{
+			Operation: "test",
+			Path:      sentAnnotationPath,
+			Value:     current,
+		}, jsonpatch.JsonPatchOperation{
+			Operation: "replace",
+			Path:      sentAnnotationPath,
+			Value:     updated,
+		})
+	case pr.Annotations == nil:
+		patch = append(patch, jsonpatch.JsonPatchOperation{
+			Operation: "add",
+			Path:      "/metadata/annotations",
+			Value:     map[string]string{SentAnnotation: updated},
+		})
+	default:
+		patch = append(patch, jsonpatch.JsonPatchOperation{
+			Operation: "add",
+			Path:      sentAnnotationPath,
+			Value:     updated,
+		})
+	}
+	b, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	if _, err := c.PipelineClientSet.TektonV1alpha1().PipelineRuns(pr.Namespace).Patch(pr.Name, types.JSONPatchType, b); err != nil {
+		return fmt.Errorf("error recording the notifications sent about PipelineRun %s/%s: %w", pr.Namespace, pr.Name, err)
+	}
+	return nil
+}
+
+// sentAnnotationPath is the JSON pointer to SentAnnotation, whose "/" is
+// escaped.
+var sentAnnotationPath = "/metadata/annotations/" + strings.Replace(SentAnnotation, "/", "~1", -1)
+
+// sentAnnotation returns the SentAnnotation of pr recording ids along with
+// the NotificationPolicies it already records.
+func sentAnnotation(pr *v1alpha1.PipelineRun, ids []string) string {
+	all := append([]string{}, ids...)
+	for id := range sentPolicies(pr) {
+		all = append(all, id)
+	}
+	sort.Strings(all)
+	return strings.Join(all, ",")
+}
+
+// sentPolicies returns the NotificationPolicies recorded as having sent their
+// notification about pr.
+func sentPolicies(pr *v1alpha1.PipelineRun) map[string]bool {
+	sent := map[string]bool{}
+	for _, id := range strings.Split(pr.Annotations[SentAnnotation], ",") {
+		if id != "" {
+			sent[id] = true
+		}
+	}
+	return sent
+}
+
+// appliesTo returns whether np selects pr. NotificationPolicies don't select
+// the PipelineRuns that completed before they were created, or the
+// PipelineRuns of other namespaces unless they are in the namespace of the
+// controller.
+func appliesTo(np *v1alpha1.NotificationPolicy, pr *v1alpha1.PipelineRun) bool {
+	if pr.Status.CompletionTime != nil && pr.Status.CompletionTime.Before(&np.CreationTimestamp) {
+		return false
+	}
+	if np.Namespace != system.GetNamespace() && np.Namespace != pr.Namespace {
+		return false
+	}
+
+	s := np.Spec.Selector
+	if len(s.Pipelines) > 0 && !contains(s.Pipelines, pipelineName(pr)) {
+		return false
+	}
+	if len(s.Namespaces) > 0 && np.Namespace == system.GetNamespace() && !contains(s.Namespaces, pr.Namespace) {
+		return false
+	}
+	if len(s.Outcomes) > 0 {
+		o := outcome(pr)
+		for _, so := range s.Outcomes {
+			if so == o {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func pipelineName(pr *v1alpha1.PipelineRun) string {
+	if pr.Spec.PipelineRef != nil {
+		return pr.Spec.PipelineRef.Name
+	}
+	return ""
+}
+
+// outcome returns the outcome of the done PipelineRun pr.
+func outcome(pr *v1alpha1.PipelineRun) v1alpha1.NotificationOutcome {
+	if pr.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		return v1alpha1.NotificationOutcomeSucceeded
+	}
+	return v1alpha1.NotificationOutcomeFailed
+}
+
+func conditionMessage(pr *v1alpha1.PipelineRun) string {
+	if c := pr.Status.GetCondition(apis.ConditionSucceeded); c != nil {
+		return c.Message
+	}
+	return ""
+}
+
+// duration returns the time between start and completion, rounded to the
+// second, or an empty string if either is unknown.
+func duration(start, completion *metav1.Time) string {
+	if start == nil || completion == nil {
+		return ""
+	}
+	return completion.Sub(start.Time).Round(time.Second).String()
+}
+
+// replacements returns the values of the variables of the message templates
+// for pr.
+func replacements(pr *v1alpha1.PipelineRun) map[string]string {
+	r := map[string]string{
+		"pipelineRun.name":      pr.Name,
+		"pipelineRun.namespace": pr.Namespace,
+		"pipelineRun.outcome":   string(outcome(pr)),
+		"pipelineRun.duration":  duration(pr.Status.StartTime, pr.Status.CompletionTime),
+		"pipelineRun.message":   conditionMessage(pr),
+		"pipeline.name":         pipelineName(pr),
+	}
+	for _, trs := range pr.Status.TaskRuns {
+		if trs.Status == nil {
+			continue
+		}
+		prefix := "tasks." + trs.PipelineTaskName
+		r[prefix+".duration"] = duration(trs.Status.StartTime, trs.Status.CompletionTime)
+		for _, result := range trs.Status.ResourcesResult {
+			r[prefix+".results."+result.Key] = result.Value
+		}
+		for _, result := range trs.Status.TaskRunResults {
+			r[prefix+".results."+result.Name] = result.Value
+		}
+	}
+	return r
+}
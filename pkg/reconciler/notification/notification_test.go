@@ -0,0 +1,322 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/system"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/configmap"
+)
+
+// sentRequest is a request sent to a sink.
+type sentRequest struct {
+	URL  string
+	Body map[string]interface{}
+}
+
+// fakeDoer records the requests sent to it, and responds with status to
+// those sent to the URLs in status.
+type fakeDoer struct {
+	requests []sentRequest
+	status   map[string]int
+}
+
+func (f *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	b, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil, err
+	}
+	f.requests = append(f.requests, sentRequest{URL: req.URL.String(), Body: body})
+	status := http.StatusOK
+	if s, ok := f.status[req.URL.String()]; ok {
+		status = s
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+var (
+	policyCreationTime = metav1.NewTime(time.Date(2019, 12, 1, 8, 0, 0, 0, time.UTC))
+	startTime          = metav1.NewTime(time.Date(2019, 12, 2, 9, 0, 0, 0, time.UTC))
+	completionTime     = metav1.NewTime(startTime.Add(90 * time.Second))
+
+	pr = &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pr",
+			Namespace:   "foo",
+			Annotations: map[string]string{SentAnnotation: "foo/already-sent"},
+		},
+		Spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "release"},
+		},
+		Status: v1alpha1.PipelineRunStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Message: "Tasks Completed: 2, Skipped: 0",
+			}}},
+			PipelineRunStatusFields: v1alpha1.PipelineRunStatusFields{
+				StartTime:      &startTime,
+				CompletionTime: &completionTime,
+				TaskRuns: map[string]*v1alpha1.PipelineRunTaskRunStatus{
+					"pr-build": {
+						PipelineTaskName: "build",
+						Status: &v1alpha1.TaskRunStatus{
+							TaskRunStatusFields: v1alpha1.TaskRunStatusFields{
+								StartTime:      &startTime,
+								CompletionTime: &metav1.Time{Time: startTime.Add(30 * time.Second)},
+								ResourcesResult: []v1alpha1.PipelineResourceResult{{
+									Key:   "digest",
+									Value: "sha256:1234",
+								}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	webhookSecret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook", Namespace: "foo"},
+		Data:       map[string][]byte{"url": []byte("https://example.com/notify\n")},
+	}
+)
+
+func policy(namespace, name string, spec v1alpha1.NotificationPolicySpec) *v1alpha1.NotificationPolicy {
+	return &v1alpha1.NotificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: policyCreationTime,
+		},
+		Spec: spec,
+	}
+}
+
+func slackSink(url string) v1alpha1.NotificationSink {
+	return v1alpha1.NotificationSink{Type: v1alpha1.NotificationSinkTypeSlack, URL: url}
+}
+
+func getController(t *testing.T, d reconcilertest.Data) (*Reconciler, reconcilertest.Clients, *fakeDoer, func()) {
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
+	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
+	r := NewController()(ctx, configMapWatcher).Reconciler.(*Reconciler)
+	doer := &fakeDoer{}
+	r.httpClient = doer
+	return r, c, doer, cancel
+}
+
+func TestReconcile(t *testing.T) {
+	late := policy("foo", "created-after-completion", v1alpha1.NotificationPolicySpec{Sink: slackSink("https://slack.example.com/late")})
+	late.CreationTimestamp = metav1.NewTime(completionTime.Add(time.Minute))
+
+	r, c, doer, cancel := getController(t, reconcilertest.Data{
+		PipelineRuns: []*v1alpha1.PipelineRun{pr},
+		NotificationPolicies: []*v1alpha1.NotificationPolicy{
+			policy("foo", "slack", v1alpha1.NotificationPolicySpec{
+				Selector: v1alpha1.NotificationSelector{
+					Pipelines: []string{"release"},
+					Outcomes:  []v1alpha1.NotificationOutcome{v1alpha1.NotificationOutcomeFailed},
+				},
+				Sink:    slackSink("https://slack.example.com/failures"),
+				Message: "$(pipeline.name) $(pipelineRun.outcome): build took $(tasks.build.duration) and built $(tasks.build.results.digest)",
+			}),
+			policy("foo", "webhook", v1alpha1.NotificationPolicySpec{
+				Sink: v1alpha1.NotificationSink{
+					Type: v1alpha1.NotificationSinkTypeWebhook,
+					URLSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+						Key:                  "url",
+					},
+				},
+			}),
+			policy("foo", "successes", v1alpha1.NotificationPolicySpec{
+				Selector: v1alpha1.NotificationSelector{
+					Outcomes: []v1alpha1.NotificationOutcome{v1alpha1.NotificationOutcomeSucceeded},
+				},
+				Sink: slackSink("https://slack.example.com/successes"),
+			}),
+			policy("foo", "other-pipeline", v1alpha1.NotificationPolicySpec{
+				Selector: v1alpha1.NotificationSelector{Pipelines: []string{"test"}},
+				Sink:     slackSink("https://slack.example.com/test"),
+			}),
+			policy("foo", "already-sent", v1alpha1.NotificationPolicySpec{Sink: slackSink("https://slack.example.com/sent")}),
+			late,
+			policy("bar", "other-namespace", v1alpha1.NotificationPolicySpec{Sink: slackSink("https://slack.example.com/bar")}),
+			policy(system.GetNamespace(), "global", v1alpha1.NotificationPolicySpec{
+				Selector: v1alpha1.NotificationSelector{Namespaces: []string{"foo"}},
+				Sink:     slackSink("https://slack.example.com/global"),
+				Message:  "$(pipelineRun.name): $(pipelineRun.message)",
+			}),
+			policy(system.GetNamespace(), "global-other-namespace", v1alpha1.NotificationPolicySpec{
+				Selector: v1alpha1.NotificationSelector{Namespaces: []string{"bar"}},
+				Sink:     slackSink("https://slack.example.com/global-bar"),
+			}),
+		},
+		Secrets: []*corev1.Secret{webhookSecret},
+	})
+	defer cancel()
+
+	if err := r.Reconcile(context.Background(), "foo/pr"); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	expected := []sentRequest{{
+		URL:  "https://slack.example.com/failures",
+		Body: map[string]interface{}{"text": "release Failed: build took 30s and built sha256:1234"},
+	}, {
+		URL: "https://example.com/notify",
+		Body: map[string]interface{}{"pipelineRun": map[string]interface{}{
+			"namespace": "foo",
+			"name":      "pr",
+			"pipeline":  "release",
+			"outcome":   "Failed",
+			"duration":  "1m30s",
+			"message":   "PipelineRun foo/pr Failed in 1m30s",
+		}},
+	}, {
+		URL:  "https://slack.example.com/global",
+		Body: map[string]interface{}{"text": "pr: Tasks Completed: 2, Skipped: 0"},
+	}}
+	if d := cmp.Diff(expected, doer.requests); d != "" {
+		t.Errorf("Unexpected notifications: %s", d)
+	}
+
+	updated, err := c.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("pr", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Getting PipelineRun: %v", err)
+	}
+	expectedSent := "foo/already-sent,foo/slack,foo/webhook," + system.GetNamespace() + "/global"
+	if d := cmp.Diff(expectedSent, updated.Annotations[SentAnnotation]); d != "" {
+		t.Errorf("Unexpected %s annotation: %s", SentAnnotation, d)
+	}
+}
+
+func TestReconcile_NotDone(t *testing.T) {
+	running := pr.DeepCopy()
+	running.Status.CompletionTime = nil
+	running.Status.Conditions[0].Status = corev1.ConditionUnknown
+
+	r, _, doer, cancel := getController(t, reconcilertest.Data{
+		PipelineRuns:         []*v1alpha1.PipelineRun{running},
+		NotificationPolicies: []*v1alpha1.NotificationPolicy{policy("foo", "slack", v1alpha1.NotificationPolicySpec{Sink: slackSink("https://slack.example.com")})},
+	})
+	defer cancel()
+
+	if err := r.Reconcile(context.Background(), "foo/pr"); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(doer.requests) != 0 {
+		t.Errorf("Expected no notification about a running PipelineRun, got %v", doer.requests)
+	}
+}
+
+func TestReconcile_SinkFailure(t *testing.T) {
+	r, c, doer, cancel := getController(t, reconcilertest.Data{
+		PipelineRuns: []*v1alpha1.PipelineRun{pr},
+		NotificationPolicies: []*v1alpha1.NotificationPolicy{
+			policy("foo", "broken", v1alpha1.NotificationPolicySpec{Sink: slackSink("https://slack.example.com/broken")}),
+			policy("foo", "working", v1alpha1.NotificationPolicySpec{Sink: slackSink("https://slack.example.com/working")}),
+		},
+	})
+	defer cancel()
+	doer.status = map[string]int{"https://slack.example.com/broken": http.StatusInternalServerError}
+
+	if err := r.Reconcile(context.Background(), "foo/pr"); err == nil {
+		t.Fatal("Expected an error when a sink fails")
+	}
+	if len(doer.requests) != 2 {
+		t.Errorf("Expected a notification to be sent to each sink, got %v", doer.requests)
+	}
+
+	// Only the notification that was sent is recorded, so that the other is
+	// retried.
+	updated, err := c.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("pr", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Getting PipelineRun: %v", err)
+	}
+	if d := cmp.Diff("foo/already-sent,foo/working", updated.Annotations[SentAnnotation]); d != "" {
+		t.Errorf("Unexpected %s annotation: %s", SentAnnotation, d)
+	}
+}
+
+func TestReconcile_RecordConflict(t *testing.T) {
+	r, c, doer, cancel := getController(t, reconcilertest.Data{
+		PipelineRuns:         []*v1alpha1.PipelineRun{pr},
+		NotificationPolicies: []*v1alpha1.NotificationPolicy{policy("foo", "slack", v1alpha1.NotificationPolicySpec{Sink: slackSink("https://slack.example.com")})},
+	})
+	defer cancel()
+	conflict := true
+	c.Pipeline.PrependReactor("patch", "pipelineruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if conflict {
+			return true, nil, errors.NewConflict(v1alpha1.Resource("pipelineruns"), "pr", nil)
+		}
+		return false, nil, nil
+	})
+
+	// A notification that can't be recorded isn't sent.
+	if err := r.Reconcile(context.Background(), "foo/pr"); err == nil {
+		t.Fatal("Expected an error when the notification can't be recorded")
+	}
+	if len(doer.requests) != 0 {
+		t.Errorf("Expected no notification to be sent, got %v", doer.requests)
+	}
+
+	// It is sent once it is recorded.
+	conflict = false
+	if err := r.Reconcile(context.Background(), "foo/pr"); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(doer.requests) != 1 {
+		t.Errorf("Expected a notification to be sent, got %v", doer.requests)
+	}
+	updated, err := c.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("pr", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Getting PipelineRun: %v", err)
+	}
+	if d := cmp.Diff("foo/already-sent,foo/slack", updated.Annotations[SentAnnotation]); d != "" {
+		t.Errorf("Unexpected %s annotation: %s", SentAnnotation, d)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+// notification is what is sent to a sink about a completed PipelineRun.
+type notification struct {
+	Namespace string                       `json:"namespace"`
+	Name      string                       `json:"name"`
+	Pipeline  string                       `json:"pipeline,omitempty"`
+	Outcome   v1alpha1.NotificationOutcome `json:"outcome"`
+	Duration  string                       `json:"duration,omitempty"`
+	Message   string                       `json:"message"`
+}
+
+// slackMessage is the payload of a Slack incoming webhook.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// webhookPayload is the payload sent to webhook sinks.
+type webhookPayload struct {
+	PipelineRun notification `json:"pipelineRun"`
+}
+
+// send sends n to the sink of type sinkType at url.
+func (c *Reconciler) send(ctx context.Context, sinkType v1alpha1.NotificationSinkType, url string, n notification) error {
+	var payload interface{}
+	switch sinkType {
+	case v1alpha1.NotificationSinkTypeSlack:
+		payload = slackMessage{Text: n.Message}
+	case v1alpha1.NotificationSinkTypeWebhook:
+		payload = webhookPayload{PipelineRun: n}
+	default:
+		return fmt.Errorf("unknown sink type %q", sinkType)
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sink responded with status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/pipelinerun"
+	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/taskrun"
+	"github.com/tektoncd/pipeline/pkg/health"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	pvcinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/persistentvolumeclaim"
+	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	resyncPeriod = 10 * time.Hour
+
+	// sweepKey is the only key the controller reconciles: each sweep looks
+	// at every Pod and PersistentVolumeClaim, then enqueues the next one.
+	sweepKey = "sweep"
+)
+
+// SweepPolicy configures how the orphaned Pods and PersistentVolumeClaims of
+// deleted runs are swept.
+type SweepPolicy struct {
+	// Interval is the time between two sweeps. Objects younger than it are
+	// never swept, so that the caches of the runs have caught up with them.
+	Interval time.Duration
+	// DryRun only logs and counts the orphans, without deleting them.
+	DryRun bool
+}
+
+// NewController returns the constructor of the controller periodically
+// deleting the Pods and PersistentVolumeClaims whose TaskRun or PipelineRun no
+// longer exists.
+func NewController(policy SweepPolicy) func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		kubeclientset := kubeclient.Get(ctx)
+		pipelineclientset := pipelineclient.Get(ctx)
+		podInformer := podinformer.Get(ctx)
+		pvcInformer := pvcinformer.Get(ctx)
+		taskRunInformer := taskruninformer.Get(ctx)
+		pipelineRunInformer := pipelineruninformer.Get(ctx)
+
+		opt := reconciler.Options{
+			KubeClientSet:     kubeclientset,
+			PipelineClientSet: pipelineclientset,
+			ConfigMapWatcher:  cmw,
+			ResyncPeriod:      resyncPeriod,
+			Logger:            logger,
+		}
+
+		metrics, err := NewRecorder()
+		if err != nil {
+			logger.Errorf("Failed to create orphan sweeper metrics recorder %v", err)
+		}
+
+		c := &Reconciler{
+			Base:              reconciler.NewBase(opt, orphanSweeperAgentName, pipeline.Images{}),
+			podLister:         podInformer.Lister(),
+			pvcLister:         pvcInformer.Lister(),
+			taskRunLister:     taskRunInformer.Lister(),
+			pipelineRunLister: pipelineRunInformer.Lister(),
+			policy:            policy,
+			metrics:           metrics,
+		}
+		impl := controller.NewImpl(c, c.Logger, pipeline.OrphanSweeperControllerName)
+		c.enqueueAfter = impl.EnqueueKeyAfter
+		health.DefaultChecks.Add(orphanSweeperAgentName+" informers", health.InformersSynced(
+			podInformer.Informer().HasSynced,
+			pvcInformer.Informer().HasSynced,
+			taskRunInformer.Informer().HasSynced,
+			pipelineRunInformer.Informer().HasSynced,
+		))
+
+		// The first sweep waits for a whole interval, so that the informers
+		// have synced and every run created before the controller started
+		// is known.
+		impl.EnqueueKeyAfter(sweepKey, policy.Interval)
+
+		return impl
+	}
+}
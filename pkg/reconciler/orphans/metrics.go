@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"errors"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+var (
+	orphansFound = stats.Float64("orphans_found_count",
+		"Number of pods and persistentvolumeclaims found whose run was deleted",
+		stats.UnitDimensionless)
+
+	orphansDeleted = stats.Float64("orphans_deleted_count",
+		"Number of pods and persistentvolumeclaims deleted because their run was deleted",
+		stats.UnitDimensionless)
+)
+
+// Recorder logs the metrics of the orphan sweeper.
+type Recorder struct {
+	initialized bool
+
+	kind tag.Key
+}
+
+// NewRecorder creates a new metrics recorder instance
+// to log the orphan sweeper related metrics
+func NewRecorder() (*Recorder, error) {
+	r := &Recorder{
+		initialized: true,
+	}
+
+	kind, err := tag.NewKey("kind")
+	if err != nil {
+		return nil, err
+	}
+	r.kind = kind
+
+	err = view.Register(
+		&view.View{
+			Description: orphansFound.Description(),
+			Measure:     orphansFound,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{r.kind},
+		},
+		&view.View{
+			Description: orphansDeleted.Description(),
+			Measure:     orphansDeleted,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{r.kind},
+		},
+	)
+
+	if err != nil {
+		r.initialized = false
+		return r, err
+	}
+
+	return r, nil
+}
+
+// Orphan counts an orphan of kind found by a sweep, and whether it was
+// deleted.
+func (r *Recorder) Orphan(kind string, deleted bool) error {
+	if r == nil || !r.initialized {
+		return errors.New("ignoring the metrics recording, failed to initialize the metrics recorder")
+	}
+
+	ctx, err := tag.New(context.Background(), tag.Insert(r.kind, kind))
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, orphansFound.M(1))
+	if deleted {
+		metrics.Record(ctx, orphansDeleted.M(1))
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// orphanSweeperAgentName defines logging agent name for the Orphan
+	// Sweeper Controller
+	orphanSweeperAgentName = "orphan-sweeper"
+
+	taskRunLabelKey     = pipeline.GroupName + pipeline.TaskRunLabelKey
+	pipelineRunLabelKey = pipeline.GroupName + pipeline.PipelineRunLabelKey
+
+	podKind = "Pod"
+	pvcKind = "PersistentVolumeClaim"
+)
+
+// Reconciler deletes the Pods of TaskRuns and the PersistentVolumeClaims of
+// PipelineRuns that no longer exist. Owner references normally have them
+// garbage collected with their run, but not when the run was deleted without
+// its dependents, e.g. with kubectl delete --cascade=false.
+type Reconciler struct {
+	*reconciler.Base
+
+	podLister         corelisters.PodLister
+	pvcLister         corelisters.PersistentVolumeClaimLister
+	taskRunLister     listers.TaskRunLister
+	pipelineRunLister listers.PipelineRunLister
+	policy            SweepPolicy
+	metrics           *Recorder
+	enqueueAfter      func(key string, delay time.Duration)
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// orphan is a Pod or PersistentVolumeClaim whose run no longer exists.
+type orphan struct {
+	kind      string
+	namespace string
+	name      string
+	uid       types.UID
+}
+
+// Reconcile sweeps the orphans, then schedules the next sweep. Failures are
+// only logged: they are retried by the next sweep.
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	defer c.enqueueAfter(key, c.policy.Interval)
+
+	orphans, err := c.findOrphans()
+	if err != nil {
+		c.Logger.Errorf("Error finding orphaned objects: %v", err)
+		return nil
+	}
+	for _, o := range orphans {
+		if c.policy.DryRun {
+			c.Logger.Infof("Found orphaned %s %s/%s, not deleting it in dry-run mode", o.kind, o.namespace, o.name)
+			c.record(o, false)
+			continue
+		}
+		if err := c.delete(o); err != nil && !errors.IsNotFound(err) && !errors.IsConflict(err) {
+			c.Logger.Errorf("Error deleting orphaned %s %s/%s: %v", o.kind, o.namespace, o.name, err)
+			c.record(o, false)
+			continue
+		}
+		c.Logger.Infof("Deleted orphaned %s %s/%s", o.kind, o.namespace, o.name)
+		c.record(o, true)
+	}
+	return nil
+}
+
+func (c *Reconciler) record(o orphan, deleted bool) {
+	if err := c.metrics.Orphan(o.kind, deleted); err != nil {
+		c.Logger.Warnf("Failed to log the metrics : %v", err)
+	}
+}
+
+// findOrphans returns the Pods labeled with a TaskRun and the
+// PersistentVolumeClaims labeled with a PipelineRun that no longer exists.
+func (c *Reconciler) findOrphans() ([]orphan, error) {
+	var orphans []orphan
+
+	pods, err := c.podLister.List(hasLabel(taskRunLabelKey))
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pods {
+		exists, err := c.ownerExists(p, "TaskRun", taskRunLabelKey, c.taskRunUID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			orphans = append(orphans, orphan{kind: podKind, namespace: p.Namespace, name: p.Name, uid: p.UID})
+		}
+	}
+
+	pvcs, err := c.pvcLister.List(hasLabel(pipelineRunLabelKey))
+	if err != nil {
+		return nil, err
+	}
+	for _, pvc := range pvcs {
+		exists, err := c.ownerExists(pvc, "PipelineRun", pipelineRunLabelKey, c.pipelineRunUID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			orphans = append(orphans, orphan{kind: pvcKind, namespace: pvc.Namespace, name: pvc.Name, uid: pvc.UID})
+		}
+	}
+	return orphans, nil
+}
+
+// ownerExists returns false if the run of kind named by the label of obj no
+// longer exists. Its owner reference, when it still has one, tells apart a
+// run re-created with the same name. Objects younger than the sweep interval
+// are assumed to have an owner.
+func (c *Reconciler) ownerExists(obj metav1.Object, kind, label string, getUID func(namespace, name string) (types.UID, error)) (bool, error) {
+	if time.Since(obj.GetCreationTimestamp().Time) < c.policy.Interval {
+		return true, nil
+	}
+	name := obj.GetLabels()[label]
+	uid, err := getUID(obj.GetNamespace(), name)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if owner := metav1.GetControllerOf(obj); owner != nil && owner.Kind == kind && owner.Name == name && owner.UID != uid {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (c *Reconciler) taskRunUID(namespace, name string) (types.UID, error) {
+	tr, err := c.taskRunLister.TaskRuns(namespace).Get(name)
+	if err != nil {
+		return "", err
+	}
+	return tr.UID, nil
+}
+
+func (c *Reconciler) pipelineRunUID(namespace, name string) (types.UID, error) {
+	pr, err := c.pipelineRunLister.PipelineRuns(namespace).Get(name)
+	if err != nil {
+		return "", err
+	}
+	return pr.UID, nil
+}
+
+// delete deletes the orphan, unless it was replaced by another object with
+// the same name since it was found.
+func (c *Reconciler) delete(o orphan) error {
+	opts := &metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &o.uid}}
+	switch o.kind {
+	case podKind:
+		return c.KubeClientSet.CoreV1().Pods(o.namespace).Delete(o.name, opts)
+	default:
+		return c.KubeClientSet.CoreV1().PersistentVolumeClaims(o.namespace).Delete(o.name, opts)
+	}
+}
+
+func hasLabel(key string) labels.Selector {
+	r, err := labels.NewRequirement(key, selection.Exists, nil)
+	if err != nil {
+		// The keys are constants, which are valid label keys.
+		panic(err)
+	}
+	return labels.NewSelector().Add(*r)
+}
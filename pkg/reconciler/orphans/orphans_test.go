@@ -0,0 +1,154 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/system"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/configmap"
+)
+
+var (
+	longAgo = metav1.NewTime(time.Date(2019, 12, 1, 8, 0, 0, 0, time.UTC))
+
+	taskRun = &v1alpha1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "build", UID: "build-uid"},
+	}
+	pipelineRun = &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "release", UID: "release-uid"},
+	}
+)
+
+func pod(name, taskRunName string, owner types.UID, created metav1.Time) *corev1.Pod {
+	p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace:         "foo",
+		Name:              name,
+		UID:               types.UID(name + "-uid"),
+		CreationTimestamp: created,
+	}}
+	if taskRunName != "" {
+		p.Labels = map[string]string{taskRunLabelKey: taskRunName}
+	}
+	if owner != "" {
+		controller := true
+		p.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "tekton.dev/v1alpha1",
+			Kind:       "TaskRun",
+			Name:       taskRunName,
+			UID:        owner,
+			Controller: &controller,
+		}}
+	}
+	return p
+}
+
+func pvc(name, pipelineRunName string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+		Namespace:         "foo",
+		Name:              name,
+		UID:               types.UID(name + "-uid"),
+		CreationTimestamp: longAgo,
+		Labels:            map[string]string{pipelineRunLabelKey: pipelineRunName},
+	}}
+}
+
+func getController(t *testing.T, d reconcilertest.Data, policy SweepPolicy) (*Reconciler, reconcilertest.Clients, *[]time.Duration, func()) {
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
+	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
+	r := NewController(policy)(ctx, configMapWatcher).Reconciler.(*Reconciler)
+	var enqueued []time.Duration
+	r.enqueueAfter = func(key string, delay time.Duration) {
+		enqueued = append(enqueued, delay)
+	}
+	return r, c, &enqueued, cancel
+}
+
+func deleted(c reconcilertest.Clients) []string {
+	var names []string
+	for _, a := range c.Kube.Actions() {
+		if d, ok := a.(ktesting.DeleteAction); ok {
+			names = append(names, d.GetResource().Resource+"/"+d.GetName())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestReconcile(t *testing.T) {
+	d := reconcilertest.Data{
+		TaskRuns:     []*v1alpha1.TaskRun{taskRun},
+		PipelineRuns: []*v1alpha1.PipelineRun{pipelineRun},
+		Pods: []*corev1.Pod{
+			pod("owned", "build", "build-uid", longAgo),
+			// The owner references of the dependents of a run deleted
+			// without them are removed.
+			pod("owner-deleted", "deleted", "", longAgo),
+			pod("owner-recreated", "build", "old-build-uid", longAgo),
+			pod("just-created", "deleted", "", metav1.Now()),
+			pod("not-tekton", "", "", longAgo),
+		},
+		PVCs: []*corev1.PersistentVolumeClaim{
+			pvc("release-pvc", "release"),
+			pvc("deleted-pvc", "deleted"),
+		},
+	}
+
+	for _, c := range []struct {
+		desc   string
+		dryRun bool
+		want   []string
+	}{{
+		desc: "deletes orphans",
+		want: []string{
+			"persistentvolumeclaims/deleted-pvc",
+			"pods/owner-deleted",
+			"pods/owner-recreated",
+		},
+	}, {
+		desc:   "dry run",
+		dryRun: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			r, clients, enqueued, cancel := getController(t, d, SweepPolicy{Interval: time.Hour, DryRun: c.dryRun})
+			defer cancel()
+
+			if err := r.Reconcile(context.Background(), sweepKey); err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+			if d := cmp.Diff(c.want, deleted(clients)); d != "" {
+				t.Errorf("Deleted objects (-want, +got): %s", d)
+			}
+			if d := cmp.Diff([]time.Duration{time.Hour}, *enqueued); d != "" {
+				t.Errorf("Expected the next sweep to be enqueued (-want, +got): %s", d)
+			}
+		})
+	}
+}
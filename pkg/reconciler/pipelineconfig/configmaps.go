@@ -0,0 +1,175 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelineconfig
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
+	"knative.dev/pkg/metrics"
+)
+
+// profilingKey is the key of config-observability enabling the profiling
+// server, which knative.dev/pkg/profiling doesn't export.
+const profilingKey = "profiling.enable"
+
+// configMaps returns the ConfigMaps the sections of spec replace, with the
+// data they hold.
+func configMaps(spec *v1alpha1.TektonPipelineConfigSpec) []v1alpha1.AppliedConfigMap {
+	var cms []v1alpha1.AppliedConfigMap
+	if d := spec.Defaults; d != nil {
+		cms = append(cms, v1alpha1.AppliedConfigMap{Name: config.DefaultsConfigName, Data: defaultsData(d)})
+	}
+	if ff := spec.FeatureFlags; ff != nil {
+		cms = append(cms, v1alpha1.AppliedConfigMap{Name: config.FeatureFlagsConfigName, Data: featureFlagsData(ff)})
+	}
+	if as := spec.ArtifactStorage; as != nil {
+		// An empty bucket ConfigMap makes the PVC store the artifacts.
+		cms = append(cms,
+			v1alpha1.AppliedConfigMap{Name: artifacts.GetBucketConfigName(), Data: bucketData(as.Bucket)},
+			v1alpha1.AppliedConfigMap{Name: artifacts.GetPVCConfigName(), Data: pvcData(as.PVC)},
+		)
+	}
+	if m := spec.Metrics; m != nil {
+		cms = append(cms, v1alpha1.AppliedConfigMap{Name: metrics.ConfigMapName(), Data: metricsData(m)})
+	}
+	return cms
+}
+
+func defaultsData(d *v1alpha1.PipelineConfigDefaults) map[string]string {
+	data := map[string]string{}
+	if d.TimeoutMinutes != nil {
+		data[config.DefaultTimeoutMinutesKey] = strconv.Itoa(*d.TimeoutMinutes)
+	}
+	if d.ServiceAccount != "" {
+		data[config.DefaultServiceAccountKey] = d.ServiceAccount
+	}
+	if d.MaximumTimeoutMinutes != 0 {
+		data[config.MaximumTimeoutMinutesKey] = strconv.Itoa(d.MaximumTimeoutMinutes)
+	}
+	if d.MaximumTimeoutPolicy != "" {
+		data[config.MaximumTimeoutPolicyKey] = d.MaximumTimeoutPolicy
+	}
+	if d.AllowNoTimeout != nil {
+		data[config.AllowNoTimeoutKey] = strconv.FormatBool(*d.AllowNoTimeout)
+	}
+	if d.MaximumPodVolumes != 0 {
+		data[config.MaximumPodVolumesKey] = strconv.Itoa(d.MaximumPodVolumes)
+	}
+	if d.MaximumMemoryVolumesSize != nil {
+		data[config.MaximumMemoryVolumesKey] = d.MaximumMemoryVolumesSize.String()
+	}
+	if d.StepsStartTimeout != nil {
+		data[config.StepsStartTimeoutKey] = d.StepsStartTimeout.Duration.String()
+	}
+	if d.MaximumPipelineTasks != 0 {
+		data[config.MaximumPipelineTasksKey] = strconv.Itoa(d.MaximumPipelineTasks)
+	}
+	if d.MaximumPipelineParams != 0 {
+		data[config.MaximumPipelineParamsKey] = strconv.Itoa(d.MaximumPipelineParams)
+	}
+	if d.MaximumMatrixCombinations != 0 {
+		data[config.MaximumMatrixCombinationsKey] = strconv.Itoa(d.MaximumMatrixCombinations)
+	}
+	if d.MaximumPipelineResultReferences != 0 {
+		data[config.MaximumPipelineResultRefsKey] = strconv.Itoa(d.MaximumPipelineResultReferences)
+	}
+	if d.CloudEventsSink != "" {
+		data[config.DefaultCloudEventsSinkKey] = d.CloudEventsSink
+	}
+	return data
+}
+
+func featureFlagsData(ff *v1alpha1.PipelineConfigFeatureFlags) map[string]string {
+	data := map[string]string{
+		config.DisableCredsInitKey:         strconv.FormatBool(ff.DisableCredsInit),
+		config.EnableCleanupFinalizerKey:   strconv.FormatBool(ff.EnableCleanupFinalizer),
+		config.RecordDefaultedFieldsKey:    strconv.FormatBool(ff.RecordDefaultedFields),
+		config.RecordStepCommandsKey:       strconv.FormatBool(ff.RecordStepCommands),
+		config.EnableRunCloudEventsSinkKey: strconv.FormatBool(ff.EnableRunCloudEventsSink),
+	}
+	if ff.CredsInitSecretLabelSelector != "" {
+		data[config.CredsInitSecretLabelSelectorKey] = ff.CredsInitSecretLabelSelector
+	}
+	if ff.CredsInitSecretAnnotationSelector != "" {
+		data[config.CredsInitSecretAnnotationSelectorKey] = ff.CredsInitSecretAnnotationSelector
+	}
+	if ff.AllowedOutputResources != nil {
+		types := make([]string, len(ff.AllowedOutputResources))
+		for i, t := range ff.AllowedOutputResources {
+			types[i] = string(t)
+		}
+		data[config.AllowedOutputResourcesKey] = strings.Join(types, ",")
+	}
+	return data
+}
+
+func bucketData(b *v1alpha1.PipelineConfigArtifactBucket) map[string]string {
+	data := map[string]string{}
+	if b == nil {
+		return data
+	}
+	data[artifacts.BucketLocationKey] = b.Location
+	if b.ServiceAccountSecret != nil {
+		data[artifacts.BucketServiceAccountSecretName] = b.ServiceAccountSecret.Name
+		data[artifacts.BucketServiceAccountSecretKey] = b.ServiceAccountSecret.Key
+	}
+	if b.ServiceAccountFieldName != "" {
+		data[artifacts.BucketServiceAccountFieldName] = b.ServiceAccountFieldName
+	}
+	if b.Provider != "" {
+		data[artifacts.BucketProviderKey] = b.Provider
+	}
+	if b.Region != "" {
+		data[artifacts.BucketRegionKey] = b.Region
+	}
+	if b.Endpoint != "" {
+		data[artifacts.BucketEndpointKey] = b.Endpoint
+	}
+	return data
+}
+
+func pvcData(pvc *v1alpha1.PipelineConfigArtifactPVC) map[string]string {
+	data := map[string]string{}
+	if pvc == nil {
+		return data
+	}
+	if pvc.Size != nil {
+		data[artifacts.PVCSizeKey] = pvc.Size.String()
+	}
+	if pvc.StorageClassName != "" {
+		data[artifacts.PVCStorageClassNameKey] = pvc.StorageClassName
+	}
+	return data
+}
+
+func metricsData(m *v1alpha1.PipelineConfigMetrics) map[string]string {
+	data := map[string]string{
+		metrics.AllowStackdriverCustomMetricsKey: strconv.FormatBool(m.AllowStackdriverCustomMetrics),
+		profilingKey:                             strconv.FormatBool(m.EnableProfiling),
+	}
+	if m.BackendDestination != "" {
+		data[metrics.BackendDestinationKey] = m.BackendDestination
+	}
+	if m.StackdriverProjectID != "" {
+		data[metrics.StackdriverProjectIDKey] = m.StackdriverProjectID
+	}
+	return data
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelineconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/system"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// pipelineConfigAgentName defines logging agent name for the
+	// PipelineConfig Controller
+	pipelineConfigAgentName = "pipelineconfig-controller"
+
+	// ReasonApplied indicates that the ConfigMaps hold the configuration
+	ReasonApplied = "Applied"
+	// ReasonFailed indicates that writing one of the ConfigMaps failed. It is
+	// written again.
+	ReasonFailed = "Failed"
+)
+
+// Reconciler writes the sections of the TektonPipelineConfig to the
+// ConfigMaps the controller and the webhook watch, and prunes the completed
+// runs.
+type Reconciler struct {
+	*reconciler.Base
+
+	pipelineConfigLister listers.TektonPipelineConfigLister
+	taskRunLister        listers.TaskRunLister
+	pipelineRunLister    listers.PipelineRunLister
+	enqueueAfter         func(key string, delay time.Duration)
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile applies the TektonPipelineConfig key, and prunes the completed
+// runs when the pruning interval elapsed.
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		c.Logger.Errorf("invalid resource key: %s", key)
+		return nil
+	}
+	// The webhook rejects the other names.
+	if name != v1alpha1.TektonPipelineConfigName {
+		return nil
+	}
+
+	original, err := c.pipelineConfigLister.Get(name)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		c.Logger.Errorf("Error retrieving TektonPipelineConfig %q: %s", name, err)
+		return err
+	}
+
+	tpc := original.DeepCopy()
+	tpc.SetDefaults(ctx)
+	if tpc.Status.GetCondition(apis.ConditionReady) == nil {
+		tpc.Status.InitializeConditions()
+	}
+	applyErr := c.apply(tpc)
+	if applyErr != nil {
+		c.Logger.Errorf("Failed to apply TektonPipelineConfig %q: %v", name, applyErr)
+		tpc.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonFailed,
+			Message: applyErr.Error(),
+		})
+	} else {
+		tpc.Status.ObservedGeneration = tpc.Generation
+		tpc.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionReady,
+			Status:  corev1.ConditionTrue,
+			Reason:  ReasonApplied,
+			Message: fmt.Sprintf("Applied to %d ConfigMaps", len(tpc.Status.ConfigMaps)),
+		})
+	}
+	if p := tpc.Spec.Pruning; p != nil {
+		c.pruneIfDue(key, tpc, p)
+	}
+
+	if !equality.Semantic.DeepEqual(original.Status, tpc.Status) {
+		if _, err := c.PipelineClientSet.TektonV1alpha1().TektonPipelineConfigs().UpdateStatus(tpc); err != nil {
+			c.Logger.Warnf("Failed to update the status of TektonPipelineConfig %q: %v", name, err)
+			return err
+		}
+	}
+	return applyErr
+}
+
+// apply writes the ConfigMaps of tpc that don't hold its data yet, and
+// records the ConfigMaps it applied in its status.
+func (c *Reconciler) apply(tpc *v1alpha1.TektonPipelineConfig) error {
+	tpc.Status.ConfigMaps = nil
+	for _, cm := range configMaps(&tpc.Spec) {
+		if err := c.writeConfigMap(cm); err != nil {
+			return fmt.Errorf("error writing ConfigMap %s: %w", cm.Name, err)
+		}
+		tpc.Status.ConfigMaps = append(tpc.Status.ConfigMaps, cm)
+	}
+	return nil
+}
+
+// writeConfigMap replaces the data of the ConfigMap cm names, creating it
+// if it doesn't exist.
+func (c *Reconciler) writeConfigMap(cm v1alpha1.AppliedConfigMap) error {
+	configMaps := c.KubeClientSet.CoreV1().ConfigMaps(system.GetNamespace())
+	existing, err := configMaps.Get(cm.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = configMaps.Create(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: cm.Name},
+			Data:       cm.Data,
+		})
+		return err
+	} else if err != nil {
+		return err
+	}
+	// Keys that aren't in the data, such as the examples of the ConfigMaps
+	// of the release, would be read as configuration: they are removed.
+	if reflect.DeepEqual(existing.Data, cm.Data) {
+		return nil
+	}
+	updated := existing.DeepCopy()
+	updated.Data = cm.Data
+	_, err = configMaps.Update(updated)
+	return err
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelineconfig
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/system"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/configmap"
+)
+
+var longAgo = time.Date(2019, 12, 1, 8, 0, 0, 0, time.UTC)
+
+func getController(t *testing.T, d reconcilertest.Data) (*Reconciler, reconcilertest.Clients, *[]time.Duration, func()) {
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
+	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
+	r := NewController()(ctx, configMapWatcher).Reconciler.(*Reconciler)
+	var enqueued []time.Duration
+	r.enqueueAfter = func(key string, delay time.Duration) {
+		enqueued = append(enqueued, delay)
+	}
+	return r, c, &enqueued, cancel
+}
+
+func getConfig(t *testing.T, c reconcilertest.Clients) *v1alpha1.TektonPipelineConfig {
+	t.Helper()
+	tpc, err := c.Pipeline.TektonV1alpha1().TektonPipelineConfigs().Get("config", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting the TektonPipelineConfig: %v", err)
+	}
+	return tpc
+}
+
+func TestReconcile(t *testing.T) {
+	timeout := 30
+	tpc := &v1alpha1.TektonPipelineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Generation: 2},
+		Spec: v1alpha1.TektonPipelineConfigSpec{
+			Defaults: &v1alpha1.PipelineConfigDefaults{TimeoutMinutes: &timeout, ServiceAccount: "builder", CloudEventsSink: "http://events.example.com"},
+			FeatureFlags: &v1alpha1.PipelineConfigFeatureFlags{
+				RecordStepCommands:           true,
+				CredsInitSecretLabelSelector: "tekton.dev/creds-init=allowed",
+				AllowedOutputResources:       []v1alpha1.PipelineResourceType{v1alpha1.PipelineResourceTypeGit, v1alpha1.PipelineResourceTypeImage},
+			},
+			ArtifactStorage: &v1alpha1.PipelineConfigArtifactStorage{
+				Bucket: &v1alpha1.PipelineConfigArtifactBucket{Location: "gs://my-bucket"},
+			},
+			Metrics: &v1alpha1.PipelineConfigMetrics{EnableProfiling: true},
+		},
+	}
+	d := reconcilertest.Data{
+		PipelineConfigs: []*v1alpha1.TektonPipelineConfig{tpc},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.GetNamespace(), Name: "config-defaults"},
+			Data:       map[string]string{"_example": "default-timeout-minutes: 60", "default-timeout-minutes": "60"},
+		}},
+	}
+	r, c, _, cancel := getController(t, d)
+	defer cancel()
+
+	if err := r.Reconcile(context.Background(), "config"); err != nil {
+		t.Fatalf("Reconcile() unexpected error = %v", err)
+	}
+
+	wantConfigMaps := []v1alpha1.AppliedConfigMap{{
+		Name: "config-defaults",
+		Data: map[string]string{"default-timeout-minutes": "30", "default-service-account": "builder", "default-cloud-events-sink": "http://events.example.com"},
+	}, {
+		Name: "feature-flags",
+		Data: map[string]string{
+			"disable-creds-init":               "false",
+			"enable-cleanup-finalizer":         "false",
+			"record-defaulted-fields":          "false",
+			"record-step-commands":             "true",
+			"enable-run-cloud-events-sink":     "false",
+			"creds-init-secret-label-selector": "tekton.dev/creds-init=allowed",
+			"allowed-output-resources":         "git,image",
+		},
+	}, {
+		Name: "config-artifact-bucket",
+		Data: map[string]string{"location": "gs://my-bucket"},
+	}, {
+		Name: "config-artifact-pvc",
+		Data: map[string]string{},
+	}, {
+		Name: "config-observability",
+		Data: map[string]string{"metrics.allow-stackdriver-custom-metrics": "false", "profiling.enable": "true"},
+	}}
+	for _, want := range wantConfigMaps {
+		cm, err := c.Kube.CoreV1().ConfigMaps(system.GetNamespace()).Get(want.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Error getting ConfigMap %s: %v", want.Name, err)
+		}
+		if d := cmp.Diff(want.Data, cm.Data); d != "" {
+			t.Errorf("ConfigMap %s data diff -want, +got: %v", want.Name, d)
+		}
+	}
+
+	got := getConfig(t, c)
+	if d := cmp.Diff(wantConfigMaps, got.Status.ConfigMaps); d != "" {
+		t.Errorf("Applied ConfigMaps diff -want, +got: %v", d)
+	}
+	if got.Status.ObservedGeneration != 2 {
+		t.Errorf("Expected the observed generation to be 2, got %d", got.Status.ObservedGeneration)
+	}
+	condition := got.Status.GetCondition(apis.ConditionReady)
+	if condition == nil || condition.Status != corev1.ConditionTrue || condition.Reason != ReasonApplied {
+		t.Errorf("Expected the TektonPipelineConfig to be applied, got %v", condition)
+	}
+}
+
+func completed() duckv1beta1.Conditions {
+	return duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}}
+}
+
+func pipelineRun(namespace, name string, completedAt *time.Time) *v1alpha1.PipelineRun {
+	pr := &v1alpha1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID(name + "-uid")}}
+	if completedAt != nil {
+		pr.Status.Conditions = completed()
+		pr.Status.CompletionTime = &metav1.Time{Time: *completedAt}
+	}
+	return pr
+}
+
+func taskRun(namespace, name, pipelineRunName string, completedAt *time.Time) *v1alpha1.TaskRun {
+	tr := &v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID(name + "-uid")}}
+	if pipelineRunName != "" {
+		tr.Labels = map[string]string{pipelineRunLabelKey: pipelineRunName}
+	}
+	if completedAt != nil {
+		tr.Status.Conditions = completed()
+		tr.Status.CompletionTime = &metav1.Time{Time: *completedAt}
+	}
+	return tr
+}
+
+func at(minutes int) *time.Time {
+	t := longAgo.Add(time.Duration(minutes) * time.Minute)
+	return &t
+}
+
+func deleted(c reconcilertest.Clients) []string {
+	var names []string
+	for _, a := range c.Pipeline.Actions() {
+		if d, ok := a.(ktesting.DeleteAction); ok {
+			names = append(names, d.GetResource().Resource+"/"+d.GetNamespace()+"/"+d.GetName())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestReconcile_Prune(t *testing.T) {
+	tpc := &v1alpha1.TektonPipelineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "config"},
+		Spec: v1alpha1.TektonPipelineConfigSpec{
+			Pruning: &v1alpha1.PipelineConfigPruning{Keep: 1, Interval: &metav1.Duration{Duration: 30 * time.Minute}},
+		},
+	}
+	d := reconcilertest.Data{
+		PipelineConfigs: []*v1alpha1.TektonPipelineConfig{tpc},
+		PipelineRuns: []*v1alpha1.PipelineRun{
+			pipelineRun("foo", "first", at(1)),
+			pipelineRun("foo", "last", at(3)),
+			pipelineRun("foo", "second", at(2)),
+			pipelineRun("foo", "running", nil),
+			pipelineRun("bar", "only", at(1)),
+		},
+		TaskRuns: []*v1alpha1.TaskRun{
+			// Deleted with their PipelineRun.
+			taskRun("foo", "first-build", "first", at(1)),
+			taskRun("foo", "standalone-first", "", at(1)),
+			taskRun("foo", "standalone-last", "", at(2)),
+		},
+	}
+	r, c, enqueued, cancel := getController(t, d)
+	defer cancel()
+
+	if err := r.Reconcile(context.Background(), "config"); err != nil {
+		t.Fatalf("Reconcile() unexpected error = %v", err)
+	}
+
+	want := []string{
+		"pipelineruns/foo/first",
+		"pipelineruns/foo/second",
+		"taskruns/foo/standalone-first",
+	}
+	if d := cmp.Diff(want, deleted(c)); d != "" {
+		t.Errorf("Deleted runs diff -want, +got: %v", d)
+	}
+	got := getConfig(t, c)
+	if got.Status.PrunedRuns != 3 || got.Status.LastPruneTime == nil {
+		t.Errorf("Expected 3 pruned runs and a prune time, got %d and %v", got.Status.PrunedRuns, got.Status.LastPruneTime)
+	}
+	if d := cmp.Diff([]time.Duration{30 * time.Minute}, *enqueued); d != "" {
+		t.Errorf("Enqueued delays diff -want, +got: %v", d)
+	}
+}
+
+func TestReconcile_PruneAlreadyDeleted(t *testing.T) {
+	tpc := &v1alpha1.TektonPipelineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "config"},
+		Spec: v1alpha1.TektonPipelineConfigSpec{
+			Pruning: &v1alpha1.PipelineConfigPruning{Keep: 1, Interval: &metav1.Duration{Duration: time.Hour}},
+		},
+	}
+	d := reconcilertest.Data{
+		PipelineConfigs: []*v1alpha1.TektonPipelineConfig{tpc},
+		PipelineRuns: []*v1alpha1.PipelineRun{
+			pipelineRun("foo", "first", at(1)),
+			pipelineRun("foo", "second", at(2)),
+			pipelineRun("foo", "last", at(3)),
+		},
+	}
+	r, c, _, cancel := getController(t, d)
+	defer cancel()
+	// The first run was deleted since the lister saw it.
+	c.Pipeline.PrependReactor("delete", "pipelineruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if action.(ktesting.DeleteAction).GetName() == "first" {
+			return true, nil, kerrors.NewNotFound(v1alpha1.Resource("pipelineruns"), "first")
+		}
+		return false, nil, nil
+	})
+
+	if err := r.Reconcile(context.Background(), "config"); err != nil {
+		t.Fatalf("Reconcile() unexpected error = %v", err)
+	}
+	if got := getConfig(t, c); got.Status.PrunedRuns != 1 {
+		t.Errorf("Expected 1 pruned run, got %d", got.Status.PrunedRuns)
+	}
+}
+
+func TestReconcile_PruneWithoutKeep(t *testing.T) {
+	tpc := &v1alpha1.TektonPipelineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "config"},
+		Spec: v1alpha1.TektonPipelineConfigSpec{
+			Pruning: &v1alpha1.PipelineConfigPruning{Interval: &metav1.Duration{Duration: time.Hour}},
+		},
+	}
+	d := reconcilertest.Data{
+		PipelineConfigs: []*v1alpha1.TektonPipelineConfig{tpc},
+		PipelineRuns:    []*v1alpha1.PipelineRun{pipelineRun("foo", "first", at(1))},
+	}
+	r, c, _, cancel := getController(t, d)
+	defer cancel()
+
+	if err := r.Reconcile(context.Background(), "config"); err != nil {
+		t.Fatalf("Reconcile() unexpected error = %v", err)
+	}
+	if names := deleted(c); len(names) != 0 {
+		t.Errorf("Expected no run to be pruned without keep, got %v", names)
+	}
+}
+
+func TestReconcile_PruneNotDue(t *testing.T) {
+	tpc := &v1alpha1.TektonPipelineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "config"},
+		Spec: v1alpha1.TektonPipelineConfigSpec{
+			Pruning: &v1alpha1.PipelineConfigPruning{Keep: 1, Interval: &metav1.Duration{Duration: time.Hour}},
+		},
+		Status: v1alpha1.TektonPipelineConfigStatus{
+			LastPruneTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+	d := reconcilertest.Data{
+		PipelineConfigs: []*v1alpha1.TektonPipelineConfig{tpc},
+		PipelineRuns:    []*v1alpha1.PipelineRun{pipelineRun("foo", "first", at(1))},
+	}
+	r, c, enqueued, cancel := getController(t, d)
+	defer cancel()
+
+	if err := r.Reconcile(context.Background(), "config"); err != nil {
+		t.Fatalf("Reconcile() unexpected error = %v", err)
+	}
+	if names := deleted(c); len(names) != 0 {
+		t.Errorf("Expected no run to be pruned before the interval elapsed, got %v", names)
+	}
+	if len(*enqueued) != 1 || (*enqueued)[0] > 50*time.Minute || (*enqueued)[0] < 49*time.Minute {
+		t.Errorf("Expected the next pruning in about 50m, got %v", *enqueued)
+	}
+}
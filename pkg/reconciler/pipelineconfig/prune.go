@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelineconfig
+
+import (
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const pipelineRunLabelKey = pipeline.GroupName + pipeline.PipelineRunLabelKey
+
+// completedRun is a completed PipelineRun or TaskRun that may be pruned.
+type completedRun struct {
+	kind      string
+	namespace string
+	name      string
+	uid       types.UID
+	completed time.Time
+	delete    func(namespace, name string, opts *metav1.DeleteOptions) error
+}
+
+// pruneIfDue prunes the completed runs if the interval of p elapsed since the
+// last pruning, and schedules the next one. Failures are only logged: they
+// are retried by the next pruning.
+func (c *Reconciler) pruneIfDue(key string, tpc *v1alpha1.TektonPipelineConfig, p *v1alpha1.PipelineConfigPruning) {
+	// The webhook rejects it, but a config stored before it did must not
+	// delete every completed run.
+	if p.Keep < 1 {
+		c.Logger.Errorf("Not pruning the completed runs: keep is %d, it should be at least 1", p.Keep)
+		return
+	}
+	if last := tpc.Status.LastPruneTime; last != nil {
+		if wait := p.Interval.Duration - time.Since(last.Time); wait > 0 {
+			c.enqueueAfter(key, wait)
+			return
+		}
+	}
+	defer c.enqueueAfter(key, p.Interval.Duration)
+
+	runs, err := c.completedRuns()
+	if err != nil {
+		c.Logger.Errorf("Error listing the completed runs: %v", err)
+		return
+	}
+	for _, r := range prunable(runs, p.Keep) {
+		opts := &metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &r.uid}}
+		switch err := r.delete(r.namespace, r.name, opts); {
+		case err == nil:
+			c.Logger.Infof("Pruned %s %s/%s", r.kind, r.namespace, r.name)
+			tpc.Status.PrunedRuns++
+		case errors.IsNotFound(err) || errors.IsConflict(err):
+			// The run was deleted, or replaced, since it was listed.
+		default:
+			c.Logger.Errorf("Error pruning %s %s/%s: %v", r.kind, r.namespace, r.name, err)
+		}
+	}
+	tpc.Status.LastPruneTime = &metav1.Time{Time: time.Now()}
+}
+
+// completedRuns returns the completed PipelineRuns, and the completed
+// TaskRuns not run by a PipelineRun: those are deleted with their
+// PipelineRun.
+func (c *Reconciler) completedRuns() ([]completedRun, error) {
+	var runs []completedRun
+	prs, err := c.pipelineRunLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	deletePipelineRun := func(namespace, name string, opts *metav1.DeleteOptions) error {
+		return c.PipelineClientSet.TektonV1alpha1().PipelineRuns(namespace).Delete(name, opts)
+	}
+	for _, pr := range prs {
+		if pr.IsDone() {
+			runs = append(runs, completedRun{kind: "PipelineRun", namespace: pr.Namespace, name: pr.Name, uid: pr.UID, completed: completionTime(pr.Status.CompletionTime, pr.CreationTimestamp), delete: deletePipelineRun})
+		}
+	}
+
+	trs, err := c.taskRunLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	deleteTaskRun := func(namespace, name string, opts *metav1.DeleteOptions) error {
+		return c.PipelineClientSet.TektonV1alpha1().TaskRuns(namespace).Delete(name, opts)
+	}
+	for _, tr := range trs {
+		if _, ok := tr.Labels[pipelineRunLabelKey]; !ok && tr.IsDone() {
+			runs = append(runs, completedRun{kind: "TaskRun", namespace: tr.Namespace, name: tr.Name, uid: tr.UID, completed: completionTime(tr.Status.CompletionTime, tr.CreationTimestamp), delete: deleteTaskRun})
+		}
+	}
+	return runs, nil
+}
+
+// completionTime returns the time a run completed, or the time it was created
+// when it completed without recording it.
+func completionTime(completed *metav1.Time, created metav1.Time) time.Time {
+	if completed != nil {
+		return completed.Time
+	}
+	return created.Time
+}
+
+// prunable returns the runs that exceed the keep runs of their kind that
+// completed last in their namespace.
+func prunable(runs []completedRun, keep int) []completedRun {
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].completed.After(runs[j].completed)
+	})
+	kept := map[string]int{}
+	var pruned []completedRun
+	for _, r := range runs {
+		group := r.kind + "/" + r.namespace
+		if kept[group] < keep {
+			kept[group]++
+			continue
+		}
+		pruned = append(pruned, r)
+	}
+	return pruned
+}
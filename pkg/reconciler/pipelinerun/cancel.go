@@ -29,20 +29,19 @@ import (
 	"knative.dev/pkg/apis"
 )
 
+// runCancellationGracePeriod is how long a PipelineRun that was cancelled or
+// timed out waits for the controllers of its custom tasks to stop its Runs,
+// before it completes anyway.
+var runCancellationGracePeriod = 30 * time.Second
+
 // cancelPipelineRun makrs the PipelineRun as cancelled and any resolved taskrun too.
-func cancelPipelineRun(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask, clientSet clientset.Interface) error {
-	pr.Status.SetCondition(&apis.Condition{
-		Type:    apis.ConditionSucceeded,
-		Status:  corev1.ConditionFalse,
-		Reason:  "PipelineRunCancelled",
-		Message: fmt.Sprintf("PipelineRun %q was cancelled", pr.Name),
-	})
-	// update pr completed time
-	pr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+// It returns how much longer the PipelineRun waits for its Runs to stop
+// before it is marked as cancelled, 0 once it is.
+func cancelPipelineRun(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask, clientSet clientset.Interface) (time.Duration, error) {
 	errs := []string{}
 	for _, rprt := range pipelineState {
-		if rprt.TaskRun == nil {
-			// No taskrun yet, pass
+		if rprt.TaskRun == nil || rprt.TaskRun.IsCancelled() {
+			// No taskrun yet, or already cancelled, pass
 			continue
 		}
 		rprt.TaskRun.Spec.Status = v1alpha1.TaskRunSpecStatusCancelled
@@ -53,8 +52,68 @@ func cancelPipelineRun(pr *v1alpha1.PipelineRun, pipelineState []*resources.Reso
 			errs = append(errs, err.Error())
 		}
 	}
+	wait, err := stopRuns(pr, pipelineState, clientSet)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	if wait == 0 {
+		pr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  "PipelineRunCancelled",
+			Message: fmt.Sprintf("PipelineRun %q was cancelled", pr.Name),
+		})
+		// update pr completed time
+		pr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	}
+	if len(errs) > 0 {
+		return wait, fmt.Errorf("error cancelled PipelineRun's TaskRun(s): %s", strings.Join(errs, "\n"))
+	}
+	return wait, nil
+}
+
+// stopRuns sets the spec status of the Runs of pipelineState that are still
+// running to RunCancelled. While some of them haven't stopped, it sets the
+// Succeeded condition of the PipelineRun to ReasonCancelling and returns how
+// much longer to wait for them, within runCancellationGracePeriod of the
+// PipelineRun starting to wait. It returns 0 once there is nothing to wait for.
+func stopRuns(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask, clientSet clientset.Interface) (time.Duration, error) {
+	errs := []string{}
+	running := false
+	for _, rprt := range pipelineState {
+		if rprt.Run == nil || rprt.Run.IsDone() {
+			continue
+		}
+		running = true
+		if rprt.Run.IsCancelled() {
+			continue
+		}
+		rprt.Run.Spec.Status = v1alpha1.RunSpecStatusCancelled
+		if _, err := clientSet.TektonV1alpha1().Runs(pr.Namespace).Update(rprt.Run); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	var err error
 	if len(errs) > 0 {
-		return fmt.Errorf("error cancelled PipelineRun's TaskRun(s): %s", strings.Join(errs, "\n"))
+		err = fmt.Errorf("error cancelling Run(s): %s", strings.Join(errs, "\n"))
+	}
+	if !running {
+		return 0, err
 	}
-	return nil
+
+	// The condition keeps the time it was first set to ReasonCancelling, as
+	// long as it isn't changed.
+	if c := pr.Status.GetCondition(apis.ConditionSucceeded); c != nil && c.Reason == ReasonCancelling {
+		if wait := time.Until(c.LastTransitionTime.Inner.Add(runCancellationGracePeriod)); wait > 0 {
+			return wait, err
+		}
+		return 0, err
+	}
+	pr.Status.SetCondition(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionUnknown,
+		Reason:  ReasonCancelling,
+		Message: fmt.Sprintf("PipelineRun %q is waiting for the controllers of its custom tasks to stop its Runs", pr.Name),
+	})
+	return runCancellationGracePeriod, err
 }
@@ -19,14 +19,17 @@ package pipelinerun
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
-	"github.com/tektoncd/pipeline/test"
 	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 func TestCancelPipelineRun(t *testing.T) {
@@ -35,6 +38,9 @@ func TestCancelPipelineRun(t *testing.T) {
 		pipelineRun   *v1alpha1.PipelineRun
 		pipelineState []*resources.ResolvedPipelineRunTask
 		taskRuns      []*v1alpha1.TaskRun
+		runs          []*v1alpha1.Run
+		// wantWait is true if the PipelineRun waits for its Runs to stop.
+		wantWait bool
 	}{{
 		name: "no-resolved-taskrun",
 		pipelineRun: tb.PipelineRun("test-pipeline-run-cancelled", "foo",
@@ -66,26 +72,74 @@ func TestCancelPipelineRun(t *testing.T) {
 			{TaskRunName: "t2", TaskRun: tb.TaskRun("t2", "foo")},
 		},
 		taskRuns: []*v1alpha1.TaskRun{tb.TaskRun("t1", "foo"), tb.TaskRun("t2", "foo")},
+	}, {
+		name: "resolved-run",
+		pipelineRun: tb.PipelineRun("test-pipeline-run-cancelled", "foo",
+			tb.PipelineRunSpec("test-pipeline",
+				tb.PipelineRunCancelled,
+			),
+		),
+		pipelineState: []*resources.ResolvedPipelineRunTask{
+			{TaskRunName: "t1", TaskRun: tb.TaskRun("t1", "foo")},
+			{CustomTask: true, RunName: "r1", Run: &v1alpha1.Run{ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "foo"}}},
+		},
+		taskRuns: []*v1alpha1.TaskRun{tb.TaskRun("t1", "foo")},
+		runs:     []*v1alpha1.Run{{ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "foo"}}},
+		wantWait: true,
+	}, {
+		name: "resolved-run-stopped",
+		pipelineRun: tb.PipelineRun("test-pipeline-run-cancelled", "foo",
+			tb.PipelineRunSpec("test-pipeline",
+				tb.PipelineRunCancelled,
+			),
+		),
+		pipelineState: []*resources.ResolvedPipelineRunTask{
+			{CustomTask: true, RunName: "r1", Run: stoppedRun("r1")},
+		},
+		runs: []*v1alpha1.Run{stoppedRun("r1")},
+	}, {
+		name: "resolved-run-grace-period-over",
+		pipelineRun: tb.PipelineRun("test-pipeline-run-cancelled", "foo",
+			tb.PipelineRunSpec("test-pipeline",
+				tb.PipelineRunCancelled,
+			),
+			tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+				Type:               apis.ConditionSucceeded,
+				Status:             corev1.ConditionUnknown,
+				Reason:             ReasonCancelling,
+				LastTransitionTime: apis.VolatileTime{Inner: metav1.NewTime(time.Now().Add(-runCancellationGracePeriod))},
+			})),
+		),
+		pipelineState: []*resources.ResolvedPipelineRunTask{
+			{CustomTask: true, RunName: "r1", Run: &v1alpha1.Run{ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "foo"}}},
+		},
+		runs: []*v1alpha1.Run{{ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "foo"}}},
 	}}
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			d := test.Data{
+			d := reconcilertest.Data{
 				PipelineRuns: []*v1alpha1.PipelineRun{tc.pipelineRun},
 				TaskRuns:     tc.taskRuns,
+				Runs:         tc.runs,
 			}
 			ctx, _ := ttesting.SetupFakeContext(t)
 			ctx, cancel := context.WithCancel(ctx)
 			defer cancel()
-			c, _ := test.SeedTestData(t, ctx, d)
-			err := cancelPipelineRun(tc.pipelineRun, tc.pipelineState, c.Pipeline)
+			c, _ := reconcilertest.SeedTestData(t, ctx, d)
+			wait, err := cancelPipelineRun(tc.pipelineRun, tc.pipelineState, c.Pipeline)
 			if err != nil {
 				t.Fatal(err)
 			}
-			// This PipelineRun should still be complete and false, and the status should reflect that
 			cond := tc.pipelineRun.Status.GetCondition(apis.ConditionSucceeded)
-			if cond.IsTrue() {
-				t.Errorf("Expected PipelineRun status to be complete and false, but was %v", cond)
+			if tc.wantWait {
+				// This PipelineRun should wait for its Runs to stop before it completes
+				if wait <= 0 || wait > runCancellationGracePeriod || !cond.IsUnknown() || cond.Reason != ReasonCancelling {
+					t.Errorf("Expected PipelineRun to wait for its Runs, but waits %s with status %v", wait, cond)
+				}
+			} else if wait != 0 || !cond.IsFalse() {
+				// This PipelineRun should be complete and false, and the status should reflect that
+				t.Errorf("Expected PipelineRun status to be complete and false, but waits %s with status %v", wait, cond)
 			}
 			l, err := c.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{})
 			if err != nil {
@@ -96,6 +150,27 @@ func TestCancelPipelineRun(t *testing.T) {
 					t.Errorf("expected task %q to be marked as cancelled, was %q", tr.Name, tr.Spec.Status)
 				}
 			}
+			runs, err := c.Pipeline.TektonV1alpha1().Runs("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range runs.Items {
+				if !r.IsCancelled() {
+					t.Errorf("expected run %q to be marked as cancelled, was %q", r.Name, r.Spec.Status)
+				}
+			}
 		})
 	}
 }
+
+// stoppedRun returns a Run that its controller cancelled.
+func stoppedRun(name string) *v1alpha1.Run {
+	return &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "foo"},
+		Spec:       v1alpha1.RunSpec{Status: v1alpha1.RunSpecStatusCancelled},
+		Status: v1alpha1.RunStatus{Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+		}}}},
+	}
+}
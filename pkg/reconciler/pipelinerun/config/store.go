@@ -19,6 +19,7 @@ package config
 import (
 	"context"
 
+	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/artifacts"
@@ -30,6 +31,8 @@ type cfgKey struct{}
 // +k8s:deepcopy-gen=false
 type Config struct {
 	ArtifactBucket *v1alpha1.ArtifactBucket
+	FeatureFlags   *apisconfig.FeatureFlags
+	Defaults       *apisconfig.Defaults
 }
 
 func FromContext(ctx context.Context) *Config {
@@ -53,31 +56,47 @@ func NewStore(images pipeline.Images, logger configmap.Logger) *Store {
 			"pipelinerun",
 			logger,
 			configmap.Constructors{
-				artifacts.GetBucketConfigName(): artifacts.NewArtifactBucketConfigFromConfigMap(images),
+				artifacts.GetBucketConfigName():   artifacts.NewArtifactBucketConfigFromConfigMap(images),
+				apisconfig.FeatureFlagsConfigName: apisconfig.NewFeatureFlagsFromConfigMap,
+				apisconfig.DefaultsConfigName:     apisconfig.NewDefaultsFromConfigMap,
 			},
 		),
 		images: images,
 	}
 }
 
+// ToContext attaches the Config to ctx, along with the config of the APIs
+// validating the Pipelines with the Defaults and FeatureFlags of the Config.
 func (s *Store) ToContext(ctx context.Context) context.Context {
-	return ToContext(ctx, s.Load())
+	cfg := s.Load()
+	ctx = apisconfig.ToContext(ctx, &apisconfig.Config{
+		Defaults:     cfg.Defaults,
+		FeatureFlags: cfg.FeatureFlags,
+		TaskPolicy:   apisconfig.FromContextOrDefaults(context.Background()).TaskPolicy,
+	})
+	return ToContext(ctx, cfg)
 }
 
 func (s *Store) Load() *Config {
-	ep := s.UntypedLoad(artifacts.GetBucketConfigName())
-	if ep == nil {
-		return &Config{
-			ArtifactBucket: &v1alpha1.ArtifactBucket{
-				Location:    "",
-				ShellImage:  s.images.ShellImage,
-				GsutilImage: s.images.GsutilImage,
-			},
-		}
+	defaults := apisconfig.FromContextOrDefaults(context.Background())
+	cfg := &Config{
+		ArtifactBucket: &v1alpha1.ArtifactBucket{
+			Location:    "",
+			ShellImage:  s.images.ShellImage,
+			GsutilImage: s.images.GsutilImage,
+			AwsCliImage: s.images.AwsCliImage,
+		},
+		FeatureFlags: defaults.FeatureFlags,
+		Defaults:     defaults.Defaults,
 	}
-
-	return &Config{
-		ArtifactBucket: ep.(*v1alpha1.ArtifactBucket).DeepCopy(),
+	if ep, ok := s.UntypedLoad(artifacts.GetBucketConfigName()).(*v1alpha1.ArtifactBucket); ok {
+		cfg.ArtifactBucket = ep.DeepCopy()
 	}
-
+	if featureFlags, ok := s.UntypedLoad(apisconfig.FeatureFlagsConfigName).(*apisconfig.FeatureFlags); ok {
+		cfg.FeatureFlags = featureFlags.DeepCopy()
+	}
+	if d, ok := s.UntypedLoad(apisconfig.DefaultsConfigName).(*apisconfig.Defaults); ok {
+		cfg.Defaults = d.DeepCopy()
+	}
+	return cfg
 }
@@ -21,7 +21,9 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	apisconfig "github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/artifacts"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 
@@ -31,7 +33,11 @@ import (
 func TestStoreLoadWithContext(t *testing.T) {
 	store := NewStore(pipeline.Images{}, ttesting.TestLogger(t))
 	bucketConfig := test.ConfigMapFromTestFile(t, "config-artifact-bucket")
+	featureFlags := test.ConfigMapFromTestFile(t, apisconfig.FeatureFlagsConfigName)
 	store.OnConfigChanged(bucketConfig)
+	store.OnConfigChanged(featureFlags)
+	defaults := test.ConfigMapFromTestFile(t, apisconfig.DefaultsConfigName)
+	store.OnConfigChanged(defaults)
 
 	config := FromContext(store.ToContext(context.Background()))
 
@@ -39,6 +45,29 @@ func TestStoreLoadWithContext(t *testing.T) {
 	if diff := cmp.Diff(expected, config.ArtifactBucket); diff != "" {
 		t.Errorf("Unexpected controller config (-want, +got): %v", diff)
 	}
+	expectedFeatureFlags, _ := apisconfig.NewFeatureFlagsFromConfigMap(featureFlags)
+	if diff := cmp.Diff(expectedFeatureFlags, config.FeatureFlags); diff != "" {
+		t.Errorf("Unexpected feature flags (-want, +got): %v", diff)
+	}
+	expectedDefaults, _ := apisconfig.NewDefaultsFromConfigMap(defaults)
+	if diff := cmp.Diff(expectedDefaults, config.Defaults); diff != "" {
+		t.Errorf("Unexpected defaults (-want, +got): %v", diff)
+	}
+}
+
+func TestStoreLoadDefaults(t *testing.T) {
+	store := NewStore(pipeline.Images{ShellImage: "busybox", GsutilImage: "google/cloud-sdk"}, ttesting.TestLogger(t))
+
+	config := store.Load()
+
+	expected := &Config{
+		ArtifactBucket: &v1alpha1.ArtifactBucket{ShellImage: "busybox", GsutilImage: "google/cloud-sdk"},
+		FeatureFlags:   &apisconfig.FeatureFlags{},
+		Defaults:       apisconfig.FromContextOrDefaults(context.Background()).Defaults,
+	}
+	if diff := cmp.Diff(expected, config); diff != "" {
+		t.Errorf("Unexpected controller config (-want, +got): %v", diff)
+	}
 }
 func TestStoreImmutableConfig(t *testing.T) {
 	store := NewStore(pipeline.Images{}, ttesting.TestLogger(t))
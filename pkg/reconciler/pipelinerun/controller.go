@@ -18,21 +18,32 @@ package pipelinerun
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
 	clustertaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/clustertask"
 	conditioninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/condition"
 	pipelineinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/pipeline"
 	resourceinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/pipelineresource"
 	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/pipelinerun"
+	resolutionrequestinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/resolutionrequest"
+	runinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run"
 	taskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/task"
 	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/taskrun"
+	"github.com/tektoncd/pipeline/pkg/health"
 	"github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/config"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/scheduler"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/resolution"
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -43,18 +54,23 @@ const (
 	resyncPeriod = 10 * time.Hour
 )
 
-func NewController(images pipeline.Images) func(context.Context, configmap.Watcher) *controller.Impl {
+func NewController(images pipeline.Images, schedulingPolicy SchedulingPolicy) func(context.Context, configmap.Watcher) *controller.Impl {
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 		logger := logging.FromContext(ctx)
 		kubeclientset := kubeclient.Get(ctx)
 		pipelineclientset := pipelineclient.Get(ctx)
 		taskRunInformer := taskruninformer.Get(ctx)
+		runInformer := runinformer.Get(ctx)
 		taskInformer := taskinformer.Get(ctx)
 		clusterTaskInformer := clustertaskinformer.Get(ctx)
 		pipelineRunInformer := pipelineruninformer.Get(ctx)
 		pipelineInformer := pipelineinformer.Get(ctx)
 		resourceInformer := resourceinformer.Get(ctx)
 		conditionInformer := conditioninformer.Get(ctx)
+		resolutionRequestInformer := resolutionrequestinformer.Get(ctx)
+		podInformer := podinformer.Get(ctx)
+		namespaceInformer := namespaceinformer.Get(ctx)
+		configMapInformer := configmapinformer.Get(ctx)
 		timeoutHandler := reconciler.NewTimeoutHandler(ctx.Done(), logger)
 		metrics, err := NewRecorder()
 		if err != nil {
@@ -76,12 +92,39 @@ func NewController(images pipeline.Images) func(context.Context, configmap.Watch
 			taskLister:        taskInformer.Lister(),
 			clusterTaskLister: clusterTaskInformer.Lister(),
 			taskRunLister:     taskRunInformer.Lister(),
+			runLister:         runInformer.Lister(),
 			resourceLister:    resourceInformer.Lister(),
 			conditionLister:   conditionInformer.Lister(),
+			configMapLister:   configMapInformer.Lister(),
+			namespaceLister:   namespaceInformer.Lister(),
+			cloudEventClient:  cloudevent.Get(ctx),
+			httpClient:        &http.Client{Timeout: paramsHookTimeout},
 			timeoutHandler:    timeoutHandler,
 			metrics:           metrics,
+			requester: resolution.Requester{
+				Client: pipelineclientset,
+				Lister: resolutionRequestInformer.Lister(),
+			},
 		}
 		impl := controller.NewImpl(c, c.Logger, pipeline.PipelineRunControllerName)
+		c.enqueueAfter = impl.EnqueueKeyAfter
+		if schedulingPolicy.MaxRunningTaskRuns > 0 {
+			c.scheduler = scheduler.New(schedulingPolicy.MaxRunningTaskRuns, schedulingPolicy.NamespaceWeights, runningTaskRuns(c.taskRunLister), impl.EnqueueKey)
+		}
+		health.DefaultChecks.Add(pipelineRunAgentName+" informers", health.InformersSynced(
+			pipelineRunInformer.Informer().HasSynced,
+			pipelineInformer.Informer().HasSynced,
+			taskRunInformer.Informer().HasSynced,
+			runInformer.Informer().HasSynced,
+			taskInformer.Informer().HasSynced,
+			clusterTaskInformer.Informer().HasSynced,
+			resourceInformer.Informer().HasSynced,
+			conditionInformer.Informer().HasSynced,
+			resolutionRequestInformer.Informer().HasSynced,
+			podInformer.Informer().HasSynced,
+			namespaceInformer.Informer().HasSynced,
+			configMapInformer.Informer().HasSynced,
+		))
 
 		timeoutHandler.SetPipelineRunCallbackFunc(impl.Enqueue)
 		timeoutHandler.CheckTimeouts(kubeclientset, pipelineclientset)
@@ -97,6 +140,23 @@ func NewController(images pipeline.Images) func(context.Context, configmap.Watch
 		taskRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 			UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
 		})
+		// The controllers of the custom tasks update the status of their
+		// Runs.
+		runInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
+		})
+
+		// Reconcile deleted PipelineRuns again when the Pod cleaning up their
+		// artifacts completes.
+		podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("PipelineRun")),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		})
+
+		resolutionRequestInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("PipelineRun")),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		})
 
 		c.Logger.Info("Setting up ConfigMap receivers")
 		c.configStore = config.NewStore(images, c.Logger.Named("config-store"))
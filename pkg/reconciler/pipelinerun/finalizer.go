@@ -0,0 +1,126 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// eventReasonArtifactsCleanupFailed is the reason of the event recorded when
+// the artifacts of a deleted PipelineRun couldn't be deleted from the bucket.
+const eventReasonArtifactsCleanupFailed = "ArtifactsCleanupFailed"
+
+// addFinalizer adds the cleanup finalizer to pr when the
+// enable-cleanup-finalizer feature flag is set. It is saved with the labels
+// and annotations of pr.
+func addFinalizer(ctx context.Context, pr *v1alpha1.PipelineRun) {
+	if pr.DeletionTimestamp == nil && config.FromContext(ctx).FeatureFlags.EnableCleanupFinalizer {
+		reconciler.AddFinalizer(pr, reconciler.CleanupFinalizer)
+	}
+}
+
+// finalize deletes the artifacts pr stored, in its PVC or in the artifact
+// bucket, and then removes the cleanup finalizer so that pr can be deleted.
+func (c *Reconciler) finalize(pr *v1alpha1.PipelineRun) error {
+	c.timeoutHandler.Release(pr)
+	if err := artifacts.CleanupArtifactStorage(pr, c.KubeClientSet, c.Logger); err != nil {
+		c.Logger.Errorf("Failed to delete PVC for PipelineRun %s: %v", pr.Name, err)
+		return err
+	}
+	storage, err := artifacts.GetArtifactStorage(c.Images, pr.Name, c.KubeClientSet, c.Logger)
+	if err != nil {
+		return err
+	}
+	// A PipelineRun that didn't run any Task didn't store any artifact.
+	if bucket, ok := storage.(*v1alpha1.ArtifactBucket); ok && len(pr.Status.TaskRuns) > 0 {
+		done, err := c.cleanupArtifactBucket(pr, bucket)
+		if err != nil || !done {
+			return err
+		}
+	}
+
+	reconciler.RemoveFinalizer(pr, reconciler.CleanupFinalizer)
+	if _, err := c.PipelineClientSet.TektonV1alpha1().PipelineRuns(pr.Namespace).Update(pr); err != nil {
+		return fmt.Errorf("error removing the cleanup finalizer of PipelineRun %s: %w", pr.Name, err)
+	}
+	return nil
+}
+
+// cleanupArtifactBucket runs a Pod deleting the artifacts pr stored in the
+// bucket, and returns true once it completed. The PipelineRun is reconciled
+// again when the Pod completes.
+func (c *Reconciler) cleanupArtifactBucket(pr *v1alpha1.PipelineRun, bucket *v1alpha1.ArtifactBucket) (bool, error) {
+	name := artifactsCleanupPodName(pr)
+	pod, err := c.KubeClientSet.CoreV1().Pods(pr.Namespace).Get(name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if _, err := c.KubeClientSet.CoreV1().Pods(pr.Namespace).Create(makeArtifactsCleanupPod(pr, bucket)); err != nil {
+			return false, fmt.Errorf("error creating the Pod deleting the artifacts of PipelineRun %s: %w", pr.Name, err)
+		}
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return true, nil
+	case corev1.PodFailed:
+		// Don't block the deletion of the PipelineRun on artifacts that can
+		// be deleted from the bucket by hand.
+		c.Recorder.Eventf(pr, corev1.EventTypeWarning, eventReasonArtifactsCleanupFailed,
+			"Failed to delete the artifacts of PipelineRun %s from %s, see Pod %s", pr.Name, bucket.Location, name)
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func artifactsCleanupPodName(pr *v1alpha1.PipelineRun) string {
+	return pr.Name + "-artifacts-cleanup"
+}
+
+// makeArtifactsCleanupPod returns the Pod deleting the artifacts pr stored
+// in the bucket. It is owned by pr, so that it is deleted with it.
+func makeArtifactsCleanupPod(pr *v1alpha1.PipelineRun, bucket *v1alpha1.ArtifactBucket) *corev1.Pod {
+	step := bucket.GetDeleteFromStorageStep(bucket.StorageBasePath(pr))
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            artifactsCleanupPodName(pr),
+			Namespace:       pr.Namespace,
+			OwnerReferences: pr.GetOwnerReference(),
+			Labels: map[string]string{
+				pipeline.GroupName + pipeline.PipelineRunLabelKey: pr.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: pr.GetDefaultServiceAccountName(),
+			RestartPolicy:      corev1.RestartPolicyNever,
+			Containers:         []corev1.Container{step.Container},
+			Volumes:            bucket.GetSecretsVolumes(),
+		},
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	"github.com/tektoncd/pipeline/pkg/system"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func deletedPipelineRun(ops ...tb.PipelineRunOp) *v1alpha1.PipelineRun {
+	pr := tb.PipelineRun("test-pipeline-run", "foo", append([]tb.PipelineRunOp{tb.PipelineRunSpec("test-pipeline")}, ops...)...)
+	now := metav1.Now()
+	pr.DeletionTimestamp = &now
+	pr.Finalizers = []string{reconciler.CleanupFinalizer}
+	return pr
+}
+
+func TestReconcile_AddFinalizer(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		flag       string
+		finalizers []string
+	}{{
+		name: "disabled",
+		flag: "false",
+	}, {
+		name:       "enabled",
+		flag:       "true",
+		finalizers: []string{reconciler.CleanupFinalizer},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := reconcilertest.Data{
+				PipelineRuns: []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo", tb.PipelineRunSpec("test-pipeline"))},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: artifacts.GetBucketConfigName(), Namespace: system.GetNamespace()},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+					Data:       map[string]string{"enable-cleanup-finalizer": tc.flag},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+				}},
+			}
+			testAssets, cancel := getPipelineRunController(t, d)
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+
+			pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			if d := cmp.Diff(tc.finalizers, pr.Finalizers); d != "" {
+				t.Errorf("Finalizers diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcile_FinalizePVC(t *testing.T) {
+	pr := deletedPipelineRun()
+	testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{PipelineRuns: []*v1alpha1.PipelineRun{pr}})
+	defer cancel()
+	c, clients := testAssets.Controller, testAssets.Clients
+	if _, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").Create(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: artifacts.GetPVCName(pr), Namespace: "foo"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+		t.Fatalf("Reconcile() = %v", err)
+	}
+
+	if _, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").Get(artifacts.GetPVCName(pr), metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the PVC to be deleted, got %v", err)
+	}
+	reconciled, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting PipelineRun: %v", err)
+	}
+	if len(reconciled.Finalizers) != 0 {
+		t.Errorf("Expected the cleanup finalizer to be removed, got %v", reconciled.Finalizers)
+	}
+}
+
+func TestReconcile_FinalizeArtifactBucket(t *testing.T) {
+	bucketConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: artifacts.GetBucketConfigName(), Namespace: system.GetNamespace()},
+		Data:       map[string]string{artifacts.BucketLocationKey: "gs://fake-bucket"},
+	}
+	cleanupPod := func(phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-artifacts-cleanup", Namespace: "foo"},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	ran := tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus("test-pipeline-run-hello", &v1alpha1.PipelineRunTaskRunStatus{PipelineTaskName: "hello"}))
+
+	for _, tc := range []struct {
+		name          string
+		pr            *v1alpha1.PipelineRun
+		pods          []*corev1.Pod
+		wantPod       bool
+		wantFinalizer bool
+	}{{
+		name:          "no cleanup pod",
+		pr:            deletedPipelineRun(ran),
+		wantPod:       true,
+		wantFinalizer: true,
+	}, {
+		name:          "cleanup pod running",
+		pr:            deletedPipelineRun(ran),
+		pods:          []*corev1.Pod{cleanupPod(corev1.PodRunning)},
+		wantPod:       true,
+		wantFinalizer: true,
+	}, {
+		name:    "cleanup pod succeeded",
+		pr:      deletedPipelineRun(ran),
+		pods:    []*corev1.Pod{cleanupPod(corev1.PodSucceeded)},
+		wantPod: true,
+	}, {
+		name:    "cleanup pod failed",
+		pr:      deletedPipelineRun(ran),
+		pods:    []*corev1.Pod{cleanupPod(corev1.PodFailed)},
+		wantPod: true,
+	}, {
+		name: "no task ran",
+		pr:   deletedPipelineRun(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := reconcilertest.Data{
+				PipelineRuns: []*v1alpha1.PipelineRun{tc.pr},
+				Pods:         tc.pods,
+				ConfigMaps: []*corev1.ConfigMap{bucketConfig, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+				}},
+			}
+			testAssets, cancel := getPipelineRunController(t, d)
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+
+			_, err := clients.Kube.CoreV1().Pods("foo").Get("test-pipeline-run-artifacts-cleanup", metav1.GetOptions{})
+			if tc.wantPod && err != nil {
+				t.Errorf("Expected a cleanup pod, got %v", err)
+			} else if !tc.wantPod && !errors.IsNotFound(err) {
+				t.Errorf("Expected no cleanup pod, got %v", err)
+			}
+			pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			if got := reconciler.HasFinalizer(pr, reconciler.CleanupFinalizer); got != tc.wantFinalizer {
+				t.Errorf("HasFinalizer() = %t, want %t", got, tc.wantFinalizer)
+			}
+		})
+	}
+}
+
+func TestMakeArtifactsCleanupPod(t *testing.T) {
+	pr := tb.PipelineRun("test-pipeline-run", "foo", tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccountName("test-sa")))
+	bucket := &v1alpha1.ArtifactBucket{
+		Location:    "gs://fake-bucket",
+		GsutilImage: "google/cloud-sdk",
+	}
+
+	want := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-pipeline-run-artifacts-cleanup",
+			Namespace:       "foo",
+			OwnerReferences: pr.GetOwnerReference(),
+			Labels:          map[string]string{"tekton.dev/pipelineRun": "test-pipeline-run"},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "test-sa",
+			RestartPolicy:      corev1.RestartPolicyNever,
+			Containers:         []corev1.Container{bucket.GetDeleteFromStorageStep("test-pipeline-run-foo-bucket").Container},
+			Volumes:            []corev1.Volume{},
+		},
+	}
+	if d := cmp.Diff(want, makeArtifactsCleanupPod(pr, bucket)); d != "" {
+		t.Errorf("makeArtifactsCleanupPod() diff -want, +got: %s", d)
+	}
+}
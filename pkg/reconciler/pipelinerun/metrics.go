@@ -46,6 +46,18 @@ var (
 	runningPRsCount = stats.Float64("running_pipelineruns_count",
 		"Number of pipelineruns executing currently",
 		stats.UnitDimensionless)
+
+	prCPUSeconds = stats.Float64("pipelinerun_requested_cpu_seconds",
+		"The CPU cores requested by the taskruns of the pipelinerun times the seconds they ran for",
+		stats.UnitDimensionless)
+
+	prMemorySeconds = stats.Float64("pipelinerun_requested_memory_byte_seconds",
+		"The memory bytes requested by the taskruns of the pipelinerun times the seconds they ran for",
+		stats.UnitDimensionless)
+
+	prExceededExpectedDurationCount = stats.Float64("pipelinerun_exceeded_expected_duration_count",
+		"Number of pipelineruns which ran for longer than the expected duration of their pipeline",
+		stats.UnitDimensionless)
 )
 
 type Recorder struct {
@@ -106,6 +118,26 @@ func NewRecorder() (*Recorder, error) {
 			Measure:     runningPRsCount,
 			Aggregation: view.LastValue(),
 		},
+		// The last value is recorded for each PipelineRun, so that recording
+		// a completed PipelineRun again doesn't count it twice.
+		&view.View{
+			Description: prCPUSeconds.Description(),
+			Measure:     prCPUSeconds,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{r.pipeline, r.pipelineRun, r.namespace},
+		},
+		&view.View{
+			Description: prMemorySeconds.Description(),
+			Measure:     prMemorySeconds,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{r.pipeline, r.pipelineRun, r.namespace},
+		},
+		&view.View{
+			Description: prExceededExpectedDurationCount.Description(),
+			Measure:     prExceededExpectedDurationCount,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{r.pipeline, r.namespace},
+		},
 	)
 
 	if err != nil {
@@ -152,7 +184,36 @@ func (r *Recorder) DurationAndCount(pr *v1alpha1.PipelineRun) error {
 
 	metrics.Record(ctx, prDuration.M(float64(duration/time.Second)))
 	metrics.Record(ctx, prCount.M(1))
+	if requested := pr.Status.RequestedResources; requested != nil {
+		metrics.Record(ctx, prCPUSeconds.M(float64(requested.CPUMilliSeconds)/1000))
+		metrics.Record(ctx, prMemorySeconds.M(float64(requested.MemoryByteSeconds)))
+	}
+
+	return nil
+}
+
+// ExceededExpectedDuration counts a PipelineRun which ran for longer than the
+// expected duration of its Pipeline
+// returns an error if its failed to log the metrics
+func (r *Recorder) ExceededExpectedDuration(pr *v1alpha1.PipelineRun) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", pr.Name)
+	}
+
+	pipelineName := "anonymous"
+	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Name != "" {
+		pipelineName = pr.Spec.PipelineRef.Name
+	}
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(r.pipeline, pipelineName),
+		tag.Insert(r.namespace, pr.Namespace),
+	)
+	if err != nil {
+		return err
+	}
 
+	metrics.Record(ctx, prExceededExpectedDurationCount.M(1))
 	return nil
 }
 
@@ -35,9 +35,11 @@ func TestUninitializedMetrics(t *testing.T) {
 
 	durationCountError := metrics.DurationAndCount(&v1alpha1.PipelineRun{})
 	prCountError := metrics.RunningPipelineRuns(nil)
+	exceededError := metrics.ExceededExpectedDuration(&v1alpha1.PipelineRun{})
 
 	assertErrNotNil(durationCountError, "DurationAndCount recording expected to return error but got nil", t)
 	assertErrNotNil(prCountError, "Current PR count recording expected to return error but got nil", t)
+	assertErrNotNil(exceededError, "ExceededExpectedDuration recording expected to return error but got nil", t)
 }
 
 func TestRecordPipelineRunDurationCount(t *testing.T) {
@@ -107,6 +109,54 @@ func TestRecordPipelineRunDurationCount(t *testing.T) {
 	}
 }
 
+func TestRecordPipelineRunRequestedResources(t *testing.T) {
+	unregisterMetrics()
+	startTime := time.Now()
+	pr := tb.PipelineRun("pipelinerun-1", "ns",
+		tb.PipelineRunSpec("pipeline-1"),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(startTime),
+			tb.PipelineRunCompletionTime(startTime.Add(1*time.Minute)),
+			tb.PipelineRunStatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}),
+		))
+	pr.Status.RequestedResources = &v1alpha1.ResourceSeconds{CPUMilliSeconds: 90000, MemoryByteSeconds: 1 << 30}
+
+	metrics, err := NewRecorder()
+	assertErrIsNil(err, "Recorder initialization failed", t)
+
+	// Recording a PipelineRun twice doesn't count it twice.
+	for i := 0; i < 2; i++ {
+		err = metrics.DurationAndCount(pr)
+		assertErrIsNil(err, "DurationAndCount recording recording got an error", t)
+	}
+	tags := map[string]string{
+		"pipeline":    "pipeline-1",
+		"pipelinerun": "pipelinerun-1",
+		"namespace":   "ns",
+	}
+	metricstest.CheckLastValueData(t, "pipelinerun_requested_cpu_seconds", tags, 90)
+	metricstest.CheckLastValueData(t, "pipelinerun_requested_memory_byte_seconds", tags, 1<<30)
+}
+
+func TestRecordPipelineRunExceededExpectedDuration(t *testing.T) {
+	unregisterMetrics()
+	pr := tb.PipelineRun("pipelinerun-1", "ns", tb.PipelineRunSpec("pipeline-1"))
+
+	metrics, err := NewRecorder()
+	assertErrIsNil(err, "Recorder initialization failed", t)
+
+	err = metrics.ExceededExpectedDuration(pr)
+	assertErrIsNil(err, "ExceededExpectedDuration recording got an error", t)
+	tags := map[string]string{
+		"pipeline":  "pipeline-1",
+		"namespace": "ns",
+	}
+	metricstest.CheckCountData(t, "pipelinerun_exceeded_expected_duration_count", tags, 1)
+}
+
 func TestRecordRunningPipelineRunsCount(t *testing.T) {
 	unregisterMetrics()
 
@@ -157,6 +207,7 @@ func assertErrIsNil(err error, message string, t *testing.T) {
 }
 
 func unregisterMetrics() {
-	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count")
+	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count",
+		"pipelinerun_requested_cpu_seconds", "pipelinerun_requested_memory_byte_seconds")
 
 }
@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+// paramsHookTimeout is how long the params hook of a Pipeline has to respond.
+const paramsHookTimeout = 30 * time.Second
+
+// paramsHookRequest is what is sent to the params hook: the PipelineRun which
+// starts, and the values of the params of the Pipeline it runs with.
+type paramsHookRequest struct {
+	PipelineRun *v1alpha1.PipelineRun `json:"pipelineRun"`
+	Params      []v1alpha1.Param      `json:"params"`
+}
+
+// paramsHookResponse is the response of the params hook.
+type paramsHookResponse struct {
+	// Params are the values of the params the hook computed.
+	Params []v1alpha1.Param `json:"params"`
+}
+
+// paramsHookUnavailableError is returned when the params hook couldn't compute
+// the params of a PipelineRun.
+type paramsHookUnavailableError struct {
+	err error
+}
+
+func (e *paramsHookUnavailableError) Error() string {
+	return fmt.Sprintf("the params hook couldn't compute the params: %v", e.err)
+}
+
+func (e *paramsHookUnavailableError) Unwrap() error {
+	return e.err
+}
+
+func isParamsHookUnavailable(err error) bool {
+	var unavailable *paramsHookUnavailableError
+	return errors.As(err, &unavailable)
+}
+
+// computeParams sends the values of the params of pr to the params hook of ps
+// and returns the params it computed. The values the PipelineRun sets take
+// precedence over those of the hook.
+func (c *Reconciler) computeParams(ctx context.Context, pr *v1alpha1.PipelineRun, ps *v1alpha1.PipelineSpec) ([]v1alpha1.Param, error) {
+	values := map[string]v1alpha1.ArrayOrString{}
+	for _, p := range ps.Params {
+		if p.Default != nil {
+			values[p.Name] = *p.Default
+		}
+	}
+	set := map[string]bool{}
+	for _, p := range pr.Spec.Params {
+		values[p.Name] = p.Value
+		set[p.Name] = true
+	}
+	declared := map[string]bool{}
+	request := paramsHookRequest{PipelineRun: pr, Params: []v1alpha1.Param{}}
+	for _, p := range ps.Params {
+		declared[p.Name] = true
+		if value, ok := values[p.Name]; ok {
+			request.Params = append(request.Params, v1alpha1.Param{Name: p.Name, Value: value})
+		}
+	}
+
+	response, err := c.sendParamsHookRequest(ctx, ps.ParamsHook.URL, request)
+	if err != nil {
+		return nil, &paramsHookUnavailableError{err: err}
+	}
+	var params []v1alpha1.Param
+	for _, p := range response.Params {
+		if !declared[p.Name] {
+			return nil, fmt.Errorf("the params hook computed the param %q, which the Pipeline doesn't declare", p.Name)
+		}
+		if !set[p.Name] {
+			params = append(params, p)
+		}
+	}
+	return params, nil
+}
+
+func (c *Reconciler) sendParamsHookRequest(ctx context.Context, url string, request paramsHookRequest) (*paramsHookResponse, error) {
+	b, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("the params hook responded with status %s", resp.Status)
+	}
+	var response paramsHookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("error decoding the response of the params hook: %w", err)
+	}
+	return &response, nil
+}
+
+// withComputedParams returns pr, with the params the params hook computed
+// added to its params.
+func withComputedParams(pr *v1alpha1.PipelineRun) *v1alpha1.PipelineRun {
+	if pr.Status.ParamsHook == nil || len(pr.Status.ParamsHook.Params) == 0 {
+		return pr
+	}
+	pr = pr.DeepCopy()
+	pr.Spec.Params = append(pr.Spec.Params, pr.Status.ParamsHook.Params...)
+	return pr
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// fakeParamsHook records the requests sent to it, and responds with response,
+// or with err if it is set.
+type fakeParamsHook struct {
+	requests []paramsHookRequest
+	response paramsHookResponse
+	err      error
+}
+
+func (f *fakeParamsHook) Do(req *http.Request) (*http.Response, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var request paramsHookRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	f.requests = append(f.requests, request)
+	b, err := json.Marshal(f.response)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     http.StatusText(http.StatusOK),
+		Body:       ioutil.NopCloser(strings.NewReader(string(b))),
+	}, nil
+}
+
+func TestReconcile_ParamsHook(t *testing.T) {
+	p := tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineParamSpec("revision", v1alpha1.ParamTypeString, tb.ParamSpecDefault("main")),
+		tb.PipelineParamSpec("version", v1alpha1.ParamTypeString, tb.ParamSpecDefault("")),
+		tb.PipelineTask("hello-world-1", "hello-world",
+			tb.PipelineTaskParam("version", "$(params.version)"),
+		),
+		tb.PipelineParamsHook("https://hooks.example.com/version"),
+	))
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParamSpec("version", v1alpha1.ParamTypeString)),
+	))}
+	computed := []v1alpha1.Param{{Name: "version", Value: *tb.ArrayOrString("1.2.3")}}
+
+	for _, tc := range []struct {
+		name        string
+		runParams   []v1alpha1.Param
+		status      *v1alpha1.PipelineRunParamsHookStatus
+		response    paramsHookResponse
+		err         error
+		wantErr     bool
+		wantParams  []v1alpha1.Param
+		wantReason  string
+		wantVersion string
+	}{{
+		name:        "computed",
+		response:    paramsHookResponse{Params: computed},
+		wantParams:  computed,
+		wantVersion: "1.2.3",
+	}, {
+		name:        "set by the PipelineRun",
+		runParams:   []v1alpha1.Param{{Name: "version", Value: *tb.ArrayOrString("2.0.0")}},
+		response:    paramsHookResponse{Params: computed},
+		wantVersion: "2.0.0",
+	}, {
+		// The params hook is only called when the PipelineRun starts.
+		name:        "already computed",
+		status:      &v1alpha1.PipelineRunParamsHookStatus{Params: computed},
+		response:    paramsHookResponse{Params: []v1alpha1.Param{{Name: "version", Value: *tb.ArrayOrString("9.9.9")}}},
+		wantParams:  computed,
+		wantVersion: "1.2.3",
+	}, {
+		name:       "undeclared param",
+		response:   paramsHookResponse{Params: []v1alpha1.Param{{Name: "tag", Value: *tb.ArrayOrString("latest")}}},
+		wantReason: ReasonCouldntComputeParams,
+	}, {
+		name:    "unavailable",
+		err:     errors.New("connection refused"),
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := tb.PipelineRun("test-pipeline-run", "foo", tb.PipelineRunSpec("test-pipeline"))
+			pr.Spec.Params = tc.runParams
+			pr.Status.ParamsHook = tc.status
+			testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+				PipelineRuns: []*v1alpha1.PipelineRun{pr},
+				Pipelines:    []*v1alpha1.Pipeline{p},
+				Tasks:        ts,
+			})
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+			r := c.Reconciler.(*Reconciler)
+			hook := &fakeParamsHook{response: tc.response, err: tc.err}
+			r.httpClient = hook
+
+			err := r.Reconcile(context.Background(), "foo/test-pipeline-run")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Reconcile() = %v, wanted error: %t", err, tc.wantErr)
+			}
+			if tc.status != nil {
+				if len(hook.requests) != 0 {
+					t.Errorf("Expected the params hook not to be called again, got %d requests", len(hook.requests))
+				}
+			} else if tc.err == nil {
+				if len(hook.requests) != 1 {
+					t.Fatalf("Expected the params hook to be called once, got %d requests", len(hook.requests))
+				}
+				wantRequestParams := append([]v1alpha1.Param{{Name: "revision", Value: *tb.ArrayOrString("main")}}, tc.runParams...)
+				if len(tc.runParams) == 0 {
+					wantRequestParams = append(wantRequestParams, v1alpha1.Param{Name: "version", Value: *tb.ArrayOrString("")})
+				}
+				if d := cmp.Diff(wantRequestParams, hook.requests[0].Params); d != "" {
+					t.Errorf("params hook request params -want, +got: %s", d)
+				}
+			}
+
+			reconciled, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get(pr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			trs, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Error listing TaskRuns: %v", err)
+			}
+			if tc.wantErr || tc.wantReason != "" {
+				if tc.wantReason != "" {
+					condition := reconciled.Status.GetCondition(apis.ConditionSucceeded)
+					if !condition.IsFalse() || condition.Reason != tc.wantReason {
+						t.Errorf("Expected PipelineRun to fail with reason %s, but condition is %v", tc.wantReason, condition)
+					}
+				}
+				if len(trs.Items) != 0 {
+					t.Errorf("Expected no TaskRun to be created, got %d", len(trs.Items))
+				}
+				return
+			}
+			if reconciled.Status.ParamsHook == nil {
+				t.Fatal("Expected the params the hook computed in the status")
+			}
+			if d := cmp.Diff(tc.wantParams, reconciled.Status.ParamsHook.Params); d != "" {
+				t.Errorf("computed params -want, +got: %s", d)
+			}
+			if len(trs.Items) != 1 {
+				t.Fatalf("Expected 1 TaskRun to be created, got %d", len(trs.Items))
+			}
+			want := []v1alpha1.Param{{Name: "version", Value: *tb.ArrayOrString(tc.wantVersion)}}
+			if d := cmp.Diff(want, trs.Items[0].Spec.Inputs.Params); d != "" {
+				t.Errorf("TaskRun params -want, +got: %s", d)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package pipelinerun
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -30,19 +31,27 @@ import (
 	"github.com/tektoncd/pipeline/pkg/artifacts"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/contexts"
+	tklogging "github.com/tektoncd/pipeline/pkg/logging"
 	"github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	prconfig "github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/config"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/scheduler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/resolution"
+	"github.com/tektoncd/pipeline/pkg/system"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
 	"knative.dev/pkg/tracker"
 )
 
@@ -50,13 +59,25 @@ const (
 	// ReasonCouldntGetPipeline indicates that the reason for the failure status is that the
 	// associated Pipeline couldn't be retrieved
 	ReasonCouldntGetPipeline = "CouldntGetPipeline"
+	// ReasonResolvingPipelineRef indicates that the PipelineRun is waiting for the
+	// resolver of its pipelineRef to fetch the Pipeline
+	ReasonResolvingPipelineRef = "ResolvingPipelineRef"
+	// ReasonResolvingTaskRef indicates that the PipelineRun is waiting for the
+	// resolvers of the taskRefs of its Pipeline to fetch the Tasks
+	ReasonResolvingTaskRef = "ResolvingTaskRef"
 	// ReasonInvalidBindings indicates that the reason for the failure status is that the
 	// PipelineResources bound in the PipelineRun didn't match those declared in the Pipeline
 	ReasonInvalidBindings = "InvalidPipelineResourceBindings"
+	// ReasonInvalidWorkspaceBindings indicates that the reason for the failure status is that the
+	// workspaces bound in the PipelineRun didn't match those declared in the Pipeline
+	ReasonInvalidWorkspaceBindings = "InvalidWorkspaceBindings"
 	// ReasonParameterTypeMismatch indicates that the reason for the failure status is that
 	// parameter(s) declared in the PipelineRun do not have the some declared type as the
 	// parameters(s) declared in the Pipeline that they are supposed to override.
 	ReasonParameterTypeMismatch = "ParameterTypeMismatch"
+	// ReasonParameterValueNotAllowed indicates that the reason for the failure status is that the
+	// value of a parameter of the PipelineRun isn't one of the enum values of the Pipeline's parameter
+	ReasonParameterValueNotAllowed = "ParameterValueNotAllowed"
 	// ReasonCouldntGetTask indicates that the reason for the failure status is that the
 	// associated Pipeline's Tasks couldn't all be retrieved
 	ReasonCouldntGetTask = "CouldntGetTask"
@@ -72,12 +93,35 @@ const (
 	// ReasonInvalidGraph indicates that the reason for the failure status is that the
 	// associated Pipeline is an invalid graph (a.k.a wrong order, cycle, …)
 	ReasonInvalidGraph = "PipelineInvalidGraph"
+	// ReasonCouldntGetConfigValue indicates that the reason for the failure status is that
+	// the associated Pipeline references config values that couldn't all be retrieved
+	ReasonCouldntGetConfigValue = "CouldntGetConfigValue"
+	// ReasonCouldntComputeParams indicates that the reason for the failure status is that
+	// the params hook of the associated Pipeline computed invalid params
+	ReasonCouldntComputeParams = "CouldntComputeParams"
+	// ReasonCouldntGetRunNumber indicates that the reason for the failure status is that
+	// the associated Pipeline uses the number of the PipelineRun, which couldn't be assigned
+	ReasonCouldntGetRunNumber = "CouldntGetRunNumber"
+	// ReasonInvalidTaskResultReference indicates that the reason for the failure status is that
+	// a PipelineTask uses a result that the TaskRun of another PipelineTask didn't report
+	ReasonInvalidTaskResultReference = "InvalidTaskResultReference"
+	// ReasonArtifactStorageLost indicates that the reason for the failure status is that the
+	// PVC holding the artifacts of the Tasks that already ran was deleted
+	ReasonArtifactStorageLost = "ArtifactStorageLost"
+	// ReasonCancelling indicates that the PipelineRun was cancelled or timed
+	// out, and waits for the controllers of its custom tasks to stop its Runs
+	ReasonCancelling = "PipelineRunCancelling"
 	// pipelineRunAgentName defines logging agent name for PipelineRun Controller
 	pipelineRunAgentName = "pipeline-controller"
 
 	// Event reasons
-	eventReasonFailed    = "PipelineRunFailed"
-	eventReasonSucceeded = "PipelineRunSucceeded"
+	eventReasonFailed          = "PipelineRunFailed"
+	eventReasonSucceeded       = "PipelineRunSucceeded"
+	eventReasonUnreachableTask = "UnreachableTask"
+	// eventReasonExceededExpectedDuration is the reason of the Warning event
+	// of a PipelineRun running for longer than the expected duration of its
+	// Pipeline
+	eventReasonExceededExpectedDuration = "ExceededExpectedDuration"
 )
 
 type configStore interface {
@@ -92,14 +136,26 @@ type Reconciler struct {
 	pipelineRunLister listers.PipelineRunLister
 	pipelineLister    listers.PipelineLister
 	taskRunLister     listers.TaskRunLister
+	runLister         listers.RunLister
 	taskLister        listers.TaskLister
 	clusterTaskLister listers.ClusterTaskLister
 	resourceLister    listers.PipelineResourceLister
 	conditionLister   listers.ConditionLister
+	configMapLister   corelisters.ConfigMapLister
+	namespaceLister   corelisters.NamespaceLister
+	requester         resolution.Requester
+	cloudEventClient  cloudevent.CEClient
+	httpClient        reconciler.HTTPDoer
 	tracker           tracker.Interface
 	configStore       configStore
 	timeoutHandler    *reconciler.TimeoutSet
 	metrics           *Recorder
+	// scheduler holds back the TaskRuns over the SchedulingPolicy, nil
+	// without a limit.
+	scheduler *scheduler.Scheduler
+	// enqueueAfter reconciles a PipelineRun again once it stopped waiting
+	// for its Runs.
+	enqueueAfter func(key string, delay time.Duration)
 }
 
 var (
@@ -110,7 +166,7 @@ var (
 // Reconcile compares the actual state with the desired, and attempts to
 // converge the two. It then updates the Status block of the Pipeline Run
 // resource with the current status of the resource.
-func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+func (c *Reconciler) Reconcile(ctx context.Context, key string) (err error) {
 	c.Logger.Infof("Reconciling %v", time.Now())
 
 	// Convert the namespace/name string into a distinct namespace and name
@@ -127,6 +183,7 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 	if errors.IsNotFound(err) {
 		// The resource no longer exists, in which case we stop processing.
 		c.Logger.Errorf("pipeline run %q in work queue no longer exists", key)
+		c.forgetScheduled(namespace, key)
 		return nil
 	} else if err != nil {
 		return err
@@ -134,6 +191,20 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 
 	// Don't modify the informer's copy.
 	pr := original.DeepCopy()
+
+	if tklogging.Traced(original) {
+		logger := tklogging.TraceLogger(logging.FromContext(ctx))
+		logger.Debugw("Reconciling traced PipelineRun", "status", original.Status)
+		defer func(start time.Time) {
+			logger.Debugw("Reconciled traced PipelineRun", "duration", time.Since(start), "status", pr.Status, zap.Error(err))
+		}(time.Now())
+	}
+
+	if pr.DeletionTimestamp != nil && reconciler.HasFinalizer(pr, reconciler.CleanupFinalizer) {
+		return c.finalize(pr)
+	}
+	addFinalizer(ctx, pr)
+
 	if !pr.HasStarted() {
 		pr.Status.InitializeConditions()
 		// In case node time was not synchronized, when controller has been scheduled to other nodes.
@@ -152,6 +223,7 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 	var merr error
 
 	if pr.IsDone() {
+		c.forgetScheduled(pr.Namespace, key)
 		if err := artifacts.CleanupArtifactStorage(pr, c.KubeClientSet, c.Logger); err != nil {
 			c.Logger.Errorf("Failed to delete PVC for PipelineRun %s: %v", pr.Name, err)
 			return err
@@ -181,6 +253,9 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 			merr = multierror.Append(merr, err)
 		}
 	}
+	cfg := prconfig.FromContext(ctx)
+	cloudevent.EmitCloudEvents(cfg.Defaults.DefaultCloudEventsSink, cfg.FeatureFlags.EnableRunCloudEventsSink,
+		original.Status.GetCondition(apis.ConditionSucceeded), pr.Status.GetCondition(apis.ConditionSucceeded), pr, c.Logger, c.cloudEventClient, c.namespaceLister)
 
 	var updated bool
 	if !equality.Semantic.DeepEqual(original.Status, pr.Status) {
@@ -194,7 +269,7 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 
 	// Since we are using the status subresource, it is not possible to update
 	// the status and labels/annotations simultaneously.
-	if !reflect.DeepEqual(original.ObjectMeta.Labels, pr.ObjectMeta.Labels) || !reflect.DeepEqual(original.ObjectMeta.Annotations, pr.ObjectMeta.Annotations) {
+	if !reflect.DeepEqual(original.ObjectMeta.Labels, pr.ObjectMeta.Labels) || !reflect.DeepEqual(original.ObjectMeta.Annotations, pr.ObjectMeta.Annotations) || !reflect.DeepEqual(original.ObjectMeta.Finalizers, pr.ObjectMeta.Finalizers) {
 		if _, err := c.updateLabelsAndAnnotations(pr); err != nil {
 			c.Logger.Warn("Failed to update PipelineRun labels/annotations", zap.Error(err))
 			c.Recorder.Event(pr, corev1.EventTypeWarning, eventReasonFailed, "PipelineRun failed to update labels/annotations")
@@ -215,7 +290,10 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 	return merr
 }
 
-func (c *Reconciler) getPipelineFunc(tr *v1alpha1.PipelineRun) resources.GetPipeline {
+func (c *Reconciler) getPipelineFunc(ctx context.Context, tr *v1alpha1.PipelineRun) resources.GetPipeline {
+	if tr.Spec.PipelineRef != nil && tr.Spec.PipelineRef.Resolver != "" {
+		return c.getRemotePipeline(ctx, tr)
+	}
 	var gtFunc resources.GetPipeline = func(name string) (v1alpha1.PipelineInterface, error) {
 		p, err := c.pipelineLister.Pipelines(tr.Namespace).Get(name)
 		if err != nil {
@@ -230,10 +308,20 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 	// We may be reading a version of the object that was stored at an older version
 	// and may not have had all of the assumed default specified.
 	pr.SetDefaults(contexts.WithUpgradeViaDefaulting(ctx))
+	pr.Status.EffectiveTimeout = &metav1.Duration{Duration: pr.Spec.Timeout.Duration}
 
-	getPipelineFunc := c.getPipelineFunc(pr)
+	getPipelineFunc := c.getPipelineFunc(ctx, pr)
 	pipelineMeta, pipelineSpec, err := resources.GetPipelineData(pr, getPipelineFunc)
-	if err != nil {
+	if resolution.IsRequestInProgress(err) {
+		// The ResolutionRequest enqueues the PipelineRun again once resolved.
+		pr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  ReasonResolvingPipelineRef,
+			Message: fmt.Sprintf("Waiting for the %s resolver to fetch the Pipeline", pr.Spec.PipelineRef.Resolver),
+		})
+		return nil
+	} else if err != nil {
 		c.Logger.Errorf("Failed to determine Pipeline spec to use for pipelinerun %s: %v", pr.Name, err)
 		pr.Status.SetCondition(&apis.Condition{
 			Type:   apis.ConditionSucceeded,
@@ -262,6 +350,10 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		pr.ObjectMeta.Annotations[key] = value
 	}
 
+	// The other tasks run after the change detection task, and are skipped
+	// if it detects no changes.
+	pipelineSpec = resources.ApplyChangeDetection(pipelineSpec)
+
 	d, err := dag.Build(v1alpha1.PipelineTaskList(pipelineSpec.Tasks))
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
@@ -287,6 +379,15 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		return nil
 	}
 
+	// Tasks which can never run don't fail the PipelineRun, but are most
+	// likely a mistake: warn about them when it starts.
+	if len(pr.Status.TaskRuns) == 0 && len(pr.Status.Runs) == 0 {
+		for _, t := range pipelineSpec.UnreachableTasks() {
+			c.Recorder.Eventf(pr, corev1.EventTypeWarning, eventReasonUnreachableTask, "Task %q of Pipeline %s can never run: %s", t.Name, pipelineMeta.Name, t.Reason)
+		}
+	}
+	c.checkExpectedDuration(pr, pipelineMeta, pipelineSpec)
+
 	if err := resources.ValidateResourceBindings(pipelineSpec, pr); err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.SetCondition(&apis.Condition{
@@ -298,6 +399,17 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		})
 		return nil
 	}
+	if err := resources.ValidateWorkspaceBindings(pipelineSpec, pr); err != nil {
+		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		pr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: ReasonInvalidWorkspaceBindings,
+			Message: fmt.Sprintf("PipelineRun %s doesn't bind Pipeline %s's workspaces correctly: %s",
+				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), fmt.Sprintf("%s/%s", pr.Namespace, pipelineMeta.Name), err),
+		})
+		return nil
+	}
 	providedResources, err := resources.GetResourcesFromBindings(pr, c.resourceLister.PipelineResources(pr.Namespace).Get)
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
@@ -311,9 +423,30 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		return nil
 	}
 
+	// Call the params hook of the Pipeline once, when the PipelineRun starts.
+	if pipelineSpec.ParamsHook != nil && pr.Status.ParamsHook == nil {
+		params, err := c.computeParams(ctx, pr, pipelineSpec)
+		if isParamsHookUnavailable(err) {
+			// Retry until the params hook computes the params.
+			c.Logger.Errorf("Failed to compute the params of PipelineRun %q: %v", pr.Name, err)
+			return err
+		} else if err != nil {
+			pr.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: ReasonCouldntComputeParams,
+				Message: fmt.Sprintf("PipelineRun %s can't be Run; the params hook of Pipeline %s failed: %s",
+					fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), fmt.Sprintf("%s/%s", pipelineMeta.Namespace, pipelineMeta.Name), err),
+			})
+			return nil
+		}
+		pr.Status.ParamsHook = &v1alpha1.PipelineRunParamsHookStatus{Params: params}
+	}
+	paramsRun := withComputedParams(pr)
+
 	// Ensure that the parameters from the PipelineRun are overriding Pipeline parameters with the same type.
 	// Weird substitution issues can occur if this is not validated (ApplyParameters() does not verify type).
-	err = resources.ValidateParamTypesMatching(pipelineSpec, pr)
+	err = resources.ValidateParamTypesMatching(pipelineSpec, paramsRun)
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.SetCondition(&apis.Condition{
@@ -326,10 +459,66 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		return nil
 	}
 
+	// Ensure that the values of the parameters from the PipelineRun are allowed by the Pipeline.
+	if err := resources.ValidateParamEnums(pipelineSpec, paramsRun); err != nil {
+		pr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: ReasonParameterValueNotAllowed,
+			Message: fmt.Sprintf("PipelineRun %s parameters have values that Pipeline %s's parameters don't allow: %s",
+				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), fmt.Sprintf("%s/%s", pipelineMeta.Namespace, pipelineMeta.Name), err),
+		})
+		return nil
+	}
+
 	// Apply parameter substitution from the PipelineRun
-	pipelineSpec = resources.ApplyParameters(pipelineSpec, pr)
+	pipelineSpec = resources.ApplyParameters(pipelineSpec, paramsRun)
+
+	// Apply the config values of the cluster, overridden by those of the
+	// namespace, once the param defaults that reference them are substituted.
+	if keys := resources.ConfigValueReferences(pipelineSpec); len(keys) > 0 {
+		values, err := resources.GetConfigValues(keys, []string{system.GetNamespace(), pr.Namespace}, func(namespace, name string) (*corev1.ConfigMap, error) {
+			return c.configMapLister.ConfigMaps(namespace).Get(name)
+		})
+		if err != nil {
+			if !goerrors.Is(err, resources.ErrConfigValueMissing) {
+				return err
+			}
+			pr.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: ReasonCouldntGetConfigValue,
+				Message: fmt.Sprintf("PipelineRun %s can't be Run; it references config values that can't be resolved: %s",
+					fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), err),
+			})
+			return nil
+		}
+		pipelineSpec = resources.ApplyConfigValues(pipelineSpec, values)
+	}
+
+	// Number the PipelineRun once, when its Pipeline uses its number.
+	if resources.UsesRunNumber(pipelineSpec) {
+		if pr.Status.Number == 0 {
+			if err := c.numberPipelineRun(pr); err != nil {
+				if errors.IsConflict(err) {
+					// Another PipelineRun of the Pipeline was just numbered.
+					return err
+				}
+				pr.Status.SetCondition(&apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+					Reason: ReasonCouldntGetRunNumber,
+					Message: fmt.Sprintf("PipelineRun %s can't be Run; it couldn't be numbered: %s",
+						fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), err),
+				})
+				return nil
+			}
+		}
+		pipelineSpec = resources.ApplyRunNumber(pipelineSpec, pr.Status.Number)
+	}
 
 	pipelineState, err := resources.ResolvePipelineRun(
+		ctx,
 		*pr,
 		func(name string) (v1alpha1.TaskInterface, error) {
 			return c.taskLister.Tasks(pr.Namespace).Get(name)
@@ -337,16 +526,28 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		func(name string) (*v1alpha1.TaskRun, error) {
 			return c.taskRunLister.TaskRuns(pr.Namespace).Get(name)
 		},
+		func(name string) (*v1alpha1.Run, error) {
+			return c.runLister.Runs(pr.Namespace).Get(name)
+		},
 		func(name string) (v1alpha1.TaskInterface, error) {
 			return c.clusterTaskLister.Get(name)
 		},
+		c.getRemoteTask(ctx, pr),
 		func(name string) (*v1alpha1.Condition, error) {
 			return c.conditionLister.Conditions(pr.Namespace).Get(name)
 		},
-		pipelineSpec.Tasks, providedResources,
+		append(append([]v1alpha1.PipelineTask{}, pipelineSpec.Tasks...), pipelineSpec.Finally...), providedResources,
 	)
 
-	if err != nil {
+	if resolution.IsRequestInProgress(err) {
+		pr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  ReasonResolvingTaskRef,
+			Message: fmt.Sprintf("Waiting for the resolvers of Pipeline %s/%s to fetch its Tasks", pipelineMeta.Namespace, pipelineMeta.Name),
+		})
+		return nil
+	} else if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		switch err := err.(type) {
 		case *resources.TaskNotFoundError:
@@ -383,7 +584,23 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		return nil
 	}
 
+	// Pass the results of the TaskRuns that succeeded to the PipelineTasks
+	// that use them.
+	if err := resources.ApplyTaskResults(pipelineState); err != nil {
+		pr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonInvalidTaskResultReference,
+			Message: fmt.Sprintf("PipelineRun %s/%s can't be Run: %s", pr.Namespace, pr.Name, err),
+		})
+		return nil
+	}
+	resources.ApplyResourceResults(pipelineState)
+
 	for _, rprt := range pipelineState {
+		if rprt.CustomTask {
+			continue
+		}
 		err := taskrun.ValidateResolvedTaskResources(rprt.PipelineTask.Params, rprt.ResolvedTaskResources)
 		if err != nil {
 			c.Logger.Errorf("Failed to validate pipelinerun %q with error %v", pr.Name, err)
@@ -401,32 +618,83 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 	// If the pipelinerun is cancelled, cancel tasks and update status
 	if pr.IsCancelled() {
 		before := pr.Status.GetCondition(apis.ConditionSucceeded)
-		err := cancelPipelineRun(pr, pipelineState, c.PipelineClientSet)
+		wait, err := cancelPipelineRun(pr, pipelineState, c.PipelineClientSet)
+		if wait > 0 {
+			c.enqueueAfter(fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), wait)
+		}
 		after := pr.Status.GetCondition(apis.ConditionSucceeded)
 		reconciler.EmitEvent(c.Recorder, before, after, pr)
 		return err
 	}
 
-	candidateTasks, err := dag.GetSchedulable(d, pipelineState.SuccessfulPipelineTaskNames()...)
-	if err != nil {
-		c.Logger.Errorf("Error getting potential next tasks for valid pipelinerun %s: %v", pr.Name, err)
+	// The controllers of the custom tasks may not enforce the timeouts of
+	// their Runs: those of a PipelineRun that timed out are cancelled.
+	if pr.IsTimedOut() {
+		wait, err := stopRuns(pr, pipelineState, c.PipelineClientSet)
+		if err != nil {
+			return err
+		}
+		if wait > 0 {
+			c.enqueueAfter(fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), wait)
+			return nil
+		}
 	}
 
-	rprts := pipelineState.GetNextTasks(candidateTasks)
+	// Once a task of the DAG failed, the tasks timed out or the PipelineRun
+	// was stopped, no other one starts or is retried. The finally tasks start
+	// once the DAG is done.
+	dagState, finallyState := pipelineState.SplitFinally(pipelineSpec.Finally)
+	dagStopped := dagState.HasFailure() || pr.HasTasksTimedOut() || pr.IsGracefullyStopped()
+	var rprts []*resources.ResolvedPipelineRunTask
+	if !dagStopped {
+		candidateTasks, err := dag.GetSchedulable(d, dagState.SuccessfulPipelineTaskNames()...)
+		if err != nil {
+			c.Logger.Errorf("Error getting potential next tasks for valid pipelinerun %s: %v", pr.Name, err)
+		}
+		rprts = dagState.GetNextTasks(candidateTasks)
+	}
+	finallyNames := finallyState.PipelineTaskNames()
+	if dagState.IsDAGDone(d, dagStopped) && len(finallyState) > 0 {
+		if pr.Status.FinallyStartTime == nil {
+			pr.Status.FinallyStartTime = &metav1.Time{Time: time.Now()}
+		}
+		rprts = append(rprts, finallyState.GetNextTasks(finallyNames)...)
+	}
 
 	var as artifacts.ArtifactStorageInterface
 
-	if as, err = artifacts.InitializeArtifactStorage(c.Images, pr, pipelineSpec, c.KubeClientSet, c.Logger); err != nil {
+	if as, err = artifacts.InitializeArtifactStorage(ctx, c.Images, pr, pipelineSpec, c.KubeClientSet, c.Logger); err != nil {
+		if artifacts.IsArtifactPVCLost(err) {
+			// This Run has failed, so we need to mark it as failed and stop reconciling it
+			pr.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: ReasonArtifactStorageLost,
+				Message: fmt.Sprintf("PipelineRun %s can't run its remaining Tasks: %s",
+					fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), err),
+			})
+			return nil
+		}
 		c.Logger.Infof("PipelineRun failed to initialize artifact storage %s", pr.Name)
 		return err
 	}
 
+	if rprts, err = c.admit(pr, rprts); err != nil {
+		return err
+	}
 	for _, rprt := range rprts {
 		if rprt == nil {
 			continue
 		}
-		if rprt.ResolvedConditionChecks == nil || rprt.ResolvedConditionChecks.IsSuccess() {
-			rprt.TaskRun, err = c.createTaskRun(rprt, pr, as.StorageBasePath(pr))
+		_, finally := finallyNames[rprt.PipelineTask.Name]
+		if rprt.CustomTask {
+			rprt.Run, err = c.createRun(rprt, pr, finally)
+			if err != nil {
+				c.Recorder.Eventf(pr, corev1.EventTypeWarning, "RunCreationFailed", "Failed to create Run %q: %v", rprt.RunName, err)
+				return fmt.Errorf("error creating Run called %s for PipelineTask %s from PipelineRun %s: %w", rprt.RunName, rprt.PipelineTask.Name, pr.Name, err)
+			}
+		} else if createsTaskRun(rprt) {
+			rprt.TaskRun, err = c.createTaskRun(rprt, pr, as.StorageBasePath(pr), finally)
 			if err != nil {
 				c.Recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create TaskRun %q: %v", rprt.TaskRunName, err)
 				return fmt.Errorf("error creating TaskRun called %s for PipelineTask %s from PipelineRun %s: %w", rprt.TaskRunName, rprt.PipelineTask.Name, pr.Name, err)
@@ -442,11 +710,18 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		}
 	}
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
-	after := resources.GetPipelineConditionStatus(pr, pipelineState, c.Logger, d)
+	after := resources.GetPipelineConditionStatus(pr, dagState, finallyState, c.Logger, d)
+	if after.IsTrue() && dagState.NoChangesDetected(pipelineSpec.ChangeDetection) {
+		after.Reason = resources.ReasonNoChanges
+		after.Message = fmt.Sprintf("PipelineTask %s detected no changes, so the other Tasks were skipped", pipelineSpec.ChangeDetection.Task)
+	}
 	pr.Status.SetCondition(after)
 	reconciler.EmitEvent(c.Recorder, before, after, pr)
 
 	pr.Status.TaskRuns = getTaskRunsStatus(pr, pipelineState)
+	pr.Status.Runs = getRunsStatus(pipelineState)
+	pr.Status.RequestedResources = getRequestedResources(pr.Status.TaskRuns)
+	pr.Status.Graph = resources.GetPipelineRunGraph(pipelineState, d)
 
 	c.Logger.Infof("PipelineRun %s status is being set to %s", pr.Name, pr.Status.GetCondition(apis.ConditionSucceeded))
 	return nil
@@ -466,6 +741,7 @@ func getTaskRunsStatus(pr *v1alpha1.PipelineRun, state []*resources.ResolvedPipe
 		if prtrs == nil {
 			prtrs = &v1alpha1.PipelineRunTaskRunStatus{
 				PipelineTaskName: rprt.PipelineTask.Name,
+				Matrix:           rprt.Matrix,
 			}
 		}
 
@@ -501,6 +777,24 @@ func getTaskRunsStatus(pr *v1alpha1.PipelineRun, state []*resources.ResolvedPipe
 	return status
 }
 
+// getRunsStatus returns the status of the Runs of the custom tasks in state.
+func getRunsStatus(state []*resources.ResolvedPipelineRunTask) map[string]*v1alpha1.PipelineRunRunStatus {
+	var status map[string]*v1alpha1.PipelineRunRunStatus
+	for _, rprt := range state {
+		if rprt.Run == nil {
+			continue
+		}
+		if status == nil {
+			status = make(map[string]*v1alpha1.PipelineRunRunStatus)
+		}
+		status[rprt.RunName] = &v1alpha1.PipelineRunRunStatus{
+			PipelineTaskName: rprt.PipelineTask.Name,
+			Status:           &rprt.Run.Status,
+		}
+	}
+	return status
+}
+
 func (c *Reconciler) updateTaskRunsStatusDirectly(pr *v1alpha1.PipelineRun) error {
 	for taskRunName := range pr.Status.TaskRuns {
 		// TODO(dibyom): Add conditionCheck statuses here
@@ -515,10 +809,37 @@ func (c *Reconciler) updateTaskRunsStatusDirectly(pr *v1alpha1.PipelineRun) erro
 			prtrs.Status = &tr.Status
 		}
 	}
+	for runName, prrs := range pr.Status.Runs {
+		r, err := c.runLister.Runs(pr.Namespace).Get(runName)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving Run %s: %w", runName, err)
+			}
+		} else {
+			prrs.Status = &r.Status
+		}
+	}
+	pr.Status.RequestedResources = getRequestedResources(pr.Status.TaskRuns)
 	return nil
 }
 
-func (c *Reconciler) createTaskRun(rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun, storageBasePath string) (*v1alpha1.TaskRun, error) {
+// getRequestedResources returns the sum of the resources requested by the
+// completed TaskRuns of taskRuns, nil if none completed.
+func getRequestedResources(taskRuns map[string]*v1alpha1.PipelineRunTaskRunStatus) *v1alpha1.ResourceSeconds {
+	var sum *v1alpha1.ResourceSeconds
+	for _, prtrs := range taskRuns {
+		if prtrs.Status == nil || prtrs.Status.RequestedResources == nil {
+			continue
+		}
+		if sum == nil {
+			sum = &v1alpha1.ResourceSeconds{}
+		}
+		sum.Add(*prtrs.Status.RequestedResources)
+	}
+	return sum
+}
+
+func (c *Reconciler) createTaskRun(rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun, storageBasePath string, finally bool) (*v1alpha1.TaskRun, error) {
 	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(rprt.TaskRunName)
 	if tr != nil {
 		//is a retry
@@ -531,13 +852,15 @@ func (c *Reconciler) createTaskRun(rprt *resources.ResolvedPipelineRunTask, pr *
 		return c.PipelineClientSet.TektonV1alpha1().TaskRuns(pr.Namespace).UpdateStatus(tr)
 	}
 
+	metadata := pr.GetTaskRunMetadata(rprt.PipelineTask.Name)
 	tr = &v1alpha1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            rprt.TaskRunName,
+			GenerateName:    metadata.GenerateName,
 			Namespace:       pr.Namespace,
 			OwnerReferences: pr.GetOwnerReference(),
 			Labels:          getTaskrunLabels(pr, rprt.PipelineTask.Name),
-			Annotations:     getTaskrunAnnotations(pr),
+			Annotations:     getTaskrunAnnotations(pr, rprt.PipelineTask.Name),
 		},
 		Spec: v1alpha1.TaskRunSpec{
 			TaskRef: &v1alpha1.TaskRef{
@@ -548,15 +871,85 @@ func (c *Reconciler) createTaskRun(rprt *resources.ResolvedPipelineRunTask, pr *
 				Params: rprt.PipelineTask.Params,
 			},
 			ServiceAccountName: pr.GetServiceAccountName(rprt.PipelineTask.Name),
-			Timeout:            getTaskRunTimeout(pr),
-			PodTemplate:        pr.Spec.PodTemplate,
+			Timeout:            getTaskRunTimeout(pr, rprt.PipelineTask, finally),
+			PodTemplate:        pr.GetPodTemplate(rprt.PipelineTask.Name),
+			Workspaces:         getTaskRunWorkspaces(pr, rprt.PipelineTask),
+			StepOverrides:      pr.GetTaskRunSpec(rprt.PipelineTask.Name).StepOverrides,
+			SidecarOverrides:   pr.GetTaskRunSpec(rprt.PipelineTask.Name).SidecarOverrides,
+			CloudEventsSink:    pr.Spec.CloudEventsSink,
 		}}
 
+	// The Task of a taskRef with a resolver is embedded, rather than fetched
+	// again by the TaskRun.
+	if rprt.PipelineTask.TaskRef.Resolver != "" {
+		tr.Spec.TaskRef = nil
+		tr.Spec.TaskSpec = rprt.ResolvedTaskResources.TaskSpec.DeepCopy()
+	}
+
 	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, storageBasePath)
 	c.Logger.Infof("Creating a new TaskRun object %s", rprt.TaskRunName)
 	return c.PipelineClientSet.TektonV1alpha1().TaskRuns(pr.Namespace).Create(tr)
 }
 
+// createRun creates the Run of the custom task of rprt, which its controller
+// reconciles, or resets the status of the failed Run to retry it.
+func (c *Reconciler) createRun(rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun, finally bool) (*v1alpha1.Run, error) {
+	r, _ := c.runLister.Runs(pr.Namespace).Get(rprt.RunName)
+	if r != nil {
+		// is a retry: the controller of the custom task runs it again once
+		// its status is reset
+		r = r.DeepCopy()
+		r.Status.RetriesStatus = append(r.Status.RetriesStatus, *r.Status.DeepCopy())
+		r.Status.RetriesStatus[len(r.Status.RetriesStatus)-1].RetriesStatus = nil
+		r.Status.StartTime = nil
+		r.Status.CompletionTime = nil
+		r.Status.Results = nil
+		r.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		})
+		return c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).UpdateStatus(r)
+	}
+
+	r = &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.RunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: pr.GetOwnerReference(),
+			Labels:          getTaskrunLabels(pr, rprt.PipelineTask.Name),
+			Annotations:     getTaskrunAnnotations(pr, rprt.PipelineTask.Name),
+		},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1alpha1.TaskRef{
+				APIVersion: rprt.PipelineTask.TaskRef.APIVersion,
+				Kind:       rprt.PipelineTask.TaskRef.Kind,
+				Name:       rprt.PipelineTask.TaskRef.Name,
+			},
+			Params:  rprt.PipelineTask.Params,
+			Timeout: getRunTimeout(pr, rprt.PipelineTask, finally),
+		},
+	}
+	c.Logger.Infof("Creating a new Run object %s", rprt.RunName)
+	return c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Create(r)
+}
+
+// getTaskRunWorkspaces returns the bindings of the workspaces that the
+// PipelineTask passes to its Task, provided by those of the PipelineRun.
+func getTaskRunWorkspaces(pr *v1alpha1.PipelineRun, pt *v1alpha1.PipelineTask) []v1alpha1.WorkspaceBinding {
+	bindings := map[string]v1alpha1.WorkspaceBinding{}
+	for _, b := range pr.Spec.Workspaces {
+		bindings[b.Name] = b
+	}
+	var workspaces []v1alpha1.WorkspaceBinding
+	for _, w := range pt.Workspaces {
+		b := bindings[w.Workspace]
+		binding := b.DeepCopy()
+		binding.Name = w.Name
+		workspaces = append(workspaces, *binding)
+	}
+	return workspaces
+}
+
 func addRetryHistory(tr *v1alpha1.TaskRun) {
 	newStatus := *tr.Status.DeepCopy()
 	newStatus.RetriesStatus = nil
@@ -569,12 +962,16 @@ func clearStatus(tr *v1alpha1.TaskRun) {
 	tr.Status.PodName = ""
 }
 
-func getTaskrunAnnotations(pr *v1alpha1.PipelineRun) map[string]string {
+func getTaskrunAnnotations(pr *v1alpha1.PipelineRun, pipelineTaskName string) map[string]string {
 	// Propagate annotations from PipelineRun to TaskRun.
 	annotations := make(map[string]string, len(pr.ObjectMeta.Annotations)+1)
 	for key, val := range pr.ObjectMeta.Annotations {
 		annotations[key] = val
 	}
+	// Add those of the PipelineTask, that override those of the PipelineRun.
+	for key, val := range pr.GetTaskRunMetadata(pipelineTaskName).Annotations {
+		annotations[key] = val
+	}
 	return annotations
 }
 
@@ -584,6 +981,10 @@ func getTaskrunLabels(pr *v1alpha1.PipelineRun, pipelineTaskName string) map[str
 	for key, val := range pr.ObjectMeta.Labels {
 		labels[key] = val
 	}
+	// Add those of the PipelineTask, that override those of the PipelineRun.
+	for key, val := range pr.GetTaskRunMetadata(pipelineTaskName).Labels {
+		labels[key] = val
+	}
 	labels[pipeline.GroupName+pipeline.PipelineRunLabelKey] = pr.Name
 	if pipelineTaskName != "" {
 		labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey] = pipelineTaskName
@@ -591,7 +992,10 @@ func getTaskrunLabels(pr *v1alpha1.PipelineRun, pipelineTaskName string) map[str
 	return labels
 }
 
-func getTaskRunTimeout(pr *v1alpha1.PipelineRun) *metav1.Duration {
+// getTaskRunTimeout returns the timeout of the TaskRun of pt, or of a
+// condition check if pt is nil: it fits in the timeout of the PipelineRun, in
+// the timeout of its tasks or of its finally tasks, and in the timeout of pt.
+func getTaskRunTimeout(pr *v1alpha1.PipelineRun, pt *v1alpha1.PipelineTask, finally bool) *metav1.Duration {
 	var taskRunTimeout = &metav1.Duration{Duration: apisconfig.NoTimeoutDuration}
 
 	var timeout time.Duration
@@ -615,9 +1019,104 @@ func getTaskRunTimeout(pr *v1alpha1.PipelineRun) *metav1.Duration {
 			taskRunTimeout = &metav1.Duration{Duration: timeout}
 		}
 	}
+
+	if pr.Spec.Timeouts != nil {
+		if finally && pr.Spec.Timeouts.Finally != nil {
+			start := time.Now()
+			if pr.Status.FinallyStartTime != nil {
+				start = pr.Status.FinallyStartTime.Time
+			}
+			taskRunTimeout = minTimeout(taskRunTimeout, remainingTimeout(start, pr.Spec.Timeouts.Finally.Duration))
+		} else if !finally && pr.Spec.Timeouts.Tasks != nil {
+			taskRunTimeout = minTimeout(taskRunTimeout, remainingTimeout(pr.Status.StartTime.Time, pr.Spec.Timeouts.Tasks.Duration))
+		}
+	}
+	if pt != nil && pt.Timeout != nil {
+		taskRunTimeout = minTimeout(taskRunTimeout, pt.Timeout.Duration)
+	}
 	return taskRunTimeout
 }
 
+// getRunTimeout returns the timeout of the Run of the custom task pt: that of
+// its TaskRun, within the time left of the timeout of the PipelineRun rather
+// than the whole of it, since the controller of the custom task enforces it
+// from the time the Run is created.
+func getRunTimeout(pr *v1alpha1.PipelineRun, pt *v1alpha1.PipelineTask, finally bool) *metav1.Duration {
+	timeout := config.DefaultTimeoutMinutes * time.Minute
+	if pr.Spec.Timeout != nil {
+		timeout = pr.Spec.Timeout.Duration
+	}
+	return minTimeout(getTaskRunTimeout(pr, pt, finally), remainingTimeout(pr.Status.StartTime.Time, timeout))
+}
+
+// remainingTimeout returns the time left before the timeout started at start
+// expires, at least 1 second, or 0 if there is no timeout.
+func remainingTimeout(start time.Time, timeout time.Duration) time.Duration {
+	if timeout == apisconfig.NoTimeoutDuration {
+		return apisconfig.NoTimeoutDuration
+	}
+	if remaining := time.Until(start.Add(timeout)); remaining > time.Second {
+		return remaining
+	}
+	return time.Second
+}
+
+// numberPipelineRun sets the number of the PipelineRun to the next one of its
+// Pipeline. The Pipeline is updated with it first, so that two PipelineRuns
+// are never given the same number.
+func (c *Reconciler) numberPipelineRun(pr *v1alpha1.PipelineRun) error {
+	if pr.Spec.PipelineRef == nil || pr.Spec.PipelineRef.Name == "" || pr.Spec.PipelineRef.Resolver != "" {
+		return fmt.Errorf("only the PipelineRuns of a Pipeline of the cluster are numbered")
+	}
+	p, err := c.PipelineClientSet.TektonV1alpha1().Pipelines(pr.Namespace).Get(pr.Spec.PipelineRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	number, err := resources.NextRunNumber(p)
+	if err != nil {
+		return err
+	}
+	if _, err := c.PipelineClientSet.TektonV1alpha1().Pipelines(pr.Namespace).Update(p); err != nil {
+		return err
+	}
+	pr.Status.Number = number
+	return nil
+}
+
+// checkExpectedDuration reports the PipelineRun, once, when it runs for longer
+// than the expected duration of its Pipeline. Until then, it is enqueued again
+// when the expected duration elapses, in case nothing else happens by then.
+func (c *Reconciler) checkExpectedDuration(pr *v1alpha1.PipelineRun, pipelineMeta *metav1.ObjectMeta, pipelineSpec *v1alpha1.PipelineSpec) {
+	if pipelineSpec.ExpectedDuration == nil || pr.Status.ExceededExpectedDuration || pr.Status.StartTime == nil {
+		return
+	}
+	expected := pipelineSpec.ExpectedDuration.Duration
+	elapsed := time.Since(pr.Status.StartTime.Time)
+	if elapsed <= expected {
+		if len(pr.Status.TaskRuns) == 0 && len(pr.Status.Runs) == 0 {
+			go c.timeoutHandler.SetPipelineRunTimer(pr, expected-elapsed)
+		}
+		return
+	}
+	pr.Status.ExceededExpectedDuration = true
+	c.Recorder.Eventf(pr, corev1.EventTypeWarning, eventReasonExceededExpectedDuration,
+		"PipelineRun has been running for %s, longer than the expected duration %s of Pipeline %s", elapsed.Round(time.Second), expected, pipelineMeta.Name)
+	go func(metrics *Recorder) {
+		if err := metrics.ExceededExpectedDuration(pr); err != nil {
+			c.Logger.Warnf("Failed to log the metrics : %v", err)
+		}
+	}(c.metrics)
+}
+
+// minTimeout returns the shortest of the timeouts a and b, where 0 means no
+// timeout.
+func minTimeout(a *metav1.Duration, b time.Duration) *metav1.Duration {
+	if b == apisconfig.NoTimeoutDuration || (a.Duration != apisconfig.NoTimeoutDuration && a.Duration <= b) {
+		return a
+	}
+	return &metav1.Duration{Duration: b}
+}
+
 func (c *Reconciler) updateStatus(pr *v1alpha1.PipelineRun) (*v1alpha1.PipelineRun, error) {
 	newPr, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pr.Name)
 	if err != nil {
@@ -641,9 +1140,10 @@ func (c *Reconciler) updateLabelsAndAnnotations(pr *v1alpha1.PipelineRun) (*v1al
 	if err != nil {
 		return nil, fmt.Errorf("error getting PipelineRun %s when updating labels/annotations: %w", pr.Name, err)
 	}
-	if !reflect.DeepEqual(pr.ObjectMeta.Labels, newPr.ObjectMeta.Labels) || !reflect.DeepEqual(pr.ObjectMeta.Annotations, newPr.ObjectMeta.Annotations) {
+	if !reflect.DeepEqual(pr.ObjectMeta.Labels, newPr.ObjectMeta.Labels) || !reflect.DeepEqual(pr.ObjectMeta.Annotations, newPr.ObjectMeta.Annotations) || !reflect.DeepEqual(pr.ObjectMeta.Finalizers, newPr.ObjectMeta.Finalizers) {
 		newPr.ObjectMeta.Labels = pr.ObjectMeta.Labels
 		newPr.ObjectMeta.Annotations = pr.ObjectMeta.Annotations
+		newPr.ObjectMeta.Finalizers = pr.ObjectMeta.Finalizers
 		return c.PipelineClientSet.TektonV1alpha1().PipelineRuns(pr.Namespace).Update(newPr)
 	}
 	return newPr, nil
@@ -664,7 +1164,7 @@ func (c *Reconciler) makeConditionCheckContainer(rprt *resources.ResolvedPipelin
 			Namespace:       pr.Namespace,
 			OwnerReferences: pr.GetOwnerReference(),
 			Labels:          labels,
-			Annotations:     getTaskrunAnnotations(pr), // Propagate annotations from PipelineRun to TaskRun.
+			Annotations:     getTaskrunAnnotations(pr, rprt.PipelineTask.Name), // Propagate annotations from PipelineRun to TaskRun.
 		},
 		Spec: v1alpha1.TaskRunSpec{
 			TaskSpec:           taskSpec,
@@ -673,8 +1173,9 @@ func (c *Reconciler) makeConditionCheckContainer(rprt *resources.ResolvedPipelin
 				Params:    rcc.PipelineTaskCondition.Params,
 				Resources: rcc.ToTaskResourceBindings(),
 			},
-			Timeout:     getTaskRunTimeout(pr),
-			PodTemplate: pr.Spec.PodTemplate,
+			Timeout:         getTaskRunTimeout(pr, nil, false),
+			PodTemplate:     pr.GetPodTemplate(rprt.PipelineTask.Name),
+			CloudEventsSink: pr.Spec.CloudEventsSink,
 		}}
 
 	cctr, err := c.PipelineClientSet.TektonV1alpha1().TaskRuns(pr.Namespace).Create(tr)
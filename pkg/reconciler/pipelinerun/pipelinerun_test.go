@@ -19,23 +19,29 @@ package pipelinerun
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/artifacts"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
 	taskrunresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources/cloudevent"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/system"
-	"github.com/tektoncd/pipeline/test"
 	tb "github.com/tektoncd/pipeline/test/builder"
 	"github.com/tektoncd/pipeline/test/names"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
 	ktesting "k8s.io/client-go/testing"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -43,7 +49,7 @@ import (
 )
 
 var (
-	ignoreLastTransitionTime = cmpopts.IgnoreTypes(apis.Condition{}.LastTransitionTime.Inner.Time)
+	ignoreLastTransitionTime = reconcilertest.IgnoreLastTransitionTime
 	images                   = pipeline.Images{
 		EntrypointImage:          "override-with-entrypoint:latest",
 		NopImage:                 "tianon/true",
@@ -64,13 +70,22 @@ func getRunName(pr *v1alpha1.PipelineRun) string {
 
 // getPipelineRunController returns an instance of the PipelineRun controller/reconciler that has been seeded with
 // d, where d represents the state of the system (existing resources) needed for the test.
-func getPipelineRunController(t *testing.T, d test.Data) (test.Assets, func()) {
+func getPipelineRunController(t *testing.T, d reconcilertest.Data) (reconcilertest.Assets, func()) {
 	ctx, _ := ttesting.SetupFakeContext(t)
-	c, _ := test.SeedTestData(t, ctx, d)
+	ctx = cloudevent.WithClient(ctx, &cloudevent.FakeClientBehaviour{SendSuccessfully: true})
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
 	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
 	ctx, cancel := context.WithCancel(ctx)
-	return test.Assets{
-		Controller: NewController(images)(ctx, configMapWatcher),
+	ctl := NewController(images, SchedulingPolicy{})(ctx, configMapWatcher)
+	// Only start watching when the test provides the configmaps, otherwise
+	// the reconciler uses the default config.
+	if len(d.ConfigMaps) > 0 {
+		if err := configMapWatcher.Start(ctx.Done()); err != nil {
+			t.Fatalf("error starting configmap watcher: %v", err)
+		}
+	}
+	return reconcilertest.Assets{
+		Controller: ctl,
 		Clients:    c,
 	}, cancel
 }
@@ -185,7 +200,7 @@ func TestReconcile(t *testing.T) {
 	// after we have resolved them.
 	rs[0].SelfLink = "some/link"
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns:      prs,
 		Pipelines:         ps,
 		Tasks:             ts,
@@ -280,6 +295,30 @@ func TestReconcile(t *testing.T) {
 		t.Errorf("Expected PipelineRun status to include TaskRun status but was %v", reconciledRun.Status.TaskRuns)
 	}
 
+	expectedGraph := &v1alpha1.PipelineRunGraph{
+		Nodes: []v1alpha1.PipelineRunGraphNode{{
+			PipelineTaskName: "unit-test-3",
+			State:            v1alpha1.PipelineTaskStatePending,
+		}, {
+			PipelineTaskName: "unit-test-1",
+			State:            v1alpha1.PipelineTaskStateRunning,
+		}, {
+			PipelineTaskName: "unit-test-2",
+			State:            v1alpha1.PipelineTaskStatePending,
+		}, {
+			PipelineTaskName: "unit-test-cluster-task",
+			State:            v1alpha1.PipelineTaskStateRunning,
+		}},
+		Edges: []v1alpha1.PipelineRunGraphEdge{{
+			From: "unit-test-2", To: "unit-test-3", Type: v1alpha1.PipelineRunGraphEdgeRunAfter,
+		}, {
+			From: "unit-test-1", To: "unit-test-2", Type: v1alpha1.PipelineRunGraphEdgeFrom,
+		}},
+	}
+	if d := cmp.Diff(expectedGraph, reconciledRun.Status.Graph, cmpopts.IgnoreFields(v1alpha1.PipelineRunGraphNode{}, "TaskRunName")); d != "" {
+		t.Errorf("Expected PipelineRun status to include the graph (-want, +got): %s", d)
+	}
+
 	// A PVC should have been created to deal with output -> input linking
 	ensurePVCCreated(t, clients, expectedTaskRun.GetPipelineRunPVCName(), "foo")
 }
@@ -309,6 +348,9 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 			tb.PipelineParamSpec("some-param", v1alpha1.ParamTypeArray),
 			tb.PipelineTask("some-task", "a-task-that-needs-array-params"))),
 		tb.Pipeline("a-pipeline-with-missing-conditions", "foo", tb.PipelineSpec(tb.PipelineTask("some-task", "a-task-that-exists", tb.PipelineTaskCondition("condition-does-not-exist")))),
+		tb.Pipeline("a-pipeline-with-enum-params", "foo", tb.PipelineSpec(
+			tb.PipelineParamSpec("some-param", v1alpha1.ParamTypeString, tb.ParamSpecEnum("amd64", "arm64")),
+			tb.PipelineTask("some-task", "a-task-that-needs-params", tb.PipelineTaskParam("some-param", "$(params.some-param)")))),
 	}
 	prs := []*v1alpha1.PipelineRun{
 		tb.PipelineRun("invalid-pipeline", "foo", tb.PipelineRunSpec("pipeline-not-exist")),
@@ -320,8 +362,9 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 		tb.PipelineRun("pipeline-resources-not-declared", "foo", tb.PipelineRunSpec("a-pipeline-that-should-be-caught-by-admission-control")),
 		tb.PipelineRun("pipeline-mismatching-param-type", "foo", tb.PipelineRunSpec("a-pipeline-with-array-params", tb.PipelineRunParam("some-param", "stringval"))),
 		tb.PipelineRun("pipeline-conditions-missing", "foo", tb.PipelineRunSpec("a-pipeline-with-missing-conditions")),
+		tb.PipelineRun("pipeline-param-not-in-enum", "foo", tb.PipelineRunSpec("a-pipeline-with-enum-params", tb.PipelineRunParam("some-param", "s390x"))),
 	}
-	d := test.Data{
+	d := reconcilertest.Data{
 		Tasks:        ts,
 		Pipelines:    ps,
 		PipelineRuns: prs,
@@ -365,6 +408,10 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 			name:        "invalid-pipeline-missing-conditions-shd-stop-reconciling",
 			pipelineRun: prs[7],
 			reason:      ReasonCouldntGetCondition,
+		}, {
+			name:        "invalid-pipeline-param-not-in-enum",
+			pipelineRun: prs[8],
+			reason:      ReasonParameterValueNotAllowed,
 		},
 	}
 
@@ -432,7 +479,7 @@ func TestReconcile_InvalidPipelineRunNames(t *testing.T) {
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			testAssets, cancel := getPipelineRunController(t, test.Data{})
+			testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{})
 			defer cancel()
 			c := testAssets.Controller
 
@@ -666,7 +713,7 @@ func TestReconcileOnCompletedPipelineRun(t *testing.T) {
 			),
 		),
 	}
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -747,7 +794,7 @@ func TestReconcileOnCancelledPipelineRun(t *testing.T) {
 		),
 	}
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -794,7 +841,7 @@ func TestReconcileWithTimeout(t *testing.T) {
 	)}
 	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -837,6 +884,60 @@ func TestReconcileWithTimeout(t *testing.T) {
 	}
 }
 
+func TestReconcileWithExpectedDuration(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.PipelineExpectedDuration(10*time.Minute),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-on-time", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunTimeout(12*time.Hour)),
+		tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-time.Minute))),
+	), tb.PipelineRun("test-pipeline-run-overdue", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunTimeout(12*time.Hour)),
+		tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-time.Hour))),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := reconcilertest.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	testAssets, cancel := getPipelineRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	for _, tc := range []struct {
+		name string
+		want bool
+	}{{
+		name: "test-pipeline-run-on-time",
+		want: false,
+	}, {
+		name: "test-pipeline-run-overdue",
+		want: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/"+tc.name); err != nil {
+				t.Fatalf("Error reconciling PipelineRun: %s", err)
+			}
+			reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get(tc.name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+			}
+			// The PipelineRun keeps running either way.
+			if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+				t.Errorf("Expected PipelineRun to be running, but condition is %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+			}
+			if reconciledRun.Status.ExceededExpectedDuration != tc.want {
+				t.Errorf("Expected ExceededExpectedDuration to be %t", tc.want)
+			}
+		})
+	}
+}
+
 func TestReconcileWithoutPVC(t *testing.T) {
 	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
 		tb.PipelineTask("hello-world-1", "hello-world"),
@@ -848,7 +949,7 @@ func TestReconcileWithoutPVC(t *testing.T) {
 	}
 	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -885,6 +986,93 @@ func TestReconcileWithoutPVC(t *testing.T) {
 	}
 }
 
+func TestReconcileWithDeletedPVC(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineDeclaredResource("git-repo", "git"),
+		tb.PipelineTask("hello-world-1", "hello-world",
+			tb.PipelineTaskOutputResource("workspace", "git-repo"),
+		),
+		tb.PipelineTask("hello-world-2", "hello-world-followup",
+			tb.PipelineTaskInputResource("workspace", "git-repo", tb.From("hello-world-1")),
+		),
+	))}
+	ts := []*v1alpha1.Task{
+		tb.Task("hello-world", "foo", tb.TaskSpec(
+			tb.TaskOutputs(tb.OutputsResource("workspace", v1alpha1.PipelineResourceTypeGit)),
+		)),
+		tb.Task("hello-world-followup", "foo", tb.TaskSpec(
+			tb.TaskInputs(tb.InputsResource("workspace", v1alpha1.PipelineResourceTypeGit)),
+		)),
+	}
+	rs := []*v1alpha1.PipelineResource{tb.PipelineResource("some-repo", "foo", tb.PipelineResourceSpec(
+		v1alpha1.PipelineResourceTypeGit,
+		tb.PipelineResourceSpecParam("url", "https://github.com/kristoff/reindeer"),
+	))}
+	trs := []*v1alpha1.TaskRun{tb.TaskRun("test-pipeline-run-hello-world-1", "foo",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run"),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
+		tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+		tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionTrue,
+		})),
+	)}
+	// The first Task ran, storing its output in the PVC of the PipelineRun.
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunResourceBinding("git-repo", tb.PipelineResourceBindingRef("some-repo")),
+		),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(time.Now()),
+			tb.PipelineRunTaskRunsStatus("test-pipeline-run-hello-world-1", &v1alpha1.PipelineRunTaskRunStatus{
+				PipelineTaskName: "hello-world-1",
+				Status:           &trs[0].Status,
+			}),
+		),
+	)}
+
+	d := reconcilertest.Data{
+		PipelineRuns:      prs,
+		Pipelines:         ps,
+		Tasks:             ts,
+		TaskRuns:          trs,
+		PipelineResources: rs,
+	}
+
+	testAssets, cancel := getPipelineRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != ReasonArtifactStorageLost {
+		t.Errorf("Expected PipelineRun to fail with reason %s, but condition is %v", ReasonArtifactStorageLost, condition)
+	}
+	// The PVC isn't recreated, and the remaining Task doesn't run.
+	for _, a := range clients.Kube.Actions() {
+		if ca, ok := a.(ktesting.CreateAction); ok {
+			if pvc, ok := ca.GetObject().(*corev1.PersistentVolumeClaim); ok {
+				t.Errorf("Did not expect to see a PVC created: %s was created", pvc.Name)
+			}
+		}
+	}
+	for _, a := range clients.Pipeline.Actions() {
+		if ca, ok := a.(ktesting.CreateAction); ok {
+			if tr, ok := ca.GetObject().(*v1alpha1.TaskRun); ok {
+				t.Errorf("Did not expect to see a TaskRun created: %s was created", tr.Name)
+			}
+		}
+	}
+}
+
 func TestReconcileCancelledPipelineRun(t *testing.T) {
 	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
 		tb.PipelineTask("hello-world-1", "hello-world", tb.Retries(1)),
@@ -896,7 +1084,7 @@ func TestReconcileCancelledPipelineRun(t *testing.T) {
 	)}
 	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -949,7 +1137,7 @@ func TestReconcilePropagateLabels(t *testing.T) {
 	)}
 	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -1014,7 +1202,7 @@ func TestReconcileWithDifferentServiceAccounts(t *testing.T) {
 		tb.Task("hello-world-task", "foo"),
 	}
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -1143,7 +1331,7 @@ func TestReconcileWithTimeoutAndRetry(t *testing.T) {
 			prs[0].Status.TaskRuns = make(map[string]*v1alpha1.PipelineRunTaskRunStatus)
 			prs[0].Status.TaskRuns["hello-world-1"] = prtrs
 
-			d := test.Data{
+			d := reconcilertest.Data{
 				PipelineRuns: prs,
 				Pipelines:    ps,
 				Tasks:        ts,
@@ -1192,7 +1380,7 @@ func TestReconcilePropagateAnnotations(t *testing.T) {
 	)}
 	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -1239,6 +1427,148 @@ func TestReconcilePropagateAnnotations(t *testing.T) {
 	}
 }
 
+func TestReconcileWithTaskRunMetadata(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-metadata", "foo",
+		tb.PipelineRunLabel("team", "pipelines"),
+		tb.PipelineRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccountName("test-sa"),
+			tb.PipelineRunTaskRunMetadata("hello-world-1", v1alpha1.PipelineTaskMetadata{
+				Labels:       map[string]string{"team": "hello", "example.com/cost-center": "42"},
+				Annotations:  map[string]string{"example.com/owner": "hello-team"},
+				GenerateName: "hello-",
+			}),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	})
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-metadata"); err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	expectedTaskRun := tb.TaskRun("hello-9l9zj", "foo",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-with-metadata",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1alpha1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel("team", "hello"),
+		tb.TaskRunLabel("example.com/cost-center", "42"),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+		tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "hello-world-1"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-with-metadata"),
+		tb.TaskRunAnnotation("PipelineRunAnnotation", "PipelineRunValue"),
+		tb.TaskRunAnnotation("example.com/owner", "hello-team"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef("hello-world"),
+			tb.TaskRunServiceAccountName("test-sa"),
+		),
+	)
+	expectedTaskRun.GenerateName = "hello-"
+	if d := cmp.Diff(expectedTaskRun, actual); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, d)
+	}
+
+	reconciled, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-with-metadata", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if _, ok := reconciled.Status.TaskRuns["hello-9l9zj"]; !ok {
+		t.Errorf("Expected the TaskRun hello-9l9zj in the status of the PipelineRun, got %v", reconciled.Status.TaskRuns)
+	}
+}
+
+func TestReconcileWithTaskRunTemplate(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.PipelineTask("hello-world-2", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-template", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunTaskRunTemplate(v1alpha1.PipelineTaskRunTemplate{
+				ServiceAccountName: "template-sa",
+				PodTemplate:        &v1alpha1.PodTemplate{NodeSelector: map[string]string{"pool": "shared"}},
+				Metadata: v1alpha1.PipelineTaskMetadata{
+					Labels: map[string]string{"team": "pipelines", "tier": "shared"},
+				},
+			}),
+			tb.PipelineRunTaskRunSpec(v1alpha1.PipelineTaskRunSpec{
+				PipelineTaskName:   "hello-world-2",
+				ServiceAccountName: "hello-sa",
+				PodTemplate:        &v1alpha1.PodTemplate{NodeSelector: map[string]string{"pool": "hello"}},
+				Metadata: v1alpha1.PipelineTaskMetadata{
+					Labels: map[string]string{"tier": "hello"},
+				},
+			}),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	})
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-template"); err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	var created []*v1alpha1.TaskRun
+	for _, a := range clients.Pipeline.Actions() {
+		if create, ok := a.(ktesting.CreateAction); ok {
+			if tr, ok := create.GetObject().(*v1alpha1.TaskRun); ok {
+				created = append(created, tr)
+			}
+		}
+	}
+	if len(created) != 2 {
+		t.Fatalf("Expected 2 TaskRuns to be created, got %d", len(created))
+	}
+	for i, expected := range []struct {
+		pipelineTask string
+		sa           string
+		nodeSelector map[string]string
+		tier         string
+	}{
+		{"hello-world-1", "template-sa", map[string]string{"pool": "shared"}, "shared"},
+		{"hello-world-2", "hello-sa", map[string]string{"pool": "hello"}, "hello"},
+	} {
+		tr := created[i]
+		if got := tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey]; got != expected.pipelineTask {
+			t.Fatalf("Expected TaskRun %d to run %s, got %s", i, expected.pipelineTask, got)
+		}
+		if tr.Spec.ServiceAccountName != expected.sa {
+			t.Errorf("Expected the TaskRun of %s to run as %s, got %s", expected.pipelineTask, expected.sa, tr.Spec.ServiceAccountName)
+		}
+		if d := cmp.Diff(expected.nodeSelector, tr.Spec.PodTemplate.NodeSelector); d != "" {
+			t.Errorf("node selector of the TaskRun of %s -want, +got: %s", expected.pipelineTask, d)
+		}
+		if tr.Labels["team"] != "pipelines" || tr.Labels["tier"] != expected.tier {
+			t.Errorf("Expected the TaskRun of %s to have the labels team=pipelines and tier=%s, got %v", expected.pipelineTask, expected.tier, tr.Labels)
+		}
+	}
+}
+
 func TestGetTaskRunTimeout(t *testing.T) {
 	prName := "pipelinerun-timeouts"
 	ns := "foo"
@@ -1247,6 +1577,8 @@ func TestGetTaskRunTimeout(t *testing.T) {
 	tcs := []struct {
 		name     string
 		pr       *v1alpha1.PipelineRun
+		pt       *v1alpha1.PipelineTask
+		finally  bool
 		expected *metav1.Duration
 	}{{
 		name: "nil timeout duration",
@@ -1275,17 +1607,133 @@ func TestGetTaskRunTimeout(t *testing.T) {
 			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(1*time.Minute)),
 			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-2*time.Minute)))),
 		expected: &metav1.Duration{Duration: 1 * time.Second},
+	}, {
+		name: "timeout of the pipeline task",
+		pr: tb.PipelineRun(prName, ns,
+			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(20*time.Minute)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+		),
+		pt:       &v1alpha1.PipelineTask{Name: "task", Timeout: &metav1.Duration{Duration: 5 * time.Minute}},
+		expected: &metav1.Duration{Duration: 5 * time.Minute},
+	}, {
+		name: "timeout of the pipeline task with no pipeline timeout",
+		pr: tb.PipelineRun(prName, ns,
+			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(0)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+		),
+		pt:       &v1alpha1.PipelineTask{Name: "task", Timeout: &metav1.Duration{Duration: 5 * time.Minute}},
+		expected: &metav1.Duration{Duration: 5 * time.Minute},
+	}, {
+		name: "time left of the tasks timeout",
+		pr: tb.PipelineRun(prName, ns,
+			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(time.Hour), tb.PipelineRunTimeouts(30*time.Minute, 10*time.Minute)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-10*time.Minute))),
+		),
+		pt:       &v1alpha1.PipelineTask{Name: "task", Timeout: &metav1.Duration{Duration: time.Hour}},
+		expected: &metav1.Duration{Duration: 20 * time.Minute},
+	}, {
+		name: "time left of the finally timeout",
+		pr: tb.PipelineRun(prName, ns,
+			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(time.Hour), tb.PipelineRunTimeouts(30*time.Minute, 10*time.Minute)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-40*time.Minute)), tb.PipelineRunFinallyStartTime(time.Now().Add(-4*time.Minute))),
+		),
+		pt:       &v1alpha1.PipelineTask{Name: "final-task"},
+		finally:  true,
+		expected: &metav1.Duration{Duration: 6 * time.Minute},
+	}, {
+		name: "finally timeout not started yet",
+		pr: tb.PipelineRun(prName, ns,
+			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(time.Hour), tb.PipelineRunTimeouts(30*time.Minute, 10*time.Minute)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-40*time.Minute))),
+		),
+		pt:       &v1alpha1.PipelineTask{Name: "final-task"},
+		finally:  true,
+		expected: &metav1.Duration{Duration: 10 * time.Minute},
+	}, {
+		name: "condition check with a tasks timeout",
+		pr: tb.PipelineRun(prName, ns,
+			tb.PipelineRunSpec(p, tb.PipelineRunTimeout(time.Hour), tb.PipelineRunTimeouts(30*time.Minute, 0)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-10*time.Minute))),
+		),
+		expected: &metav1.Duration{Duration: 20 * time.Minute},
 	}}
 
+	// The time left of a timeout goes down as the test runs.
+	approx := cmp.Comparer(func(x, y metav1.Duration) bool {
+		d := x.Duration - y.Duration
+		return d < time.Second && d > -time.Second
+	})
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			if d := cmp.Diff(getTaskRunTimeout(tc.pr), tc.expected); d != "" {
+			if d := cmp.Diff(getTaskRunTimeout(tc.pr, tc.pt, tc.finally), tc.expected, approx); d != "" {
 				t.Errorf("Unexpected task run timeout. Diff %s", d)
 			}
 		})
 	}
 }
 
+func TestGetRunTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		pr       *v1alpha1.PipelineRun
+		pt       *v1alpha1.PipelineTask
+		finally  bool
+		expected *metav1.Duration
+	}{{
+		name: "time left of the pipelinerun timeout",
+		pr: tb.PipelineRun("pipelinerun-timeouts", "foo",
+			tb.PipelineRunSpec("pipeline", tb.PipelineRunTimeout(time.Hour)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-40*time.Minute))),
+		),
+		pt:       &v1alpha1.PipelineTask{Name: "wait"},
+		expected: &metav1.Duration{Duration: 20 * time.Minute},
+	}, {
+		name: "time left of the default timeout",
+		pr: tb.PipelineRun("pipelinerun-timeouts", "foo",
+			tb.PipelineRunSpec("pipeline", tb.PipelineRunNilTimeout),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-40*time.Minute))),
+		),
+		pt:       &v1alpha1.PipelineTask{Name: "wait"},
+		expected: &metav1.Duration{Duration: 20 * time.Minute},
+	}, {
+		name: "timeout of the pipeline task",
+		pr: tb.PipelineRun("pipelinerun-timeouts", "foo",
+			tb.PipelineRunSpec("pipeline", tb.PipelineRunTimeout(time.Hour)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-40*time.Minute))),
+		),
+		pt:       &v1alpha1.PipelineTask{Name: "wait", Timeout: &metav1.Duration{Duration: 5 * time.Minute}},
+		expected: &metav1.Duration{Duration: 5 * time.Minute},
+	}, {
+		name: "no pipelinerun timeout",
+		pr: tb.PipelineRun("pipelinerun-timeouts", "foo",
+			tb.PipelineRunSpec("pipeline", tb.PipelineRunTimeout(0)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-40*time.Minute))),
+		),
+		pt:       &v1alpha1.PipelineTask{Name: "wait"},
+		expected: &metav1.Duration{Duration: 0},
+	}, {
+		name: "time left of the finally timeout",
+		pr: tb.PipelineRun("pipelinerun-timeouts", "foo",
+			tb.PipelineRunSpec("pipeline", tb.PipelineRunTimeout(time.Hour), tb.PipelineRunTimeouts(30*time.Minute, 10*time.Minute)),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-55*time.Minute)), tb.PipelineRunFinallyStartTime(time.Now().Add(-2*time.Minute))),
+		),
+		pt:       &v1alpha1.PipelineTask{Name: "wait"},
+		finally:  true,
+		expected: &metav1.Duration{Duration: 5 * time.Minute},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			// The time left of a timeout goes down as the test runs.
+			approx := cmp.Comparer(func(x, y metav1.Duration) bool {
+				d := x.Duration - y.Duration
+				return d < time.Second && d > -time.Second
+			})
+			if d := cmp.Diff(getRunTimeout(tc.pr, tc.pt, tc.finally), tc.expected, approx); d != "" {
+				t.Errorf("Unexpected run timeout. Diff %s", d)
+			}
+		})
+	}
+}
+
 func TestReconcileWithConditionChecks(t *testing.T) {
 	names.TestingSeed()
 	prName := "test-pipeline-run"
@@ -1310,7 +1758,7 @@ func TestReconcileWithConditionChecks(t *testing.T) {
 	)}
 	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -1418,7 +1866,7 @@ func TestReconcileWithFailingConditionChecks(t *testing.T) {
 			),
 		),
 	}
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -1488,7 +1936,7 @@ func makeExpectedTr(condName, ccName string) *v1alpha1.TaskRun {
 	)
 }
 
-func ensurePVCCreated(t *testing.T, clients test.Clients, name, namespace string) {
+func ensurePVCCreated(t *testing.T, clients reconcilertest.Clients, name, namespace string) {
 	t.Helper()
 	_, err := clients.Kube.CoreV1().PersistentVolumeClaims(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
@@ -1504,3 +1952,1074 @@ func ensurePVCCreated(t *testing.T, clients test.Clients, name, namespace string
 		t.Errorf("Expected to see volume resource PVC created but didn't")
 	}
 }
+
+func TestGetRequestedResources(t *testing.T) {
+	completed := func(cpu, memory int64) *v1alpha1.PipelineRunTaskRunStatus {
+		return &v1alpha1.PipelineRunTaskRunStatus{Status: &v1alpha1.TaskRunStatus{
+			TaskRunStatusFields: v1alpha1.TaskRunStatusFields{
+				RequestedResources: &v1alpha1.ResourceSeconds{CPUMilliSeconds: cpu, MemoryByteSeconds: memory},
+			},
+		}}
+	}
+	for _, tc := range []struct {
+		name     string
+		taskRuns map[string]*v1alpha1.PipelineRunTaskRunStatus
+		want     *v1alpha1.ResourceSeconds
+	}{{
+		name: "none completed",
+		taskRuns: map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"running":     {Status: &v1alpha1.TaskRunStatus{}},
+			"not-started": {},
+		},
+	}, {
+		name: "completed",
+		taskRuns: map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"build":   completed(60000, 1<<30),
+			"test":    completed(30000, 1<<20),
+			"running": {Status: &v1alpha1.TaskRunStatus{}},
+		},
+		want: &v1alpha1.ResourceSeconds{CPUMilliSeconds: 90000, MemoryByteSeconds: 1<<30 + 1<<20},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, getRequestedResources(tc.taskRuns)); d != "" {
+				t.Errorf("getRequestedResources() diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcileWithConfigValues(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineParamSpec("registry", v1alpha1.ParamTypeString, tb.ParamSpecDefault("$(config.registry)")),
+		tb.PipelineTask("hello-world-1", "hello-world",
+			tb.PipelineTaskParam("image", "$(params.registry)/app"),
+			tb.PipelineTaskParam("endpoint", "https://$(config.cluster-host)"),
+		),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(
+			tb.InputsParamSpec("image", v1alpha1.ParamTypeString),
+			tb.InputsParamSpec("endpoint", v1alpha1.ParamTypeString),
+		),
+	))}
+	for _, tc := range []struct {
+		name       string
+		configMaps []*corev1.ConfigMap
+		wantParams []v1alpha1.Param
+		wantReason string
+	}{{
+		name: "namespace overrides",
+		configMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.ConfigValuesConfigMapName, Namespace: system.GetNamespace()},
+			Data:       map[string]string{"registry": "gcr.io/shared", "cluster-host": "prod.example.com"},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: resources.ConfigValuesConfigMapName, Namespace: "foo"},
+			Data:       map[string]string{"cluster-host": "foo.example.com"},
+		}},
+		wantParams: []v1alpha1.Param{
+			{Name: "image", Value: *tb.ArrayOrString("gcr.io/shared/app")},
+			{Name: "endpoint", Value: *tb.ArrayOrString("https://foo.example.com")},
+		},
+	}, {
+		name: "missing value",
+		configMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.ConfigValuesConfigMapName, Namespace: system.GetNamespace()},
+			Data:       map[string]string{"registry": "gcr.io/shared"},
+		}},
+		wantReason: ReasonCouldntGetConfigValue,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline"),
+			)}
+			testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				// The config values are read through the ConfigMap informer,
+				// alongside the ConfigMaps the controller watches.
+				ConfigMaps: append([]*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: artifacts.GetBucketConfigName(), Namespace: system.GetNamespace()},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+				}}, tc.configMaps...),
+			})
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			trs, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Error listing TaskRuns: %v", err)
+			}
+			if tc.wantReason != "" {
+				condition := pr.Status.GetCondition(apis.ConditionSucceeded)
+				if !condition.IsFalse() || condition.Reason != tc.wantReason {
+					t.Errorf("Succeeded condition = %v, want False with reason %s", condition, tc.wantReason)
+				}
+				if len(trs.Items) != 0 {
+					t.Errorf("Expected no TaskRun to be created, got %d", len(trs.Items))
+				}
+				return
+			}
+			if len(trs.Items) != 1 {
+				t.Fatalf("Expected 1 TaskRun to be created, got %d", len(trs.Items))
+			}
+			if d := cmp.Diff(tc.wantParams, trs.Items[0].Spec.Inputs.Params); d != "" {
+				t.Errorf("TaskRun params diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcileWithRunNumber(t *testing.T) {
+	p := tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world",
+			tb.PipelineTaskParam("version", "1.0.$(context.pipelineRun.number)"),
+		),
+	))
+	p.Annotations = map[string]string{resources.LastRunNumberAnnotation: "41"}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParamSpec("version", v1alpha1.ParamTypeString)),
+	))}
+	embedded := tb.PipelineRun("test-pipeline-run-embedded", "foo")
+	embedded.Spec.PipelineSpec = p.Spec.DeepCopy()
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun("test-pipeline-run-1", "foo", tb.PipelineRunSpec("test-pipeline")),
+		tb.PipelineRun("test-pipeline-run-2", "foo", tb.PipelineRunSpec("test-pipeline")),
+		embedded,
+	}
+	testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+		PipelineRuns: prs,
+		Pipelines:    []*v1alpha1.Pipeline{p},
+		Tasks:        ts,
+	})
+	defer cancel()
+	c, clients := testAssets.Controller, testAssets.Clients
+
+	for _, tc := range []struct {
+		name        string
+		wantNumber  int64
+		wantVersion string
+	}{{
+		name:        "test-pipeline-run-1",
+		wantNumber:  42,
+		wantVersion: "1.0.42",
+	}, {
+		name:        "test-pipeline-run-2",
+		wantNumber:  43,
+		wantVersion: "1.0.43",
+	}, {
+		name: "test-pipeline-run-embedded",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/"+tc.name); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get(tc.name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			if pr.Status.Number != tc.wantNumber {
+				t.Errorf("Number = %d, want %d", pr.Status.Number, tc.wantNumber)
+			}
+			trs, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{
+				LabelSelector: pipeline.GroupName + pipeline.PipelineRunLabelKey + "=" + tc.name,
+			})
+			if err != nil {
+				t.Fatalf("Error listing TaskRuns: %v", err)
+			}
+			if tc.wantNumber == 0 {
+				// Only the PipelineRuns of a Pipeline of the cluster are numbered.
+				condition := pr.Status.GetCondition(apis.ConditionSucceeded)
+				if !condition.IsFalse() || condition.Reason != ReasonCouldntGetRunNumber {
+					t.Errorf("Succeeded condition = %v, want False with reason %s", condition, ReasonCouldntGetRunNumber)
+				}
+				if len(trs.Items) != 0 {
+					t.Errorf("Expected no TaskRun to be created, got %d", len(trs.Items))
+				}
+				return
+			}
+			if len(trs.Items) != 1 {
+				t.Fatalf("Expected 1 TaskRun to be created, got %d", len(trs.Items))
+			}
+			want := []v1alpha1.Param{{Name: "version", Value: *tb.ArrayOrString(tc.wantVersion)}}
+			if d := cmp.Diff(want, trs.Items[0].Spec.Inputs.Params); d != "" {
+				t.Errorf("TaskRun params diff -want, +got: %s", d)
+			}
+			p, err := clients.Pipeline.TektonV1alpha1().Pipelines("foo").Get("test-pipeline", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting Pipeline: %v", err)
+			}
+			if got, want := p.Annotations[resources.LastRunNumberAnnotation], strconv.FormatInt(tc.wantNumber, 10); got != want {
+				t.Errorf("Last run number = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestReconcileWithTaskResults(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("clone", "hello-world"),
+		tb.PipelineTask("build", "hello-world",
+			tb.PipelineTaskParam("commit", "$(tasks.clone.results.commit)"),
+		),
+		tb.PipelineTask("deploy", "hello-world",
+			tb.PipelineTaskWhenExpression("$(tasks.clone.results.branch)", selection.In, "main"),
+		),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParamSpec("commit", v1alpha1.ParamTypeString, tb.ParamSpecDefault(""))),
+		tb.TaskResult("commit", "The commit that was cloned"),
+		tb.TaskResult("branch", "The branch that was cloned"),
+	))}
+	for _, tc := range []struct {
+		name         string
+		results      []tb.TaskRunStatusOp
+		wantTaskRuns map[string][]v1alpha1.Param
+		wantReason   string
+	}{{
+		name:    "results passed",
+		results: []tb.TaskRunStatusOp{tb.TaskRunResult("commit", "abc123"), tb.TaskRunResult("branch", "main")},
+		wantTaskRuns: map[string][]v1alpha1.Param{
+			"build":  {{Name: "commit", Value: *tb.ArrayOrString("abc123")}},
+			"deploy": nil,
+		},
+	}, {
+		name:    "when expression over a result false",
+		results: []tb.TaskRunStatusOp{tb.TaskRunResult("commit", "abc123"), tb.TaskRunResult("branch", "feature")},
+		wantTaskRuns: map[string][]v1alpha1.Param{
+			"build": {{Name: "commit", Value: *tb.ArrayOrString("abc123")}},
+		},
+	}, {
+		name:       "result not reported",
+		results:    []tb.TaskRunStatusOp{tb.TaskRunResult("branch", "main")},
+		wantReason: ReasonInvalidTaskResultReference,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline"),
+				tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus("test-pipeline-run-clone", &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "clone",
+				})),
+			)}
+			trs := []*v1alpha1.TaskRun{tb.TaskRun("test-pipeline-run-clone", "foo",
+				tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "clone"),
+				tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+				tb.TaskRunStatus(append([]tb.TaskRunStatusOp{tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				})}, tc.results...)...),
+			)}
+			testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+			})
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			if tc.wantReason != "" {
+				condition := pr.Status.GetCondition(apis.ConditionSucceeded)
+				if !condition.IsFalse() || condition.Reason != tc.wantReason {
+					t.Errorf("Succeeded condition = %v, want False with reason %s", condition, tc.wantReason)
+				}
+			}
+			created, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{
+				LabelSelector: pipeline.GroupName + pipeline.PipelineRunLabelKey + "=test-pipeline-run",
+			})
+			if err != nil {
+				t.Fatalf("Error listing TaskRuns: %v", err)
+			}
+			got := map[string][]v1alpha1.Param{}
+			for _, tr := range created.Items {
+				got[tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey]] = tr.Spec.Inputs.Params
+			}
+			if tc.wantTaskRuns == nil {
+				tc.wantTaskRuns = map[string][]v1alpha1.Param{}
+			}
+			if d := cmp.Diff(tc.wantTaskRuns, got); d != "" {
+				t.Errorf("TaskRuns created diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcileWithChangeDetection(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("detect", "hello-world"),
+		tb.PipelineTask("build", "hello-world"),
+		tb.PipelineTask("test", "hello-world", tb.RunAfter("build")),
+		tb.PipelineChangeDetection("detect", "changed"),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskResult("changed", "Whether there are changes to build"),
+	))}
+	for _, tc := range []struct {
+		name         string
+		changed      string
+		wantTaskRuns []string
+		wantReason   string
+	}{{
+		name:         "changes",
+		changed:      "true",
+		wantTaskRuns: []string{"build"},
+		wantReason:   resources.ReasonRunning,
+	}, {
+		name:       "no changes",
+		changed:    "false",
+		wantReason: resources.ReasonNoChanges,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline"),
+				tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus("test-pipeline-run-detect", &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "detect",
+				})),
+			)}
+			trs := []*v1alpha1.TaskRun{tb.TaskRun("test-pipeline-run-detect", "foo",
+				tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "detect"),
+				tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+				tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}), tb.TaskRunResult("changed", tc.changed)),
+			)}
+			testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+			})
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			if condition := pr.Status.GetCondition(apis.ConditionSucceeded); condition.Reason != tc.wantReason {
+				t.Errorf("Succeeded condition = %v, want reason %s", condition, tc.wantReason)
+			}
+			created, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{
+				LabelSelector: pipeline.GroupName + pipeline.PipelineRunLabelKey + "=test-pipeline-run",
+			})
+			if err != nil {
+				t.Fatalf("Error listing TaskRuns: %v", err)
+			}
+			var got []string
+			for _, tr := range created.Items {
+				got = append(got, tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey])
+			}
+			if d := cmp.Diff(tc.wantTaskRuns, got); d != "" {
+				t.Errorf("TaskRuns created diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcileWithCustomTask(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("wait", "wait-10s", tb.PipelineTaskCustomTask("example.dev/v0", "Wait"),
+			tb.PipelineTaskParam("duration", "10s")),
+		tb.PipelineTask("build", "hello-world",
+			tb.PipelineTaskParam("digest", "$(tasks.wait.results.digest)")),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParamSpec("digest", v1alpha1.ParamTypeString)),
+	))}
+	for _, tc := range []struct {
+		name         string
+		runStatus    *v1alpha1.RunStatus
+		wantTaskRuns []string
+		wantReason   string
+	}{{
+		name:       "run created",
+		wantReason: resources.ReasonRunning,
+	}, {
+		name: "run running",
+		runStatus: &v1alpha1.RunStatus{Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		}}}},
+		wantReason: resources.ReasonRunning,
+	}, {
+		name: "run succeeded",
+		runStatus: &v1alpha1.RunStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}}},
+			Results: []v1alpha1.TaskRunResult{{Name: "digest", Value: "sha256:abc"}},
+		},
+		wantTaskRuns: []string{"build"},
+		wantReason:   resources.ReasonRunning,
+	}, {
+		name: "run failed",
+		runStatus: &v1alpha1.RunStatus{Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+		}}}},
+		wantReason: resources.ReasonFailed,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline"),
+				tb.PipelineRunStatus(tb.PipelineRunRunsStatus("test-pipeline-run-wait", &v1alpha1.PipelineRunRunStatus{
+					PipelineTaskName: "wait",
+				})),
+			)}
+			var runs []*v1alpha1.Run
+			if tc.runStatus != nil {
+				runs = append(runs, &v1alpha1.Run{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-wait", Namespace: "foo"},
+					Spec: v1alpha1.RunSpec{
+						Ref: &v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait", Name: "wait-10s"},
+					},
+					Status: *tc.runStatus,
+				})
+			}
+			testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				Runs:         runs,
+			})
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			if condition := pr.Status.GetCondition(apis.ConditionSucceeded); condition.Reason != tc.wantReason {
+				t.Errorf("Succeeded condition = %v, want reason %s", condition, tc.wantReason)
+			}
+			if prrs, ok := pr.Status.Runs["test-pipeline-run-wait"]; !ok || prrs.PipelineTaskName != "wait" {
+				t.Errorf("Status of the Run = %v, want the status of PipelineTask wait", pr.Status.Runs)
+			}
+
+			run, err := clients.Pipeline.TektonV1alpha1().Runs("foo").Get("test-pipeline-run-wait", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting Run: %v", err)
+			}
+			if tc.runStatus == nil {
+				wantSpec := v1alpha1.RunSpec{
+					Ref:    &v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait", Name: "wait-10s"},
+					Params: []v1alpha1.Param{{Name: "duration", Value: *tb.ArrayOrString("10s")}},
+				}
+				if d := cmp.Diff(wantSpec, run.Spec, cmpopts.IgnoreFields(v1alpha1.RunSpec{}, "Timeout")); d != "" {
+					t.Errorf("Run spec diff -want, +got: %s", d)
+				}
+				if run.Spec.Timeout == nil || run.Spec.Timeout.Duration > prs[0].Spec.Timeout.Duration {
+					t.Errorf("Run timeout %v should be less than or equal to PipelineRun timeout %s", run.Spec.Timeout, prs[0].Spec.Timeout.Duration)
+				}
+				if got := run.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey]; got != "wait" {
+					t.Errorf("Run label %s = %q, want wait", pipeline.PipelineTaskLabelKey, got)
+				}
+			}
+
+			created, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{
+				LabelSelector: pipeline.GroupName + pipeline.PipelineRunLabelKey + "=test-pipeline-run",
+			})
+			if err != nil {
+				t.Fatalf("Error listing TaskRuns: %v", err)
+			}
+			var got []string
+			for _, tr := range created.Items {
+				got = append(got, tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey])
+				if d := cmp.Diff([]v1alpha1.Param{{Name: "digest", Value: *tb.ArrayOrString("sha256:abc")}}, tr.Spec.Inputs.Params); d != "" {
+					t.Errorf("TaskRun params diff -want, +got: %s", d)
+				}
+			}
+			if d := cmp.Diff(tc.wantTaskRuns, got); d != "" {
+				t.Errorf("TaskRuns created diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcileWithCustomTaskRetries(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("wait", "wait-10s", tb.PipelineTaskCustomTask("example.dev/v0", "Wait"), tb.Retries(1)),
+	))}
+	failed := v1alpha1.RunStatus{Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionFalse,
+	}}}}
+	for _, tc := range []struct {
+		name          string
+		retriesStatus []v1alpha1.RunStatus
+		wantRunStatus corev1.ConditionStatus
+		wantRetries   int
+		wantReason    string
+	}{{
+		name:          "run retried",
+		wantRunStatus: corev1.ConditionUnknown,
+		wantRetries:   1,
+		wantReason:    resources.ReasonRunning,
+	}, {
+		name:          "run failed after its retries",
+		retriesStatus: []v1alpha1.RunStatus{failed},
+		wantRunStatus: corev1.ConditionFalse,
+		wantRetries:   1,
+		wantReason:    resources.ReasonFailed,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline"),
+				tb.PipelineRunStatus(tb.PipelineRunRunsStatus("test-pipeline-run-wait", &v1alpha1.PipelineRunRunStatus{
+					PipelineTaskName: "wait",
+				})),
+			)}
+			status := *failed.DeepCopy()
+			status.RetriesStatus = tc.retriesStatus
+			runs := []*v1alpha1.Run{{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-wait", Namespace: "foo"},
+				Spec: v1alpha1.RunSpec{
+					Ref: &v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait", Name: "wait-10s"},
+				},
+				Status: status,
+			}}
+			testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Runs:         runs,
+			})
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			if condition := pr.Status.GetCondition(apis.ConditionSucceeded); condition.Reason != tc.wantReason {
+				t.Errorf("Succeeded condition = %v, want reason %s", condition, tc.wantReason)
+			}
+			run, err := clients.Pipeline.TektonV1alpha1().Runs("foo").Get("test-pipeline-run-wait", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting Run: %v", err)
+			}
+			if condition := run.Status.GetCondition(apis.ConditionSucceeded); condition.Status != tc.wantRunStatus {
+				t.Errorf("Run succeeded condition = %v, want status %s", condition, tc.wantRunStatus)
+			}
+			if len(run.Status.RetriesStatus) != tc.wantRetries {
+				t.Errorf("Run has %d retries, want %d", len(run.Status.RetriesStatus), tc.wantRetries)
+			}
+		})
+	}
+}
+
+func TestReconcileWithTimeoutCancelsRuns(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("wait", "wait-10s", tb.PipelineTaskCustomTask("example.dev/v0", "Wait")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunTimeout(12*time.Hour)),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(time.Now().AddDate(0, 0, -1)),
+			tb.PipelineRunRunsStatus("test-pipeline-run-wait", &v1alpha1.PipelineRunRunStatus{
+				PipelineTaskName: "wait",
+			}),
+		),
+	)}
+	runs := []*v1alpha1.Run{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-wait", Namespace: "foo"},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait", Name: "wait-10s"},
+		},
+		Status: v1alpha1.RunStatus{Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		}}}},
+	}}
+	testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Runs:         runs,
+	})
+	defer cancel()
+	c, clients := testAssets.Controller, testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+		t.Fatalf("Reconcile() = %v", err)
+	}
+	run, err := clients.Pipeline.TektonV1alpha1().Runs("foo").Get("test-pipeline-run-wait", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting Run: %v", err)
+	}
+	if !run.IsCancelled() {
+		t.Errorf("Expected Run to be cancelled, spec status was %q", run.Spec.Status)
+	}
+	// The PipelineRun times out once the controller of the custom task
+	// stopped the Run, or the grace period is over.
+	pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting PipelineRun: %v", err)
+	}
+	if condition := pr.Status.GetCondition(apis.ConditionSucceeded); !condition.IsUnknown() || condition.Reason != ReasonCancelling {
+		t.Errorf("Succeeded condition = %v, want Unknown with reason %s", condition, ReasonCancelling)
+	}
+}
+
+func TestReconcileWithWorkspaces(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineWorkspaceDeclaration("source"),
+		tb.PipelineTask("build", "hello-world",
+			tb.PipelineTaskWorkspaceBinding("src", "source"),
+		),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskWorkspace("src", "", "", false),
+	))}
+	for _, tc := range []struct {
+		name           string
+		ops            []tb.PipelineRunSpecOp
+		wantWorkspaces []v1alpha1.WorkspaceBinding
+		wantReason     string
+	}{{
+		name: "workspace passed to the TaskRun",
+		ops:  []tb.PipelineRunSpecOp{tb.PipelineRunWorkspaceBindingClaim("source", "pvc", "src")},
+		wantWorkspaces: []v1alpha1.WorkspaceBinding{{
+			Name:                  "src",
+			SubPath:               "src",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+		}},
+	}, {
+		name:       "workspace not bound",
+		wantReason: ReasonInvalidWorkspaceBindings,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline", tc.ops...),
+			)}
+			testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+			})
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			if tc.wantReason != "" {
+				condition := pr.Status.GetCondition(apis.ConditionSucceeded)
+				if !condition.IsFalse() || condition.Reason != tc.wantReason {
+					t.Errorf("Succeeded condition = %v, want False with reason %s", condition, tc.wantReason)
+				}
+				return
+			}
+			created, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Error listing TaskRuns: %v", err)
+			}
+			if len(created.Items) != 1 {
+				t.Fatalf("Expected 1 TaskRun to be created, got %d", len(created.Items))
+			}
+			if d := cmp.Diff(tc.wantWorkspaces, created.Items[0].Spec.Workspaces); d != "" {
+				t.Errorf("TaskRun workspaces diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcileWithFinallyTasks(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "hello-world"),
+		tb.PipelineTask("test", "hello-world", tb.RunAfter("build")),
+		tb.FinallyTask("cleanup", "hello-world"),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec())}
+	for _, tc := range []struct {
+		name         string
+		stopped      bool
+		taskRuns     map[string]corev1.ConditionStatus
+		wantTaskRuns []string
+		wantStatus   corev1.ConditionStatus
+	}{{
+		name:         "tasks running",
+		taskRuns:     map[string]corev1.ConditionStatus{"build": corev1.ConditionUnknown},
+		wantTaskRuns: []string{"build"},
+		wantStatus:   corev1.ConditionUnknown,
+	}, {
+		name:         "tasks succeeded",
+		taskRuns:     map[string]corev1.ConditionStatus{"build": corev1.ConditionTrue, "test": corev1.ConditionTrue},
+		wantTaskRuns: []string{"build", "cleanup", "test"},
+		wantStatus:   corev1.ConditionUnknown,
+	}, {
+		name:         "task failed",
+		taskRuns:     map[string]corev1.ConditionStatus{"build": corev1.ConditionFalse},
+		wantTaskRuns: []string{"build", "cleanup"},
+		wantStatus:   corev1.ConditionUnknown,
+	}, {
+		name:         "finally task succeeded after a failure",
+		taskRuns:     map[string]corev1.ConditionStatus{"build": corev1.ConditionFalse, "cleanup": corev1.ConditionTrue},
+		wantTaskRuns: []string{"build", "cleanup"},
+		wantStatus:   corev1.ConditionFalse,
+	}, {
+		name:         "finally task failed",
+		taskRuns:     map[string]corev1.ConditionStatus{"build": corev1.ConditionTrue, "test": corev1.ConditionTrue, "cleanup": corev1.ConditionFalse},
+		wantTaskRuns: []string{"build", "cleanup", "test"},
+		wantStatus:   corev1.ConditionFalse,
+	}, {
+		name:         "tasks and finally task succeeded",
+		taskRuns:     map[string]corev1.ConditionStatus{"build": corev1.ConditionTrue, "test": corev1.ConditionTrue, "cleanup": corev1.ConditionTrue},
+		wantTaskRuns: []string{"build", "cleanup", "test"},
+		wantStatus:   corev1.ConditionTrue,
+	}, {
+		name:         "stopped while a task runs",
+		stopped:      true,
+		taskRuns:     map[string]corev1.ConditionStatus{"build": corev1.ConditionUnknown},
+		wantTaskRuns: []string{"build"},
+		wantStatus:   corev1.ConditionUnknown,
+	}, {
+		name:         "stopped after a task succeeded",
+		stopped:      true,
+		taskRuns:     map[string]corev1.ConditionStatus{"build": corev1.ConditionTrue},
+		wantTaskRuns: []string{"build", "cleanup"},
+		wantStatus:   corev1.ConditionUnknown,
+	}, {
+		name:         "finally task succeeded after stopping",
+		stopped:      true,
+		taskRuns:     map[string]corev1.ConditionStatus{"build": corev1.ConditionTrue, "cleanup": corev1.ConditionTrue},
+		wantTaskRuns: []string{"build", "cleanup"},
+		wantStatus:   corev1.ConditionFalse,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var statusOps []tb.PipelineRunStatusOp
+			var trs []*v1alpha1.TaskRun
+			for name, status := range tc.taskRuns {
+				trName := "test-pipeline-run-" + name
+				statusOps = append(statusOps, tb.PipelineRunTaskRunsStatus(trName, &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: name,
+				}))
+				trs = append(trs, tb.TaskRun(trName, "foo",
+					tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run"),
+					tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, name),
+					tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+					tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+						Type:   apis.ConditionSucceeded,
+						Status: status,
+					})),
+				))
+			}
+			var specOps []tb.PipelineRunSpecOp
+			if tc.stopped {
+				specOps = append(specOps, tb.PipelineRunStoppedRunFinally)
+			}
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline", specOps...),
+				tb.PipelineRunStatus(statusOps...),
+			)}
+			testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+			})
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			if condition := pr.Status.GetCondition(apis.ConditionSucceeded); condition == nil || condition.Status != tc.wantStatus {
+				t.Errorf("Succeeded condition = %v, want status %s", condition, tc.wantStatus)
+			}
+			created, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{
+				LabelSelector: pipeline.GroupName + pipeline.PipelineRunLabelKey + "=test-pipeline-run",
+			})
+			if err != nil {
+				t.Fatalf("Error listing TaskRuns: %v", err)
+			}
+			var got []string
+			for _, tr := range created.Items {
+				got = append(got, tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey])
+			}
+			sort.Strings(got)
+			if d := cmp.Diff(tc.wantTaskRuns, got); d != "" {
+				t.Errorf("TaskRuns diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcileWithMatrix(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "hello-world",
+			tb.PipelineTaskMatrix("platform", "linux", "mac"),
+		),
+		tb.PipelineTask("release", "hello-world", tb.RunAfter("build")),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParamSpec("platform", v1alpha1.ParamTypeString, tb.ParamSpecDefault(""))),
+	))}
+	for _, tc := range []struct {
+		name         string
+		builds       map[string]corev1.ConditionStatus
+		wantTaskRuns []string
+	}{{
+		name:         "combinations started",
+		wantTaskRuns: []string{"build platform=linux", "build platform=mac"},
+	}, {
+		name:         "combination running",
+		builds:       map[string]corev1.ConditionStatus{"linux": corev1.ConditionTrue, "mac": corev1.ConditionUnknown},
+		wantTaskRuns: []string{"build platform=linux", "build platform=mac"},
+	}, {
+		name:         "combinations succeeded",
+		builds:       map[string]corev1.ConditionStatus{"linux": corev1.ConditionTrue, "mac": corev1.ConditionTrue},
+		wantTaskRuns: []string{"build platform=linux", "build platform=mac", "release"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var statusOps []tb.PipelineRunStatusOp
+			var trs []*v1alpha1.TaskRun
+			for platform, status := range tc.builds {
+				trName := "test-pipeline-run-build-" + platform
+				statusOps = append(statusOps, tb.PipelineRunTaskRunsStatus(trName, &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: "build",
+					Matrix:           []v1alpha1.Param{{Name: "platform", Value: *tb.ArrayOrString(platform)}},
+				}))
+				trs = append(trs, tb.TaskRun(trName, "foo",
+					tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineRunLabelKey, "test-pipeline-run"),
+					tb.TaskRunLabel(pipeline.GroupName+pipeline.PipelineTaskLabelKey, "build"),
+					tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world"), tb.TaskRunInputs(tb.TaskRunInputsParam("platform", platform))),
+					tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+						Type:   apis.ConditionSucceeded,
+						Status: status,
+					})),
+				))
+			}
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline"),
+				tb.PipelineRunStatus(statusOps...),
+			)}
+			testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+			})
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting PipelineRun: %v", err)
+			}
+			created, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{
+				LabelSelector: pipeline.GroupName + pipeline.PipelineRunLabelKey + "=test-pipeline-run",
+			})
+			if err != nil {
+				t.Fatalf("Error listing TaskRuns: %v", err)
+			}
+			var got []string
+			for _, tr := range created.Items {
+				desc := tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey]
+				for _, p := range tr.Spec.Inputs.Params {
+					desc += fmt.Sprintf(" %s=%s", p.Name, p.Value.StringVal)
+				}
+				got = append(got, desc)
+				if prtrs, ok := pr.Status.TaskRuns[tr.Name]; !ok || !cmp.Equal(prtrs.Matrix, tr.Spec.Inputs.Params) {
+					t.Errorf("TaskRun %s status = %v, want its params %v as matrix", tr.Name, prtrs, tr.Spec.Inputs.Params)
+				}
+			}
+			sort.Strings(got)
+			if d := cmp.Diff(tc.wantTaskRuns, got); d != "" {
+				t.Errorf("TaskRuns diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcileWithMatrix_ArrayParamExceedsMaximum(t *testing.T) {
+	// The validation of the Pipeline counts $(params.platforms) as a single
+	// combination: the three platforms are only known once it is substituted.
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineParamSpec("platforms", v1alpha1.ParamTypeArray),
+		tb.PipelineTask("build", "hello-world",
+			tb.PipelineTaskMatrix("platform", "$(params.platforms)"),
+		),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParamSpec("platform", v1alpha1.ParamTypeString, tb.ParamSpecDefault(""))),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunParam("platforms", "linux", "mac", "windows")),
+	)}
+	testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: artifacts.GetBucketConfigName(), Namespace: system.GetNamespace()},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+			Data:       map[string]string{"maximum-matrix-combinations": "2"},
+		}},
+	})
+	defer cancel()
+	c, clients := testAssets.Controller, testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+		t.Fatalf("Reconcile() = %v", err)
+	}
+	pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting PipelineRun: %v", err)
+	}
+	if condition := pr.Status.GetCondition(apis.ConditionSucceeded); !condition.IsFalse() || condition.Reason != ReasonFailedValidation {
+		t.Errorf("Succeeded condition = %v, want False with reason %s", condition, ReasonFailedValidation)
+	}
+	trs, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Error listing TaskRuns: %v", err)
+	}
+	if len(trs.Items) != 0 {
+		t.Errorf("Expected no TaskRun to be created, got %d", len(trs.Items))
+	}
+}
+
+func TestReconcile_RemotePipelineAndTasks(t *testing.T) {
+	pr := tb.PipelineRun("test-pipeline-run-remote", "foo", tb.PipelineRunSpec(""))
+	pr.Spec.PipelineRef.ResolverRef = v1alpha1.ResolverRef{
+		Resolver: "git",
+		Params: []v1alpha1.Param{
+			{Name: "url", Value: *tb.ArrayOrString("https://github.com/tektoncd/catalog")},
+			{Name: "path", Value: *tb.ArrayOrString("pipeline/build.yaml")},
+		},
+	}
+	resolved := map[string]string{
+		"pipeline/build.yaml": `apiVersion: tekton.dev/v1alpha1
+kind: Pipeline
+metadata:
+  name: build
+spec:
+  tasks:
+  - name: echo
+    taskRef:
+      resolver: git
+      params:
+      - name: url
+        value: https://github.com/tektoncd/catalog
+      - name: path
+        value: task/echo.yaml
+`,
+		"task/echo.yaml": `apiVersion: tekton.dev/v1alpha1
+kind: Task
+metadata:
+  name: echo
+spec:
+  steps:
+  - name: echo
+    image: foo
+    command: [/mycmd]
+`,
+	}
+	resolve := func(rr *v1alpha1.ResolutionRequest) *v1alpha1.ResolutionRequest {
+		rr = rr.DeepCopy()
+		rr.Status.Data = resolved[rr.Spec.ParamsMap()["path"]]
+		rr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+		return rr
+	}
+	// reconcile reconciles the PipelineRun with the ResolutionRequests rrs,
+	// and returns its condition and the ResolutionRequests by path.
+	reconcile := func(rrs ...*v1alpha1.ResolutionRequest) (reconcilertest.Clients, *apis.Condition, map[string]*v1alpha1.ResolutionRequest) {
+		t.Helper()
+		testAssets, cancel := getPipelineRunController(t, reconcilertest.Data{
+			PipelineRuns:       []*v1alpha1.PipelineRun{pr},
+			ResolutionRequests: rrs,
+		})
+		defer cancel()
+		clients := testAssets.Clients
+		if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/"+pr.Name); err != nil {
+			t.Fatalf("Unexpected error when reconciling PipelineRun: %v", err)
+		}
+		reconciled, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get(pr.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Error getting PipelineRun: %v", err)
+		}
+		list, err := clients.Pipeline.TektonV1alpha1().ResolutionRequests("foo").List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		byPath := map[string]*v1alpha1.ResolutionRequest{}
+		for i := range list.Items {
+			rr := &list.Items[i]
+			if owner := metav1.GetControllerOf(rr); owner == nil || owner.Kind != "PipelineRun" || owner.Name != pr.Name {
+				t.Errorf("Expected ResolutionRequest %s to be owned by the PipelineRun, got %v", rr.Name, owner)
+			}
+			byPath[rr.Spec.ParamsMap()["path"]] = rr
+		}
+		return clients, reconciled.Status.GetCondition(apis.ConditionSucceeded), byPath
+	}
+	_, condition, requests := reconcile()
+	if !condition.IsUnknown() || condition.Reason != ReasonResolvingPipelineRef {
+		t.Errorf("Expected PipelineRun to be resolving its Pipeline, but condition is %v", condition)
+	}
+	pipelineRequest, ok := requests["pipeline/build.yaml"]
+	if !ok || len(requests) != 1 {
+		t.Fatalf("Expected the Pipeline to be requested, got %v", requests)
+	}
+
+	_, condition, requests = reconcile(resolve(pipelineRequest))
+	if !condition.IsUnknown() || condition.Reason != ReasonResolvingTaskRef {
+		t.Errorf("Expected PipelineRun to be resolving its Tasks, but condition is %v", condition)
+	}
+	taskRequest, ok := requests["task/echo.yaml"]
+	if !ok {
+		t.Fatalf("Expected the Task to be requested, got %v", requests)
+	}
+
+	clients, _, _ := reconcile(resolve(pipelineRequest), resolve(taskRequest))
+	trs, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trs.Items) != 1 {
+		t.Fatalf("Expected a TaskRun to be created, got %d", len(trs.Items))
+	}
+	tr := trs.Items[0]
+	if tr.Spec.TaskRef != nil || tr.Spec.TaskSpec == nil || len(tr.Spec.TaskSpec.Steps) != 1 || tr.Spec.TaskSpec.Steps[0].Name != "echo" {
+		t.Errorf("Expected the TaskRun to embed the resolved Task, got %+v", tr.Spec)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/resolution"
+)
+
+// getRemotePipeline returns the GetPipeline fetching the Pipeline of the
+// pipelineRef of pr with its resolver, through a ResolutionRequest owned by
+// pr.
+func (c *Reconciler) getRemotePipeline(ctx context.Context, pr *v1alpha1.PipelineRun) resources.GetPipeline {
+	return func(string) (v1alpha1.PipelineInterface, error) {
+		data, err := c.requester.Request(pr, pr.GetOwnerReference(), pr.Spec.PipelineRef.ResolverRef)
+		if err != nil {
+			return nil, err
+		}
+		return resolution.Pipeline(ctx, data)
+	}
+}
+
+// getRemoteTask returns the GetRemoteTask fetching the Tasks of the
+// PipelineTasks of pr whose taskRef has a resolver. The PipelineTasks
+// referencing the same Task share a ResolutionRequest.
+func (c *Reconciler) getRemoteTask(ctx context.Context, pr *v1alpha1.PipelineRun) resources.GetRemoteTask {
+	return func(ref v1alpha1.ResolverRef) (v1alpha1.TaskInterface, error) {
+		data, err := c.requester.Request(pr, pr.GetOwnerReference(), ref)
+		if err != nil {
+			return nil, err
+		}
+		return resolution.Task(ctx, data, pr.Namespace)
+	}
+}
@@ -18,8 +18,10 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/selection"
 )
 
 // ApplyParameters applies the params from a PipelineRun.Params to a PipelineSpec.
@@ -34,20 +36,12 @@ func ApplyParameters(p *v1alpha1.PipelineSpec, pr *v1alpha1.PipelineRun) *v1alph
 	// Set all the default stringReplacements
 	for _, p := range p.Params {
 		if p.Default != nil {
-			if p.Default.Type == v1alpha1.ParamTypeString {
-				stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.StringVal
-			} else {
-				arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default.ArrayVal
-			}
+			p.Default.AddReplacements(fmt.Sprintf("params.%s", p.Name), stringReplacements, arrayReplacements)
 		}
 	}
 	// Set and overwrite params with the ones from the PipelineRun
 	for _, p := range pr.Spec.Params {
-		if p.Value.Type == v1alpha1.ParamTypeString {
-			stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.StringVal
-		} else {
-			arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.ArrayVal
-		}
+		p.Value.AddReplacements(fmt.Sprintf("params.%s", p.Name), stringReplacements, arrayReplacements)
 	}
 
 	return ApplyReplacements(p, stringReplacements, arrayReplacements)
@@ -57,22 +51,147 @@ func ApplyParameters(p *v1alpha1.PipelineSpec, pr *v1alpha1.PipelineRun) *v1alph
 func ApplyReplacements(p *v1alpha1.PipelineSpec, replacements map[string]string, arrayReplacements map[string][]string) *v1alpha1.PipelineSpec {
 	p = p.DeepCopy()
 
-	tasks := p.Tasks
-
-	for i := range tasks {
-		tasks[i].Params = replaceParamValues(tasks[i].Params, replacements, arrayReplacements)
-		for j := range tasks[i].Conditions {
-			c := tasks[i].Conditions[j]
-			c.Params = replaceParamValues(c.Params, replacements, arrayReplacements)
+	for _, tasks := range [][]v1alpha1.PipelineTask{p.Tasks, p.Finally} {
+		for i := range tasks {
+			tasks[i].Params = replaceParamValues(tasks[i].Params, replacements, arrayReplacements)
+			tasks[i].Matrix = replaceParamValues(tasks[i].Matrix, replacements, arrayReplacements)
+			for j := range tasks[i].Conditions {
+				c := tasks[i].Conditions[j]
+				c.Params = replaceParamValues(c.Params, replacements, arrayReplacements)
+			}
+			tasks[i].WhenExpressions.ApplyReplacements(replacements)
 		}
 	}
 
 	return p
 }
 
+// ApplyChangeDetection makes the PipelineTasks of p, other than its change
+// detection task, run after it and only when it doesn't report "false" as its
+// result. The finally tasks run either way.
+func ApplyChangeDetection(p *v1alpha1.PipelineSpec) *v1alpha1.PipelineSpec {
+	if p.ChangeDetection == nil {
+		return p
+	}
+	p = p.DeepCopy()
+	when := v1alpha1.WhenExpression{
+		Input:    fmt.Sprintf("$(tasks.%s.results.%s)", p.ChangeDetection.Task, p.ChangeDetection.Result),
+		Operator: selection.NotIn,
+		Values:   []string{"false"},
+	}
+	for i := range p.Tasks {
+		if p.Tasks[i].Name != p.ChangeDetection.Task {
+			p.Tasks[i].WhenExpressions = append(p.Tasks[i].WhenExpressions, when)
+		}
+	}
+	return p
+}
+
 func replaceParamValues(params []v1alpha1.Param, stringReplacements map[string]string, arrayReplacements map[string][]string) []v1alpha1.Param {
 	for i := range params {
 		params[i].Value.ApplyReplacements(stringReplacements, arrayReplacements)
 	}
 	return params
 }
+
+// ApplyTaskResults replaces the $(tasks.<name>.results.<result>) variables in
+// the params and WhenExpressions of the PipelineTasks of state that haven't
+// started with the results of the TaskRuns and Runs that succeeded. It returns
+// an error if one of those didn't report a result that is used.
+func ApplyTaskResults(state PipelineRunState) error {
+	results := map[string][]v1alpha1.TaskRunResult{}
+	for _, rprt := range state {
+		if rprt.IsSuccessful() {
+			results[rprt.PipelineTask.Name] = rprt.results()
+		}
+	}
+	for _, rprt := range state {
+		if rprt.TaskRun != nil || rprt.Run != nil {
+			continue
+		}
+		replacements := map[string]string{}
+		for _, ref := range rprt.PipelineTask.ResultRefs() {
+			taskResults, ok := results[ref.PipelineTask]
+			if !ok {
+				// Not run yet, the PipelineTask can't be scheduled either.
+				continue
+			}
+			value, found := findTaskResult(taskResults, ref.Result)
+			if !found {
+				return fmt.Errorf("PipelineTask %s uses the result %s of PipelineTask %s, which its TaskRun didn't report", rprt.PipelineTask.Name, ref.Result, ref.PipelineTask)
+			}
+			replacements[ref.Variable()] = value
+		}
+		if len(replacements) > 0 {
+			pt := rprt.PipelineTask.DeepCopy()
+			pt.Params = replaceParamValues(pt.Params, replacements, map[string][]string{})
+			pt.WhenExpressions.ApplyReplacements(replacements)
+			rprt.PipelineTask = pt
+		}
+	}
+	return nil
+}
+
+func findTaskResult(results []v1alpha1.TaskRunResult, name string) (string, bool) {
+	for _, r := range results {
+		if r.Name == name {
+			return r.Value, true
+		}
+	}
+	return "", false
+}
+
+// ApplyResourceResults sets the digest of the image input resources of the
+// PipelineTasks of state that haven't started to the digest reported by the
+// TaskRun they come from, so that $(resources.inputs.<name>.digest) refers to
+// the image that TaskRun built.
+func ApplyResourceResults(state PipelineRunState) {
+	digests := map[string]map[string]string{}
+	for _, rprt := range state {
+		if rprt.TaskRun == nil || !rprt.IsSuccessful() {
+			continue
+		}
+		for _, r := range rprt.TaskRun.Status.ResourcesResult {
+			if r.Key != "digest" || r.ResourceRef.Name == "" {
+				continue
+			}
+			if digests[rprt.PipelineTask.Name] == nil {
+				digests[rprt.PipelineTask.Name] = map[string]string{}
+			}
+			digests[rprt.PipelineTask.Name][r.ResourceRef.Name] = r.Value
+		}
+	}
+	for _, rprt := range state {
+		if rprt.TaskRun != nil || rprt.ResolvedTaskResources == nil || rprt.PipelineTask.Resources == nil {
+			continue
+		}
+		for _, input := range rprt.PipelineTask.Resources.Inputs {
+			resource, ok := rprt.ResolvedTaskResources.Inputs[input.Name]
+			if !ok || resource.Spec.Type != v1alpha1.PipelineResourceTypeImage {
+				continue
+			}
+			for _, from := range input.From {
+				digest, ok := digests[from][resource.Name]
+				if !ok {
+					continue
+				}
+				resource = resource.DeepCopy()
+				resource.Spec.Params = setResourceParam(resource.Spec.Params, "digest", digest)
+				// The resource is passed by spec from now on, see GetInputSteps.
+				resource.SelfLink = ""
+				rprt.ResolvedTaskResources.Inputs[input.Name] = resource
+				break
+			}
+		}
+	}
+}
+
+func setResourceParam(params []v1alpha1.ResourceParam, name, value string) []v1alpha1.ResourceParam {
+	for i := range params {
+		if strings.EqualFold(params[i].Name, name) {
+			params[i].Value = value
+			return params
+		}
+	}
+	return append(params, v1alpha1.ResourceParam{Name: name, Value: value})
+}
@@ -22,7 +22,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/apis"
 )
 
 func TestApplyParameters(t *testing.T) {
@@ -54,6 +58,41 @@ func TestApplyParameters(t *testing.T) {
 					tb.PipelineTaskParam("first-task-second-param", "second-value"),
 					tb.PipelineTaskParam("first-task-third-param", "static value"),
 				))),
+	}, {
+		name: "parameter of a finally task",
+		original: tb.Pipeline("test-pipeline", "foo",
+			tb.PipelineSpec(
+				tb.PipelineParamSpec("channel", v1alpha1.ParamTypeString, tb.ParamSpecDefault("builds")),
+				tb.PipelineTask("build", "build-task"),
+				tb.FinallyTask("notify", "notify-task",
+					tb.PipelineTaskParam("channel", "$(params.channel)"),
+				))),
+		run: tb.PipelineRun("test-pipeline-run", "foo",
+			tb.PipelineRunSpec("test-pipeline")),
+		expected: tb.Pipeline("test-pipeline", "foo",
+			tb.PipelineSpec(
+				tb.PipelineParamSpec("channel", v1alpha1.ParamTypeString, tb.ParamSpecDefault("builds")),
+				tb.PipelineTask("build", "build-task"),
+				tb.FinallyTask("notify", "notify-task",
+					tb.PipelineTaskParam("channel", "builds"),
+				))),
+	}, {
+		name: "array parameter in a matrix",
+		original: tb.Pipeline("test-pipeline", "foo",
+			tb.PipelineSpec(
+				tb.PipelineParamSpec("platforms", v1alpha1.ParamTypeArray),
+				tb.PipelineTask("build", "build-task",
+					tb.PipelineTaskMatrix("platform", "$(params.platforms)", "windows"),
+				))),
+		run: tb.PipelineRun("test-pipeline-run", "foo",
+			tb.PipelineRunSpec("test-pipeline",
+				tb.PipelineRunParam("platforms", "linux", "mac"))),
+		expected: tb.Pipeline("test-pipeline", "foo",
+			tb.PipelineSpec(
+				tb.PipelineParamSpec("platforms", v1alpha1.ParamTypeArray),
+				tb.PipelineTask("build", "build-task",
+					tb.PipelineTaskMatrix("platform", "linux", "mac", "windows"),
+				))),
 	}, {
 		name: "pipeline parameter nested inside task parameter",
 		original: tb.Pipeline("test-pipeline", "foo",
@@ -99,6 +138,25 @@ func TestApplyParameters(t *testing.T) {
 						tb.PipelineTaskConditionParam("cond-second-param", "second-value"),
 					),
 				))),
+	}, {
+		name: "parameters in when expressions",
+		original: tb.Pipeline("test-pipeline", "foo",
+			tb.PipelineSpec(
+				tb.PipelineParamSpec("first-param", v1alpha1.ParamTypeString, tb.ParamSpecDefault("default-value")),
+				tb.PipelineParamSpec("second-param", v1alpha1.ParamTypeString),
+				tb.PipelineTask("first-task-1", "first-task",
+					tb.PipelineTaskWhenExpression("$(params.first-param)", selection.In, "$(params.second-param)", "static value"),
+				))),
+		run: tb.PipelineRun("test-pipeline-run", "foo",
+			tb.PipelineRunSpec("test-pipeline",
+				tb.PipelineRunParam("second-param", "second-value"))),
+		expected: tb.Pipeline("test-pipeline", "foo",
+			tb.PipelineSpec(
+				tb.PipelineParamSpec("first-param", v1alpha1.ParamTypeString, tb.ParamSpecDefault("default-value")),
+				tb.PipelineParamSpec("second-param", v1alpha1.ParamTypeString),
+				tb.PipelineTask("first-task-1", "first-task",
+					tb.PipelineTaskWhenExpression("default-value", selection.In, "second-value", "static value"),
+				))),
 	}, {
 		name: "array parameter",
 		original: tb.Pipeline("test-pipeline", "foo",
@@ -139,3 +197,169 @@ func TestApplyParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyChangeDetection(t *testing.T) {
+	tcs := []struct {
+		name     string
+		original *v1alpha1.Pipeline
+		expected *v1alpha1.Pipeline
+	}{{
+		name: "no change detection",
+		original: tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"))),
+		expected: tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"))),
+	}, {
+		name: "change detection",
+		original: tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineChangeDetection("detect", "changed"),
+			tb.PipelineTask("detect", "detect-task"),
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskWhenExpression("$(params.branch)", selection.In, "main")),
+			tb.FinallyTask("notify", "notify-task"))),
+		expected: tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineChangeDetection("detect", "changed"),
+			tb.PipelineTask("detect", "detect-task"),
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskWhenExpression("$(params.branch)", selection.In, "main"),
+				tb.PipelineTaskWhenExpression("$(tasks.detect.results.changed)", selection.NotIn, "false")),
+			tb.FinallyTask("notify", "notify-task"))),
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ApplyChangeDetection(&tc.original.Spec)
+			if d := cmp.Diff(&tc.expected.Spec, got); d != "" {
+				t.Errorf("ApplyChangeDetection() -want, +got: %v", d)
+			}
+		})
+	}
+}
+
+func TestApplyTaskResults(t *testing.T) {
+	succeeded := func(name string, ops ...tb.TaskRunStatusOp) *v1alpha1.TaskRun {
+		return tb.TaskRun(name, "foo", tb.TaskRunStatus(append([]tb.TaskRunStatusOp{tb.StatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionTrue,
+		})}, ops...)...))
+	}
+	pipelineTask := func(name, taskName string, ops ...tb.PipelineTaskOp) *v1alpha1.PipelineTask {
+		spec := &v1alpha1.PipelineSpec{}
+		tb.PipelineTask(name, taskName, ops...)(spec)
+		return &spec.Tasks[0]
+	}
+	build := pipelineTask("build", "build-task",
+		tb.PipelineTaskParam("commit", "$(tasks.clone.results.commit)"),
+		tb.PipelineTaskWhenExpression("$(tasks.clone.results.branch)", selection.In, "main"),
+	)
+	for _, tc := range []struct {
+		name    string
+		state   PipelineRunState
+		want    *v1alpha1.PipelineTask
+		wantErr bool
+	}{{
+		name: "results replaced",
+		state: PipelineRunState{{
+			PipelineTask: pipelineTask("clone", "clone-task"),
+			TaskRun:      succeeded("clone", tb.TaskRunResult("commit", "abc123"), tb.TaskRunResult("branch", "main")),
+		}, {
+			PipelineTask: build.DeepCopy(),
+		}},
+		want: pipelineTask("build", "build-task",
+			tb.PipelineTaskParam("commit", "abc123"),
+			tb.PipelineTaskWhenExpression("main", selection.In, "main"),
+		),
+	}, {
+		name: "referenced task not run yet",
+		state: PipelineRunState{{
+			PipelineTask: pipelineTask("clone", "clone-task"),
+		}, {
+			PipelineTask: build.DeepCopy(),
+		}},
+		want: build,
+	}, {
+		name: "result not reported",
+		state: PipelineRunState{{
+			PipelineTask: pipelineTask("clone", "clone-task"),
+			TaskRun:      succeeded("clone", tb.TaskRunResult("branch", "main")),
+		}, {
+			PipelineTask: build.DeepCopy(),
+		}},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ApplyTaskResults(tc.state)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyTaskResults() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, tc.state[1].PipelineTask); d != "" {
+				t.Errorf("PipelineTask diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestApplyResourceResults(t *testing.T) {
+	pipelineTask := func(name, taskName string, ops ...tb.PipelineTaskOp) *v1alpha1.PipelineTask {
+		spec := &v1alpha1.PipelineSpec{}
+		tb.PipelineTask(name, taskName, ops...)(spec)
+		return &spec.Tasks[0]
+	}
+	image := func(ops ...tb.PipelineResourceSpecOp) *v1alpha1.PipelineResource {
+		r := tb.PipelineResource("image", "foo", tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeImage,
+			append([]tb.PipelineResourceSpecOp{tb.PipelineResourceSpecParam("url", "gcr.io/foo/bar")}, ops...)...))
+		r.SelfLink = "/apis/tekton.dev/v1alpha1/namespaces/foo/pipelineresources/image"
+		return r
+	}
+	built := tb.TaskRun("build", "foo", tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionTrue,
+	})))
+	built.Status.ResourcesResult = []v1alpha1.PipelineResourceResult{{
+		Key:         "digest",
+		Value:       "sha256:abc",
+		ResourceRef: v1alpha1.PipelineResourceRef{Name: "image"},
+	}}
+	deployed := image(tb.PipelineResourceSpecParam("digest", "sha256:abc"))
+	deployed.SelfLink = ""
+	for _, tc := range []struct {
+		name  string
+		from  []string
+		build *v1alpha1.TaskRun
+		want  *v1alpha1.PipelineResource
+	}{{
+		name:  "digest set",
+		from:  []string{"build"},
+		build: built,
+		want:  deployed,
+	}, {
+		name: "build not run yet",
+		from: []string{"build"},
+		want: image(),
+	}, {
+		name:  "not from the build",
+		build: built,
+		want:  image(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := PipelineRunState{{
+				PipelineTask: pipelineTask("build", "build-task"),
+				TaskRun:      tc.build,
+			}, {
+				PipelineTask: pipelineTask("deploy", "deploy-task", tb.PipelineTaskInputResource("image", "image", tb.From(tc.from...))),
+				ResolvedTaskResources: &resources.ResolvedTaskResources{
+					Inputs: map[string]*v1alpha1.PipelineResource{"image": image()},
+				},
+			}}
+			ApplyResourceResults(state)
+			if d := cmp.Diff(tc.want, state[1].ResolvedTaskResources.Inputs["image"]); d != "" {
+				t.Errorf("PipelineResource diff -want, +got: %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ConfigValuesConfigMapName is the name of the ConfigMaps holding the values
+// of the $(config.<key>) variables of Pipelines: in the namespace of the
+// controller for the whole cluster, and in the namespace of a PipelineRun to
+// override them.
+const ConfigValuesConfigMapName = "config-values"
+
+// ErrConfigValueMissing is wrapped by the errors of GetConfigValues when a key
+// has no value, as opposed to when the config values couldn't be read.
+var ErrConfigValueMissing = errors.New("no config value set")
+
+var configValueReference = regexp.MustCompile(`\$\(config\.([^)]+)\)`)
+
+// GetConfigMap is a function used to retrieve ConfigMaps
+type GetConfigMap func(namespace, name string) (*corev1.ConfigMap, error)
+
+// ConfigValueReferences returns the sorted keys of the config values the
+// params of the PipelineTasks and finally tasks, of their Conditions and of
+// their WhenExpressions reference.
+func ConfigValueReferences(p *v1alpha1.PipelineSpec) []string {
+	keys := map[string]struct{}{}
+	addValues := func(values []string) {
+		for _, value := range values {
+			for _, match := range configValueReference.FindAllStringSubmatch(value, -1) {
+				keys[match[1]] = struct{}{}
+			}
+		}
+	}
+	addParams := func(params []v1alpha1.Param) {
+		for _, param := range params {
+			addValues(append([]string{param.Value.StringVal}, param.Value.ArrayVal...))
+		}
+	}
+	for _, t := range append(append([]v1alpha1.PipelineTask{}, p.Tasks...), p.Finally...) {
+		addParams(t.Params)
+		for _, c := range t.Conditions {
+			addParams(c.Params)
+		}
+		for _, we := range t.WhenExpressions {
+			addValues(append([]string{we.Input}, we.Values...))
+		}
+	}
+	var sorted []string
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// GetConfigValues returns the values of keys, read from the config values of
+// each of namespaces in turn: the values of a namespace override those of the
+// namespaces before it. It returns an error if one of keys has no value.
+func GetConfigValues(keys []string, namespaces []string, getConfigMap GetConfigMap) (map[string]string, error) {
+	values := map[string]string{}
+	for _, ns := range namespaces {
+		cm, err := getConfigMap(ns, ConfigValuesConfigMapName)
+		if kerrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("couldn't get the config values of namespace %s: %w", ns, err)
+		}
+		for _, k := range keys {
+			if v, ok := cm.Data[k]; ok {
+				values[k] = v
+			}
+		}
+	}
+	var missing []string
+	for _, k := range keys {
+		if _, ok := values[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w for %s", ErrConfigValueMissing, strings.Join(missing, ", "))
+	}
+	return values, nil
+}
+
+// ApplyConfigValues replaces the $(config.<key>) variables of the PipelineSpec
+// with values.
+func ApplyConfigValues(p *v1alpha1.PipelineSpec, values map[string]string) *v1alpha1.PipelineSpec {
+	replacements := map[string]string{}
+	for k, v := range values {
+		replacements[fmt.Sprintf("config.%s", k)] = v
+	}
+	return ApplyReplacements(p, replacements, map[string][]string{})
+}
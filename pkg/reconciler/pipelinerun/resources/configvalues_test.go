@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+func TestConfigValueReferences(t *testing.T) {
+	p := tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("deploy", "deploy-task",
+			tb.PipelineTaskParam("endpoint", "https://$(config.cluster-host):$(config.cluster-port)"),
+			tb.PipelineTaskParam("registry", "$(config.registry)"),
+			tb.PipelineTaskParam("static", "$(params.not-a-config-value)"),
+			tb.PipelineTaskCondition("cond-1", tb.PipelineTaskConditionParam("host", "$(config.cluster-host)")),
+		),
+		tb.PipelineTask("push", "push-task",
+			tb.PipelineTaskParam("tags", "$(config.registry)/app", "$(config.tag-prefix)-latest"),
+			tb.PipelineTaskWhenExpression("$(config.environment)", selection.NotIn, "production"),
+		),
+	))
+	want := []string{"cluster-host", "cluster-port", "environment", "registry", "tag-prefix"}
+	if d := cmp.Diff(want, ConfigValueReferences(&p.Spec)); d != "" {
+		t.Errorf("ConfigValueReferences() diff -want, +got: %s", d)
+	}
+}
+
+func TestGetConfigValues(t *testing.T) {
+	configMaps := map[string]*corev1.ConfigMap{
+		"tekton-pipelines": {
+			ObjectMeta: metav1.ObjectMeta{Name: ConfigValuesConfigMapName, Namespace: "tekton-pipelines"},
+			Data:       map[string]string{"registry": "gcr.io/shared", "cluster-host": "prod.example.com"},
+		},
+		"staging": {
+			ObjectMeta: metav1.ObjectMeta{Name: ConfigValuesConfigMapName, Namespace: "staging"},
+			Data:       map[string]string{"cluster-host": "staging.example.com"},
+		},
+	}
+	getConfigMap := func(namespace, name string) (*corev1.ConfigMap, error) {
+		if cm, ok := configMaps[namespace]; ok && cm.Name == name {
+			return cm, nil
+		}
+		return nil, kerrors.NewNotFound(corev1.Resource("configmaps"), name)
+	}
+	for _, tc := range []struct {
+		name       string
+		keys       []string
+		namespaces []string
+		want       map[string]string
+		wantErr    string
+	}{{
+		name:       "cluster values",
+		keys:       []string{"cluster-host", "registry"},
+		namespaces: []string{"tekton-pipelines", "prod"},
+		want:       map[string]string{"cluster-host": "prod.example.com", "registry": "gcr.io/shared"},
+	}, {
+		name:       "namespace overrides",
+		keys:       []string{"cluster-host", "registry"},
+		namespaces: []string{"tekton-pipelines", "staging"},
+		want:       map[string]string{"cluster-host": "staging.example.com", "registry": "gcr.io/shared"},
+	}, {
+		name:       "missing values",
+		keys:       []string{"cluster-host", "cluster-port", "token"},
+		namespaces: []string{"tekton-pipelines", "staging"},
+		wantErr:    "no config value set for cluster-port, token",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := GetConfigValues(tc.keys, tc.namespaces, getConfigMap)
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if d := cmp.Diff(tc.wantErr, gotErr); d != "" {
+				t.Errorf("GetConfigValues() error diff -want, +got: %s", d)
+			}
+			if err != nil && !errors.Is(err, ErrConfigValueMissing) {
+				t.Errorf("GetConfigValues() error = %v, want it to wrap ErrConfigValueMissing", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("GetConfigValues() diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestGetConfigValuesError(t *testing.T) {
+	getConfigMap := func(namespace, name string) (*corev1.ConfigMap, error) {
+		return nil, errors.New("connection refused")
+	}
+	_, err := GetConfigValues([]string{"registry"}, []string{"tekton-pipelines"}, getConfigMap)
+	if err == nil {
+		t.Fatal("GetConfigValues() = nil, want an error")
+	}
+	if errors.Is(err, ErrConfigValueMissing) {
+		t.Errorf("GetConfigValues() error = %v, want it not to wrap ErrConfigValueMissing", err)
+	}
+}
+
+func TestApplyConfigValues(t *testing.T) {
+	p := tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("deploy", "deploy-task",
+			tb.PipelineTaskParam("endpoint", "https://$(config.cluster-host)"),
+			tb.PipelineTaskParam("tags", "$(config.registry)/app", "$(config.registry)/app:latest"),
+			tb.PipelineTaskCondition("cond-1", tb.PipelineTaskConditionParam("host", "$(config.cluster-host)")),
+		),
+	))
+	want := tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("deploy", "deploy-task",
+			tb.PipelineTaskParam("endpoint", "https://staging.example.com"),
+			tb.PipelineTaskParam("tags", "gcr.io/shared/app", "gcr.io/shared/app:latest"),
+			tb.PipelineTaskCondition("cond-1", tb.PipelineTaskConditionParam("host", "staging.example.com")),
+		),
+	))
+	got := ApplyConfigValues(&p.Spec, map[string]string{"cluster-host": "staging.example.com", "registry": "gcr.io/shared"})
+	if d := cmp.Diff(&want.Spec, got); d != "" {
+		t.Errorf("ApplyConfigValues() diff -want, +got: %s", d)
+	}
+}
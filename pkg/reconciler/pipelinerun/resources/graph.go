@@ -0,0 +1,88 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+)
+
+// GetPipelineRunGraph returns the graph of the PipelineTasks in state, with
+// the dependencies d was built from, to be reported in the PipelineRun status.
+func GetPipelineRunGraph(state PipelineRunState, d *dag.Graph) *v1alpha1.PipelineRunGraph {
+	g := &v1alpha1.PipelineRunGraph{}
+	stateMap := state.toMap()
+	seen := map[string]struct{}{}
+	for _, rprt := range state {
+		g.Nodes = append(g.Nodes, v1alpha1.PipelineRunGraphNode{
+			PipelineTaskName: rprt.PipelineTask.Name,
+			TaskRunName:      rprt.TaskRunName,
+			RunName:          rprt.RunName,
+			State:            getPipelineTaskState(rprt, stateMap, d),
+		})
+		// A PipelineTask fanned out by its matrix has a node per TaskRun,
+		// but its edges only once.
+		if _, ok := seen[rprt.PipelineTask.Name]; !ok {
+			seen[rprt.PipelineTask.Name] = struct{}{}
+			g.Edges = append(g.Edges, getEdges(rprt.PipelineTask)...)
+		}
+	}
+	return g
+}
+
+func getPipelineTaskState(rprt *ResolvedPipelineRunTask, stateMap map[string]*ResolvedPipelineRunTask, d *dag.Graph) v1alpha1.PipelineTaskState {
+	switch {
+	case rprt.IsSuccessful():
+		return v1alpha1.PipelineTaskStateSucceeded
+	case rprt.IsFailure():
+		return v1alpha1.PipelineTaskStateFailed
+	case rprt.TaskRun != nil || rprt.Run != nil:
+		return v1alpha1.PipelineTaskStateRunning
+	case isSkipped(rprt, stateMap, d):
+		return v1alpha1.PipelineTaskStateSkipped
+	default:
+		return v1alpha1.PipelineTaskStatePending
+	}
+}
+
+// getEdges returns the edges from the PipelineTasks pt depends on, as
+// returned by its Deps, to pt, once per PipelineTask and type of dependency.
+func getEdges(pt *v1alpha1.PipelineTask) []v1alpha1.PipelineRunGraphEdge {
+	var edges []v1alpha1.PipelineRunGraphEdge
+	seen := map[v1alpha1.PipelineRunGraphEdge]struct{}{}
+	add := func(from string, t v1alpha1.PipelineRunGraphEdgeType) {
+		e := v1alpha1.PipelineRunGraphEdge{From: from, To: pt.Name, Type: t}
+		if _, ok := seen[e]; !ok {
+			seen[e] = struct{}{}
+			edges = append(edges, e)
+		}
+	}
+	for _, from := range pt.RunAfter {
+		add(from, v1alpha1.PipelineRunGraphEdgeRunAfter)
+	}
+	if pt.Resources != nil {
+		for _, rd := range pt.Resources.Inputs {
+			for _, from := range rd.From {
+				add(from, v1alpha1.PipelineRunGraphEdgeFrom)
+			}
+		}
+	}
+	for _, ref := range pt.ResultRefs() {
+		add(ref.PipelineTask, v1alpha1.PipelineRunGraphEdgeResult)
+	}
+	return edges
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+)
+
+func TestGetPipelineRunGraph(t *testing.T) {
+	build := v1alpha1.PipelineTask{
+		Name:    "build",
+		TaskRef: v1alpha1.TaskRef{Name: "task"},
+	}
+	check := v1alpha1.PipelineTask{
+		Name:       "check",
+		TaskRef:    v1alpha1.TaskRef{Name: "task"},
+		Conditions: []v1alpha1.PipelineTaskCondition{{ConditionRef: "always-true"}},
+	}
+	test := v1alpha1.PipelineTask{
+		Name:     "test",
+		TaskRef:  v1alpha1.TaskRef{Name: "task"},
+		RunAfter: []string{"build"},
+		Resources: &v1alpha1.PipelineTaskResources{
+			Inputs: []v1alpha1.PipelineTaskInputResource{{
+				Name: "image", Resource: "image", From: []string{"build"},
+			}, {
+				Name: "other-image", Resource: "other-image", From: []string{"build"},
+			}},
+		},
+	}
+	deploy := v1alpha1.PipelineTask{
+		Name:     "deploy",
+		TaskRef:  v1alpha1.TaskRef{Name: "task"},
+		RunAfter: []string{"test", "check"},
+	}
+	retried := v1alpha1.PipelineTask{
+		Name:    "retried",
+		TaskRef: v1alpha1.TaskRef{Name: "task"},
+		Retries: 1,
+	}
+	failed := v1alpha1.PipelineTask{
+		Name:    "failed",
+		TaskRef: v1alpha1.TaskRef{Name: "task"},
+	}
+	// report only depends on build through its result.
+	report := v1alpha1.PipelineTask{
+		Name:    "report",
+		TaskRef: v1alpha1.TaskRef{Name: "task"},
+		Params: []v1alpha1.Param{{
+			Name:  "digest",
+			Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeString, StringVal: "$(tasks.build.results.digest)"},
+		}},
+	}
+	state := PipelineRunState{{
+		PipelineTask: &build,
+		TaskRunName:  "pr-build",
+		TaskRun:      makeSucceeded(trs[0]),
+	}, {
+		PipelineTask: &check,
+		TaskRunName:  "pr-check",
+		// The condition failed, so check and deploy will never run.
+		ResolvedConditionChecks: failedTaskConditionCheckState,
+	}, {
+		PipelineTask: &test,
+		TaskRunName:  "pr-test",
+		TaskRun:      makeStarted(trs[1]),
+	}, {
+		PipelineTask: &deploy,
+		TaskRunName:  "pr-deploy",
+	}, {
+		PipelineTask: &retried,
+		TaskRunName:  "pr-retried",
+		// The TaskRun failed but will be retried.
+		TaskRun: makeFailed(trs[0]),
+	}, {
+		PipelineTask: &failed,
+		TaskRunName:  "pr-failed",
+		TaskRun:      makeFailed(trs[1]),
+	}, {
+		PipelineTask: &report,
+		TaskRunName:  "pr-report",
+	}}
+	d, err := dag.Build(v1alpha1.PipelineTaskList{build, check, test, deploy, retried, failed, report})
+	if err != nil {
+		t.Fatalf("Couldn't build the dag: %v", err)
+	}
+
+	expected := &v1alpha1.PipelineRunGraph{
+		Nodes: []v1alpha1.PipelineRunGraphNode{{
+			PipelineTaskName: "build",
+			TaskRunName:      "pr-build",
+			State:            v1alpha1.PipelineTaskStateSucceeded,
+		}, {
+			PipelineTaskName: "check",
+			TaskRunName:      "pr-check",
+			State:            v1alpha1.PipelineTaskStateSkipped,
+		}, {
+			PipelineTaskName: "test",
+			TaskRunName:      "pr-test",
+			State:            v1alpha1.PipelineTaskStateRunning,
+		}, {
+			PipelineTaskName: "deploy",
+			TaskRunName:      "pr-deploy",
+			State:            v1alpha1.PipelineTaskStateSkipped,
+		}, {
+			PipelineTaskName: "retried",
+			TaskRunName:      "pr-retried",
+			State:            v1alpha1.PipelineTaskStateRunning,
+		}, {
+			PipelineTaskName: "failed",
+			TaskRunName:      "pr-failed",
+			State:            v1alpha1.PipelineTaskStateFailed,
+		}, {
+			PipelineTaskName: "report",
+			TaskRunName:      "pr-report",
+			State:            v1alpha1.PipelineTaskStatePending,
+		}},
+		Edges: []v1alpha1.PipelineRunGraphEdge{{
+			From: "build", To: "test", Type: v1alpha1.PipelineRunGraphEdgeRunAfter,
+		}, {
+			From: "build", To: "test", Type: v1alpha1.PipelineRunGraphEdgeFrom,
+		}, {
+			From: "test", To: "deploy", Type: v1alpha1.PipelineRunGraphEdgeRunAfter,
+		}, {
+			From: "check", To: "deploy", Type: v1alpha1.PipelineRunGraphEdgeRunAfter,
+		}, {
+			From: "build", To: "report", Type: v1alpha1.PipelineRunGraphEdgeResult,
+		}},
+	}
+	if d := cmp.Diff(expected, GetPipelineRunGraph(state, d)); d != "" {
+		t.Errorf("Unexpected graph (-want, +got): %s", d)
+	}
+}
+
+func TestGetPipelineRunGraph_Pending(t *testing.T) {
+	state := PipelineRunState{{
+		PipelineTask: &pts[0],
+		TaskRunName:  "pipelinerun-mytask1",
+	}}
+	d, err := dag.Build(v1alpha1.PipelineTaskList{pts[0]})
+	if err != nil {
+		t.Fatalf("Couldn't build the dag: %v", err)
+	}
+
+	expected := &v1alpha1.PipelineRunGraph{
+		Nodes: []v1alpha1.PipelineRunGraphNode{{
+			PipelineTaskName: "mytask1",
+			TaskRunName:      "pipelinerun-mytask1",
+			State:            v1alpha1.PipelineTaskStatePending,
+		}},
+	}
+	if d := cmp.Diff(expected, GetPipelineRunGraph(state, d)); d != "" {
+		t.Errorf("Unexpected graph (-want, +got): %s", d)
+	}
+}
@@ -17,19 +17,23 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"knative.dev/pkg/apis"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/names"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/resolution"
 )
 
 const (
@@ -44,10 +48,18 @@ const (
 	// completed successfully
 	ReasonSucceeded = "Succeeded"
 
+	// ReasonNoChanges indicates that the reason for the finished status is that the change
+	// detection task found no changes, so that the other tasks were skipped
+	ReasonNoChanges = "NoChanges"
+
 	// ReasonTimedOut indicates that the PipelineRun has taken longer than its configured
 	// timeout
 	ReasonTimedOut = "PipelineRunTimeout"
 
+	// ReasonStopped indicates that the PipelineRun was stopped before all of
+	// its tasks ran
+	ReasonStopped = "StoppedRunFinally"
+
 	// ReasonConditionCheckFailed indicates that the reason for the failure status is that the
 	// condition check associated to the pipeline task evaluated to false
 	ReasonConditionCheckFailed = "ConditionCheckFailed"
@@ -60,6 +72,15 @@ type ResolvedPipelineRunTask struct {
 	TaskRun               *v1alpha1.TaskRun
 	PipelineTask          *v1alpha1.PipelineTask
 	ResolvedTaskResources *resources.ResolvedTaskResources
+	// CustomTask is true if PipelineTask references a custom task, which
+	// runs as the Run RunName instead of a TaskRun: there are no
+	// ResolvedTaskResources, and Run is nil until it is created.
+	CustomTask bool
+	RunName    string
+	Run        *v1alpha1.Run
+	// Matrix is the combination of the matrix params of PipelineTask the
+	// TaskRun runs with, which are also in its params.
+	Matrix []v1alpha1.Param
 	// ConditionChecks ~~TaskRuns but for evaling conditions
 	ResolvedConditionChecks TaskConditionCheckState // Could also be a TaskRun or maybe just a Pod?
 }
@@ -69,6 +90,13 @@ type ResolvedPipelineRunTask struct {
 type PipelineRunState []*ResolvedPipelineRunTask
 
 func (t ResolvedPipelineRunTask) IsDone() (isDone bool) {
+	if t.CustomTask {
+		if t.Run == nil {
+			return false
+		}
+		status := t.Run.Status.GetCondition(apis.ConditionSucceeded)
+		return status.IsTrue() || status.IsFalse() && !t.retriesRun()
+	}
 	if t.TaskRun == nil || t.PipelineTask == nil {
 		return
 	}
@@ -82,6 +110,9 @@ func (t ResolvedPipelineRunTask) IsDone() (isDone bool) {
 
 // IsSuccessful returns true only if the taskrun itself has completed successfully
 func (t ResolvedPipelineRunTask) IsSuccessful() bool {
+	if t.CustomTask {
+		return t.Run != nil && t.Run.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+	}
 	if t.TaskRun == nil {
 		return false
 	}
@@ -98,6 +129,9 @@ func (t ResolvedPipelineRunTask) IsSuccessful() bool {
 
 // IsFailure returns true only if the taskrun itself has failed
 func (t ResolvedPipelineRunTask) IsFailure() bool {
+	if t.CustomTask {
+		return t.Run != nil && t.Run.Status.GetCondition(apis.ConditionSucceeded).IsFalse() && !t.retriesRun()
+	}
 	if t.TaskRun == nil {
 		return false
 	}
@@ -107,6 +141,24 @@ func (t ResolvedPipelineRunTask) IsFailure() bool {
 	return c.IsFalse() && retriesDone >= retries
 }
 
+// retriesRun returns true if the failed Run of t is retried: it wasn't
+// cancelled, and its PipelineTask has retries left.
+func (t ResolvedPipelineRunTask) retriesRun() bool {
+	return !t.Run.IsCancelled() && len(t.Run.Status.RetriesStatus) < t.PipelineTask.Retries
+}
+
+// results returns the results reported by the TaskRun or the Run of t.
+func (t ResolvedPipelineRunTask) results() []v1alpha1.TaskRunResult {
+	switch {
+	case t.Run != nil:
+		return t.Run.Status.Results
+	case t.TaskRun != nil:
+		return t.TaskRun.Status.TaskRunResults
+	default:
+		return nil
+	}
+}
+
 func (state PipelineRunState) toMap() map[string]*ResolvedPipelineRunTask {
 	m := make(map[string]*ResolvedPipelineRunTask)
 	for _, rprt := range state {
@@ -118,7 +170,7 @@ func (state PipelineRunState) toMap() map[string]*ResolvedPipelineRunTask {
 func (state PipelineRunState) IsDone() (isDone bool) {
 	isDone = true
 	for _, t := range state {
-		if t.TaskRun == nil || t.PipelineTask == nil {
+		if (t.TaskRun == nil && t.Run == nil) || t.PipelineTask == nil {
 			return false
 		}
 		isDone = isDone && t.IsDone()
@@ -134,7 +186,7 @@ func (state PipelineRunState) IsDone() (isDone bool) {
 func (state PipelineRunState) GetNextTasks(candidateTasks map[string]struct{}) []*ResolvedPipelineRunTask {
 	tasks := []*ResolvedPipelineRunTask{}
 	for _, t := range state {
-		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.TaskRun == nil {
+		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.TaskRun == nil && t.Run == nil && t.PipelineTask.WhenExpressions.AllowsExecution() {
 			tasks = append(tasks, t)
 		}
 		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.TaskRun != nil {
@@ -147,20 +199,116 @@ func (state PipelineRunState) GetNextTasks(candidateTasks map[string]struct{}) [
 				}
 			}
 		}
+		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.Run != nil {
+			if t.Run.Status.GetCondition(apis.ConditionSucceeded).IsFalse() && t.retriesRun() {
+				tasks = append(tasks, t)
+			}
+		}
 	}
 	return tasks
 }
 
+// PipelineTaskNames returns the names of the PipelineTasks in state, as
+// candidates for GetNextTasks.
+func (state PipelineRunState) PipelineTaskNames() map[string]struct{} {
+	names := map[string]struct{}{}
+	for _, t := range state {
+		names[t.PipelineTask.Name] = struct{}{}
+	}
+	return names
+}
+
+// SplitFinally returns the state of the PipelineTasks of the DAG and the
+// state of the finally tasks.
+func (state PipelineRunState) SplitFinally(finally []v1alpha1.PipelineTask) (PipelineRunState, PipelineRunState) {
+	finallyNames := map[string]struct{}{}
+	for _, pt := range finally {
+		finallyNames[pt.Name] = struct{}{}
+	}
+	var dagState, finallyState PipelineRunState
+	for _, t := range state {
+		if _, ok := finallyNames[t.PipelineTask.Name]; ok {
+			finallyState = append(finallyState, t)
+		} else {
+			dagState = append(dagState, t)
+		}
+	}
+	return dagState, finallyState
+}
+
+// HasFailure returns true if one of the PipelineTasks in state failed, once
+// its retries are exhausted.
+func (state PipelineRunState) HasFailure() bool {
+	for _, t := range state {
+		if t.IsFailure() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDAGDone returns true once every PipelineTask of the DAG d in state is
+// done or skipped or, if the DAG is stopped because one of them failed or the
+// tasks timed out, once none of them is running anymore: the other ones are
+// never started or retried.
+func (state PipelineRunState) IsDAGDone(d *dag.Graph, stopped bool) bool {
+	stateMap := state.toMap()
+	for _, t := range state {
+		if t.IsDone() || isSkipped(t, stateMap, d) {
+			continue
+		}
+		if stopped && !t.isRunning() {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// NoChangesDetected returns true if the change detection task cd of state
+// succeeded and reported "false" as its result.
+func (state PipelineRunState) NoChangesDetected(cd *v1alpha1.PipelineChangeDetection) bool {
+	if cd == nil {
+		return false
+	}
+	t, ok := state.toMap()[cd.Task]
+	if !ok || !t.IsSuccessful() {
+		return false
+	}
+	value, found := findTaskResult(t.results(), cd.Result)
+	return found && value == "false"
+}
+
+// isRunning returns true if the TaskRun, the Run or the condition checks of t
+// are running.
+func (t ResolvedPipelineRunTask) isRunning() bool {
+	if t.Run != nil {
+		return !t.Run.IsDone()
+	}
+	if t.TaskRun != nil {
+		return t.TaskRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
+	}
+	return t.ResolvedConditionChecks.HasStarted() && !t.ResolvedConditionChecks.IsDone()
+}
+
 // SuccessfulPipelineTaskNames returns a list of the names of all of the PipelineTasks in state
-// which have successfully completed.
+// which have successfully completed. A PipelineTask fanned out by its matrix
+// has only completed once the TaskRuns of all of its combinations have.
 func (state PipelineRunState) SuccessfulPipelineTaskNames() []string {
 	done := []string{}
+	unsuccessful := map[string]struct{}{}
 	for _, t := range state {
-		if t.TaskRun != nil {
-			c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-			if c.IsTrue() {
-				done = append(done, t.PipelineTask.Name)
-			}
+		if !t.IsSuccessful() {
+			unsuccessful[t.PipelineTask.Name] = struct{}{}
+		}
+	}
+	seen := map[string]struct{}{}
+	for _, t := range state {
+		_, isUnsuccessful := unsuccessful[t.PipelineTask.Name]
+		_, isSeen := seen[t.PipelineTask.Name]
+		if !isUnsuccessful && !isSeen {
+			done = append(done, t.PipelineTask.Name)
+			seen[t.PipelineTask.Name] = struct{}{}
 		}
 	}
 	return done
@@ -169,6 +317,13 @@ func (state PipelineRunState) SuccessfulPipelineTaskNames() []string {
 // GetTaskRun is a function that will retrieve the TaskRun name.
 type GetTaskRun func(name string) (*v1alpha1.TaskRun, error)
 
+// GetRun is a function that will retrieve the Run name.
+type GetRun func(name string) (*v1alpha1.Run, error)
+
+// GetRemoteTask is a function that will fetch the Task ref references with
+// its resolver.
+type GetRemoteTask func(ref v1alpha1.ResolverRef) (v1alpha1.TaskInterface, error)
+
 // GetResourcesFromBindings will retrieve all Resources bound in PipelineRun pr and return a map
 // from the declared name of the PipelineResource (which is how the PipelineResource will
 // be referred to in the PipelineRun) to the PipelineResource, obtained via getResource.
@@ -200,6 +355,23 @@ func ValidateResourceBindings(p *v1alpha1.PipelineSpec, pr *v1alpha1.PipelineRun
 	return nil
 }
 
+// ValidateWorkspaceBindings validates that the PipelineRun binds each of the
+// workspaces the Pipeline declares, and only those.
+func ValidateWorkspaceBindings(p *v1alpha1.PipelineSpec, pr *v1alpha1.PipelineRun) error {
+	required := make([]string, 0, len(p.Workspaces))
+	for _, w := range p.Workspaces {
+		required = append(required, w.Name)
+	}
+	provided := make([]string, 0, len(pr.Spec.Workspaces))
+	for _, w := range pr.Spec.Workspaces {
+		provided = append(provided, w.Name)
+	}
+	if err := list.IsSame(required, provided); err != nil {
+		return fmt.Errorf("pipelineRun bound workspaces didn't match Pipeline: %w", err)
+	}
+	return nil
+}
+
 // TaskNotFoundError indicates that the resolution failed because a referenced Task couldn't be retrieved
 type TaskNotFoundError struct {
 	Name string
@@ -220,37 +392,71 @@ func (e *ConditionNotFoundError) Error() string {
 }
 
 // ResolvePipelineRun retrieves all Tasks instances which are reference by tasks, getting
-// instances from getTask. If it is unable to retrieve an instance of a referenced Task, it
-// will return an error, otherwise it returns a list of all of the Tasks retrieved.
+// instances from getTask, or getRemoteTask for the taskRefs with a resolver. If it is unable
+// to retrieve an instance of a referenced Task, it will return an error, otherwise it returns
+// a list of all of the Tasks retrieved. While a resolver is fetching a Task, the error is
+// resolution.ErrRequestInProgress.
 // It will retrieve the Resources needed for the TaskRun using the mapping of providedResources.
+// The PipelineTasks referencing custom tasks aren't resolved: their Runs, from getRun, are
+// reconciled by the controllers of the custom tasks.
 func ResolvePipelineRun(
+	ctx context.Context,
 	pipelineRun v1alpha1.PipelineRun,
 	getTask resources.GetTask,
 	getTaskRun resources.GetTaskRun,
+	getRun GetRun,
 	getClusterTask resources.GetClusterTask,
+	getRemoteTask GetRemoteTask,
 	getCondition GetCondition,
 	tasks []v1alpha1.PipelineTask,
 	providedResources map[string]*v1alpha1.PipelineResource,
 ) (PipelineRunState, error) {
 
+	pipelineTasks, matrices, err := expandMatrices(ctx, tasks)
+	if err != nil {
+		return nil, err
+	}
 	state := []*ResolvedPipelineRunTask{}
-	for i := range tasks {
-		pt := tasks[i]
+	for i := range pipelineTasks {
+		pt := pipelineTasks[i]
+
+		if pt.TaskRef.IsCustomTask() {
+			rprt := ResolvedPipelineRunTask{
+				PipelineTask: &pt,
+				CustomTask:   true,
+				RunName:      getRunName(pipelineRun.Status.Runs, pt.Name, pipelineRun.Name),
+			}
+			run, err := getRun(rprt.RunName)
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("error retrieving Run %s: %w", rprt.RunName, err)
+			}
+			if run != nil {
+				rprt.Run = run
+			}
+			state = append(state, &rprt)
+			continue
+		}
 
 		rprt := ResolvedPipelineRunTask{
 			PipelineTask: &pt,
-			TaskRunName:  getTaskRunName(pipelineRun.Status.TaskRuns, pt.Name, pipelineRun.Name),
+			Matrix:       matrices[i],
+			TaskRunName:  getTaskRunName(pipelineRun.Status.TaskRuns, pt.Name, matrices[i], pipelineRun.Name, pipelineRun.GetTaskRunMetadata(pt.Name).GenerateName),
 		}
 
 		// Find the Task that this PipelineTask is using
 		var t v1alpha1.TaskInterface
 		var err error
-		if pt.TaskRef.Kind == v1alpha1.ClusterTaskKind {
+		switch {
+		case pt.TaskRef.Resolver != "":
+			t, err = getRemoteTask(pt.TaskRef.ResolverRef)
+		case pt.TaskRef.Kind == v1alpha1.ClusterTaskKind:
 			t, err = getClusterTask(pt.TaskRef.Name)
-		} else {
+		default:
 			t, err = getTask(pt.TaskRef.Name)
 		}
-		if err != nil {
+		if resolution.IsRequestInProgress(err) {
+			return nil, err
+		} else if err != nil {
 			return nil, &TaskNotFoundError{
 				Name: pt.TaskRef.Name,
 				Msg:  err.Error(),
@@ -290,6 +496,42 @@ func ResolvePipelineRun(
 	return state, nil
 }
 
+// expandMatrices returns tasks with each PipelineTask fanned out by its matrix
+// replaced by one copy per combination, with the combination added to its
+// params, and the combination of each of the returned PipelineTasks.
+func expandMatrices(ctx context.Context, tasks []v1alpha1.PipelineTask) ([]v1alpha1.PipelineTask, [][]v1alpha1.Param, error) {
+	maximum := config.FromContextOrDefaults(ctx).Defaults.MaximumMatrixCombinations
+	if maximum == 0 {
+		maximum = v1alpha1.MaxMatrixCombinations
+	}
+	var expanded []v1alpha1.PipelineTask
+	var matrices [][]v1alpha1.Param
+	for _, pt := range tasks {
+		if len(pt.Matrix) == 0 {
+			expanded = append(expanded, pt)
+			matrices = append(matrices, nil)
+			continue
+		}
+		// The validation of the Pipeline counts a matrix param set to an
+		// array param, ["$(params.list)"], as a single value: the number of
+		// combinations is only known once the params are substituted.
+		if count := pt.MatrixCombinationsCount(); count > maximum {
+			return nil, nil, fmt.Errorf("PipelineTask %s is fanned out into %d TaskRuns by its matrix, more than the maximum of %d", pt.Name, count, maximum)
+		}
+		combinations := pt.MatrixCombinations()
+		if len(combinations) == 0 {
+			return nil, nil, fmt.Errorf("PipelineTask %s is fanned out into no TaskRuns by its matrix", pt.Name)
+		}
+		for _, matrix := range combinations {
+			c := pt
+			c.Params = append(append([]v1alpha1.Param{}, pt.Params...), matrix...)
+			expanded = append(expanded, c)
+			matrices = append(matrices, matrix)
+		}
+	}
+	return expanded, matrices, nil
+}
+
 // getConditionCheckName should return a unique name for a `ConditionCheck` if one has not already been defined, and the existing one otherwise.
 func getConditionCheckName(taskRunStatus map[string]*v1alpha1.PipelineRunTaskRunStatus, trName, conditionName string) string {
 	trStatus, ok := taskRunStatus[trName]
@@ -304,20 +546,37 @@ func getConditionCheckName(taskRunStatus map[string]*v1alpha1.PipelineRunTaskRun
 	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", trName, conditionName))
 }
 
-// getTaskRunName should return a unique name for a `TaskRun` if one has not already been defined, and the existing one otherwise.
-func getTaskRunName(taskRunsStatus map[string]*v1alpha1.PipelineRunTaskRunStatus, ptName, prName string) string {
+// getTaskRunName returns the name of the TaskRun of ptName, for the matrix
+// combination if it is fanned out, recorded in the status, or else a new name
+// made of generateName, <prName>-<ptName>- by default, and a random suffix.
+func getTaskRunName(taskRunsStatus map[string]*v1alpha1.PipelineRunTaskRunStatus, ptName string, matrix []v1alpha1.Param, prName, generateName string) string {
 	for k, v := range taskRunsStatus {
-		if v.PipelineTaskName == ptName {
+		if v.PipelineTaskName == ptName && reflect.DeepEqual(v.Matrix, matrix) {
 			return k
 		}
 	}
 
+	base := fmt.Sprintf("%s-%s", prName, ptName)
+	if generateName != "" {
+		base = strings.TrimSuffix(generateName, "-")
+	}
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(base)
+}
+
+// getRunName returns the name of the Run of ptName recorded in the status, or
+// else a new name made of <prName>-<ptName>- and a random suffix.
+func getRunName(runsStatus map[string]*v1alpha1.PipelineRunRunStatus, ptName, prName string) string {
+	for k, v := range runsStatus {
+		if v.PipelineTaskName == ptName {
+			return k
+		}
+	}
 	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
 }
 
 // GetPipelineConditionStatus will return the Condition that the PipelineRun prName should be
-// updated with, based on the status of the TaskRuns in state.
-func GetPipelineConditionStatus(pr *v1alpha1.PipelineRun, state PipelineRunState, logger *zap.SugaredLogger, dag *dag.Graph) *apis.Condition {
+// updated with, based on the status of the TaskRuns in state and in finallyState.
+func GetPipelineConditionStatus(pr *v1alpha1.PipelineRun, state, finallyState PipelineRunState, logger *zap.SugaredLogger, dag *dag.Graph) *apis.Condition {
 	// We have 4 different states here:
 	// 1. Timed out -> Failed
 	// 2. Any one TaskRun has failed - >Failed. This should change with #1020 and #1023
@@ -332,8 +591,41 @@ func GetPipelineConditionStatus(pr *v1alpha1.PipelineRun, state PipelineRunState
 		}
 	}
 
+	// The finally tasks run once the DAG is done, whether its tasks succeeded
+	// or not: the PipelineRun completes after them.
+	tasksTimedOut := pr.HasTasksTimedOut()
+	stopped := pr.IsGracefullyStopped()
+	if len(finallyState) > 0 && !(state.IsDAGDone(dag, state.HasFailure() || tasksTimedOut || stopped) && finallyState.IsDone()) {
+		return &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  ReasonRunning,
+			Message: "Not all Tasks in the Pipeline have finished executing",
+		}
+	}
+	if (tasksTimedOut || stopped) && !state.IsDAGDone(dag, true) {
+		// The tasks that are still running complete, within the timeout.
+		return &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  ReasonRunning,
+			Message: "Not all Tasks in the Pipeline have finished executing",
+		}
+	}
+
+	state = append(append(PipelineRunState{}, state...), finallyState...)
+
 	// A single failed task mean we fail the pipeline
 	for _, rprt := range state {
+		if rprt.IsFailure() && rprt.CustomTask {
+			logger.Infof("Run %s has failed, so PipelineRun %s has failed", rprt.RunName, pr.Name)
+			return &apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  ReasonFailed,
+				Message: fmt.Sprintf("Run %s has failed", rprt.RunName),
+			}
+		}
 		if rprt.IsFailure() { //IsDone ensures we have crossed the retry limit
 			logger.Infof("TaskRun %s has failed, so PipelineRun %s has failed, retries done: %b", rprt.TaskRunName, pr.Name, len(rprt.TaskRun.Status.RetriesStatus))
 			return &apis.Condition{
@@ -356,6 +648,27 @@ func GetPipelineConditionStatus(pr *v1alpha1.PipelineRun, state PipelineRunState
 		}
 	}
 
+	// Once the tasks timed out, the ones that haven't started never will.
+	if tasksTimedOut && !reflect.DeepEqual(allTasks, successOrSkipTasks) {
+		return &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonTimedOut,
+			Message: fmt.Sprintf("PipelineRun %q failed to finish its tasks within %q", pr.Name, pr.Spec.Timeouts.Tasks.Duration.String()),
+		}
+	}
+
+	// Once the PipelineRun was stopped, the tasks that haven't started never
+	// will either.
+	if stopped && !reflect.DeepEqual(allTasks, successOrSkipTasks) {
+		return &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonStopped,
+			Message: fmt.Sprintf("PipelineRun %q was stopped before all of its tasks ran", pr.Name),
+		}
+	}
+
 	if reflect.DeepEqual(allTasks, successOrSkipTasks) {
 		logger.Infof("All TaskRuns have finished for PipelineRun %s so it has finished", pr.Name)
 		return &apis.Condition{
@@ -377,14 +690,34 @@ func GetPipelineConditionStatus(pr *v1alpha1.PipelineRun, state PipelineRunState
 }
 
 // isSkipped returns true if a Task in a TaskRun will not be run either because
-//  its Condition Checks failed or because one of the parent tasks's conditions failed
+//  its Condition Checks or WhenExpressions failed or because one of the parent tasks's conditions failed
 // Note that this means isSkipped returns false if a conditionCheck is in progress
 func isSkipped(rprt *ResolvedPipelineRunTask, stateMap map[string]*ResolvedPipelineRunTask, d *dag.Graph) bool {
 	// Taskrun not skipped if it already exists
-	if rprt.TaskRun != nil {
+	if rprt.TaskRun != nil || rprt.Run != nil {
 		return false
 	}
 
+	// Finally tasks aren't in the DAG, and are never skipped.
+	node, ok := d.Nodes[rprt.PipelineTask.Name]
+	if !ok {
+		return false
+	}
+
+	// Check if whenExpressions are false, if so task is skipped. They may
+	// use the results of the parent tasks, so only once those succeeded.
+	if !rprt.PipelineTask.WhenExpressions.AllowsExecution() {
+		parentsSucceeded := true
+		for _, p := range node.Prev {
+			if !stateMap[p.Task.HashKey()].IsSuccessful() {
+				parentsSucceeded = false
+			}
+		}
+		if parentsSucceeded {
+			return true
+		}
+	}
+
 	// Check if conditionChecks have failed, if so task is skipped
 	if len(rprt.ResolvedConditionChecks) > 0 {
 		// isSkipped is only true iof
@@ -395,7 +728,6 @@ func isSkipped(rprt *ResolvedPipelineRunTask, stateMap map[string]*ResolvedPipel
 
 	// Recursively look at parent tasks to see if they have been skipped,
 	// if any of the parents have been skipped, skip as well
-	node := d.Nodes[rprt.PipelineTask.Name]
 	for _, p := range node.Prev {
 		skip := isSkipped(stateMap[p.Task.HashKey()], stateMap, d)
 		if skip {
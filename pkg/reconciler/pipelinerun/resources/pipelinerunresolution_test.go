@@ -17,9 +17,11 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -32,6 +34,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
@@ -461,6 +464,26 @@ func DagFromState(state PipelineRunState) (*dag.Graph, error) {
 }
 
 func TestGetNextTasks(t *testing.T) {
+	whenTrueTask := pts[0].DeepCopy()
+	whenTrueTask.WhenExpressions = v1alpha1.WhenExpressions{{
+		Input:    "main",
+		Operator: selection.NotIn,
+		Values:   []string{"release"},
+	}}
+	whenFalseTask := pts[1].DeepCopy()
+	whenFalseTask.WhenExpressions = v1alpha1.WhenExpressions{{
+		Input:    "main",
+		Operator: selection.In,
+		Values:   []string{"release"},
+	}}
+	whenState := PipelineRunState{{
+		PipelineTask: whenTrueTask,
+		TaskRunName:  "pipelinerun-mytask1",
+	}, {
+		PipelineTask: whenFalseTask,
+		TaskRunName:  "pipelinerun-mytask2",
+	}}
+
 	tcs := []struct {
 		name         string
 		state        PipelineRunState
@@ -471,6 +494,14 @@ func TestGetNextTasks(t *testing.T) {
 		state:        noneStartedState,
 		candidates:   map[string]struct{}{},
 		expectedNext: []*ResolvedPipelineRunTask{},
+	}, {
+		name:  "when-expressions-false",
+		state: whenState,
+		candidates: map[string]struct{}{
+			"mytask1": {},
+			"mytask2": {},
+		},
+		expectedNext: []*ResolvedPipelineRunTask{whenState[0]},
 	}, {
 		name:  "no-tasks-started-one-candidate",
 		state: noneStartedState,
@@ -737,6 +768,57 @@ func TestGetNextTaskWithRetries(t *testing.T) {
 	}
 }
 
+func TestGetNextTaskWithRetries_CustomTask(t *testing.T) {
+	failedRun := func(retriesDone int) *v1alpha1.Run {
+		r := &v1alpha1.Run{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-mytask5"}}
+		r.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse})
+		for i := 0; i < retriesDone; i++ {
+			r.Status.RetriesStatus = append(r.Status.RetriesStatus, *r.Status.DeepCopy())
+		}
+		return r
+	}
+	cancelledRun := failedRun(0)
+	cancelledRun.Spec.Status = v1alpha1.RunSpecStatusCancelled
+	runningRun := &v1alpha1.Run{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-mytask5"}}
+	runningRun.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown})
+
+	for _, tc := range []struct {
+		name     string
+		run      *v1alpha1.Run
+		wantNext bool
+	}{{
+		name: "run-running",
+		run:  runningRun,
+	}, {
+		name:     "run-failed-with-retries-left",
+		run:      failedRun(1),
+		wantNext: true,
+	}, {
+		name: "run-failed-after-its-retries",
+		run:  failedRun(2),
+	}, {
+		name: "run-cancelled",
+		run:  cancelledRun,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := PipelineRunState{{
+				PipelineTask: &pts[4], // 2 retries needed
+				CustomTask:   true,
+				RunName:      "pipelinerun-mytask5",
+				Run:          tc.run,
+			}}
+			expectedNext := []*ResolvedPipelineRunTask{}
+			if tc.wantNext {
+				expectedNext = append(expectedNext, state[0])
+			}
+			next := state.GetNextTasks(map[string]struct{}{"mytask5": {}})
+			if d := cmp.Diff(next, expectedNext); d != "" {
+				t.Errorf("Didn't get expected next Tasks: %v", d)
+			}
+		})
+	}
+}
+
 func TestIsDone(t *testing.T) {
 
 	var taskCancelledByStatusState = PipelineRunState{{
@@ -912,6 +994,52 @@ func TestSuccessfulPipelineTaskNames(t *testing.T) {
 	}
 }
 
+func TestNoChangesDetected(t *testing.T) {
+	cd := &v1alpha1.PipelineChangeDetection{Task: "mytask1", Result: "changed"}
+	withResult := func(value string) PipelineRunState {
+		tr := makeSucceeded(trs[0])
+		tr.Status.TaskRunResults = []v1alpha1.TaskRunResult{{Name: "changed", Value: value}}
+		return PipelineRunState{{
+			PipelineTask: &pts[0],
+			TaskRunName:  "pipelinerun-mytask1",
+			TaskRun:      tr,
+		}}
+	}
+	tcs := []struct {
+		name  string
+		cd    *v1alpha1.PipelineChangeDetection
+		state PipelineRunState
+		want  bool
+	}{{
+		name:  "no change detection",
+		state: withResult("false"),
+	}, {
+		name:  "not done",
+		cd:    cd,
+		state: oneStartedState,
+	}, {
+		name:  "result not reported",
+		cd:    cd,
+		state: oneFinishedState,
+	}, {
+		name:  "changes",
+		cd:    cd,
+		state: withResult("true"),
+	}, {
+		name:  "no changes",
+		cd:    cd,
+		state: withResult("false"),
+		want:  true,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.state.NoChangesDetected(tc.cd); got != tc.want {
+				t.Errorf("NoChangesDetected() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestGetPipelineConditionStatus(t *testing.T) {
 
 	var taskRetriedState = PipelineRunState{{
@@ -923,6 +1051,24 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 		},
 	}}
 
+	whenFalseTask := pts[5].DeepCopy()
+	whenFalseTask.WhenExpressions = v1alpha1.WhenExpressions{{
+		Input:    "main",
+		Operator: selection.In,
+		Values:   []string{"release"},
+	}}
+	var whenFalseState = PipelineRunState{{
+		TaskRunName:  "taskrunName",
+		PipelineTask: whenFalseTask,
+	}}
+	var taskWithParentWhenFalseState = PipelineRunState{{
+		TaskRunName:  "taskrunName",
+		PipelineTask: whenFalseTask,
+	}, {
+		TaskRunName:  "childtaskrun",
+		PipelineTask: &pts[6],
+	}}
+
 	tcs := []struct {
 		name           string
 		state          []*ResolvedPipelineRunTask
@@ -1015,6 +1161,14 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 		name:           "task with grand parents; one not run yet",
 		state:          taskWithGrandParentsOneNotRunState,
 		expectedStatus: corev1.ConditionUnknown,
+	}, {
+		name:           "when expressions false",
+		state:          whenFalseState,
+		expectedStatus: corev1.ConditionTrue,
+	}, {
+		name:           "task skipped due to when expressions of parent",
+		state:          taskWithParentWhenFalseState,
+		expectedStatus: corev1.ConditionTrue,
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1023,7 +1177,7 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 			if err != nil {
 				t.Fatalf("Unexpected error while buildig DAG for state %v: %v", tc.state, err)
 			}
-			c := GetPipelineConditionStatus(pr, tc.state, zap.NewNop().Sugar(), dag)
+			c := GetPipelineConditionStatus(pr, tc.state, nil, zap.NewNop().Sugar(), dag)
 			if c.Status != tc.expectedStatus {
 				t.Fatalf("Expected to get status %s but got %s for state %v", tc.expectedStatus, c.Status, tc.state)
 			}
@@ -1031,6 +1185,95 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 	}
 }
 
+func TestGetPipelineConditionStatus_TasksTimedOut(t *testing.T) {
+	tcs := []struct {
+		name           string
+		state          []*ResolvedPipelineRunTask
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{{
+		name:           "no-tasks-started",
+		state:          noneStartedState,
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: ReasonTimedOut,
+	}, {
+		name:           "one-task-started",
+		state:          oneStartedState,
+		expectedStatus: corev1.ConditionUnknown,
+		expectedReason: ReasonRunning,
+	}, {
+		name:           "one-task-finished",
+		state:          oneFinishedState,
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: ReasonTimedOut,
+	}, {
+		name:           "all-finished",
+		state:          allFinishedState,
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: ReasonSucceeded,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := tb.PipelineRun("somepipelinerun", "foo",
+				tb.PipelineRunSpec("pipeline", tb.PipelineRunTimeout(time.Hour), tb.PipelineRunTimeouts(time.Minute, 0)),
+				tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-2*time.Minute))),
+			)
+			dag, err := DagFromState(tc.state)
+			if err != nil {
+				t.Fatalf("Unexpected error while buildig DAG for state %v: %v", tc.state, err)
+			}
+			c := GetPipelineConditionStatus(pr, tc.state, nil, zap.NewNop().Sugar(), dag)
+			if c.Status != tc.expectedStatus || c.Reason != tc.expectedReason {
+				t.Fatalf("Expected to get status %s with reason %s but got %s with reason %s", tc.expectedStatus, tc.expectedReason, c.Status, c.Reason)
+			}
+		})
+	}
+}
+
+func TestGetPipelineConditionStatus_Stopped(t *testing.T) {
+	tcs := []struct {
+		name           string
+		state          []*ResolvedPipelineRunTask
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{{
+		name:           "no-tasks-started",
+		state:          noneStartedState,
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: ReasonStopped,
+	}, {
+		name:           "one-task-started",
+		state:          oneStartedState,
+		expectedStatus: corev1.ConditionUnknown,
+		expectedReason: ReasonRunning,
+	}, {
+		name:           "one-task-finished",
+		state:          oneFinishedState,
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: ReasonStopped,
+	}, {
+		name:           "all-finished",
+		state:          allFinishedState,
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: ReasonSucceeded,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := tb.PipelineRun("somepipelinerun", "foo",
+				tb.PipelineRunSpec("pipeline", tb.PipelineRunStoppedRunFinally),
+			)
+			dag, err := DagFromState(tc.state)
+			if err != nil {
+				t.Fatalf("Unexpected error while buildig DAG for state %v: %v", tc.state, err)
+			}
+			c := GetPipelineConditionStatus(pr, tc.state, nil, zap.NewNop().Sugar(), dag)
+			if c.Status != tc.expectedStatus || c.Reason != tc.expectedReason {
+				t.Fatalf("Expected to get status %s with reason %s but got %s with reason %s", tc.expectedStatus, tc.expectedReason, c.Status, c.Reason)
+			}
+		})
+	}
+}
+
 func TestGetResourcesFromBindings(t *testing.T) {
 	pr := tb.PipelineRun("pipelinerun", "namespace", tb.PipelineRunSpec("pipeline",
 		tb.PipelineRunResourceBinding("git-resource", tb.PipelineResourceBindingRef("sweet-resource")),
@@ -1141,7 +1384,7 @@ func TestResolvePipelineRun(t *testing.T) {
 	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, nil }
 	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
 
-	pipelineState, err := ResolvePipelineRun(pr, getTask, getTaskRun, getClusterTask, getCondition, p.Spec.Tasks, providedResources)
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, nil, getClusterTask, nil, getCondition, p.Spec.Tasks, providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -1210,7 +1453,7 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 			Name: "pipelinerun",
 		},
 	}
-	pipelineState, err := ResolvePipelineRun(pr, getTask, getTaskRun, getClusterTask, getCondition, pts, providedResources)
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, nil, getClusterTask, nil, getCondition, pts, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Resources: %v", err)
 	}
@@ -1257,7 +1500,7 @@ func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 			Name: "pipelinerun",
 		},
 	}
-	_, err := ResolvePipelineRun(pr, getTask, getTaskRun, getClusterTask, getCondition, pts, providedResources)
+	_, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, nil, getClusterTask, nil, getCondition, pts, providedResources)
 	switch err := err.(type) {
 	case nil:
 		t.Fatalf("Expected error getting non-existent Tasks for Pipeline %s but got none", p.Name)
@@ -1268,6 +1511,126 @@ func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 	}
 }
 
+func TestResolvePipelineRun_CustomTask(t *testing.T) {
+	pts := []v1alpha1.PipelineTask{{
+		Name:    "wait",
+		TaskRef: v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait", Name: "wait-10s"},
+	}, {
+		Name:    "new-wait",
+		TaskRef: v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait", Name: "wait-10s"},
+	}}
+	run := &v1alpha1.Run{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-wait"}}
+	// The custom tasks aren't fetched: getTask and getTaskRun fail.
+	getTask := func(name string) (v1alpha1.TaskInterface, error) {
+		return nil, kerrors.NewNotFound(v1alpha1.Resource("task"), name)
+	}
+	getTaskRun := func(name string) (*v1alpha1.TaskRun, error) {
+		return nil, kerrors.NewNotFound(v1alpha1.Resource("taskrun"), name)
+	}
+	getRun := func(name string) (*v1alpha1.Run, error) {
+		if name == run.Name {
+			return run, nil
+		}
+		return nil, kerrors.NewNotFound(v1alpha1.Resource("run"), name)
+	}
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Status: v1alpha1.PipelineRunStatus{Runs: map[string]*v1alpha1.PipelineRunRunStatus{
+			"pipelinerun-wait": {PipelineTaskName: "wait"},
+		}},
+	}
+	names.TestingSeed()
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, getRun, nil, nil, nil, pts, nil)
+	if err != nil {
+		t.Fatalf("ResolvePipelineRun() = %v", err)
+	}
+	expectedState := PipelineRunState{{
+		PipelineTask: &pts[0],
+		CustomTask:   true,
+		RunName:      "pipelinerun-wait",
+		Run:          run,
+	}, {
+		PipelineTask: &pts[1],
+		CustomTask:   true,
+		RunName:      "pipelinerun-new-wait-9l9zj",
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Expected to get current pipeline state %v, but actual differed: %s", expectedState, d)
+	}
+}
+
+func TestResolvedPipelineRunTask_CustomTask(t *testing.T) {
+	withRunStatus := func(status corev1.ConditionStatus) *v1alpha1.Run {
+		r := &v1alpha1.Run{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-wait"}}
+		r.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: status})
+		return r
+	}
+	retried := withRunStatus(corev1.ConditionFalse)
+	retried.Status.RetriesStatus = []v1alpha1.RunStatus{withRunStatus(corev1.ConditionFalse).Status}
+	cancelled := withRunStatus(corev1.ConditionFalse)
+	cancelled.Spec.Status = v1alpha1.RunSpecStatusCancelled
+	for _, tc := range []struct {
+		name           string
+		run            *v1alpha1.Run
+		retries        int
+		wantDone       bool
+		wantSuccessful bool
+		wantFailure    bool
+	}{{
+		name: "no run",
+	}, {
+		name: "run without status",
+		run:  &v1alpha1.Run{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-wait"}},
+	}, {
+		name: "run running",
+		run:  withRunStatus(corev1.ConditionUnknown),
+	}, {
+		name:           "run succeeded",
+		run:            withRunStatus(corev1.ConditionTrue),
+		wantDone:       true,
+		wantSuccessful: true,
+	}, {
+		name:        "run failed",
+		run:         withRunStatus(corev1.ConditionFalse),
+		wantDone:    true,
+		wantFailure: true,
+	}, {
+		name:    "run failed with retries left",
+		run:     withRunStatus(corev1.ConditionFalse),
+		retries: 1,
+	}, {
+		name:        "run failed after its retries",
+		run:         retried,
+		retries:     1,
+		wantDone:    true,
+		wantFailure: true,
+	}, {
+		name:        "run cancelled with retries left",
+		run:         cancelled,
+		retries:     1,
+		wantDone:    true,
+		wantFailure: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rprt := ResolvedPipelineRunTask{
+				PipelineTask: &v1alpha1.PipelineTask{Name: "wait", Retries: tc.retries},
+				CustomTask:   true,
+				RunName:      "pipelinerun-wait",
+				Run:          tc.run,
+			}
+			if got := rprt.IsDone(); got != tc.wantDone {
+				t.Errorf("IsDone() = %t, want %t", got, tc.wantDone)
+			}
+			if got := rprt.IsSuccessful(); got != tc.wantSuccessful {
+				t.Errorf("IsSuccessful() = %t, want %t", got, tc.wantSuccessful)
+			}
+			if got := rprt.IsFailure(); got != tc.wantFailure {
+				t.Errorf("IsFailure() = %t, want %t", got, tc.wantFailure)
+			}
+		})
+	}
+}
+
 func TestResolvePipelineRun_ResourceBindingsDontExist(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1303,7 +1666,7 @@ func TestResolvePipelineRun_ResourceBindingsDontExist(t *testing.T) {
 					Name: "pipelinerun",
 				},
 			}
-			_, err := ResolvePipelineRun(pr, getTask, getTaskRun, getClusterTask, getCondition, tt.p.Spec.Tasks, providedResources)
+			_, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, nil, getClusterTask, nil, getCondition, tt.p.Spec.Tasks, providedResources)
 			if err == nil {
 				t.Fatalf("Expected error when bindings are in incorrect state for Pipeline %s but got none", p.Name)
 			}
@@ -1353,7 +1716,7 @@ func TestResolvePipelineRun_withExistingTaskRuns(t *testing.T) {
 	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, nil }
 	getTaskRun := func(name string) (*v1alpha1.TaskRun, error) { return nil, nil }
 	getCondition := func(name string) (*v1alpha1.Condition, error) { return nil, nil }
-	pipelineState, err := ResolvePipelineRun(pr, getTask, getTaskRun, getClusterTask, getCondition, p.Spec.Tasks, providedResources)
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, nil, getClusterTask, nil, getCondition, p.Spec.Tasks, providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -1452,7 +1815,7 @@ func TestResolveConditionChecks(t *testing.T) {
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			pipelineState, err := ResolvePipelineRun(pr, getTask, tc.getTaskRun, getClusterTask, getCondition, pts, providedResources)
+			pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, tc.getTaskRun, nil, getClusterTask, nil, getCondition, pts, providedResources)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 			}
@@ -1543,7 +1906,7 @@ func TestResolveConditionChecks_MultipleConditions(t *testing.T) {
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			pipelineState, err := ResolvePipelineRun(pr, getTask, tc.getTaskRun, getClusterTask, getCondition, pts, providedResources)
+			pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, tc.getTaskRun, nil, getClusterTask, nil, getCondition, pts, providedResources)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 			}
@@ -1587,7 +1950,7 @@ func TestResolveConditionChecks_ConditionDoesNotExist(t *testing.T) {
 		},
 	}
 
-	_, err := ResolvePipelineRun(pr, getTask, getTaskRun, getClusterTask, getCondition, pts, providedResources)
+	_, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, nil, getClusterTask, nil, getCondition, pts, providedResources)
 
 	switch err := err.(type) {
 	case nil:
@@ -1656,7 +2019,7 @@ func TestResolveConditionCheck_UseExistingConditionCheckName(t *testing.T) {
 		},
 	}
 
-	pipelineState, err := ResolvePipelineRun(pr, getTask, getTaskRun, getClusterTask, getCondition, pts, providedResources)
+	pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, nil, getClusterTask, nil, getCondition, pts, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Conditions: %v", err)
 	}
@@ -1731,7 +2094,7 @@ func TestResolvedConditionCheck_WithResources(t *testing.T) {
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			pipelineState, err := ResolvePipelineRun(pr, getTask, getTaskRun, getClusterTask, getCondition, pts, tc.providedResources)
+			pipelineState, err := ResolvePipelineRun(context.Background(), pr, getTask, getTaskRun, nil, getClusterTask, nil, getCondition, pts, tc.providedResources)
 
 			if tc.wantErr {
 				if err == nil {
@@ -1782,6 +2145,47 @@ func TestValidateResourceBindings_Missing(t *testing.T) {
 	}
 }
 
+func TestValidateWorkspaceBindings(t *testing.T) {
+	p := tb.Pipeline("pipelines", "namespace", tb.PipelineSpec(
+		tb.PipelineWorkspaceDeclaration("source", "cache"),
+	))
+	for _, tc := range []struct {
+		name    string
+		pr      *v1alpha1.PipelineRun
+		wantErr bool
+	}{{
+		name: "all bound",
+		pr: tb.PipelineRun("pipelinerun", "namespace", tb.PipelineRunSpec("pipeline",
+			tb.PipelineRunWorkspaceBindingClaim("source", "pvc", "src"),
+			tb.PipelineRunWorkspaceBindingEmptyDir("cache"),
+		)),
+	}, {
+		name: "missing binding",
+		pr: tb.PipelineRun("pipelinerun", "namespace", tb.PipelineRunSpec("pipeline",
+			tb.PipelineRunWorkspaceBindingClaim("source", "pvc", ""),
+		)),
+		wantErr: true,
+	}, {
+		name: "extra binding",
+		pr: tb.PipelineRun("pipelinerun", "namespace", tb.PipelineRunSpec("pipeline",
+			tb.PipelineRunWorkspaceBindingClaim("source", "pvc", ""),
+			tb.PipelineRunWorkspaceBindingEmptyDir("cache"),
+			tb.PipelineRunWorkspaceBindingEmptyDir("output"),
+		)),
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateWorkspaceBindings(&p.Spec, tc.pr)
+			if tc.wantErr && err == nil {
+				t.Error("Expected an error, got none")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
 func TestGetResourcesFromBindings_Extra(t *testing.T) {
 	p := tb.Pipeline("pipelines", "namespace", tb.PipelineSpec(
 		tb.PipelineDeclaredResource("git-resource", "git"),
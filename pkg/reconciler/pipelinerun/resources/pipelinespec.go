@@ -33,7 +33,7 @@ func GetPipelineData(pipelineRun *v1alpha1.PipelineRun, getPipeline GetPipeline)
 	pipelineMeta := metav1.ObjectMeta{}
 	pipelineSpec := v1alpha1.PipelineSpec{}
 	switch {
-	case pipelineRun.Spec.PipelineRef != nil && pipelineRun.Spec.PipelineRef.Name != "":
+	case pipelineRun.Spec.PipelineRef != nil && (pipelineRun.Spec.PipelineRef.Name != "" || pipelineRun.Spec.PipelineRef.Resolver != ""):
 		// Get related pipeline for pipelinerun
 		t, err := getPipeline(pipelineRun.Spec.PipelineRef.Name)
 		if err != nil {
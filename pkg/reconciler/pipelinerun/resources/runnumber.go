@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+const (
+	// LastRunNumberAnnotation is the annotation of a Pipeline holding the
+	// number of its last PipelineRun.
+	LastRunNumberAnnotation = "tekton.dev/last-run-number"
+
+	runNumberVariable = "context.pipelineRun.number"
+)
+
+// UsesRunNumber returns whether the params of the PipelineTasks and finally
+// tasks, of their matrix, of their Conditions or their WhenExpressions use the
+// $(context.pipelineRun.number) variable.
+func UsesRunNumber(p *v1alpha1.PipelineSpec) bool {
+	uses := func(values []string) bool {
+		for _, value := range values {
+			if strings.Contains(value, "$("+runNumberVariable+")") {
+				return true
+			}
+		}
+		return false
+	}
+	usesInParams := func(params []v1alpha1.Param) bool {
+		for _, param := range params {
+			if uses(append([]string{param.Value.StringVal}, param.Value.ArrayVal...)) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, t := range append(append([]v1alpha1.PipelineTask{}, p.Tasks...), p.Finally...) {
+		if usesInParams(t.Params) || usesInParams(t.Matrix) {
+			return true
+		}
+		for _, c := range t.Conditions {
+			if usesInParams(c.Params) {
+				return true
+			}
+		}
+		for _, we := range t.WhenExpressions {
+			if uses(append([]string{we.Input}, we.Values...)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NextRunNumber returns the number of the next PipelineRun of the Pipeline,
+// after the one of its LastRunNumberAnnotation, and sets the annotation to it.
+// The caller is responsible for updating the Pipeline.
+func NextRunNumber(p *v1alpha1.Pipeline) (int64, error) {
+	var last int64
+	if value, ok := p.Annotations[LastRunNumberAnnotation]; ok {
+		var err error
+		if last, err = strconv.ParseInt(value, 10, 64); err != nil || last < 0 {
+			return 0, fmt.Errorf("the %s annotation of Pipeline %s should be a number, not %q", LastRunNumberAnnotation, p.Name, value)
+		}
+	}
+	if p.Annotations == nil {
+		p.Annotations = map[string]string{}
+	}
+	p.Annotations[LastRunNumberAnnotation] = strconv.FormatInt(last+1, 10)
+	return last + 1, nil
+}
+
+// ApplyRunNumber replaces the $(context.pipelineRun.number) variable of the
+// PipelineSpec with number.
+func ApplyRunNumber(p *v1alpha1.PipelineSpec, number int64) *v1alpha1.PipelineSpec {
+	return ApplyReplacements(p, map[string]string{runNumberVariable: strconv.FormatInt(number, 10)}, map[string][]string{})
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+func TestUsesRunNumber(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		p    *v1alpha1.Pipeline
+		want bool
+	}{{
+		name: "param",
+		p: tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskParam("version", "1.0.$(context.pipelineRun.number)")),
+		)),
+		want: true,
+	}, {
+		name: "when expression",
+		p: tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskWhenExpression("$(context.pipelineRun.number)", selection.NotIn, "1")),
+		)),
+		want: true,
+	}, {
+		name: "finally task",
+		p: tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task"),
+			tb.FinallyTask("notify", "notify-task",
+				tb.PipelineTaskParam("build", "#$(context.pipelineRun.number)")),
+		)),
+		want: true,
+	}, {
+		name: "no number",
+		p: tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("build", "build-task",
+				tb.PipelineTaskParam("version", "$(params.version)")),
+		)),
+		want: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := UsesRunNumber(&tc.p.Spec); got != tc.want {
+				t.Errorf("UsesRunNumber() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNextRunNumber(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		want        int64
+		wantErr     bool
+	}{{
+		name: "first run",
+		want: 1,
+	}, {
+		name:        "next run",
+		annotations: map[string]string{LastRunNumberAnnotation: "41"},
+		want:        42,
+	}, {
+		name:        "invalid annotation",
+		annotations: map[string]string{LastRunNumberAnnotation: "latest"},
+		wantErr:     true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := tb.Pipeline("test-pipeline", "foo")
+			p.Annotations = tc.annotations
+			got, err := NextRunNumber(p)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got number %d", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NextRunNumber() = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("NextRunNumber() = %d, want %d", got, tc.want)
+			}
+			if got, want := p.Annotations[LastRunNumberAnnotation], strconv.FormatInt(tc.want, 10); got != want {
+				t.Errorf("Last run number = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyRunNumber(t *testing.T) {
+	p := tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "build-task",
+			tb.PipelineTaskParam("version", "1.0.$(context.pipelineRun.number)"),
+			tb.PipelineTaskParam("tags", "latest", "build-$(context.pipelineRun.number)")),
+	))
+	want := tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "build-task",
+			tb.PipelineTaskParam("version", "1.0.42"),
+			tb.PipelineTaskParam("tags", "latest", "build-42")),
+	))
+	if d := cmp.Diff(&want.Spec, ApplyRunNumber(&p.Spec, 42)); d != "" {
+		t.Errorf("ApplyRunNumber() diff -want, +got: %s", d)
+	}
+}
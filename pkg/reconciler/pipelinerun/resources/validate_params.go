@@ -24,17 +24,18 @@ import (
 
 // Validate that parameters in PipelineRun override corresponding parameters in Pipeline of the same type.
 func ValidateParamTypesMatching(p *v1alpha1.PipelineSpec, pr *v1alpha1.PipelineRun) error {
-	// Build a map of parameter names/types declared in p.
-	paramTypes := make(map[string]v1alpha1.ParamType)
+	// Build a map of parameters declared in p by name.
+	paramSpecs := make(map[string]v1alpha1.ParamSpec)
 	for _, param := range p.Params {
-		paramTypes[param.Name] = param.Type
+		paramSpecs[param.Name] = param
 	}
 
-	// Build a list of parameter names from pr that have mismatching types with the map created above.
+	// Build a list of parameter names from pr that have mismatching types, or
+	// keys for objects, with the map created above.
 	var wrongTypeParamNames []string
 	for _, param := range pr.Spec.Params {
-		if paramType, ok := paramTypes[param.Name]; ok {
-			if param.Value.Type != paramType {
+		if spec, ok := paramSpecs[param.Name]; ok {
+			if !spec.Matches(param.Value) {
 				wrongTypeParamNames = append(wrongTypeParamNames, param.Name)
 			}
 		}
@@ -46,3 +47,24 @@ func ValidateParamTypesMatching(p *v1alpha1.PipelineSpec, pr *v1alpha1.PipelineR
 	}
 	return nil
 }
+
+// ValidateParamEnums validates that the values of the parameters in PipelineRun
+// are among the enum values of the corresponding parameters in Pipeline.
+func ValidateParamEnums(p *v1alpha1.PipelineSpec, pr *v1alpha1.PipelineRun) error {
+	paramSpecs := make(map[string]v1alpha1.ParamSpec)
+	for _, param := range p.Params {
+		paramSpecs[param.Name] = param
+	}
+
+	var notAllowedParamNames []string
+	for _, param := range pr.Spec.Params {
+		if spec, ok := paramSpecs[param.Name]; ok && !spec.Allows(param.Value) {
+			notAllowedParamNames = append(notAllowedParamNames, param.Name)
+		}
+	}
+
+	if len(notAllowedParamNames) != 0 {
+		return fmt.Errorf("parameters aren't one of their enum values : %s", notAllowedParamNames)
+	}
+	return nil
+}
@@ -112,6 +112,17 @@ func TestValidateParamTypesMatching_Invalid(t *testing.T) {
 				tb.PipelineRunParam("correct-type-1", "somestring"),
 				tb.PipelineRunParam("mismatching-type", "astring"),
 				tb.PipelineRunParam("correct-type-2", "another", "array"))),
+	}, {
+		name: "object missing a key",
+		p: tb.Pipeline("a-pipeline", namespace, tb.PipelineSpec(
+			tb.PipelineParamSpec("repo", v1alpha1.ParamTypeObject, tb.ParamSpecProperties("url", "revision")))),
+		pr: tb.PipelineRun("a-pipelinerun", namespace,
+			tb.PipelineRunSpec("test-pipeline", func(spec *v1alpha1.PipelineRunSpec) {
+				spec.Params = append(spec.Params, v1alpha1.Param{
+					Name:  "repo",
+					Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeObject, ObjectVal: map[string]string{"url": "https://github.com/tektoncd/pipeline"}},
+				})
+			})),
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -121,3 +132,39 @@ func TestValidateParamTypesMatching_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateParamEnums(t *testing.T) {
+	p := tb.Pipeline("a-pipeline", namespace, tb.PipelineSpec(
+		tb.PipelineParamSpec("arch", v1alpha1.ParamTypeString, tb.ParamSpecEnum("amd64", "arm64")),
+		tb.PipelineParamSpec("other", v1alpha1.ParamTypeString)))
+	tcs := []struct {
+		name          string
+		pr            *v1alpha1.PipelineRun
+		errorExpected bool
+	}{{
+		name: "enum value",
+		pr: tb.PipelineRun("a-pipelinerun", namespace,
+			tb.PipelineRunSpec("test-pipeline",
+				tb.PipelineRunParam("arch", "arm64"),
+				tb.PipelineRunParam("other", "anything"))),
+		errorExpected: false,
+	}, {
+		name:          "no params",
+		pr:            tb.PipelineRun("a-pipelinerun", namespace),
+		errorExpected: false,
+	}, {
+		name: "value not in enum",
+		pr: tb.PipelineRun("a-pipelinerun", namespace,
+			tb.PipelineRunSpec("test-pipeline",
+				tb.PipelineRunParam("arch", "s390x"))),
+		errorExpected: true,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateParamEnums(&p.Spec, tc.pr)
+			if (err != nil) != tc.errorExpected {
+				t.Errorf("expected error %t, got %v", tc.errorExpected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scheduler holds back the TaskRuns of PipelineRuns while a global
+// number of them is running, and shares the free slots fairly between the
+// namespaces waiting for them.
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// inflightTimeout is how long a TaskRun that was admitted counts as running
+// while it doesn't show in the running TaskRuns, e.g. because the informer
+// cache didn't observe its creation yet.
+const inflightTimeout = time.Minute
+
+// Running returns the keys of the running TaskRuns of PipelineRuns, by
+// namespace.
+type Running func() (map[string]sets.String, error)
+
+// Scheduler admits the TaskRuns of PipelineRuns as long as fewer than Max of
+// them are running. PipelineRuns that have to wait for a free slot are
+// remembered, and each slot goes to the waiting namespace running the fewest
+// TaskRuns relative to its weight, so that one namespace submitting many
+// PipelineRuns can't starve the others.
+type Scheduler struct {
+	max     int
+	weights map[string]int
+	running Running
+	enqueue func(key string)
+	now     func() time.Time
+
+	mu sync.Mutex
+	// waiting holds, by namespace, the keys of the PipelineRuns waiting for
+	// a slot and since when they wait.
+	waiting map[string]map[string]time.Time
+	// inflight holds the namespace and admission time of the admitted
+	// TaskRuns that Running doesn't return yet.
+	inflight map[string]inflightTaskRun
+}
+
+type inflightTaskRun struct {
+	namespace string
+	admitted  time.Time
+}
+
+// New returns a Scheduler running at most max TaskRuns, counting the running
+// TaskRuns with running. The namespaces without a weight have weight 1.
+// enqueue is called with the key of a waiting PipelineRun when a slot is free
+// for it.
+func New(max int, weights map[string]int, running Running, enqueue func(key string)) *Scheduler {
+	return &Scheduler{
+		max:      max,
+		weights:  weights,
+		running:  running,
+		enqueue:  enqueue,
+		now:      time.Now,
+		waiting:  map[string]map[string]time.Time{},
+		inflight: map[string]inflightTaskRun{},
+	}
+}
+
+// Admit returns the keys of taskRuns, the TaskRuns that the PipelineRun key
+// of namespace would create, that it may create now, in order. The
+// PipelineRun waits for the others: it is enqueued once a slot is free for
+// it, and must then call Admit again.
+func (s *Scheduler) Admit(namespace, key string, taskRuns []string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts, err := s.counts()
+	if err != nil {
+		return nil, err
+	}
+	if len(taskRuns) > 0 {
+		if _, ok := s.waiting[namespace][key]; !ok {
+			if s.waiting[namespace] == nil {
+				s.waiting[namespace] = map[string]time.Time{}
+			}
+			s.waiting[namespace][key] = s.now()
+		}
+	}
+	var admitted []string
+	for _, tr := range taskRuns {
+		if total(counts) >= s.max || s.next(counts) != namespace {
+			break
+		}
+		admitted = append(admitted, tr)
+		counts[namespace]++
+		s.inflight[tr] = inflightTaskRun{namespace: namespace, admitted: s.now()}
+	}
+	if len(admitted) == len(taskRuns) {
+		s.forget(namespace, key)
+	}
+	s.wakeNext(counts)
+	return admitted, nil
+}
+
+// Forget stops the PipelineRun key of namespace from waiting, e.g. because
+// it completed or was deleted, and hands the free slots to the PipelineRuns
+// waiting for them.
+func (s *Scheduler) Forget(namespace, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.forget(namespace, key)
+	if counts, err := s.counts(); err == nil {
+		s.wakeNext(counts)
+	}
+}
+
+func (s *Scheduler) forget(namespace, key string) {
+	delete(s.waiting[namespace], key)
+	if len(s.waiting[namespace]) == 0 {
+		delete(s.waiting, namespace)
+	}
+}
+
+// counts returns the number of running TaskRuns by namespace, including the
+// admitted TaskRuns that Running doesn't return yet.
+func (s *Scheduler) counts() (map[string]int, error) {
+	running, err := s.running()
+	if err != nil {
+		return nil, fmt.Errorf("listing the running TaskRuns: %w", err)
+	}
+	counts := map[string]int{}
+	for ns, keys := range running {
+		counts[ns] = keys.Len()
+	}
+	for tr, i := range s.inflight {
+		if running[i.namespace].Has(tr) || s.now().Sub(i.admitted) > inflightTimeout {
+			delete(s.inflight, tr)
+			continue
+		}
+		counts[i.namespace]++
+	}
+	return counts, nil
+}
+
+func total(counts map[string]int) int {
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	return n
+}
+
+func (s *Scheduler) weight(namespace string) int {
+	if w, ok := s.weights[namespace]; ok && w > 0 {
+		return w
+	}
+	return 1
+}
+
+// next returns the waiting namespace the next slot goes to: the one running
+// the fewest TaskRuns relative to its weight, and among those the one waiting
+// for the longest.
+func (s *Scheduler) next(counts map[string]int) string {
+	var next string
+	var nextSince time.Time
+	for ns := range s.waiting {
+		since := s.waitingSince(ns)
+		if next == "" {
+			next, nextSince = ns, since
+			continue
+		}
+		// Compare counts[ns]/weight(ns) with counts[next]/weight(next).
+		lhs, rhs := counts[ns]*s.weight(next), counts[next]*s.weight(ns)
+		if lhs < rhs || lhs == rhs && (since.Before(nextSince) || since.Equal(nextSince) && ns < next) {
+			next, nextSince = ns, since
+		}
+	}
+	return next
+}
+
+func (s *Scheduler) waitingSince(namespace string) time.Time {
+	var since time.Time
+	for _, t := range s.waiting[namespace] {
+		if since.IsZero() || t.Before(since) {
+			since = t
+		}
+	}
+	return since
+}
+
+// wakeNext enqueues the PipelineRuns of the namespace the next slot goes to,
+// if a slot is free.
+func (s *Scheduler) wakeNext(counts map[string]int) {
+	if total(counts) >= s.max {
+		return
+	}
+	next := s.next(counts)
+	keys := make([]string, 0, len(s.waiting[next]))
+	for key := range s.waiting[next] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s.enqueue(key)
+	}
+}
+
+// ParseWeights parses weights of the form "team-a=3,team-b=2" into the
+// weight of each namespace.
+func ParseWeights(s string) (map[string]int, error) {
+	weights := map[string]int{}
+	for _, entry := range strings.Split(s, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("namespace weight %q is not of the form <namespace>=<weight>", entry)
+		}
+		w, err := strconv.Atoi(parts[1])
+		if err != nil || w < 1 {
+			return nil, fmt.Errorf("weight of namespace %q must be a positive integer, got %q", parts[0], parts[1])
+		}
+		weights[parts[0]] = w
+	}
+	return weights, nil
+}
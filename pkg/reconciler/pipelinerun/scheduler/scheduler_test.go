@@ -0,0 +1,201 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// fakeCluster tracks the running TaskRuns and the enqueued PipelineRuns.
+type fakeCluster struct {
+	running  map[string]sets.String
+	enqueued []string
+}
+
+func (c *fakeCluster) list() (map[string]sets.String, error) {
+	return c.running, nil
+}
+
+func (c *fakeCluster) enqueue(key string) {
+	c.enqueued = append(c.enqueued, key)
+}
+
+func newScheduler(max int, weights map[string]int) (*Scheduler, *fakeCluster) {
+	c := &fakeCluster{running: map[string]sets.String{}}
+	s := New(max, weights, c.list, c.enqueue)
+	now := time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	return s, c
+}
+
+func TestAdmit(t *testing.T) {
+	s, c := newScheduler(3, nil)
+
+	got, err := s.Admit("team-a", "team-a/big", []string{"team-a/big-1", "team-a/big-2", "team-a/big-3", "team-a/big-4"})
+	if err != nil {
+		t.Fatalf("Admit() = %v", err)
+	}
+	if d := cmp.Diff([]string{"team-a/big-1", "team-a/big-2", "team-a/big-3"}, got); d != "" {
+		t.Errorf("Admit() diff -want, +got: %s", d)
+	}
+
+	// The cap applies to an other namespace too.
+	got, err = s.Admit("team-b", "team-b/small", []string{"team-b/small-1"})
+	if err != nil {
+		t.Fatalf("Admit() = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Admit() = %v, want nothing admitted", got)
+	}
+
+	// The informer observes the admitted TaskRuns, then one of them completes:
+	// the slot goes to team-b, which runs fewer TaskRuns.
+	c.running["team-a"] = sets.NewString("team-a/big-1", "team-a/big-2", "team-a/big-3")
+	if _, err := s.Admit("team-a", "team-a/big", []string{"team-a/big-4"}); err != nil {
+		t.Fatalf("Admit() = %v", err)
+	}
+	c.running["team-a"].Delete("team-a/big-1")
+	got, err = s.Admit("team-a", "team-a/big", []string{"team-a/big-4"})
+	if err != nil {
+		t.Fatalf("Admit() = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Admit() = %v, want nothing admitted", got)
+	}
+	if d := cmp.Diff([]string{"team-b/small"}, c.enqueued); d != "" {
+		t.Errorf("enqueued diff -want, +got: %s", d)
+	}
+	got, err = s.Admit("team-b", "team-b/small", []string{"team-b/small-1"})
+	if err != nil {
+		t.Fatalf("Admit() = %v", err)
+	}
+	if d := cmp.Diff([]string{"team-b/small-1"}, got); d != "" {
+		t.Errorf("Admit() diff -want, +got: %s", d)
+	}
+}
+
+func TestAdmitWeights(t *testing.T) {
+	s, c := newScheduler(4, map[string]int{"team-a": 3})
+	c.running["team-c"] = sets.NewString("team-c/run-1", "team-c/run-2", "team-c/run-3", "team-c/run-4")
+	for _, tc := range []struct {
+		namespace, key string
+		taskRuns       []string
+	}{{
+		namespace: "team-b",
+		key:       "team-b/run",
+		taskRuns:  []string{"team-b/run-1", "team-b/run-2"},
+	}, {
+		namespace: "team-a",
+		key:       "team-a/run",
+		taskRuns:  []string{"team-a/run-1", "team-a/run-2", "team-a/run-3"},
+	}} {
+		if got, err := s.Admit(tc.namespace, tc.key, tc.taskRuns); err != nil || len(got) != 0 {
+			t.Fatalf("Admit() = %v, %v, want nothing admitted", got, err)
+		}
+	}
+
+	// The TaskRuns of team-c complete. team-b waits for the longest, so it
+	// gets the first slot, then team-a gets three slots for team-b's one.
+	delete(c.running, "team-c")
+	s.Forget("team-c", "team-c/run")
+	if d := cmp.Diff([]string{"team-b/run"}, c.enqueued); d != "" {
+		t.Errorf("enqueued diff -want, +got: %s", d)
+	}
+	got, err := s.Admit("team-b", "team-b/run", []string{"team-b/run-1", "team-b/run-2"})
+	if err != nil {
+		t.Fatalf("Admit() = %v", err)
+	}
+	if d := cmp.Diff([]string{"team-b/run-1"}, got); d != "" {
+		t.Errorf("Admit() diff -want, +got: %s", d)
+	}
+	if d := cmp.Diff([]string{"team-b/run", "team-a/run"}, c.enqueued); d != "" {
+		t.Errorf("enqueued diff -want, +got: %s", d)
+	}
+	got, err = s.Admit("team-a", "team-a/run", []string{"team-a/run-1", "team-a/run-2", "team-a/run-3"})
+	if err != nil {
+		t.Fatalf("Admit() = %v", err)
+	}
+	if d := cmp.Diff([]string{"team-a/run-1", "team-a/run-2", "team-a/run-3"}, got); d != "" {
+		t.Errorf("Admit() diff -want, +got: %s", d)
+	}
+}
+
+func TestAdmitInflightTimeout(t *testing.T) {
+	s, c := newScheduler(1, nil)
+	if got, err := s.Admit("team-a", "team-a/run", []string{"team-a/run-1"}); err != nil || len(got) != 1 {
+		t.Fatalf("Admit() = %v, %v", got, err)
+	}
+	// The TaskRun was never created, so its slot is freed after a while.
+	if got, err := s.Admit("team-a", "team-a/other", []string{"team-a/other-1"}); err != nil || len(got) != 0 {
+		t.Fatalf("Admit() = %v, %v", got, err)
+	}
+	s.now = func() time.Time { return time.Date(2019, 12, 1, 1, 0, 0, 0, time.UTC) }
+	if got, err := s.Admit("team-a", "team-a/other", []string{"team-a/other-1"}); err != nil || len(got) != 1 {
+		t.Fatalf("Admit() = %v, %v", got, err)
+	}
+	if len(c.enqueued) != 0 {
+		t.Errorf("enqueued = %v, want none", c.enqueued)
+	}
+}
+
+func TestAdmitRunningError(t *testing.T) {
+	s := New(1, nil, func() (map[string]sets.String, error) { return nil, errors.New("not synced") }, func(string) {})
+	if _, err := s.Admit("team-a", "team-a/run", []string{"team-a/run-1"}); err == nil {
+		t.Error("Admit() expected an error")
+	}
+}
+
+func TestParseWeights(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    map[string]int
+		wantErr bool
+	}{{
+		in:   "",
+		want: map[string]int{},
+	}, {
+		in:   "team-a=3, team-b=1",
+		want: map[string]int{"team-a": 3, "team-b": 1},
+	}, {
+		in:      "team-a",
+		wantErr: true,
+	}, {
+		in:      "team-a=0",
+		wantErr: true,
+	}, {
+		in:      "team-a=heavy",
+		wantErr: true,
+	}} {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseWeights(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseWeights() = %v, wantErr %t", err, tc.wantErr)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("ParseWeights() diff -want, +got: %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/scheduler"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// eventReasonTaskRunsQueued is the reason of the event recorded when
+// TaskRuns of a PipelineRun wait for other TaskRuns to complete.
+const eventReasonTaskRunsQueued = "TaskRunsQueued"
+
+// SchedulingPolicy limits the TaskRuns of PipelineRuns running at once.
+type SchedulingPolicy struct {
+	// MaxRunningTaskRuns is how many TaskRuns of PipelineRuns may run at
+	// once, across all namespaces. There is no limit if it is 0.
+	MaxRunningTaskRuns int
+	// NamespaceWeights are the shares of the free slots that the namespaces
+	// get when several of them wait for one, 1 by default.
+	NamespaceWeights map[string]int
+}
+
+// runningTaskRuns returns the running TaskRuns of PipelineRuns listed by
+// lister.
+func runningTaskRuns(lister listers.TaskRunLister) scheduler.Running {
+	return func() (map[string]sets.String, error) {
+		req, err := labels.NewRequirement(pipeline.GroupName+pipeline.PipelineRunLabelKey, selection.Exists, nil)
+		if err != nil {
+			return nil, err
+		}
+		trs, err := lister.List(labels.NewSelector().Add(*req))
+		if err != nil {
+			return nil, err
+		}
+		running := map[string]sets.String{}
+		for _, tr := range trs {
+			if tr.IsDone() {
+				continue
+			}
+			if running[tr.Namespace] == nil {
+				running[tr.Namespace] = sets.NewString()
+			}
+			running[tr.Namespace].Insert(tr.Namespace + "/" + tr.Name)
+		}
+		return running, nil
+	}
+}
+
+// createsTaskRun returns true if rprt creates the TaskRun of its
+// PipelineTask, rather than its condition checks or the Run of its custom
+// task.
+func createsTaskRun(rprt *resources.ResolvedPipelineRunTask) bool {
+	return !rprt.CustomTask && (rprt.ResolvedConditionChecks == nil || rprt.ResolvedConditionChecks.IsSuccess())
+}
+
+// admit returns the rprts that may create their TaskRun now; the others wait
+// for a slot of the scheduler. The condition checks and Runs aren't held back.
+func (c *Reconciler) admit(pr *v1alpha1.PipelineRun, rprts []*resources.ResolvedPipelineRunTask) ([]*resources.ResolvedPipelineRunTask, error) {
+	if c.scheduler == nil {
+		return rprts, nil
+	}
+	var keys []string
+	for _, rprt := range rprts {
+		if rprt != nil && createsTaskRun(rprt) {
+			keys = append(keys, pr.Namespace+"/"+rprt.TaskRunName)
+		}
+	}
+	admitted, err := c.scheduler.Admit(pr.Namespace, pr.Namespace+"/"+pr.Name, keys)
+	if err != nil {
+		return nil, err
+	}
+	if queued := len(keys) - len(admitted); queued > 0 {
+		c.Recorder.Eventf(pr, corev1.EventTypeNormal, eventReasonTaskRunsQueued, "%d TaskRuns wait for other TaskRuns to complete", queued)
+	}
+	isAdmitted := sets.NewString(admitted...)
+	var result []*resources.ResolvedPipelineRunTask
+	for _, rprt := range rprts {
+		if rprt == nil || createsTaskRun(rprt) && !isAdmitted.Has(pr.Namespace+"/"+rprt.TaskRunName) {
+			continue
+		}
+		result = append(result, rprt)
+	}
+	return result, nil
+}
+
+// forgetScheduled stops the PipelineRun key of namespace from waiting for a
+// slot of the scheduler.
+func (c *Reconciler) forgetScheduled(namespace, key string) {
+	if c.scheduler != nil {
+		c.scheduler.Forget(namespace, key)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/scheduler"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestReconcileWithMaxRunningTaskRuns(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-0", "hello-world-task"),
+		tb.PipelineTask("hello-world-1", "hello-world-task"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run", "foo",
+		tb.PipelineRunSpec("test-pipeline"),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world-task", "foo")}
+	// A TaskRun of a PipelineRun of another namespace takes one of the slots.
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("other-pipeline-run-build", "bar",
+			tb.TaskRunLabel("tekton.dev/pipelineRun", "other-pipeline-run"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("build")),
+		),
+		// Completed TaskRuns don't count.
+		tb.TaskRun("other-pipeline-run-test", "bar",
+			tb.TaskRunLabel("tekton.dev/pipelineRun", "other-pipeline-run"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("test")),
+			tb.TaskRunStatus(tb.StatusCondition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})),
+		),
+	}
+
+	d := reconcilertest.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	testAssets, cancel := getPipelineRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+	r := c.Reconciler.(*Reconciler)
+	var enqueued []string
+	r.scheduler = scheduler.New(2, nil, runningTaskRuns(r.taskRunLister), func(key string) { enqueued = append(enqueued, key) })
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+		t.Fatalf("Reconcile() = %v", err)
+	}
+
+	taskRuns, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(taskRuns.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRun to be created, got %d", len(taskRuns.Items))
+	}
+	if got := taskRuns.Items[0].Labels["tekton.dev/pipelineTask"]; got != "hello-world-0" {
+		t.Errorf("Expected the TaskRun of hello-world-0 to be created, got the one of %q", got)
+	}
+	if len(enqueued) != 0 {
+		t.Errorf("Expected no PipelineRun to be enqueued, got %v", enqueued)
+	}
+}
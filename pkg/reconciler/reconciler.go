@@ -17,13 +17,16 @@ limitations under the License.
 package reconciler
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	pipelineScheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	tklogging "github.com/tektoncd/pipeline/pkg/logging"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -56,6 +59,13 @@ func (o Options) GetTrackerLease() time.Duration {
 	return o.ResyncPeriod * 3
 }
 
+// HTTPDoer sends HTTP requests, it is implemented by *http.Client. The
+// reconcilers calling out to webhooks and sinks take one, so that tests can
+// replace the client.
+type HTTPDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
 // Base implements the core controller logic, given a Reconciler.
 type Base struct {
 	// KubeClientSet allows us to talk to the k8s for core APIs
@@ -88,8 +98,12 @@ type Base struct {
 // NewBase instantiates a new instance of Base implementing
 // the common & boilerplate code between our reconcilers.
 func NewBase(opt Options, controllerAgentName string, images pipeline.Images) *Base {
-	// Enrich the logs with controller name
-	logger := opt.Logger.Named(controllerAgentName).With(zap.String(logkey.ControllerType, controllerAgentName))
+	// Enrich the logs with controller name, and allow overriding the level of
+	// its logs at runtime.
+	logger := tklogging.DefaultLevels.Named(opt.Logger, controllerAgentName).With(zap.String(logkey.ControllerType, controllerAgentName))
+
+	// Apply the levels of config-logging for this controller without restarts.
+	watchLogLevels(opt.ConfigMapWatcher, logger)
 
 	// Use recorder provided in options if presents.   Otherwise, create a new one.
 	recorder := opt.Recorder
@@ -120,6 +134,22 @@ func NewBase(opt Options, controllerAgentName string, images pipeline.Images) *B
 	return base
 }
 
+// watchLogLevels updates the levels of tklogging.DefaultLevels every time
+// config-logging changes.
+func watchLogLevels(cmw configmap.Watcher, logger *zap.SugaredLogger) {
+	observer := tklogging.DefaultLevels.UpdateFromConfigMap(logger)
+	switch w := cmw.(type) {
+	case nil:
+	case configmap.DefaultingWatcher:
+		// config-logging is optional for the levels of the controllers.
+		w.WatchWithDefault(corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: tklogging.ConfigName},
+		}, observer)
+	default:
+		w.Watch(tklogging.ConfigName, observer)
+	}
+}
+
 func init() {
 	// Add pipeline types to the default Kubernetes Scheme so Events can be
 	// logged for pipeline types.
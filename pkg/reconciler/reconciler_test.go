@@ -25,8 +25,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
-	"github.com/tektoncd/pipeline/test"
 	tb "github.com/tektoncd/pipeline/test/builder"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
@@ -51,7 +51,7 @@ func TestRecorderOptions(t *testing.T) {
 		tb.PipelineTask("hello-world-1", "hellow-world"),
 	))}
 	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -59,7 +59,7 @@ func TestRecorderOptions(t *testing.T) {
 	ctx, _ := ttesting.SetupFakeContext(t)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
-	c, _ := test.SeedTestData(t, ctx, d)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
 
 	observer, _ := observer.New(zap.InfoLevel)
 
@@ -0,0 +1,59 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcilertest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+// IgnoreLastTransitionTime ignores when the conditions last transitioned,
+// which tests can't predict, when comparing them with cmp.Diff.
+var IgnoreLastTransitionTime = cmpopts.IgnoreTypes(apis.Condition{}.LastTransitionTime.Inner.Time)
+
+// ConditionGetter is the status of a Tekton object, such as a
+// TaskRunStatus or a PipelineRunStatus.
+type ConditionGetter interface {
+	GetCondition(t apis.ConditionType) *apis.Condition
+}
+
+// CheckCondition fails the test if the condition of type want.Type of status
+// isn't want, ignoring when it last transitioned.
+func CheckCondition(t testing.TB, status ConditionGetter, want apis.Condition) {
+	t.Helper()
+	if d := cmp.Diff(&want, status.GetCondition(want.Type), IgnoreLastTransitionTime); d != "" {
+		t.Errorf("Unexpected %s condition -want, +got: %s", want.Type, d)
+	}
+}
+
+// CheckSucceeded fails the test if the Succeeded condition of status doesn't
+// have the status and reason.
+func CheckSucceeded(t testing.TB, status ConditionGetter, conditionStatus corev1.ConditionStatus, reason string) {
+	t.Helper()
+	c := status.GetCondition(apis.ConditionSucceeded)
+	if c == nil {
+		t.Errorf("Expected a Succeeded condition with status %s and reason %q, got none", conditionStatus, reason)
+		return
+	}
+	if c.Status != conditionStatus || c.Reason != reason {
+		t.Errorf("Expected the Succeeded condition to have status %s and reason %q, got %s and %q: %s", conditionStatus, reason, c.Status, c.Reason, c.Message)
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reconcilertest provides fake clients and informers seeded with
+// Tekton objects, and assertions on their conditions, for the tests of
+// controllers reconciling Tekton types.
+//
+// The fakes are those of knative.dev/pkg/injection, so the context of the
+// test must come from SetupFakeContext of knative.dev/pkg/reconciler/testing:
+//
+//	ctx, _ := rtesting.SetupFakeContext(t)
+//	clients, informers := reconcilertest.SeedTestData(t, ctx, reconcilertest.Data{
+//		TaskRuns: []*v1alpha1.TaskRun{tr},
+//	})
+package reconcilertest
@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolutionrequest
+
+import (
+	"context"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	resolutionrequestinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/resolutionrequest"
+	"github.com/tektoncd/pipeline/pkg/health"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/resolution"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	resyncPeriod = 10 * time.Hour
+)
+
+// NewController returns the constructor of the controller resolving the
+// ResolutionRequests of the resolvers.
+func NewController(resolvers ...resolution.Resolver) func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		kubeclientset := kubeclient.Get(ctx)
+		pipelineclientset := pipelineclient.Get(ctx)
+		resolutionRequestInformer := resolutionrequestinformer.Get(ctx)
+
+		opt := reconciler.Options{
+			KubeClientSet:     kubeclientset,
+			PipelineClientSet: pipelineclientset,
+			ConfigMapWatcher:  cmw,
+			ResyncPeriod:      resyncPeriod,
+			Logger:            logger,
+		}
+
+		c := &Reconciler{
+			Base:                    reconciler.NewBase(opt, resolutionRequestAgentName, pipeline.Images{}),
+			resolutionRequestLister: resolutionRequestInformer.Lister(),
+			resolvers:               make(map[string]resolution.Resolver, len(resolvers)),
+		}
+		for _, r := range resolvers {
+			c.resolvers[r.Name()] = r
+		}
+		impl := controller.NewImpl(c, c.Logger, pipeline.ResolutionRequestControllerName)
+		health.DefaultChecks.Add(resolutionRequestAgentName+" informers", health.InformersSynced(
+			resolutionRequestInformer.Informer().HasSynced,
+		))
+
+		c.Logger.Info("Setting up event handlers")
+		resolutionRequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    impl.Enqueue,
+			UpdateFunc: controller.PassNew(impl.Enqueue),
+		})
+
+		return impl
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolutionrequest
+
+import (
+	"context"
+	"fmt"
+
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/resolution"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// resolutionRequestAgentName defines logging agent name for the
+	// ResolutionRequest Controller
+	resolutionRequestAgentName = "resolutionrequest-controller"
+
+	// ReasonResolved indicates that the resource was fetched
+	ReasonResolved = "Resolved"
+	// ReasonResolutionFailed indicates that the resolver couldn't fetch the
+	// resource
+	ReasonResolutionFailed = "ResolutionFailed"
+)
+
+// Reconciler writes the resources its resolvers fetch to the status of the
+// ResolutionRequests naming them. The ResolutionRequests of other resolvers
+// are left to their own controllers.
+type Reconciler struct {
+	*reconciler.Base
+
+	resolutionRequestLister listers.ResolutionRequestLister
+	resolvers               map[string]resolution.Resolver
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile resolves the ResolutionRequest of the key, unless it is done.
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		c.Logger.Errorf("invalid resource key: %s", key)
+		return nil
+	}
+
+	original, err := c.resolutionRequestLister.ResolutionRequests(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		c.Logger.Errorf("Error retrieving ResolutionRequest %q: %s", key, err)
+		return err
+	}
+	if original.IsDone() {
+		return nil
+	}
+	resolver, ok := c.resolvers[original.Spec.Resolver]
+	if !ok {
+		return nil
+	}
+
+	rr := original.DeepCopy()
+	rr.Status.InitializeConditions()
+	data, err := resolver.Resolve(ctx, rr.Spec.ParamsMap())
+	if err != nil {
+		c.Logger.Infof("The %s resolver failed to resolve ResolutionRequest %q: %v", rr.Spec.Resolver, key, err)
+		rr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonResolutionFailed,
+			Message: err.Error(),
+		})
+	} else {
+		rr.Status.Data = string(data)
+		rr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionTrue,
+			Reason:  ReasonResolved,
+			Message: fmt.Sprintf("Resolved by the %s resolver", rr.Spec.Resolver),
+		})
+	}
+
+	if _, err := c.PipelineClientSet.TektonV1alpha1().ResolutionRequests(namespace).UpdateStatus(rr); err != nil {
+		c.Logger.Warnf("Failed to update the status of ResolutionRequest %q: %v", key, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolutionrequest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/resolution"
+	"github.com/tektoncd/pipeline/pkg/system"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/configmap"
+)
+
+// fakeResolver returns the resources of its files by their path param.
+type fakeResolver struct {
+	files    map[string]string
+	resolved []map[string]string
+}
+
+func (*fakeResolver) Name() string { return "fake" }
+
+func (r *fakeResolver) Resolve(_ context.Context, params map[string]string) ([]byte, error) {
+	r.resolved = append(r.resolved, params)
+	data, ok := r.files[params["path"]]
+	if !ok {
+		return nil, errors.New(params["path"] + " not found")
+	}
+	return []byte(data), nil
+}
+
+func getController(t *testing.T, d reconcilertest.Data, resolvers ...resolution.Resolver) (*Reconciler, reconcilertest.Clients, func()) {
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
+	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
+	r := NewController(resolvers...)(ctx, configMapWatcher).Reconciler.(*Reconciler)
+	return r, c, cancel
+}
+
+func request(resolver, path string, status v1alpha1.ResolutionRequestStatus) *v1alpha1.ResolutionRequest {
+	return &v1alpha1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-0123456789", Namespace: "foo"},
+		Spec: v1alpha1.ResolutionRequestSpec{ResolverRef: v1alpha1.ResolverRef{
+			Resolver: resolver,
+			Params:   []v1alpha1.Param{{Name: "path", Value: *tb.ArrayOrString(path)}},
+		}},
+		Status: status,
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	status := func(c apis.Condition, data string) v1alpha1.ResolutionRequestStatus {
+		c.Type = apis.ConditionSucceeded
+		return v1alpha1.ResolutionRequestStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{c}},
+			Data:   data,
+		}
+	}
+
+	for _, tc := range []struct {
+		name           string
+		request        *v1alpha1.ResolutionRequest
+		expectedStatus v1alpha1.ResolutionRequestStatus
+	}{{
+		name:    "resolved",
+		request: request("fake", "task.yaml", v1alpha1.ResolutionRequestStatus{}),
+		expectedStatus: status(apis.Condition{
+			Status:  corev1.ConditionTrue,
+			Reason:  ReasonResolved,
+			Message: "Resolved by the fake resolver",
+		}, "kind: Task"),
+	}, {
+		name:    "resolution fails",
+		request: request("fake", "missing.yaml", v1alpha1.ResolutionRequestStatus{}),
+		expectedStatus: status(apis.Condition{
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonResolutionFailed,
+			Message: "missing.yaml not found",
+		}, ""),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := &fakeResolver{files: map[string]string{"task.yaml": "kind: Task"}}
+			r, c, cancel := getController(t, reconcilertest.Data{
+				ResolutionRequests: []*v1alpha1.ResolutionRequest{tc.request},
+			}, resolver)
+			defer cancel()
+
+			if err := r.Reconcile(context.Background(), "foo/run-0123456789"); err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+			reconciled, err := c.Pipeline.TektonV1alpha1().ResolutionRequests("foo").Get("run-0123456789", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Getting ResolutionRequest: %v", err)
+			}
+			if d := cmp.Diff(tc.expectedStatus, reconciled.Status, cmpopts.IgnoreTypes(apis.VolatileTime{})); d != "" {
+				t.Errorf("Unexpected status: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcile_LeftAlone(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		request *v1alpha1.ResolutionRequest
+	}{{
+		name: "done",
+		request: request("fake", "task.yaml", v1alpha1.ResolutionRequestStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: ReasonResolutionFailed,
+			}}},
+		}),
+	}, {
+		name:    "other resolver",
+		request: request("git", "task.yaml", v1alpha1.ResolutionRequestStatus{}),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := &fakeResolver{}
+			r, c, cancel := getController(t, reconcilertest.Data{
+				ResolutionRequests: []*v1alpha1.ResolutionRequest{tc.request},
+			}, resolver)
+			defer cancel()
+			c.Pipeline.ClearActions()
+
+			if err := r.Reconcile(context.Background(), "foo/run-0123456789"); err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+			if len(resolver.resolved) != 0 || len(c.Pipeline.Actions()) != 0 {
+				t.Errorf("Expected the ResolutionRequest to be left alone, got resolutions %v and actions %v", resolver.resolved, c.Pipeline.Actions())
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	runinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run"
+	"github.com/tektoncd/pipeline/pkg/health"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+const resyncPeriod = 10 * time.Hour
+
+// NewController returns the constructor of the controller of the custom task
+// of apiVersion and kind, which runs its Runs with impl. The Runs of other
+// custom tasks are ignored.
+func NewController(apiVersion string, kind v1alpha1.TaskKind, impl Interface) func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		kubeclientset := kubeclient.Get(ctx)
+		pipelineclientset := pipelineclient.Get(ctx)
+		runInformer := runinformer.Get(ctx)
+
+		opt := reconciler.Options{
+			KubeClientSet:     kubeclientset,
+			PipelineClientSet: pipelineclientset,
+			ConfigMapWatcher:  cmw,
+			ResyncPeriod:      resyncPeriod,
+			Logger:            logger,
+		}
+
+		agentName := strings.ToLower(string(kind)) + "-controller"
+		c := &Reconciler{
+			Base:       reconciler.NewBase(opt, agentName, pipeline.Images{}),
+			runLister:  runInformer.Lister(),
+			apiVersion: apiVersion,
+			kind:       kind,
+			impl:       impl,
+		}
+		ctlImpl := controller.NewImpl(c, c.Logger, string(kind))
+		c.enqueueAfter = ctlImpl.EnqueueKeyAfter
+		health.DefaultChecks.Add(agentName+" informers", health.InformersSynced(
+			runInformer.Informer().HasSynced,
+		))
+
+		c.Logger.Info("Setting up event handlers")
+		runInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: func(obj interface{}) bool {
+				r, ok := obj.(*v1alpha1.Run)
+				return ok && c.handles(r)
+			},
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    ctlImpl.Enqueue,
+				UpdateFunc: controller.PassNew(ctlImpl.Enqueue),
+			},
+		})
+
+		return ctlImpl
+	}
+}
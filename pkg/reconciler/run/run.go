@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package run is a library for writing the controllers of custom tasks: a
+// custom task implements Interface, and the Reconciler of the package starts,
+// cancels and times out its Runs, updates their status and emits their events.
+package run
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// ReasonCancelled indicates that the Run was cancelled, by its
+	// PipelineRun or by the user.
+	ReasonCancelled = "RunCancelled"
+	// ReasonTimedOut indicates that the Run took longer than its timeout.
+	ReasonTimedOut = "RunTimedOut"
+)
+
+// Interface is the business logic of a custom task.
+type Interface interface {
+	// ReconcileRun runs the custom task of r, a Run that started and wasn't
+	// cancelled nor timed out. It sets the status of r, with MarkRunSucceeded
+	// or MarkRunFailed once the custom task completed. The status is updated
+	// even if ReconcileRun returns an error, after which r is reconciled again.
+	ReconcileRun(ctx context.Context, r *v1alpha1.Run) error
+}
+
+// Canceller is implemented by the custom tasks that stop what they manage
+// when their Run is cancelled, before it is marked as failed.
+type Canceller interface {
+	CancelRun(ctx context.Context, r *v1alpha1.Run) error
+}
+
+// TimeoutHandler is implemented by the custom tasks that stop what they
+// manage when their Run times out, before it is marked as failed.
+type TimeoutHandler interface {
+	TimeoutRun(ctx context.Context, r *v1alpha1.Run) error
+}
+
+// Reconciler reconciles the Runs of a custom task with its Interface.
+type Reconciler struct {
+	*reconciler.Base
+	runLister  listers.RunLister
+	apiVersion string
+	kind       v1alpha1.TaskKind
+	impl       Interface
+	// enqueueAfter reconciles a Run again once it times out.
+	enqueueAfter func(key string, delay time.Duration)
+}
+
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile starts the Run of the custom task named by key, or cancels or
+// times it out, or else runs its custom task, and updates its status.
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		c.Logger.Errorf("invalid resource key: %s", key)
+		return nil
+	}
+
+	original, err := c.runLister.Runs(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		c.Logger.Errorf("Error retrieving Run %q: %s", name, err)
+		return err
+	}
+	if !c.handles(original) || original.IsDone() {
+		return nil
+	}
+
+	// Don't modify the informer's copy.
+	r := original.DeepCopy()
+	before := r.Status.GetCondition(apis.ConditionSucceeded)
+	err = c.reconcile(ctx, key, r)
+	if err != nil {
+		c.Logger.Errorf("Reconcile error: %v", err)
+	}
+	after := r.Status.GetCondition(apis.ConditionSucceeded)
+	reconciler.EmitEvent(c.Recorder, before, after, r)
+
+	if !equality.Semantic.DeepEqual(original.Status, r.Status) {
+		if _, uerr := c.PipelineClientSet.TektonV1alpha1().Runs(namespace).UpdateStatus(r); uerr != nil {
+			c.Logger.Warn("Failed to update Run status", zap.Error(uerr))
+			return multierror.Append(err, uerr)
+		}
+	}
+	return err
+}
+
+func (c *Reconciler) reconcile(ctx context.Context, key string, r *v1alpha1.Run) error {
+	// A Run the PipelineRun retries has no start time either.
+	if r.Status.StartTime == nil {
+		r.Status.InitializeConditions()
+		r.Status.StartTime = &metav1.Time{Time: time.Now()}
+	}
+
+	if r.IsCancelled() {
+		if canceller, ok := c.impl.(Canceller); ok {
+			if err := canceller.CancelRun(ctx, r); err != nil {
+				return err
+			}
+		}
+		MarkRunFailed(r, ReasonCancelled, "Run %q was cancelled", r.Name)
+		return nil
+	}
+
+	if r.Spec.Timeout != nil && r.Spec.Timeout.Duration > 0 {
+		remaining := time.Until(r.Status.StartTime.Add(r.Spec.Timeout.Duration))
+		if remaining <= 0 {
+			if handler, ok := c.impl.(TimeoutHandler); ok {
+				if err := handler.TimeoutRun(ctx, r); err != nil {
+					return err
+				}
+			}
+			MarkRunFailed(r, ReasonTimedOut, "Run %q failed to finish within %q", r.Name, r.Spec.Timeout.Duration.String())
+			return nil
+		}
+		c.enqueueAfter(key, remaining)
+	}
+
+	return c.impl.ReconcileRun(ctx, r)
+}
+
+// handles returns true if r runs the custom task of the Reconciler.
+func (c *Reconciler) handles(r *v1alpha1.Run) bool {
+	return r.Spec.Ref != nil && r.Spec.Ref.APIVersion == c.apiVersion && r.Spec.Ref.Kind == c.kind
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/system"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/configmap"
+)
+
+// fakeWait is a custom task waiting for its Runs to be approved, by their
+// "approved" param.
+type fakeWait struct {
+	reconciled []string
+	cancelled  []string
+	timedOut   []string
+}
+
+func (f *fakeWait) ReconcileRun(ctx context.Context, r *v1alpha1.Run) error {
+	f.reconciled = append(f.reconciled, r.Name)
+	for _, p := range r.Spec.Params {
+		if p.Name == "approved" && p.Value.StringVal == "true" {
+			SetRunResult(r, "approver", "someone")
+			MarkRunSucceeded(r, "Approved", "Run %q was approved", r.Name)
+			return nil
+		}
+	}
+	MarkRunRunning(r, "WaitingForApproval", "Run %q is waiting for approval", r.Name)
+	return nil
+}
+
+func (f *fakeWait) CancelRun(ctx context.Context, r *v1alpha1.Run) error {
+	f.cancelled = append(f.cancelled, r.Name)
+	return nil
+}
+
+func (f *fakeWait) TimeoutRun(ctx context.Context, r *v1alpha1.Run) error {
+	f.timedOut = append(f.timedOut, r.Name)
+	return nil
+}
+
+func getController(t *testing.T, d reconcilertest.Data, impl Interface) (*Reconciler, reconcilertest.Clients, func()) {
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
+	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
+	r := NewController("example.dev/v0", "Wait", impl)(ctx, configMapWatcher).Reconciler.(*Reconciler)
+	r.enqueueAfter = func(string, time.Duration) {}
+	return r, c, cancel
+}
+
+func waitRun(name string, ops ...func(*v1alpha1.Run)) *v1alpha1.Run {
+	r := &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "foo"},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1alpha1.TaskRef{APIVersion: "example.dev/v0", Kind: "Wait", Name: "wait-for-approval"},
+		},
+	}
+	for _, op := range ops {
+		op(r)
+	}
+	return r
+}
+
+func TestReconcile(t *testing.T) {
+	longAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+	for _, tc := range []struct {
+		name           string
+		run            *v1alpha1.Run
+		wantStatus     corev1.ConditionStatus
+		wantReason     string
+		wantCompleted  bool
+		wantResults    []v1alpha1.TaskRunResult
+		wantReconciled []string
+		wantCancelled  []string
+		wantTimedOut   []string
+	}{{
+		name:           "run started",
+		run:            waitRun("wait"),
+		wantStatus:     corev1.ConditionUnknown,
+		wantReason:     "WaitingForApproval",
+		wantReconciled: []string{"wait"},
+	}, {
+		name: "run succeeded",
+		run: waitRun("wait", func(r *v1alpha1.Run) {
+			r.Spec.Params = []v1alpha1.Param{{Name: "approved", Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeString, StringVal: "true"}}}
+		}),
+		wantStatus:     corev1.ConditionTrue,
+		wantReason:     "Approved",
+		wantCompleted:  true,
+		wantResults:    []v1alpha1.TaskRunResult{{Name: "approver", Value: "someone"}},
+		wantReconciled: []string{"wait"},
+	}, {
+		name: "run cancelled",
+		run: waitRun("wait", func(r *v1alpha1.Run) {
+			r.Spec.Status = v1alpha1.RunSpecStatusCancelled
+		}),
+		wantStatus:    corev1.ConditionFalse,
+		wantReason:    ReasonCancelled,
+		wantCompleted: true,
+		wantCancelled: []string{"wait"},
+	}, {
+		name: "run timed out",
+		run: waitRun("wait", func(r *v1alpha1.Run) {
+			r.Spec.Timeout = &metav1.Duration{Duration: time.Minute}
+			r.Status.StartTime = &longAgo
+		}),
+		wantStatus:    corev1.ConditionFalse,
+		wantReason:    ReasonTimedOut,
+		wantCompleted: true,
+		wantTimedOut:  []string{"wait"},
+	}, {
+		name: "run within its timeout",
+		run: waitRun("wait", func(r *v1alpha1.Run) {
+			r.Spec.Timeout = &metav1.Duration{Duration: 2 * time.Hour}
+			r.Status.StartTime = &longAgo
+		}),
+		wantStatus:     corev1.ConditionUnknown,
+		wantReason:     "WaitingForApproval",
+		wantReconciled: []string{"wait"},
+	}, {
+		name: "run completed",
+		run: waitRun("wait", func(r *v1alpha1.Run) {
+			r.Status.StartTime = &longAgo
+			r.Status.Conditions = duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "Rejected"}}
+		}),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: "Rejected",
+	}, {
+		name: "run of another custom task",
+		run: waitRun("wait", func(r *v1alpha1.Run) {
+			r.Spec.Ref.Kind = "Approval"
+		}),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			impl := &fakeWait{}
+			r, c, cancel := getController(t, reconcilertest.Data{Runs: []*v1alpha1.Run{tc.run}}, impl)
+			defer cancel()
+
+			if err := r.Reconcile(context.Background(), "foo/wait"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			run, err := c.Pipeline.TektonV1alpha1().Runs("foo").Get("wait", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting Run: %v", err)
+			}
+			condition := run.Status.GetCondition(apis.ConditionSucceeded)
+			if tc.wantStatus == "" {
+				if condition != nil || run.Status.StartTime != nil {
+					t.Errorf("Expected Run of another custom task to be ignored, got status %v", run.Status)
+				}
+			} else if condition == nil || condition.Status != tc.wantStatus || condition.Reason != tc.wantReason {
+				t.Errorf("Succeeded condition = %v, want %s with reason %s", condition, tc.wantStatus, tc.wantReason)
+			}
+			if tc.wantStatus != "" && run.Status.StartTime == nil {
+				t.Error("Expected Run to have a start time")
+			}
+			if completed := run.Status.CompletionTime != nil; completed != tc.wantCompleted {
+				t.Errorf("Run completion time = %v, want one: %t", run.Status.CompletionTime, tc.wantCompleted)
+			}
+			if d := cmp.Diff(tc.wantResults, run.Status.Results); d != "" {
+				t.Errorf("Run results diff -want, +got: %s", d)
+			}
+			if d := cmp.Diff(tc.wantReconciled, impl.reconciled); d != "" {
+				t.Errorf("ReconcileRun() calls diff -want, +got: %s", d)
+			}
+			if d := cmp.Diff(tc.wantCancelled, impl.cancelled); d != "" {
+				t.Errorf("CancelRun() calls diff -want, +got: %s", d)
+			}
+			if d := cmp.Diff(tc.wantTimedOut, impl.timedOut); d != "" {
+				t.Errorf("TimeoutRun() calls diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestSetRunResult(t *testing.T) {
+	r := waitRun("wait")
+	SetRunResult(r, "approver", "someone")
+	SetRunResult(r, "comment", "lgtm")
+	SetRunResult(r, "approver", "someone-else")
+	want := []v1alpha1.TaskRunResult{{Name: "approver", Value: "someone-else"}, {Name: "comment", Value: "lgtm"}}
+	if d := cmp.Diff(want, r.Status.Results); d != "" {
+		t.Errorf("Run results diff -want, +got: %s", d)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// MarkRunRunning sets the Succeeded condition of r to Unknown, with reason
+// and a message describing the progress of the custom task.
+func MarkRunRunning(r *v1alpha1.Run, reason, messageFormat string, messageA ...interface{}) {
+	markRun(r, corev1.ConditionUnknown, reason, fmt.Sprintf(messageFormat, messageA...))
+}
+
+// MarkRunSucceeded sets the Succeeded condition of r to True, and its
+// completion time.
+func MarkRunSucceeded(r *v1alpha1.Run, reason, messageFormat string, messageA ...interface{}) {
+	markRun(r, corev1.ConditionTrue, reason, fmt.Sprintf(messageFormat, messageA...))
+	r.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+}
+
+// MarkRunFailed sets the Succeeded condition of r to False, and its
+// completion time.
+func MarkRunFailed(r *v1alpha1.Run, reason, messageFormat string, messageA ...interface{}) {
+	markRun(r, corev1.ConditionFalse, reason, fmt.Sprintf(messageFormat, messageA...))
+	r.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+}
+
+func markRun(r *v1alpha1.Run, status corev1.ConditionStatus, reason, message string) {
+	r.Status.SetCondition(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// SetRunResult sets the result name of r to value, which the other tasks of
+// its Pipeline can use.
+func SetRunResult(r *v1alpha1.Run, name, value string) {
+	for i := range r.Status.Results {
+		if r.Status.Results[i].Name == name {
+			r.Status.Results[i].Value = value
+			return
+		}
+	}
+	r.Status.Results = append(r.Status.Results, v1alpha1.TaskRunResult{Name: name, Value: value})
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagemigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	storagemigrationinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/storagemigration"
+	"github.com/tektoncd/pipeline/pkg/health"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	resyncPeriod = 10 * time.Hour
+)
+
+// NewController returns the constructor of the controller running the
+// StorageMigrations.
+func NewController() func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		kubeclientset := kubeclient.Get(ctx)
+		pipelineclientset := pipelineclient.Get(ctx)
+		storageMigrationInformer := storagemigrationinformer.Get(ctx)
+
+		opt := reconciler.Options{
+			KubeClientSet:     kubeclientset,
+			PipelineClientSet: pipelineclientset,
+			ConfigMapWatcher:  cmw,
+			ResyncPeriod:      resyncPeriod,
+			Logger:            logger,
+		}
+
+		c := &Reconciler{
+			Base:                   reconciler.NewBase(opt, storageMigrationAgentName, pipeline.Images{}),
+			storageMigrationLister: storageMigrationInformer.Lister(),
+			resources:              resourceListers(pipelineclientset),
+		}
+		impl := controller.NewImpl(c, c.Logger, pipeline.StorageMigrationControllerName)
+		health.DefaultChecks.Add(storageMigrationAgentName+" informers", health.InformersSynced(
+			storageMigrationInformer.Informer().HasSynced,
+		))
+
+		c.Logger.Info("Setting up event handlers")
+		// Updating the status after each page enqueues the StorageMigration
+		// again, to migrate the next one.
+		storageMigrationInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    impl.Enqueue,
+			UpdateFunc: controller.PassNew(impl.Enqueue),
+		})
+
+		return impl
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagemigration
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// rewrite rewrites an object.
+type rewrite struct {
+	// object is the "namespace/name" of the object, or its "name" if it is
+	// cluster-scoped.
+	object string
+	do     func() error
+}
+
+// listFunc lists a page of the objects of a resource in every namespace, and
+// returns the rewrites of each of them along with the token listing the next
+// page.
+type listFunc func(opts metav1.ListOptions) (rewrites []rewrite, next string, err error)
+
+// newListFunc returns the listFunc of a resource listing its objects with
+// list and rewriting each of them with update.
+func newListFunc(list func(metav1.ListOptions) (runtime.Object, error), update func(runtime.Object) error) listFunc {
+	return func(opts metav1.ListOptions) ([]rewrite, string, error) {
+		l, err := list(opts)
+		if err != nil {
+			return nil, "", err
+		}
+		items, err := meta.ExtractList(l)
+		if err != nil {
+			return nil, "", err
+		}
+		listMeta, err := meta.ListAccessor(l)
+		if err != nil {
+			return nil, "", err
+		}
+		var rewrites []rewrite
+		for _, o := range items {
+			o := o
+			key, err := cache.MetaNamespaceKeyFunc(o)
+			if err != nil {
+				return nil, "", err
+			}
+			rewrites = append(rewrites, rewrite{object: key, do: func() error { return update(o) }})
+		}
+		return rewrites, listMeta.GetContinue(), nil
+	}
+}
+
+// resourceListers returns the listFunc of each resource that can be
+// migrated. Updating an object without changing it makes the API server
+// store it in the current storage version of the resource.
+func resourceListers(c clientset.Interface) map[string]listFunc {
+	tekton := c.TektonV1alpha1()
+	return map[string]listFunc{
+		"clustertasks": newListFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+			return tekton.ClusterTasks().List(opts)
+		}, func(o runtime.Object) error {
+			_, err := tekton.ClusterTasks().Update(o.(*v1alpha1.ClusterTask))
+			return err
+		}),
+		"conditions": newListFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+			return tekton.Conditions(metav1.NamespaceAll).List(opts)
+		}, func(o runtime.Object) error {
+			c := o.(*v1alpha1.Condition)
+			_, err := tekton.Conditions(c.Namespace).Update(c)
+			return err
+		}),
+		"notificationpolicies": newListFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+			return tekton.NotificationPolicies(metav1.NamespaceAll).List(opts)
+		}, func(o runtime.Object) error {
+			np := o.(*v1alpha1.NotificationPolicy)
+			_, err := tekton.NotificationPolicies(np.Namespace).Update(np)
+			return err
+		}),
+		"pipelineresources": newListFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+			return tekton.PipelineResources(metav1.NamespaceAll).List(opts)
+		}, func(o runtime.Object) error {
+			r := o.(*v1alpha1.PipelineResource)
+			_, err := tekton.PipelineResources(r.Namespace).Update(r)
+			return err
+		}),
+		"pipelineruns": newListFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+			return tekton.PipelineRuns(metav1.NamespaceAll).List(opts)
+		}, func(o runtime.Object) error {
+			pr := o.(*v1alpha1.PipelineRun)
+			_, err := tekton.PipelineRuns(pr.Namespace).Update(pr)
+			return err
+		}),
+		"pipelines": newListFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+			return tekton.Pipelines(metav1.NamespaceAll).List(opts)
+		}, func(o runtime.Object) error {
+			p := o.(*v1alpha1.Pipeline)
+			_, err := tekton.Pipelines(p.Namespace).Update(p)
+			return err
+		}),
+		"taskruns": newListFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+			return tekton.TaskRuns(metav1.NamespaceAll).List(opts)
+		}, func(o runtime.Object) error {
+			tr := o.(*v1alpha1.TaskRun)
+			_, err := tekton.TaskRuns(tr.Namespace).Update(tr)
+			return err
+		}),
+		"tasks": newListFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+			return tekton.Tasks(metav1.NamespaceAll).List(opts)
+		}, func(o runtime.Object) error {
+			t := o.(*v1alpha1.Task)
+			_, err := tekton.Tasks(t.Namespace).Update(t)
+			return err
+		}),
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagemigration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// storageMigrationAgentName defines logging agent name for the
+	// StorageMigration Controller
+	storageMigrationAgentName = "storagemigration-controller"
+
+	// ReasonRunning indicates that the StorageMigration is migrating objects
+	ReasonRunning = "Running"
+	// ReasonCompleted indicates that every object was migrated
+	ReasonCompleted = "Completed"
+	// ReasonFailed indicates that listing or rewriting the objects of a page
+	// failed. The page is migrated again.
+	ReasonFailed = "Failed"
+	// ReasonUnsupportedResource indicates that the resource of the
+	// StorageMigration can't be migrated
+	ReasonUnsupportedResource = "UnsupportedResource"
+)
+
+// Reconciler migrates the objects of a StorageMigration one page at a time.
+// The token listing the next page is recorded in its status, so that the
+// migration resumes where it stopped when the controller restarts.
+type Reconciler struct {
+	*reconciler.Base
+
+	storageMigrationLister listers.StorageMigrationLister
+	resources              map[string]listFunc
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile migrates the next page of objects of the StorageMigration key,
+// unless it is done.
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		c.Logger.Errorf("invalid resource key: %s", key)
+		return nil
+	}
+
+	original, err := c.storageMigrationLister.Get(name)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		c.Logger.Errorf("Error retrieving StorageMigration %q: %s", name, err)
+		return err
+	}
+	if original.IsDone() {
+		return nil
+	}
+
+	sm := original.DeepCopy()
+	sm.SetDefaults(ctx)
+	if sm.Status.StartTime == nil {
+		sm.Status.InitializeConditions()
+	}
+	migrateErr := c.migratePage(ctx, sm)
+	if migrateErr != nil {
+		c.Logger.Errorf("Failed to migrate the %s of StorageMigration %q: %v", sm.Spec.Resource, name, migrateErr)
+		sm.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  ReasonFailed,
+			Message: migrateErr.Error(),
+		})
+	}
+
+	if !equality.Semantic.DeepEqual(original.Status, sm.Status) {
+		if _, err := c.PipelineClientSet.TektonV1alpha1().StorageMigrations().UpdateStatus(sm); err != nil {
+			c.Logger.Warnf("Failed to update the status of StorageMigration %q: %v", name, err)
+			return err
+		}
+	}
+	return migrateErr
+}
+
+// migratePage rewrites the objects of the next page of the resource of sm,
+// at the rate of sm, and records the progress in its status.
+func (c *Reconciler) migratePage(ctx context.Context, sm *v1alpha1.StorageMigration) error {
+	list, ok := c.resources[sm.Spec.Resource]
+	if !ok {
+		sm.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonUnsupportedResource,
+			Message: fmt.Sprintf("The %s can't be migrated", sm.Spec.Resource),
+		})
+		return nil
+	}
+
+	rewrites, next, err := list(metav1.ListOptions{Limit: sm.Spec.PageSize, Continue: sm.Status.Continue})
+	if errors.IsResourceExpired(err) {
+		// The token is too old to list the next page: list every object
+		// again, those already migrated are rewritten as they are.
+		c.Logger.Infof("The continue token of StorageMigration %q expired, restarting from the first page", sm.Name)
+		sm.Status.Continue = ""
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error listing the %s: %w", sm.Spec.Resource, err)
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(sm.Spec.ObjectsPerSecond), 1)
+	for _, rewrite := range rewrites {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		// An object that was deleted doesn't need to be migrated, and one
+		// that was updated since it was listed was stored in the current
+		// version. Any other object that can't be rewritten is recorded and
+		// skipped, rather than failing the page again and again.
+		if err := rewrite.do(); err != nil && !errors.IsNotFound(err) && !errors.IsConflict(err) {
+			c.Logger.Errorf("Failed to rewrite %s %q of StorageMigration %q: %v", sm.Spec.Resource, rewrite.object, sm.Name, err)
+			c.Recorder.Eventf(sm, corev1.EventTypeWarning, "RewriteFailed", "Couldn't rewrite %s %q: %v", sm.Spec.Resource, rewrite.object, err)
+			sm.Status.FailedObjects = append(sm.Status.FailedObjects, rewrite.object)
+			continue
+		}
+		sm.Status.MigratedObjects++
+	}
+
+	sm.Status.Continue = next
+	if next == "" {
+		sm.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+		sm.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionTrue,
+			Reason:  ReasonCompleted,
+			Message: fmt.Sprintf("Migrated %d %s", sm.Status.MigratedObjects, sm.Spec.Resource) + failedMessage(sm),
+		})
+		return nil
+	}
+	sm.Status.SetCondition(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionUnknown,
+		Reason:  ReasonRunning,
+		Message: fmt.Sprintf("Migrated %d %s so far", sm.Status.MigratedObjects, sm.Spec.Resource) + failedMessage(sm),
+	})
+	return nil
+}
+
+// failedMessage returns the part of the message of the condition of sm about
+// the objects that couldn't be rewritten, if any.
+func failedMessage(sm *v1alpha1.StorageMigration) string {
+	if len(sm.Status.FailedObjects) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", %d couldn't be rewritten", len(sm.Status.FailedObjects))
+}
@@ -0,0 +1,310 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagemigration
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/system"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/configmap"
+)
+
+var startTime = metav1.NewTime(time.Date(2019, 12, 1, 8, 0, 0, 0, time.UTC))
+
+// fakeResource pages through objects, using the index of the first object of
+// the next page as continue token.
+type fakeResource struct {
+	objects []string
+	// errors are returned when rewriting the objects.
+	errors    map[string]error
+	rewritten []string
+}
+
+func (f *fakeResource) list(opts metav1.ListOptions) ([]rewrite, string, error) {
+	if opts.Continue == "expired" {
+		return nil, "", apierrors.NewResourceExpired("the continue token is too old")
+	}
+	start := 0
+	if opts.Continue != "" {
+		var err error
+		if start, err = strconv.Atoi(opts.Continue); err != nil {
+			return nil, "", err
+		}
+	}
+	end := len(f.objects)
+	next := ""
+	if opts.Limit > 0 && start+int(opts.Limit) < end {
+		end = start + int(opts.Limit)
+		next = strconv.Itoa(end)
+	}
+	var rewrites []rewrite
+	for _, o := range f.objects[start:end] {
+		o := o
+		rewrites = append(rewrites, rewrite{object: o, do: func() error {
+			if err := f.errors[o]; err != nil {
+				return err
+			}
+			f.rewritten = append(f.rewritten, o)
+			return nil
+		}})
+	}
+	return rewrites, next, nil
+}
+
+func getController(t *testing.T, d reconcilertest.Data) (*Reconciler, reconcilertest.Clients, func()) {
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
+	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
+	r := NewController()(ctx, configMapWatcher).Reconciler.(*Reconciler)
+	return r, c, cancel
+}
+
+func TestReconcile(t *testing.T) {
+	taskRunsGR := schema.GroupResource{Group: "tekton.dev", Resource: "taskruns"}
+	migration := func(resource string, status v1alpha1.StorageMigrationStatus) *v1alpha1.StorageMigration {
+		return &v1alpha1.StorageMigration{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate"},
+			Spec: v1alpha1.StorageMigrationSpec{
+				Resource:         resource,
+				PageSize:         2,
+				ObjectsPerSecond: 1000,
+			},
+			Status: status,
+		}
+	}
+	status := func(c apis.Condition, next string, migrated int64) v1alpha1.StorageMigrationStatus {
+		c.Type = apis.ConditionSucceeded
+		return v1alpha1.StorageMigrationStatus{
+			Status:          duckv1beta1.Status{Conditions: duckv1beta1.Conditions{c}},
+			StartTime:       &startTime,
+			Continue:        next,
+			MigratedObjects: migrated,
+		}
+	}
+	withFailed := func(s v1alpha1.StorageMigrationStatus, failed ...string) v1alpha1.StorageMigrationStatus {
+		s.FailedObjects = failed
+		return s
+	}
+	running := func(next string, migrated int64) v1alpha1.StorageMigrationStatus {
+		return status(apis.Condition{Status: corev1.ConditionUnknown, Reason: ReasonRunning, Message: "Migrated " + strconv.FormatInt(migrated, 10) + " taskruns so far"}, next, migrated)
+	}
+
+	for _, tc := range []struct {
+		name           string
+		migration      *v1alpha1.StorageMigration
+		errors         map[string]error
+		expectedStatus v1alpha1.StorageMigrationStatus
+		expectedObject []string
+		expectError    bool
+	}{{
+		name:           "first page",
+		migration:      migration("taskruns", v1alpha1.StorageMigrationStatus{StartTime: &startTime}),
+		expectedStatus: running("2", 2),
+		expectedObject: []string{"a", "b"},
+	}, {
+		name:           "resumes from the continue token",
+		migration:      migration("taskruns", running("2", 2)),
+		expectedStatus: running("4", 4),
+		expectedObject: []string{"c", "d"},
+	}, {
+		name:      "last page",
+		migration: migration("taskruns", running("4", 4)),
+		expectedStatus: status(apis.Condition{
+			Status:  corev1.ConditionTrue,
+			Reason:  ReasonCompleted,
+			Message: "Migrated 5 taskruns",
+		}, "", 5),
+		expectedObject: []string{"e"},
+	}, {
+		name:           "expired continue token",
+		migration:      migration("taskruns", running("expired", 2)),
+		expectedStatus: running("", 2),
+	}, {
+		name:      "deleted and updated objects",
+		migration: migration("taskruns", running("", 0)),
+		errors: map[string]error{
+			"a": apierrors.NewNotFound(taskRunsGR, "a"),
+			"b": apierrors.NewConflict(taskRunsGR, "b", errors.New("the object has been modified")),
+		},
+		expectedStatus: running("2", 2),
+	}, {
+		name:      "rewrite fails",
+		migration: migration("taskruns", running("2", 2)),
+		errors:    map[string]error{"c": errors.New("admission webhook denied the request")},
+		expectedStatus: withFailed(status(apis.Condition{
+			Status:  corev1.ConditionUnknown,
+			Reason:  ReasonRunning,
+			Message: "Migrated 3 taskruns so far, 1 couldn't be rewritten",
+		}, "4", 3), "c"),
+		expectedObject: []string{"d"},
+	}, {
+		name:      "last page after failed rewrites",
+		migration: migration("taskruns", withFailed(running("4", 3), "c")),
+		errors:    map[string]error{"e": errors.New("admission webhook denied the request")},
+		expectedStatus: withFailed(status(apis.Condition{
+			Status:  corev1.ConditionTrue,
+			Reason:  ReasonCompleted,
+			Message: "Migrated 3 taskruns, 2 couldn't be rewritten",
+		}, "", 3), "c", "e"),
+	}, {
+		name:      "list fails",
+		migration: migration("taskruns", running("invalid", 2)),
+		expectedStatus: status(apis.Condition{
+			Status:  corev1.ConditionUnknown,
+			Reason:  ReasonFailed,
+			Message: `error listing the taskruns: strconv.Atoi: parsing "invalid": invalid syntax`,
+		}, "invalid", 2),
+		expectError: true,
+	}, {
+		name:      "unsupported resource",
+		migration: migration("pods", v1alpha1.StorageMigrationStatus{StartTime: &startTime}),
+		expectedStatus: status(apis.Condition{
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonUnsupportedResource,
+			Message: "The pods can't be migrated",
+		}, "", 0),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, c, cancel := getController(t, reconcilertest.Data{
+				StorageMigrations: []*v1alpha1.StorageMigration{tc.migration},
+			})
+			defer cancel()
+			resource := &fakeResource{objects: []string{"a", "b", "c", "d", "e"}, errors: tc.errors}
+			r.resources = map[string]listFunc{"taskruns": resource.list}
+
+			err := r.Reconcile(context.Background(), "migrate")
+			if tc.expectError != (err != nil) {
+				t.Errorf("Expected an error: %t, got %v", tc.expectError, err)
+			}
+
+			reconciled, err := c.Pipeline.TektonV1alpha1().StorageMigrations().Get("migrate", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Getting StorageMigration: %v", err)
+			}
+			if d := cmp.Diff(tc.expectedStatus, reconciled.Status, cmpopts.IgnoreTypes(apis.VolatileTime{}), cmpopts.IgnoreFields(v1alpha1.StorageMigrationStatus{}, "CompletionTime")); d != "" {
+				t.Errorf("Unexpected status: %s", d)
+			}
+			if completed := reconciled.Status.GetCondition(apis.ConditionSucceeded).IsTrue(); completed != (reconciled.Status.CompletionTime != nil) {
+				t.Errorf("Expected the completion time to be set: %t, got %v", completed, reconciled.Status.CompletionTime)
+			}
+			if d := cmp.Diff(tc.expectedObject, resource.rewritten); d != "" {
+				t.Errorf("Unexpected rewritten objects: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcile_Done(t *testing.T) {
+	done := &v1alpha1.StorageMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate"},
+		Spec:       v1alpha1.StorageMigrationSpec{Resource: "taskruns"},
+		Status: v1alpha1.StorageMigrationStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+				Reason: ReasonCompleted,
+			}}},
+		},
+	}
+	r, c, cancel := getController(t, reconcilertest.Data{
+		StorageMigrations: []*v1alpha1.StorageMigration{done},
+	})
+	defer cancel()
+	c.Pipeline.ClearActions()
+	resource := &fakeResource{objects: []string{"a"}}
+	r.resources = map[string]listFunc{"taskruns": resource.list}
+
+	if err := r.Reconcile(context.Background(), "migrate"); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(resource.rewritten) != 0 || len(c.Pipeline.Actions()) != 0 {
+		t.Errorf("Expected a completed StorageMigration to be left alone, got rewrites %v and actions %v", resource.rewritten, c.Pipeline.Actions())
+	}
+}
+
+func TestResourceListers(t *testing.T) {
+	ctx, _ := ttesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c, _ := reconcilertest.SeedTestData(t, ctx, reconcilertest.Data{
+		TaskRuns: []*v1alpha1.TaskRun{
+			tb.TaskRun("run-1", "foo"),
+			tb.TaskRun("run-2", "bar"),
+		},
+		ClusterTasks: []*v1alpha1.ClusterTask{tb.ClusterTask("cluster-task")},
+	})
+	c.Pipeline.ClearActions()
+	listers := resourceListers(c.Pipeline)
+
+	for _, resource := range v1alpha1.StorageMigrationResources {
+		if _, ok := listers[resource]; !ok {
+			t.Errorf("No lister for %s", resource)
+		}
+	}
+
+	for _, tc := range []struct {
+		resource string
+		expected []string
+	}{{
+		resource: "taskruns",
+		expected: []string{"bar/run-2", "foo/run-1"},
+	}, {
+		resource: "clustertasks",
+		expected: []string{"/cluster-task"},
+	}} {
+		t.Run(tc.resource, func(t *testing.T) {
+			c.Pipeline.ClearActions()
+			rewrites, _, err := listers[tc.resource](metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Listing %s: %v", tc.resource, err)
+			}
+			for _, rewrite := range rewrites {
+				if err := rewrite.do(); err != nil {
+					t.Errorf("Rewriting: %v", err)
+				}
+			}
+			var updated []string
+			for _, a := range c.Pipeline.Actions() {
+				if a.GetVerb() == "update" && a.GetResource().Resource == tc.resource {
+					o := a.(interface{ GetObject() runtime.Object }).GetObject().(metav1.Object)
+					updated = append(updated, o.GetNamespace()+"/"+o.GetName())
+				}
+			}
+			if d := cmp.Diff(tc.expected, updated, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+				t.Errorf("Unexpected updates: %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/reconciler/indexes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// adoptPod returns the Pod of the current attempt of tr if it was created but
+// not recorded in its status, for example because the controller crashed in
+// between. The Pods controlled by tr are its Pods; a Pod without a controller
+// is only adopted if it has the name of the Pod of tr, since anyone can label
+// a Pod as the Pod of a TaskRun. If there are several Pods for the current
+// attempt, the extra ones are deleted. It returns nil if there is no such Pod.
+func (c *Reconciler) adoptPod(tr *v1alpha1.TaskRun) (*corev1.Pod, error) {
+	// The informer may not have seen a Pod that was just created, but then
+	// creating the Pod fails as its name is taken, and tr is reconciled again.
+	objs, err := c.podIndexer.ByIndex(indexes.TaskRun, indexes.Key(tr.Namespace, tr.Name))
+	if err != nil {
+		return nil, fmt.Errorf("error listing the pods of TaskRun %s/%s: %w", tr.Namespace, tr.Name, err)
+	}
+
+	// The Pods of the previous attempts are kept.
+	previous := map[string]bool{}
+	for _, retry := range tr.Status.RetriesStatus {
+		previous[retry.PodName] = true
+	}
+	name := podconvert.PodName(tr)
+	var pods []*corev1.Pod
+	for _, obj := range objs {
+		p := obj.(*corev1.Pod)
+		if previous[p.Name] || p.DeletionTimestamp != nil {
+			continue
+		}
+		if ref := metav1.GetControllerOf(p); (ref == nil && p.Name == name) || (ref != nil && ref.UID == tr.UID) {
+			pods = append(pods, p)
+		}
+	}
+	if len(pods) == 0 {
+		return nil, nil
+	}
+
+	// Keep the Pod with the expected name, or else the oldest one.
+	sort.Slice(pods, func(i, j int) bool {
+		if (pods[i].Name == name) != (pods[j].Name == name) {
+			return pods[i].Name == name
+		}
+		if !pods[i].CreationTimestamp.Equal(&pods[j].CreationTimestamp) {
+			return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	for _, extra := range pods[1:] {
+		c.Logger.Infof("Deleting extra pod %q of TaskRun %s/%s", extra.Name, tr.Namespace, tr.Name)
+		if err := c.KubeClientSet.CoreV1().Pods(tr.Namespace).Delete(extra.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("error deleting extra pod %q: %w", extra.Name, err)
+		}
+	}
+
+	pod := pods[0]
+	if metav1.GetControllerOf(pod) == nil {
+		c.Logger.Infof("Adopting orphan pod %q of TaskRun %s/%s", pod.Name, tr.Namespace, tr.Name)
+		pod = pod.DeepCopy()
+		pod.OwnerReferences = append(pod.OwnerReferences, *metav1.NewControllerRef(tr, v1alpha1.SchemeGroupVersion.WithKind("TaskRun")))
+		if pod, err = c.KubeClientSet.CoreV1().Pods(tr.Namespace).Update(pod); err != nil {
+			return nil, fmt.Errorf("error adopting pod %q: %w", pods[0].Name, err)
+		}
+	} else {
+		c.Logger.Infof("Found pod %q of TaskRun %s/%s, missing from its status", pod.Name, tr.Namespace, tr.Name)
+	}
+	return pod, nil
+}
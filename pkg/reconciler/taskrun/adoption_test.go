@@ -0,0 +1,186 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconcile_AdoptPod(t *testing.T) {
+	newTaskRun := func() *v1alpha1.TaskRun {
+		tr := tb.TaskRun("test-taskrun-adopt", "foo", tb.TaskRunSpec(tb.TaskRunTaskRef(simpleTask.Name)))
+		tr.UID = "taskrun-uid"
+		return tr
+	}
+	tr := newTaskRun()
+	expectedName := podconvert.PodName(tr)
+
+	created := metav1.NewTime(time.Date(2019, 12, 1, 8, 0, 0, 0, time.UTC))
+	pod := func(name string, controller types.UID, age time.Duration) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "foo",
+			Labels:            map[string]string{taskRunNameLabelKey: "test-taskrun-adopt"},
+			CreationTimestamp: metav1.NewTime(created.Add(-age)),
+		}}
+		if controller != "" {
+			owner := newTaskRun()
+			owner.UID = controller
+			p.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, v1alpha1.SchemeGroupVersion.WithKind("TaskRun"))}
+		}
+		return p
+	}
+
+	retried := newTaskRun()
+	retried.Status.RetriesStatus = []v1alpha1.TaskRunStatus{{
+		TaskRunStatusFields: v1alpha1.TaskRunStatusFields{PodName: "test-taskrun-adopt-pod-first"},
+	}}
+
+	for _, tc := range []struct {
+		name string
+		tr   *v1alpha1.TaskRun
+		pods []*corev1.Pod
+		// expectedPod is the Pod recorded in the status of the TaskRun.
+		expectedPod string
+		// expectedPods are the Pods left after the reconcile.
+		expectedPods []string
+		expectCreate bool
+	}{{
+		name:         "no pod",
+		tr:           newTaskRun(),
+		expectedPod:  expectedName,
+		expectedPods: []string{expectedName},
+		expectCreate: true,
+	}, {
+		name:         "crash after the pod was created",
+		tr:           newTaskRun(),
+		pods:         []*corev1.Pod{pod(expectedName, "taskrun-uid", 0)},
+		expectedPod:  expectedName,
+		expectedPods: []string{expectedName},
+	}, {
+		name:         "orphan pod",
+		tr:           newTaskRun(),
+		pods:         []*corev1.Pod{pod(expectedName, "", 0)},
+		expectedPod:  expectedName,
+		expectedPods: []string{expectedName},
+	}, {
+		name:         "orphan pod with another name",
+		tr:           newTaskRun(),
+		pods:         []*corev1.Pod{pod("test-taskrun-adopt-pod-abcde", "", time.Minute)},
+		expectedPod:  expectedName,
+		expectedPods: []string{"test-taskrun-adopt-pod-abcde", expectedName},
+		expectCreate: true,
+	}, {
+		name: "extra pods",
+		tr:   newTaskRun(),
+		pods: []*corev1.Pod{
+			pod("test-taskrun-adopt-pod-abcde", "taskrun-uid", time.Minute),
+			pod("test-taskrun-adopt-pod-fghij", "taskrun-uid", 2*time.Minute),
+			pod(expectedName, "taskrun-uid", 0),
+		},
+		expectedPod:  expectedName,
+		expectedPods: []string{expectedName},
+	}, {
+		name: "extra pods without the expected name",
+		tr:   newTaskRun(),
+		pods: []*corev1.Pod{
+			pod("test-taskrun-adopt-pod-abcde", "taskrun-uid", time.Minute),
+			pod("test-taskrun-adopt-pod-fghij", "taskrun-uid", 2*time.Minute),
+		},
+		expectedPod:  "test-taskrun-adopt-pod-fghij",
+		expectedPods: []string{"test-taskrun-adopt-pod-fghij"},
+	}, {
+		name:         "pod of a deleted taskrun with the same name",
+		tr:           newTaskRun(),
+		pods:         []*corev1.Pod{pod("test-taskrun-adopt-pod-abcde", "deleted-taskrun-uid", time.Minute)},
+		expectedPod:  expectedName,
+		expectedPods: []string{"test-taskrun-adopt-pod-abcde", expectedName},
+		expectCreate: true,
+	}, {
+		name:         "pod of a previous attempt",
+		tr:           retried,
+		pods:         []*corev1.Pod{pod("test-taskrun-adopt-pod-first", "taskrun-uid", time.Minute)},
+		expectedPod:  podconvert.PodName(retried),
+		expectedPods: []string{podconvert.PodName(retried), "test-taskrun-adopt-pod-first"},
+		expectCreate: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getTaskRunController(t, reconcilertest.Data{
+				TaskRuns: []*v1alpha1.TaskRun{tc.tr},
+				Tasks:    []*v1alpha1.Task{simpleTask},
+				Pods:     tc.pods,
+			})
+			defer cancel()
+			clients := testAssets.Clients
+			if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/test-taskrun-adopt"); err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+
+			reconciled, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get("test-taskrun-adopt", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Getting TaskRun: %v", err)
+			}
+			if reconciled.Status.PodName != tc.expectedPod {
+				t.Errorf("Expected the TaskRun to run pod %q, got %q", tc.expectedPod, reconciled.Status.PodName)
+			}
+
+			podList, err := clients.Kube.CoreV1().Pods("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Listing pods: %v", err)
+			}
+			var names []string
+			for _, p := range podList.Items {
+				names = append(names, p.Name)
+				if p.Name == tc.expectedPod && !metav1.IsControlledBy(&p, tc.tr) {
+					t.Errorf("Expected pod %q to be controlled by the TaskRun, got owners %v", p.Name, p.OwnerReferences)
+				}
+			}
+			sort.Strings(names)
+			if d := cmp.Diff(tc.expectedPods, names); d != "" {
+				t.Errorf("Unexpected pods: %s", d)
+			}
+
+			creates := 0
+			for _, a := range clients.Kube.Actions() {
+				if a.GetVerb() == "create" && a.GetResource().Resource == "pods" {
+					creates++
+				}
+			}
+			if tc.expectCreate != (creates == 1) || creates > 1 {
+				t.Errorf("Expected a pod to be created: %t, got %d creations", tc.expectCreate, creates)
+			}
+		})
+	}
+}
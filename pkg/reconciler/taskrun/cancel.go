@@ -20,7 +20,9 @@ import (
 	"fmt"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"knative.dev/pkg/apis"
@@ -31,22 +33,43 @@ type logger interface {
 	Warnf(template string, args ...interface{})
 }
 
-// cancelTaskRun marks the TaskRun as cancelled and delete pods linked to it.
+// cancelTaskRun marks the TaskRun as cancelled, along with the steps that
+// haven't terminated, and deletes the pod linked to it.
 func cancelTaskRun(tr *v1alpha1.TaskRun, clientSet kubernetes.Interface, logger logger) error {
 	logger.Warn("task run %q has been cancelled", tr.Name)
 	tr.Status.SetCondition(&apis.Condition{
 		Type:    apis.ConditionSucceeded,
 		Status:  corev1.ConditionFalse,
-		Reason:  "TaskRunCancelled",
+		Reason:  podconvert.ReasonCancelled,
 		Message: fmt.Sprintf("TaskRun %q was cancelled", tr.Name),
 	})
+	now := metav1.Now()
+	tr.Status.CompletionTime = &now
+	for i, s := range tr.Status.Steps {
+		if s.Terminated != nil {
+			continue
+		}
+		var startedAt metav1.Time
+		if s.Running != nil {
+			startedAt = s.Running.StartedAt
+		}
+		tr.Status.Steps[i].ContainerState = corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{
+				ExitCode:   1,
+				Reason:     podconvert.ReasonCancelled,
+				Message:    fmt.Sprintf("Step %q was stopped because TaskRun %q was cancelled", s.Name, tr.Name),
+				StartedAt:  startedAt,
+				FinishedAt: now,
+			},
+		}
+	}
 
 	if tr.Status.PodName == "" {
 		logger.Warnf("task run %q has no pod running yet", tr.Name)
 		return nil
 	}
 
-	if err := clientSet.CoreV1().Pods(tr.Namespace).Delete(tr.Status.PodName, &metav1.DeleteOptions{}); err != nil {
+	if err := clientSet.CoreV1().Pods(tr.Namespace).Delete(tr.Status.PodName, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 	return nil
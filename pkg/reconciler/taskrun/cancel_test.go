@@ -19,11 +19,13 @@ package taskrun
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
-	"github.com/tektoncd/pipeline/test"
 	tb "github.com/tektoncd/pipeline/test/builder"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
@@ -33,11 +35,14 @@ import (
 )
 
 func TestCancelTaskRun(t *testing.T) {
+	startedAt := metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
 	testCases := []struct {
 		name           string
 		taskRun        *v1alpha1.TaskRun
 		pod            *corev1.Pod
+		steps          []v1alpha1.StepState
 		expectedStatus apis.Condition
+		expectedSteps  []v1alpha1.StepState
 	}{{
 		name: "no-pod-scheduled",
 		taskRun: tb.TaskRun("test-taskrun-run-cancelled", "foo", tb.TaskRunSpec(
@@ -66,6 +71,60 @@ func TestCancelTaskRun(t *testing.T) {
 			Namespace: "foo",
 			Name:      "foo-is-bar",
 		}},
+		steps: []v1alpha1.StepState{{
+			Name: "done",
+			ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 0,
+				Reason:   "Completed",
+			}},
+		}, {
+			Name: "running",
+			ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{
+				StartedAt: startedAt,
+			}},
+		}, {
+			Name: "waiting",
+			ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+				Reason: "PodInitializing",
+			}},
+		}},
+		expectedStatus: apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  "TaskRunCancelled",
+			Message: `TaskRun "test-taskrun-run-cancelled" was cancelled`,
+		},
+		expectedSteps: []v1alpha1.StepState{{
+			Name: "done",
+			ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 0,
+				Reason:   "Completed",
+			}},
+		}, {
+			Name: "running",
+			ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode:  1,
+				Reason:    "TaskRunCancelled",
+				Message:   `Step "running" was stopped because TaskRun "test-taskrun-run-cancelled" was cancelled`,
+				StartedAt: startedAt,
+			}},
+		}, {
+			Name: "waiting",
+			ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 1,
+				Reason:   "TaskRunCancelled",
+				Message:  `Step "waiting" was stopped because TaskRun "test-taskrun-run-cancelled" was cancelled`,
+			}},
+		}},
+	}, {
+		name: "pod-already-deleted",
+		taskRun: tb.TaskRun("test-taskrun-run-cancelled", "foo", tb.TaskRunSpec(
+			tb.TaskRunTaskRef(simpleTask.Name),
+			tb.TaskRunCancelled,
+		), tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		}), tb.PodName("foo-is-bar"))),
 		expectedStatus: apis.Condition{
 			Type:    apis.ConditionSucceeded,
 			Status:  corev1.ConditionFalse,
@@ -76,17 +135,19 @@ func TestCancelTaskRun(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			d := test.Data{
+			d := reconcilertest.Data{
 				TaskRuns: []*v1alpha1.TaskRun{tc.taskRun},
 			}
 			if tc.pod != nil {
 				d.Pods = []*corev1.Pod{tc.pod}
 			}
 
+			tc.taskRun.Status.Steps = tc.steps
+
 			ctx, _ := ttesting.SetupFakeContext(t)
 			ctx, cancel := context.WithCancel(ctx)
 			defer cancel()
-			c, _ := test.SeedTestData(t, ctx, d)
+			c, _ := reconcilertest.SeedTestData(t, ctx, d)
 			observer, _ := observer.New(zap.InfoLevel)
 			err := cancelTaskRun(tc.taskRun, c.Kube, zap.New(observer).Sugar())
 			if err != nil {
@@ -95,6 +156,13 @@ func TestCancelTaskRun(t *testing.T) {
 			if d := cmp.Diff(tc.taskRun.Status.GetCondition(apis.ConditionSucceeded), &tc.expectedStatus, ignoreLastTransitionTime); d != "" {
 				t.Fatalf("-want, +got: %v", d)
 			}
+			if tc.taskRun.Status.CompletionTime == nil {
+				t.Error("CompletionTime isn't set")
+			}
+			ignoreFinishedAt := cmpopts.IgnoreFields(corev1.ContainerStateTerminated{}, "FinishedAt")
+			if d := cmp.Diff(tc.expectedSteps, tc.taskRun.Status.Steps, ignoreFinishedAt); d != "" {
+				t.Errorf("Steps -want, +got: %v", d)
+			}
 		})
 	}
 }
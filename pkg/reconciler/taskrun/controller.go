@@ -18,20 +18,28 @@ package taskrun
 
 import (
 	"context"
+	"net/http"
 	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
 	clustertaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/clustertask"
 	resourceinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/pipelineresource"
+	resolutionrequestinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/resolutionrequest"
 	taskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/task"
 	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/taskrun"
+	"github.com/tektoncd/pipeline/pkg/health"
 	"github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/reconciler/indexes"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/resolution"
+	"github.com/tektoncd/pipeline/pkg/resourceplugins"
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -43,7 +51,7 @@ const (
 	resyncPeriod = 10 * time.Hour
 )
 
-func NewController(images pipeline.Images) func(context.Context, configmap.Watcher) *controller.Impl {
+func NewController(images pipeline.Images, podPolicy PodPolicy) func(context.Context, configmap.Watcher) *controller.Impl {
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 		logger := logging.FromContext(ctx)
 		kubeclientset := kubeclient.Get(ctx)
@@ -52,7 +60,9 @@ func NewController(images pipeline.Images) func(context.Context, configmap.Watch
 		taskInformer := taskinformer.Get(ctx)
 		clusterTaskInformer := clustertaskinformer.Get(ctx)
 		podInformer := podinformer.Get(ctx)
+		namespaceInformer := namespaceinformer.Get(ctx)
 		resourceInformer := resourceinformer.Get(ctx)
+		resolutionRequestInformer := resolutionrequestinformer.Get(ctx)
 		timeoutHandler := reconciler.NewTimeoutHandler(ctx.Done(), logger)
 		metrics, err := NewRecorder()
 		if err != nil {
@@ -72,18 +82,39 @@ func NewController(images pipeline.Images) func(context.Context, configmap.Watch
 			logger.Fatalf("Error creating entrypoint cache: %v", err)
 		}
 
+		if err := indexes.AddPodIndexes(podInformer.Informer()); err != nil {
+			logger.Fatalf("Error adding the pod indexes: %v", err)
+		}
+
 		c := &Reconciler{
 			Base:              reconciler.NewBase(opt, taskRunAgentName, images),
 			taskRunLister:     taskRunInformer.Lister(),
 			taskLister:        taskInformer.Lister(),
 			clusterTaskLister: clusterTaskInformer.Lister(),
 			resourceLister:    resourceInformer.Lister(),
+			namespaceLister:   namespaceInformer.Lister(),
+			podIndexer:        podInformer.Informer().GetIndexer(),
+			podPolicy:         podPolicy,
+			httpClient:        &http.Client{Timeout: podPolicy.Timeout},
 			timeoutHandler:    timeoutHandler,
 			cloudEventClient:  cloudeventclient.Get(ctx),
 			metrics:           metrics,
 			entrypointCache:   entrypointCache,
+			requester: resolution.Requester{
+				Client: pipelineclientset,
+				Lister: resolutionRequestInformer.Lister(),
+			},
 		}
 		impl := controller.NewImpl(c, c.Logger, pipeline.TaskRunControllerName)
+		health.DefaultChecks.Add(taskRunAgentName+" informers", health.InformersSynced(
+			taskRunInformer.Informer().HasSynced,
+			taskInformer.Informer().HasSynced,
+			clusterTaskInformer.Informer().HasSynced,
+			resourceInformer.Informer().HasSynced,
+			resolutionRequestInformer.Informer().HasSynced,
+			podInformer.Informer().HasSynced,
+			namespaceInformer.Informer().HasSynced,
+		))
 
 		timeoutHandler.SetTaskRunCallbackFunc(impl.Enqueue)
 		timeoutHandler.CheckTimeouts(kubeclientset, pipelineclientset)
@@ -96,11 +127,21 @@ func NewController(images pipeline.Images) func(context.Context, configmap.Watch
 
 		c.tracker = tracker.New(impl.EnqueueKey, controller.GetTrackerLease(ctx))
 
+		c.Logger.Info("Setting up ConfigMap receivers")
+		c.configStore = config.NewStore(c.Logger.Named("config-store"))
+		c.configStore.WatchConfigs(opt.ConfigMapWatcher)
+		resourceplugins.Watch(opt.ConfigMapWatcher, c.Logger)
+
 		podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("TaskRun")),
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 		})
 
+		resolutionRequestInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("TaskRun")),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		})
+
 		return impl
 	}
 }
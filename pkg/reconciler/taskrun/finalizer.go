@@ -0,0 +1,58 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// eventReasonCloudEventCancelled is the reason of the event recorded for each
+// cloud event that wasn't delivered before its TaskRun was deleted.
+const eventReasonCloudEventCancelled = "CloudEventCancelled"
+
+// addFinalizer adds the cleanup finalizer to tr when the
+// enable-cleanup-finalizer feature flag is set. It is saved with the labels
+// and annotations of tr.
+func addFinalizer(ctx context.Context, tr *v1alpha1.TaskRun) {
+	if tr.DeletionTimestamp == nil && config.FromContextOrDefaults(ctx).FeatureFlags.EnableCleanupFinalizer {
+		reconciler.AddFinalizer(tr, reconciler.CleanupFinalizer)
+	}
+}
+
+// finalize cancels the delivery of the cloud events of tr that weren't sent
+// yet, and then removes the cleanup finalizer so that tr can be deleted.
+func (c *Reconciler) finalize(tr *v1alpha1.TaskRun) error {
+	c.timeoutHandler.Release(tr)
+	for _, delivery := range tr.Status.CloudEvents {
+		if delivery.Status.Condition == v1alpha1.CloudEventConditionUnknown {
+			c.Recorder.Eventf(tr, corev1.EventTypeWarning, eventReasonCloudEventCancelled,
+				"Cancelled the delivery of the cloud event to %s, TaskRun %s is deleted", delivery.Target, tr.Name)
+		}
+	}
+
+	reconciler.RemoveFinalizer(tr, reconciler.CleanupFinalizer)
+	if _, err := c.PipelineClientSet.TektonV1alpha1().TaskRuns(tr.Namespace).Update(tr); err != nil {
+		return fmt.Errorf("error removing the cleanup finalizer of TaskRun %s: %w", tr.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	"github.com/tektoncd/pipeline/pkg/system"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcile_AddFinalizer(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		flag       string
+		finalizers []string
+	}{{
+		name: "disabled",
+		flag: "false",
+	}, {
+		name:       "enabled",
+		flag:       "true",
+		finalizers: []string{reconciler.CleanupFinalizer},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := reconcilertest.Data{
+				TaskRuns: []*v1alpha1.TaskRun{tb.TaskRun("test-taskrun", "foo", tb.TaskRunSpec(tb.TaskRunTaskRef(simpleTask.Name)))},
+				Tasks:    []*v1alpha1.Task{simpleTask},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+					Data:       map[string]string{"enable-cleanup-finalizer": tc.flag},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.TaskPolicyConfigName, Namespace: system.GetNamespace()},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+			if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-taskrun"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+
+			tr, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get("test-taskrun", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting TaskRun: %v", err)
+			}
+			if d := cmp.Diff(tc.finalizers, tr.Finalizers); d != "" {
+				t.Errorf("Finalizers diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcile_Finalize(t *testing.T) {
+	tr := tb.TaskRun("test-taskrun", "foo",
+		tb.TaskRunSpec(tb.TaskRunTaskRef(simpleTask.Name)),
+		tb.TaskRunStatus(
+			tb.TaskRunCloudEvent("http://sent", "", 1, v1alpha1.CloudEventConditionSent),
+			tb.TaskRunCloudEvent("http://pending", "", 0, v1alpha1.CloudEventConditionUnknown),
+		),
+	)
+	now := metav1.Now()
+	tr.DeletionTimestamp = &now
+	tr.Finalizers = []string{"other", reconciler.CleanupFinalizer}
+	d := reconcilertest.Data{
+		TaskRuns: []*v1alpha1.TaskRun{tr},
+		Tasks:    []*v1alpha1.Task{simpleTask},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c, clients := testAssets.Controller, testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-taskrun"); err != nil {
+		t.Fatalf("Reconcile() = %v", err)
+	}
+
+	reconciled, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get("test-taskrun", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting TaskRun: %v", err)
+	}
+	if d := cmp.Diff([]string{"other"}, reconciled.Finalizers); d != "" {
+		t.Errorf("Finalizers diff -want, +got: %s", d)
+	}
+	// A deleted TaskRun doesn't start.
+	if pods, err := clients.Kube.CoreV1().Pods("foo").List(metav1.ListOptions{}); err != nil {
+		t.Fatal(err)
+	} else if len(pods.Items) != 0 {
+		t.Errorf("Expected no pod to be created, got %d", len(pods.Items))
+	}
+}
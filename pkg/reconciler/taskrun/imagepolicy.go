@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"knative.dev/pkg/apis"
+)
+
+// imageNotPinnedError is returned when the task policy requires image digests
+// and a step or sidecar of a TaskRun runs an image without one.
+type imageNotPinnedError struct {
+	container string
+	image     string
+}
+
+func (e *imageNotPinnedError) Error() string {
+	return fmt.Sprintf("image %q of %s isn't pinned to a digest, which the task policy requires", e.image, e.container)
+}
+
+func isImageNotPinned(err error) bool {
+	var notPinned *imageNotPinnedError
+	return errors.As(err, &notPinned)
+}
+
+// taskPolicyError is returned when the resolved TaskSpec of a TaskRun has
+// fields the task policy forbids.
+type taskPolicyError struct {
+	errs *apis.FieldError
+}
+
+func (e *taskPolicyError) Error() string {
+	return e.errs.Error()
+}
+
+func isTaskPolicyViolation(err error) bool {
+	var violation *taskPolicyError
+	return isImageNotPinned(err) || errors.As(err, &violation)
+}
+
+// checkTaskPolicy returns an error if the task policy forbids fields of ts,
+// whose params and resources are substituted, in namespace. The webhook only
+// checks the specs it admits when they are created or their restricted
+// fields change: not the Tasks which existed before the policy was
+// configured, nor the Tasks resolved remotely.
+func checkTaskPolicy(ctx context.Context, namespace string, ts *v1alpha1.TaskSpec) error {
+	if err := checkImageDigests(ctx, namespace, ts); err != nil {
+		return err
+	}
+	if errs := v1alpha1.ValidateTaskPolicy(ctx, namespace, ts); errs != nil {
+		return &taskPolicyError{errs: errs.ViaField("taskSpec")}
+	}
+	return nil
+}
+
+// checkImageDigests returns an error if the task policy requires image digests
+// in namespace and a step or sidecar of ts, whose params and resources are
+// substituted, runs an image without one. The webhook can't check the images
+// set by params or resources, which are only known at this point.
+func checkImageDigests(ctx context.Context, namespace string, ts *v1alpha1.TaskSpec) error {
+	policy := config.FromContextOrDefaults(ctx).TaskPolicy
+	if !policy.RequireImageDigests || policy.Exempts(namespace) {
+		return nil
+	}
+	steps, err := v1alpha1.MergeStepsWithStepTemplate(ts.StepTemplate, ts.DeepCopy().Steps)
+	if err != nil {
+		return err
+	}
+	for i, s := range steps {
+		if policy.ImageNotPinned(s.Image) {
+			container := fmt.Sprintf("step %d", i)
+			if s.Name != "" {
+				container = fmt.Sprintf("step %q", s.Name)
+			}
+			return &imageNotPinnedError{container: container, image: s.Image}
+		}
+	}
+	for _, s := range ts.Sidecars {
+		if policy.ImageNotPinned(s.Image) {
+			return &imageNotPinnedError{container: fmt.Sprintf("sidecar %q", s.Name), image: s.Image}
+		}
+	}
+	return nil
+}
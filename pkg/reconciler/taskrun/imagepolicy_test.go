@@ -0,0 +1,201 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	"github.com/tektoncd/pipeline/pkg/system"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestReconcile_RequireImageDigests(t *testing.T) {
+	pinned := "busybox@sha256:895ab622e92e18d6b461d671081757af7dbaa3b00e3e28e12505af7817f73649"
+	task := tb.Task("image-task", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParamSpec("image", v1alpha1.ParamTypeString)),
+		tb.Step("build", "$(inputs.params.image)", tb.StepCommand("/mycmd")),
+	))
+	for _, tc := range []struct {
+		name       string
+		image      string
+		namespace  string
+		wantReason string
+		wantPod    bool
+	}{{
+		name:    "pinned",
+		image:   pinned,
+		wantPod: true,
+	}, {
+		name:       "not pinned",
+		image:      "busybox",
+		wantReason: podconvert.ReasonFailedValidation,
+	}, {
+		name:      "exempt namespace",
+		image:     "busybox",
+		namespace: "foo",
+		wantPod:   true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := tb.TaskRun("test-taskrun", "foo", tb.TaskRunSpec(
+				tb.TaskRunTaskRef(task.Name),
+				tb.TaskRunInputs(tb.TaskRunInputsParam("image", tc.image)),
+			))
+			testAssets, cancel := getTaskRunController(t, reconcilertest.Data{
+				TaskRuns: []*v1alpha1.TaskRun{taskRun},
+				Tasks:    []*v1alpha1.Task{task},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.TaskPolicyConfigName, Namespace: system.GetNamespace()},
+					Data: map[string]string{
+						"require-image-digests": "true",
+						"exempt-namespaces":     tc.namespace,
+					},
+				}},
+			})
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+			if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			tr, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get(taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting TaskRun: %v", err)
+			}
+			if tc.wantReason != "" {
+				condition := tr.Status.GetCondition(apis.ConditionSucceeded)
+				if !condition.IsFalse() || condition.Reason != tc.wantReason {
+					t.Errorf("Succeeded condition = %v, want False with reason %s", condition, tc.wantReason)
+				}
+			}
+			if d := cmp.Diff(tc.wantPod, tr.Status.PodName != ""); d != "" {
+				t.Errorf("Pod created diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcile_TaskPolicy(t *testing.T) {
+	// The Task was stored before the task policy was configured, so the
+	// webhook didn't check it.
+	task := tb.Task("docker-task", "foo", tb.TaskSpec(
+		tb.Step("build", "docker", tb.StepCommand("/mycmd")),
+		tb.TaskVolume("docker-socket", tb.VolumeSource(corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"},
+		})),
+	))
+	taskRun := tb.TaskRun("test-taskrun", "foo", tb.TaskRunSpec(tb.TaskRunTaskRef(task.Name)))
+	testAssets, cancel := getTaskRunController(t, reconcilertest.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRun},
+		Tasks:    []*v1alpha1.Task{task},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: config.TaskPolicyConfigName, Namespace: system.GetNamespace()},
+			Data:       map[string]string{"disallow-host-path": "true"},
+		}},
+	})
+	defer cancel()
+	c, clients := testAssets.Controller, testAssets.Clients
+	if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("Reconcile() = %v", err)
+	}
+	tr, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting TaskRun: %v", err)
+	}
+	condition := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != podconvert.ReasonFailedValidation {
+		t.Errorf("Succeeded condition = %v, want False with reason %s", condition, podconvert.ReasonFailedValidation)
+	}
+	if tr.Status.PodName != "" {
+		t.Errorf("Expected no pod to be created, got %q", tr.Status.PodName)
+	}
+}
+
+func TestCheckImageDigests(t *testing.T) {
+	pinned := "busybox@sha256:895ab622e92e18d6b461d671081757af7dbaa3b00e3e28e12505af7817f73649"
+	cfg := config.FromContextOrDefaults(context.Background())
+	cfg.TaskPolicy = &config.TaskPolicy{RequireImageDigests: true}
+	ctx := config.ToContext(context.Background(), cfg)
+	for _, tc := range []struct {
+		name    string
+		spec    v1alpha1.TaskSpec
+		wantErr string
+	}{{
+		name: "pinned",
+		spec: v1alpha1.TaskSpec{
+			Steps:    []v1alpha1.Step{{Container: corev1.Container{Name: "build", Image: pinned}}},
+			Sidecars: []corev1.Container{{Name: "proxy", Image: pinned}},
+		},
+	}, {
+		name: "step template",
+		spec: v1alpha1.TaskSpec{
+			Steps:        []v1alpha1.Step{{Container: corev1.Container{Name: "build"}}},
+			StepTemplate: &corev1.Container{Image: "busybox"},
+		},
+		wantErr: `image "busybox" of step "build" isn't pinned to a digest, which the task policy requires`,
+	}, {
+		name: "unnamed step",
+		spec: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{Image: pinned}}, {Container: corev1.Container{Image: "busybox:1.31"}}},
+		},
+		wantErr: `image "busybox:1.31" of step 1 isn't pinned to a digest, which the task policy requires`,
+	}, {
+		name: "sidecar",
+		spec: v1alpha1.TaskSpec{
+			Steps:    []v1alpha1.Step{{Container: corev1.Container{Name: "build", Image: pinned}}},
+			Sidecars: []corev1.Container{{Name: "proxy", Image: "envoyproxy/envoy"}},
+		},
+		wantErr: `image "envoyproxy/envoy" of sidecar "proxy" isn't pinned to a digest, which the task policy requires`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErr string
+			if err := checkImageDigests(ctx, "default", &tc.spec); err != nil {
+				gotErr = err.Error()
+			}
+			if d := cmp.Diff(tc.wantErr, gotErr); d != "" {
+				t.Errorf("checkImageDigests() errors diff -want, +got: %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// hasPlatformNodes returns true if a Node of the cluster runs one of the
+// platforms, or if there are no platforms to run on.
+func hasPlatformNodes(kubeclient kubernetes.Interface, platforms []string) (bool, error) {
+	if len(platforms) == 0 {
+		return true, nil
+	}
+	for _, p := range platforms {
+		nodes, err := kubeclient.CoreV1().Nodes().List(metav1.ListOptions{
+			LabelSelector: podconvert.PlatformNodeLabels(p).String(),
+			Limit:         1,
+		})
+		if err != nil {
+			return false, err
+		}
+		if len(nodes.Items) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
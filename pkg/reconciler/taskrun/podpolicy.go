@@ -0,0 +1,162 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// eventReasonPodMutated is the reason of the events recorded on the TaskRuns
+// whose Pod was changed by the pod policy.
+const eventReasonPodMutated = "PodMutated"
+
+// PodPolicy configures the endpoint reviewing the Pods of the TaskRuns before
+// they are created.
+type PodPolicy struct {
+	// URL is where the Pods are sent for review. Pods are created without
+	// review if it is empty.
+	URL string
+	// Timeout is how long the endpoint has to respond.
+	Timeout time.Duration
+	// IgnoreFailures creates the Pods the endpoint couldn't review, rather
+	// than retrying until it does.
+	IgnoreFailures bool
+}
+
+// podPolicyReview is what is sent to the pod policy endpoint: the Pod about to
+// be created for the TaskRun.
+type podPolicyReview struct {
+	TaskRun *v1alpha1.TaskRun `json:"taskRun"`
+	Pod     *corev1.Pod       `json:"pod"`
+}
+
+// podPolicyDecision is the response of the pod policy endpoint.
+type podPolicyDecision struct {
+	// Allowed is true if the Pod can be created.
+	Allowed bool `json:"allowed"`
+	// Reason explains the decision. It is recorded on the TaskRun.
+	Reason string `json:"reason,omitempty"`
+	// Pod, if set, is created instead of the reviewed Pod.
+	Pod *corev1.Pod `json:"pod,omitempty"`
+}
+
+// podDeniedError is returned when the pod policy denies the Pod of a TaskRun.
+type podDeniedError struct {
+	reason string
+}
+
+func (e *podDeniedError) Error() string {
+	if e.reason == "" {
+		return "no reason given"
+	}
+	return e.reason
+}
+
+// podPolicyUnavailableError is returned when the pod policy endpoint couldn't
+// review the Pod of a TaskRun.
+type podPolicyUnavailableError struct {
+	err error
+}
+
+func (e *podPolicyUnavailableError) Error() string {
+	return fmt.Sprintf("the pod policy couldn't review the pod: %v", e.err)
+}
+
+func (e *podPolicyUnavailableError) Unwrap() error {
+	return e.err
+}
+
+func isPodDenied(err error) bool {
+	var denied *podDeniedError
+	return errors.As(err, &denied)
+}
+
+func isPodPolicyUnavailable(err error) bool {
+	var unavailable *podPolicyUnavailableError
+	return errors.As(err, &unavailable)
+}
+
+// reviewPod sends pod to the pod policy endpoint, if there is one, and
+// returns the Pod to create for tr: pod, or the Pod the endpoint changed it
+// to.
+func (c *Reconciler) reviewPod(ctx context.Context, tr *v1alpha1.TaskRun, pod *corev1.Pod) (*corev1.Pod, error) {
+	if c.podPolicy.URL == "" {
+		return pod, nil
+	}
+	decision, err := c.sendPodReview(ctx, podPolicyReview{TaskRun: tr, Pod: pod})
+	if err != nil {
+		if c.podPolicy.IgnoreFailures {
+			c.Logger.Warnf("Creating the pod of TaskRun %s/%s without review: %v", tr.Namespace, tr.Name, err)
+			return pod, nil
+		}
+		return nil, &podPolicyUnavailableError{err: err}
+	}
+	if !decision.Allowed {
+		return nil, &podDeniedError{reason: decision.Reason}
+	}
+	if decision.Pod == nil {
+		return pod, nil
+	}
+
+	// The controller finds the Pod of the TaskRun by its name, owner and
+	// labels, which the policy can't change.
+	mutated := decision.Pod
+	mutated.Name = pod.Name
+	mutated.Namespace = pod.Namespace
+	mutated.OwnerReferences = pod.OwnerReferences
+	if mutated.Labels == nil {
+		mutated.Labels = map[string]string{}
+	}
+	mutated.Labels[pipeline.GroupName+pipeline.TaskRunLabelKey] = pod.Labels[pipeline.GroupName+pipeline.TaskRunLabelKey]
+	c.Recorder.Eventf(tr, corev1.EventTypeNormal, eventReasonPodMutated, "The pod policy changed the pod: %s", decision.Reason)
+	return mutated, nil
+}
+
+func (c *Reconciler) sendPodReview(ctx context.Context, review podPolicyReview) (*podPolicyDecision, error) {
+	b, err := json.Marshal(review)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.podPolicy.URL, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("the pod policy responded with status %s", resp.Status)
+	}
+	var decision podPolicyDecision
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return nil, fmt.Errorf("error decoding the decision of the pod policy: %w", err)
+	}
+	return &decision, nil
+}
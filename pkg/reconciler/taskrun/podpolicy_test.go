@@ -0,0 +1,186 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// fakePodPolicy records the reviews sent to it, and responds with decision,
+// or with err if it is set.
+type fakePodPolicy struct {
+	reviews  []podPolicyReview
+	decision podPolicyDecision
+	err      error
+}
+
+func (f *fakePodPolicy) Do(req *http.Request) (*http.Response, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var review podPolicyReview
+	if err := json.NewDecoder(req.Body).Decode(&review); err != nil {
+		return nil, err
+	}
+	f.reviews = append(f.reviews, review)
+	b, err := json.Marshal(f.decision)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     http.StatusText(http.StatusOK),
+		Body:       ioutil.NopCloser(strings.NewReader(string(b))),
+	}, nil
+}
+
+func TestReconcile_PodPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		decision         podPolicyDecision
+		err              error
+		ignoreFailures   bool
+		wantErr          bool
+		wantPod          bool
+		wantReason       string
+		wantPodLabels    map[string]string
+		wantNodeSelector map[string]string
+	}{{
+		name:     "allowed",
+		decision: podPolicyDecision{Allowed: true},
+		wantPod:  true,
+	}, {
+		name:       "denied",
+		decision:   podPolicyDecision{Allowed: false, Reason: "privileged steps are not allowed"},
+		wantReason: podconvert.ReasonPodDenied,
+	}, {
+		name: "mutated",
+		decision: podPolicyDecision{Allowed: true, Reason: "pinned to the build nodes", Pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "renamed",
+				Labels: map[string]string{"team": "build"},
+			},
+			Spec: corev1.PodSpec{
+				NodeSelector: map[string]string{"pool": "build"},
+				Containers:   []corev1.Container{{Name: "step-simple-step", Image: "foo"}},
+			},
+		}},
+		wantPod: true,
+		wantPodLabels: map[string]string{
+			"team":               "build",
+			"tekton.dev/taskRun": "test-taskrun-pod-policy",
+		},
+		wantNodeSelector: map[string]string{"pool": "build"},
+	}, {
+		name:    "unavailable",
+		err:     errors.New("connection refused"),
+		wantErr: true,
+	}, {
+		name:           "unavailable, ignoring failures",
+		err:            errors.New("connection refused"),
+		ignoreFailures: true,
+		wantPod:        true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := tb.TaskRun("test-taskrun-pod-policy", "foo", tb.TaskRunSpec(
+				tb.TaskRunTaskRef(simpleTask.Name),
+			))
+			testAssets, cancel := getTaskRunController(t, reconcilertest.Data{
+				TaskRuns: []*v1alpha1.TaskRun{taskRun},
+				Tasks:    []*v1alpha1.Task{simpleTask},
+			})
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+			if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			r := c.Reconciler.(*Reconciler)
+			r.podPolicy = PodPolicy{URL: "https://policy.example.com/review", IgnoreFailures: tc.ignoreFailures}
+			policy := &fakePodPolicy{decision: tc.decision, err: tc.err}
+			r.httpClient = policy
+
+			err := r.Reconcile(context.Background(), getRunName(taskRun))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Reconcile() = %v, wanted error: %t", err, tc.wantErr)
+			}
+			if tc.err == nil {
+				if len(policy.reviews) != 1 {
+					t.Fatalf("Expected the pod to be reviewed once, got %d reviews", len(policy.reviews))
+				}
+				if review := policy.reviews[0]; review.TaskRun.Name != taskRun.Name || review.Pod == nil {
+					t.Errorf("Expected a review of the pod of TaskRun %s, got %v", taskRun.Name, review)
+				}
+			}
+
+			reconciled, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get(taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting TaskRun: %v", err)
+			}
+			if tc.wantReason != "" {
+				condition := reconciled.Status.GetCondition(apis.ConditionSucceeded)
+				if !condition.IsFalse() || condition.Reason != tc.wantReason {
+					t.Errorf("Expected TaskRun to fail with reason %s, but condition is %v", tc.wantReason, condition)
+				}
+			}
+
+			pods, err := clients.Kube.CoreV1().Pods("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tc.wantPod {
+				if len(pods.Items) != 0 {
+					t.Errorf("Expected no pod to be created, got %d", len(pods.Items))
+				}
+				return
+			}
+			if len(pods.Items) != 1 {
+				t.Fatalf("Expected a pod to be created, got %d", len(pods.Items))
+			}
+			pod := pods.Items[0]
+			if pod.Name != reconciled.Status.PodName || len(pod.OwnerReferences) != 1 || pod.OwnerReferences[0].Name != taskRun.Name {
+				t.Errorf("Expected the pod to keep the name %s and the TaskRun as owner, got %s owned by %v", reconciled.Status.PodName, pod.Name, pod.OwnerReferences)
+			}
+			if tc.wantPodLabels != nil {
+				if d := cmp.Diff(tc.wantPodLabels, pod.Labels); d != "" {
+					t.Errorf("pod labels -want, +got: %s", d)
+				}
+			}
+			if tc.wantNodeSelector != nil {
+				if d := cmp.Diff(tc.wantNodeSelector, pod.Spec.NodeSelector); d != "" {
+					t.Errorf("pod node selector -want, +got: %s", d)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/resolution"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getRemoteTask returns the GetTask fetching the Task of the taskRef of tr
+// with its resolver, through a ResolutionRequest owned by tr.
+func (c *Reconciler) getRemoteTask(ctx context.Context, tr *v1alpha1.TaskRun) resources.GetTask {
+	return func(string) (v1alpha1.TaskInterface, error) {
+		ownerRefs := []metav1.OwnerReference{*metav1.NewControllerRef(tr, v1alpha1.SchemeGroupVersion.WithKind("TaskRun"))}
+		data, err := c.requester.Request(tr, ownerRefs, tr.Spec.TaskRef.ResolverRef)
+		if err != nil {
+			return nil, err
+		}
+		return resolution.Task(ctx, data, tr.Namespace)
+	}
+}
@@ -35,20 +35,12 @@ func ApplyParameters(spec *v1alpha1.TaskSpec, tr *v1alpha1.TaskRun, defaults ...
 	// Set all the default stringReplacements
 	for _, p := range defaults {
 		if p.Default != nil {
-			if p.Default.Type == v1alpha1.ParamTypeString {
-				stringReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Default.StringVal
-			} else {
-				arrayReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Default.ArrayVal
-			}
+			p.Default.AddReplacements(fmt.Sprintf("inputs.params.%s", p.Name), stringReplacements, arrayReplacements)
 		}
 	}
 	// Set and overwrite params with the ones from the TaskRun
 	for _, p := range tr.Spec.Inputs.Params {
-		if p.Value.Type == v1alpha1.ParamTypeString {
-			stringReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value.StringVal
-		} else {
-			arrayReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value.ArrayVal
-		}
+		p.Value.AddReplacements(fmt.Sprintf("inputs.params.%s", p.Name), stringReplacements, arrayReplacements)
 	}
 
 	return ApplyReplacements(spec, stringReplacements, arrayReplacements)
@@ -61,6 +53,7 @@ func ApplyResources(spec *v1alpha1.TaskSpec, resolvedResources map[string]v1alph
 	for name, r := range resolvedResources {
 		for k, v := range r.Replacements() {
 			replacements[fmt.Sprintf("%s.resources.%s.%s", replacementStr, name, k)] = v
+			replacements[fmt.Sprintf("resources.%s.%s.%s", replacementStr, name, k)] = v
 		}
 	}
 
@@ -68,17 +61,39 @@ func ApplyResources(spec *v1alpha1.TaskSpec, resolvedResources map[string]v1alph
 	if spec.Inputs != nil {
 		for _, r := range spec.Inputs.Resources {
 			replacements[fmt.Sprintf("inputs.resources.%s.path", r.Name)] = v1alpha1.InputResourcePath(r.ResourceDeclaration)
+			replacements[fmt.Sprintf("resources.inputs.%s.path", r.Name)] = v1alpha1.InputResourcePath(r.ResourceDeclaration)
 		}
 	}
 	if spec.Outputs != nil {
 		for _, r := range spec.Outputs.Resources {
 			replacements[fmt.Sprintf("outputs.resources.%s.path", r.Name)] = v1alpha1.OutputResourcePath(r.ResourceDeclaration)
+			replacements[fmt.Sprintf("resources.outputs.%s.path", r.Name)] = v1alpha1.OutputResourcePath(r.ResourceDeclaration)
 		}
 	}
 
 	return ApplyReplacements(spec, replacements, map[string][]string{})
 }
 
+// ApplyResults applies the substitution of the paths of the files the steps
+// write the results of the Task to.
+func ApplyResults(spec *v1alpha1.TaskSpec) *v1alpha1.TaskSpec {
+	replacements := map[string]string{}
+	for _, r := range spec.Results {
+		replacements[fmt.Sprintf("results.%s.path", r.Name)] = r.Path()
+	}
+	return ApplyReplacements(spec, replacements, map[string][]string{})
+}
+
+// ApplyWorkspaces applies the substitution of the paths the workspaces are
+// mounted at.
+func ApplyWorkspaces(spec *v1alpha1.TaskSpec) *v1alpha1.TaskSpec {
+	replacements := map[string]string{}
+	for _, w := range spec.Workspaces {
+		replacements[fmt.Sprintf("workspaces.%s.path", w.Name)] = w.GetMountPath()
+	}
+	return ApplyReplacements(spec, replacements, map[string][]string{})
+}
+
 // ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
 func ApplyReplacements(spec *v1alpha1.TaskSpec, stringReplacements map[string]string, arrayReplacements map[string][]string) *v1alpha1.TaskSpec {
 	spec = spec.DeepCopy()
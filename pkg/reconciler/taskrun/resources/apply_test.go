@@ -303,6 +303,22 @@ var (
 		},
 	}
 
+	resourcesVariablesTaskSpec = &v1alpha1.TaskSpec{
+		Inputs: &v1alpha1.Inputs{
+			Resources: []v1alpha1.TaskResource{{
+				ResourceDeclaration: v1alpha1.ResourceDeclaration{
+					Name: "workspace",
+				},
+			}},
+		},
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name:       "foo",
+			Image:      "busybox",
+			WorkingDir: "$(resources.inputs.workspace.path)",
+			Args:       []string{"$(resources.inputs.workspace.url)", "$(resources.inputs.workspace.revision)"},
+		}}},
+	}
+
 	inputs = map[string]v1alpha1.PipelineResourceInterface{
 		"workspace": gitResource,
 	}
@@ -443,6 +459,37 @@ func TestApplyArrayParameters(t *testing.T) {
 		want: applyMutation(arrayParamTaskSpec, func(spec *v1alpha1.TaskSpec) {
 			spec.Steps[1].Args = []string{"first", "second", "defaulted", "value!", "last"}
 		}),
+	}, {
+		name: "starred array parameter",
+		args: args{
+			ts: applyMutation(arrayParamTaskSpec, func(spec *v1alpha1.TaskSpec) {
+				spec.Steps[1].Args = []string{"first", "second", "$(inputs.params.array-param[*])", "last"}
+			}),
+			tr: arrayTaskRun3Elements,
+		},
+		want: applyMutation(arrayParamTaskSpec, func(spec *v1alpha1.TaskSpec) {
+			spec.Steps[1].Args = []string{"first", "second", "foo", "bar", "third", "last"}
+		}),
+	}, {
+		name: "object parameter",
+		args: args{
+			ts: applyMutation(arrayParamTaskSpec, func(spec *v1alpha1.TaskSpec) {
+				spec.Steps[1].Args = []string{"clone", "$(inputs.params.repo.url)", "--revision=$(inputs.params.repo.revision)"}
+			}),
+			tr: &v1alpha1.TaskRun{
+				Spec: v1alpha1.TaskRunSpec{
+					Inputs: v1alpha1.TaskRunInputs{
+						Params: []v1alpha1.Param{{
+							Name:  "repo",
+							Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeObject, ObjectVal: map[string]string{"url": "https://github.com/tektoncd/pipeline", "revision": "master"}},
+						}},
+					},
+				},
+			},
+		},
+		want: applyMutation(arrayParamTaskSpec, func(spec *v1alpha1.TaskSpec) {
+			spec.Steps[1].Args = []string{"clone", "https://github.com/tektoncd/pipeline", "--revision=master"}
+		}),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -566,6 +613,17 @@ func TestApplyResources(t *testing.T) {
 		want: applyMutation(gcsTaskSpec, func(spec *v1alpha1.TaskSpec) {
 			spec.Steps[0].Args = []string{"/workspace/output/bucket"}
 		}),
+	}, {
+		name: "input resource specified with resources variables",
+		args: args{
+			ts:   resourcesVariablesTaskSpec,
+			r:    inputs,
+			rStr: "inputs",
+		},
+		want: applyMutation(resourcesVariablesTaskSpec, func(spec *v1alpha1.TaskSpec) {
+			spec.Steps[0].WorkingDir = "/workspace/workspace"
+			spec.Steps[0].Args = []string{"https://git-repo", "master"}
+		}),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -576,3 +634,54 @@ func TestApplyResources(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyResults(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Results: []v1alpha1.TaskResult{{Name: "commit"}},
+		Steps: []v1alpha1.Step{{
+			Container: corev1.Container{
+				Name:  "git-rev-parse",
+				Image: "alpine/git",
+				Args:  []string{"rev-parse", "HEAD", "--output=$(results.commit.path)"},
+			},
+			Script: "git rev-parse HEAD > $(results.commit.path)",
+		}},
+	}
+	want := applyMutation(ts, func(spec *v1alpha1.TaskSpec) {
+		spec.Steps[0].Args = []string{"rev-parse", "HEAD", "--output=/tekton/results/commit"}
+		spec.Steps[0].Script = "git rev-parse HEAD > /tekton/results/commit"
+	})
+	got := resources.ApplyResults(ts)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyResults() -want, +got: %v", d)
+	}
+}
+
+func TestApplyWorkspaces(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Workspaces: []v1alpha1.WorkspaceDeclaration{{
+			Name: "source",
+		}, {
+			Name:      "cache",
+			MountPath: "/cache",
+		}},
+		Steps: []v1alpha1.Step{{
+			Container: corev1.Container{
+				Name:       "build",
+				Image:      "golang",
+				WorkingDir: "$(workspaces.source.path)",
+				Env:        []corev1.EnvVar{{Name: "GOCACHE", Value: "$(workspaces.cache.path)/go"}},
+			},
+			Script: "go build -o $(workspaces.source.path)/bin ./...",
+		}},
+	}
+	want := applyMutation(ts, func(spec *v1alpha1.TaskSpec) {
+		spec.Steps[0].WorkingDir = "/workspace/source"
+		spec.Steps[0].Env[0].Value = "/cache/go"
+		spec.Steps[0].Script = "go build -o /workspace/source/bin ./..."
+	})
+	got := resources.ApplyWorkspaces(ts)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyWorkspaces() -want, +got: %v", d)
+	}
+}
@@ -53,10 +53,12 @@ const (
 type CEClient client.Client
 
 // TektonCloudEventData type is used to marshal and unmarshal the payload of
-// a Tekton cloud event. It only includes a TaskRun for now. Using a type opens
-// the possibility for the future to add more data to the payload
+// a Tekton cloud event. It includes the TaskRun or the PipelineRun the event
+// is about. Using a type opens the possibility for the future to add more
+// data to the payload
 type TektonCloudEventData struct {
-	TaskRun *v1alpha1.TaskRun `json:"taskRun"`
+	TaskRun     *v1alpha1.TaskRun     `json:"taskRun,omitempty"`
+	PipelineRun *v1alpha1.PipelineRun `json:"pipelineRun,omitempty"`
 }
 
 // NewTektonCloudEventData returns a new instance of NewTektonCloudEventData
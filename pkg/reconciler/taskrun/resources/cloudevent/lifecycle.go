@@ -0,0 +1,161 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/apis"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+// CloudEventsSinkAnnotation is the annotation that, set on a Namespace, sends
+// the cloud events about its runs to its value rather than to the
+// default-cloud-events-sink.
+const CloudEventsSinkAnnotation = "tekton.dev/cloud-events-sink"
+
+const (
+	// TektonTaskRunStartedV1 is sent for TaskRuns when they are first reconciled
+	TektonTaskRunStartedV1 TektonEventType = "dev.tekton.event.task.started.v1"
+	// TektonTaskRunRunningV1 is sent for TaskRuns whose "ConditionSucceeded" "Unknown" changes
+	TektonTaskRunRunningV1 TektonEventType = "dev.tekton.event.task.running.v1"
+	// TektonTaskRunCancelledV1 is sent for TaskRuns that were cancelled
+	TektonTaskRunCancelledV1 TektonEventType = "dev.tekton.event.task.cancelled.v1"
+	// TektonPipelineRunStartedV1 is sent for PipelineRuns when they are first reconciled
+	TektonPipelineRunStartedV1 TektonEventType = "dev.tekton.event.pipeline.started.v1"
+	// TektonPipelineRunRunningV1 is sent for PipelineRuns whose "ConditionSucceeded" "Unknown" changes
+	TektonPipelineRunRunningV1 TektonEventType = "dev.tekton.event.pipeline.running.v1"
+	// TektonPipelineRunSuccessfulV1 is sent for PipelineRuns with "ConditionSucceeded" "True"
+	TektonPipelineRunSuccessfulV1 TektonEventType = "dev.tekton.event.pipeline.successful.v1"
+	// TektonPipelineRunFailedV1 is sent for PipelineRuns with "ConditionSucceeded" "False"
+	TektonPipelineRunFailedV1 TektonEventType = "dev.tekton.event.pipeline.failed.v1"
+	// TektonPipelineRunCancelledV1 is sent for PipelineRuns that were cancelled
+	TektonPipelineRunCancelledV1 TektonEventType = "dev.tekton.event.pipeline.cancelled.v1"
+)
+
+// EmitCloudEvents sends cloud events about run, a TaskRun or a PipelineRun,
+// if its "ConditionSucceeded" changed from before to after: a started event if
+// it had no condition before and an event for the state after otherwise. The
+// events are sent to the most specific sink configured for run, see sinkURI,
+// and nothing is sent if there is none. Errors sending the events are logged,
+// they don't fail the reconciliation of run.
+func EmitCloudEvents(defaultSinkURI string, enableRunSink bool, before, after *apis.Condition, run interface{}, logger *zap.SugaredLogger, cloudEventClient CEClient, namespaceLister corelisters.NamespaceLister) {
+	if after == nil {
+		return
+	}
+	eventTypes := lifecycleEventTypes(before, after, run)
+	if len(eventTypes) == 0 {
+		return
+	}
+	sinkURI := sinkURI(defaultSinkURI, enableRunSink, run, namespaceLister, logger)
+	if sinkURI == "" {
+		return
+	}
+	for _, eventType := range eventTypes {
+		if err := sendLifecycleCloudEvent(sinkURI, run, eventType, logger, cloudEventClient); err != nil {
+			logger.Warnf("Failed to send the %s cloud event to %s: %v", eventType, sinkURI, err)
+		}
+	}
+}
+
+// sinkURI returns the URL the cloud events about run are sent to: the
+// CloudEventsSink of its spec if enableRunSink, or else the
+// CloudEventsSinkAnnotation of its Namespace, or else defaultSinkURI.
+func sinkURI(defaultSinkURI string, enableRunSink bool, run interface{}, namespaceLister corelisters.NamespaceLister, logger *zap.SugaredLogger) string {
+	var namespace, sink string
+	switch r := run.(type) {
+	case *v1alpha1.TaskRun:
+		namespace, sink = r.Namespace, r.Spec.CloudEventsSink
+	case *v1alpha1.PipelineRun:
+		namespace, sink = r.Namespace, r.Spec.CloudEventsSink
+	}
+	switch {
+	case sink != "" && enableRunSink:
+		return sink
+	case sink != "":
+		logger.Warnf("Ignoring the cloud events sink %s of the run: the enable-run-cloud-events-sink feature flag is off", sink)
+	}
+	ns, err := namespaceLister.Get(namespace)
+	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
+		logger.Warnf("Failed to get the cloud events sink of namespace %s, using the default one: %v", namespace, err)
+	case ns.Annotations[CloudEventsSinkAnnotation] != "":
+		return ns.Annotations[CloudEventsSinkAnnotation]
+	}
+	return defaultSinkURI
+}
+
+func lifecycleEventTypes(before, after *apis.Condition, run interface{}) []TektonEventType {
+	var started, running, successful, failed, cancelled TektonEventType
+	var isCancelled bool
+	switch r := run.(type) {
+	case *v1alpha1.TaskRun:
+		started, running, successful, failed, cancelled = TektonTaskRunStartedV1, TektonTaskRunRunningV1, TektonTaskRunSuccessfulV1, TektonTaskRunFailedV1, TektonTaskRunCancelledV1
+		isCancelled = r.IsCancelled()
+	case *v1alpha1.PipelineRun:
+		started, running, successful, failed, cancelled = TektonPipelineRunStartedV1, TektonPipelineRunRunningV1, TektonPipelineRunSuccessfulV1, TektonPipelineRunFailedV1, TektonPipelineRunCancelledV1
+		isCancelled = r.IsCancelled()
+	default:
+		return nil
+	}
+
+	var eventTypes []TektonEventType
+	if before == nil {
+		eventTypes = append(eventTypes, started)
+	} else if before.Status == after.Status && before.Reason == after.Reason {
+		return nil
+	}
+	switch {
+	case after.IsTrue():
+		eventTypes = append(eventTypes, successful)
+	case after.IsFalse() && isCancelled:
+		eventTypes = append(eventTypes, cancelled)
+	case after.IsFalse():
+		eventTypes = append(eventTypes, failed)
+	case before != nil || after.Reason != "":
+		eventTypes = append(eventTypes, running)
+	}
+	return eventTypes
+}
+
+func sendLifecycleCloudEvent(sinkURI string, run interface{}, eventType TektonEventType, logger *zap.SugaredLogger, cloudEventClient CEClient) error {
+	var data TektonCloudEventData
+	var uid, selfLink string
+	switch r := run.(type) {
+	case *v1alpha1.TaskRun:
+		data.TaskRun = r
+		uid, selfLink = string(r.UID), r.SelfLink
+	case *v1alpha1.PipelineRun:
+		data.PipelineRun = r
+		uid, selfLink = string(r.UID), r.SelfLink
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	// The ID of an event must be unique for its source, run gets at most one
+	// event of each type.
+	eventID := fmt.Sprintf("%s.%s", uid, eventType)
+	_, err = SendCloudEvent(sinkURI, eventID, selfLink, payload, eventType, logger, cloudEventClient)
+	return err
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudevents/sdk-go/pkg/cloudevents"
+	cecontext "github.com/cloudevents/sdk-go/pkg/cloudevents/context"
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+)
+
+// recordingClient records the events it sends, and the sinks it sends them
+// to.
+type recordingClient struct {
+	events  *[]cloudevents.Event
+	targets *[]string
+}
+
+func (c recordingClient) Send(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, error) {
+	*c.events = append(*c.events, event)
+	if c.targets != nil {
+		*c.targets = append(*c.targets, cecontext.TargetFrom(ctx).String())
+	}
+	return &event, nil
+}
+
+func (c recordingClient) StartReceiver(ctx context.Context, fn interface{}) error {
+	return nil
+}
+
+func TestEmitCloudEvents(t *testing.T) {
+	unknown := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}
+	running := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown, Reason: "Running"}
+	succeeded := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: "Succeeded"}
+	failed := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "Failed"}
+	taskRun := &v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{
+		Name:     "test-taskrun",
+		UID:      "1234",
+		SelfLink: "/apis/tekton.dev/v1alpha1/namespaces/foo/taskruns/test-taskrun",
+	}}
+	cancelledTaskRun := taskRun.DeepCopy()
+	cancelledTaskRun.Spec.Status = v1alpha1.TaskRunSpecStatusCancelled
+	pipelineRun := &v1alpha1.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+		Name:     "test-pipelinerun",
+		UID:      "5678",
+		SelfLink: "/apis/tekton.dev/v1alpha1/namespaces/foo/pipelineruns/test-pipelinerun",
+	}}
+	cancelledPipelineRun := pipelineRun.DeepCopy()
+	cancelledPipelineRun.Spec.Status = v1alpha1.PipelineRunSpecStatusCancelled
+
+	for _, tc := range []struct {
+		name      string
+		sinkURI   string
+		before    *apis.Condition
+		after     *apis.Condition
+		run       interface{}
+		wantTypes []string
+	}{{
+		name:      "no sink",
+		after:     unknown,
+		run:       taskRun,
+		wantTypes: nil,
+	}, {
+		name:      "taskrun started",
+		sinkURI:   defaultSinkURI,
+		after:     unknown,
+		run:       taskRun,
+		wantTypes: []string{"dev.tekton.event.task.started.v1"},
+	}, {
+		name:      "taskrun started and running",
+		sinkURI:   defaultSinkURI,
+		after:     running,
+		run:       taskRun,
+		wantTypes: []string{"dev.tekton.event.task.started.v1", "dev.tekton.event.task.running.v1"},
+	}, {
+		name:      "taskrun running",
+		sinkURI:   defaultSinkURI,
+		before:    unknown,
+		after:     running,
+		run:       taskRun,
+		wantTypes: []string{"dev.tekton.event.task.running.v1"},
+	}, {
+		name:      "taskrun unchanged",
+		sinkURI:   defaultSinkURI,
+		before:    running,
+		after:     running,
+		run:       taskRun,
+		wantTypes: nil,
+	}, {
+		name:      "taskrun succeeded",
+		sinkURI:   defaultSinkURI,
+		before:    running,
+		after:     succeeded,
+		run:       taskRun,
+		wantTypes: []string{"dev.tekton.event.task.successful.v1"},
+	}, {
+		name:      "taskrun failed",
+		sinkURI:   defaultSinkURI,
+		before:    running,
+		after:     failed,
+		run:       taskRun,
+		wantTypes: []string{"dev.tekton.event.task.failed.v1"},
+	}, {
+		name:      "taskrun cancelled",
+		sinkURI:   defaultSinkURI,
+		before:    running,
+		after:     failed,
+		run:       cancelledTaskRun,
+		wantTypes: []string{"dev.tekton.event.task.cancelled.v1"},
+	}, {
+		name:      "pipelinerun started",
+		sinkURI:   defaultSinkURI,
+		after:     unknown,
+		run:       pipelineRun,
+		wantTypes: []string{"dev.tekton.event.pipeline.started.v1"},
+	}, {
+		name:      "pipelinerun succeeded",
+		sinkURI:   defaultSinkURI,
+		before:    running,
+		after:     succeeded,
+		run:       pipelineRun,
+		wantTypes: []string{"dev.tekton.event.pipeline.successful.v1"},
+	}, {
+		name:      "pipelinerun failed",
+		sinkURI:   defaultSinkURI,
+		before:    running,
+		after:     failed,
+		run:       pipelineRun,
+		wantTypes: []string{"dev.tekton.event.pipeline.failed.v1"},
+	}, {
+		name:      "pipelinerun cancelled",
+		sinkURI:   defaultSinkURI,
+		before:    running,
+		after:     failed,
+		run:       cancelledPipelineRun,
+		wantTypes: []string{"dev.tekton.event.pipeline.cancelled.v1"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var events []cloudevents.Event
+			logger, _ := logging.NewLogger("", "")
+			EmitCloudEvents(tc.sinkURI, false, tc.before, tc.after, tc.run, logger, recordingClient{events: &events}, corelisters.NewNamespaceLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})))
+
+			var gotTypes []string
+			for _, event := range events {
+				gotTypes = append(gotTypes, event.Type())
+				var data TektonCloudEventData
+				if err := json.Unmarshal(event.Data.([]byte), &data); err != nil {
+					t.Fatalf("Unexpected error unmarshalling the data of the event: %v", err)
+				}
+				if data.TaskRun == nil && data.PipelineRun == nil {
+					t.Errorf("Expected the data of the %s event to hold the run", event.Type())
+				}
+			}
+			if diff := cmp.Diff(tc.wantTypes, gotTypes); diff != "" {
+				t.Errorf("Unexpected event types (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestEmitCloudEventsSink(t *testing.T) {
+	unknown := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}
+	namespace := func(annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo", Annotations: annotations}}
+	}
+	taskRun := func(sink string) *v1alpha1.TaskRun {
+		return &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-taskrun",
+				Namespace: "foo",
+				UID:       "1234",
+				SelfLink:  "/apis/tekton.dev/v1alpha1/namespaces/foo/taskruns/test-taskrun",
+			},
+			Spec: v1alpha1.TaskRunSpec{CloudEventsSink: sink},
+		}
+	}
+	pipelineRun := func(sink string) *v1alpha1.PipelineRun {
+		return &v1alpha1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pipelinerun",
+				Namespace: "foo",
+				UID:       "5678",
+				SelfLink:  "/apis/tekton.dev/v1alpha1/namespaces/foo/pipelineruns/test-pipelinerun",
+			},
+			Spec: v1alpha1.PipelineRunSpec{CloudEventsSink: sink},
+		}
+	}
+
+	for _, tc := range []struct {
+		name          string
+		sinkURI       string
+		namespace     *corev1.Namespace
+		enableRunSink bool
+		run           interface{}
+		wantTargets   []string
+	}{{
+		name:        "default sink",
+		sinkURI:     defaultSinkURI,
+		namespace:   namespace(nil),
+		run:         taskRun(""),
+		wantTargets: []string{defaultSinkURI},
+	}, {
+		name:        "no namespace",
+		sinkURI:     defaultSinkURI,
+		run:         pipelineRun(""),
+		wantTargets: []string{defaultSinkURI},
+	}, {
+		name:        "namespace sink",
+		sinkURI:     defaultSinkURI,
+		namespace:   namespace(map[string]string{CloudEventsSinkAnnotation: "http://namespace-sink"}),
+		run:         taskRun(""),
+		wantTargets: []string{"http://namespace-sink"},
+	}, {
+		name:        "namespace sink without default sink",
+		namespace:   namespace(map[string]string{CloudEventsSinkAnnotation: "http://namespace-sink"}),
+		run:         pipelineRun(""),
+		wantTargets: []string{"http://namespace-sink"},
+	}, {
+		name:          "taskrun sink",
+		sinkURI:       defaultSinkURI,
+		namespace:     namespace(map[string]string{CloudEventsSinkAnnotation: "http://namespace-sink"}),
+		enableRunSink: true,
+		run:           taskRun("http://run-sink"),
+		wantTargets:   []string{"http://run-sink"},
+	}, {
+		name:          "pipelinerun sink",
+		namespace:     namespace(map[string]string{CloudEventsSinkAnnotation: "http://namespace-sink"}),
+		enableRunSink: true,
+		run:           pipelineRun("http://run-sink"),
+		wantTargets:   []string{"http://run-sink"},
+	}, {
+		name:        "run sink disabled",
+		sinkURI:     defaultSinkURI,
+		namespace:   namespace(map[string]string{CloudEventsSinkAnnotation: "http://namespace-sink"}),
+		run:         taskRun("http://run-sink"),
+		wantTargets: []string{"http://namespace-sink"},
+	}, {
+		name:        "run sink disabled without namespace sink",
+		sinkURI:     defaultSinkURI,
+		namespace:   namespace(nil),
+		run:         pipelineRun("http://run-sink"),
+		wantTargets: []string{defaultSinkURI},
+	}, {
+		name:      "no sink",
+		namespace: namespace(nil),
+		run:       taskRun(""),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			if tc.namespace != nil {
+				if err := indexer.Add(tc.namespace); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var events []cloudevents.Event
+			var targets []string
+			logger, _ := logging.NewLogger("", "")
+			EmitCloudEvents(tc.sinkURI, tc.enableRunSink, nil, unknown, tc.run, logger, recordingClient{events: &events, targets: &targets}, corelisters.NewNamespaceLister(indexer))
+
+			if diff := cmp.Diff(tc.wantTargets, targets); diff != "" {
+				t.Errorf("Unexpected sinks (-want, +got): %s", diff)
+			}
+		})
+	}
+}
@@ -17,9 +17,11 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/artifacts"
@@ -44,6 +46,7 @@ func getBoundResource(resourceName string, boundResources []v1alpha1.TaskResourc
 // from  previous task
 // 3. If resource has paths declared then fresh copy of resource is not fetched
 func AddInputResource(
+	ctx context.Context,
 	kubeclient kubernetes.Interface,
 	images pipeline.Images,
 	taskName string,
@@ -57,6 +60,7 @@ func AddInputResource(
 		return taskSpec, nil
 	}
 	taskSpec = taskSpec.DeepCopy()
+	cfg := config.FromContextOrDefaults(ctx)
 
 	pvcName := taskRun.GetPipelineRunPVCName()
 	mountPVC := false
@@ -86,7 +90,7 @@ func AddInputResource(
 		dPath := destinationPath(input.Name, input.TargetPath)
 		// if taskrun is fetching resource from previous task then execute copy step instead of fetching new copy
 		// to the desired destination directory, as long as the resource exports output to be copied
-		if v1alpha1.AllowedOutputResources[resource.GetType()] && taskRun.HasPipelineRunOwnerReference() {
+		if cfg.FeatureFlags.OutputResourceAllowed(resource.GetType()) && taskRun.HasPipelineRunOwnerReference() {
 			for _, path := range boundResource.Paths {
 				cpSteps := as.GetCopyFromStorageToSteps(boundResource.Name, path, dPath)
 				if as.GetType() == pipeline.ArtifactStoragePVCType {
@@ -113,7 +117,11 @@ func AddInputResource(
 			if err != nil {
 				return nil, err
 			}
-			if err := v1alpha1.ApplyTaskModifier(taskSpec, modifier); err != nil {
+			if err := v1alpha1.ApplyTaskModifiers(taskSpec, v1alpha1.NamedTaskModifier{
+				TaskModifier:   modifier,
+				Name:           boundResource.Name,
+				ConflictPolicy: v1alpha1.TaskModifierConflictMerge,
+			}); err != nil {
 				return nil, fmt.Errorf("unabled to apply Resource %s: %w", boundResource.Name, err)
 			}
 		}
@@ -17,9 +17,11 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/artifacts"
@@ -44,6 +46,7 @@ var (
 // 1. If resource has a targetpath that is used. Otherwise:
 // 2. If resource is declared in outputs only then the default is /output/resource_name
 func AddOutputResources(
+	ctx context.Context,
 	kubeclient kubernetes.Interface,
 	images pipeline.Images,
 	taskName string,
@@ -58,6 +61,7 @@ func AddOutputResources(
 	}
 
 	taskSpec = taskSpec.DeepCopy()
+	cfg := config.FromContextOrDefaults(ctx)
 
 	pvcName := taskRun.GetPipelineRunPVCName()
 	as, err := artifacts.GetArtifactStorage(images, pvcName, kubeclient, logger)
@@ -87,7 +91,7 @@ func AddOutputResources(
 		mkdirSteps := []v1alpha1.Step{v1alpha1.CreateDirStep(images.ShellImage, boundResource.Name, sourcePath)}
 		taskSpec.Steps = append(mkdirSteps, taskSpec.Steps...)
 
-		if v1alpha1.AllowedOutputResources[resource.GetType()] && taskRun.HasPipelineRunOwnerReference() {
+		if cfg.FeatureFlags.OutputResourceAllowed(resource.GetType()) && taskRun.HasPipelineRunOwnerReference() {
 			var newSteps []v1alpha1.Step
 			for _, dPath := range boundResource.Paths {
 				newSteps = append(newSteps, as.GetCopyToStorageFromSteps(resource.GetName(), sourcePath, dPath)...)
@@ -102,7 +106,11 @@ func AddOutputResources(
 		if err != nil {
 			return nil, err
 		}
-		if err := v1alpha1.ApplyTaskModifier(taskSpec, modifier); err != nil {
+		if err := v1alpha1.ApplyTaskModifiers(taskSpec, v1alpha1.NamedTaskModifier{
+			TaskModifier:   modifier,
+			Name:           boundResource.Name,
+			ConflictPolicy: v1alpha1.TaskModifierConflictMerge,
+		}); err != nil {
 			return nil, fmt.Errorf("Unabled to apply Resource %s: %w", boundResource.Name, err)
 		}
 	}
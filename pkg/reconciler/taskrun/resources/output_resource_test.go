@@ -17,9 +17,11 @@ limitations under the License.
 package resources
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/artifacts"
 	"github.com/tektoncd/pipeline/pkg/logging"
@@ -867,7 +869,7 @@ func TestValidOutputResources(t *testing.T) {
 			names.TestingSeed()
 			outputResourceSetup()
 			fakekubeclient := fakek8s.NewSimpleClientset()
-			got, err := AddOutputResources(fakekubeclient, images, c.task.Name, &c.task.Spec, c.taskRun, resolveOutputResources(c.taskRun), logger)
+			got, err := AddOutputResources(context.Background(), fakekubeclient, images, c.task.Name, &c.task.Spec, c.taskRun, resolveOutputResources(c.taskRun), logger)
 			if err != nil {
 				t.Fatalf("Failed to declare output resources for test name %q ; test description %q: error %v", c.name, c.desc, err)
 			}
@@ -884,6 +886,107 @@ func TestValidOutputResources(t *testing.T) {
 	}
 }
 
+func TestOutputResourcesAllowedByFeatureFlags(t *testing.T) {
+	taskRun := &v1alpha1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-taskrun-run-output-steps",
+			Namespace: "marshmallow",
+			OwnerReferences: []metav1.OwnerReference{{
+				Kind: "PipelineRun",
+				Name: "pipelinerun",
+			}},
+		},
+		Spec: v1alpha1.TaskRunSpec{
+			Outputs: v1alpha1.TaskRunOutputs{
+				Resources: []v1alpha1.TaskResourceBinding{{
+					PipelineResourceBinding: v1alpha1.PipelineResourceBinding{
+						Name: "image",
+						ResourceRef: &v1alpha1.PipelineResourceRef{
+							Name: "source-image",
+						},
+					},
+					Paths: []string{"pipeline-task-name"},
+				}},
+			},
+		},
+	}
+	task := &v1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "task1",
+			Namespace: "marshmallow",
+		},
+		Spec: v1alpha1.TaskSpec{
+			Outputs: &v1alpha1.Outputs{
+				Resources: []v1alpha1.TaskResource{{
+					ResourceDeclaration: v1alpha1.ResourceDeclaration{
+						Name: "image",
+						Type: "image",
+					}}},
+			},
+		},
+	}
+	mkdirStep := v1alpha1.Step{Container: corev1.Container{
+		Name:    "create-dir-image-9l9zj",
+		Image:   "busybox",
+		Command: []string{"mkdir", "-p", "/workspace/output/image"},
+	}}
+	for _, c := range []struct {
+		desc         string
+		featureFlags *config.FeatureFlags
+		wantSteps    []v1alpha1.Step
+		wantVolumes  []corev1.Volume
+	}{{
+		desc:         "image outputs not allowed by default",
+		featureFlags: &config.FeatureFlags{},
+		wantSteps:    []v1alpha1.Step{mkdirStep},
+	}, {
+		desc:         "image outputs allowed",
+		featureFlags: &config.FeatureFlags{AllowedOutputResources: []v1alpha1.PipelineResourceType{"git", "image"}},
+		wantSteps: []v1alpha1.Step{mkdirStep, {Container: corev1.Container{
+			Name:    "source-mkdir-source-image-mz4c7",
+			Image:   "busybox",
+			Command: []string{"mkdir", "-p", "pipeline-task-name"},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "pipelinerun-pvc",
+				MountPath: "/pvc",
+			}},
+		}}, {Container: corev1.Container{
+			Name:    "source-copy-source-image-mssqb",
+			Image:   "busybox",
+			Command: []string{"cp", "-r", "/workspace/output/image/.", "pipeline-task-name"},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "pipelinerun-pvc",
+				MountPath: "/pvc",
+			}},
+		}}},
+		wantVolumes: []corev1.Volume{{
+			Name: "pipelinerun-pvc",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "pipelinerun-pvc",
+				},
+			},
+		}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			names.TestingSeed()
+			outputResourceSetup()
+			ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: c.featureFlags})
+			fakekubeclient := fakek8s.NewSimpleClientset()
+			got, err := AddOutputResources(ctx, fakekubeclient, images, task.Name, &task.Spec, taskRun, resolveOutputResources(taskRun), logger)
+			if err != nil {
+				t.Fatalf("AddOutputResources() = %v", err)
+			}
+			if d := cmp.Diff(c.wantSteps, got.Steps); d != "" {
+				t.Errorf("Steps diff (-want, +got): %s", d)
+			}
+			if d := cmp.Diff(c.wantVolumes, got.Volumes); d != "" {
+				t.Errorf("Volumes diff (-want, +got): %s", d)
+			}
+		})
+	}
+}
+
 func TestValidOutputResourcesWithBucketStorage(t *testing.T) {
 	for _, c := range []struct {
 		name      string
@@ -1066,7 +1169,7 @@ func TestValidOutputResourcesWithBucketStorage(t *testing.T) {
 					},
 				},
 			)
-			got, err := AddOutputResources(fakekubeclient, images, c.task.Name, &c.task.Spec, c.taskRun, resolveOutputResources(c.taskRun), logger)
+			got, err := AddOutputResources(context.Background(), fakekubeclient, images, c.task.Name, &c.task.Spec, c.taskRun, resolveOutputResources(c.taskRun), logger)
 			if err != nil {
 				t.Fatalf("Failed to declare output resources for test name %q ; test description %q: error %v", c.name, c.desc, err)
 			}
@@ -1214,7 +1317,7 @@ func TestInvalidOutputResources(t *testing.T) {
 		t.Run(c.desc, func(t *testing.T) {
 			outputResourceSetup()
 			fakekubeclient := fakek8s.NewSimpleClientset()
-			_, err := AddOutputResources(fakekubeclient, images, c.task.Name, &c.task.Spec, c.taskRun, resolveOutputResources(c.taskRun), logger)
+			_, err := AddOutputResources(context.Background(), fakekubeclient, images, c.task.Name, &c.task.Spec, c.taskRun, resolveOutputResources(c.taskRun), logger)
 			if (err != nil) != c.wantErr {
 				t.Fatalf("Test AddOutputResourceSteps %v : error%v", c.desc, err)
 			}
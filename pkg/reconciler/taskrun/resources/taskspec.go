@@ -37,7 +37,7 @@ func GetTaskData(taskRun *v1alpha1.TaskRun, getTask GetTask) (*metav1.ObjectMeta
 	taskMeta := metav1.ObjectMeta{}
 	taskSpec := v1alpha1.TaskSpec{}
 	switch {
-	case taskRun.Spec.TaskRef != nil && taskRun.Spec.TaskRef.Name != "":
+	case taskRun.Spec.TaskRef != nil && (taskRun.Spec.TaskRef.Name != "" || taskRun.Spec.TaskRef.Resolver != ""):
 		// Get related task for taskrun
 		t, err := getTask(taskRun.Spec.TaskRef.Name)
 		if err != nil {
@@ -18,7 +18,6 @@ package taskrun
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -30,18 +29,24 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/contexts"
+	tklogging "github.com/tektoncd/pipeline/pkg/logging"
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/resolution"
+	"github.com/tektoncd/pipeline/pkg/termination"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
 	"knative.dev/pkg/tracker"
 )
 
@@ -50,6 +55,11 @@ const (
 	taskRunAgentName = "taskrun-controller"
 )
 
+type configStore interface {
+	ToContext(ctx context.Context) context.Context
+	WatchConfigs(w configmap.Watcher)
+}
+
 // Reconciler implements controller.Reconciler for Configuration resources.
 type Reconciler struct {
 	*reconciler.Base
@@ -59,8 +69,14 @@ type Reconciler struct {
 	taskLister        listers.TaskLister
 	clusterTaskLister listers.ClusterTaskLister
 	resourceLister    listers.PipelineResourceLister
+	namespaceLister   corelisters.NamespaceLister
+	requester         resolution.Requester
+	podIndexer        cache.Indexer
+	podPolicy         PodPolicy
+	httpClient        reconciler.HTTPDoer
 	cloudEventClient  cloudevent.CEClient
 	tracker           tracker.Interface
+	configStore       configStore
 	entrypointCache   podconvert.EntrypointCache
 	timeoutHandler    *reconciler.TimeoutSet
 	metrics           *Recorder
@@ -72,7 +88,7 @@ var _ controller.Reconciler = (*Reconciler)(nil)
 // Reconcile compares the actual state with the desired, and attempts to
 // converge the two. It then updates the Status block of the Task Run
 // resource with the current status of the resource.
-func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+func (c *Reconciler) Reconcile(ctx context.Context, key string) (err error) {
 	// In case of reconcile errors, we store the error in a multierror, attempt
 	// to update, and return the original error combined with any update error
 	var merr error
@@ -84,6 +100,8 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 		return nil
 	}
 
+	ctx = c.configStore.ToContext(ctx)
+
 	// Get the Task Run resource with this namespace/name
 	original, err := c.taskRunLister.TaskRuns(namespace).Get(name)
 	if errors.IsNotFound(err) {
@@ -98,6 +116,19 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 	// Don't modify the informer's copy.
 	tr := original.DeepCopy()
 
+	if tklogging.Traced(original) {
+		logger := tklogging.TraceLogger(logging.FromContext(ctx))
+		logger.Debugw("Reconciling traced TaskRun", "status", original.Status)
+		defer func(start time.Time) {
+			logger.Debugw("Reconciled traced TaskRun", "duration", time.Since(start), "status", tr.Status, zap.Error(err))
+		}(time.Now())
+	}
+
+	if tr.DeletionTimestamp != nil && reconciler.HasFinalizer(tr, reconciler.CleanupFinalizer) {
+		return c.finalize(tr)
+	}
+	addFinalizer(ctx, tr)
+
 	// If the TaskRun is just starting, this will also set the starttime,
 	// from which the timeout will immediately begin counting down.
 	tr.Status.InitializeConditions()
@@ -152,6 +183,9 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 		c.Logger.Errorf("Reconcile error: %v", err.Error())
 		merr = multierror.Append(merr, err)
 	}
+	cfg := config.FromContextOrDefaults(ctx)
+	cloudevent.EmitCloudEvents(cfg.Defaults.DefaultCloudEventsSink, cfg.FeatureFlags.EnableRunCloudEventsSink,
+		original.Status.GetCondition(apis.ConditionSucceeded), tr.Status.GetCondition(apis.ConditionSucceeded), tr, c.Logger, c.cloudEventClient, c.namespaceLister)
 	return multierror.Append(merr, c.updateStatusLabelsAndAnnotations(tr, original)).ErrorOrNil()
 }
 
@@ -172,7 +206,7 @@ func (c *Reconciler) updateStatusLabelsAndAnnotations(tr, original *v1alpha1.Tas
 
 	// Since we are using the status subresource, it is not possible to update
 	// the status and labels/annotations simultaneously.
-	if !reflect.DeepEqual(original.ObjectMeta.Labels, tr.ObjectMeta.Labels) || !reflect.DeepEqual(original.ObjectMeta.Annotations, tr.ObjectMeta.Annotations) {
+	if !reflect.DeepEqual(original.ObjectMeta.Labels, tr.ObjectMeta.Labels) || !reflect.DeepEqual(original.ObjectMeta.Annotations, tr.ObjectMeta.Annotations) || !reflect.DeepEqual(original.ObjectMeta.Finalizers, tr.ObjectMeta.Finalizers) {
 		if _, err := c.updateLabelsAndAnnotations(tr); err != nil {
 			c.Logger.Warn("Failed to update TaskRun labels/annotations", zap.Error(err))
 			return err
@@ -192,10 +226,12 @@ func (c *Reconciler) updateStatusLabelsAndAnnotations(tr, original *v1alpha1.Tas
 	return nil
 }
 
-func (c *Reconciler) getTaskFunc(tr *v1alpha1.TaskRun) (resources.GetTask, v1alpha1.TaskKind) {
+func (c *Reconciler) getTaskFunc(ctx context.Context, tr *v1alpha1.TaskRun) (resources.GetTask, v1alpha1.TaskKind) {
 	var gtFunc resources.GetTask
 	kind := v1alpha1.NamespacedTaskKind
-	if tr.Spec.TaskRef != nil && tr.Spec.TaskRef.Kind == v1alpha1.ClusterTaskKind {
+	if tr.Spec.TaskRef != nil && tr.Spec.TaskRef.Resolver != "" {
+		gtFunc = c.getRemoteTask(ctx, tr)
+	} else if tr.Spec.TaskRef != nil && tr.Spec.TaskRef.Kind == v1alpha1.ClusterTaskKind {
 		gtFunc = func(name string) (v1alpha1.TaskInterface, error) {
 			t, err := c.PipelineClientSet.TektonV1alpha1().ClusterTasks().Get(name, metav1.GetOptions{})
 			if err != nil {
@@ -218,7 +254,12 @@ func (c *Reconciler) getTaskFunc(tr *v1alpha1.TaskRun) (resources.GetTask, v1alp
 
 func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error {
 	// We may be reading a version of the object that was stored at an older version
-	// and may not have had all of the assumed default specified.
+	// and may not have had all of the assumed default specified. A TaskRun
+	// without a timeout runs with the configured default timeout, rather than
+	// the one of older versions.
+	if tr.Spec.Timeout == nil {
+		tr.Spec.Timeout = &metav1.Duration{Duration: time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes) * time.Minute}
+	}
 	tr.SetDefaults(contexts.WithUpgradeViaDefaulting(ctx))
 
 	// If the taskrun is cancelled, kill resources and update status
@@ -226,13 +267,25 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 		before := tr.Status.GetCondition(apis.ConditionSucceeded)
 		err := cancelTaskRun(tr, c.KubeClientSet, c.Logger)
 		after := tr.Status.GetCondition(apis.ConditionSucceeded)
+		if before == nil || before.Reason != podconvert.ReasonCancelled {
+			c.Recorder.Event(tr, corev1.EventTypeWarning, podconvert.ReasonCancelled, after.Message)
+		}
 		reconciler.EmitEvent(c.Recorder, before, after, tr)
 		return err
 	}
 
-	getTaskFunc, kind := c.getTaskFunc(tr)
+	getTaskFunc, kind := c.getTaskFunc(ctx, tr)
 	taskMeta, taskSpec, err := resources.GetTaskData(tr, getTaskFunc)
-	if err != nil {
+	if resolution.IsRequestInProgress(err) {
+		// The ResolutionRequest enqueues the TaskRun again once resolved.
+		tr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  podconvert.ReasonResolvingTaskRef,
+			Message: fmt.Sprintf("Waiting for the %s resolver to fetch the Task", tr.Spec.TaskRef.Resolver),
+		})
+		return nil
+	} else if err != nil {
 		c.Logger.Errorf("Failed to determine Task spec to use for taskrun %s: %v", tr.Name, err)
 		tr.Status.SetCondition(&apis.Condition{
 			Type:    apis.ConditionSucceeded,
@@ -243,8 +296,11 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 		return nil
 	}
 
-	// Propagate labels from Task to TaskRun.
-	if tr.ObjectMeta.Labels == nil {
+	// Propagate labels from Task to TaskRun. The maps are only allocated when
+	// there is something to propagate: turning a nil map into an empty one
+	// would cost an extra update (and requeue) of TaskRuns with an embedded
+	// spec and no labels.
+	if tr.ObjectMeta.Labels == nil && (len(taskMeta.Labels) > 0 || tr.Spec.TaskRef != nil) {
 		tr.ObjectMeta.Labels = make(map[string]string, len(taskMeta.Labels)+1)
 	}
 	for key, value := range taskMeta.Labels {
@@ -252,19 +308,20 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 	}
 	if tr.Spec.TaskRef != nil {
 		tr.ObjectMeta.Labels[pipeline.GroupName+pipeline.TaskLabelKey] = taskMeta.Name
+		if kind == v1alpha1.ClusterTaskKind {
+			tr.ObjectMeta.Labels[pipeline.GroupName+pipeline.ClusterTaskLabelKey] = taskMeta.Name
+		}
 	}
 
 	// Propagate annotations from Task to TaskRun.
-	if tr.ObjectMeta.Annotations == nil {
+	if tr.ObjectMeta.Annotations == nil && len(taskMeta.Annotations) > 0 {
 		tr.ObjectMeta.Annotations = make(map[string]string, len(taskMeta.Annotations))
 	}
 	for key, value := range taskMeta.Annotations {
 		tr.ObjectMeta.Annotations[key] = value
 	}
 
-	if tr.Spec.Timeout == nil {
-		tr.Spec.Timeout = &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute}
-	}
+	tr.Status.EffectiveTimeout = &metav1.Duration{Duration: tr.Spec.Timeout.Duration}
 	// Check if the TaskRun has timed out; if it is, this will set its status
 	// accordingly.
 	if CheckTimeout(tr) {
@@ -297,6 +354,17 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 		return nil
 	}
 
+	if err := ValidateWorkspaceBindings(rtr.TaskSpec.Workspaces, tr.Spec.Workspaces); err != nil {
+		c.Logger.Errorf("Failed to validate taskrun %q: %v", tr.Name, err)
+		tr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  podconvert.ReasonFailedValidation,
+			Message: err.Error(),
+		})
+		return nil
+	}
+
 	// Initialize the cloud events if at least a CloudEventResource is defined
 	// and they have not been initialized yet.
 	// FIXME(afrittoli) This resource specific logic will have to be replaced
@@ -320,10 +388,41 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 		}
 	}
 	if pod == nil {
-		pod, err = c.createPod(tr, rtr)
+		// The Pod may have been created without being recorded in the
+		// status.
+		pod, err = c.adoptPod(tr)
 		if err != nil {
-			c.handlePodCreationError(tr, err)
-			return nil
+			c.Logger.Errorf("Failed to adopt the pod of TaskRun %q: %v", tr.Name, err)
+			return err
+		}
+		if pod == nil {
+			// Fail fast rather than leave the Pod Pending forever.
+			if ok, err := hasPlatformNodes(c.KubeClientSet, rtr.TaskSpec.Platforms); err != nil {
+				c.Logger.Errorf("Failed to list the nodes for TaskRun %q: %v", tr.Name, err)
+				return err
+			} else if !ok {
+				tr.Status.SetCondition(&apis.Condition{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Reason:  podconvert.ReasonNoMatchingNodes,
+					Message: fmt.Sprintf("No node of the cluster runs one of the platforms %v of the Task", rtr.TaskSpec.Platforms),
+				})
+				return nil
+			}
+			pod, err = c.createPod(ctx, tr, rtr)
+			if errors.IsAlreadyExists(err) {
+				// The name of the Pod is taken by a Pod that isn't controlled
+				// by this TaskRun.
+				c.Logger.Errorf("Failed to create pod for TaskRun %q: %v", tr.Name, err)
+				return err
+			} else if isPodPolicyUnavailable(err) {
+				// Retry until the pod policy reviews the Pod.
+				c.Logger.Errorf("Failed to create pod for TaskRun %q: %v", tr.Name, err)
+				return err
+			} else if err != nil {
+				c.handlePodCreationError(tr, err)
+				return nil
+			}
 		}
 		go c.timeoutHandler.WaitTaskRun(tr, tr.Status.StartTime)
 	}
@@ -346,8 +445,12 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 
 	// Convert the Pod's status to the equivalent TaskRun Status.
 	tr.Status = podconvert.MakeTaskRunStatus(*tr, pod, *taskSpec)
+	if config.FromContextOrDefaults(ctx).FeatureFlags.RecordStepCommands {
+		tr.Status.StepCommands = podconvert.StepCommands(pod)
+	}
 
-	updateTaskRunResourceResult(tr, pod, c.Logger)
+	updateTaskRunResourceResult(tr, pod, c.getContainerLogs(tr.Namespace), c.Logger)
+	updateMissingResults(tr, taskSpec.Results)
 
 	after := tr.Status.GetCondition(apis.ConditionSucceeded)
 
@@ -368,6 +471,18 @@ func (c *Reconciler) handlePodCreationError(tr *v1alpha1.TaskRun, err error) {
 			go c.timeoutHandler.SetTaskRunTimer(tr, time.Until(backoff.NextAttempt))
 		}
 		msg = fmt.Sprintf("TaskRun Pod exceeded available resources, reattempted %d times", backoff.NumAttempts)
+	} else if podconvert.IsVolumeLimitExceeded(err) {
+		succeededStatus = corev1.ConditionFalse
+		reason = podconvert.ReasonExceededVolumeLimit
+		msg = "TaskRun Pod exceeds the maximum number of volumes"
+	} else if isTaskPolicyViolation(err) {
+		succeededStatus = corev1.ConditionFalse
+		reason = podconvert.ReasonFailedValidation
+		msg = "TaskRun violates the task policy"
+	} else if isPodDenied(err) {
+		succeededStatus = corev1.ConditionFalse
+		reason = podconvert.ReasonPodDenied
+		msg = "TaskRun Pod was denied by the pod policy"
 	} else {
 		succeededStatus = corev1.ConditionFalse
 		reason = podconvert.ReasonCouldntGetTask
@@ -387,11 +502,33 @@ func (c *Reconciler) handlePodCreationError(tr *v1alpha1.TaskRun, err error) {
 	c.Logger.Errorf("Failed to create build pod for task %q: %v", tr.Name, err)
 }
 
-func updateTaskRunResourceResult(taskRun *v1alpha1.TaskRun, pod *corev1.Pod, logger *zap.SugaredLogger) {
+// GetContainerLogs returns the logs of a container of a Pod.
+type GetContainerLogs func(podName, containerName string) (string, error)
+
+func (c *Reconciler) getContainerLogs(namespace string) GetContainerLogs {
+	return func(podName, containerName string) (string, error) {
+		b, err := c.KubeClientSet.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{Container: containerName}).DoRaw()
+		return string(b), err
+	}
+}
+
+func updateTaskRunResourceResult(taskRun *v1alpha1.TaskRun, pod *corev1.Pod, getLogs GetContainerLogs, logger *zap.SugaredLogger) {
 	if taskRun.IsSuccessful() {
 		for _, cs := range pod.Status.ContainerStatuses {
 			if cs.State.Terminated != nil {
 				msg := cs.State.Terminated.Message
+				if termination.Overflowed(msg) {
+					// The results didn't fit in the termination message, so
+					// the container wrote them to its log instead.
+					logs, err := getLogs(pod.Name, cs.Name)
+					if err == nil {
+						msg, err = termination.MessageFromLog(logs)
+					}
+					if err != nil {
+						logger.Warnf("Failed to get the results of %s for %s/%s from its log: %s", cs.Name, taskRun.Name, taskRun.Namespace, err)
+						continue
+					}
+				}
 				if msg != "" {
 					if err := updateTaskRunStatusWithResourceResult(taskRun, []byte(msg)); err != nil {
 						logger.Infof("No resource result from %s for %s/%s: %s", cs.Name, taskRun.Name, taskRun.Namespace, err)
@@ -402,13 +539,43 @@ func updateTaskRunResourceResult(taskRun *v1alpha1.TaskRun, pod *corev1.Pod, log
 	}
 }
 
-// updateTaskRunStatusWithResourceResult if there is an update to the outout image resource, add to taskrun status result
+// updateMissingResults sets the MissingResults of a successful TaskRun to the
+// names of the declared results that aren't in its TaskRunResults. A step
+// that continued on error may not have written them, which doesn't fail the
+// TaskRun.
+func updateMissingResults(taskRun *v1alpha1.TaskRun, declared []v1alpha1.TaskResult) {
+	taskRun.Status.MissingResults = nil
+	if !taskRun.IsSuccessful() {
+		return
+	}
+	reported := map[string]struct{}{}
+	for _, r := range taskRun.Status.TaskRunResults {
+		reported[r.Name] = struct{}{}
+	}
+	for _, r := range declared {
+		if _, ok := reported[r.Name]; !ok {
+			taskRun.Status.MissingResults = append(taskRun.Status.MissingResults, r.Name)
+		}
+	}
+}
+
+// updateTaskRunStatusWithResourceResult if there is an update to the outout image resource, add to taskrun status result.
+// The results the Task declares are added to the TaskRunResults instead.
 func updateTaskRunStatusWithResourceResult(taskRun *v1alpha1.TaskRun, logContent []byte) error {
-	results := []v1alpha1.PipelineResourceResult{}
-	if err := json.Unmarshal(logContent, &results); err != nil {
-		return fmt.Errorf("failed to unmarshal output image exporter JSON output: %w", err)
+	results, err := termination.Parse(string(logContent))
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		if r.ResultType == v1alpha1.TaskRunResultType {
+			taskRun.Status.TaskRunResults = append(taskRun.Status.TaskRunResults, v1alpha1.TaskRunResult{
+				Name:  r.Key,
+				Value: r.Value,
+			})
+			continue
+		}
+		taskRun.Status.ResourcesResult = append(taskRun.Status.ResourcesResult, r)
 	}
-	taskRun.Status.ResourcesResult = append(taskRun.Status.ResourcesResult, results...)
 	return nil
 }
 
@@ -429,9 +596,10 @@ func (c *Reconciler) updateLabelsAndAnnotations(tr *v1alpha1.TaskRun) (*v1alpha1
 	if err != nil {
 		return nil, fmt.Errorf("error getting TaskRun %s when updating labels/annotations: %w", tr.Name, err)
 	}
-	if !reflect.DeepEqual(tr.ObjectMeta.Labels, newTr.ObjectMeta.Labels) || !reflect.DeepEqual(tr.ObjectMeta.Annotations, newTr.ObjectMeta.Annotations) {
+	if !reflect.DeepEqual(tr.ObjectMeta.Labels, newTr.ObjectMeta.Labels) || !reflect.DeepEqual(tr.ObjectMeta.Annotations, newTr.ObjectMeta.Annotations) || !reflect.DeepEqual(tr.ObjectMeta.Finalizers, newTr.ObjectMeta.Finalizers) {
 		newTr.ObjectMeta.Labels = tr.ObjectMeta.Labels
 		newTr.ObjectMeta.Annotations = tr.ObjectMeta.Annotations
+		newTr.ObjectMeta.Finalizers = tr.ObjectMeta.Finalizers
 		return c.PipelineClientSet.TektonV1alpha1().TaskRuns(tr.Namespace).Update(newTr)
 	}
 	return newTr, nil
@@ -439,7 +607,7 @@ func (c *Reconciler) updateLabelsAndAnnotations(tr *v1alpha1.TaskRun) (*v1alpha1
 
 // createPod creates a Pod based on the Task's configuration, with pvcName as a volumeMount
 // TODO(dibyom): Refactor resource setup/substitution logic to its own function in the resources package
-func (c *Reconciler) createPod(tr *v1alpha1.TaskRun, rtr *resources.ResolvedTaskResources) (*corev1.Pod, error) {
+func (c *Reconciler) createPod(ctx context.Context, tr *v1alpha1.TaskRun, rtr *resources.ResolvedTaskResources) (*corev1.Pod, error) {
 	ts := rtr.TaskSpec.DeepCopy()
 	inputResources, err := resourceImplBinding(rtr.Inputs, c.Images)
 	if err != nil {
@@ -460,13 +628,13 @@ func (c *Reconciler) createPod(tr *v1alpha1.TaskRun, rtr *resources.ResolvedTask
 		return nil, err
 	}
 
-	ts, err = resources.AddInputResource(c.KubeClientSet, c.Images, rtr.TaskName, ts, tr, inputResources, c.Logger)
+	ts, err = resources.AddInputResource(ctx, c.KubeClientSet, c.Images, rtr.TaskName, ts, tr, inputResources, c.Logger)
 	if err != nil {
 		c.Logger.Errorf("Failed to create a build for taskrun: %s due to input resource error %v", tr.Name, err)
 		return nil, err
 	}
 
-	ts, err = resources.AddOutputResources(c.KubeClientSet, c.Images, rtr.TaskName, ts, tr, outputResources, c.Logger)
+	ts, err = resources.AddOutputResources(ctx, c.KubeClientSet, c.Images, rtr.TaskName, ts, tr, outputResources, c.Logger)
 	if err != nil {
 		c.Logger.Errorf("Failed to create a build for taskrun: %s due to output resource error %v", tr.Name, err)
 		return nil, err
@@ -483,10 +651,26 @@ func (c *Reconciler) createPod(tr *v1alpha1.TaskRun, rtr *resources.ResolvedTask
 	ts = resources.ApplyResources(ts, inputResources, "inputs")
 	ts = resources.ApplyResources(ts, outputResources, "outputs")
 
-	pod, err := podconvert.MakePod(c.Images, tr, *ts, c.KubeClientSet, c.entrypointCache)
+	// Apply the substitution of the paths of the results and workspaces.
+	ts = resources.ApplyResults(ts)
+	ts = resources.ApplyWorkspaces(ts)
+
+	// The steps the resources added run the controller's images: only the
+	// Task's own are checked against the task policy.
+	own := resources.ApplyParameters(rtr.TaskSpec.DeepCopy(), tr, defaults...)
+	own = resources.ApplyResources(own, inputResources, "inputs")
+	own = resources.ApplyResources(own, outputResources, "outputs")
+	if err := checkTaskPolicy(ctx, tr.Namespace, own); err != nil {
+		return nil, err
+	}
+
+	pod, err := podconvert.MakePod(ctx, c.Images, tr, *ts, c.KubeClientSet, c.entrypointCache)
 	if err != nil {
 		return nil, fmt.Errorf("translating Build to Pod: %w", err)
 	}
+	if pod, err = c.reviewPod(ctx, tr, pod); err != nil {
+		return nil, err
+	}
 
 	return c.KubeClientSet.CoreV1().Pods(tr.Namespace).Create(pod)
 }
@@ -29,13 +29,16 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources/cloudevent"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/system"
-	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/pkg/termination"
 	tb "github.com/tektoncd/pipeline/test/builder"
 	"github.com/tektoncd/pipeline/test/names"
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -43,15 +46,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sruntimeschema "k8s.io/apimachinery/pkg/runtime/schema"
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
 	ktesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
 )
 
 const (
 	entrypointLocation  = "/tekton/tools/entrypoint"
 	taskNameLabelKey    = pipeline.GroupName + pipeline.TaskLabelKey
+	clusterTaskLabelKey = pipeline.GroupName + pipeline.ClusterTaskLabelKey
 	taskRunNameLabelKey = pipeline.GroupName + pipeline.TaskRunLabelKey
 	workspaceDir        = "/workspace"
 	currentAPIVersion   = "tekton.dev/v1alpha1"
@@ -70,7 +79,7 @@ var (
 		PRImage:                  "override-with-pr:latest",
 		ImageDigestExporterImage: "override-with-imagedigest-exporter-image:latest",
 	}
-	ignoreLastTransitionTime = cmpopts.IgnoreTypes(apis.Condition{}.LastTransitionTime.Inner.Time)
+	ignoreLastTransitionTime = reconcilertest.IgnoreLastTransitionTime
 	// Pods are created with a random 5-character suffix that we want to
 	// ignore in our diffs.
 	ignoreRandomPodNameSuffix = cmp.FilterPath(func(path cmp.Path) bool {
@@ -78,6 +87,10 @@ var (
 	}, cmp.Comparer(func(name1, name2 string) bool {
 		return name1[:len(name1)-5] == name2[:len(name2)-5]
 	}))
+	// The deadline of the steps depends on when the TaskRun started.
+	ignoreDeadlineEnv = cmpopts.IgnoreSliceElements(func(e corev1.EnvVar) bool {
+		return e.Name == entrypoint.DeadlineEnvVar
+	})
 	resourceQuantityCmp = cmp.Comparer(func(x, y resource.Quantity) bool {
 		return x.Cmp(y) == 0
 	})
@@ -235,17 +248,25 @@ func getRunName(tr *v1alpha1.TaskRun) string {
 
 // getTaskRunController returns an instance of the TaskRun controller/reconciler that has been seeded with
 // d, where d represents the state of the system (existing resources) needed for the test.
-func getTaskRunController(t *testing.T, d test.Data) (test.Assets, func()) {
+func getTaskRunController(t *testing.T, d reconcilertest.Data) (reconcilertest.Assets, func()) {
 	ctx, _ := ttesting.SetupFakeContext(t)
 	ctx, cancel := context.WithCancel(ctx)
 	cloudEventClientBehaviour := cloudevent.FakeClientBehaviour{
 		SendSuccessfully: true,
 	}
 	ctx = cloudevent.WithClient(ctx, &cloudEventClientBehaviour)
-	c, _ := test.SeedTestData(t, ctx, d)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
 	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
-	return test.Assets{
-		Controller: NewController(images)(ctx, configMapWatcher),
+	ctl := NewController(images, PodPolicy{})(ctx, configMapWatcher)
+	// Only start watching when the test provides the configmaps, otherwise
+	// the reconciler uses the default config.
+	if len(d.ConfigMaps) > 0 {
+		if err := configMapWatcher.Start(ctx.Done()); err != nil {
+			t.Fatalf("error starting configmap watcher: %v", err)
+		}
+	}
+	return reconcilertest.Assets{
+		Controller: ctl,
 		Clients:    c,
 	}, cancel
 }
@@ -259,17 +280,21 @@ func TestReconcile_ExplicitDefaultSA(t *testing.T) {
 		tb.TaskRunServiceAccountName("test-sa"),
 	))
 	taskruns := []*v1alpha1.TaskRun{taskRunSuccess, taskRunWithSaSuccess}
-	d := test.Data{
+	defaultSAName := "pipelines"
+	d := reconcilertest.Data{
 		TaskRuns: taskruns,
 		Tasks:    []*v1alpha1.Task{simpleTask, saTask},
-	}
-
-	defaultSAName := "pipelines"
-	defaultCfg := &config.Config{
-		Defaults: &config.Defaults{
-			DefaultServiceAccount: defaultSAName,
-			DefaultTimeoutMinutes: 60,
-		},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+			Data: map[string]string{
+				"default-service-account": defaultSAName,
+				"default-timeout-minutes": "60",
+			},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: config.TaskPolicyConfigName, Namespace: system.GetNamespace()},
+		}},
 	}
 
 	for _, tc := range []struct {
@@ -364,8 +389,7 @@ func TestReconcile_ExplicitDefaultSA(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			ctx := config.ToContext(context.Background(), defaultCfg)
-			if err := c.Reconciler.Reconcile(ctx, getRunName(tc.taskRun)); err != nil {
+			if err := c.Reconciler.Reconcile(context.Background(), getRunName(tc.taskRun)); err != nil {
 				t.Errorf("expected no error. Got error %v", err)
 			}
 			if len(clients.Kube.Actions()) == 0 {
@@ -402,7 +426,7 @@ func TestReconcile_ExplicitDefaultSA(t *testing.T) {
 				t.Errorf("Pod metadata doesn't match (-want, +got): %s", d)
 			}
 
-			if d := cmp.Diff(tc.wantPod.Spec, pod.Spec, resourceQuantityCmp); d != "" {
+			if d := cmp.Diff(tc.wantPod.Spec, pod.Spec, resourceQuantityCmp, ignoreDeadlineEnv); d != "" {
 				t.Errorf("Pod spec doesn't match, (-want, +got): %s", d)
 			}
 			if len(clients.Kube.Actions()) == 0 {
@@ -518,7 +542,7 @@ func TestReconcile(t *testing.T) {
 		taskRunWithLabels, taskRunWithAnnotations, taskRunWithPod,
 	}
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns:          taskruns,
 		Tasks:             []*v1alpha1.Task{simpleTask, saTask, templatedTask, outputTask},
 		ClusterTasks:      []*v1alpha1.ClusterTask{clustertask},
@@ -720,6 +744,7 @@ func TestReconcile(t *testing.T) {
 		taskRun: taskRunWithClusterTask,
 		wantPod: tb.Pod("test-taskrun-with-cluster-task-pod-abcde", "foo",
 			tb.PodLabel(taskNameLabelKey, "test-cluster-task"),
+			tb.PodLabel(clusterTaskLabelKey, "test-cluster-task"),
 			tb.PodLabel(taskRunNameLabelKey, "test-taskrun-with-cluster-task"),
 			tb.PodLabel(podconvert.ManagedByLabelKey, podconvert.ManagedByLabelValue),
 			tb.PodOwnerReference("TaskRun", "test-taskrun-with-cluster-task",
@@ -885,7 +910,7 @@ func TestReconcile(t *testing.T) {
 			}
 
 			pod.Name = tc.wantPod.Name // Ignore pod name differences, the pod name is generated and tested in pod_test.go
-			if d := cmp.Diff(tc.wantPod.Spec, pod.Spec, resourceQuantityCmp); d != "" {
+			if d := cmp.Diff(tc.wantPod.Spec, pod.Spec, resourceQuantityCmp, ignoreDeadlineEnv); d != "" {
 				t.Errorf("Pod spec doesn't match (-want, +got): %s", d)
 			}
 			if len(clients.Kube.Actions()) == 0 {
@@ -899,7 +924,7 @@ func TestReconcile_SetsStartTime(t *testing.T) {
 	taskRun := tb.TaskRun("test-taskrun", "foo", tb.TaskRunSpec(
 		tb.TaskRunTaskRef(simpleTask.Name),
 	))
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns: []*v1alpha1.TaskRun{taskRun},
 		Tasks:    []*v1alpha1.Task{simpleTask},
 	}
@@ -915,6 +940,137 @@ func TestReconcile_SetsStartTime(t *testing.T) {
 	}
 }
 
+func TestReconcile_SetsEffectiveTimeout(t *testing.T) {
+	configMaps := func(defaultTimeoutMinutes string) []*corev1.ConfigMap {
+		return []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+			Data:       map[string]string{"default-timeout-minutes": defaultTimeoutMinutes},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: config.TaskPolicyConfigName, Namespace: system.GetNamespace()},
+		}}
+	}
+	for _, tc := range []struct {
+		name       string
+		taskRun    *v1alpha1.TaskRun
+		configMaps []*corev1.ConfigMap
+		want       time.Duration
+	}{{
+		name: "explicit timeout",
+		taskRun: tb.TaskRun("test-taskrun-timeout", "foo", tb.TaskRunSpec(
+			tb.TaskRunTaskRef(simpleTask.Name),
+			tb.TaskRunTimeout(10*time.Minute),
+		)),
+		want: 10 * time.Minute,
+	}, {
+		name: "no timeout",
+		taskRun: tb.TaskRun("test-taskrun-no-timeout", "foo", tb.TaskRunSpec(
+			tb.TaskRunTaskRef(simpleTask.Name),
+			tb.TaskRunTimeout(config.NoTimeoutDuration),
+		)),
+		want: config.NoTimeoutDuration,
+	}, {
+		name: "default timeout",
+		taskRun: tb.TaskRun("test-taskrun-nil-timeout", "foo", tb.TaskRunSpec(
+			tb.TaskRunTaskRef(simpleTask.Name),
+			tb.TaskRunNilTimeout,
+		)),
+		want: config.DefaultTimeoutMinutes * time.Minute,
+	}, {
+		name: "configured default timeout",
+		taskRun: tb.TaskRun("test-taskrun-nil-timeout", "foo", tb.TaskRunSpec(
+			tb.TaskRunTaskRef(simpleTask.Name),
+			tb.TaskRunNilTimeout,
+		)),
+		configMaps: configMaps("30"),
+		want:       30 * time.Minute,
+	}, {
+		name: "configured default of no timeout",
+		taskRun: tb.TaskRun("test-taskrun-nil-timeout", "foo", tb.TaskRunSpec(
+			tb.TaskRunTaskRef(simpleTask.Name),
+			tb.TaskRunNilTimeout,
+		)),
+		configMaps: configMaps("0"),
+		want:       config.NoTimeoutDuration,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := reconcilertest.Data{
+				TaskRuns:   []*v1alpha1.TaskRun{tc.taskRun},
+				Tasks:      []*v1alpha1.Task{simpleTask},
+				ConfigMaps: tc.configMaps,
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+
+			if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), getRunName(tc.taskRun)); err != nil {
+				t.Fatalf("expected no error reconciling valid TaskRun but got %v", err)
+			}
+			newTr, err := testAssets.Clients.Pipeline.TektonV1alpha1().TaskRuns(tc.taskRun.Namespace).Get(tc.taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("expected TaskRun %s to exist but got error when getting it: %v", tc.taskRun.Name, err)
+			}
+			if d := cmp.Diff(&metav1.Duration{Duration: tc.want}, newTr.Status.EffectiveTimeout); d != "" {
+				t.Errorf("unexpected effective timeout (-want, +got): %s", d)
+			}
+		})
+	}
+}
+
+// TestReconcile_EmbeddedTaskSpecSinglePass verifies that a TaskRun with an
+// embedded spec gets its Pod in a single reconcile, and that the only write
+// back to the TaskRun is its status, so it isn't needlessly requeued.
+func TestReconcile_EmbeddedTaskSpecSinglePass(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-embedded", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskSpec(tb.Step("simple-step", "foo", tb.StepCommand("/mycmd"))),
+	))
+	d := reconcilertest.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRun},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+	if _, err := clients.Kube.CoreV1().ServiceAccounts(taskRun.Namespace).Create(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: taskRun.Namespace},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	clients.Pipeline.ClearActions()
+	clients.Kube.ClearActions()
+
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("expected no error reconciling valid TaskRun but got %v", err)
+	}
+
+	var podCreates int
+	for _, a := range clients.Kube.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "pods" {
+			podCreates++
+		}
+	}
+	if podCreates != 1 {
+		t.Errorf("expected 1 pod to be created, got %d: %+v", podCreates, clients.Kube.Actions())
+	}
+
+	var updates []string
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "update" {
+			updates = append(updates, a.GetSubresource())
+		}
+	}
+	if d := cmp.Diff([]string{"status"}, updates); d != "" {
+		t.Errorf("unexpected TaskRun updates (-want, +got): %s", d)
+	}
+
+	newTr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected TaskRun %s to exist but got error when getting it: %v", taskRun.Name, err)
+	}
+	if newTr.Status.PodName == "" {
+		t.Error("expected the TaskRun status to record the created pod")
+	}
+}
+
 func TestReconcile_SortTaskRunStatusSteps(t *testing.T) {
 	taskRun := tb.TaskRun("test-taskrun", "foo", tb.TaskRunSpec(
 		tb.TaskRunTaskRef(taskMultipleSteps.Name)),
@@ -926,7 +1082,7 @@ func TestReconcile_SortTaskRunStatusSteps(t *testing.T) {
 	// The order of the container statuses has been shuffled, not aligning with the order of the
 	// spec steps of the Task any more. After Reconcile is called, we should see the order of status
 	// steps in TaksRun has been converted to the same one as in spec steps of the Task.
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns: []*v1alpha1.TaskRun{taskRun},
 		Tasks:    []*v1alpha1.Task{taskMultipleSteps},
 		Pods: []*corev1.Pod{{
@@ -1001,7 +1157,7 @@ func TestReconcile_DoesntChangeStartTime(t *testing.T) {
 			tb.PodName("the-pod"),
 		),
 	)
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns: []*v1alpha1.TaskRun{taskRun},
 		Tasks:    []*v1alpha1.Task{simpleTask},
 		Pods: []*corev1.Pod{{
@@ -1031,7 +1187,7 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 	taskRuns := []*v1alpha1.TaskRun{noTaskRun, withWrongRef}
 	tasks := []*v1alpha1.Task{simpleTask}
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns: taskRuns,
 		Tasks:    tasks,
 	}
@@ -1086,7 +1242,7 @@ func TestReconcilePodFetchError(t *testing.T) {
 		tb.TaskRunSpec(tb.TaskRunTaskRef("test-task")),
 		tb.TaskRunStatus(tb.PodName("will-not-be-found")),
 	)
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns: []*v1alpha1.TaskRun{taskRun},
 		Tasks:    []*v1alpha1.Task{simpleTask},
 	}
@@ -1107,7 +1263,7 @@ func TestReconcilePodFetchError(t *testing.T) {
 
 func makePod(taskRun *v1alpha1.TaskRun, task *v1alpha1.Task) (*corev1.Pod, error) {
 	// TODO(jasonhall): This avoids a circular dependency where
-	// getTaskRunController takes a test.Data which must be populated with
+	// getTaskRunController takes a reconcilertest.Data which must be populated with
 	// a pod created from MakePod which requires a (fake) Kube client. When
 	// we remove Build entirely from this controller, we should simply
 	// specify the Pod we want to exist directly, and not call MakePod from
@@ -1125,7 +1281,7 @@ func makePod(taskRun *v1alpha1.TaskRun, task *v1alpha1.Task) (*corev1.Pod, error
 		return nil, err
 	}
 
-	return podconvert.MakePod(images, taskRun, task.Spec, kubeclient, entrypointCache)
+	return podconvert.MakePod(context.Background(), images, taskRun, task.Spec, kubeclient, entrypointCache)
 }
 
 func TestReconcilePodUpdateStatus(t *testing.T) {
@@ -1140,7 +1296,7 @@ func TestReconcilePodUpdateStatus(t *testing.T) {
 			PodName: pod.Name,
 		},
 	}
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns: []*v1alpha1.TaskRun{taskRun},
 		Tasks:    []*v1alpha1.Task{simpleTask},
 		Pods:     []*corev1.Pod{pod},
@@ -1202,7 +1358,7 @@ func TestReconcileOnCompletedTaskRun(t *testing.T) {
 	taskRun := tb.TaskRun("test-taskrun-run-success", "foo", tb.TaskRunSpec(
 		tb.TaskRunTaskRef(simpleTask.Name),
 	), tb.TaskRunStatus(tb.StatusCondition(*taskSt)))
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns: []*v1alpha1.TaskRun{
 			taskRun,
 		},
@@ -1234,7 +1390,7 @@ func TestReconcileOnCancelledTaskRun(t *testing.T) {
 		Type:   apis.ConditionSucceeded,
 		Status: corev1.ConditionUnknown,
 	})))
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns: []*v1alpha1.TaskRun{taskRun},
 		Tasks:    []*v1alpha1.Task{simpleTask},
 	}
@@ -1323,7 +1479,7 @@ func TestReconcileTimeouts(t *testing.T) {
 		}}
 
 	for _, tc := range testcases {
-		d := test.Data{
+		d := reconcilertest.Data{
 			TaskRuns: []*v1alpha1.TaskRun{tc.taskRun},
 			Tasks:    []*v1alpha1.Task{simpleTask},
 		}
@@ -1356,7 +1512,7 @@ func TestHandlePodCreationError(t *testing.T) {
 			Status: corev1.ConditionUnknown,
 		}),
 	))
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns: []*v1alpha1.TaskRun{taskRun},
 		Tasks:    []*v1alpha1.Task{simpleTask},
 	}
@@ -1407,6 +1563,50 @@ func TestHandlePodCreationError(t *testing.T) {
 	}
 }
 
+func TestReconcile_ExceededVolumeLimit(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-too-many-volumes", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskRef(simpleTask.Name),
+	))
+	d := reconcilertest.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRun},
+		Tasks:    []*v1alpha1.Task{simpleTask},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+			Data:       map[string]string{"maximum-pod-volumes": "1"},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: config.TaskPolicyConfigName, Namespace: system.GetNamespace()},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c, clients := testAssets.Controller, testAssets.Clients
+	if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when reconciling TaskRun: %v", err)
+	}
+
+	reconciled, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting TaskRun: %v", err)
+	}
+	condition := reconciled.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != podconvert.ReasonExceededVolumeLimit {
+		t.Errorf("Expected TaskRun to fail with reason %s, but condition is %v", podconvert.ReasonExceededVolumeLimit, condition)
+	}
+	if pods, err := clients.Kube.CoreV1().Pods("foo").List(metav1.ListOptions{}); err != nil {
+		t.Fatal(err)
+	} else if len(pods.Items) != 0 {
+		t.Errorf("Expected no pod to be created, got %d", len(pods.Items))
+	}
+}
+
 func TestReconcileCloudEvents(t *testing.T) {
 
 	taskRunWithNoCEResources := tb.TaskRun("test-taskrun-no-ce-resources", "foo",
@@ -1495,7 +1695,7 @@ func TestReconcileCloudEvents(t *testing.T) {
 		taskRunWithCESuccededOneAttempt,
 	}
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns:          taskruns,
 		Tasks:             []*v1alpha1.Task{simpleTask, twoOutputsTask},
 		ClusterTasks:      []*v1alpha1.ClusterTask{},
@@ -1588,10 +1788,11 @@ func TestReconcileCloudEvents(t *testing.T) {
 
 func TestUpdateTaskRunStatus_withValidJson(t *testing.T) {
 	for _, c := range []struct {
-		desc    string
-		podLog  []byte
-		taskRun *v1alpha1.TaskRun
-		want    []v1alpha1.PipelineResourceResult
+		desc            string
+		podLog          []byte
+		taskRun         *v1alpha1.TaskRun
+		want            []v1alpha1.PipelineResourceResult
+		wantTaskResults []v1alpha1.TaskRunResult
 	}{{
 		desc:   "image resource updated",
 		podLog: []byte("[{\"name\":\"source-image\",\"digest\":\"sha256:1234\"}]"),
@@ -1627,6 +1828,40 @@ func TestUpdateTaskRunStatus_withValidJson(t *testing.T) {
 			Name:   "source-image",
 			Digest: "sha256:1234",
 		}},
+	}, {
+		desc:   "versioned termination message",
+		podLog: []byte(`{"version":1,"results":[{"type":"PipelineResourceResult","key":"digest","value":"sha256:1234","resourceRef":{"name":"source-image"}}]}`),
+		taskRun: &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-taskrun-run-output-steps",
+				Namespace: "marshmallow",
+			},
+		},
+		want: []v1alpha1.PipelineResourceResult{{
+			ResultType:  v1alpha1.PipelineResourceResultType,
+			Key:         "digest",
+			Value:       "sha256:1234",
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: "source-image"},
+		}},
+	}, {
+		desc:   "task results",
+		podLog: []byte(`{"version":1,"results":[{"type":"PipelineResourceResult","key":"digest","value":"sha256:1234","resourceRef":{"name":"source-image"}},{"type":"TaskRunResult","key":"commit","value":"abc123"}]}`),
+		taskRun: &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-taskrun-run-output-steps",
+				Namespace: "marshmallow",
+			},
+		},
+		want: []v1alpha1.PipelineResourceResult{{
+			ResultType:  v1alpha1.PipelineResourceResultType,
+			Key:         "digest",
+			Value:       "sha256:1234",
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: "source-image"},
+		}},
+		wantTaskResults: []v1alpha1.TaskRunResult{{
+			Name:  "commit",
+			Value: "abc123",
+		}},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			names.TestingSeed()
@@ -1640,6 +1875,9 @@ func TestUpdateTaskRunStatus_withValidJson(t *testing.T) {
 			if d := cmp.Diff(c.want, c.taskRun.Status.ResourcesResult); d != "" {
 				t.Errorf("post build steps mismatch (-want, +got): %s", d)
 			}
+			if d := cmp.Diff(c.wantTaskResults, c.taskRun.Status.TaskRunResults); d != "" {
+				t.Errorf("task results mismatch (-want, +got): %s", d)
+			}
 		})
 	}
 }
@@ -1708,3 +1946,389 @@ func TestUpdateTaskRunStatus_withInvalidJson(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateTaskRunResourceResult_Overflow(t *testing.T) {
+	full := `{"version":1,"results":[{"type":"PipelineResourceResult","key":"commit","value":"abcd"}]}`
+	for _, c := range []struct {
+		desc string
+		logs string
+		err  error
+		want []v1alpha1.PipelineResourceResult
+	}{{
+		desc: "results read from the log",
+		logs: "cloning\n" + termination.LogPrefix + full + "\n",
+		want: []v1alpha1.PipelineResourceResult{{
+			ResultType: v1alpha1.PipelineResourceResultType,
+			Key:        "commit",
+			Value:      "abcd",
+		}},
+	}, {
+		desc: "results missing from the log",
+		logs: "cloning\n",
+	}, {
+		desc: "logs unavailable",
+		err:  errors.New("logs are gone"),
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			tr := tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			})))
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-pod"},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "step-git-source",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+							Message: `{"version":1,"results":[],"overflow":true}`,
+						}},
+					}},
+				},
+			}
+			getLogs := func(podName, containerName string) (string, error) {
+				if podName != pod.Name || containerName != "step-git-source" {
+					t.Errorf("unexpected logs requested for %s/%s", podName, containerName)
+				}
+				return c.logs, c.err
+			}
+			updateTaskRunResourceResult(tr, pod, getLogs, zap.NewNop().Sugar())
+			if d := cmp.Diff(c.want, tr.Status.ResourcesResult); d != "" {
+				t.Errorf("resources result mismatch (-want, +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestUpdateMissingResults(t *testing.T) {
+	declared := []v1alpha1.TaskResult{{Name: "commit"}, {Name: "digest"}, {Name: "report"}}
+	for _, c := range []struct {
+		desc   string
+		status corev1.ConditionStatus
+		want   []string
+	}{{
+		desc:   "succeeded",
+		status: corev1.ConditionTrue,
+		want:   []string{"digest", "report"},
+	}, {
+		desc:   "failed",
+		status: corev1.ConditionFalse,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			tr := tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: c.status,
+			})))
+			tr.Status.TaskRunResults = []v1alpha1.TaskRunResult{{Name: "commit", Value: "abc123"}}
+			updateMissingResults(tr, declared)
+			if d := cmp.Diff(c.want, tr.Status.MissingResults); d != "" {
+				t.Errorf("missing results mismatch (-want, +got): %s", d)
+			}
+		})
+	}
+}
+
+// BenchmarkReconcile_EmbeddedTaskSpec measures the reconciler overhead of
+// taking a new TaskRun with an embedded spec to a created Pod. It doesn't
+// enforce a time budget; TestReconcile_EmbeddedTaskSpecSinglePass checks the
+// work done by the reconcile instead.
+func BenchmarkReconcile_EmbeddedTaskSpec(b *testing.B) {
+	taskRuns := make([]*v1alpha1.TaskRun, b.N)
+	for i := range taskRuns {
+		taskRuns[i] = tb.TaskRun(fmt.Sprintf("bench-taskrun-%d", i), "foo", tb.TaskRunSpec(
+			tb.TaskRunTaskSpec(tb.Step("simple-step", "foo", tb.StepCommand("/mycmd"))),
+		))
+	}
+
+	ctx := logging.WithLogger(context.Background(), zap.NewNop().Sugar())
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx = controller.WithEventRecorder(ctx, record.NewFakeRecorder(b.N*10))
+	ctx, _ = injection.Fake.SetupInformers(ctx, &rest.Config{})
+	ctx = cloudevent.WithClient(ctx, &cloudevent.FakeClientBehaviour{SendSuccessfully: true})
+	c, _ := reconcilertest.SeedTestData(b, ctx, reconcilertest.Data{TaskRuns: taskRuns})
+	if _, err := c.Kube.CoreV1().ServiceAccounts("foo").Create(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+	}); err != nil {
+		b.Fatal(err)
+	}
+	configMapWatcher := configmap.NewInformedWatcher(c.Kube, system.GetNamespace())
+	r := NewController(images, PodPolicy{})(ctx, configMapWatcher).Reconciler
+
+	b.ResetTimer()
+	for _, tr := range taskRuns {
+		if err := r.Reconcile(ctx, getRunName(tr)); err != nil {
+			b.Fatalf("unexpected error reconciling %s: %v", tr.Name, err)
+		}
+	}
+}
+
+func TestReconcile_RecordStepCommands(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		flag string
+		want []v1alpha1.StepCommand
+	}{{
+		name: "disabled",
+		flag: "false",
+	}, {
+		name: "enabled",
+		flag: "true",
+		want: []v1alpha1.StepCommand{{
+			Name:    "simple-step",
+			Image:   "foo",
+			Command: []string{"/mycmd"},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := reconcilertest.Data{
+				TaskRuns: []*v1alpha1.TaskRun{tb.TaskRun("test-taskrun", "foo", tb.TaskRunSpec(tb.TaskRunTaskRef(simpleTask.Name)))},
+				Tasks:    []*v1alpha1.Task{simpleTask},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+					Data:       map[string]string{"record-step-commands": tc.flag},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.TaskPolicyConfigName, Namespace: system.GetNamespace()},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+			if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-taskrun"); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+
+			tr, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get("test-taskrun", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting TaskRun: %v", err)
+			}
+			// The environment is the one set up by MakePod.
+			if d := cmp.Diff(tc.want, tr.Status.StepCommands, cmpopts.IgnoreFields(v1alpha1.StepCommand{}, "Env", "WorkingDir")); d != "" {
+				t.Errorf("StepCommands diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcile_Platforms(t *testing.T) {
+	platformTask := tb.Task("test-platform-task", "foo", tb.TaskSpec(
+		tb.Step("simple-step", "foo", tb.StepCommand("/mycmd")),
+		tb.TaskPlatforms("linux/amd64", "linux/arm64"),
+	))
+	for _, tc := range []struct {
+		name       string
+		nodeLabels map[string]string
+		wantPod    bool
+	}{{
+		name:       "node of one of the platforms",
+		nodeLabels: map[string]string{"kubernetes.io/os": "linux", "kubernetes.io/arch": "arm64"},
+		wantPod:    true,
+	}, {
+		name:       "no node of the platforms",
+		nodeLabels: map[string]string{"kubernetes.io/os": "windows", "kubernetes.io/arch": "amd64"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := tb.TaskRun("test-taskrun-platforms", "foo", tb.TaskRunSpec(
+				tb.TaskRunTaskRef(platformTask.Name),
+			))
+			d := reconcilertest.Data{
+				TaskRuns: []*v1alpha1.TaskRun{taskRun},
+				Tasks:    []*v1alpha1.Task{platformTask},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c, clients := testAssets.Controller, testAssets.Clients
+			if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := clients.Kube.CoreV1().Nodes().Create(&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node", Labels: tc.nodeLabels},
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+				t.Fatalf("Unexpected error when reconciling TaskRun: %v", err)
+			}
+
+			reconciled, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get(taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting TaskRun: %v", err)
+			}
+			pods, err := clients.Kube.CoreV1().Pods("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tc.wantPod {
+				condition := reconciled.Status.GetCondition(apis.ConditionSucceeded)
+				if !condition.IsFalse() || condition.Reason != podconvert.ReasonNoMatchingNodes {
+					t.Errorf("Expected TaskRun to fail with reason %s, but condition is %v", podconvert.ReasonNoMatchingNodes, condition)
+				}
+				if len(pods.Items) != 0 {
+					t.Errorf("Expected no pod to be created, got %d", len(pods.Items))
+				}
+				return
+			}
+			if len(pods.Items) != 1 {
+				t.Fatalf("Expected a pod to be created, got %d", len(pods.Items))
+			}
+			if pods.Items[0].Spec.Affinity == nil || pods.Items[0].Spec.Affinity.NodeAffinity == nil {
+				t.Errorf("Expected the pod to have a node affinity, got %v", pods.Items[0].Spec.Affinity)
+			}
+		})
+	}
+}
+
+func TestReconcile_RemoteTask(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-remote", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("", tb.TaskRefResolver("git",
+			v1alpha1.Param{Name: "url", Value: *tb.ArrayOrString("https://github.com/tektoncd/catalog")},
+			v1alpha1.Param{Name: "path", Value: *tb.ArrayOrString("task/echo.yaml")},
+		)),
+	))
+	reconcile := func(d reconcilertest.Data) reconcilertest.Clients {
+		t.Helper()
+		testAssets, cancel := getTaskRunController(t, d)
+		defer cancel()
+		clients := testAssets.Clients
+		if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+			t.Fatalf("Unexpected error when reconciling TaskRun: %v", err)
+		}
+		return clients
+	}
+
+	// The first reconcile requests the Task.
+	clients := reconcile(reconcilertest.Data{TaskRuns: []*v1alpha1.TaskRun{taskRun}})
+	reconciled, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting TaskRun: %v", err)
+	}
+	condition := reconciled.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() || condition.Reason != podconvert.ReasonResolvingTaskRef {
+		t.Errorf("Expected TaskRun to be resolving its Task, but condition is %v", condition)
+	}
+	rrs, err := clients.Pipeline.TektonV1alpha1().ResolutionRequests("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rrs.Items) != 1 {
+		t.Fatalf("Expected a ResolutionRequest to be created, got %d", len(rrs.Items))
+	}
+	if owner := metav1.GetControllerOf(&rrs.Items[0]); owner == nil || owner.Kind != "TaskRun" || owner.Name != taskRun.Name {
+		t.Errorf("Expected the ResolutionRequest to be owned by the TaskRun, got %v", owner)
+	}
+	pods, err := clients.Kube.CoreV1().Pods("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods.Items) != 0 {
+		t.Errorf("Expected no pod to be created while resolving, got %d", len(pods.Items))
+	}
+
+	// Once resolved, the TaskRun runs the Task.
+	rr := rrs.Items[0].DeepCopy()
+	rr.Status.Data = "apiVersion: tekton.dev/v1alpha1\nkind: Task\nmetadata:\n  name: echo\nspec:\n  steps:\n  - name: echo\n    image: foo\n    command: [/mycmd]\n"
+	rr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	clients = reconcile(reconcilertest.Data{
+		TaskRuns:           []*v1alpha1.TaskRun{taskRun},
+		ResolutionRequests: []*v1alpha1.ResolutionRequest{rr},
+	})
+	reconciled, err = clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting TaskRun: %v", err)
+	}
+	if reconciled.Labels[taskNameLabelKey] != "echo" {
+		t.Errorf("Expected the TaskRun to be labelled with the resolved Task, got %v", reconciled.Labels)
+	}
+	pods, err = clients.Kube.CoreV1().Pods("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods.Items) != 1 {
+		t.Fatalf("Expected a pod to be created, got %d", len(pods.Items))
+	}
+	if name := pods.Items[0].Spec.Containers[0].Name; name != "step-echo" {
+		t.Errorf("Expected the pod to run the echo step, got %s", name)
+	}
+}
+
+func TestReconcile_RemoteTaskPolicy(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-remote", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("", tb.TaskRefResolver("git",
+			v1alpha1.Param{Name: "url", Value: *tb.ArrayOrString("https://github.com/tektoncd/catalog")},
+			v1alpha1.Param{Name: "path", Value: *tb.ArrayOrString("task/privileged.yaml")},
+		)),
+	))
+	configMaps := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.DefaultsConfigName, Namespace: system.GetNamespace()},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: config.FeatureFlagsConfigName, Namespace: system.GetNamespace()},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: config.TaskPolicyConfigName, Namespace: system.GetNamespace()},
+		Data:       map[string]string{"disallow-privileged": "true"},
+	}}
+	reconcile := func(d reconcilertest.Data) reconcilertest.Clients {
+		t.Helper()
+		d.ConfigMaps = configMaps
+		testAssets, cancel := getTaskRunController(t, d)
+		defer cancel()
+		clients := testAssets.Clients
+		if _, err := clients.Kube.CoreV1().ServiceAccounts("foo").Create(&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "foo"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+			t.Fatalf("Unexpected error when reconciling TaskRun: %v", err)
+		}
+		return clients
+	}
+
+	clients := reconcile(reconcilertest.Data{TaskRuns: []*v1alpha1.TaskRun{taskRun}})
+	rrs, err := clients.Pipeline.TektonV1alpha1().ResolutionRequests("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rrs.Items) != 1 {
+		t.Fatalf("Expected a ResolutionRequest to be created, got %d", len(rrs.Items))
+	}
+
+	// The resolved Task runs a privileged step, which the task policy
+	// disallows even though it was never admitted by the webhook.
+	rr := rrs.Items[0].DeepCopy()
+	rr.Status.Data = "apiVersion: tekton.dev/v1alpha1\nkind: Task\nmetadata:\n  name: privileged\nspec:\n  steps:\n  - name: root\n    image: foo\n    command: [/mycmd]\n    securityContext:\n      privileged: true\n"
+	rr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	clients = reconcile(reconcilertest.Data{
+		TaskRuns:           []*v1alpha1.TaskRun{taskRun},
+		ResolutionRequests: []*v1alpha1.ResolutionRequest{rr},
+	})
+	reconciled, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting TaskRun: %v", err)
+	}
+	condition := reconciled.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != podconvert.ReasonFailedResolution || !strings.Contains(condition.Message, "violates the task policy") {
+		t.Errorf("Expected TaskRun to fail for violating the task policy, but condition is %v", condition)
+	}
+	pods, err := clients.Kube.CoreV1().Pods("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods.Items) != 0 {
+		t.Errorf("Expected no pod to be created for a privileged Task, got %d", len(pods.Items))
+	}
+}
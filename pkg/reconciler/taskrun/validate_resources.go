@@ -66,12 +66,12 @@ func validateResources(requiredResources []v1alpha1.TaskResource, providedResour
 
 func validateParams(inputs *v1alpha1.Inputs, params []v1alpha1.Param) error {
 	var neededParams []string
-	paramTypes := make(map[string]v1alpha1.ParamType)
+	paramSpecs := make(map[string]v1alpha1.ParamSpec)
 	if inputs != nil {
 		neededParams = make([]string, 0, len(inputs.Params))
 		for _, inputResourceParam := range inputs.Params {
 			neededParams = append(neededParams, inputResourceParam.Name)
-			paramTypes[inputResourceParam.Name] = inputResourceParam.Type
+			paramSpecs[inputResourceParam.Name] = inputResourceParam
 		}
 	}
 	providedParams := make([]string, 0, len(params))
@@ -96,10 +96,10 @@ func validateParams(inputs *v1alpha1.Inputs, params []v1alpha1.Param) error {
 	}
 
 	// Now that we have checked against missing/extra params, make sure each param's actual type matches
-	// the user-specified type.
+	// the user-specified type, and that objects have the declared keys.
 	var wrongTypeParamNames []string
 	for _, param := range params {
-		if param.Value.Type != paramTypes[param.Name] {
+		if spec := paramSpecs[param.Name]; !spec.Matches(param.Value) {
 			wrongTypeParamNames = append(wrongTypeParamNames, param.Name)
 		}
 	}
@@ -107,6 +107,17 @@ func validateParams(inputs *v1alpha1.Inputs, params []v1alpha1.Param) error {
 		return fmt.Errorf("param types don't match the user-specified type: %s", wrongTypeParamNames)
 	}
 
+	// Then make sure the values of params with an enum are among its values.
+	var notAllowedParamNames []string
+	for _, param := range params {
+		if spec := paramSpecs[param.Name]; !spec.Allows(param.Value) {
+			notAllowedParamNames = append(notAllowedParamNames, param.Name)
+		}
+	}
+	if len(notAllowedParamNames) != 0 {
+		return fmt.Errorf("param values aren't one of their enum values: %s", notAllowedParamNames)
+	}
+
 	return nil
 }
 
@@ -124,3 +135,20 @@ func ValidateResolvedTaskResources(params []v1alpha1.Param, rtr *resources.Resol
 
 	return nil
 }
+
+// ValidateWorkspaceBindings validates that the TaskRun binds each of the
+// workspaces the Task declares, and only those.
+func ValidateWorkspaceBindings(workspaces []v1alpha1.WorkspaceDeclaration, bindings []v1alpha1.WorkspaceBinding) error {
+	required := make([]string, 0, len(workspaces))
+	for _, w := range workspaces {
+		required = append(required, w.Name)
+	}
+	provided := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		provided = append(provided, b.Name)
+	}
+	if err := list.IsSame(required, provided); err != nil {
+		return fmt.Errorf("taskRun's bound workspaces didn't match the workspaces of the Task: %w", err)
+	}
+	return nil
+}
@@ -89,6 +89,26 @@ func TestValidateResolvedTaskResources_InvalidParams(t *testing.T) {
 			Name:  "extra",
 			Value: *tb.ArrayOrString("i am an extra param"),
 		}},
+	}, {
+		name: "object-missing-key",
+		rtr: tb.ResolvedTaskResources(tb.ResolvedTaskResourcesTaskSpec(
+			tb.Step("mystep", "myimage", tb.StepCommand("mycmd")),
+			tb.TaskInputs(tb.InputsParamSpec("repo", v1alpha1.ParamTypeObject, tb.ParamSpecProperties("url", "revision"))),
+		)),
+		params: []v1alpha1.Param{{
+			Name:  "repo",
+			Value: v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeObject, ObjectVal: map[string]string{"url": "https://github.com/tektoncd/pipeline"}},
+		}},
+	}, {
+		name: "value-not-in-enum",
+		rtr: tb.ResolvedTaskResources(tb.ResolvedTaskResourcesTaskSpec(
+			tb.Step("mystep", "myimage", tb.StepCommand("mycmd")),
+			tb.TaskInputs(tb.InputsParamSpec("arch", v1alpha1.ParamTypeString, tb.ParamSpecEnum("amd64", "arm64"))),
+		)),
+		params: []v1alpha1.Param{{
+			Name:  "arch",
+			Value: *tb.ArrayOrString("s390x"),
+		}},
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -175,3 +195,33 @@ func TestValidateResolvedTaskResources_InvalidResources(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateWorkspaceBindings(t *testing.T) {
+	workspaces := []v1alpha1.WorkspaceDeclaration{{Name: "source"}, {Name: "cache"}}
+	for _, tc := range []struct {
+		name     string
+		bindings []v1alpha1.WorkspaceBinding
+		wantErr  bool
+	}{{
+		name:     "all bound",
+		bindings: []v1alpha1.WorkspaceBinding{{Name: "cache"}, {Name: "source"}},
+	}, {
+		name:     "missing binding",
+		bindings: []v1alpha1.WorkspaceBinding{{Name: "source"}},
+		wantErr:  true,
+	}, {
+		name:     "extra binding",
+		bindings: []v1alpha1.WorkspaceBinding{{Name: "source"}, {Name: "cache"}, {Name: "output"}},
+		wantErr:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := taskrun.ValidateWorkspaceBindings(workspaces, tc.bindings)
+			if tc.wantErr && err == nil {
+				t.Error("Expected an error, got none")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
@@ -144,9 +144,12 @@ func (t *TimeoutSet) GetBackoff(tr *v1alpha1.TaskRun) (Backoff, bool) {
 	}
 	b.NumAttempts++
 	b.NextAttempt = time.Now().Add(backoffDuration(b.NumAttempts, rand.Intn))
-	timeoutDeadline := tr.Status.StartTime.Time.Add(tr.Spec.Timeout.Duration)
-	if timeoutDeadline.Before(b.NextAttempt) {
-		b.NextAttempt = timeoutDeadline
+	// A TaskRun without a timeout has no deadline to cap the backoff at.
+	if tr.Spec.Timeout.Duration != config.NoTimeoutDuration {
+		timeoutDeadline := tr.Status.StartTime.Time.Add(tr.Spec.Timeout.Duration)
+		if timeoutDeadline.Before(b.NextAttempt) {
+			b.NextAttempt = timeoutDeadline
+		}
 	}
 	t.backoffs[tr.GetRunKey()] = b
 	return b, false
@@ -251,6 +254,11 @@ func (t *TimeoutSet) waitRun(runObj StatusKey, timeout time.Duration, startTime
 	if callback == nil {
 		callback = defaultFunc
 	}
+	if timeout == config.NoTimeoutDuration {
+		// Arming the timer would fire it right away.
+		t.logger.Infof("%s has no timeout, not starting a timeout timer", runObj.GetRunKey())
+		return
+	}
 	runtime := time.Since(startTime.Time)
 	t.logger.Infof("About to start timeout timer for %s. started at %s, timeout is %s, running for %s", runObj.GetRunKey(), startTime.Time, timeout, runtime)
 	defer t.Release(runObj)
@@ -273,6 +281,18 @@ func (t *TimeoutSet) SetTaskRunTimer(tr *v1alpha1.TaskRun, d time.Duration) {
 	t.setTimer(tr, d, callback)
 }
 
+// SetPipelineRunTimer creates a blocking function for pipelinerun to wait for
+// 1. Stop signal, 2. PipelineRun to complete or 3. a given Duration to elapse,
+// enqueuing the PipelineRun in the latter case.
+func (t *TimeoutSet) SetPipelineRunTimer(pr *v1alpha1.PipelineRun, d time.Duration) {
+	callback := t.pipelineRunCallbackFunc
+	if callback == nil {
+		t.logger.Errorf("attempted to set a timer for %q but no pipeline run callback has been assigned", pr.Name)
+		return
+	}
+	t.setTimer(pr, d, callback)
+}
+
 func (t *TimeoutSet) setTimer(runObj StatusKey, timeout time.Duration, callback func(interface{})) {
 	finished := t.getOrCreateFinishedChan(runObj)
 	started := time.Now()
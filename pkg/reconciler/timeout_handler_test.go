@@ -24,8 +24,8 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
-	"github.com/tektoncd/pipeline/test"
 	tb "github.com/tektoncd/pipeline/test/builder"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
@@ -69,6 +69,15 @@ func TestTaskRunCheckTimeouts(t *testing.T) {
 		tb.TaskRunStartTime(time.Now()),
 	))
 
+	taskRunRunningNoTimeout := tb.TaskRun("test-taskrun-running-no-timeout", testNs, tb.TaskRunSpec(
+		tb.TaskRunTaskRef(simpleTask.Name, tb.TaskRefAPIVersion("a1")),
+		tb.TaskRunTimeout(config.NoTimeoutDuration),
+	), tb.TaskRunStatus(tb.StatusCondition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown}),
+		tb.TaskRunStartTime(time.Now().Add(-10*time.Second)),
+	))
+
 	taskRunDone := tb.TaskRun("test-taskrun-completed", testNs, tb.TaskRunSpec(
 		tb.TaskRunTaskRef(simpleTask.Name, tb.TaskRefAPIVersion("a1")),
 		tb.TaskRunTimeout(config.DefaultTimeoutMinutes*time.Minute),
@@ -86,8 +95,8 @@ func TestTaskRunCheckTimeouts(t *testing.T) {
 		Status: corev1.ConditionUnknown}),
 	))
 
-	d := test.Data{
-		TaskRuns: []*v1alpha1.TaskRun{taskRunTimedout, taskRunRunning, taskRunDone, taskRunCancelled, taskRunRunningNilTimeout},
+	d := reconcilertest.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRunTimedout, taskRunRunning, taskRunDone, taskRunCancelled, taskRunRunningNilTimeout, taskRunRunningNoTimeout},
 		Tasks:    []*v1alpha1.Task{simpleTask},
 		Namespaces: []*corev1.Namespace{{
 			ObjectMeta: metav1.ObjectMeta{
@@ -96,7 +105,7 @@ func TestTaskRunCheckTimeouts(t *testing.T) {
 		}},
 	}
 	ctx, _ := ttesting.SetupFakeContext(t)
-	c, _ := test.SeedTestData(t, ctx, d)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
 	stopCh := make(chan struct{})
 	defer close(stopCh)
 	observer, _ := observer.New(zap.InfoLevel)
@@ -127,6 +136,10 @@ func TestTaskRunCheckTimeouts(t *testing.T) {
 		name:           "running-with-nil-timeout",
 		taskRun:        taskRunRunningNilTimeout,
 		expectCallback: false,
+	}, {
+		name:           "running-with-no-timeout",
+		taskRun:        taskRunRunningNoTimeout,
+		expectCallback: false,
 	}, {
 		name:           "completed",
 		taskRun:        taskRunDone,
@@ -210,7 +223,7 @@ func TestPipelinRunCheckTimeouts(t *testing.T) {
 			Status: corev1.ConditionUnknown}),
 		),
 	)
-	d := test.Data{
+	d := reconcilertest.Data{
 		PipelineRuns: []*v1alpha1.PipelineRun{prTimeout, prRunning, prDone, prCancelled, prRunningNilTimeout},
 		Pipelines:    []*v1alpha1.Pipeline{simplePipeline},
 		Tasks:        []*v1alpha1.Task{ts},
@@ -222,7 +235,7 @@ func TestPipelinRunCheckTimeouts(t *testing.T) {
 	}
 
 	ctx, _ := ttesting.SetupFakeContext(t)
-	c, _ := test.SeedTestData(t, ctx, d)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
 	stopCh := make(chan struct{})
 	defer close(stopCh)
 	observer, _ := observer.New(zap.InfoLevel)
@@ -292,7 +305,7 @@ func TestWithNoFunc(t *testing.T) {
 		tb.TaskRunStartTime(time.Now().Add(-10*time.Second)),
 	))
 
-	d := test.Data{
+	d := reconcilertest.Data{
 		TaskRuns: []*v1alpha1.TaskRun{taskRunRunning},
 		Tasks:    []*v1alpha1.Task{simpleTask},
 		Namespaces: []*corev1.Namespace{{
@@ -302,7 +315,7 @@ func TestWithNoFunc(t *testing.T) {
 		}},
 	}
 	ctx, _ := ttesting.SetupFakeContext(t)
-	c, _ := test.SeedTestData(t, ctx, d)
+	c, _ := reconcilertest.SeedTestData(t, ctx, d)
 	stopCh := make(chan struct{})
 	observer, _ := observer.New(zap.InfoLevel)
 	testHandler := NewTimeoutHandler(stopCh, zap.New(observer).Sugar())
@@ -349,6 +362,24 @@ func TestSetTaskRunTimer(t *testing.T) {
 	}
 }
 
+// TestGetBackoff_NoTimeout checks that the backoff of a TaskRun without a
+// timeout isn't capped at its start time.
+func TestGetBackoff_NoTimeout(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-backoff-no-timeout", testNs, tb.TaskRunSpec(
+		tb.TaskRunTaskRef(simpleTask.Name),
+		tb.TaskRunTimeout(config.NoTimeoutDuration),
+	), tb.TaskRunStatus(tb.TaskRunStartTime(time.Now().Add(-10*time.Second))))
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	observer, _ := observer.New(zap.InfoLevel)
+	testHandler := NewTimeoutHandler(stopCh, zap.New(observer).Sugar())
+	backoff, _ := testHandler.GetBackoff(taskRun)
+	if !backoff.NextAttempt.After(time.Now()) {
+		t.Errorf("expected the next attempt to be in the future, got %s", backoff.NextAttempt)
+	}
+}
+
 // TestBackoffDuration asserts that the backoffDuration func returns Durations
 // within the timeout handler's bounds.
 func TestBackoffDuration(t *testing.T) {
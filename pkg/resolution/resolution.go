@@ -0,0 +1,155 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resolution fetches the Tasks and Pipelines that TaskRefs and
+// PipelineRefs reference with a resolver, e.g. from a git repository, through
+// ResolutionRequests.
+package resolution
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrRequestInProgress is returned while the ResolutionRequest of a resource
+// hasn't been resolved yet. The run is reconciled again once it is.
+var ErrRequestInProgress = errors.New("resolution request in progress")
+
+// IsRequestInProgress returns true if err is, or wraps, ErrRequestInProgress.
+func IsRequestInProgress(err error) bool {
+	return errors.Is(err, ErrRequestInProgress)
+}
+
+// Resolver fetches the resources of the ResolutionRequests naming it.
+type Resolver interface {
+	// Name is the resolver field of the ResolverRefs the resolver fetches.
+	Name() string
+	// Resolve returns the resource the params reference, in YAML.
+	Resolve(ctx context.Context, params map[string]string) ([]byte, error)
+}
+
+// Requester creates the ResolutionRequests of the runs that reference remote
+// Tasks and Pipelines, and returns their resources once resolved.
+type Requester struct {
+	Client versioned.Interface
+	Lister listers.ResolutionRequestLister
+}
+
+// Request returns the resource ref references for the run owner, which the
+// ownerRefs reference. The first time, it creates the ResolutionRequest of
+// the resource and returns ErrRequestInProgress.
+func (r Requester) Request(owner metav1.Object, ownerRefs []metav1.OwnerReference, ref v1alpha1.ResolverRef) ([]byte, error) {
+	name, err := requestName(owner.GetName(), ref)
+	if err != nil {
+		return nil, err
+	}
+	rr, err := r.Lister.ResolutionRequests(owner.GetNamespace()).Get(name)
+	if kerrors.IsNotFound(err) {
+		_, err = r.Client.TektonV1alpha1().ResolutionRequests(owner.GetNamespace()).Create(&v1alpha1.ResolutionRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       owner.GetNamespace(),
+				OwnerReferences: ownerRefs,
+			},
+			Spec: v1alpha1.ResolutionRequestSpec{ResolverRef: ref},
+		})
+		if err != nil && !kerrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("error creating ResolutionRequest %s: %w", name, err)
+		}
+		return nil, ErrRequestInProgress
+	} else if err != nil {
+		return nil, err
+	}
+
+	cond := rr.Status.GetCondition(apis.ConditionSucceeded)
+	switch {
+	case cond.IsTrue():
+		return []byte(rr.Status.Data), nil
+	case cond.IsFalse():
+		return nil, fmt.Errorf("%s resolver failed to resolve ResolutionRequest %s: %s", ref.Resolver, name, cond.Message)
+	default:
+		return nil, ErrRequestInProgress
+	}
+}
+
+// requestName returns the name of the ResolutionRequest of the resource ref
+// references for the run named owner: each resource a run references has its
+// own.
+func requestName(owner string, ref v1alpha1.ResolverRef) (string, error) {
+	b, err := json.Marshal(ref)
+	if err != nil {
+		return "", err
+	}
+	return kmeta.ChildName(owner, fmt.Sprintf("-%x", sha256.Sum256(b))[:11]), nil
+}
+
+// Task returns the Task a resolver fetched for a run in namespace, defaulted
+// and validated, including against the task policy of namespace.
+func Task(ctx context.Context, data []byte, namespace string) (*v1alpha1.Task, error) {
+	t := &v1alpha1.Task{}
+	if err := decode(data, "Task", t); err != nil {
+		return nil, err
+	}
+	t.SetDefaults(ctx)
+	if err := t.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("invalid Task %s: %w", t.Name, err)
+	}
+	if err := v1alpha1.ValidateTaskPolicy(ctx, namespace, &t.Spec); err != nil {
+		return nil, fmt.Errorf("Task %s violates the task policy: %w", t.Name, err)
+	}
+	return t, nil
+}
+
+// Pipeline returns the Pipeline a resolver fetched, defaulted and validated.
+func Pipeline(ctx context.Context, data []byte) (*v1alpha1.Pipeline, error) {
+	p := &v1alpha1.Pipeline{}
+	if err := decode(data, "Pipeline", p); err != nil {
+		return nil, err
+	}
+	p.SetDefaults(ctx)
+	if err := p.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("invalid Pipeline %s: %w", p.Name, err)
+	}
+	return p, nil
+}
+
+// decode unmarshals the resource in data to obj, checking that it is a kind
+// of the current API version.
+func decode(data []byte, kind string, obj interface{}) error {
+	var tm metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &tm); err != nil {
+		return fmt.Errorf("error decoding the resolved resource: %w", err)
+	}
+	if tm.APIVersion != v1alpha1.SchemeGroupVersion.String() || tm.Kind != kind {
+		return fmt.Errorf("the resolved resource is a %s %s, not a %s %s", tm.APIVersion, tm.Kind, v1alpha1.SchemeGroupVersion, kind)
+	}
+	if err := yaml.UnmarshalStrict(data, obj); err != nil {
+		return fmt.Errorf("error decoding the resolved %s: %w", kind, err)
+	}
+	return nil
+}
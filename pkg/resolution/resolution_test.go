@@ -0,0 +1,218 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolution_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/resolution"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+const taskYAML = `apiVersion: tekton.dev/v1alpha1
+kind: Task
+metadata:
+  name: echo
+spec:
+  steps:
+  - image: busybox
+    script: echo hello
+`
+
+func TestRequest(t *testing.T) {
+	ref := v1alpha1.ResolverRef{
+		Resolver: "git",
+		Params: []v1alpha1.Param{
+			{Name: "url", Value: *tb.ArrayOrString("https://github.com/tektoncd/catalog")},
+			{Name: "path", Value: *tb.ArrayOrString("task/echo.yaml")},
+		},
+	}
+	owner := tb.TaskRun("run", "foo")
+	ownerRefs := []metav1.OwnerReference{*metav1.NewControllerRef(owner, v1alpha1.SchemeGroupVersion.WithKind("TaskRun"))}
+
+	ctx, _ := ttesting.SetupFakeContext(t)
+	c, i := reconcilertest.SeedTestData(t, ctx, reconcilertest.Data{})
+	requester := resolution.Requester{Client: c.Pipeline, Lister: i.ResolutionRequest.Lister()}
+
+	if _, err := requester.Request(owner, ownerRefs, ref); !errors.Is(err, resolution.ErrRequestInProgress) {
+		t.Fatalf("Request() of a new resource error = %v, want ErrRequestInProgress", err)
+	}
+	rrs, err := c.Pipeline.TektonV1alpha1().ResolutionRequests("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rrs.Items) != 1 {
+		t.Fatalf("Request() created %d ResolutionRequests, want 1", len(rrs.Items))
+	}
+	rr := rrs.Items[0]
+	if d := cmp.Diff(ownerRefs, rr.OwnerReferences); d != "" {
+		t.Errorf("ResolutionRequest owner references -want, +got: %s", d)
+	}
+	if d := cmp.Diff(ref, rr.Spec.ResolverRef); d != "" {
+		t.Errorf("ResolutionRequest spec -want, +got: %s", d)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		status   v1alpha1.ResolutionRequestStatus
+		wantData string
+		wantErr  string
+	}{{
+		name: "in progress",
+		status: v1alpha1.ResolutionRequestStatus{Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		}}}},
+		wantErr: resolution.ErrRequestInProgress.Error(),
+	}, {
+		name: "resolved",
+		status: v1alpha1.ResolutionRequestStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}}},
+			Data: taskYAML,
+		},
+		wantData: taskYAML,
+	}, {
+		name: "failed",
+		status: v1alpha1.ResolutionRequestStatus{Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Message: "repository not found",
+		}}}},
+		wantErr: "git resolver failed to resolve ResolutionRequest " + rr.Name + ": repository not found",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := rr.DeepCopy()
+			rr.Status = tc.status
+			if err := i.ResolutionRequest.Informer().GetIndexer().Update(rr); err != nil {
+				t.Fatal(err)
+			}
+			data, err := requester.Request(owner, ownerRefs, ref)
+			if tc.wantErr != "" {
+				if err == nil {
+					t.Fatal("Request() succeeded, want an error")
+				}
+				if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+					t.Errorf("Request() error -want, +got: %s", d)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Request() = %v", err)
+			}
+			if d := cmp.Diff(tc.wantData, string(data)); d != "" {
+				t.Errorf("Request() -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestTask(t *testing.T) {
+	task, err := resolution.Task(context.Background(), []byte(taskYAML), "foo")
+	if err != nil {
+		t.Fatalf("Task() = %v", err)
+	}
+	if task.Name != "echo" || len(task.Spec.Steps) != 1 || task.Spec.Steps[0].Script != "echo hello" {
+		t.Errorf("Task() = %+v, want the echo Task", task)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		data    string
+		wantErr string
+	}{{
+		name:    "pipeline",
+		data:    "apiVersion: tekton.dev/v1alpha1\nkind: Pipeline\nmetadata:\n  name: echo\n",
+		wantErr: "the resolved resource is a tekton.dev/v1alpha1 Pipeline, not a tekton.dev/v1alpha1 Task",
+	}, {
+		name:    "other version",
+		data:    "apiVersion: tekton.dev/v1beta1\nkind: Task\nmetadata:\n  name: echo\n",
+		wantErr: "the resolved resource is a tekton.dev/v1beta1 Task, not a tekton.dev/v1alpha1 Task",
+	}, {
+		name:    "unknown field",
+		data:    taskYAML + "  stepz: []\n",
+		wantErr: `error decoding the resolved Task: error unmarshaling JSON: while decoding JSON: json: unknown field "stepz"`,
+	}, {
+		name:    "invalid",
+		data:    "apiVersion: tekton.dev/v1alpha1\nkind: Task\nmetadata:\n  name: echo\nspec:\n  steps:\n  - name: echo\n",
+		wantErr: "invalid Task echo: missing field(s): steps.Image",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resolution.Task(context.Background(), []byte(tc.data), "foo")
+			if err == nil {
+				t.Fatal("Task() succeeded, want an error")
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("Task() error -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestTaskPolicy(t *testing.T) {
+	cfg := config.FromContextOrDefaults(context.Background())
+	cfg.TaskPolicy = &config.TaskPolicy{DisallowPrivileged: true, ExemptNamespaces: []string{"tekton-ci"}}
+	ctx := config.ToContext(context.Background(), cfg)
+	data := []byte(taskYAML + "    securityContext:\n      privileged: true\n")
+
+	_, err := resolution.Task(ctx, data, "foo")
+	if err == nil {
+		t.Fatal("Task() of a privileged Task succeeded, want an error")
+	}
+	want := "Task echo violates the task policy: privileged containers are disallowed by the task policy: steps[0].securityContext.privileged"
+	if d := cmp.Diff(want, err.Error()); d != "" {
+		t.Errorf("Task() error -want, +got: %s", d)
+	}
+	if _, err := resolution.Task(ctx, data, "tekton-ci"); err != nil {
+		t.Errorf("Task() in an exempt namespace = %v", err)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	p, err := resolution.Pipeline(context.Background(), []byte(`apiVersion: tekton.dev/v1alpha1
+kind: Pipeline
+metadata:
+  name: build
+spec:
+  tasks:
+  - name: clone
+    taskRef:
+      name: git-clone
+`))
+	if err != nil {
+		t.Fatalf("Pipeline() = %v", err)
+	}
+	if p.Name != "build" || len(p.Spec.Tasks) != 1 || p.Spec.Tasks[0].TaskRef.Name != "git-clone" {
+		t.Errorf("Pipeline() = %+v, want the build Pipeline", p)
+	}
+	if _, err := resolution.Pipeline(context.Background(), []byte(taskYAML)); err == nil {
+		t.Error("Pipeline() of a Task succeeded, want an error")
+	}
+}
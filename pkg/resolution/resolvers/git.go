@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/resolution"
+)
+
+// allowedGitProtocols are the transports the url param can use: the file and
+// ext transports would read the filesystem of the controller and run commands
+// in it.
+var allowedGitProtocols = "https:http:ssh:git"
+
+// Git fetches the file at the path param from the git repository at the url
+// param, at the revision param, which defaults to master. It runs the git
+// binary, which must be installed.
+type Git struct{}
+
+var _ resolution.Resolver = (*Git)(nil)
+
+// Name implements resolution.Resolver.
+func (*Git) Name() string { return "git" }
+
+// Resolve implements resolution.Resolver.
+func (*Git) Resolve(ctx context.Context, params map[string]string) ([]byte, error) {
+	repo, path := params["url"], params["path"]
+	if repo == "" || path == "" {
+		return nil, errors.New("the url and path params are required")
+	}
+	// Options would be read from a value starting with a dash.
+	if strings.HasPrefix(repo, "-") {
+		return nil, fmt.Errorf("%s should be the URL of a git repository", repo)
+	}
+	revision := params["revision"]
+	if revision == "" {
+		revision = "master"
+	}
+	if strings.HasPrefix(revision, "-") {
+		return nil, fmt.Errorf("%s should be a revision", revision)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	dir, err := ioutil.TempDir("", "git-resolver-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	if _, err := runGit(ctx, dir, "init"); err != nil {
+		return nil, err
+	}
+	if _, err := runGit(ctx, dir, "fetch", "--depth=1", "--", repo, revision); err != nil {
+		return nil, err
+	}
+	// The file is read from the fetched commit, without checking it out.
+	out, err := runGit(ctx, dir, "show", "FETCH_HEAD:"+strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, err
+	}
+	return readLimited(bytes.NewReader(out), fmt.Sprintf("%s in %s at %s", path, repo, revision))
+}
+
+// runGit runs git with args in dir, and returns its output.
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_ALLOW_PROTOCOL="+allowedGitProtocols, "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error running git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
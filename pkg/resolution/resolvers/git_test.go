@@ -0,0 +1,128 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolvers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// initRepo commits the files to a new git repository, and returns its
+// directory.
+func initRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "git-resolver-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+		}
+	}
+	git("init", "-q")
+	git("checkout", "-q", "-b", "master")
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "tasks")
+	git("tag", "v0.1")
+	return dir
+}
+
+func TestGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := initRepo(t, map[string]string{"task/echo.yaml": taskYAML})
+	defer os.RemoveAll(dir)
+	defer func(protocols string) { allowedGitProtocols = protocols }(allowedGitProtocols)
+	allowedGitProtocols += ":file"
+
+	for _, params := range []map[string]string{
+		{"url": "file://" + dir, "path": "task/echo.yaml"},
+		{"url": "file://" + dir, "path": "/task/echo.yaml", "revision": "v0.1"},
+	} {
+		got, err := (&Git{}).Resolve(context.Background(), params)
+		if err != nil {
+			t.Fatalf("Resolve(%v) = %v", params, err)
+		}
+		if d := cmp.Diff(taskYAML, string(got)); d != "" {
+			t.Errorf("Resolve(%v) -want, +got: %s", params, d)
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		params  map[string]string
+		wantErr string
+	}{{
+		name:    "no path",
+		params:  map[string]string{"url": "file://" + dir},
+		wantErr: "the url and path params are required",
+	}, {
+		name:    "option url",
+		params:  map[string]string{"url": "--upload-pack=touch /tmp/pwned", "path": "task/echo.yaml"},
+		wantErr: "--upload-pack=touch /tmp/pwned should be the URL of a git repository",
+	}, {
+		name:    "option revision",
+		params:  map[string]string{"url": "file://" + dir, "path": "task/echo.yaml", "revision": "--all"},
+		wantErr: "--all should be a revision",
+	}, {
+		name:    "missing file",
+		params:  map[string]string{"url": "file://" + dir, "path": "task/missing.yaml"},
+		wantErr: "error running git show",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := (&Git{}).Resolve(context.Background(), tc.params)
+			if err == nil {
+				t.Fatal("Resolve() succeeded, want an error")
+			}
+			if !strings.HasPrefix(err.Error(), tc.wantErr) {
+				t.Errorf("Resolve() error = %q, want it to start with %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGit_DisallowedProtocol(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := initRepo(t, map[string]string{"task/echo.yaml": taskYAML})
+	defer os.RemoveAll(dir)
+
+	_, err := (&Git{}).Resolve(context.Background(), map[string]string{"url": "file://" + dir, "path": "task/echo.yaml"})
+	if err == nil || !strings.HasPrefix(err.Error(), "error running git fetch") {
+		t.Errorf("Resolve() of a file URL error = %v, want a failed fetch", err)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resolvers holds the resolvers built into the controller.
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/resolution"
+)
+
+const (
+	// MaxResourceSize is the largest resource a resolver returns, so that
+	// it fits in the status of the ResolutionRequest.
+	MaxResourceSize = 1 << 20
+
+	fetchTimeout = time.Minute
+)
+
+// HTTP fetches the resource at the url param, which must be http or https.
+type HTTP struct {
+	// Client sends the requests. http.DefaultClient is used if nil.
+	Client *http.Client
+}
+
+var _ resolution.Resolver = (*HTTP)(nil)
+
+// Name implements resolution.Resolver.
+func (*HTTP) Name() string { return "http" }
+
+// Resolve implements resolution.Resolver.
+func (r *HTTP) Resolve(ctx context.Context, params map[string]string) ([]byte, error) {
+	rawURL := params["url"]
+	if rawURL == "" {
+		return nil, errors.New("missing the url param")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("%s should be an http or https URL", rawURL)
+	}
+	return get(ctx, r.Client, u.String())
+}
+
+// get returns the body of the response to a GET of rawURL, which must
+// succeed.
+func get(ctx context.Context, client *http.Client, rawURL string) ([]byte, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("error fetching %s: %s", rawURL, resp.Status)
+	}
+	return readLimited(resp.Body, rawURL)
+}
+
+// readLimited reads the resource named name from r, which must be at most
+// MaxResourceSize.
+func readLimited(r io.Reader, name string) ([]byte, error) {
+	b, err := ioutil.ReadAll(io.LimitReader(r, MaxResourceSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", name, err)
+	}
+	if len(b) > MaxResourceSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", name, MaxResourceSize)
+	}
+	return b, nil
+}
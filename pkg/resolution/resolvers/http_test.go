@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolvers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const taskYAML = `apiVersion: tekton.dev/v1alpha1
+kind: Task
+metadata:
+  name: echo
+spec:
+  steps:
+  - image: busybox
+    script: echo hello
+`
+
+func TestHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/task.yaml":
+			w.Write([]byte(taskYAML))
+		case "/large.yaml":
+			w.Write([]byte(strings.Repeat("#", MaxResourceSize+1)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	got, err := (&HTTP{}).Resolve(context.Background(), map[string]string{"url": srv.URL + "/task.yaml"})
+	if err != nil {
+		t.Fatalf("Resolve() = %v", err)
+	}
+	if d := cmp.Diff(taskYAML, string(got)); d != "" {
+		t.Errorf("Resolve() -want, +got: %s", d)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		params  map[string]string
+		wantErr string
+	}{{
+		name:    "no url",
+		params:  map[string]string{},
+		wantErr: "missing the url param",
+	}, {
+		name:    "not http",
+		params:  map[string]string{"url": "file:///etc/passwd"},
+		wantErr: "file:///etc/passwd should be an http or https URL",
+	}, {
+		name:    "not found",
+		params:  map[string]string{"url": srv.URL + "/missing.yaml"},
+		wantErr: "error fetching " + srv.URL + "/missing.yaml: 404 Not Found",
+	}, {
+		name:    "too large",
+		params:  map[string]string{"url": srv.URL + "/large.yaml"},
+		wantErr: srv.URL + "/large.yaml is larger than 1048576 bytes",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := (&HTTP{}).Resolve(context.Background(), tc.params)
+			if err == nil {
+				t.Fatal("Resolve() succeeded, want an error")
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("Resolve() error -want, +got: %s", d)
+			}
+		})
+	}
+}
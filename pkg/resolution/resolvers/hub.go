@@ -0,0 +1,74 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/resolution"
+)
+
+// DefaultHubURL is the API of the public Tekton Hub.
+const DefaultHubURL = "https://api.hub.tekton.dev"
+
+// Hub fetches the version of the Task or Pipeline named by the name and
+// version params from a catalog of the Tekton Hub. The kind param is either
+// task, the default, or pipeline; the catalog param defaults to tekton.
+type Hub struct {
+	// URL is the URL of the API of the Hub. DefaultHubURL is used if empty.
+	URL string
+	// Client sends the requests. http.DefaultClient is used if nil.
+	Client *http.Client
+}
+
+var _ resolution.Resolver = (*Hub)(nil)
+
+// Name implements resolution.Resolver.
+func (*Hub) Name() string { return "hub" }
+
+// Resolve implements resolution.Resolver.
+func (r *Hub) Resolve(ctx context.Context, params map[string]string) ([]byte, error) {
+	name, version := params["name"], params["version"]
+	if name == "" || version == "" {
+		return nil, errors.New("the name and version params are required")
+	}
+	kind := params["kind"]
+	if kind == "" {
+		kind = "task"
+	}
+	if kind != "task" && kind != "pipeline" {
+		return nil, fmt.Errorf("kind %s should be task or pipeline", kind)
+	}
+	catalog := params["catalog"]
+	if catalog == "" {
+		catalog = "tekton"
+	}
+	hubURL := r.URL
+	if hubURL == "" {
+		hubURL = DefaultHubURL
+	}
+	segments := []string{catalog, kind, name, version}
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return get(ctx, r.Client, fmt.Sprintf("%s/v1/resource/%s/yaml", strings.TrimSuffix(hubURL, "/"), strings.Join(segments, "/")))
+}
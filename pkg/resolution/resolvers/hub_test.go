@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolvers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHub(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(taskYAML))
+	}))
+	defer srv.Close()
+	hub := &Hub{URL: srv.URL}
+
+	for _, params := range []map[string]string{
+		{"name": "git-clone", "version": "0.1"},
+		{"name": "buildpacks", "version": "0.2", "kind": "pipeline", "catalog": "community"},
+	} {
+		if _, err := hub.Resolve(context.Background(), params); err != nil {
+			t.Errorf("Resolve(%v) = %v", params, err)
+		}
+	}
+	want := []string{
+		"/v1/resource/tekton/task/git-clone/0.1/yaml",
+		"/v1/resource/community/pipeline/buildpacks/0.2/yaml",
+	}
+	if d := cmp.Diff(want, paths); d != "" {
+		t.Errorf("requested paths -want, +got: %s", d)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		params  map[string]string
+		wantErr string
+	}{{
+		name:    "no version",
+		params:  map[string]string{"name": "git-clone"},
+		wantErr: "the name and version params are required",
+	}, {
+		name:    "invalid kind",
+		params:  map[string]string{"name": "git-clone", "version": "0.1", "kind": "condition"},
+		wantErr: "kind condition should be task or pipeline",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := hub.Resolve(context.Background(), tc.params)
+			if err == nil {
+				t.Fatal("Resolve() succeeded, want an error")
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("Resolve() error -want, +got: %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resourceplugins registers the types of PipelineResources that
+// operators declare in a ConfigMap, each implemented by an exec plugin.
+package resourceplugins
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/configmap"
+)
+
+// ConfigName is the name of the ConfigMap declaring the plugins: each key is
+// a type of PipelineResource, and its value the image of the plugin
+// implementing it. Keys starting with "_" are ignored.
+const ConfigName = "config-resource-plugins"
+
+var (
+	mu sync.Mutex
+	// registered are the types of PipelineResources registered from the
+	// ConfigMap, so that those it no longer declares are unregistered.
+	registered = map[v1alpha1.PipelineResourceType]bool{}
+)
+
+// UpdateFromConfigMap returns an observer registering a
+// v1alpha1.PluginResourceType for each plugin the ConfigMap declares, and
+// unregistering those it no longer declares. The built-in types can't be
+// replaced.
+func UpdateFromConfigMap(logger *zap.SugaredLogger) func(*corev1.ConfigMap) {
+	return func(cm *corev1.ConfigMap) {
+		mu.Lock()
+		defer mu.Unlock()
+		declared := map[v1alpha1.PipelineResourceType]bool{}
+		for key, image := range cm.Data {
+			t := v1alpha1.PipelineResourceType(key)
+			switch {
+			case strings.HasPrefix(key, "_"):
+				continue
+			case v1alpha1.IsBuiltinResourceType(t):
+				logger.Errorf("The %s PipelineResources are built-in and can't be implemented by a plugin", key)
+				continue
+			case strings.TrimSpace(image) == "":
+				logger.Errorf("The plugin implementing the %s PipelineResources has no image", key)
+				continue
+			}
+			v1alpha1.RegisterResourceType(t, v1alpha1.PluginResourceType{Image: strings.TrimSpace(image)})
+			declared[t] = true
+		}
+		for t := range registered {
+			if !declared[t] {
+				v1alpha1.UnregisterResourceType(t)
+			}
+		}
+		registered = declared
+	}
+}
+
+// Watch keeps the plugins registered in sync with the ConfigMap, which is
+// optional.
+func Watch(cmw configmap.Watcher, logger *zap.SugaredLogger) {
+	observer := UpdateFromConfigMap(logger)
+	switch w := cmw.(type) {
+	case nil:
+	case configmap.DefaultingWatcher:
+		w.WatchWithDefault(corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ConfigName},
+		}, observer)
+	default:
+		w.Watch(ConfigName, observer)
+	}
+}
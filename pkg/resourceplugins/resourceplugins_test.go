@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceplugins
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestUpdateFromConfigMap(t *testing.T) {
+	update := UpdateFromConfigMap(logtesting.TestLogger(t))
+	configMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ConfigName}, Data: data}
+	}
+	defer update(configMap(nil))
+
+	update(configMap(map[string]string{
+		"_example":    "ignored",
+		"artifactory": " example.com/artifactory-plugin ",
+		"helm-chart":  "example.com/helm-plugin",
+		"git":         "example.com/git-plugin",
+		"empty":       "",
+	}))
+	for _, tc := range []struct {
+		resourceType v1alpha1.PipelineResourceType
+		want         v1alpha1.ResourceType
+	}{{
+		resourceType: "artifactory",
+		want:         v1alpha1.PluginResourceType{Image: "example.com/artifactory-plugin"},
+	}, {
+		resourceType: "helm-chart",
+		want:         v1alpha1.PluginResourceType{Image: "example.com/helm-plugin"},
+	}, {
+		resourceType: "_example",
+	}, {
+		resourceType: "empty",
+	}} {
+		got, _ := v1alpha1.GetResourceType(tc.resourceType)
+		if d := cmp.Diff(tc.want, got); d != "" {
+			t.Errorf("ResourceType of %s diff -want, +got: %s", tc.resourceType, d)
+		}
+	}
+	if rt, _ := v1alpha1.GetResourceType("git"); rt == (v1alpha1.PluginResourceType{Image: "example.com/git-plugin"}) {
+		t.Error("The built-in git PipelineResources should not be replaced by a plugin")
+	}
+
+	update(configMap(map[string]string{
+		"helm-chart": "example.com/helm-plugin:v2",
+	}))
+	if _, ok := v1alpha1.GetResourceType("artifactory"); ok {
+		t.Error("The artifactory PipelineResources should be unregistered once the ConfigMap no longer declares them")
+	}
+	if got, _ := v1alpha1.GetResourceType("helm-chart"); got != (v1alpha1.PluginResourceType{Image: "example.com/helm-plugin:v2"}) {
+		t.Errorf("ResourceType of helm-chart = %v, want the updated plugin", got)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runtool composes the PipelineRuns of Pipelines, checked the way the
+// webhook and the controller would check them, and submits them.
+package runtool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/names"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"knative.dev/pkg/apis"
+)
+
+// Options configures the PipelineRun composed by NewPipelineRun.
+type Options struct {
+	// Namespace of the PipelineRun, by default the namespace of the
+	// Pipeline.
+	Namespace string
+	// NamePrefix is the prefix of the generated name of the PipelineRun, by
+	// default the name of the Pipeline followed by "-run".
+	NamePrefix string
+	// Params are the values of the params of the Pipeline, by name.
+	Params map[string]v1alpha1.ArrayOrString
+	// Resources are the names of the PipelineResources bound to the
+	// resources declared by the Pipeline, by declared name.
+	Resources map[string]string
+	// ServiceAccountName runs the TaskRuns of the PipelineRun, by default
+	// the default-service-account of config-defaults.
+	ServiceAccountName string
+	// Timeout of the PipelineRun, by default the default-timeout-minutes of
+	// config-defaults.
+	Timeout *metav1.Duration
+	// Labels and Annotations are added to the PipelineRun.
+	Labels      map[string]string
+	Annotations map[string]string
+	// Embed embeds the spec of the Pipeline in the PipelineRun instead of
+	// referencing it by name, for Pipelines that aren't in the cluster.
+	Embed bool
+}
+
+// NewPipelineRun returns a PipelineRun of p, with a generated name. Like the
+// webhook, it sets its defaults from the configuration of ctx and validates
+// it; like the controller, it checks that the params and resources match
+// those p declares.
+func NewPipelineRun(ctx context.Context, p *v1alpha1.Pipeline, opts Options) (*v1alpha1.PipelineRun, error) {
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = p.Namespace
+	}
+	prefix := opts.NamePrefix
+	if prefix == "" {
+		prefix = p.Name + "-run"
+	}
+	pr := &v1alpha1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "PipelineRun",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(prefix),
+			Labels:      opts.Labels,
+			Annotations: opts.Annotations,
+		},
+		Spec: v1alpha1.PipelineRunSpec{
+			Timeout: opts.Timeout,
+			TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{
+				ServiceAccountName: opts.ServiceAccountName,
+			},
+		},
+	}
+	if opts.Embed {
+		pr.Spec.PipelineSpec = p.Spec.DeepCopy()
+	} else {
+		pr.Spec.PipelineRef = &v1alpha1.PipelineRef{Name: p.Name}
+	}
+	for _, name := range sortedKeys(opts.Params) {
+		pr.Spec.Params = append(pr.Spec.Params, v1alpha1.Param{Name: name, Value: opts.Params[name]})
+	}
+	resourceNames := make([]string, 0, len(opts.Resources))
+	for name := range opts.Resources {
+		resourceNames = append(resourceNames, name)
+	}
+	sort.Strings(resourceNames)
+	for _, name := range resourceNames {
+		pr.Spec.Resources = append(pr.Spec.Resources, v1alpha1.PipelineResourceBinding{
+			Name:        name,
+			ResourceRef: &v1alpha1.PipelineResourceRef{Name: opts.Resources[name]},
+		})
+	}
+
+	ctx = apis.WithinCreate(ctx)
+	pr.SetDefaults(ctx)
+	if err := pr.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("invalid PipelineRun of Pipeline %q: %w", p.Name, err)
+	}
+	if err := validateParams(&p.Spec, opts.Params); err != nil {
+		return nil, fmt.Errorf("invalid PipelineRun of Pipeline %q: %w", p.Name, err)
+	}
+	if err := resources.ValidateParamTypesMatching(&p.Spec, pr); err != nil {
+		return nil, fmt.Errorf("invalid PipelineRun of Pipeline %q: %w", p.Name, err)
+	}
+	if err := resources.ValidateParamEnums(&p.Spec, pr); err != nil {
+		return nil, fmt.Errorf("invalid PipelineRun of Pipeline %q: %w", p.Name, err)
+	}
+	if err := resources.ValidateResourceBindings(&p.Spec, pr); err != nil {
+		return nil, fmt.Errorf("invalid PipelineRun of Pipeline %q: %w", p.Name, err)
+	}
+	return pr, nil
+}
+
+// NewPipelineRunFromRef returns a PipelineRun of the Pipeline name of
+// namespace, see NewPipelineRun.
+func NewPipelineRunFromRef(ctx context.Context, client versioned.Interface, namespace, name string, opts Options) (*v1alpha1.PipelineRun, error) {
+	p, err := client.TektonV1alpha1().Pipelines(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting Pipeline %s/%s: %w", namespace, name, err)
+	}
+	return NewPipelineRun(ctx, p, opts)
+}
+
+// Submit creates pr, returning it as created.
+func Submit(client versioned.Interface, pr *v1alpha1.PipelineRun) (*v1alpha1.PipelineRun, error) {
+	created, err := client.TektonV1alpha1().PipelineRuns(pr.Namespace).Create(pr)
+	if err != nil {
+		return nil, fmt.Errorf("error creating PipelineRun %s/%s: %w", pr.Namespace, pr.Name, err)
+	}
+	return created, nil
+}
+
+// Wait polls pr every interval until it is done or ctx is done, returning
+// its latest version.
+func Wait(ctx context.Context, client versioned.Interface, pr *v1alpha1.PipelineRun, interval time.Duration) (*v1alpha1.PipelineRun, error) {
+	latest := pr
+	err := wait.PollImmediateUntil(interval, func() (bool, error) {
+		got, err := client.TektonV1alpha1().PipelineRuns(pr.Namespace).Get(pr.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		latest = got
+		return got.IsDone(), nil
+	}, ctx.Done())
+	if err != nil {
+		return latest, fmt.Errorf("error waiting for PipelineRun %s/%s: %w", pr.Namespace, pr.Name, err)
+	}
+	return latest, nil
+}
+
+// validateParams checks that params only holds params p declares, and every
+// param p declares without a default.
+func validateParams(p *v1alpha1.PipelineSpec, params map[string]v1alpha1.ArrayOrString) error {
+	declared := map[string]bool{}
+	for _, ps := range p.Params {
+		declared[ps.Name] = true
+		if _, ok := params[ps.Name]; !ok && ps.Default == nil {
+			return fmt.Errorf("missing value for param %q, which has no default", ps.Name)
+		}
+	}
+	for _, name := range sortedKeys(params) {
+		if !declared[name] {
+			return fmt.Errorf("param %q isn't declared by the Pipeline", name)
+		}
+	}
+	return nil
+}
+
+func sortedKeys(params map[string]v1alpha1.ArrayOrString) []string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
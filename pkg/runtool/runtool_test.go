@@ -0,0 +1,190 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtool
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	fakepipelineclientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+var pipeline = &v1alpha1.Pipeline{
+	ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "release"},
+	Spec: v1alpha1.PipelineSpec{
+		Params: []v1alpha1.ParamSpec{{
+			Name: "version",
+			Type: v1alpha1.ParamTypeString,
+		}, {
+			Name:    "platforms",
+			Type:    v1alpha1.ParamTypeArray,
+			Default: &v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeArray, ArrayVal: []string{"linux/amd64"}},
+		}},
+		Resources: []v1alpha1.PipelineDeclaredResource{{
+			Name: "source",
+			Type: v1alpha1.PipelineResourceTypeGit,
+		}},
+		Tasks: []v1alpha1.PipelineTask{{
+			Name:    "build",
+			TaskRef: v1alpha1.TaskRef{Name: "build"},
+		}},
+	},
+}
+
+func stringValue(s string) v1alpha1.ArrayOrString {
+	return v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeString, StringVal: s}
+}
+
+func TestNewPipelineRun(t *testing.T) {
+	opts := Options{
+		Params:             map[string]v1alpha1.ArrayOrString{"version": stringValue("v1.2.3")},
+		Resources:          map[string]string{"source": "release-repo"},
+		ServiceAccountName: "releaser",
+		Labels:             map[string]string{"team": "release"},
+	}
+	pr, err := NewPipelineRun(context.Background(), pipeline, opts)
+	if err != nil {
+		t.Fatalf("NewPipelineRun: %v", err)
+	}
+	if !strings.HasPrefix(pr.Name, "release-run-") {
+		t.Errorf("Expected the name %q to start with release-run-", pr.Name)
+	}
+	want := &v1alpha1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{APIVersion: "tekton.dev/v1alpha1", Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+			Labels:    map[string]string{"team": "release"},
+		},
+		Spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: &v1alpha1.PipelineRef{Name: "release"},
+			Params:      []v1alpha1.Param{{Name: "version", Value: stringValue("v1.2.3")}},
+			Resources: []v1alpha1.PipelineResourceBinding{{
+				Name:        "source",
+				ResourceRef: &v1alpha1.PipelineResourceRef{Name: "release-repo"},
+			}},
+			TaskRunTemplate: v1alpha1.PipelineTaskRunTemplate{ServiceAccountName: "releaser"},
+			// Defaulted from config-defaults.
+			Timeout: &metav1.Duration{Duration: time.Hour},
+		},
+	}
+	if d := cmp.Diff(want, pr, cmpopts.IgnoreFields(metav1.ObjectMeta{}, "Name")); d != "" {
+		t.Errorf("PipelineRun (-want, +got): %s", d)
+	}
+
+	opts.Embed = true
+	embedded, err := NewPipelineRun(context.Background(), pipeline, opts)
+	if err != nil {
+		t.Fatalf("NewPipelineRun: %v", err)
+	}
+	if embedded.Spec.PipelineRef != nil || embedded.Spec.PipelineSpec == nil {
+		t.Errorf("Expected the spec of the Pipeline to be embedded, got %+v", embedded.Spec)
+	}
+	if embedded.Name == pr.Name {
+		t.Errorf("Expected a different name for each PipelineRun, got %q twice", pr.Name)
+	}
+}
+
+func TestNewPipelineRun_Invalid(t *testing.T) {
+	source := map[string]string{"source": "release-repo"}
+	for _, c := range []struct {
+		desc string
+		opts Options
+		want string
+	}{{
+		desc: "missing param",
+		opts: Options{Resources: source},
+		want: `missing value for param "version", which has no default`,
+	}, {
+		desc: "undeclared param",
+		opts: Options{
+			Params:    map[string]v1alpha1.ArrayOrString{"version": stringValue("v1"), "channel": stringValue("stable")},
+			Resources: source,
+		},
+		want: `param "channel" isn't declared by the Pipeline`,
+	}, {
+		desc: "param of the wrong type",
+		opts: Options{
+			Params:    map[string]v1alpha1.ArrayOrString{"version": stringValue("v1"), "platforms": stringValue("linux/arm64")},
+			Resources: source,
+		},
+		want: "parameters have inconsistent types : [platforms]",
+	}, {
+		desc: "missing resource",
+		opts: Options{Params: map[string]v1alpha1.ArrayOrString{"version": stringValue("v1")}},
+		want: "pipelineRun bound resources didn't match Pipeline",
+	}, {
+		desc: "negative timeout",
+		opts: Options{
+			Params:    map[string]v1alpha1.ArrayOrString{"version": stringValue("v1")},
+			Resources: source,
+			Timeout:   &metav1.Duration{Duration: -time.Hour},
+		},
+		want: "should be >= 0",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			_, err := NewPipelineRun(context.Background(), pipeline, c.opts)
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Errorf("Expected an error containing %q, got %v", c.want, err)
+			}
+		})
+	}
+}
+
+func TestSubmitAndWait(t *testing.T) {
+	client := fakepipelineclientset.NewSimpleClientset(pipeline)
+	pr, err := NewPipelineRunFromRef(context.Background(), client, "foo", "release", Options{
+		Params:    map[string]v1alpha1.ArrayOrString{"version": stringValue("v1.2.3")},
+		Resources: map[string]string{"source": "release-repo"},
+	})
+	if err != nil {
+		t.Fatalf("NewPipelineRunFromRef: %v", err)
+	}
+	created, err := Submit(client, pr)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	// A PipelineRun that isn't done is waited for until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := Wait(ctx, client, created, 10*time.Millisecond); err == nil {
+		t.Errorf("Expected waiting for a running PipelineRun to time out")
+	}
+
+	created.Status = v1alpha1.PipelineRunStatus{Status: duckv1beta1.Status{
+		Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+	}}
+	if _, err := client.TektonV1alpha1().PipelineRuns("foo").UpdateStatus(created); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	done, err := Wait(context.Background(), client, created, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if !done.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Errorf("Expected the PipelineRun to have succeeded, got %+v", done.Status)
+	}
+}
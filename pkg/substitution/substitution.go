@@ -24,7 +24,12 @@ import (
 	"knative.dev/pkg/apis"
 )
 
-const parameterSubstitution = "[_a-zA-Z][_a-zA-Z0-9.-]*"
+// parameterSubstitution matches foo, foo.bar and foo[*], the whole of the
+// array foo.
+const parameterSubstitution = "[_a-zA-Z][_a-zA-Z0-9.-]*(?:\\[\\*\\])?"
+
+// starSuffix ends the references to a whole array.
+const starSuffix = "[*]"
 
 const braceMatchingRegex = "(\\$(\\(%s.(?P<var>%s)\\)))"
 
@@ -75,6 +80,48 @@ func ValidateVariableIsolated(name, value, prefix, contextPrefix, locationName,
 	return nil
 }
 
+// ValidateVariableStarred verifies that the variables referenced whole with
+// [*] are in arrayVars.
+func ValidateVariableStarred(name, value, prefix, contextPrefix, locationName, path string, arrayVars map[string]struct{}) *apis.FieldError {
+	for _, v := range extractFullVariablesFromString(value, contextPrefix+prefix) {
+		if !strings.HasSuffix(v, starSuffix) {
+			continue
+		}
+		if _, ok := arrayVars[strings.TrimSuffix(v, starSuffix)]; !ok {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("[*] used on a variable that isn't an array in %q for %s %s", value, locationName, name),
+				Paths:   []string{path + "." + name},
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateObjectKeys verifies that the variables of objectVars, by name, are
+// only referenced by one of their keys, such as $(params.foo.key).
+func ValidateObjectKeys(name, value, prefix, contextPrefix, locationName, path string, objectVars map[string]map[string]struct{}) *apis.FieldError {
+	for _, v := range extractFullVariablesFromString(value, contextPrefix+prefix) {
+		parts := strings.SplitN(v, ".", 2)
+		keys, ok := objectVars[parts[0]]
+		if !ok {
+			continue
+		}
+		if len(parts) == 1 {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("object variable must be referenced by key in %q for %s %s", value, locationName, name),
+				Paths:   []string{path + "." + name},
+			}
+		}
+		if _, ok := keys[parts[1]]; !ok {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("non-existent key in %q for %s %s", value, locationName, name),
+				Paths:   []string{path + "." + name},
+			}
+		}
+	}
+	return nil
+}
+
 // Extract a the first full string expressions found (e.g "$(input.params.foo)"). Return
 // "" and false if nothing is found.
 func extractExpressionFromString(s, prefix string) (string, bool) {
@@ -100,11 +147,24 @@ func extractVariablesFromString(s, prefix string) ([]string, bool) {
 		// foo -> foo
 		// foo.bar -> foo
 		// foo.bar.baz -> foo
-		vars[i] = strings.SplitN(groups["var"], ".", 2)[0]
+		// foo[*] -> foo
+		vars[i] = strings.SplitN(strings.TrimSuffix(groups["var"], starSuffix), ".", 2)[0]
 	}
 	return vars, true
 }
 
+// extractFullVariablesFromString returns the variables referenced in s, such
+// as foo.bar for $(<prefix>.foo.bar).
+func extractFullVariablesFromString(s, prefix string) []string {
+	pattern := fmt.Sprintf(braceMatchingRegex, prefix, parameterSubstitution)
+	re := regexp.MustCompile(pattern)
+	var vars []string
+	for _, match := range re.FindAllStringSubmatch(s, -1) {
+		vars = append(vars, matchGroups(match, re)["var"])
+	}
+	return vars
+}
+
 func matchGroups(matches []string, pattern *regexp.Regexp) map[string]string {
 	groups := make(map[string]string)
 	for i, name := range pattern.SubexpNames()[1:] {
@@ -77,6 +77,35 @@ func TestValidateVariables(t *testing.T) {
 			},
 		},
 		expectedError: nil,
+	}, {
+		name: "whole array variable",
+		args: args{
+			input:         "$(inputs.params.baz[*])",
+			prefix:        "params",
+			contextPrefix: "inputs.",
+			locationName:  "step",
+			path:          "taskspec.steps",
+			vars: map[string]struct{}{
+				"baz": {},
+			},
+		},
+		expectedError: nil,
+	}, {
+		name: "undefined whole array variable",
+		args: args{
+			input:         "$(inputs.params.baz[*])",
+			prefix:        "params",
+			contextPrefix: "inputs.",
+			locationName:  "step",
+			path:          "taskspec.steps",
+			vars: map[string]struct{}{
+				"foo": {},
+			},
+		},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(inputs.params.baz[*])" for step somefield`,
+			Paths:   []string{"taskspec.steps.somefield"},
+		},
 	}, {
 		name: "undefined variable",
 		args: args{
@@ -120,6 +149,72 @@ func TestValidateVariables(t *testing.T) {
 	}
 }
 
+func TestValidateVariableStarred(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		input         string
+		expectedError *apis.FieldError
+	}{{
+		name:  "array",
+		input: "$(params.array[*])",
+	}, {
+		name:  "string without star",
+		input: "--flag=$(params.string)",
+	}, {
+		name:  "string",
+		input: "$(params.string[*])",
+		expectedError: &apis.FieldError{
+			Message: `[*] used on a variable that isn't an array in "$(params.string[*])" for task parameter somefield`,
+			Paths:   []string{"pipelinespec.params.somefield"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := substitution.ValidateVariableStarred("somefield", tc.input, "params", "", "task parameter", "pipelinespec.params", map[string]struct{}{"array": {}})
+			if d := cmp.Diff(tc.expectedError, got, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ValidateVariableStarred() -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestValidateObjectKeys(t *testing.T) {
+	objects := map[string]map[string]struct{}{
+		"repo": {"url": {}, "branch": {}},
+	}
+	for _, tc := range []struct {
+		name          string
+		input         string
+		expectedError *apis.FieldError
+	}{{
+		name:  "keys",
+		input: "git clone -b $(inputs.params.repo.branch) $(inputs.params.repo.url)",
+	}, {
+		name:  "not an object",
+		input: "$(inputs.params.name)",
+	}, {
+		name:  "whole object",
+		input: "$(inputs.params.repo)",
+		expectedError: &apis.FieldError{
+			Message: `object variable must be referenced by key in "$(inputs.params.repo)" for step somefield`,
+			Paths:   []string{"taskspec.steps.somefield"},
+		},
+	}, {
+		name:  "undefined key",
+		input: "$(inputs.params.repo.revision)",
+		expectedError: &apis.FieldError{
+			Message: `non-existent key in "$(inputs.params.repo.revision)" for step somefield`,
+			Paths:   []string{"taskspec.steps.somefield"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := substitution.ValidateObjectKeys("somefield", tc.input, "params", "inputs.", "step", "taskspec.steps", objects)
+			if d := cmp.Diff(tc.expectedError, got, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ValidateObjectKeys() -want, +got: %s", d)
+			}
+		})
+	}
+}
+
 func TestApplyReplacements(t *testing.T) {
 	type args struct {
 		input        string
@@ -0,0 +1,183 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package termination
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+const (
+	// LegacyVersion is the version of termination messages written before
+	// the schema was versioned: a bare JSON array of results.
+	LegacyVersion = 0
+	// CurrentVersion is the version of the schema written by Encode.
+	CurrentVersion = 1
+
+	// MaxMessageSize is the largest termination message that is kept intact
+	// by every container runtime: the kubelet truncates each message to
+	// 4096 bytes, and some runtimes keep even less of the combined messages
+	// of a Pod.
+	MaxMessageSize = 4096
+	// LogPrefix starts the log line holding the complete termination
+	// message of a container whose results didn't fit in MaxMessageSize.
+	LogPrefix = "tekton-termination-message:"
+
+	// ReasonStepsStartTimeout is the Reason of the termination message of a
+	// step that timed out waiting for the Pod to signal it can start.
+	ReasonStepsStartTimeout = "StepsStartTimeout"
+	// ReasonStepTimeout is the Reason of the termination message of a step
+	// whose command was killed because it exceeded the timeout of the step.
+	ReasonStepTimeout = "StepTimeout"
+)
+
+// Message is the versioned schema of the termination message written by
+// Tekton's injected containers, e.g.
+//
+//	{"version":1,"results":[{"type":"PipelineResourceResult","key":"digest","value":"sha256:...","resourceRef":{"name":"image"}}]}
+//
+// Fields are only ever added to a version; any change that would break an
+// existing reader bumps CurrentVersion.
+type Message struct {
+	Version int                               `json:"version"`
+	Results []v1alpha1.PipelineResourceResult `json:"results"`
+	// Overflow is set when the results were too large for the termination
+	// message. They are then omitted, and the complete message is written to
+	// the container's log on a line starting with LogPrefix instead.
+	Overflow bool `json:"overflow,omitempty"`
+	// Reason is set when the container failed before running its command,
+	// e.g. to ReasonStepsStartTimeout, or when its command was killed, e.g.
+	// to ReasonStepTimeout.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Encode returns the termination message reporting results, in the
+// CurrentVersion of the schema.
+func Encode(results []v1alpha1.PipelineResourceResult) ([]byte, error) {
+	if results == nil {
+		results = []v1alpha1.PipelineResourceResult{}
+	}
+	return json.Marshal(Message{
+		Version: CurrentVersion,
+		Results: results,
+	})
+}
+
+// EncodeReason returns the termination message of a container that failed
+// before running its command, for reason.
+func EncodeReason(reason string) ([]byte, error) {
+	return json.Marshal(Message{
+		Version: CurrentVersion,
+		Results: []v1alpha1.PipelineResourceResult{},
+		Reason:  reason,
+	})
+}
+
+// encodeWithOverflow returns the termination message reporting results. If
+// it would be larger than MaxMessageSize it instead returns an overflow
+// message, along with the log line holding the complete message.
+func encodeWithOverflow(results []v1alpha1.PipelineResourceResult) ([]byte, string, error) {
+	b, err := Encode(results)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(b) <= MaxMessageSize {
+		return b, "", nil
+	}
+	overflow, err := json.Marshal(Message{
+		Version:  CurrentVersion,
+		Results:  []v1alpha1.PipelineResourceResult{},
+		Overflow: true,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return overflow, LogPrefix + string(b), nil
+}
+
+// Parse returns the results reported by a termination message. It accepts
+// messages of every version up to CurrentVersion, including unversioned
+// messages written by older releases.
+func Parse(msg string) ([]v1alpha1.PipelineResourceResult, error) {
+	m, err := decode(msg)
+	if err != nil {
+		return nil, err
+	}
+	if m.Overflow {
+		return nil, fmt.Errorf("termination message overflowed, its results are in the container's log")
+	}
+	return m.Results, nil
+}
+
+// Overflowed returns true if msg is a termination message whose results
+// didn't fit, see MessageFromLog.
+func Overflowed(msg string) bool {
+	m, err := decode(msg)
+	return err == nil && m.Overflow
+}
+
+// Reason returns the Reason of a termination message, or "" if it has none
+// or isn't a termination message.
+func Reason(msg string) string {
+	m, err := decode(msg)
+	if err != nil {
+		return ""
+	}
+	return m.Reason
+}
+
+// MessageFromLog returns the complete termination message written to the
+// log of a container whose termination message overflowed.
+func MessageFromLog(log string) (string, error) {
+	lines := strings.Split(log, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.HasPrefix(lines[i], LogPrefix) {
+			return strings.TrimPrefix(lines[i], LogPrefix), nil
+		}
+	}
+	return "", fmt.Errorf("no line starting with %q in the container's log", LogPrefix)
+}
+
+func decode(msg string) (*Message, error) {
+	b := bytes.TrimSpace([]byte(msg))
+	if len(b) > 0 && b[0] == '[' {
+		results := []v1alpha1.PipelineResourceResult{}
+		if err := json.Unmarshal(b, &results); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal legacy termination message: %w", err)
+		}
+		return &Message{Version: LegacyVersion, Results: results}, nil
+	}
+
+	var m Message
+	if err := json.Unmarshal(b, &m); err != nil {
+		if bytes.HasPrefix(b, []byte(`{"version":`)) {
+			return nil, fmt.Errorf("failed to unmarshal termination message of %d bytes, it was likely truncated by the container runtime: %w", len(b), err)
+		}
+		return nil, fmt.Errorf("failed to unmarshal termination message: %w", err)
+	}
+	if m.Version <= LegacyVersion || m.Version > CurrentVersion {
+		return nil, fmt.Errorf("unsupported termination message version %d, must be between %d and %d", m.Version, LegacyVersion+1, CurrentVersion)
+	}
+	if m.Results == nil {
+		m.Results = []v1alpha1.PipelineResourceResult{}
+	}
+	return &m, nil
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package termination
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+func TestEncode(t *testing.T) {
+	for _, c := range []struct {
+		desc    string
+		results []v1alpha1.PipelineResourceResult
+		want    string
+	}{{
+		desc: "no results",
+		want: `{"version":1,"results":[]}`,
+	}, {
+		desc: "resource result",
+		results: []v1alpha1.PipelineResourceResult{{
+			ResultType:  v1alpha1.PipelineResourceResultType,
+			Key:         "digest",
+			Value:       "sha256:1234",
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: "source-image"},
+		}},
+		want: `{"version":1,"results":[{"name":"","digest":"","key":"digest","value":"sha256:1234","resourceRef":{"name":"source-image"},"type":"PipelineResourceResult"}]}`,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := Encode(c.results)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			if d := cmp.Diff(c.want, string(got)); d != "" {
+				t.Errorf("Diff(-want, +got): %s", d)
+			}
+		})
+	}
+}
+
+// TestParse_Compatibility checks that messages written by every version of
+// the schema, including the unversioned one, can still be read.
+func TestParse_Compatibility(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		msg  string
+		want []v1alpha1.PipelineResourceResult
+	}{{
+		desc: "legacy name and digest",
+		msg:  `[{"name":"source-image","digest":"sha256:1234"}]`,
+		want: []v1alpha1.PipelineResourceResult{{
+			Name:   "source-image",
+			Digest: "sha256:1234",
+		}},
+	}, {
+		desc: "legacy key and value",
+		msg:  `[{"key":"commit","value":"abcd"}]`,
+		want: []v1alpha1.PipelineResourceResult{{
+			Key:   "commit",
+			Value: "abcd",
+		}},
+	}, {
+		desc: "legacy empty array",
+		msg:  `[]`,
+		want: []v1alpha1.PipelineResourceResult{},
+	}, {
+		desc: "version 1",
+		msg:  `{"version":1,"results":[{"type":"PipelineResourceResult","key":"digest","value":"sha256:1234","resourceRef":{"name":"source-image"}}]}`,
+		want: []v1alpha1.PipelineResourceResult{{
+			ResultType:  v1alpha1.PipelineResourceResultType,
+			Key:         "digest",
+			Value:       "sha256:1234",
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: "source-image"},
+		}},
+	}, {
+		desc: "version 1 with unknown fields",
+		msg:  `{"version":1,"extra":true,"results":[{"key":"commit","value":"abcd","extra":true}]}`,
+		want: []v1alpha1.PipelineResourceResult{{
+			Key:   "commit",
+			Value: "abcd",
+		}},
+	}, {
+		desc: "surrounding whitespace",
+		msg:  "\n {\"version\":1} \n",
+		want: []v1alpha1.PipelineResourceResult{},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := Parse(c.msg)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if d := cmp.Diff(c.want, got); d != "" {
+				t.Errorf("Diff(-want, +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		msg  string
+	}{{
+		desc: "empty",
+		msg:  "",
+	}, {
+		desc: "not json",
+		msg:  "extralogscamehere[{\"name\":\"source-image\"}]",
+	}, {
+		desc: "missing version",
+		msg:  `{"results":[]}`,
+	}, {
+		desc: "future version",
+		msg:  `{"version":2,"results":[]}`,
+	}, {
+		desc: "truncated",
+		msg:  `{"version":1,"results":[{"key":"dig`,
+	}, {
+		desc: "overflowed",
+		msg:  `{"version":1,"results":[],"overflow":true}`,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			if got, err := Parse(c.msg); err == nil {
+				t.Errorf("Parse(%q) = %v, expected error", c.msg, got)
+			}
+		})
+	}
+}
+
+func TestEncodeParse_RoundTrip(t *testing.T) {
+	results := []v1alpha1.PipelineResourceResult{{
+		Name:   "source-image",
+		Digest: "sha256:1234",
+	}, {
+		ResultType:  v1alpha1.PipelineResourceResultType,
+		Key:         "digest",
+		Value:       "sha256:1234",
+		ResourceRef: v1alpha1.PipelineResourceRef{Name: "source-image"},
+	}}
+	b, err := Encode(results)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := Parse(string(b))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if d := cmp.Diff(results, got); d != "" {
+		t.Errorf("Diff(-want, +got): %s", d)
+	}
+}
+
+func TestEncodeReason(t *testing.T) {
+	b, err := EncodeReason(ReasonStepsStartTimeout)
+	if err != nil {
+		t.Fatalf("EncodeReason: %v", err)
+	}
+	if d := cmp.Diff(`{"version":1,"results":[],"reason":"StepsStartTimeout"}`, string(b)); d != "" {
+		t.Errorf("Diff(-want, +got): %s", d)
+	}
+	if got := Reason(string(b)); got != ReasonStepsStartTimeout {
+		t.Errorf("Reason() = %q, want %q", got, ReasonStepsStartTimeout)
+	}
+	// The results of a message without a reason are still parsed.
+	if got, err := Parse(string(b)); err != nil || len(got) != 0 {
+		t.Errorf("Parse() = %v, %v, want no results", got, err)
+	}
+	for _, msg := range []string{"", "not json", `{"version":1,"results":[]}`} {
+		if got := Reason(msg); got != "" {
+			t.Errorf("Reason(%q) = %q, want none", msg, got)
+		}
+	}
+}
+
+func largeResults(n int) []v1alpha1.PipelineResourceResult {
+	var results []v1alpha1.PipelineResourceResult
+	for i := 0; i < n; i++ {
+		results = append(results, v1alpha1.PipelineResourceResult{
+			ResultType: v1alpha1.PipelineResourceResultType,
+			Key:        fmt.Sprintf("key-%d", i),
+			Value:      strings.Repeat("v", 100),
+		})
+	}
+	return results
+}
+
+func TestEncodeWithOverflow(t *testing.T) {
+	small := largeResults(1)
+	msg, logLine, err := encodeWithOverflow(small)
+	if err != nil {
+		t.Fatalf("encodeWithOverflow: %v", err)
+	}
+	if logLine != "" {
+		t.Errorf("expected no log line for a message that fits, got %q", logLine)
+	}
+	if Overflowed(string(msg)) {
+		t.Errorf("expected %s not to overflow", msg)
+	}
+
+	large := largeResults(100)
+	msg, logLine, err = encodeWithOverflow(large)
+	if err != nil {
+		t.Fatalf("encodeWithOverflow: %v", err)
+	}
+	if len(msg) > MaxMessageSize {
+		t.Errorf("expected the termination message to fit in %d bytes, got %d", MaxMessageSize, len(msg))
+	}
+	if !Overflowed(string(msg)) {
+		t.Errorf("expected %s to overflow", msg)
+	}
+
+	// The complete message is found in the log, among other log lines.
+	log := strings.Join([]string{"some output", logLine, "more output", ""}, "\n")
+	full, err := MessageFromLog(log)
+	if err != nil {
+		t.Fatalf("MessageFromLog: %v", err)
+	}
+	got, err := Parse(full)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if d := cmp.Diff(large, got); d != "" {
+		t.Errorf("Diff(-want, +got): %s", d)
+	}
+}
+
+func TestMessageFromLog_Missing(t *testing.T) {
+	if got, err := MessageFromLog("some output\nmore output\n"); err == nil {
+		t.Errorf("MessageFromLog() = %q, expected error", got)
+	}
+}
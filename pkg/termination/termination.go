@@ -16,7 +16,8 @@ limitations under the License.
 package termination
 
 import (
-	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 
@@ -24,11 +25,18 @@ import (
 	"go.uber.org/zap"
 )
 
+// WriteMessage writes pro to path as a termination message, see Message.
+// Results too large to be kept by the container runtime are written to
+// stdout instead, see MessageFromLog.
 func WriteMessage(logger *zap.SugaredLogger, path string, pro []v1alpha1.PipelineResourceResult) {
-	jsonOutput, err := json.Marshal(pro)
+	jsonOutput, logLine, err := encodeWithOverflow(pro)
 	if err != nil {
 		logger.Fatalf("Error marshaling json: %s", err)
 	}
+	if logLine != "" {
+		logger.Infof("Results are larger than %d bytes, writing them to the log", MaxMessageSize)
+		fmt.Println(logLine)
+	}
 	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0666)
 	if err != nil {
 		log.Fatalf("Unexpected error converting output to json %v: %v", pro, err)
@@ -43,3 +51,33 @@ func WriteMessage(logger *zap.SugaredLogger, path string, pro []v1alpha1.Pipelin
 		logger.Fatalf("Unexpected error converting output to json %v: %v", pro, err)
 	}
 }
+
+// WriteReason writes the termination message of a container that failed
+// before running its command to path, see EncodeReason.
+func WriteReason(path string, reason string) error {
+	b, err := EncodeReason(reason)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0666)
+}
+
+// AppendResults adds results to the termination message at path, keeping
+// the results already written to it, e.g. by the container's own command. A
+// message that can't be parsed is replaced. Results too large to be kept by
+// the container runtime are written to stdout instead, see MessageFromLog.
+func AppendResults(path string, results []v1alpha1.PipelineResourceResult) error {
+	if b, err := ioutil.ReadFile(path); err == nil && len(b) > 0 {
+		if existing, err := Parse(string(b)); err == nil {
+			results = append(existing, results...)
+		}
+	}
+	b, logLine, err := encodeWithOverflow(results)
+	if err != nil {
+		return err
+	}
+	if logLine != "" {
+		fmt.Println(logLine)
+	}
+	return ioutil.WriteFile(path, b, 0666)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package termination
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+func TestAppendResults(t *testing.T) {
+	taskResult := v1alpha1.PipelineResourceResult{
+		Key:        "commit",
+		Value:      "abc123",
+		ResultType: v1alpha1.TaskRunResultType,
+	}
+	resourceResult := v1alpha1.PipelineResourceResult{
+		Key:         "digest",
+		Value:       "sha256:1234",
+		ResourceRef: v1alpha1.PipelineResourceRef{Name: "image"},
+		ResultType:  v1alpha1.PipelineResourceResultType,
+	}
+	for _, c := range []struct {
+		desc     string
+		existing string
+		want     []v1alpha1.PipelineResourceResult
+	}{{
+		desc: "no message",
+		want: []v1alpha1.PipelineResourceResult{taskResult},
+	}, {
+		desc:     "message written by the command",
+		existing: `{"version":1,"results":[{"key":"digest","value":"sha256:1234","resourceRef":{"name":"image"},"type":"PipelineResourceResult","name":"","digest":""}]}`,
+		want:     []v1alpha1.PipelineResourceResult{resourceResult, taskResult},
+	}, {
+		desc:     "message that isn't a termination message",
+		existing: "the tests passed, and this message is longer than the results",
+		want:     []v1alpha1.PipelineResourceResult{taskResult},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "termination")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "termination-log")
+			if c.existing != "" {
+				if err := ioutil.WriteFile(path, []byte(c.existing), 0666); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := AppendResults(path, []v1alpha1.PipelineResourceResult{taskResult}); err != nil {
+				t.Fatalf("AppendResults: %v", err)
+			}
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Parse(string(b))
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", b, err)
+			}
+			if d := cmp.Diff(c.want, got); d != "" {
+				t.Errorf("results diff -want, +got: %v", d)
+			}
+		})
+	}
+}
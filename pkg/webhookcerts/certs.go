@@ -0,0 +1,251 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhookcerts serves the webhook with the certificate of a Secret
+// issued by an external CA, such as a cert-manager Certificate, rather than
+// with the self-signed certificate the webhook generates. The certificate is
+// reloaded, without restarting the webhook, each time the Secret is rotated.
+package webhookcerts
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/webhook"
+)
+
+// CAKey is the key of the certificate of the CA in the Secrets issued by
+// cert-manager, besides the corev1.TLSCertKey and corev1.TLSPrivateKeyKey
+// keys of kubernetes.io/tls Secrets.
+const CAKey = "ca.crt"
+
+// The keys of the Secret of the self-signed certificate generated by
+// knative.dev/pkg/webhook.
+const (
+	selfSignedServerKey  = "server-key.pem"
+	selfSignedServerCert = "server-cert.pem"
+	selfSignedCACert     = "ca-cert.pem"
+)
+
+// resyncPeriod is how often the Secret is reloaded even if it didn't change.
+const resyncPeriod = 10 * time.Minute
+
+// caRotationGracePeriod is how long the webhook stays registered with the
+// previous CA along with the new one after the CA rotates: every replica of
+// the webhook reloads the Secret, at the latest when it resyncs, and serves a
+// certificate of the new CA by then.
+var caRotationGracePeriod = 2 * resyncPeriod
+
+// Certs holds the certificate of the webhook, from the latest version of its
+// Secret that could be loaded.
+type Certs struct {
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	certPEM []byte
+	caCert  []byte
+}
+
+// Update loads the certificate of secret. It returns true if the certificate
+// of the CA changed, in which case the webhook must be registered again with
+// it. The current certificate is kept if secret can't be loaded.
+func (c *Certs) Update(secret *corev1.Secret) (bool, error) {
+	certPEM, key, caCert := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], secret.Data[CAKey]
+	if len(caCert) == 0 {
+		return false, fmt.Errorf("secret %s/%s has no %q", secret.Namespace, secret.Name, CAKey)
+	}
+	cert, err := tls.X509KeyPair(certPEM, key)
+	if err != nil {
+		return false, fmt.Errorf("secret %s/%s: %v", secret.Namespace, secret.Name, err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	caChanged := !bytes.Equal(c.caCert, caCert)
+	c.cert, c.certPEM, c.caCert = &cert, certPEM, caCert
+	return caChanged, nil
+}
+
+// GetCertificate returns the certificate to serve, for tls.Config.
+func (c *Certs) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.cert == nil {
+		return nil, errors.New("no certificate loaded")
+	}
+	return c.cert, nil
+}
+
+// CertPEM returns the PEM encoded certificate that is served.
+func (c *Certs) CertPEM() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.cert == nil {
+		return nil, errors.New("no certificate loaded")
+	}
+	return c.certPEM, nil
+}
+
+// CACert returns the PEM encoded certificate of the CA.
+func (c *Certs) CACert() []byte {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.caCert
+}
+
+// Watch loads the certificate of the Secret name in namespace, and reloads
+// it each time the Secret changes until stop is closed. register is called
+// with the certificate of the CA when it is first loaded, to register the
+// webhook with it. Each time it changes, register is called with a bundle of
+// the previous and the new certificates of the CA, so that the replicas still
+// serving a certificate of the previous CA keep being trusted, and then with
+// the new one alone once every replica reloaded the Secret. Watch returns once
+// the Secret was first loaded.
+func (c *Certs) Watch(kubeClient kubernetes.Interface, namespace, name string, register func(caCert []byte) error, logger *zap.SugaredLogger, stop <-chan struct{}) error {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if _, err := c.Update(secret); err != nil {
+		return err
+	}
+	if err := register(c.CACert()); err != nil {
+		return err
+	}
+
+	update := func(obj interface{}) {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			return
+		}
+		previousCACert := c.CACert()
+		caChanged, err := c.Update(secret)
+		if err != nil {
+			logger.Errorw("Failed to reload the webhook certificate, keeping the current one", zap.Error(err))
+			return
+		}
+		if !caChanged {
+			return
+		}
+		caCert := c.CACert()
+		logger.Info("The CA of the webhook certificate changed, registering the webhook with the previous and the new CA")
+		if err := register(caBundle(previousCACert, caCert)); err != nil {
+			logger.Errorw("Failed to register the webhook with the new CA", zap.Error(err))
+		}
+		go func() {
+			select {
+			case <-stop:
+				return
+			case <-time.After(caRotationGracePeriod):
+			}
+			// The CA may have rotated again since, and be registered
+			// along with this one.
+			if !bytes.Equal(c.CACert(), caCert) {
+				return
+			}
+			logger.Info("Registering the webhook with the new CA alone")
+			if err := register(caCert); err != nil {
+				logger.Errorw("Failed to register the webhook with the new CA", zap.Error(err))
+			}
+		}()
+	}
+	informer := coreinformers.NewFilteredSecretInformer(kubeClient, namespace, resyncPeriod, cache.Indexers{}, func(opts *metav1.ListOptions) {
+		opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	})
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    update,
+		UpdateFunc: func(_, obj interface{}) { update(obj) },
+	})
+	go informer.Run(stop)
+	return nil
+}
+
+// caBundle returns the PEM encoded certificates of previous and current.
+func caBundle(previous, current []byte) []byte {
+	bundle := append([]byte{}, bytes.TrimSpace(previous)...)
+	bundle = append(bundle, '\n')
+	return append(bundle, current...)
+}
+
+// SelfSigned loads the self-signed certificate of the webhook from the Secret
+// name in namespace, with the keys knative.dev/pkg/webhook uses, generating it
+// for the Service serviceName when the Secret doesn't exist yet. Unlike
+// webhook.Webhook.Run, it lets the webhook serve other handlers than its
+// admission controllers with the certificate.
+func (c *Certs) SelfSigned(ctx context.Context, kubeClient kubernetes.Interface, namespace, name, serviceName string) error {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		var serverKey, serverCert, caCert []byte
+		serverKey, serverCert, caCert, err = webhook.CreateCerts(ctx, serviceName, namespace)
+		if err != nil {
+			return err
+		}
+		secret, err = kubeClient.CoreV1().Secrets(namespace).Create(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data: map[string][]byte{
+				selfSignedServerKey:  serverKey,
+				selfSignedServerCert: serverCert,
+				selfSignedCACert:     caCert,
+			},
+		})
+		if apierrors.IsAlreadyExists(err) {
+			// Another replica of the webhook generated it first.
+			secret, err = kubeClient.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+		}
+	}
+	if err != nil {
+		return err
+	}
+	_, err = c.Update(&corev1.Secret{
+		ObjectMeta: secret.ObjectMeta,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       secret.Data[selfSignedServerCert],
+			corev1.TLSPrivateKeyKey: secret.Data[selfSignedServerKey],
+			CAKey:                   secret.Data[selfSignedCACert],
+		},
+	})
+	return err
+}
+
+// Serve serves handler over TLS on port with the certificate of c, until
+// stop is closed.
+func (c *Certs) Serve(port int, handler http.Handler, stop <-chan struct{}) error {
+	server := &http.Server{
+		Handler:   handler,
+		Addr:      fmt.Sprintf(":%d", port),
+		TLSConfig: &tls.Config{GetCertificate: c.GetCertificate},
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.ListenAndServeTLS("", "") }()
+	select {
+	case <-stop:
+		return server.Close()
+	case err := <-errCh:
+		return err
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookcerts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestUpdate(t *testing.T) {
+	var c Certs
+	if _, err := c.GetCertificate(nil); err == nil {
+		t.Error("GetCertificate() before Update() = nil error, want an error")
+	}
+
+	first := testSecret(t, 1, "ca-1")
+	if caChanged, err := c.Update(first); err != nil || !caChanged {
+		t.Fatalf("Update(first) = %t, %v, want true, nil", caChanged, err)
+	}
+	assertServes(t, &c, first)
+
+	// A rotated certificate with the same CA doesn't need a new registration.
+	rotated := testSecret(t, 2, "ca-1")
+	if caChanged, err := c.Update(rotated); err != nil || caChanged {
+		t.Fatalf("Update(rotated) = %t, %v, want false, nil", caChanged, err)
+	}
+	assertServes(t, &c, rotated)
+
+	newCA := testSecret(t, 3, "ca-2")
+	if caChanged, err := c.Update(newCA); err != nil || !caChanged {
+		t.Fatalf("Update(newCA) = %t, %v, want true, nil", caChanged, err)
+	}
+	assertServes(t, &c, newCA)
+
+	// The current certificate is kept when the Secret can't be loaded.
+	for _, invalid := range []*corev1.Secret{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tekton-pipelines", Name: "webhook-tls"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       newCA.Data[corev1.TLSCertKey],
+			corev1.TLSPrivateKeyKey: newCA.Data[corev1.TLSPrivateKeyKey],
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tekton-pipelines", Name: "webhook-tls"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       newCA.Data[corev1.TLSCertKey],
+			corev1.TLSPrivateKeyKey: first.Data[corev1.TLSPrivateKeyKey],
+			CAKey:                   []byte("ca-3"),
+		},
+	}} {
+		if _, err := c.Update(invalid); err == nil {
+			t.Errorf("Update(%v) = nil error, want an error", invalid.Data)
+		}
+		assertServes(t, &c, newCA)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	secret := testSecret(t, 1, "ca-1")
+	kubeClient := fakekubeclientset.NewSimpleClientset(secret)
+
+	var mu sync.Mutex
+	var registered []string
+	register := func(caCert []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		registered = append(registered, string(caCert))
+		return nil
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	defer func(d time.Duration) { caRotationGracePeriod = d }(caRotationGracePeriod)
+	caRotationGracePeriod = 100 * time.Millisecond
+
+	var c Certs
+	if err := c.Watch(kubeClient, secret.Namespace, secret.Name, register, logtesting.TestLogger(t), stop); err != nil {
+		t.Fatalf("Watch() = %v", err)
+	}
+	assertServes(t, &c, secret)
+
+	rotated := testSecret(t, 2, "ca-2")
+	if _, err := kubeClient.CoreV1().Secrets(rotated.Namespace).Update(rotated); err != nil {
+		t.Fatalf("Updating the Secret: %v", err)
+	}
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		got, err := c.CertPEM()
+		return err == nil && string(got) == string(rotated.Data[corev1.TLSCertKey]), nil
+	}); err != nil {
+		t.Fatalf("The rotated certificate wasn't reloaded: %v", err)
+	}
+
+	// The webhook is registered with both CAs until the grace period of the
+	// rotation elapsed, and then with the new one alone.
+	want := []string{"ca-1", "ca-1\nca-2", "ca-2"}
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(registered) == len(want), nil
+	}); err != nil {
+		t.Fatalf("The webhook wasn't registered with the new CA alone: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if d := cmp.Diff(want, registered); d != "" {
+		t.Errorf("Registered CAs diff -want, +got: %s", d)
+	}
+}
+
+func TestSelfSigned(t *testing.T) {
+	kubeClient := fakekubeclientset.NewSimpleClientset()
+	ctx := context.Background()
+
+	var c Certs
+	if err := c.SelfSigned(ctx, kubeClient, "tekton-pipelines", "webhook-certs", "tekton-pipelines-webhook"); err != nil {
+		t.Fatalf("SelfSigned() = %v", err)
+	}
+	secret, err := kubeClient.CoreV1().Secrets("tekton-pipelines").Get("webhook-certs", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("The self-signed certificate wasn't stored: %v", err)
+	}
+	certPEM, err := c.CertPEM()
+	if err != nil {
+		t.Fatalf("CertPEM() = %v", err)
+	}
+	if d := cmp.Diff(string(secret.Data[selfSignedServerCert]), string(certPEM)); d != "" {
+		t.Errorf("CertPEM() diff -want, +got: %s", d)
+	}
+	if d := cmp.Diff(string(secret.Data[selfSignedCACert]), string(c.CACert())); d != "" {
+		t.Errorf("CACert() diff -want, +got: %s", d)
+	}
+
+	// The certificate is reused by the next webhook.
+	var next Certs
+	if err := next.SelfSigned(ctx, kubeClient, "tekton-pipelines", "webhook-certs", "tekton-pipelines-webhook"); err != nil {
+		t.Fatalf("SelfSigned() = %v", err)
+	}
+	if d := cmp.Diff(string(c.CACert()), string(next.CACert())); d != "" {
+		t.Errorf("CACert() of the next webhook diff -want, +got: %s", d)
+	}
+}
+
+func assertServes(t *testing.T, c *Certs, secret *corev1.Secret) {
+	t.Helper()
+	cert, err := c.GetCertificate(nil)
+	if err != nil {
+		t.Fatalf("GetCertificate() = %v", err)
+	}
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if d := cmp.Diff(block.Bytes, cert.Certificate[0]); d != "" {
+		t.Errorf("GetCertificate() diff -want, +got: %s", d)
+	}
+	if d := cmp.Diff(string(secret.Data[CAKey]), string(c.CACert())); d != "" {
+		t.Errorf("CACert() diff -want, +got: %s", d)
+	}
+}
+
+// testSecret returns a Secret with a new certificate, of serial number
+// serial, issued by caCert.
+func testSecret(t *testing.T, serial int64, caCert string) *corev1.Secret {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey: %v", err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tekton-pipelines", Name: "webhook-tls"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+			CAKey:                   []byte(caCert),
+		},
+	}
+}
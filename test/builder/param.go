@@ -48,3 +48,21 @@ func ParamSpecDefault(value string, additionalValues ...string) ParamSpecOp {
 		ps.Default = arrayOrString
 	}
 }
+
+// ParamSpecProperties sets the properties of an object ParamSpec, all of
+// type string.
+func ParamSpecProperties(names ...string) ParamSpecOp {
+	return func(ps *v1alpha1.ParamSpec) {
+		ps.Properties = map[string]v1alpha1.PropertySpec{}
+		for _, name := range names {
+			ps.Properties[name] = v1alpha1.PropertySpec{Type: v1alpha1.ParamTypeString}
+		}
+	}
+}
+
+// ParamSpecEnum sets the values a string ParamSpec can take.
+func ParamSpecEnum(values ...string) ParamSpecOp {
+	return func(ps *v1alpha1.ParamSpec) {
+		ps.Enum = values
+	}
+}
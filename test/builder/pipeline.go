@@ -23,6 +23,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
 	"knative.dev/pkg/apis"
 )
 
@@ -102,6 +103,12 @@ func PipelineRunCancelled(spec *v1alpha1.PipelineRunSpec) {
 	spec.Status = v1alpha1.PipelineRunSpecStatusCancelled
 }
 
+// PipelineRunStoppedRunFinally sets the status to gracefully stop to the
+// PipelineRunSpec.
+func PipelineRunStoppedRunFinally(spec *v1alpha1.PipelineRunSpec) {
+	spec.Status = v1alpha1.PipelineRunSpecStatusStoppedRunFinally
+}
+
 // PipelineDeclaredResource adds a resource declaration to the Pipeline Spec,
 // with the specified name and type.
 func PipelineDeclaredResource(name string, t v1alpha1.PipelineResourceType) PipelineSpecOp {
@@ -126,6 +133,16 @@ func PipelineParamSpec(name string, pt v1alpha1.ParamType, ops ...ParamSpecOp) P
 	}
 }
 
+// PipelineWorkspaceDeclaration adds workspaces, with the specified names, to
+// the PipelineSpec.
+func PipelineWorkspaceDeclaration(names ...string) PipelineSpecOp {
+	return func(ps *v1alpha1.PipelineSpec) {
+		for _, name := range names {
+			ps.Workspaces = append(ps.Workspaces, v1alpha1.PipelineWorkspaceDeclaration{Name: name})
+		}
+	}
+}
+
 // PipelineTask adds a PipelineTask, with specified name and task name, to the PipelineSpec.
 // Any number of PipelineTask modifier can be passed to transform it.
 func PipelineTask(name, taskName string, ops ...PipelineTaskOp) PipelineSpecOp {
@@ -143,6 +160,23 @@ func PipelineTask(name, taskName string, ops ...PipelineTaskOp) PipelineSpecOp {
 	}
 }
 
+// FinallyTask adds a finally PipelineTask, with the specified ops, to the
+// PipelineSpec.
+func FinallyTask(name, taskName string, ops ...PipelineTaskOp) PipelineSpecOp {
+	return func(ps *v1alpha1.PipelineSpec) {
+		pTask := &v1alpha1.PipelineTask{
+			Name: name,
+			TaskRef: v1alpha1.TaskRef{
+				Name: taskName,
+			},
+		}
+		for _, op := range ops {
+			op(pTask)
+		}
+		ps.Finally = append(ps.Finally, *pTask)
+	}
+}
+
 func Retries(retries int) PipelineTaskOp {
 	return func(pt *v1alpha1.PipelineTask) {
 		pt.Retries = retries
@@ -164,6 +198,24 @@ func PipelineTaskRefKind(kind v1alpha1.TaskKind) PipelineTaskOp {
 	}
 }
 
+// PipelineTaskCustomTask sets the apiVersion and kind of the custom task the
+// PipelineTask references to its TaskRef.
+func PipelineTaskCustomTask(apiVersion string, kind v1alpha1.TaskKind) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.TaskRef.APIVersion = apiVersion
+		pt.TaskRef.Kind = kind
+	}
+}
+
+// PipelineTaskResolver sets the resolver fetching the Task of the PipelineTask,
+// and its params, to the TaskRef of the PipelineTask.
+func PipelineTaskResolver(resolver string, params ...v1alpha1.Param) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.TaskRef.Resolver = resolver
+		pt.TaskRef.Params = params
+	}
+}
+
 // PipelineTaskParam adds a ResourceParam, with specified name and value, to the PipelineTask.
 func PipelineTaskParam(name string, value string, additionalValues ...string) PipelineTaskOp {
 	arrayOrString := ArrayOrString(value, additionalValues...)
@@ -175,6 +227,38 @@ func PipelineTaskParam(name string, value string, additionalValues ...string) Pi
 	}
 }
 
+// PipelineTaskMatrix adds a matrix param, with the specified name and array of
+// values, to the PipelineTask.
+func PipelineTaskMatrix(name string, values ...string) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Matrix = append(pt.Matrix, v1alpha1.Param{
+			Name: name,
+			Value: v1alpha1.ArrayOrString{
+				Type:     v1alpha1.ParamTypeArray,
+				ArrayVal: values,
+			},
+		})
+	}
+}
+
+// PipelineTaskTimeout sets the timeout of the TaskRun of the PipelineTask.
+func PipelineTaskTimeout(duration time.Duration) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Timeout = &metav1.Duration{Duration: duration}
+	}
+}
+
+// PipelineTaskWorkspaceBinding passes the workspace of the Pipeline to the
+// workspace name of the Task of the PipelineTask.
+func PipelineTaskWorkspaceBinding(name, workspace string) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Workspaces = append(pt.Workspaces, v1alpha1.WorkspacePipelineTaskBinding{
+			Name:      name,
+			Workspace: workspace,
+		})
+	}
+}
+
 // From will update the provided PipelineTaskInputResource to indicate that it
 // should come from tasks.
 func From(tasks ...string) PipelineTaskInputResourceOp {
@@ -232,6 +316,17 @@ func PipelineTaskCondition(conditionRef string, ops ...PipelineTaskConditionOp)
 	}
 }
 
+// PipelineTaskWhenExpression adds a WhenExpression to the PipelineTask.
+func PipelineTaskWhenExpression(input string, operator selection.Operator, values ...string) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.WhenExpressions = append(pt.WhenExpressions, v1alpha1.WhenExpression{
+			Input:    input,
+			Operator: operator,
+			Values:   values,
+		})
+	}
+}
+
 // PipelineTaskConditionParam adds a parameter to a PipelineTaskCondition
 func PipelineTaskConditionParam(name, val string) PipelineTaskConditionOp {
 	return func(condition *v1alpha1.PipelineTaskCondition) {
@@ -362,6 +457,31 @@ func PipelineRunServiceAccountNameTask(taskName, sa string) PipelineRunSpecOp {
 	}
 }
 
+// PipelineRunTaskRunMetadata configures the metadata of the TaskRun of the
+// given Task in PipelineRun.
+func PipelineRunTaskRunMetadata(taskName string, metadata v1alpha1.PipelineTaskMetadata) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.TaskRunSpecs = append(prs.TaskRunSpecs, v1alpha1.PipelineTaskRunSpec{
+			PipelineTaskName: taskName,
+			Metadata:         metadata,
+		})
+	}
+}
+
+// PipelineRunTaskRunTemplate configures every TaskRun of the PipelineRun.
+func PipelineRunTaskRunTemplate(template v1alpha1.PipelineTaskRunTemplate) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.TaskRunTemplate = template
+	}
+}
+
+// PipelineRunTaskRunSpec configures the TaskRun of a Task in PipelineRun.
+func PipelineRunTaskRunSpec(spec v1alpha1.PipelineTaskRunSpec) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.TaskRunSpecs = append(prs.TaskRunSpecs, spec)
+	}
+}
+
 // PipelineRunParam add a param, with specified name and value, to the PipelineRunSpec.
 func PipelineRunParam(name string, value string, additionalValues ...string) PipelineRunSpecOp {
 	arrayOrString := ArrayOrString(value, additionalValues...)
@@ -373,6 +493,29 @@ func PipelineRunParam(name string, value string, additionalValues ...string) Pip
 	}
 }
 
+// PipelineRunWorkspaceBindingEmptyDir binds the workspace name of the
+// Pipeline to an emptyDir.
+func PipelineRunWorkspaceBindingEmptyDir(name string) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.Workspaces = append(prs.Workspaces, v1alpha1.WorkspaceBinding{
+			Name:     name,
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		})
+	}
+}
+
+// PipelineRunWorkspaceBindingClaim binds the workspace name of the Pipeline
+// to the directory subPath of the PersistentVolumeClaim claimName.
+func PipelineRunWorkspaceBindingClaim(name, claimName, subPath string) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.Workspaces = append(prs.Workspaces, v1alpha1.WorkspaceBinding{
+			Name:                  name,
+			SubPath:               subPath,
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+		})
+	}
+}
+
 // PipelineRunTimeout sets the timeout to the PipelineRunSpec.
 func PipelineRunTimeout(duration time.Duration) PipelineRunSpecOp {
 	return func(prs *v1alpha1.PipelineRunSpec) {
@@ -380,6 +523,17 @@ func PipelineRunTimeout(duration time.Duration) PipelineRunSpecOp {
 	}
 }
 
+// PipelineRunTimeouts sets the timeouts of the tasks and of the finally tasks
+// to the PipelineRunSpec.
+func PipelineRunTimeouts(tasks, finally time.Duration) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.Timeouts = &v1alpha1.PipelineRunTimeouts{
+			Tasks:   &metav1.Duration{Duration: tasks},
+			Finally: &metav1.Duration{Duration: finally},
+		}
+	}
+}
+
 // PipelineRunNilTimeout sets the timeout to nil on the PipelineRunSpec
 func PipelineRunNilTimeout(prs *v1alpha1.PipelineRunSpec) {
 	prs.Timeout = nil
@@ -432,6 +586,14 @@ func PipelineRunStartTime(startTime time.Time) PipelineRunStatusOp {
 	}
 }
 
+// PipelineRunFinallyStartTime sets the start time of the finally tasks to the
+// PipelineRunStatus.
+func PipelineRunFinallyStartTime(t time.Time) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.FinallyStartTime = &metav1.Time{Time: t}
+	}
+}
+
 // PipelineRunCompletionTime sets the completion time  to the PipelineRunStatus.
 func PipelineRunCompletionTime(t time.Time) PipelineRunStatusOp {
 	return func(s *v1alpha1.PipelineRunStatus) {
@@ -449,6 +611,17 @@ func PipelineRunTaskRunsStatus(taskRunName string, status *v1alpha1.PipelineRunT
 	}
 }
 
+// PipelineRunRunsStatus sets the status of the Run runName of a custom task
+// to the PipelineRunStatus.
+func PipelineRunRunsStatus(runName string, status *v1alpha1.PipelineRunRunStatus) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		if s.Runs == nil {
+			s.Runs = make(map[string]*v1alpha1.PipelineRunRunStatus)
+		}
+		s.Runs[runName] = status
+	}
+}
+
 // PipelineResource creates a PipelineResource with default values.
 // Any number of PipelineResource modifier can be passed to transform it.
 func PipelineResource(name, namespace string, ops ...PipelineResourceOp) *v1alpha1.PipelineResource {
@@ -497,3 +670,27 @@ func PipelineResourceSpecSecretParam(fieldname, secretName, secretKey string) Pi
 		})
 	}
 }
+
+// PipelineChangeDetection sets the PipelineTask, and its result, which detect
+// whether there are changes for the other PipelineTasks.
+func PipelineChangeDetection(task, result string) PipelineSpecOp {
+	return func(ps *v1alpha1.PipelineSpec) {
+		ps.ChangeDetection = &v1alpha1.PipelineChangeDetection{Task: task, Result: result}
+	}
+}
+
+// PipelineExpectedDuration sets how long the PipelineRuns of the Pipeline are
+// expected to run for.
+func PipelineExpectedDuration(d time.Duration) PipelineSpecOp {
+	return func(ps *v1alpha1.PipelineSpec) {
+		ps.ExpectedDuration = &metav1.Duration{Duration: d}
+	}
+}
+
+// PipelineParamsHook sets the URL of the params hook computing the params of
+// the PipelineRuns of the Pipeline.
+func PipelineParamsHook(url string) PipelineSpecOp {
+	return func(ps *v1alpha1.PipelineSpec) {
+		ps.ParamsHook = &v1alpha1.PipelineParamsHook{URL: url}
+	}
+}
@@ -178,6 +178,35 @@ func TaskStepTemplate(ops ...ContainerOp) TaskSpecOp {
 	}
 }
 
+// TaskWorkspace adds a workspace declaration to the TaskSpec.
+func TaskWorkspace(name, description, mountPath string, readOnly bool) TaskSpecOp {
+	return func(spec *v1alpha1.TaskSpec) {
+		spec.Workspaces = append(spec.Workspaces, v1alpha1.WorkspaceDeclaration{
+			Name:        name,
+			Description: description,
+			MountPath:   mountPath,
+			ReadOnly:    readOnly,
+		})
+	}
+}
+
+// TaskPlatforms sets the platforms the Pod of the TaskSpec can run on.
+func TaskPlatforms(platforms ...string) TaskSpecOp {
+	return func(spec *v1alpha1.TaskSpec) {
+		spec.Platforms = platforms
+	}
+}
+
+// TaskResult adds a result with the specified name to the TaskSpec.
+func TaskResult(name, description string) TaskSpecOp {
+	return func(spec *v1alpha1.TaskSpec) {
+		spec.Results = append(spec.Results, v1alpha1.TaskResult{
+			Name:        name,
+			Description: description,
+		})
+	}
+}
+
 // TaskVolume adds a volume with specified name to the TaskSpec.
 // Any number of Volume modifier can be passed to transform it.
 func TaskVolume(name string, ops ...VolumeOp) TaskSpecOp {
@@ -311,6 +340,16 @@ func StatusCondition(condition apis.Condition) TaskRunStatusOp {
 	}
 }
 
+// TaskRunResult adds a result the steps reported to the TaskRunStatus.
+func TaskRunResult(name, value string) TaskRunStatusOp {
+	return func(s *v1alpha1.TaskRunStatus) {
+		s.TaskRunResults = append(s.TaskRunResults, v1alpha1.TaskRunResult{
+			Name:  name,
+			Value: value,
+		})
+	}
+}
+
 func Retry(retry v1alpha1.TaskRunStatus) TaskRunStatusOp {
 	return func(s *v1alpha1.TaskRunStatus) {
 		s.RetriesStatus = append(s.RetriesStatus, retry)
@@ -529,6 +568,15 @@ func TaskRefKind(kind v1alpha1.TaskKind) TaskRefOp {
 	}
 }
 
+// TaskRefResolver sets the resolver fetching the Task of the TaskRef, and its
+// params, to the TaskRef.
+func TaskRefResolver(resolver string, params ...v1alpha1.Param) TaskRefOp {
+	return func(ref *v1alpha1.TaskRef) {
+		ref.Resolver = resolver
+		ref.Params = params
+	}
+}
+
 // TaskRefAPIVersion sets the specified api version to the TaskRef.
 func TaskRefAPIVersion(version string) TaskRefOp {
 	return func(ref *v1alpha1.TaskRef) {
@@ -548,6 +596,30 @@ func TaskRunTaskSpec(ops ...TaskSpecOp) TaskRunSpecOp {
 	}
 }
 
+// TaskRunWorkspaceEmptyDir binds the workspace name of the Task to an
+// emptyDir.
+func TaskRunWorkspaceEmptyDir(name, subPath string) TaskRunSpecOp {
+	return func(spec *v1alpha1.TaskRunSpec) {
+		spec.Workspaces = append(spec.Workspaces, v1alpha1.WorkspaceBinding{
+			Name:     name,
+			SubPath:  subPath,
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		})
+	}
+}
+
+// TaskRunWorkspacePVC binds the workspace name of the Task to the
+// PersistentVolumeClaim claimName.
+func TaskRunWorkspacePVC(name, subPath, claimName string) TaskRunSpecOp {
+	return func(spec *v1alpha1.TaskRunSpec) {
+		spec.Workspaces = append(spec.Workspaces, v1alpha1.WorkspaceBinding{
+			Name:                  name,
+			SubPath:               subPath,
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+		})
+	}
+}
+
 // TaskRunServiceAccount sets the serviceAccount to the TaskRunSpec.
 func TaskRunServiceAccountName(sa string) TaskRunSpecOp {
 	return func(trs *v1alpha1.TaskRunSpec) {
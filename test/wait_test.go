@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/reconcilertest"
 	tb "github.com/tektoncd/pipeline/test/builder"
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
@@ -38,7 +39,7 @@ var (
 )
 
 func TestWaitForTaskRunStateSucceed(t *testing.T) {
-	d := Data{
+	d := reconcilertest.Data{
 		TaskRuns: []*v1alpha1.TaskRun{
 			tb.TaskRun("foo", waitNamespace, tb.TaskRunStatus(
 				tb.StatusCondition(success),
@@ -53,7 +54,7 @@ func TestWaitForTaskRunStateSucceed(t *testing.T) {
 	}
 }
 func TestWaitForTaskRunStateFailed(t *testing.T) {
-	d := Data{
+	d := reconcilertest.Data{
 		TaskRuns: []*v1alpha1.TaskRun{
 			tb.TaskRun("foo", waitNamespace, tb.TaskRunStatus(
 				tb.StatusCondition(failure),
@@ -69,7 +70,7 @@ func TestWaitForTaskRunStateFailed(t *testing.T) {
 }
 
 func TestWaitForPipelineRunStateSucceed(t *testing.T) {
-	d := Data{
+	d := reconcilertest.Data{
 		PipelineRuns: []*v1alpha1.PipelineRun{
 			tb.PipelineRun("bar", waitNamespace, tb.PipelineRunStatus(
 				tb.PipelineRunStatusCondition(success),
@@ -85,7 +86,7 @@ func TestWaitForPipelineRunStateSucceed(t *testing.T) {
 }
 
 func TestWaitForPipelineRunStateFailed(t *testing.T) {
-	d := Data{
+	d := reconcilertest.Data{
 		PipelineRuns: []*v1alpha1.PipelineRun{
 			tb.PipelineRun("bar", waitNamespace, tb.PipelineRunStatus(
 				tb.PipelineRunStatusCondition(failure),
@@ -100,10 +101,10 @@ func TestWaitForPipelineRunStateFailed(t *testing.T) {
 	}
 }
 
-func fakeClients(t *testing.T, d Data) (*clients, func()) {
+func fakeClients(t *testing.T, d reconcilertest.Data) (*clients, func()) {
 	ctx, _ := rtesting.SetupFakeContext(t)
 	ctx, cancel := context.WithCancel(ctx)
-	fakeClients, _ := SeedTestData(t, ctx, d)
+	fakeClients, _ := reconcilertest.SeedTestData(t, ctx, d)
 	// 	c.KubeClient = fakeClients.Kube
 	return &clients{
 		PipelineClient:         fakeClients.Pipeline.TektonV1alpha1().Pipelines(waitNamespace),